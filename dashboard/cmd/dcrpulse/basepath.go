@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// basePathRe is the form a BASE_PATH takes once normalized: slash-separated
+// segments of URL-safe characters, with a leading slash and no trailing one.
+var basePathRe = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+$`)
+
+// normalizeBasePath turns the BASE_PATH setting into "/a/b" form, or "" for
+// serving at the root. Surrounding slashes are optional; anything that would
+// need escaping in a URL is refused.
+func normalizeBasePath(s string) (string, error) {
+	s = strings.Trim(strings.TrimSpace(s), "/")
+	if s == "" {
+		return "", nil
+	}
+	p := "/" + s
+	if !basePathRe.MatchString(p) || path.Clean(p) != p {
+		return "", fmt.Errorf("invalid BASE_PATH %q", s)
+	}
+	return p, nil
+}
+
+// mountAtBasePath serves h under base, for a reverse proxy that forwards a
+// subpath without stripping it: base itself redirects to base+"/", requests
+// below it reach h with base removed, and anything else is not found. The
+// router and the frontend's file server so keep seeing root paths.
+func mountAtBasePath(base string, h http.Handler) http.Handler {
+	stripped := http.StripPrefix(base, h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == base:
+			target := base + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+		case strings.HasPrefix(r.URL.Path, base+"/"):
+			stripped.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"dcrpulse/internal/middleware"
+)
+
+//go:embed web/dist
+var embeddedFiles embed.FS
+
+// inlineScriptRe matches bare inline <script> blocks in index.html. The app
+// bundle is loaded via <script type="module" src=...> and is not matched.
+var inlineScriptRe = regexp.MustCompile(`(?s)<script>(.*?)</script>`)
+
+// loadFrontend picks where the frontend is served from: nothing when
+// FRONTEND_DISABLED is set (dcrpulse runs as a pure API), the FRONTEND_DIR
+// directory when given (e.g. a `vite build --watch` output during
+// development), otherwise the embedded build. It also returns a description
+// for the startup log.
+func loadFrontend() (fs.FS, string) {
+	switch strings.ToLower(getEnv("FRONTEND_DISABLED", "")) {
+	case "1", "true", "yes":
+		return nil, "Disabled (FRONTEND_DISABLED); serving the API only"
+	}
+
+	if dir := getEnv("FRONTEND_DIR", ""); dir != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			log.Printf("Warning: FRONTEND_DIR %q is not a readable directory: %v", dir, err)
+			return nil, "Not available (FRONTEND_DIR unreadable)"
+		}
+		return os.DirFS(dir), fmt.Sprintf("Static files served at / from %s", dir)
+	}
+
+	distFS, err := fs.Sub(embeddedFiles, "web/dist")
+	if err != nil {
+		log.Printf("Warning: Could not load embedded frontend files: %v", err)
+		log.Println("Frontend will not be available. This is expected in development mode.")
+		return nil, "Not available"
+	}
+	return distFS, "Embedded static files served at /"
+}
+
+// baseHrefRe matches index.html's <base href>, which points relative asset
+// URLs at the path the frontend is served from.
+var baseHrefRe = regexp.MustCompile(`<base\s+href="[^"]*"\s*/?>`)
+
+// serveIndex writes index.html with its <base href> set to basePath, adding
+// one to <head> if the build has none. It is read on every request so a
+// FRONTEND_DIR rebuild is picked up.
+func serveIndex(w http.ResponseWriter, req *http.Request, distFS fs.FS, basePath string) {
+	html, err := fs.ReadFile(distFS, "index.html")
+	if err != nil {
+		http.NotFound(w, req)
+		return
+	}
+	base := []byte(`<base href="` + basePath + `/" />`)
+	if baseHrefRe.Match(html) {
+		html = baseHrefRe.ReplaceAllLiteral(html, base)
+	} else {
+		html = bytes.Replace(html, []byte("<head>"), append([]byte("<head>\n    "), base...), 1)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(html)
+}
+
+// serveFrontend serves distFS at / with SPA fallback to index.html. basePath
+// is the subpath a reverse proxy serves dcrpulse under ("" at the root); the
+// router already sees paths with it removed, but the browser resolves asset
+// URLs against it.
+func serveFrontend(r *mux.Router, distFS fs.FS, basePath string) {
+	// Allow the inline scripts shipped in index.html (the pre-mount theme
+	// loader) under the strict script-src 'self' CSP by hashing them at
+	// startup. Recomputing from the served HTML means edits to the inline
+	// script never require updating the CSP by hand; with FRONTEND_DIR a
+	// changed inline script needs a restart.
+	if html, rerr := fs.ReadFile(distFS, "index.html"); rerr == nil {
+		var hashes []string
+		for _, m := range inlineScriptRe.FindAllSubmatch(html, -1) {
+			hashes = append(hashes, middleware.InlineScriptHash(m[1]))
+		}
+		middleware.ConfigureInlineScriptHashes(hashes...)
+	} else {
+		log.Printf("Warning: Could not hash inline frontend scripts for CSP: %v", rerr)
+	}
+
+	fileServer := http.FileServer(http.FS(distFS))
+	r.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path := req.URL.Path
+
+		// Skip API routes
+		if strings.HasPrefix(path, "/api") {
+			http.NotFound(w, req)
+			return
+		}
+
+		// Try to serve the requested file
+		if path != "/" && path != "/index.html" {
+			filePath := strings.TrimPrefix(path, "/")
+			if f, err := distFS.Open(filePath); err == nil {
+				f.Close()
+				// The dcrtime file-hashing Web Worker is the only place
+				// WebAssembly runs; grant it (and nothing else) the
+				// wasm-unsafe-eval CSP token. The strict document CSP set by
+				// SecurityHeaders is left untouched for every other asset.
+				if strings.Contains(filePath, "dcrtime-hash-worker") {
+					w.Header().Set("Content-Security-Policy",
+						"default-src 'none'; script-src 'self' 'wasm-unsafe-eval'")
+				}
+				fileServer.ServeHTTP(w, req)
+				return
+			}
+		}
+
+		// Fallback to index.html for SPA routing
+		serveIndex(w, req, distFS, basePath)
+	})
+}
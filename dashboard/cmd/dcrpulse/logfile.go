@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultLogMaxSizeMB = 10
+	defaultLogMaxFiles  = 5
+)
+
+// setupLogging points the standard logger at LOG_FILE when set, rotating it
+// once it reaches LOG_MAX_SIZE_MB and keeping LOG_MAX_FILES old files
+// (LOG_FILE.1 newest). Console output stays on unless LOG_CONSOLE is false.
+// The returned func closes the file and must be called on exit.
+func setupLogging() func() {
+	path := getEnv("LOG_FILE", "")
+	if path == "" {
+		return func() {}
+	}
+
+	maxSizeMB := envInt("LOG_MAX_SIZE_MB", defaultLogMaxSizeMB)
+	maxFiles := envInt("LOG_MAX_FILES", defaultLogMaxFiles)
+	rf, err := openRotatingFile(path, int64(maxSizeMB)<<20, maxFiles)
+	if err != nil {
+		log.Printf("Warning: LOG_FILE unavailable, logging to console only: %v", err)
+		return func() {}
+	}
+
+	switch strings.ToLower(getEnv("LOG_CONSOLE", "")) {
+	case "0", "false", "no":
+		log.SetOutput(rf)
+	default:
+		log.SetOutput(io.MultiWriter(os.Stderr, rf))
+	}
+	log.Printf("Logging to %s (rotate at %d MB, keep %d files)", path, maxSizeMB, maxFiles)
+	return func() {
+		log.SetOutput(os.Stderr)
+		rf.Close()
+	}
+}
+
+// envInt reads a positive integer environment variable, falling back to def
+// when it is unset or invalid.
+func envInt(key string, def int) int {
+	v := getEnv(key, "")
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Printf("Warning: invalid %s %q, using %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// rotatingFile is an append-only log file that is renamed to path.1 (shifting
+// older files up to path.<maxFiles>) once a write would take it past maxSize.
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	rf := &rotatingFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.f, rf.size = f, info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if it would overflow a non-empty file. A
+// failed rotation keeps writing to the current file rather than losing logs.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "log rotation failed: %v\n", err)
+		}
+	}
+	if rf.f == nil {
+		if err := rf.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, shifts the old ones up and opens a fresh
+// one. On failure rf.f is left nil, so the next Write reopens path and keeps
+// appending to it.
+func (rf *rotatingFile) rotate() error {
+	// The file is unusable after Close even when Close reports an error.
+	err := rf.f.Close()
+	rf.f = nil
+	if err != nil {
+		return err
+	}
+
+	os.Remove(fmt.Sprintf("%s.%d", rf.path, rf.maxFiles))
+	for i := rf.maxFiles - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", rf.path, i)
+		if _, err := os.Stat(from); err == nil {
+			os.Rename(from, fmt.Sprintf("%s.%d", rf.path, i+1))
+		}
+	}
+	if err := os.Rename(rf.path, rf.path+".1"); err != nil {
+		return err
+	}
+	return rf.open()
+}
+
+// Close closes the current file.
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.f == nil {
+		return nil
+	}
+	err := rf.f.Close()
+	rf.f = nil
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "<missing>"
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func writeLog(t *testing.T, rf *rotatingFile, s string) {
+	t.Helper()
+	if _, err := rf.Write([]byte(s)); err != nil {
+		t.Fatalf("Write(%q): %v", s, err)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "dcrpulse.log")
+	rf, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	// Filling the file exactly to maxSize does not rotate.
+	writeLog(t, rf, "12345")
+	writeLog(t, rf, "67890")
+	if got := readLog(t, path+".1"); got != "<missing>" {
+		t.Fatalf("rotated at maxSize: %s.1 = %q", path, got)
+	}
+
+	// The next byte would pass it, so the file rotates first.
+	writeLog(t, rf, "a")
+	if got := readLog(t, path+".1"); got != "1234567890" {
+		t.Errorf("%s.1 = %q, want the first file", path, got)
+	}
+	if got := readLog(t, path); got != "a" {
+		t.Errorf("current file = %q, want %q", got, "a")
+	}
+
+	// Older files shift up and the one past maxFiles is dropped.
+	writeLog(t, rf, "bcdefghijk")
+	writeLog(t, rf, "l")
+	for suffix, want := range map[string]string{"": "l", ".1": "bcdefghijk", ".2": "a", ".3": "<missing>"} {
+		if got := readLog(t, path+suffix); got != want {
+			t.Errorf("%s%s = %q, want %q", path, suffix, got, want)
+		}
+	}
+
+	// A write larger than maxSize into an empty file is kept whole.
+	rf2, err := openRotatingFile(filepath.Join(filepath.Dir(path), "big.log"), 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf2.Close()
+	writeLog(t, rf2, "0123456789")
+	if got := readLog(t, rf2.path+".1"); got != "<missing>" {
+		t.Errorf("empty file rotated before an oversized write: %q", got)
+	}
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dcrpulse.log")
+	if err := os.WriteFile(path, []byte("12345678"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rf, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	// The existing size counts toward the threshold.
+	writeLog(t, rf, "abc")
+	if got := readLog(t, path+".1"); got != "12345678" {
+		t.Errorf("%s.1 = %q, want the pre-existing file", path, got)
+	}
+}
+
+func TestRotatingFileCloseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dcrpulse.log")
+	rf, err := openRotatingFile(path, 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+	writeLog(t, rf, "1234")
+
+	// Closing the file underneath makes rotate's Close fail; logging must
+	// carry on in a reopened file instead of writing to the closed one.
+	rf.f.Close()
+	writeLog(t, rf, "5")
+	if got := readLog(t, path); got != "12345" {
+		t.Errorf("current file = %q, want %q", got, "12345")
+	}
+}
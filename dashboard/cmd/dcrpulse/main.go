@@ -6,16 +6,18 @@ package main
 
 import (
 	"context"
-	"embed"
+	"errors"
 	"fmt"
-	"io/fs"
 	"log"
 	"net/http"
 	"os"
-	"regexp"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/decred/dcrd/wire"
 	"github.com/gorilla/mux"
 
 	"dcrpulse/internal/auth"
@@ -27,14 +29,16 @@ import (
 	"dcrpulse/internal/timestamp"
 )
 
-//go:embed web/dist
-var embeddedFiles embed.FS
+func main() {
+	closeLog := setupLogging()
+	defer closeLog()
 
-// inlineScriptRe matches bare inline <script> blocks in index.html. The app
-// bundle is loaded via <script type="module" src=...> and is not matched.
-var inlineScriptRe = regexp.MustCompile(`(?s)<script>(.*?)</script>`)
+	// Root context for the server's lifetime: cancelled on SIGINT/SIGTERM so
+	// background jobs stop and the HTTP server drains before exit.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	services.SetRootContext(ctx)
 
-func main() {
 	// Load dcrd configuration from environment variables
 	dcrdConfig := rpc.Config{
 		RPCHost:     getEnv("DCRD_RPC_HOST", "localhost"),
@@ -44,18 +48,127 @@ func main() {
 		RPCCert:     getEnv("DCRD_RPC_CERT", ""),
 	}
 
+	// Cap simultaneous dcrd calls so scans and page loads queue here rather
+	// than exhausting dcrd's rpcmaxconcurrentreqs.
+	rpc.SetDcrdConcurrency(envInt("DCRD_RPC_MAX_CONCURRENT", rpc.DefaultDcrdConcurrency))
+
+	// Treasury activation height for networks where dcrd can't report it and
+	// the chain parameters don't determine it; 0 detects it.
+	services.SetTreasuryActivationHeight(int64(envInt("TREASURY_ACTIVATION_HEIGHT", 0)))
+
+	// Subpath the dashboard is served under behind a reverse proxy; empty
+	// serves it at the root.
+	basePath, err := normalizeBasePath(getEnv("BASE_PATH", ""))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Largest JSON request body the API accepts on POST/PUT/PATCH/DELETE.
+	maxBodyBytes := int64(envInt("API_MAX_BODY_BYTES", middleware.DefaultMaxBodyBytes))
+	// Treasury scan snapshots are imported whole, so their route takes more.
+	snapshotLimit := middleware.BodyLimit{
+		Path:     "/api/treasury/snapshot/import",
+		MaxBytes: int64(envInt("TREASURY_SNAPSHOT_MAX_BYTES", handlers.DefaultScanSnapshotMaxBytes)),
+	}
+
+	// Browser WebSocket keep-alive: ping interval, how long without a pong
+	// before the connection is dropped, and the per-write deadline.
+	handlers.SetWSKeepAlive(
+		time.Duration(envInt("WS_PING_INTERVAL_SECONDS", int(handlers.DefaultWSPingInterval/time.Second)))*time.Second,
+		time.Duration(envInt("WS_PONG_TIMEOUT_SECONDS", int(handlers.DefaultWSPongTimeout/time.Second)))*time.Second,
+		time.Duration(envInt("WS_WRITE_TIMEOUT_SECONDS", int(handlers.DefaultWSWriteTimeout/time.Second)))*time.Second,
+	)
+	handlers.SetWSMaxConnections(envInt("WS_MAX_CONNECTIONS", handlers.DefaultWSMaxConnections))
+
+	// Wallet sync streams re-read the wallet's and dcrd's heights after this
+	// long without a sync notification.
+	handlers.SetSyncPollInterval(
+		time.Duration(envInt("SYNC_POLL_INTERVAL_SECONDS", int(handlers.DefaultSyncPollInterval/time.Second))) * time.Second)
+
+	// Explorer requests are bounded per route unless one limit is set here.
+	handlers.SetExplorerTimeout(time.Duration(envInt("EXPLORER_TIMEOUT_SECONDS", 0)) * time.Second)
+
+	// Treasury scan logging: per-block debug detail, and how often the
+	// historical scan logs its position and the vote count its progress.
+	var scanDebug bool
+	switch strings.ToLower(getEnv("TREASURY_SCAN_DEBUG", "")) {
+	case "1", "true", "yes":
+		scanDebug = true
+	}
+	services.ConfigureScanLogging(scanDebug,
+		envInt("TREASURY_SCAN_LOG_INTERVAL", services.DefaultScanLogInterval),
+		envInt("VOTE_PROGRESS_INTERVAL", services.DefaultVoteProgressInterval),
+	)
+
+	// Vote count block fetching: concurrent workers and blocks per batch.
+	services.ConfigureVoteScanner(
+		envInt("VOTE_SCAN_WORKERS", services.DefaultVoteScanWorkers),
+		envInt("VOTE_SCAN_BATCH", services.DefaultVoteScanBatch),
+	)
+
+	// Refuse historical treasury scans while dcrd is still syncing.
+	switch strings.ToLower(getEnv("TREASURY_SCAN_REQUIRE_SYNCED", "")) {
+	case "1", "true", "yes":
+		services.ConfigureScanRequiresSync(true)
+	}
+
+	// Extend the treasury scan results to each new block as it connects.
+	switch strings.ToLower(getEnv("TREASURY_AUTO_SCAN", "")) {
+	case "1", "true", "yes":
+		services.ConfigureTreasuryAutoScan(true)
+	}
+
+	// How many tspends' vote counts stay cached, and how long a finished
+	// count's progress stays readable.
+	services.ConfigureVoteCache(
+		envInt("VOTE_CACHE_MAX_ENTRIES", services.DefaultVoteCacheEntries),
+		time.Duration(envInt("VOTE_PROGRESS_TTL_MINUTES", int(services.DefaultVoteProgressTTL/time.Minute)))*time.Minute,
+	)
+
+	// Cached vote counts unused for VOTE_RETENTION_DAYS are pruned hourly:
+	// their per-block votes, or the whole count with
+	// VOTE_RETENTION_KEEP_VERDICTS=false.
+	keepVerdicts := true
+	switch strings.ToLower(getEnv("VOTE_RETENTION_KEEP_VERDICTS", "")) {
+	case "0", "false", "no":
+		keepVerdicts = false
+	}
+	services.ConfigureVoteRetention(envInt("VOTE_RETENTION_DAYS", 0), keepVerdicts)
+	services.StartVoteRetention(ctx)
+
+	// Background work that needs a connected client runs once, whether the
+	// client came up here or later through /api/connect.
+	var dcrdStarted, rpcSyncStarted sync.Once
+	startDcrdServices := func() {
+		dcrdStarted.Do(func() {
+			// Seed + push dcrd sync progress, refreshed on block-connected
+			// notifications (websocket) instead of a fixed poll interval.
+			services.StartNodeSync(ctx)
+			if err := rpc.InitDcrdNotifyClient(rpc.DcrdConfig, func(header *wire.BlockHeader) {
+				services.TriggerNodeSyncRefresh()
+				services.TriggerAddressWatchBlock()
+				services.TriggerTreasuryAutoScan()
+				services.PublishBlockConnected(header)
+			}, services.HandleBlockDisconnected, services.PublishMempoolTx); err != nil {
+				log.Printf("Warning: dcrd notification client unavailable (progress falls back to timer): %v", err)
+			}
+		})
+		services.DetectNetwork(ctx)
+	}
+	startRpcSync := func() {
+		// Supervise RpcSync from dcrd. Resumes automatically when the
+		// wallet is loaded, reconnects with backoff if the stream dies.
+		rpcSyncStarted.Do(func() { go superviseRpcSync(ctx) })
+	}
+	services.SetConnectHooks(startDcrdServices, startRpcSync)
+
 	// Try to initialize dcrd RPC client if credentials are provided
 	if dcrdConfig.RPCUser != "" && dcrdConfig.RPCPassword != "" {
 		if err := rpc.InitDcrdClient(dcrdConfig); err != nil {
 			log.Printf("Warning: Could not connect to dcrd on startup: %v", err)
 			log.Println("RPC connection can be configured via API")
 		} else {
-			// Seed + push dcrd sync progress, refreshed on block-connected
-			// notifications (websocket) instead of a fixed poll interval.
-			services.StartNodeSync(context.Background())
-			if err := rpc.InitDcrdNotifyClient(dcrdConfig, services.TriggerNodeSyncRefresh); err != nil {
-				log.Printf("Warning: dcrd notification client unavailable (progress falls back to timer): %v", err)
-			}
+			startDcrdServices()
 		}
 	} else {
 		log.Println("No dcrd RPC credentials provided. Use /api/connect endpoint to configure.")
@@ -96,14 +209,17 @@ func main() {
 			log.Printf("Warning: Could not connect to dcrwallet gRPC on startup: %v", err)
 			log.Println("Streaming features will be unavailable")
 		} else {
-			// Supervise RpcSync from dcrd. Resumes automatically when the
-			// wallet is loaded, reconnects with backoff if the stream dies.
-			go superviseRpcSync(context.Background())
+			startRpcSync()
 		}
 	} else {
 		log.Println("No gRPC certificate provided. Streaming features disabled.")
 	}
 
+	// A dcrd and a dcrwallet on different networks is a misconfiguration
+	// that otherwise only shows as odd failures; wallet writes are refused
+	// until it is fixed.
+	services.CheckNetworkMatch(ctx)
+
 	// Best-effort dcrlnd connection. dcrlnd may still be locked or
 	// uninitialised at this point; failures are logged and the
 	// dashboard re-tries on demand via ReinitDcrlndClient.
@@ -123,7 +239,7 @@ func main() {
 	// brclientd clientrpc config. The cert pair is owned by brclientd and
 	// mounted read-only into this container; lazy init means the dashboard
 	// starts even before brclientd has provisioned its identity and certs.
-	brServerCert, brClientCert, brClientKey := services.BrclientdDaemonCertPaths(context.Background())
+	brServerCert, brClientCert, brClientKey := services.BrclientdDaemonCertPaths(ctx)
 	rpc.InitBrclientdConfig(rpc.BrclientdConfig{
 		Host:           getEnv("BRCLIENTD_HOST", "brclientd"),
 		Port:           getEnv("BRCLIENTD_PORT", "7676"),
@@ -146,6 +262,21 @@ func main() {
 		WSCertPath: config.DcrdexWSCert(activeWallet),
 	})
 
+	// Optional outbound webhooks for treasury and wallet events. WEBHOOK_URLS is
+	// a space-separated list of "url" or "url|event,event" entries; requests
+	// are signed with WEBHOOK_SECRET when set.
+	services.ConfigureWebhooks(ctx, getEnv("WEBHOOK_URLS", ""), getEnv("WEBHOOK_SECRET", ""))
+	services.StartWalletTxWebhooks(ctx)
+
+	// Optional linkage of tspends to the Politeia proposals they fund, from
+	// TSPEND_PROPOSAL_MAP and approved proposals' funding windows.
+	var linkProposals bool
+	switch strings.ToLower(getEnv("TSPEND_PROPOSAL_LINKS", "")) {
+	case "1", "true", "yes":
+		linkProposals = true
+	}
+	services.ConfigureProposalLinks(linkProposals, getEnv("POLITEIA_API_URL", ""), getEnv("TSPEND_PROPOSAL_MAP", ""))
+
 	// Tail dcrwallet's log file for mixer-relevant entries; pushes them into
 	// the same ring buffer the /wallet/privacy/events WebSocket reads from.
 	services.StartWalletLogTail()
@@ -155,12 +286,12 @@ func main() {
 	services.StartBrseederRefresh()
 
 	// Persistent WS subscriptions to brclientd for chat / KX / GC events.
-	services.StartBisonrelayStreams(context.Background())
-	services.StartBrclientdNotifs(context.Background())
+	services.StartBisonrelayStreams(ctx)
+	services.StartBrclientdNotifs(ctx)
 
 	// Background poller that advances dcrtime timestamp records to "anchored" as
 	// the public dcrtime server commits their digests to the chain.
-	timestamp.StartWorker(context.Background())
+	timestamp.StartWorker(ctx)
 
 	// Load the optional dashboard app-password gate (off unless configured).
 	if err := auth.Init(); err != nil {
@@ -173,7 +304,8 @@ func main() {
 
 	// API routes
 	api := r.PathPrefix("/api").Subrouter()
-	api.Use(middleware.RequireSameOrigin, middleware.LimitJSONBody(1<<20), auth.RequireAuth)
+	api.Use(middleware.Gzip, middleware.RequireSameOrigin, middleware.LimitJSONBody(maxBodyBytes, snapshotLimit), auth.RequireAuth,
+		handlers.RequireMatchingNetworks, handlers.LimitWebSockets)
 
 	// Dashboard app-password (optional). /auth/status + /auth/login are exempt
 	// from RequireAuth so the user can reach the login handshake; every other
@@ -245,11 +377,21 @@ func main() {
 
 	// Node/dcrd routes
 	api.HandleFunc("/health", handlers.HealthCheckHandler).Methods("GET")
-	api.HandleFunc("/dashboard", handlers.GetDashboardDataHandler).Methods("GET")
-	api.HandleFunc("/node/status", handlers.GetNodeStatusHandler).Methods("GET")
+	api.HandleFunc("/healthz", handlers.LivenessHandler).Methods("GET")
+	api.HandleFunc("/readyz", handlers.ReadinessHandler).Methods("GET")
+	api.HandleFunc("/connect", handlers.ConnectRPCHandler).Methods("POST")
+	api.Handle("/dashboard", handlers.Requires(handlers.GetDashboardDataHandler, handlers.NeedDcrd)).Methods("GET")
+	api.HandleFunc("/overview", handlers.GetOverviewHandler).Methods("GET")
+	api.Handle("/node/status", handlers.Requires(handlers.GetNodeStatusHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/node/info", handlers.Requires(handlers.GetNodeInfoHandler, handlers.NeedDcrd)).Methods("GET")
 	api.HandleFunc("/node/sync/stream", handlers.StreamNodeSyncHandler).Methods("GET")
-	api.HandleFunc("/blockchain/info", handlers.GetBlockchainInfoHandler).Methods("GET")
-	api.HandleFunc("/network/peers", handlers.GetPeersHandler).Methods("GET")
+	api.Handle("/node/network", handlers.Requires(handlers.GetNetworkHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/node/params", handlers.Requires(handlers.GetConsensusParamsHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/blockchain/info", handlers.Requires(handlers.GetBlockchainInfoHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/blockchain/tip", handlers.Requires(handlers.GetBlockchainTipHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/blockchain/ticketpool", handlers.Requires(handlers.GetTicketPoolHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/network/peers", handlers.Requires(handlers.GetPeersHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/network/summary", handlers.Requires(handlers.GetNetworkSummaryHandler, handlers.NeedDcrd)).Methods("GET")
 
 	// Multi-wallet routes. select/create/delete relaunch the dcrwallet daemon,
 	// so they are rate limited like other daemon-cycling endpoints.
@@ -266,53 +408,59 @@ func main() {
 	api.Handle("/wallets/delete",
 		middleware.RateLimit("wallet-delete", 5*time.Second, 1)(
 			http.HandlerFunc(handlers.DeleteWalletHandler))).Methods("POST")
-	api.HandleFunc("/wallet/close", handlers.CloseWalletHandler).Methods("POST")
 
 	// Wallet routes
 	api.HandleFunc("/wallet/exists", handlers.WalletExistsHandler).Methods("GET")
 	api.HandleFunc("/wallet/loaded", handlers.WalletLoadedHandler).Methods("GET")
 	api.HandleFunc("/wallet/generate-seed", handlers.GenerateSeedHandler).Methods("POST")
 	api.HandleFunc("/wallet/decode-seed", handlers.DecodeSeedHandler).Methods("POST")
+	api.HandleFunc("/wallet/verify-seed", handlers.VerifySeedHandler).Methods("POST")
 	api.HandleFunc("/wallet/seed-words", handlers.SeedWordsHandler).Methods("GET")
 	api.HandleFunc("/wallet/create", handlers.CreateWalletHandler).Methods("POST")
+	api.HandleFunc("/wallet/restore", handlers.RestoreWalletHandler).Methods("POST")
 	api.HandleFunc("/wallet/open", handlers.OpenWalletHandler).Methods("POST")
-	api.HandleFunc("/wallet/status", handlers.GetWalletStatusHandler).Methods("GET")
-	api.HandleFunc("/wallet/dashboard", handlers.GetWalletDashboardHandler).Methods("GET")
-	api.HandleFunc("/wallet/transactions", handlers.ListTransactionsHandler).Methods("GET")
-	api.HandleFunc("/wallet/export", handlers.ExportTransactionsHandler).Methods("GET")
+	api.HandleFunc("/wallet/close", handlers.CloseWalletHandler).Methods("POST")
+	api.HandleFunc("/wallet/reload", handlers.ReloadWalletHandler).Methods("POST")
+	api.Handle("/wallet/status", handlers.Requires(handlers.GetWalletStatusHandler, handlers.NeedDcrd, handlers.NeedWallet)).Methods("GET")
+	api.Handle("/wallet/dashboard", handlers.Requires(handlers.GetWalletDashboardHandler, handlers.NeedWallet)).Methods("GET")
+	api.Handle("/wallet/transactions", handlers.Requires(handlers.ListTransactionsHandler, handlers.NeedWallet)).Methods("GET")
+	api.Handle("/wallet/transactions/{txhash}", handlers.Requires(handlers.GetWalletTransactionHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/export", handlers.Requires(handlers.ExportTransactionsHandler, handlers.NeedWalletGrpc)).Methods("GET")
 	api.Handle("/wallet/importxpub",
 		middleware.RateLimit("importxpub", 30*time.Second, 1)(
-			http.HandlerFunc(handlers.ImportXpubHandler))).Methods("POST")
-	api.HandleFunc("/wallet/accounts", handlers.GetAccountsHandler).Methods("GET")
-	api.HandleFunc("/wallet/create-account", handlers.CreateAccountHandler).Methods("POST")
-	api.HandleFunc("/wallet/rename-account", handlers.RenameAccountHandler).Methods("POST")
-	api.HandleFunc("/wallet/account-extended-pubkey", handlers.GetAccountExtendedPubKeyHandler).Methods("GET")
-	api.HandleFunc("/wallet/privacy/status", handlers.PrivacyStatusHandler).Methods("GET")
-	api.HandleFunc("/wallet/privacy/setup", handlers.PrivacySetupHandler).Methods("POST")
-	api.HandleFunc("/wallet/privacy/start", handlers.PrivacyStartHandler).Methods("POST")
+			handlers.Requires(handlers.ImportXpubHandler, handlers.NeedWallet))).Methods("POST")
+	api.HandleFunc("/wallet/importxpub/status/{id}", handlers.ImportXpubStatusHandler).Methods("GET")
+	api.Handle("/wallet/accounts", handlers.Requires(handlers.GetAccountsHandler, handlers.NeedWallet)).Methods("GET")
+	api.Handle("/wallet/addresses", handlers.Requires(handlers.ListWalletAddressesHandler, handlers.NeedWallet, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/create-account", handlers.Requires(handlers.CreateAccountHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/rename-account", handlers.Requires(handlers.RenameAccountHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/account-extended-pubkey", handlers.Requires(handlers.GetAccountExtendedPubKeyHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/privacy/status", handlers.Requires(handlers.PrivacyStatusHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/privacy/setup", handlers.Requires(handlers.PrivacySetupHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/privacy/start", handlers.Requires(handlers.PrivacyStartHandler, handlers.NeedMixer)).Methods("POST")
 	api.HandleFunc("/wallet/privacy/stop", handlers.PrivacyStopHandler).Methods("POST")
 	api.HandleFunc("/wallet/privacy/events", handlers.StreamMixerEventsHandler).Methods("GET")
-	api.HandleFunc("/wallet/mixer/debug", handlers.MixerDebugHandler).Methods("GET", "POST")
+	api.Handle("/wallet/mixer/debug", handlers.Requires(handlers.MixerDebugHandler, handlers.NeedWallet)).Methods("GET", "POST")
 	api.HandleFunc("/wallet/staking/vsps", handlers.ListVSPsHandler).Methods("GET")
 	api.HandleFunc("/wallet/staking/vsp-info", handlers.VSPInfoHandler).Methods("GET")
-	api.HandleFunc("/wallet/staking/purchase", handlers.PurchaseTicketsHandler).Methods("POST")
+	api.Handle("/wallet/staking/purchase", handlers.Requires(handlers.PurchaseTicketsHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/wallet/staking/purchase/status", handlers.PurchaseStatusHandler).Methods("GET")
 	api.HandleFunc("/wallet/staking/purchase/events", handlers.StreamPurchaseEventsHandler).Methods("GET")
-	api.HandleFunc("/wallet/staking/tickets", handlers.ListTicketsHandler).Methods("GET")
-	api.HandleFunc("/wallet/staking/sync-failed-vsp-tickets", handlers.SyncFailedVSPTicketsHandler).Methods("POST")
-	api.HandleFunc("/wallet/staking/process-unmanaged-vsp-tickets", handlers.ProcessUnmanagedVSPTicketsHandler).Methods("POST")
+	api.Handle("/wallet/staking/tickets", handlers.Requires(handlers.ListTicketsHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/staking/sync-failed-vsp-tickets", handlers.Requires(handlers.SyncFailedVSPTicketsHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/staking/process-unmanaged-vsp-tickets", handlers.Requires(handlers.ProcessUnmanagedVSPTicketsHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/wallet/staking/autobuyer/status", handlers.AutobuyerStatusHandler).Methods("GET")
 	api.HandleFunc("/wallet/staking/autobuyer/settings", handlers.GetAutobuyerSettingsHandler).Methods("GET")
 	api.HandleFunc("/wallet/staking/autobuyer/settings", handlers.SaveAutobuyerSettingsHandler).Methods("POST")
-	api.HandleFunc("/wallet/staking/autobuyer/start", handlers.StartAutobuyerHandler).Methods("POST")
+	api.Handle("/wallet/staking/autobuyer/start", handlers.Requires(handlers.StartAutobuyerHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/wallet/staking/autobuyer/stop", handlers.StopAutobuyerHandler).Methods("POST")
 	api.HandleFunc("/wallet/staking/autobuyer/events", handlers.StreamAutobuyerEventsHandler).Methods("GET")
 	api.HandleFunc("/wallet/settings", handlers.GetSettingsHandler).Methods("GET")
 	api.HandleFunc("/wallet/settings", handlers.SaveSettingsHandler).Methods("POST")
-	api.HandleFunc("/wallet/settings/change-passphrase", handlers.ChangePassphraseHandler).Methods("POST")
+	api.Handle("/wallet/settings/change-passphrase", handlers.Requires(handlers.ChangePassphraseHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.Handle("/wallet/settings/discover-addresses",
 		middleware.RateLimit("discover-addresses", 30*time.Second, 1)(
-			http.HandlerFunc(handlers.DiscoverAddressesHandler))).Methods("POST")
+			handlers.Requires(handlers.DiscoverAddressesHandler, handlers.NeedWalletGrpc))).Methods("POST")
 	api.HandleFunc("/wallet/settings/logs", handlers.GetLogsHandler).Methods("GET")
 	api.HandleFunc("/themes", handlers.GetThemesHandler).Methods("GET")
 	api.HandleFunc("/themes", handlers.SaveThemesHandler).Methods("POST")
@@ -333,22 +481,24 @@ func main() {
 	api.HandleFunc("/tor/status", handlers.GetTorStatusHandler).Methods("GET")
 	api.HandleFunc("/tor/control", handlers.GetTorControlHandler).Methods("GET")
 	api.HandleFunc("/tor/newidentity", handlers.TorNewIdentityHandler).Methods("POST")
-	api.HandleFunc("/wallet/governance/agendas", handlers.GetAgendasHandler).Methods("GET")
-	api.HandleFunc("/wallet/governance/agendas/set", handlers.SetAgendaChoiceHandler).Methods("POST")
-	api.HandleFunc("/wallet/governance/treasury/keys", handlers.GetTreasuryKeyPoliciesHandler).Methods("GET")
-	api.HandleFunc("/wallet/governance/treasury/keys/set", handlers.SetTreasuryKeyPolicyHandler).Methods("POST")
-	api.HandleFunc("/wallet/governance/treasury/tspends", handlers.GetTSpendPoliciesHandler).Methods("GET")
-	api.HandleFunc("/wallet/governance/treasury/tspends/set", handlers.SetTSpendPolicyHandler).Methods("POST")
+	api.Handle("/wallet/governance/agendas", handlers.Requires(handlers.GetAgendasHandler, handlers.NeedDcrd, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/governance/agendas/set", handlers.Requires(handlers.SetAgendaChoiceHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/governance/treasury/keys", handlers.Requires(handlers.GetTreasuryKeyPoliciesHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/governance/treasury/keys/set", handlers.Requires(handlers.SetTreasuryKeyPolicyHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/governance/treasury/tspends", handlers.Requires(handlers.GetTSpendPoliciesHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/governance/treasury/tspends/set", handlers.Requires(handlers.SetTSpendPolicyHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/voting-policy", handlers.Requires(handlers.GetVotingPolicyHandler, handlers.NeedDcrd, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/voting-policy", handlers.Requires(handlers.SetVotingPolicyHandler, handlers.NeedDcrd, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/wallet/governance/proposals", handlers.GetProposalsHandler).Methods("GET")
 	api.HandleFunc("/wallet/governance/proposals/{token}", handlers.GetProposalDetailHandler).Methods("GET")
-	api.HandleFunc("/wallet/governance/proposals/cast-vote", handlers.CastPoliteiaVoteHandler).Methods("POST")
+	api.Handle("/wallet/governance/proposals/cast-vote", handlers.Requires(handlers.CastPoliteiaVoteHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/wallet/governance/proposals/refresh", handlers.RefreshProposalsHandler).Methods("POST")
-	api.HandleFunc("/wallet/governance/votetrickle/start", handlers.StartVoteTrickleHandler).Methods("POST")
+	api.Handle("/wallet/governance/votetrickle/start", handlers.Requires(handlers.StartVoteTrickleHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/wallet/governance/votetrickle/stop", handlers.StopVoteTrickleHandler).Methods("POST")
 	api.HandleFunc("/wallet/governance/votetrickle/status", handlers.VoteTrickleStatusHandler).Methods("GET")
 	api.HandleFunc("/wallet/governance/votetrickle/events", handlers.StreamVoteTrickleEventsHandler).Methods("GET")
 	api.HandleFunc("/wallet/governance/proposals/{token}/refresh", handlers.RefreshProposalDetailHandler).Methods("POST")
-	api.HandleFunc("/wallet/governance/proposals/{token}/vote-eligibility", handlers.PrepareProposalVoteHandler).Methods("POST")
+	api.Handle("/wallet/governance/proposals/{token}/vote-eligibility", handlers.Requires(handlers.PrepareProposalVoteHandler, handlers.NeedWalletGrpc)).Methods("POST")
 	api.HandleFunc("/br/version", handlers.BisonrelayVersionHandler).Methods("GET")
 	api.HandleFunc("/br/status", handlers.BisonrelayStatusHandler).Methods("GET")
 	api.HandleFunc("/br/setup", handlers.BisonrelaySetupHandler).Methods("POST")
@@ -521,95 +671,94 @@ func main() {
 	api.HandleFunc("/wallet/ln/watchtowers/remove", handlers.LightningWatchtowerRemoveHandler).Methods("POST")
 	api.HandleFunc("/wallet/ln/graph/node", handlers.LightningGraphNodeHandler).Methods("GET")
 	api.HandleFunc("/wallet/ln/graph/routes", handlers.LightningGraphRoutesHandler).Methods("POST")
-	api.HandleFunc("/wallet/next-address", handlers.NextAddressHandler).Methods("GET")
-	api.HandleFunc("/wallet/validate-address", handlers.ValidateAddressHandler).Methods("GET")
-	api.HandleFunc("/wallet/construct-transaction", handlers.ConstructTransactionHandler).Methods("POST")
-	api.HandleFunc("/wallet/sign-publish-transaction", handlers.SignPublishTransactionHandler).Methods("POST")
-	api.HandleFunc("/wallet/decode-signed-transaction", handlers.DecodeSignedTransactionHandler).Methods("POST")
-	api.HandleFunc("/wallet/broadcast-signed-transaction", handlers.BroadcastSignedTransactionHandler).Methods("POST")
-	api.HandleFunc("/wallet/build-sign-request", handlers.BuildSignRequestHandler).Methods("POST")
-	api.HandleFunc("/wallet/device-balance", handlers.DeviceBalanceHandler).Methods("GET")
+	api.Handle("/wallet/next-address", handlers.Requires(handlers.NextAddressHandler, handlers.NeedWallet, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/validate-address", handlers.Requires(handlers.ValidateAddressHandler, handlers.NeedWalletGrpc)).Methods("GET")
+	api.Handle("/wallet/construct-transaction", handlers.Requires(handlers.ConstructTransactionHandler, handlers.NeedWalletGrpc, handlers.NeedTxDecoder)).Methods("POST")
+	api.Handle("/wallet/sign-publish-transaction", handlers.Requires(handlers.SignPublishTransactionHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/decode-signed-transaction", handlers.Requires(handlers.DecodeSignedTransactionHandler, handlers.NeedWalletGrpc, handlers.NeedTxDecoder)).Methods("POST")
+	api.Handle("/wallet/broadcast-signed-transaction", handlers.Requires(handlers.BroadcastSignedTransactionHandler, handlers.NeedWalletGrpc)).Methods("POST")
+	api.Handle("/wallet/build-sign-request", handlers.Requires(handlers.BuildSignRequestHandler, handlers.NeedWalletGrpc, handlers.NeedTxDecoder)).Methods("POST")
+	api.Handle("/wallet/device-balance", handlers.Requires(handlers.DeviceBalanceHandler, handlers.NeedWalletGrpc)).Methods("GET")
 	api.HandleFunc("/wallet/parse-account-export", handlers.ParseAccountExportHandler).Methods("POST")
 	api.Handle("/wallet/rescan",
 		middleware.RateLimit("rescan", 60*time.Second, 1)(
-			http.HandlerFunc(handlers.RescanWalletHandler))).Methods("POST")
+			handlers.Requires(handlers.RescanWalletHandler, handlers.NeedWallet))).Methods("POST")
 	api.HandleFunc("/wallet/sync-progress", handlers.GetSyncProgressHandler).Methods("GET")
+	api.HandleFunc("/wallet/sync-status", handlers.GetWalletSyncStatusHandler).Methods("GET")
 
 	// WebSocket streaming routes (log-based monitoring, does not start rescans)
 	api.HandleFunc("/wallet/stream-rescan-progress", handlers.StreamRescanProgressHandler).Methods("GET")
 	api.HandleFunc("/wallet/grpc/stream-rescan", handlers.StreamRescanGrpcHandler).Methods("GET")
-
-	// Explorer routes
-	api.HandleFunc("/explorer/search", handlers.SearchHandler).Methods("GET")
-	api.HandleFunc("/explorer/blocks/recent", handlers.GetRecentBlocksHandler).Methods("GET")
-	api.HandleFunc("/explorer/blocks/{height:[0-9]+}", handlers.GetBlockByHeightHandler).Methods("GET")
-	api.HandleFunc("/explorer/blocks/hash/{hash}", handlers.GetBlockByHashHandler).Methods("GET")
-	api.HandleFunc("/explorer/transactions/{txhash}", handlers.GetTransactionHandler).Methods("GET")
-	api.HandleFunc("/explorer/address/{address}", handlers.GetAddressHandler).Methods("GET")
-	api.HandleFunc("/explorer/mempool", handlers.GetMempoolTransactionsHandler).Methods("GET")
+	api.HandleFunc("/wallet/rescan-progress/events", handlers.StreamRescanProgressSSEHandler).Methods("GET")
+
+	// Explorer routes. Those that tag the response while dcrd syncs need it
+	// connected too.
+	needDcrd := handlers.RequireClients(handlers.NeedDcrd)
+	api.Handle("/explorer/search", needDcrd(handlers.WarnWhileNodeSyncing(handlers.SearchHandler))).Methods("GET")
+	api.Handle("/explorer/blocks/recent", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetRecentBlocksHandler))).Methods("GET")
+	api.Handle("/explorer/blocks/headers", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetBlockHeadersHandler))).Methods("GET")
+	api.Handle("/explorer/blocks/{height:[0-9]+}", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetBlockByHeightHandler))).Methods("GET")
+	api.Handle("/explorer/blocks/{height:[0-9]+}/votes", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetBlockVotesHandler))).Methods("GET")
+	api.Handle("/explorer/blocks/hash/{hash}", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetBlockByHashHandler))).Methods("GET")
+	api.Handle("/explorer/blocks/hash/{hash}/raw", handlers.Requires(handlers.GetRawBlockHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/explorer/blocks/hash/{hash}/transactions", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetBlockTransactionsHandler))).Methods("GET")
+	api.Handle("/explorer/transactions/{txhash}", handlers.Requires(handlers.GetTransactionHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/explorer/transactions/{txhash}/raw", handlers.Requires(handlers.GetRawTransactionHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/explorer/ticket/{hash}", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetTicketLifecycleHandler))).Methods("GET")
+	api.Handle("/explorer/address/{address}", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetAddressHandler))).Methods("GET")
+	api.Handle("/explorer/address/{address}/stream", handlers.Requires(handlers.StreamAddressHandler, handlers.NeedDcrdNotify)).Methods("GET")
+	api.Handle("/explorer/address/{address}/trace", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetAddressTraceHandler))).Methods("GET")
+	api.Handle("/explorer/votes", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetVoteHistoryHandler))).Methods("GET")
+	api.Handle("/explorer/mempool", handlers.Requires(handlers.GetMempoolTransactionsHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/explorer/stream-mempool", handlers.Requires(handlers.StreamMempoolHandler, handlers.NeedDcrdNotify)).Methods("GET")
+	api.Handle("/explorer/stream-blocks", handlers.Requires(handlers.StreamBlocksHandler, handlers.NeedDcrdNotify)).Methods("GET")
 
 	// Treasury/Governance routes
-	api.HandleFunc("/treasury/info", handlers.GetTreasuryInfoHandler).Methods("GET")
-	api.HandleFunc("/treasury/balance-history", handlers.GetTreasuryBalanceHistoryHandler).Methods("GET")
+	api.Handle("/treasury/info", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetTreasuryInfoHandler))).Methods("GET")
+	api.Handle("/treasury/balance-history", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetTreasuryBalanceHistoryHandler))).Methods("GET")
+	api.Handle("/treasury/flow", handlers.WarnWhileNodeSyncing(handlers.GetTreasuryFlowHandler)).Methods("GET")
+	api.Handle("/treasury/payees", handlers.WarnWhileNodeSyncing(handlers.GetTreasuryPayeesHandler)).Methods("GET")
 	api.Handle("/treasury/scan-history",
 		middleware.RateLimit("treasury-scan", 60*time.Second, 1)(
-			http.HandlerFunc(handlers.TriggerTSpendScanHandler))).Methods("POST")
+			handlers.Requires(handlers.TriggerTSpendScanHandler, handlers.NeedDcrd))).Methods("POST")
+	api.Handle("/treasury/scan-heights",
+		middleware.RateLimit("treasury-scan-heights", 10*time.Second, 1)(
+			handlers.Requires(handlers.ScanTSpendHeightsHandler, handlers.NeedDcrd))).Methods("POST")
+	api.Handle("/treasury/scan-estimate",
+		middleware.RateLimit("treasury-scan-estimate", 10*time.Second, 1)(
+			handlers.Requires(handlers.EstimateTSpendScanHandler, handlers.NeedDcrd))).Methods("GET")
 	api.HandleFunc("/treasury/scan-progress", handlers.GetTSpendScanProgressHandler).Methods("GET")
-	api.HandleFunc("/treasury/scan-results", handlers.GetTSpendScanResultsHandler).Methods("GET")
-	api.HandleFunc("/treasury/mempool", handlers.GetMempoolTSpendsHandler).Methods("GET")
+	api.HandleFunc("/treasury/scan-progress/wait", handlers.WaitTSpendScanProgressHandler).Methods("GET")
+	api.HandleFunc("/treasury/scan-progress/events", handlers.StreamTSpendScanProgressSSEHandler).Methods("GET")
+	api.Handle("/treasury/scan-results", handlers.WarnWhileNodeSyncing(handlers.GetTSpendScanResultsHandler)).Methods("GET")
+	api.HandleFunc("/treasury/scan-found", handlers.GetTSpendScanFoundHandler).Methods("GET")
+	api.Handle("/treasury/snapshot", handlers.Requires(handlers.ExportScanSnapshotHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/treasury/snapshot/import", handlers.Requires(handlers.ImportScanSnapshotHandler, handlers.NeedDcrd)).Methods("POST")
+	api.Handle("/treasury/mempool", handlers.Requires(handlers.GetMempoolTSpendsHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/treasury/mempool/standings", handlers.Requires(handlers.GetMempoolTSpendStandingsHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/treasury/tspend/{txhash}", handlers.Requires(handlers.GetTSpendDetailHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/treasury/tspend/{txhash}/votes/export", handlers.Requires(handlers.ExportTSpendVotesHandler, handlers.NeedDcrd)).Methods("GET")
+	api.Handle("/treasury/tspend/{txhash}/ticket/{tickethash}", handlers.Requires(handlers.GetTicketTSpendVoteHandler, handlers.NeedDcrd)).Methods("GET")
 	api.HandleFunc("/treasury/votes/{txhash}/progress", handlers.GetVoteParsingProgressHandler).Methods("GET")
+	api.HandleFunc("/treasury/votes/{txhash}/progress/wait", handlers.WaitVoteParsingProgressHandler).Methods("GET")
+	api.HandleFunc("/treasury/votes/{txhash}/progress/events", handlers.StreamVoteParsingProgressSSEHandler).Methods("GET")
+	api.HandleFunc("/treasury/votes/{txhash}/cancel", handlers.CancelVoteParsingHandler).Methods("POST")
+	api.Handle("/governance/dashboard", needDcrd(handlers.WarnWhileNodeSyncing(handlers.GetGovernanceDashboardHandler))).Methods("GET")
+	api.HandleFunc("/admin/compact", handlers.CompactHandler).Methods("POST")
+
+	// Serve the frontend (embedded build, FRONTEND_DIR, or none) with SPA
+	// fallback
+	frontend, frontendDesc := loadFrontend()
+	if frontend != nil {
+		serveFrontend(r, frontend, basePath)
+	}
 
-	// Serve embedded static files for frontend
-	distFS, err := fs.Sub(embeddedFiles, "web/dist")
-	if err != nil {
-		log.Printf("Warning: Could not load embedded frontend files: %v", err)
-		log.Println("Frontend will not be available. This is expected in development mode.")
-	} else {
-		// Allow the inline scripts shipped in index.html (the pre-mount theme
-		// loader) under the strict script-src 'self' CSP by hashing them at
-		// startup. Recomputing from the embedded HTML means edits to the inline
-		// script never require updating the CSP by hand.
-		if html, rerr := fs.ReadFile(distFS, "index.html"); rerr == nil {
-			var hashes []string
-			for _, m := range inlineScriptRe.FindAllSubmatch(html, -1) {
-				hashes = append(hashes, middleware.InlineScriptHash(m[1]))
-			}
-			middleware.ConfigureInlineScriptHashes(hashes...)
-		} else {
-			log.Printf("Warning: Could not hash inline frontend scripts for CSP: %v", rerr)
-		}
-
-		// Serve static files with SPA fallback
-		r.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			path := req.URL.Path
-
-			// Skip API routes
-			if strings.HasPrefix(path, "/api") {
-				http.NotFound(w, req)
-				return
-			}
-
-			// Try to serve the requested file
-			if path != "/" {
-				filePath := strings.TrimPrefix(path, "/")
-				if _, err := distFS.Open(filePath); err == nil {
-					// The dcrtime file-hashing Web Worker is the only place
-					// WebAssembly runs; grant it (and nothing else) the
-					// wasm-unsafe-eval CSP token. The strict document CSP set by
-					// SecurityHeaders is left untouched for every other asset.
-					if strings.Contains(filePath, "dcrtime-hash-worker") {
-						w.Header().Set("Content-Security-Policy",
-							"default-src 'none'; script-src 'self' 'wasm-unsafe-eval'")
-					}
-					http.FileServer(http.FS(distFS)).ServeHTTP(w, req)
-					return
-				}
-			}
-
-			// Fallback to index.html for SPA routing
-			req.URL.Path = "/"
-			http.FileServer(http.FS(distFS)).ServeHTTP(w, req)
-		})
+	// Behind a reverse proxy that forwards a subpath such as /dcrpulse/,
+	// BASE_PATH serves the API and frontend there instead of at the root.
+	var handler http.Handler = r
+	if basePath != "" {
+		handler = mountAtBasePath(basePath, r)
+		log.Printf("Serving under base path %s/", basePath)
 	}
 
 	// Start server
@@ -617,23 +766,34 @@ func main() {
 	address := fmt.Sprintf(":%s", port)
 
 	log.Printf("Starting dcrpulse Dashboard server on %s", address)
-	log.Println("Node endpoints: /api/dashboard, /api/node/*, /api/blockchain/*, /api/network/*")
+	log.Println("Node endpoints: /api/dashboard, /api/overview, /api/node/*, /api/blockchain/*, /api/network/*")
 	log.Println("Wallet endpoints: /api/wallet/status, /api/wallet/dashboard, /api/wallet/importxpub")
 	log.Println("Wallet gRPC endpoints: /api/wallet/grpc/stream-rescan (real-time streaming)")
 	log.Println("Explorer endpoints: /api/explorer/search, /api/explorer/blocks/*, /api/explorer/transactions/*")
 	log.Println("Treasury endpoints: /api/treasury/info, /api/treasury/scan-history, /api/treasury/scan-progress")
-	log.Println("Frontend: Embedded static files served at /")
+	log.Printf("Frontend: %s", frontendDesc)
 	// ReadHeaderTimeout bounds the header-read phase to defeat Slowloris. Read
 	// and Write timeouts are intentionally left unset so long-lived streams
 	// (WebSocket rescan/events, SSE progress) and large BR file uploads are not
 	// cut off mid-transfer.
 	srv := &http.Server{
 		Addr:              address,
-		Handler:           r,
+		Handler:           handler,
 		ReadHeaderTimeout: 15 * time.Second,
 		IdleTimeout:       120 * time.Second,
 	}
-	log.Fatal(srv.ListenAndServe())
+	go func() {
+		<-ctx.Done()
+		log.Println("Shutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown: %v", err)
+		}
+	}()
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
 }
 
 func getEnv(key, defaultValue string) string {
@@ -700,7 +860,12 @@ func superviseRpcSync(ctx context.Context) {
 		if ctx.Err() != nil {
 			return
 		}
-		if err != nil {
+		switch {
+		case errors.Is(err, services.ErrRpcSyncAlreadyRunning):
+			// Another stream (e.g. restore discovery) owns the slot and the
+			// wallet keeps syncing; take over once it ends.
+			log.Printf("RPC sync already running in dcrwallet (will retry in %v)", backoff)
+		case err != nil:
 			services.MarkSyncDisconnected(err.Error())
 			log.Printf("RPC sync error (will retry in %v): %v", backoff, err)
 		}
@@ -708,6 +873,10 @@ func superviseRpcSync(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-services.SyncKicked():
+			// A wallet reload reopened the wallet; reconnect at once.
+			backoff = 5 * time.Second
+			continue
 		case <-time.After(backoff):
 		}
 		if backoff < 60*time.Second {
@@ -23,6 +23,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"dcrpulse/internal/config"
+	"dcrpulse/internal/middleware"
 )
 
 const (
@@ -301,7 +302,8 @@ func isSecure(r *http.Request) bool {
 
 // RequireAuth gates the /api subrouter. While the app password is disabled it
 // is a pass-through. When enabled, only the login handshake (/api/auth/login,
-// /api/auth/status) is exempt; every other route needs a valid session cookie.
+// /api/auth/status) and the orchestration probes (/api/healthz, /api/readyz)
+// are exempt; every other route needs a valid session cookie.
 func RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !Enabled() {
@@ -309,7 +311,7 @@ func RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 		switch r.URL.Path {
-		case "/api/auth/login", "/api/auth/status":
+		case "/api/auth/login", "/api/auth/status", "/api/healthz", "/api/readyz":
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -319,7 +321,7 @@ func RequireAuth(next http.Handler) http.Handler {
 			// prompt after a wallet switch) and re-lock only on a genuine
 			// app-password session failure.
 			w.Header().Set("X-Dashboard-Auth", "required")
-			http.Error(w, "authentication required", http.StatusUnauthorized)
+			middleware.WriteError(w, http.StatusUnauthorized, "unauthorized", "authentication required")
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -21,6 +21,9 @@ const (
 	// number (>= 2^31, stringified) to its real BIP44 account index. dcrpulse-only
 	// (not a Decrediton key); needed because the device derives keys by BIP44 index.
 	KeyXpubAccountIndexes = "xpub_account_indexes"
+	// KeyBirthHeight is the block a wallet was first used at, given on restore
+	// or open. dcrpulse-only; rescans the dashboard starts begin there.
+	KeyBirthHeight = "birth_height"
 	KeyGapLimit          = "gap_limit"
 	KeyDiscoverAccounts  = "discover_accounts"
 	KeyMixedAccountCfg   = "mixed_account_cfg"
@@ -0,0 +1,17 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+
+	"dcrpulse/internal/services"
+)
+
+// CompactHandler applies the vote cache retention policy now instead of
+// waiting for its scheduled run, and reports what was pruned.
+func CompactHandler(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, services.CompactVoteCache())
+}
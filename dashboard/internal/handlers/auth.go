@@ -5,7 +5,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"dcrpulse/internal/auth"
@@ -15,8 +14,7 @@ import (
 // boolean-only; the frontend uses it to decide between the login screen, the
 // first-run setup prompt, and the app.
 func AuthStatusHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]bool{
+	respondJSON(w, http.StatusOK, map[string]bool{
 		"enabled":        auth.Enabled(),
 		"configured":     auth.Configured(),
 		"authenticated":  auth.Authenticated(r),
@@ -29,20 +27,20 @@ func AuthLoginHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Password string `json:"password"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if !auth.Enabled() {
-		http.Error(w, "app password is not enabled", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "app password is not enabled")
 		return
 	}
 	if !auth.Verify(req.Password) {
-		http.Error(w, "incorrect password", http.StatusUnauthorized)
+		respondError(w, http.StatusUnauthorized, "incorrect password")
 		return
 	}
 	if err := auth.SetSessionCookie(w, r); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -54,20 +52,20 @@ func AuthSetupHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Password string `json:"password"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if auth.Configured() {
-		http.Error(w, "a password is already configured", http.StatusConflict)
+		respondError(w, http.StatusConflict, "a password is already configured")
 		return
 	}
 	if err := auth.Setup(req.Password); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	if err := auth.SetSessionCookie(w, r); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -76,7 +74,7 @@ func AuthSetupHandler(w http.ResponseWriter, r *http.Request) {
 // AuthSkipSetupHandler records that the user declined the first-run prompt.
 func AuthSkipSetupHandler(w http.ResponseWriter, r *http.Request) {
 	if err := auth.MarkSetupDismissed(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -95,12 +93,12 @@ func AuthChangeHandler(w http.ResponseWriter, r *http.Request) {
 		Current string `json:"current"`
 		New     string `json:"new"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if err := auth.Change(req.Current, req.New); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	// Refresh the cookie so the session stays alive after the change.
@@ -114,12 +112,12 @@ func AuthDisableHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Current string `json:"current"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if err := auth.Disable(req.Current); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	auth.ClearSessionCookie(w, r)
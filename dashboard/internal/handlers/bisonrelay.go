@@ -40,8 +40,7 @@ func BisonrelayVersionHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(ver)
+	respondJSON(w, http.StatusOK, ver)
 }
 
 // BisonrelayStatusHandler proxies brclientd's /status endpoint, returning
@@ -53,8 +52,7 @@ func BisonrelayStatusHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(status)
+	respondJSON(w, http.StatusOK, status)
 }
 
 // BisonrelayIdentityHandler returns brclientd's local BR identity payload
@@ -66,8 +64,7 @@ func BisonrelayIdentityHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(id)
+	respondJSON(w, http.StatusOK, json.RawMessage(id))
 }
 
 // BisonrelaySetAvatarHandler proxies brclientd's /avatar. Body: {avatar}
@@ -77,8 +74,8 @@ func BisonrelaySetAvatarHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Avatar string `json:"avatar"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdSetAvatar(r.Context(), req.Avatar); err != nil {
@@ -99,6 +96,8 @@ func BisonrelayEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
@@ -115,21 +114,15 @@ func BisonrelayEventsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	pinger := time.NewTicker(30 * time.Second)
-	defer pinger.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-pinger.C:
-			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
-				return
-			}
 		case evt, ok := <-events:
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(evt); err != nil {
+			if err := writeWSJSON(conn, evt); err != nil {
 				return
 			}
 		}
@@ -181,19 +174,19 @@ func BisonrelayFileSendHandler(w http.ResponseWriter, r *http.Request) {
 	const maxUpload = 1 << 30
 	r.Body = http.MaxBytesReader(w, r.Body, maxUpload)
 	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		http.Error(w, "parse multipart: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "parse multipart: "+err.Error())
 		return
 	}
 	defer r.MultipartForm.RemoveAll()
 
 	user := strings.TrimSpace(r.FormValue("user"))
 	if user == "" {
-		http.Error(w, "user field is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "user field is required")
 		return
 	}
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "file part missing: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "file part missing: "+err.Error())
 		return
 	}
 	defer file.Close()
@@ -204,8 +197,7 @@ func BisonrelayFileSendHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(result)
+	respondJSON(w, http.StatusOK, result)
 }
 
 // BisonrelayDownloadHandler serves a completed file-transfer download that
@@ -251,8 +243,7 @@ func BisonrelayDownloadsListHandler(w http.ResponseWriter, r *http.Request) {
 	dir := filepath.Join(services.BrclientdDownloadsDir(network), contact)
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"files":[]}`))
+		respondJSON(w, http.StatusOK, json.RawMessage(`{"files":[]}`))
 		return
 	}
 	type fileEntry struct {
@@ -271,8 +262,7 @@ func BisonrelayDownloadsListHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		out = append(out, fileEntry{Name: e.Name(), Size: fi.Size(), ModTime: fi.ModTime().Unix()})
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string][]fileEntry{"files": out})
+	respondJSON(w, http.StatusOK, map[string][]fileEntry{"files": out})
 }
 
 // BisonrelayContactsHandler proxies brclientd's /contacts endpoint.
@@ -283,8 +273,7 @@ func BisonrelayContactsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayContactRenameHandler proxies brclientd's /contacts/rename
@@ -295,12 +284,12 @@ func BisonrelayContactRenameHandler(w http.ResponseWriter, r *http.Request) {
 		UID     string `json:"uid"`
 		NewNick string `json:"new_nick"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.NewNick == "" {
-		http.Error(w, "uid and new_nick are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and new_nick are required")
 		return
 	}
 	if err := rpc.BrclientdRenameContact(r.Context(), req.UID, req.NewNick); err != nil {
@@ -333,16 +322,16 @@ func BisonrelayContactResetAllHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		AgeDays int `json:"age_days"`
 	}
-	if r.Body != nil {
-		_ = json.NewDecoder(r.Body).Decode(&req)
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
 	}
 	raw, err := rpc.BrclientdResetAllRatchets(r.Context(), req.AgeDays)
 	if err != nil {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // BisonrelayConnectionHandler proxies brclientd's /connection: GET reports
@@ -357,14 +346,13 @@ func BisonrelayConnectionHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 	case http.MethodPost:
 		var req struct {
 			Online bool `json:"online"`
 		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 			return
 		}
 		if err := rpc.BrclientdSetConnection(r.Context(), req.Online); err != nil {
@@ -373,7 +361,7 @@ func BisonrelayConnectionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(http.StatusNoContent)
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -382,7 +370,7 @@ func BisonrelayConnectionHandler(w http.ResponseWriter, r *http.Request) {
 func BisonrelayTipAttemptsHandler(w http.ResponseWriter, r *http.Request) {
 	uid := strings.TrimSpace(r.URL.Query().Get("uid"))
 	if uid == "" {
-		http.Error(w, "uid query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid query param is required")
 		return
 	}
 	body, err := rpc.BrclientdTipAttempts(r.Context(), uid)
@@ -390,8 +378,7 @@ func BisonrelayTipAttemptsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayRunningTipsHandler returns the tip attempts the daemon is
@@ -402,8 +389,7 @@ func BisonrelayRunningTipsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayRTDTMessagesHandler returns the chat messages tracked for a
@@ -415,8 +401,7 @@ func BisonrelayRTDTMessagesHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // BisonrelayRTDTChatHandler sends a text message into a live RTDT session.
@@ -425,12 +410,12 @@ func BisonrelayRTDTChatHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Message string `json:"message"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.Message) == "" {
-		http.Error(w, "message is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "message is required")
 		return
 	}
 	if err := rpc.BrclientdRTDTChat(r.Context(), rv, req.Message); err != nil {
@@ -447,8 +432,7 @@ func BisonrelayKXSearchesHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // BisonrelayMediateIDsHandler proxies the in-flight mediated introductions:
@@ -461,19 +445,18 @@ func BisonrelayMediateIDsHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 	case http.MethodPost:
 		var req struct {
 			Mediator string `json:"mediator"`
 			Target   string `json:"target"`
 		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 			return
 		}
 		if req.Mediator == "" || req.Target == "" {
-			http.Error(w, "mediator and target are required", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "mediator and target are required")
 			return
 		}
 		if err := rpc.BrclientdCancelMediateID(r.Context(), req.Mediator, req.Target); err != nil {
@@ -482,7 +465,7 @@ func BisonrelayMediateIDsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(http.StatusNoContent)
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -500,8 +483,7 @@ func BisonrelayRecentNotificationsHandler(w http.ResponseWriter, r *http.Request
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayDeleteNotificationHandler removes a single BR notification-bell
@@ -510,8 +492,8 @@ func BisonrelayDeleteNotificationHandler(w http.ResponseWriter, r *http.Request)
 	var req struct {
 		ID int64 `json:"id"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdDeleteNotification(r.Context(), req.ID); err != nil {
@@ -542,12 +524,11 @@ func BisonrelayBehaviorSettingsHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 	case http.MethodPost:
 		var update map[string]any
-		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
-			http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+		if err := decodeJSON(r, &update); err != nil {
+			respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 			return
 		}
 		if err := rpc.BrclientdSetBRBehavior(r.Context(), update); err != nil {
@@ -556,7 +537,7 @@ func BisonrelayBehaviorSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(http.StatusNoContent)
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -572,12 +553,11 @@ func BisonrelayFiltersHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 	case http.MethodPost:
 		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
 		if err != nil {
-			http.Error(w, "read body: "+err.Error(), http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "read body: "+err.Error())
 			return
 		}
 		raw, err := rpc.BrclientdUpsertFilter(r.Context(), body)
@@ -586,13 +566,12 @@ func BisonrelayFiltersHandler(w http.ResponseWriter, r *http.Request) {
 			if strings.Contains(err.Error(), "HTTP 400") {
 				status = http.StatusBadRequest
 			}
-			http.Error(w, err.Error(), status)
+			respondError(w, status, err.Error())
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -601,8 +580,8 @@ func BisonrelayFilterDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		ID uint64 `json:"id"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdDeleteFilter(r.Context(), req.ID); err != nil {
@@ -630,8 +609,7 @@ func BisonrelayKXListHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // BisonrelayContactHandshakeHandler proxies brclientd's /contacts/handshake.
@@ -670,8 +648,7 @@ func BisonrelayBlockedContactsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // BisonrelayContactUnblockHandler proxies brclientd's /contacts/unblock. Body:
@@ -688,8 +665,7 @@ func BisonrelayContactUnblockHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // BisonrelayClearHistoryHandler proxies brclientd's /history/pm/clear. Body:
@@ -717,8 +693,7 @@ func BisonrelayContactGroupsHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 		return
 	}
 	var req struct {
@@ -726,8 +701,8 @@ func BisonrelayContactGroupsHandler(w http.ResponseWriter, r *http.Request) {
 		ID     string `json:"id"`
 		Name   string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.Action == "create" {
@@ -736,8 +711,7 @@ func BisonrelayContactGroupsHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(raw)
+		respondJSON(w, http.StatusOK, json.RawMessage(raw))
 		return
 	}
 	if err := rpc.BrclientdContactGroupAction(r.Context(), req.Action, req.ID, req.Name); err != nil {
@@ -755,12 +729,12 @@ func BisonrelayContactGroupAssignHandler(w http.ResponseWriter, r *http.Request)
 		Group  string `json:"group"`
 		Pinned bool   `json:"pinned"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" {
-		http.Error(w, "uid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid is required")
 		return
 	}
 	if err := rpc.BrclientdContactGroupAssign(r.Context(), req.UID, req.Group, req.Pinned); err != nil {
@@ -776,8 +750,8 @@ func BisonrelayContactGroupSettingsHandler(w http.ResponseWriter, r *http.Reques
 	var req struct {
 		AutoArchiveDays int `json:"auto_archive_days"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdContactGroupSettings(r.Context(), req.AutoArchiveDays); err != nil {
@@ -809,12 +783,12 @@ func BisonrelayContactIgnoreHandler(w http.ResponseWriter, r *http.Request) {
 		UID    string `json:"uid"`
 		Ignore bool   `json:"ignore"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.UID) == "" {
-		http.Error(w, "uid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid is required")
 		return
 	}
 	if err := rpc.BrclientdIgnoreContact(r.Context(), req.UID, req.Ignore); err != nil {
@@ -831,12 +805,12 @@ func BisonrelayContactSuggestKXHandler(w http.ResponseWriter, r *http.Request) {
 		Invitee string `json:"invitee"`
 		Target  string `json:"target"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.Invitee == "" || req.Target == "" {
-		http.Error(w, "invitee and target are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invitee and target are required")
 		return
 	}
 	if err := rpc.BrclientdSuggestKX(r.Context(), req.Invitee, req.Target); err != nil {
@@ -854,12 +828,12 @@ func BisonrelayContactTransResetHandler(w http.ResponseWriter, r *http.Request)
 		Mediator string `json:"mediator"`
 		Target   string `json:"target"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.Mediator == "" || req.Target == "" {
-		http.Error(w, "mediator and target are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "mediator and target are required")
 		return
 	}
 	if err := rpc.BrclientdTransReset(r.Context(), req.Mediator, req.Target); err != nil {
@@ -920,12 +894,12 @@ func BisonrelayContactFetchPostHandler(w http.ResponseWriter, r *http.Request) {
 		UID string `json:"uid"`
 		PID string `json:"pid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.PID == "" {
-		http.Error(w, "uid and pid are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid are required")
 		return
 	}
 	if err := rpc.BrclientdFetchPost(r.Context(), req.UID, req.PID); err != nil {
@@ -940,7 +914,7 @@ func BisonrelayPostCommentsHandler(w http.ResponseWriter, r *http.Request) {
 	uid := strings.TrimSpace(r.URL.Query().Get("uid"))
 	pid := strings.TrimSpace(r.URL.Query().Get("pid"))
 	if uid == "" || pid == "" {
-		http.Error(w, "uid and pid query params are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid query params are required")
 		return
 	}
 	body, err := rpc.BrclientdPostComments(r.Context(), uid, pid)
@@ -948,8 +922,7 @@ func BisonrelayPostCommentsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(renderCommentSegments(body))
+	respondJSON(w, http.StatusOK, json.RawMessage(renderCommentSegments(body)))
 }
 
 // renderCommentSegments adds a rendered "segments" field to each comment,
@@ -1013,12 +986,12 @@ func BisonrelayPostCommentHandler(w http.ResponseWriter, r *http.Request) {
 		Comment string `json:"comment"`
 		Parent  string `json:"parent"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.PID == "" || strings.TrimSpace(req.Comment) == "" {
-		http.Error(w, "uid, pid, and comment are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid, pid, and comment are required")
 		return
 	}
 	identifier, err := rpc.BrclientdPostComment(r.Context(), req.UID, req.PID, req.Comment, req.Parent)
@@ -1026,8 +999,7 @@ func BisonrelayPostCommentHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"identifier": identifier})
+	respondJSON(w, http.StatusOK, map[string]string{"identifier": identifier})
 }
 
 // BisonrelayPostReceiveReceiptsHandler returns the receive receipts for one
@@ -1035,7 +1007,7 @@ func BisonrelayPostCommentHandler(w http.ResponseWriter, r *http.Request) {
 func BisonrelayPostReceiveReceiptsHandler(w http.ResponseWriter, r *http.Request) {
 	pid := strings.TrimSpace(r.URL.Query().Get("pid"))
 	if pid == "" {
-		http.Error(w, "pid query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pid query param is required")
 		return
 	}
 	body, err := rpc.BrclientdPostReceiveReceipts(r.Context(), pid)
@@ -1043,8 +1015,7 @@ func BisonrelayPostReceiveReceiptsHandler(w http.ResponseWriter, r *http.Request
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayPostRelayHandler relays a post to one user or to all of the
@@ -1055,12 +1026,12 @@ func BisonrelayPostRelayHandler(w http.ResponseWriter, r *http.Request) {
 		PID   string `json:"pid"`
 		ToUID string `json:"toUid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.PID == "" {
-		http.Error(w, "uid and pid are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid are required")
 		return
 	}
 	if err := rpc.BrclientdRelayPost(r.Context(), req.UID, req.PID, req.ToUID); err != nil {
@@ -1075,7 +1046,7 @@ func BisonrelayPostRelayHandler(w http.ResponseWriter, r *http.Request) {
 func BisonrelayPostCommentReceiptsHandler(w http.ResponseWriter, r *http.Request) {
 	pid := strings.TrimSpace(r.URL.Query().Get("pid"))
 	if pid == "" {
-		http.Error(w, "pid query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pid query param is required")
 		return
 	}
 	body, err := rpc.BrclientdPostCommentReceipts(r.Context(), pid)
@@ -1083,8 +1054,7 @@ func BisonrelayPostCommentReceiptsHandler(w http.ResponseWriter, r *http.Request
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayPostHeartsHandler returns the current heart count + my-own
@@ -1093,7 +1063,7 @@ func BisonrelayPostHeartsHandler(w http.ResponseWriter, r *http.Request) {
 	uid := strings.TrimSpace(r.URL.Query().Get("uid"))
 	pid := strings.TrimSpace(r.URL.Query().Get("pid"))
 	if uid == "" || pid == "" {
-		http.Error(w, "uid and pid query params are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid query params are required")
 		return
 	}
 	body, err := rpc.BrclientdPostHearts(r.Context(), uid, pid)
@@ -1101,8 +1071,7 @@ func BisonrelayPostHeartsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayPostHeartHandler toggles the local identity's heart on a post.
@@ -1112,12 +1081,12 @@ func BisonrelayPostHeartHandler(w http.ResponseWriter, r *http.Request) {
 		PID   string `json:"pid"`
 		Heart bool   `json:"heart"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.PID == "" {
-		http.Error(w, "uid and pid are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid are required")
 		return
 	}
 	if err := rpc.BrclientdPostHeart(r.Context(), req.UID, req.PID, req.Heart); err != nil {
@@ -1134,8 +1103,7 @@ func BisonrelaySharedFilesHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayManageAddHandler accepts a multipart upload from the browser
@@ -1146,7 +1114,7 @@ func BisonrelayManageAddHandler(w http.ResponseWriter, r *http.Request) {
 	const maxUpload = 200 << 20 // 200 MiB upper bound; BR can store larger via chunks
 	r.Body = http.MaxBytesReader(w, r.Body, maxUpload)
 	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		http.Error(w, "parse multipart: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "parse multipart: "+err.Error())
 		return
 	}
 	defer r.MultipartForm.RemoveAll()
@@ -1156,7 +1124,7 @@ func BisonrelayManageAddHandler(w http.ResponseWriter, r *http.Request) {
 	if costDCRStr != "" {
 		costDCR, err := strconv.ParseFloat(costDCRStr, 64)
 		if err != nil || costDCR < 0 {
-			http.Error(w, "invalid cost_dcr", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "invalid cost_dcr")
 			return
 		}
 		// BR shared-file costs are in atoms (1 DCR = 1e8), not the milli-atoms
@@ -1168,7 +1136,7 @@ func BisonrelayManageAddHandler(w http.ResponseWriter, r *http.Request) {
 
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "file part missing: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "file part missing: "+err.Error())
 		return
 	}
 	defer file.Close()
@@ -1178,8 +1146,7 @@ func BisonrelayManageAddHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayManageUnshareHandler removes a share. Body: {fid, target_uid?}.
@@ -1188,12 +1155,12 @@ func BisonrelayManageUnshareHandler(w http.ResponseWriter, r *http.Request) {
 		FID       string `json:"fid"`
 		TargetUID string `json:"target_uid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.FID == "" {
-		http.Error(w, "fid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "fid is required")
 		return
 	}
 	if err := rpc.BrclientdUnshareFile(r.Context(), req.FID, req.TargetUID); err != nil {
@@ -1210,8 +1177,7 @@ func BisonrelayManageDownloadsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayManageCancelDownloadHandler aborts an in-flight download.
@@ -1219,12 +1185,12 @@ func BisonrelayManageCancelDownloadHandler(w http.ResponseWriter, r *http.Reques
 	var req struct {
 		FID string `json:"fid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.FID == "" {
-		http.Error(w, "fid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "fid is required")
 		return
 	}
 	if err := rpc.BrclientdCancelDownload(r.Context(), req.FID); err != nil {
@@ -1244,16 +1210,16 @@ func BisonrelayManageDeleteDownloadHandler(w http.ResponseWriter, r *http.Reques
 		FID string `json:"fid"`
 		UID string `json:"uid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if !downloadIDRe.MatchString(req.FID) {
-		http.Error(w, "invalid fid", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid fid")
 		return
 	}
 	if req.UID != "" && !downloadIDRe.MatchString(req.UID) {
-		http.Error(w, "invalid uid", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid uid")
 		return
 	}
 	if err := rpc.BrclientdDeleteDownload(r.Context(), req.FID, req.UID); err != nil {
@@ -1270,8 +1236,7 @@ func BisonrelayStatsOverviewHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStatsPaymentsHandler proxies brclientd's /stats/payments.
@@ -1281,8 +1246,7 @@ func BisonrelayStatsPaymentsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStatsNetworkHandler proxies brclientd's /stats/network.
@@ -1292,8 +1256,7 @@ func BisonrelayStatsNetworkHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStatsContactsHandler proxies brclientd's /stats/contacts.
@@ -1303,8 +1266,7 @@ func BisonrelayStatsContactsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStatsPostsHandler proxies brclientd's /stats/posts.
@@ -1314,8 +1276,7 @@ func BisonrelayStatsPostsHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // ---- RTDT realtime-voice control plane ----------------------------------
@@ -1327,8 +1288,7 @@ func BisonrelayRTDTListHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayRTDTCreateHandler creates a fresh session.
@@ -1337,8 +1297,8 @@ func BisonrelayRTDTCreateHandler(w http.ResponseWriter, r *http.Request) {
 		Size        uint16 `json:"size"`
 		Description string `json:"description"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	body, err := rpc.BrclientdRTDTCreate(r.Context(), req.Size, req.Description)
@@ -1346,8 +1306,7 @@ func BisonrelayRTDTCreateHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayRTDTCreateInstantHandler creates an instant call.
@@ -1355,8 +1314,8 @@ func BisonrelayRTDTCreateInstantHandler(w http.ResponseWriter, r *http.Request)
 	var req struct {
 		UIDs []string `json:"uids"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	body, err := rpc.BrclientdRTDTCreateInstant(r.Context(), req.UIDs)
@@ -1364,8 +1323,7 @@ func BisonrelayRTDTCreateInstantHandler(w http.ResponseWriter, r *http.Request)
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayRTDTInviteHandler invites users to an existing session.
@@ -1375,8 +1333,8 @@ func BisonrelayRTDTInviteHandler(w http.ResponseWriter, r *http.Request) {
 		UIDs        []string `json:"uids"`
 		AsPublisher bool     `json:"as_publisher"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdRTDTInvite(r.Context(), rv, req.UIDs, req.AsPublisher); err != nil {
@@ -1393,8 +1351,8 @@ func BisonrelayRTDTAcceptHandler(w http.ResponseWriter, r *http.Request) {
 		Inviter     string `json:"inviter"`
 		AsPublisher bool   `json:"as_publisher"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdRTDTAccept(r.Context(), rv, req.Inviter, req.AsPublisher); err != nil {
@@ -1441,8 +1399,8 @@ func BisonrelayRTDTKickHandler(w http.ResponseWriter, r *http.Request) {
 		PeerID     uint32 `json:"peer_id"`
 		BanSeconds int64  `json:"ban_seconds"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdRTDTKick(r.Context(), rv, req.PeerID, req.BanSeconds); err != nil {
@@ -1459,8 +1417,8 @@ func BisonrelayRTDTRemoveHandler(w http.ResponseWriter, r *http.Request) {
 		UID    string `json:"uid"`
 		Reason string `json:"reason"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdRTDTRemove(r.Context(), rv, req.UID, req.Reason); err != nil {
@@ -1489,8 +1447,8 @@ func BisonrelayPostsRenderHandler(w http.ResponseWriter, r *http.Request) {
 		Post  string `json:"post"`
 		Title string `json:"title"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	segments := services.SplitAndRenderBRPostBody(req.Post)
@@ -1499,8 +1457,7 @@ func BisonrelayPostsRenderHandler(w http.ResponseWriter, r *http.Request) {
 		"markdown": req.Post,
 		"segments": segments,
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // BisonrelayPagesRenderHandler renders draft page markdown into structured
@@ -1511,12 +1468,11 @@ func BisonrelayPagesRenderHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Markdown string `json:"markdown"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]any{
+	respondJSON(w, http.StatusOK, map[string]any{
 		"markdown": req.Markdown,
 		"segments": services.SplitAndRenderBRPage(req.Markdown),
 	})
@@ -1528,12 +1484,12 @@ func BisonrelayPostsNewHandler(w http.ResponseWriter, r *http.Request) {
 		Post  string `json:"post"`
 		Descr string `json:"descr"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.Post) == "" {
-		http.Error(w, "post body is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "post body is required")
 		return
 	}
 	body, err := rpc.BrclientdCreatePost(r.Context(), req.Post, req.Descr)
@@ -1541,8 +1497,7 @@ func BisonrelayPostsNewHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayPostsFeedHandler returns the local list of all received posts
@@ -1553,8 +1508,7 @@ func BisonrelayPostsFeedHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayPostBodyHandler fetches a single post's full body, splits it
@@ -1565,7 +1519,7 @@ func BisonrelayPostBodyHandler(w http.ResponseWriter, r *http.Request) {
 	uid := strings.TrimSpace(r.URL.Query().Get("uid"))
 	pid := strings.TrimSpace(r.URL.Query().Get("pid"))
 	if uid == "" || pid == "" {
-		http.Error(w, "uid and pid query params are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid query params are required")
 		return
 	}
 	body, err := rpc.BrclientdPostBody(r.Context(), uid, pid)
@@ -1578,7 +1532,7 @@ func BisonrelayPostBodyHandler(w http.ResponseWriter, r *http.Request) {
 		Attributes map[string]string `json:"attributes"`
 	}
 	if err := json.Unmarshal(body, &pm); err != nil {
-		http.Error(w, "decode post: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode post: "+err.Error())
 		return
 	}
 	mainMD := pm.Attributes["main"]
@@ -1590,8 +1544,7 @@ func BisonrelayPostBodyHandler(w http.ResponseWriter, r *http.Request) {
 		"segments":   segments,
 		"attributes": pm.Attributes,
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // maxResolvedQuotes bounds how many quote embeds one post body resolves.
@@ -1646,12 +1599,12 @@ func BisonrelayPagesFetchHandler(w http.ResponseWriter, r *http.Request) {
 		FieldTypes    map[string]string `json:"field_types,omitempty"`
 		AsyncTargetID string            `json:"async_target_id,omitempty"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.UID) == "" {
-		http.Error(w, "uid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid is required")
 		return
 	}
 	// Coerce submitted form values to the JSON types the resource handler
@@ -1676,7 +1629,7 @@ func BisonrelayPagesFetchHandler(w http.ResponseWriter, r *http.Request) {
 		AsyncTargetID string            `json:"async_target_id"`
 	}
 	if err := json.Unmarshal(body, &fetched); err != nil {
-		http.Error(w, "decode page reply: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode page reply: "+err.Error())
 		return
 	}
 	out := map[string]any{
@@ -1689,8 +1642,7 @@ func BisonrelayPagesFetchHandler(w http.ResponseWriter, r *http.Request) {
 		"async_target_id": fetched.AsyncTargetID,
 		"segments":        services.SplitAndRenderBRPage(fetched.Markdown),
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // sanitizeBRFormData coerces a page form's submitted values to the JSON types
@@ -1754,8 +1706,7 @@ func BisonrelayPagesLocalListHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // safeBRPath reports whether a brclientd-bound page/template/store name or path
@@ -1794,11 +1745,11 @@ func safeStoreMediaPath(p string) bool {
 func BisonrelayPagesLocalFileHandler(w http.ResponseWriter, r *http.Request) {
 	name := strings.TrimSpace(r.URL.Query().Get("name"))
 	if name == "" {
-		http.Error(w, "name query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "name query param is required")
 		return
 	}
 	if !safeBRPath(name) {
-		http.Error(w, "invalid name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid name")
 		return
 	}
 	body, err := rpc.BrclientdPagesLocalFile(r.Context(), name)
@@ -1806,8 +1757,7 @@ func BisonrelayPagesLocalFileHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayPagesLocalSaveHandler creates or overwrites one hosted page.
@@ -1817,12 +1767,12 @@ func BisonrelayPagesLocalSaveHandler(w http.ResponseWriter, r *http.Request) {
 		Name    string `json:"name"`
 		Content string `json:"content"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if !safeBRPath(strings.TrimSpace(req.Name)) {
-		http.Error(w, "invalid name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid name")
 		return
 	}
 	if err := rpc.BrclientdPagesLocalSave(r.Context(), req); err != nil {
@@ -1837,12 +1787,12 @@ func BisonrelayPagesLocalDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if !safeBRPath(strings.TrimSpace(req.Name)) {
-		http.Error(w, "invalid name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid name")
 		return
 	}
 	if err := rpc.BrclientdPagesLocalDelete(r.Context(), req); err != nil {
@@ -1865,12 +1815,12 @@ func BisonrelayContentGetHandler(w http.ResponseWriter, r *http.Request) {
 		FID          string `json:"fid"`
 		MaxCostAtoms uint64 `json:"maxCostAtoms"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.FID == "" {
-		http.Error(w, "uid and fid are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and fid are required")
 		return
 	}
 	if err := rpc.BrclientdContentGet(r.Context(), req.UID, req.FID, req.MaxCostAtoms); err != nil {
@@ -1888,7 +1838,7 @@ func BisonrelayContentFileHandler(w http.ResponseWriter, r *http.Request) {
 	fid := strings.TrimSpace(r.URL.Query().Get("fid"))
 	uid := strings.TrimSpace(r.URL.Query().Get("uid"))
 	if fid == "" {
-		http.Error(w, "fid query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "fid query param is required")
 		return
 	}
 	resp, err := rpc.BrclientdContentFile(r.Context(), uid, fid)
@@ -1899,7 +1849,7 @@ func BisonrelayContentFileHandler(w http.ResponseWriter, r *http.Request) {
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
-		http.Error(w, string(body), resp.StatusCode)
+		respondError(w, resp.StatusCode, string(body))
 		return
 	}
 	for _, h := range []string{"Content-Type", "Content-Disposition", "Content-Length"} {
@@ -1924,14 +1874,14 @@ func BisonrelayPostsEmbedDataHandler(w http.ResponseWriter, r *http.Request) {
 	uid := strings.TrimSpace(r.URL.Query().Get("uid"))
 	pid := strings.TrimSpace(r.URL.Query().Get("pid"))
 	if uid == "" || pid == "" {
-		http.Error(w, "uid and pid query params are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and pid query params are required")
 		return
 	}
 	index := 0
 	if idxStr := strings.TrimSpace(r.URL.Query().Get("index")); idxStr != "" {
 		n, err := strconv.Atoi(idxStr)
 		if err != nil || n < 0 {
-			http.Error(w, "invalid index", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "invalid index")
 			return
 		}
 		index = n
@@ -1944,7 +1894,7 @@ func BisonrelayPostsEmbedDataHandler(w http.ResponseWriter, r *http.Request) {
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
-		http.Error(w, string(body), resp.StatusCode)
+		respondError(w, resp.StatusCode, string(body))
 		return
 	}
 	for _, h := range []string{"Content-Type", "Content-Length", "Cache-Control"} {
@@ -2017,8 +1967,7 @@ func BisonrelayBackupPrepareHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	st := brBackupStatusLocked()
 	brBackupMu.Unlock()
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(st)
+	respondJSON(w, http.StatusOK, st)
 }
 
 // BisonrelayBackupStatusHandler reports the prepared-backup slot so the UI
@@ -2027,8 +1976,7 @@ func BisonrelayBackupStatusHandler(w http.ResponseWriter, r *http.Request) {
 	brBackupMu.Lock()
 	st := brBackupStatusLocked()
 	brBackupMu.Unlock()
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(st)
+	respondJSON(w, http.StatusOK, st)
 }
 
 // runBrBackupPrepare fetches the tarball from brclientd's /backup and spools
@@ -2135,14 +2083,14 @@ func BisonrelayBackupHandler(w http.ResponseWriter, r *http.Request) {
 	brBackupMu.Lock()
 	if brBackupState != "ready" {
 		brBackupMu.Unlock()
-		http.Error(w, "no backup prepared", http.StatusConflict)
+		respondError(w, http.StatusConflict, "no backup prepared")
 		return
 	}
 	f, err := os.Open(brBackupPath)
 	filename, ctype, modtime := brBackupFilename, brBackupCType, brBackupReadyAt
 	brBackupMu.Unlock()
 	if err != nil {
-		http.Error(w, "open backup: "+err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "open backup: "+err.Error())
 		return
 	}
 	defer f.Close()
@@ -2163,12 +2111,12 @@ func BisonrelayRestoreBackupHandler(w http.ResponseWriter, r *http.Request) {
 	r.Body = http.MaxBytesReader(w, r.Body, maxUpload)
 	mr, err := r.MultipartReader()
 	if err != nil {
-		http.Error(w, "parse multipart: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "parse multipart: "+err.Error())
 		return
 	}
 	part, err := mr.NextPart()
 	if err != nil {
-		http.Error(w, "read multipart: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "read multipart: "+err.Error())
 		return
 	}
 	defer part.Close()
@@ -2187,8 +2135,7 @@ func BisonrelayRatesHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStoreModeHandler proxies brclientd's /store/mode. GET returns the
@@ -2201,13 +2148,12 @@ func BisonrelayStoreModeHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(body)
+		respondJSON(w, http.StatusOK, json.RawMessage(body))
 		return
 	}
 	var req map[string]any
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	body, err := rpc.BrclientdSetStoreMode(r.Context(), req)
@@ -2215,8 +2161,7 @@ func BisonrelayStoreModeHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStoreProductsHandler proxies brclientd's /store/products: GET lists
@@ -2228,13 +2173,12 @@ func BisonrelayStoreProductsHandler(w http.ResponseWriter, r *http.Request) {
 			brWriteErr(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write(body)
+		respondJSON(w, http.StatusOK, json.RawMessage(body))
 		return
 	}
 	var req map[string]any
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdSaveStoreProduct(r.Context(), req); err != nil {
@@ -2249,12 +2193,12 @@ func BisonrelayStoreProductDeleteHandler(w http.ResponseWriter, r *http.Request)
 	var req struct {
 		SKU string `json:"sku"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.SKU == "" {
-		http.Error(w, "sku is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "sku is required")
 		return
 	}
 	if err := rpc.BrclientdDeleteStoreProduct(r.Context(), req.SKU); err != nil {
@@ -2272,8 +2216,7 @@ func BisonrelayStoreOrdersHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStoreOrderStatusHandler updates one order's status. Body:
@@ -2284,12 +2227,12 @@ func BisonrelayStoreOrderStatusHandler(w http.ResponseWriter, r *http.Request) {
 		ID     uint64 `json:"id"`
 		Status string `json:"status"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.Status == "" {
-		http.Error(w, "uid and status are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and status are required")
 		return
 	}
 	if err := rpc.BrclientdSetStoreOrderStatus(r.Context(), req.UID, req.ID, req.Status); err != nil {
@@ -2307,8 +2250,7 @@ func BisonrelayStoreTemplatesHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStoreTemplateFileHandler returns one template's content. Query:
@@ -2316,11 +2258,11 @@ func BisonrelayStoreTemplatesHandler(w http.ResponseWriter, r *http.Request) {
 func BisonrelayStoreTemplateFileHandler(w http.ResponseWriter, r *http.Request) {
 	name := strings.TrimSpace(r.URL.Query().Get("name"))
 	if name == "" {
-		http.Error(w, "name query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "name query param is required")
 		return
 	}
 	if !safeBRPath(name) {
-		http.Error(w, "invalid name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid name")
 		return
 	}
 	body, err := rpc.BrclientdStoreTemplateFile(r.Context(), name)
@@ -2328,8 +2270,7 @@ func BisonrelayStoreTemplateFileHandler(w http.ResponseWriter, r *http.Request)
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStoreTemplateSaveHandler writes a template. Body: {name, content}.
@@ -2338,12 +2279,12 @@ func BisonrelayStoreTemplateSaveHandler(w http.ResponseWriter, r *http.Request)
 		Name    string `json:"name"`
 		Content string `json:"content"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if !safeBRPath(strings.TrimSpace(req.Name)) {
-		http.Error(w, "invalid name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid name")
 		return
 	}
 	if err := rpc.BrclientdSaveStoreTemplate(r.Context(), req); err != nil {
@@ -2358,12 +2299,12 @@ func BisonrelayStoreTemplateDeleteHandler(w http.ResponseWriter, r *http.Request
 	var req struct {
 		Name string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if !safeBRPath(strings.TrimSpace(req.Name)) {
-		http.Error(w, "invalid name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid name")
 		return
 	}
 	if err := rpc.BrclientdDeleteStoreTemplate(r.Context(), req.Name); err != nil {
@@ -2381,8 +2322,7 @@ func BisonrelayStoreFilesListHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayStoreFileGetHandler streams one store file (image preview or
@@ -2390,11 +2330,11 @@ func BisonrelayStoreFilesListHandler(w http.ResponseWriter, r *http.Request) {
 func BisonrelayStoreFileGetHandler(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimSpace(r.URL.Query().Get("path"))
 	if path == "" {
-		http.Error(w, "path query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "path query param is required")
 		return
 	}
 	if !safeStoreMediaPath(path) {
-		http.Error(w, "invalid path", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid path")
 		return
 	}
 	data, ctype, err := rpc.BrclientdGetStoreFile(r.Context(), path)
@@ -2415,13 +2355,13 @@ func BisonrelayStoreFileDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Path string `json:"path"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	p := strings.TrimSpace(req.Path)
 	if p == "" || !safeStoreMediaPath(p) {
-		http.Error(w, "invalid path", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid path")
 		return
 	}
 	if err := rpc.BrclientdDeleteStoreFile(r.Context(), p); err != nil {
@@ -2439,12 +2379,12 @@ func BisonrelayStoreOrderCommentHandler(w http.ResponseWriter, r *http.Request)
 		ID      uint64 `json:"id"`
 		Comment string `json:"comment"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" || req.Comment == "" {
-		http.Error(w, "uid and comment are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid and comment are required")
 		return
 	}
 	if err := rpc.BrclientdAddStoreOrderComment(r.Context(), req.UID, req.ID, req.Comment); err != nil {
@@ -2461,7 +2401,7 @@ func BisonrelayStoreFileUploadHandler(w http.ResponseWriter, r *http.Request) {
 	const maxUpload = 200 << 20
 	r.Body = http.MaxBytesReader(w, r.Body, maxUpload)
 	if err := r.ParseMultipartForm(64 << 20); err != nil {
-		http.Error(w, "parse multipart: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "parse multipart: "+err.Error())
 		return
 	}
 	defer r.MultipartForm.RemoveAll()
@@ -2469,16 +2409,16 @@ func BisonrelayStoreFileUploadHandler(w http.ResponseWriter, r *http.Request) {
 	overwrite := r.FormValue("overwrite") == "true"
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "file part missing: "+err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "file part missing: "+err.Error())
 		return
 	}
 	defer file.Close()
 	if relPath != "" && !safeStoreMediaPath(relPath) {
-		http.Error(w, "invalid path", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid path")
 		return
 	}
 	if strings.ContainsRune(header.Filename, '/') || !safeStoreMediaPath(header.Filename) {
-		http.Error(w, "invalid file name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid file name")
 		return
 	}
 	mime := header.Header.Get("Content-Type")
@@ -2487,8 +2427,7 @@ func BisonrelayStoreFileUploadHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayContactListContentHandler proxies the brclientd list-content
@@ -2516,16 +2455,16 @@ func BisonrelayContactTipHandler(w http.ResponseWriter, r *http.Request) {
 		DCRAmount   float64 `json:"dcrAmount"`
 		MaxAttempts int32   `json:"maxAttempts"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.UID == "" {
-		http.Error(w, "uid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid is required")
 		return
 	}
 	if req.DCRAmount <= 0 {
-		http.Error(w, "dcrAmount must be positive", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "dcrAmount must be positive")
 		return
 	}
 	if req.MaxAttempts <= 0 {
@@ -2545,12 +2484,12 @@ func BisonrelayContactAcceptSuggestionHandler(w http.ResponseWriter, r *http.Req
 		Mediator string `json:"mediator"`
 		Target   string `json:"target"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.Mediator == "" || req.Target == "" {
-		http.Error(w, "mediator and target are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "mediator and target are required")
 		return
 	}
 	if err := rpc.BrclientdAcceptSuggestion(r.Context(), req.Mediator, req.Target); err != nil {
@@ -2566,12 +2505,12 @@ func decodeBisonrelayUIDBody(w http.ResponseWriter, r *http.Request) (string, bo
 	var req struct {
 		UID string `json:"uid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return "", false
 	}
 	if req.UID == "" {
-		http.Error(w, "uid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "uid is required")
 		return "", false
 	}
 	return req.UID, true
@@ -2597,18 +2536,18 @@ func BisonrelayPMHandler(w http.ResponseWriter, r *http.Request) {
 			DataB64 string `json:"data_b64"`
 		} `json:"embed,omitempty"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	req.User = strings.TrimSpace(req.User)
 	req.Msg = strings.TrimSpace(req.Msg)
 	if req.User == "" {
-		http.Error(w, "user is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "user is required")
 		return
 	}
 	if req.Embed == nil && req.Msg == "" {
-		http.Error(w, "msg or embed is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "msg or embed is required")
 		return
 	}
 
@@ -2616,11 +2555,11 @@ func BisonrelayPMHandler(w http.ResponseWriter, r *http.Request) {
 	if req.Embed != nil {
 		decoded, err := base64.StdEncoding.DecodeString(req.Embed.DataB64)
 		if err != nil {
-			http.Error(w, "embed data_b64: "+err.Error(), http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "embed data_b64: "+err.Error())
 			return
 		}
 		if len(decoded) > maxInlineEmbedBytes {
-			http.Error(w, "embed exceeds inline size cap", http.StatusRequestEntityTooLarge)
+			respondError(w, http.StatusRequestEntityTooLarge, "embed exceeds inline size cap")
 			return
 		}
 		tag := buildEmbedTag(req.Embed.Name, req.Embed.Mime, req.Embed.DataB64)
@@ -2635,8 +2574,7 @@ func BisonrelayPMHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"body": body})
+	respondJSON(w, http.StatusOK, map[string]string{"body": body})
 }
 
 // buildEmbedTag renders bruig's --embed[...]-- tag. Field order mirrors
@@ -2670,8 +2608,7 @@ func BisonrelayInviteWriteHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{
+	respondJSON(w, http.StatusOK, map[string]string{
 		"invite_bytes": result.InviteBytes,
 		"invite_key":   result.InviteKey,
 	})
@@ -2687,8 +2624,8 @@ func BisonrelayInviteAcceptHandler(w http.ResponseWriter, r *http.Request) {
 		Invite      string `json:"invite"`
 		InviteBytes string `json:"invite_bytes"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	value := strings.TrimSpace(req.Invite)
@@ -2696,7 +2633,7 @@ func BisonrelayInviteAcceptHandler(w http.ResponseWriter, r *http.Request) {
 		value = strings.TrimSpace(req.InviteBytes)
 	}
 	if value == "" {
-		http.Error(w, "invite is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invite is required")
 		return
 	}
 
@@ -2714,8 +2651,7 @@ func BisonrelayInviteAcceptHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayMessagesHandler proxies brclientd's /history/pm endpoint. Query
@@ -2726,7 +2662,7 @@ func BisonrelayMessagesHandler(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	contact := strings.TrimSpace(q.Get("contact"))
 	if contact == "" {
-		http.Error(w, "contact query param is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "contact query param is required")
 		return
 	}
 	page := 0
@@ -2746,8 +2682,7 @@ func BisonrelayMessagesHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelaySetupHandler proxies a nick/name pair to brclientd's pre-setup
@@ -2759,21 +2694,21 @@ func BisonrelaySetupHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if ready, reason := services.WalletReady(r.Context()); !ready {
-		http.Error(w, reason, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, reason)
 		return
 	}
 	var req struct {
 		Nick string `json:"nick"`
 		Name string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	req.Nick = strings.TrimSpace(req.Nick)
 	req.Name = strings.TrimSpace(req.Name)
 	if req.Nick == "" {
-		http.Error(w, "nick is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "nick is required")
 		return
 	}
 	if err := rpc.BrclientdCreateIdentity(r.Context(), req.Nick, req.Name); err != nil {
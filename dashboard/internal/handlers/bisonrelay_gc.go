@@ -23,8 +23,7 @@ func BisonrelayGCListHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayGCCreateHandler creates a new GC.
@@ -32,12 +31,12 @@ func BisonrelayGCCreateHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "name is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "name is required")
 		return
 	}
 	body, err := rpc.BrclientdGCCreate(r.Context(), req.Name)
@@ -45,8 +44,7 @@ func BisonrelayGCCreateHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayGCInvitesListHandler lists pending GC invites for the local user.
@@ -56,8 +54,7 @@ func BisonrelayGCInvitesListHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayGCInvitesAcceptHandler accepts an invite by IID.
@@ -65,12 +62,12 @@ func BisonrelayGCInvitesAcceptHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		IID uint64 `json:"iid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.IID == 0 {
-		http.Error(w, "iid is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "iid is required")
 		return
 	}
 	if err := rpc.BrclientdGCInvitesAccept(r.Context(), req.IID); err != nil {
@@ -88,8 +85,7 @@ func BisonrelayGCDetailHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayGCInviteHandler invites a contact to a GC.
@@ -98,8 +94,8 @@ func BisonrelayGCInviteHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UID string `json:"uid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCInvite(r.Context(), gcid, req.UID); err != nil {
@@ -125,13 +121,13 @@ func BisonrelayGCMessageHandler(w http.ResponseWriter, r *http.Request) {
 			DataB64 string `json:"data_b64"`
 		} `json:"embed,omitempty"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	req.Msg = strings.TrimSpace(req.Msg)
 	if req.Embed == nil && req.Msg == "" {
-		http.Error(w, "msg or embed is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "msg or embed is required")
 		return
 	}
 
@@ -139,11 +135,11 @@ func BisonrelayGCMessageHandler(w http.ResponseWriter, r *http.Request) {
 	if req.Embed != nil {
 		decoded, err := base64.StdEncoding.DecodeString(req.Embed.DataB64)
 		if err != nil {
-			http.Error(w, "embed data_b64: "+err.Error(), http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "embed data_b64: "+err.Error())
 			return
 		}
 		if len(decoded) > maxInlineEmbedBytes {
-			http.Error(w, "embed exceeds inline size cap", http.StatusRequestEntityTooLarge)
+			respondError(w, http.StatusRequestEntityTooLarge, "embed exceeds inline size cap")
 			return
 		}
 		tag := buildEmbedTag(req.Embed.Name, req.Embed.Mime, req.Embed.DataB64)
@@ -158,8 +154,7 @@ func BisonrelayGCMessageHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"body": body})
+	respondJSON(w, http.StatusOK, map[string]string{"body": body})
 }
 
 // BisonrelayGCHistoryHandler paginates GC message history.
@@ -172,8 +167,7 @@ func BisonrelayGCHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		brWriteErr(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(body)
+	respondJSON(w, http.StatusOK, json.RawMessage(body))
 }
 
 // BisonrelayGCPartHandler leaves a GC (non-owner action).
@@ -182,7 +176,10 @@ func BisonrelayGCPartHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Reason string `json:"reason"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&req)
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
 	if err := rpc.BrclientdGCPart(r.Context(), gcid, req.Reason); err != nil {
 		brWriteErr(w, err)
 		return
@@ -196,7 +193,10 @@ func BisonrelayGCKillHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Reason string `json:"reason"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&req)
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
 	if err := rpc.BrclientdGCKill(r.Context(), gcid, req.Reason); err != nil {
 		brWriteErr(w, err)
 		return
@@ -211,8 +211,8 @@ func BisonrelayGCKickHandler(w http.ResponseWriter, r *http.Request) {
 		UID    string `json:"uid"`
 		Reason string `json:"reason"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCKick(r.Context(), gcid, req.UID, req.Reason); err != nil {
@@ -228,8 +228,8 @@ func BisonrelayGCBlockHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UID string `json:"uid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCBlock(r.Context(), gcid, req.UID); err != nil {
@@ -245,8 +245,8 @@ func BisonrelayGCUnblockHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UID string `json:"uid"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCUnblock(r.Context(), gcid, req.UID); err != nil {
@@ -263,8 +263,8 @@ func BisonrelayGCAdminsHandler(w http.ResponseWriter, r *http.Request) {
 		ExtraAdmins []string `json:"extra_admins"`
 		Reason      string   `json:"reason"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCModifyAdmins(r.Context(), gcid, req.ExtraAdmins, req.Reason); err != nil {
@@ -281,8 +281,8 @@ func BisonrelayGCOwnerHandler(w http.ResponseWriter, r *http.Request) {
 		NewOwner string `json:"new_owner"`
 		Reason   string `json:"reason"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCModifyOwner(r.Context(), gcid, req.NewOwner, req.Reason); err != nil {
@@ -298,8 +298,8 @@ func BisonrelayGCUpgradeHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		NewVersion uint8 `json:"new_version"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCUpgrade(r.Context(), gcid, req.NewVersion); err != nil {
@@ -315,8 +315,8 @@ func BisonrelayGCAliasHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Alias string `json:"alias"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if err := rpc.BrclientdGCAlias(r.Context(), gcid, req.Alias); err != nil {
@@ -332,7 +332,10 @@ func BisonrelayGCResendListHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UID string `json:"uid"`
 	}
-	_ = json.NewDecoder(r.Body).Decode(&req)
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
 	if err := rpc.BrclientdGCResendList(r.Context(), gcid, req.UID); err != nil {
 		brWriteErr(w, err)
 		return
@@ -17,11 +17,6 @@ import (
 // BR-MCP). brclientd speaks atoms; the frontend speaks DCR, converted here
 // via dcrutil.
 
-func brMCPJSON(w http.ResponseWriter, v any) {
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(v)
-}
-
 // brMCPSettingsWire mirrors brclientd's mcpclient.json shape. The listener
 // address is not here - it is brclientd startup config (mcplisten), not a
 // runtime setting.
@@ -75,24 +70,24 @@ func BisonrelayMCPSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		var wire brMCPSettingsWire
 		if err := json.Unmarshal(raw, &wire); err != nil {
-			http.Error(w, "parse settings: "+err.Error(), http.StatusBadGateway)
+			respondError(w, http.StatusBadGateway, "parse settings: "+err.Error())
 			return
 		}
-		brMCPJSON(w, brMCPSettingsToView(wire))
+		respondJSON(w, http.StatusOK, brMCPSettingsToView(wire))
 	case http.MethodPost:
 		var view brMCPSettingsView
-		if err := json.NewDecoder(r.Body).Decode(&view); err != nil {
-			http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+		if err := decodeJSON(r, &view); err != nil {
+			respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 			return
 		}
 		perCall, err := dcrutil.NewAmount(view.PerCallCapDcr)
 		if err != nil || perCall < 0 {
-			http.Error(w, "invalid per-call cap", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "invalid per-call cap")
 			return
 		}
 		perDay, err := dcrutil.NewAmount(view.PerDayCapDcr)
 		if err != nil || perDay < 0 {
-			http.Error(w, "invalid per-day cap", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "invalid per-day cap")
 			return
 		}
 		wire := brMCPSettingsWire{
@@ -112,12 +107,12 @@ func BisonrelayMCPSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		var applied brMCPSettingsWire
 		if err := json.Unmarshal(raw, &applied); err != nil {
-			http.Error(w, "parse settings: "+err.Error(), http.StatusBadGateway)
+			respondError(w, http.StatusBadGateway, "parse settings: "+err.Error())
 			return
 		}
-		brMCPJSON(w, brMCPSettingsToView(applied))
+		respondJSON(w, http.StatusOK, brMCPSettingsToView(applied))
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -138,7 +133,7 @@ func BisonrelayMCPPendingHandler(w http.ResponseWriter, r *http.Request) {
 		} `json:"pending"`
 	}
 	if err := json.Unmarshal(raw, &wire); err != nil {
-		http.Error(w, "parse pending: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "parse pending: "+err.Error())
 		return
 	}
 	type entry struct {
@@ -156,7 +151,7 @@ func BisonrelayMCPPendingHandler(w http.ResponseWriter, r *http.Request) {
 			Created:   p.Created,
 		})
 	}
-	brMCPJSON(w, struct {
+	respondJSON(w, http.StatusOK, struct {
 		Pending []entry `json:"pending"`
 	}{Pending: out})
 }
@@ -167,12 +162,12 @@ func BisonrelayMCPResolvePendingHandler(w http.ResponseWriter, r *http.Request)
 		ID      string `json:"id"`
 		Approve bool   `json:"approve"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "decode body: "+err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "decode body: "+err.Error())
 		return
 	}
 	if req.ID == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "id is required")
 		return
 	}
 	if err := rpc.BrclientdMCPResolvePending(r.Context(), req.ID, req.Approve); err != nil {
@@ -202,7 +197,7 @@ func BisonrelayMCPSpendHandler(w http.ResponseWriter, r *http.Request) {
 		TodayAtoms int64 `json:"today_atoms"`
 	}
 	if err := json.Unmarshal(raw, &wire); err != nil {
-		http.Error(w, "parse spend: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "parse spend: "+err.Error())
 		return
 	}
 	type entry struct {
@@ -222,7 +217,7 @@ func BisonrelayMCPSpendHandler(w http.ResponseWriter, r *http.Request) {
 			Status:    e.Status, Err: e.Err,
 		})
 	}
-	brMCPJSON(w, struct {
+	respondJSON(w, http.StatusOK, struct {
 		Entries  []entry `json:"entries"`
 		TodayDcr float64 `json:"todayDcr"`
 	}{Entries: out, TodayDcr: dcrutil.Amount(wire.TodayAtoms).ToCoin()})
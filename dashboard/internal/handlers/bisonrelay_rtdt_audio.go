@@ -39,12 +39,12 @@ func BisonrelayRTDTAudioHandler(w http.ResponseWriter, r *http.Request) {
 	rv := mux.Vars(r)["rv"]
 	if rv == "" {
 		log.Printf("RTDT audio: missing rv in path %s", r.URL.Path)
-		http.Error(w, "missing session rv", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "missing session rv")
 		return
 	}
 	if !rtdtRVValid.MatchString(rv) {
 		log.Printf("RTDT audio: rejecting malformed rv %q", rv)
-		http.Error(w, "invalid session rv", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid session rv")
 		return
 	}
 	log.Printf("RTDT audio: upgrade request rv=%s origin=%q", rv, r.Header.Get("Origin"))
@@ -52,7 +52,7 @@ func BisonrelayRTDTAudioHandler(w http.ResponseWriter, r *http.Request) {
 	tlsCfg, baseURL, err := rpc.BrclientdWSDialer()
 	if err != nil {
 		log.Printf("RTDT audio: dialer config: %v", err)
-		http.Error(w, "brclientd dialer: "+err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "brclientd dialer: "+err.Error())
 		return
 	}
 	dialer := &websocket.Dialer{
@@ -69,11 +69,11 @@ func BisonrelayRTDTAudioHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if resp != nil {
 			log.Printf("RTDT audio: brclientd dial rv=%s HTTP %d", rv, resp.StatusCode)
-			http.Error(w, "brclientd /rtdt/audio: "+resp.Status, resp.StatusCode)
+			respondError(w, resp.StatusCode, "brclientd /rtdt/audio: "+resp.Status)
 			return
 		}
 		log.Printf("RTDT audio: brclientd dial rv=%s err=%v", rv, err)
-		http.Error(w, "brclientd dial: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "brclientd dial: "+err.Error())
 		return
 	}
 	defer upstream.Close()
@@ -87,6 +87,11 @@ func BisonrelayRTDTAudioHandler(w http.ResponseWriter, r *http.Request) {
 	defer browser.Close()
 	log.Printf("RTDT audio: browser WS upgraded rv=%s", rv)
 
+	// brclientd pings its own side; the keep-alive covers the browser leg,
+	// which is otherwise silent between speech bursts.
+	stopKeepAlive := armWSKeepAlive(browser)
+	defer stopKeepAlive()
+
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
 
@@ -128,7 +133,7 @@ func BisonrelayRTDTAudioHandler(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 			brWriteMu.Lock()
-			_ = browser.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			_ = browser.SetWriteDeadline(wsWriteDeadline())
 			err = browser.WriteMessage(websocket.BinaryMessage, data)
 			brWriteMu.Unlock()
 			if err != nil {
@@ -137,24 +142,5 @@ func BisonrelayRTDTAudioHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	// Heartbeat to both sides every 30s. brclientd already pings its
-	// own side; this ping is just to keep the browser <-> dashboard leg
-	// alive when audio is silent (no binary frames between speech
-	// bursts).
-	t := time.NewTicker(30 * time.Second)
-	defer t.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-t.C:
-			brWriteMu.Lock()
-			_ = browser.SetWriteDeadline(time.Now().Add(5 * time.Second))
-			err := browser.WriteMessage(websocket.PingMessage, nil)
-			brWriteMu.Unlock()
-			if err != nil {
-				return
-			}
-		}
-	}
+	<-ctx.Done()
 }
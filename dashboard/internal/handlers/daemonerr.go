@@ -6,10 +6,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"time"
 
+	"dcrpulse/internal/rpc"
 	"dcrpulse/internal/services"
 )
 
@@ -17,20 +19,37 @@ import (
 // error looks like the daemon being unreachable (down, still starting, or
 // running a startup database upgrade), it returns 503 with a friendly,
 // log-derived message so the UI can show a "starting" / "database upgrade in
-// progress" state instead of a raw 500. Genuine RPC errors from a responsive
-// daemon are returned as 500 unchanged, matching prior behavior.
+// progress" state instead of a raw 500. Other errors get the status
+// respondRPCError gives them.
 func respondDaemonError(w http.ResponseWriter, r *http.Request, component services.LogComponent, err error) {
-	if services.IsDaemonUnreachable(err) {
+	if errors.Is(err, rpc.ErrNotConnected) || services.IsDaemonUnreachable(err) {
 		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 		defer cancel()
 		hint := services.DaemonStartupHint(ctx, component)
 		if hint.Detail != "" {
 			log.Printf("%s unreachable (%s): %s", component, hint.State, hint.Detail)
 		}
-		http.Error(w, hint.Message, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, hint.Message)
 		return
 	}
-	http.Error(w, err.Error(), http.StatusInternalServerError)
+	respondRPCError(w, err)
+}
+
+// respondRPCError writes a failed dcrd or dcrwallet call by its class: 503
+// when the daemon couldn't be reached, 504 when the call or the request's
+// deadline timed out and 502 when the daemon answered with an error.
+// Anything else is a 500.
+func respondRPCError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, rpc.ErrNotConnected):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, rpc.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusGatewayTimeout
+	case errors.Is(err, rpc.ErrRPC):
+		status = http.StatusBadGateway
+	}
+	respondError(w, status, err.Error())
 }
 
 // respondUpstreamError writes the HTTP response for a failed call to an upstream
@@ -42,10 +61,10 @@ func respondUpstreamError(w http.ResponseWriter, component services.LogComponent
 	if services.IsDaemonUnreachable(err) {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
-		http.Error(w, services.DaemonStartupHint(ctx, component).Message, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, services.DaemonStartupHint(ctx, component).Message)
 		return
 	}
-	http.Error(w, err.Error(), http.StatusBadGateway)
+	respondError(w, http.StatusBadGateway, err.Error())
 }
 
 // dexWriteErr and brWriteErr are component-bound shortcuts for respondUpstreamError
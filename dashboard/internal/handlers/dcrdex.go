@@ -7,7 +7,6 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"log"
 	"math"
@@ -56,7 +55,6 @@ type DcrdexStatus struct {
 // GetDcrdexStatusHandler reports whether the bisonw RPC server is reachable and
 // its versions, via the version route (no app initialization required).
 func GetDcrdexStatusHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 
 	st := DcrdexStatus{Initialized: dcrdexInitialized(), SeedBackedUp: dcrdexSeedBackedUp()}
 	_, st.Unlocked = rpc.DcrdexAppPass()
@@ -68,7 +66,7 @@ func GetDcrdexStatusHandler(w http.ResponseWriter, r *http.Request) {
 		hctx, hcancel := context.WithTimeout(r.Context(), 2*time.Second)
 		st.Message = services.DaemonStartupHint(hctx, services.LogComponentDcrdex).Message
 		hcancel()
-		json.NewEncoder(w).Encode(st)
+		respondJSON(w, http.StatusOK, st)
 		return
 	}
 
@@ -80,7 +78,7 @@ func GetDcrdexStatusHandler(w http.ResponseWriter, r *http.Request) {
 		st.Stage = "unavailable"
 		st.Error = err.Error()
 		st.Message = services.DaemonStartupHint(ctx, services.LogComponentDcrdex).Message
-		json.NewEncoder(w).Encode(st)
+		respondJSON(w, http.StatusOK, st)
 		return
 	}
 
@@ -103,7 +101,7 @@ func GetDcrdexStatusHandler(w http.ResponseWriter, r *http.Request) {
 			st.Stage = "ready"
 		}
 	}
-	json.NewEncoder(w).Encode(st)
+	respondJSON(w, http.StatusOK, st)
 }
 
 // dexWalletCfg loads the active wallet's per-wallet config. DEX onboarding state
@@ -186,22 +184,25 @@ type dcrdexAuthRequest struct {
 // password (optionally restoring from a seed), logs in, and holds the password
 // in memory for the session. The password is never persisted.
 func InitDcrdexHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	if rejectWatchOnly(w, r) {
 		return
 	}
 	if ready, reason := services.WalletReady(r.Context()); !ready {
-		http.Error(w, reason, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, reason)
 		return
 	}
 	var req dcrdexAuthRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AppPass == "" {
-		http.Error(w, "appPass is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.AppPass == "" {
+		respondError(w, http.StatusBadRequest, "appPass is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -223,21 +224,24 @@ func InitDcrdexHandler(w http.ResponseWriter, r *http.Request) {
 	if err := setDcrdexSeedBackedUp(req.Seed != ""); err != nil {
 		log.Printf("dcrdex: persist seed-backed-up flag: %v", err)
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // UnlockDcrdexHandler logs the bisonw client in with the supplied app password
 // and holds it in memory for the session (used after a restart re-locks it).
 func UnlockDcrdexHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req dcrdexAuthRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AppPass == "" {
-		http.Error(w, "appPass is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.AppPass == "" {
+		respondError(w, http.StatusBadRequest, "appPass is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -247,13 +251,12 @@ func UnlockDcrdexHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rpc.SetDcrdexAppPass(req.AppPass)
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // LockDcrdexHandler logs the bisonw client out and forgets the in-memory app
 // password.
 func LockDcrdexHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	// Only forget the in-memory app password if bisonw actually locked. Logout
 	// refuses (and locks nothing) while any order is still active, so clearing
 	// the password regardless would leave the daemon - wallets, dex account and
@@ -262,12 +265,12 @@ func LockDcrdexHandler(w http.ResponseWriter, r *http.Request) {
 		ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 		defer cancel()
 		if err := client.Logout(ctx); err != nil {
-			http.Error(w, err.Error(), http.StatusConflict)
+			respondError(w, http.StatusConflict, err.Error())
 			return
 		}
 	}
 	rpc.ClearDcrdexAppPass()
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // CreateDcrdexWalletHandler configures DCRDEX's Decred wallet against the
@@ -275,33 +278,36 @@ func LockDcrdexHandler(w http.ResponseWriter, r *http.Request) {
 // it with the supplied wallet passphrase if missing), then registers the
 // dcrwalletRPC wallet in bisonw. Requires the DEX session to be unlocked.
 func CreateDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	if ready, reason := services.WalletReady(r.Context()); !ready {
-		http.Error(w, reason, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, reason)
 		return
 	}
 	var req struct {
 		WalletPass string `json:"walletPass"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.WalletPass == "" {
-		http.Error(w, "walletPass is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.WalletPass == "" {
+		respondError(w, http.StatusBadRequest, "walletPass is required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
 
 	if err := ensureDexAccount(ctx, []byte(req.WalletPass)); err != nil {
-		http.Error(w, "dex account: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "dex account: "+err.Error())
 		return
 	}
 
@@ -316,7 +322,7 @@ func CreateDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // ensureDexAccount makes sure the dedicated `dex` account exists in dcrwallet,
@@ -356,10 +362,9 @@ type DcrdexWalletInfo struct {
 // GetDcrdexWalletHandler returns the DCRDEX Decred wallet's available balance
 // (in DCR) and deposit address, so the user can fund it before posting a bond.
 func GetDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -379,12 +384,12 @@ func GetDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		} `json:"balance"`
 	}
 	if err := json.Unmarshal(raw, &states); err != nil {
-		http.Error(w, "decode wallets: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode wallets: "+err.Error())
 		return
 	}
 	for _, s := range states {
 		if s.AssetID == bisonw.AssetDCR {
-			json.NewEncoder(w).Encode(DcrdexWalletInfo{
+			respondJSON(w, http.StatusOK, DcrdexWalletInfo{
 				Configured:   true,
 				AvailableDcr: dcrutil.Amount(s.Balance.Available).ToCoin(),
 				Address:      s.Address,
@@ -394,15 +399,14 @@ func GetDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	json.NewEncoder(w).Encode(DcrdexWalletInfo{Configured: false})
+	respondJSON(w, http.StatusOK, DcrdexWalletInfo{Configured: false})
 }
 
 // GetDcrdexExchangesHandler returns the known/registered DEX servers (raw).
 func GetDcrdexExchangesHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -412,7 +416,7 @@ func GetDcrdexExchangesHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // atomsToConv converts an atomic amount to conventional units using the asset's
@@ -455,7 +459,6 @@ func dexAssetID(r *http.Request) uint32 {
 // client; the asset backend manages the address index and returns its next unused
 // one.
 func NewDexDepositAddressHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, appPass, ok := mmWebClient(w)
 	if !ok {
 		return
@@ -467,16 +470,15 @@ func NewDexDepositAddressHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]string{"address": addr})
+	respondJSON(w, http.StatusOK, map[string]string{"address": addr})
 }
 
 // DexAddressUsedHandler reports whether an address has already received funds,
 // used to warn against deposit-address reuse on the Wallets page.
 func DexAddressUsedHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	addr := r.URL.Query().Get("addr")
 	if addr == "" {
-		http.Error(w, "addr is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "addr is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -490,7 +492,7 @@ func DexAddressUsedHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"used": used})
+	respondJSON(w, http.StatusOK, map[string]bool{"used": used})
 }
 
 // DexWalletState is the funding view of a single DCRDEX-managed wallet. Balances
@@ -520,10 +522,9 @@ type DexWalletState struct {
 // GetDcrdexWalletsHandler returns the DCRDEX-managed wallets and their balances
 // (in conventional units), so the Wallets tab can show the funding picture.
 func GetDcrdexWalletsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -556,7 +557,7 @@ func GetDcrdexWalletsHandler(w http.ResponseWriter, r *http.Request) {
 		} `json:"balance"`
 	}
 	if err := json.Unmarshal(raw, &states); err != nil {
-		http.Error(w, "decode wallets: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode wallets: "+err.Error())
 		return
 	}
 	out := make([]DexWalletState, 0, len(states))
@@ -591,7 +592,7 @@ func GetDcrdexWalletsHandler(w http.ResponseWriter, r *http.Request) {
 		out = append(out, ws)
 	}
 	sort.Slice(out, func(i, j int) bool { return out[i].AssetID < out[j].AssetID })
-	json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // DexPendingBond is a bond awaiting confirmations.
@@ -645,15 +646,14 @@ type DexAccount struct {
 // GetDcrdexAccountHandler returns the account state (tier, reputation, bonds)
 // for the DEX server given in the `host` query parameter.
 func GetDcrdexAccountHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	host := r.URL.Query().Get("host")
 	if host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -697,12 +697,12 @@ func GetDcrdexAccountHandler(w http.ResponseWriter, r *http.Request) {
 		} `json:"auth"`
 	}
 	if err := json.Unmarshal(raw, &xcs); err != nil {
-		http.Error(w, "decode exchanges: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode exchanges: "+err.Error())
 		return
 	}
 	xc, ok := xcs[host]
 	if !ok {
-		http.Error(w, "unknown DEX host", http.StatusNotFound)
+		respondError(w, http.StatusNotFound, "unknown DEX host")
 		return
 	}
 	pending := make([]DexPendingBond, 0, len(xc.Auth.PendingBonds))
@@ -728,7 +728,7 @@ func GetDcrdexAccountHandler(w http.ResponseWriter, r *http.Request) {
 		bondSym = "dcr"
 	}
 	perTier := xc.BondAssets[bondSym]
-	json.NewEncoder(w).Encode(DexAccount{
+	respondJSON(w, http.StatusOK, DexAccount{
 		Host:               host,
 		AcctID:             xc.AcctID,
 		Registered:         xc.AcctID != "",
@@ -761,7 +761,6 @@ func GetDcrdexAccountHandler(w http.ResponseWriter, r *http.Request) {
 // is conventional and converted to atoms here (0 resets to the server default).
 // Requires the DEX session to be unlocked.
 func SetDcrdexBondOptionsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host         string   `json:"host"`
 		TargetTier   *int     `json:"targetTier"`
@@ -769,18 +768,22 @@ func SetDcrdexBondOptionsHandler(w http.ResponseWriter, r *http.Request) {
 		BondAssetID  *int     `json:"bondAssetID"`
 		PenaltyComps *int     `json:"penaltyComps"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" {
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 
@@ -822,7 +825,7 @@ func SetDcrdexBondOptionsHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // DexConfigResponse is the registration-screen view of a DEX server's config.
@@ -938,15 +941,14 @@ func cachedDEXConfig(ctx context.Context, client *bisonw.Client, host string) (j
 // bond requirements) for the host given in the `host` query parameter, with the
 // DCR bond amount converted to coins. Read-only; populates the registration screen.
 func GetDcrdexConfigHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	host := r.URL.Query().Get("host")
 	if host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	// Allow the DEX server ample time to answer the one-shot getdexconfig
@@ -990,7 +992,7 @@ func GetDcrdexConfigHandler(w http.ResponseWriter, r *http.Request) {
 		} `json:"assets"`
 	}
 	if err := json.Unmarshal(raw, &xc); err != nil {
-		http.Error(w, "decode dex config: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode dex config: "+err.Error())
 		return
 	}
 	convFactor := func(assetID uint32) uint64 {
@@ -1038,7 +1040,7 @@ func GetDcrdexConfigHandler(w http.ResponseWriter, r *http.Request) {
 	if cfgHost == "" {
 		cfgHost = host
 	}
-	json.NewEncoder(w).Encode(DexConfigResponse{
+	respondJSON(w, http.StatusOK, DexConfigResponse{
 		Host:             cfgHost,
 		ConnectionStatus: xc.ConnectionStatus,
 		Registered:       xc.AcctID != "",
@@ -1059,10 +1061,9 @@ func GetDcrdexConfigHandler(w http.ResponseWriter, r *http.Request) {
 // adds it to the bond when checking the deposit covers a bond post. The amount is
 // returned in the asset's conventional units.
 func GetDcrdexBondsFeeBufferHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	assetID, err := strconv.ParseUint(r.URL.Query().Get("assetID"), 10, 32)
 	if err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "assetID is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -1076,7 +1077,7 @@ func GetDcrdexBondsFeeBufferHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]float64{"feeBuffer": atomsToConv(raw, dexassets.ConvFactor(uint32(assetID)))})
+	respondJSON(w, http.StatusOK, map[string]float64{"feeBuffer": atomsToConv(raw, dexassets.ConvFactor(uint32(assetID)))})
 }
 
 // bondSubmitState tracks an in-flight async PostBond per DEX host. bisonw's
@@ -1108,25 +1109,28 @@ func setBondSubmit(host string, s bondSubmitState) {
 // after broadcast); confirmation progress arrives over /api/dcrdex/notify and a
 // pre-broadcast failure is exposed via /dcrdex/postbond/status.
 func PostDcrdexBondHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host         string `json:"host"`
 		Bond         uint64 `json:"bond"`
 		AssetID      uint32 `json:"assetID,omitempty"`
 		MaintainTier *bool  `json:"maintainTier,omitempty"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" || req.Bond == 0 {
-		http.Error(w, "host and bond are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" || req.Bond == 0 {
+		respondError(w, http.StatusBadRequest, "host and bond are required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	host := req.Host
@@ -1148,15 +1152,13 @@ func PostDcrdexBondHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		setBondSubmit(host, bondSubmitState{Phase: "broadcast"})
 	}()
-	w.WriteHeader(http.StatusAccepted)
-	_ = json.NewEncoder(w).Encode(map[string]bool{"accepted": true})
+	respondJSON(w, http.StatusAccepted, map[string]bool{"accepted": true})
 }
 
 // PostDcrdexBondStatusHandler reports the state of the most recent async
 // PostBond for a host (query param "host"), so the UI can surface a
 // pre-broadcast failure that bisonw does not emit as a notification.
 func PostDcrdexBondStatusHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	host := r.URL.Query().Get("host")
 	bondSubmit.Lock()
 	s, ok := bondSubmit.m[host]
@@ -1164,7 +1166,7 @@ func PostDcrdexBondStatusHandler(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		s = bondSubmitState{Phase: "none"}
 	}
-	_ = json.NewEncoder(w).Encode(s)
+	respondJSON(w, http.StatusOK, s)
 }
 
 // DcrdexWSHandler is a transparent WebSocket relay between the browser and
@@ -1175,7 +1177,7 @@ func PostDcrdexBondStatusHandler(w http.ResponseWriter, r *http.Request) {
 func DcrdexWSHandler(w http.ResponseWriter, r *http.Request) {
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	relayBisonwWS(w, r, client)
@@ -1188,7 +1190,7 @@ func DcrdexWSHandler(w http.ResponseWriter, r *http.Request) {
 func DcrdexNotifyWSHandler(w http.ResponseWriter, r *http.Request) {
 	client, err := rpc.DcrdexWSClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	relayBisonwWS(w, r, client)
@@ -1214,11 +1216,14 @@ func relayBisonwWS(w http.ResponseWriter, r *http.Request, client *bisonw.Client
 		if resp != nil {
 			msg += " (http " + itoa(uint32(resp.StatusCode)) + ")"
 		}
-		front.WriteJSON(map[string]string{"error": msg})
+		_ = writeWSJSON(front, map[string]string{"error": msg})
 		return
 	}
 	defer up.Close()
 
+	stopKeepAlive := armWSKeepAlive(front)
+	defer stopKeepAlive()
+
 	errc := make(chan struct{}, 2)
 	pipe := func(dst, src *websocket.Conn) {
 		for {
@@ -1227,6 +1232,7 @@ func relayBisonwWS(w http.ResponseWriter, r *http.Request, client *bisonw.Client
 				errc <- struct{}{}
 				return
 			}
+			_ = dst.SetWriteDeadline(wsWriteDeadline())
 			if err := dst.WriteMessage(mt, data); err != nil {
 				errc <- struct{}{}
 				return
@@ -1241,10 +1247,9 @@ func relayBisonwWS(w http.ResponseWriter, r *http.Request, client *bisonw.Client
 // GetDcrdexMyOrdersHandler returns the user's active and recent orders (raw),
 // optionally filtered to the `host` query parameter.
 func GetDcrdexMyOrdersHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -1254,7 +1259,7 @@ func GetDcrdexMyOrdersHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // bwCoin/bwMatch/bwOrder mirror the core.Order JSON the webserver /api/orders
@@ -1422,7 +1427,6 @@ func normalizeDexHistOrder(o *bwOrder) *dexHistOrder {
 // supports a status filter, a market filter, and offset-based pagination, and
 // normalizes the result to the myorders shape the dashboard consumes.
 func GetDcrdexOrdersHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host   string `json:"host"`
 		N      int    `json:"n"`
@@ -1433,8 +1437,8 @@ func GetDcrdexOrdersHandler(w http.ResponseWriter, r *http.Request) {
 			QuoteID uint32 `json:"quoteID"`
 		} `json:"market"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	n := req.N
@@ -1469,7 +1473,7 @@ func GetDcrdexOrdersHandler(w http.ResponseWriter, r *http.Request) {
 	var in []*bwOrder
 	if len(raw) > 0 {
 		if err := json.Unmarshal(raw, &in); err != nil {
-			http.Error(w, "decode orders: "+err.Error(), http.StatusBadGateway)
+			respondError(w, http.StatusBadGateway, "decode orders: "+err.Error())
 			return
 		}
 	}
@@ -1477,7 +1481,7 @@ func GetDcrdexOrdersHandler(w http.ResponseWriter, r *http.Request) {
 	for _, o := range in {
 		out = append(out, normalizeDexHistOrder(o))
 	}
-	json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // dexFullCoin/dexFullMatch/dexFullOrder mirror the myorders shape but keep each
@@ -1591,12 +1595,15 @@ func normalizeDexFullOrder(o *bwOrder) *dexFullOrder {
 // and the orders archive omit confs; this is the only source of live confs, used
 // by the order-detail swap tracker.
 func GetDcrdexSingleOrderHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		ID string `json:"id"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.ID == "" {
+		respondError(w, http.StatusBadRequest, "id is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -1612,25 +1619,28 @@ func GetDcrdexSingleOrderHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	var o bwOrder
 	if err := json.Unmarshal(raw, &o); err != nil {
-		http.Error(w, "decode order: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode order: "+err.Error())
 		return
 	}
-	json.NewEncoder(w).Encode(normalizeDexFullOrder(&o))
+	respondJSON(w, http.StatusOK, normalizeDexFullOrder(&o))
 }
 
 // CancelDcrdexOrderHandler cancels an active order by its hex order ID.
 func CancelDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		OrderID string `json:"orderID"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OrderID == "" {
-		http.Error(w, "orderID is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.OrderID == "" {
+		respondError(w, http.StatusBadRequest, "orderID is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -1639,14 +1649,13 @@ func CancelDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // PlaceDcrdexOrderHandler places a limit or market order. Qty and Rate are in
 // atomic units. This spends real funds on mainnet; the dashboard calls it only
 // on explicit user action.
 func PlaceDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host    string            `json:"host"`
 		IsLimit bool              `json:"isLimit"`
@@ -1658,18 +1667,22 @@ func PlaceDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
 		TifNow  bool              `json:"tifNow"`
 		Options map[string]string `json:"options"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" || req.Qty == 0 {
-		http.Error(w, "host and qty are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" || req.Qty == 0 {
+		respondError(w, http.StatusBadRequest, "host and qty are required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
@@ -1694,7 +1707,7 @@ func PlaceDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // PreDcrdexOrderHandler returns bisonw's pre-order estimate (swap + redeem fee
@@ -1702,7 +1715,6 @@ func PlaceDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
 // order form can show fees and options before the user commits. Read-only; goes
 // through the webserver, which the RPC server has no equivalent for.
 func PreDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host    string            `json:"host"`
 		IsLimit bool              `json:"isLimit"`
@@ -1714,8 +1726,12 @@ func PreDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
 		TifNow  bool              `json:"tifNow"`
 		Options map[string]string `json:"options"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" || req.Qty == 0 {
-		http.Error(w, "host and qty are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" || req.Qty == 0 {
+		respondError(w, http.StatusBadRequest, "host and qty are required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -1729,21 +1745,24 @@ func PreDcrdexOrderHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // MaxDcrdexBuyHandler returns the largest buy order fundable at the given rate on
 // the market, with fee estimates. Webserver-only route.
 func MaxDcrdexBuyHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host  string `json:"host"`
 		Base  uint32 `json:"base"`
 		Quote uint32 `json:"quote"`
 		Rate  uint64 `json:"rate"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" || req.Rate == 0 {
-		http.Error(w, "host and rate are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" || req.Rate == 0 {
+		respondError(w, http.StatusBadRequest, "host and rate are required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -1757,20 +1776,23 @@ func MaxDcrdexBuyHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // MaxDcrdexSellHandler returns the largest sell order fundable on the market,
 // with fee estimates. Webserver-only route.
 func MaxDcrdexSellHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host  string `json:"host"`
 		Base  uint32 `json:"base"`
 		Quote uint32 `json:"quote"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" {
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -1784,15 +1806,14 @@ func MaxDcrdexSellHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // GetDcrdexAssetsHandler serves the embedded DCRDEX supported-asset catalog
 // (wallet definitions and config-option schemas), which the bisonw RPC does not
 // expose. Used by the frontend to drive the create-wallet forms.
 func GetDcrdexAssetsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(dexassets.Raw())
+	respondJSON(w, http.StatusOK, json.RawMessage(dexassets.Raw()))
 }
 
 // CreateDcrdexAssetWalletHandler creates a wallet for an arbitrary asset from a
@@ -1800,25 +1821,28 @@ func GetDcrdexAssetsHandler(w http.ResponseWriter, r *http.Request) {
 // handler (CreateDcrdexWalletHandler) that wires the pinned dex account; this
 // generic path serves every other asset. Requires the DEX session unlocked.
 func CreateDcrdexAssetWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID    uint32            `json:"assetID"`
 		WalletType string            `json:"walletType"`
 		Config     map[string]string `json:"config"`
 		WalletPass string            `json:"walletPass"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.WalletType == "" {
-		http.Error(w, "assetID and walletType are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.WalletType == "" {
+		respondError(w, http.StatusBadRequest, "assetID and walletType are required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
@@ -1833,7 +1857,7 @@ func CreateDcrdexAssetWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // DexWalletTx is a wallet transaction with amounts converted to conventional
@@ -1938,7 +1962,7 @@ func dexAccountTxIDs(ctx context.Context, fresh bool) (map[string]struct{}, []De
 		return dexTxIDSet, dexUnminedTxs, nil
 	}
 	if rpc.WalletGrpcClient == nil {
-		return nil, nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	// Resolve the dcrwallet "dex" account number. (listtransactions cannot filter
 	// by account, and its per-entry account tag is unreliable for sends to
@@ -2027,10 +2051,9 @@ func dexAccountTxIDs(ctx context.Context, fresh bool) (map[string]struct{}, []De
 // conventional units). Query: assetID (required), n, refID, past. For the Decred
 // wallet the history is scoped to the dcrwallet "dex" account.
 func GetDcrdexWalletTxsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	assetID, err := strconv.ParseUint(r.URL.Query().Get("assetID"), 10, 32)
 	if err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "assetID is required")
 		return
 	}
 	num, _ := strconv.Atoi(r.URL.Query().Get("n"))
@@ -2038,7 +2061,7 @@ func GetDcrdexWalletTxsHandler(w http.ResponseWriter, r *http.Request) {
 	past := r.URL.Query().Get("past") == "true"
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
@@ -2052,14 +2075,14 @@ func GetDcrdexWalletTxsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		var txs []rawWalletTx
 		if err := json.Unmarshal(raw, &txs); err != nil {
-			http.Error(w, "decode txs: "+err.Error(), http.StatusBadGateway)
+			respondError(w, http.StatusBadGateway, "decode txs: "+err.Error())
 			return
 		}
 		out := make([]DexWalletTx, 0, len(txs))
 		for _, t := range txs {
 			out = append(out, convWalletTx(uint32(assetID), t))
 		}
-		json.NewEncoder(w).Encode(out)
+		respondJSON(w, http.StatusOK, out)
 		return
 	}
 
@@ -2087,7 +2110,7 @@ func GetDcrdexWalletTxsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		var txs []rawWalletTx
 		if err := json.Unmarshal(raw, &txs); err != nil {
-			http.Error(w, "decode txs: "+err.Error(), http.StatusBadGateway)
+			respondError(w, http.StatusBadGateway, "decode txs: "+err.Error())
 			return
 		}
 		if len(txs) == 0 {
@@ -2123,26 +2146,25 @@ func GetDcrdexWalletTxsHandler(w http.ResponseWriter, r *http.Request) {
 	if len(out) > want {
 		out = out[:want]
 	}
-	json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // GetDcrdexWalletTxHandler returns a single wallet transaction. Query: assetID,
 // txID.
 func GetDcrdexWalletTxHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	assetID, err := strconv.ParseUint(r.URL.Query().Get("assetID"), 10, 32)
 	if err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "assetID is required")
 		return
 	}
 	txID := r.URL.Query().Get("txID")
 	if txID == "" {
-		http.Error(w, "txID is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "txID is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
@@ -2154,34 +2176,37 @@ func GetDcrdexWalletTxHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	var t rawWalletTx
 	if err := json.Unmarshal(raw, &t); err != nil {
-		http.Error(w, "decode tx: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "decode tx: "+err.Error())
 		return
 	}
-	json.NewEncoder(w).Encode(convWalletTx(uint32(assetID), t))
+	respondJSON(w, http.StatusOK, convWalletTx(uint32(assetID), t))
 }
 
 // SendDcrdexWalletHandler sends a conventional amount of an asset to an address.
 // The amount is converted to atoms in the backend. Spends real funds; requires
 // the DEX session unlocked.
 func SendDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID uint32  `json:"assetID"`
 		Value   float64 `json:"value"`
 		Address string  `json:"address"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Address == "" || req.Value <= 0 {
-		http.Error(w, "assetID, value and address are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Address == "" || req.Value <= 0 {
+		respondError(w, http.StatusBadRequest, "assetID, value and address are required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
@@ -2192,7 +2217,7 @@ func SendDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]string{"coin": coin})
+	respondJSON(w, http.StatusOK, map[string]string{"coin": coin})
 }
 
 // EstimateDcrdexSendFeeHandler estimates the network fee to send from a wallet and
@@ -2200,25 +2225,28 @@ func SendDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 // fee estimation). The fee is returned in conventional units of the fee asset (the
 // parent chain for a token), with that asset's symbol.
 func EstimateDcrdexSendFeeHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID  uint32  `json:"assetID"`
 		Value    float64 `json:"value"`
 		Address  string  `json:"address"`
 		Subtract bool    `json:"subtract"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Address == "" || req.Value <= 0 {
-		http.Error(w, "assetID, value and address are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Address == "" || req.Value <= 0 {
+		respondError(w, http.StatusBadRequest, "assetID, value and address are required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexWebClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2230,7 +2258,7 @@ func EstimateDcrdexSendFeeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	feeAsset := dexassets.FeeAsset(req.AssetID)
-	json.NewEncoder(w).Encode(map[string]any{
+	respondJSON(w, http.StatusOK, map[string]any{
 		"fee":          atomsToConv(txFee, dexassets.ConvFactor(feeAsset)),
 		"feeSymbol":    dexassets.Symbol(feeAsset),
 		"validAddress": validAddr,
@@ -2245,28 +2273,27 @@ func dexWalletActionAsset(r *http.Request) (uint32, error) {
 		Disable bool   `json:"disable"`
 		Address string `json:"address"`
 	}
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err := decodeJSON(r, &req)
 	return req.AssetID, err
 }
 
 // OpenDcrdexWalletHandler unlocks a wallet. Requires the DEX session unlocked.
 func OpenDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID uint32 `json:"assetID"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2275,20 +2302,19 @@ func OpenDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // CloseDcrdexWalletHandler locks a wallet.
 func CloseDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	assetID, err := dexWalletActionAsset(r)
 	if err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "assetID is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2297,23 +2323,22 @@ func CloseDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // ToggleDcrdexWalletHandler enables or disables a wallet.
 func ToggleDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID uint32 `json:"assetID"`
 		Disable bool   `json:"disable"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2322,23 +2347,22 @@ func ToggleDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // RescanDcrdexWalletHandler triggers a wallet rescan.
 func RescanDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID uint32 `json:"assetID"`
 		Force   bool   `json:"force"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2347,20 +2371,19 @@ func RescanDcrdexWalletHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // GetDcrdexWalletPeersHandler returns a wallet's peers (raw). Query: assetID.
 func GetDcrdexWalletPeersHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	assetID, err := strconv.ParseUint(r.URL.Query().Get("assetID"), 10, 32)
 	if err != nil {
-		http.Error(w, "assetID is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "assetID is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -2370,23 +2393,26 @@ func GetDcrdexWalletPeersHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // AddDcrdexWalletPeerHandler adds a persistent peer to a wallet.
 func AddDcrdexWalletPeerHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID uint32 `json:"assetID"`
 		Address string `json:"address"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Address == "" {
-		http.Error(w, "assetID and address are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Address == "" {
+		respondError(w, http.StatusBadRequest, "assetID and address are required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2395,23 +2421,26 @@ func AddDcrdexWalletPeerHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // RemoveDcrdexWalletPeerHandler removes a persistent peer from a wallet.
 func RemoveDcrdexWalletPeerHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AssetID uint32 `json:"assetID"`
 		Address string `json:"address"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Address == "" {
-		http.Error(w, "assetID and address are required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Address == "" {
+		respondError(w, http.StatusBadRequest, "assetID and address are required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -2420,21 +2449,20 @@ func RemoveDcrdexWalletPeerHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // GetDcrdexNotificationsHandler returns up to `n` recent bisonw notifications
 // (raw: type, topic, subject, details, severity, stamp, acked, id) for the
 // notifications panel. Defaults to 50.
 func GetDcrdexNotificationsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	n := 50
 	if v, err := strconv.Atoi(r.URL.Query().Get("n")); err == nil && v > 0 {
 		n = v
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -2444,7 +2472,7 @@ func GetDcrdexNotificationsHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	w.Write(raw)
+	respondJSON(w, http.StatusOK, json.RawMessage(raw))
 }
 
 // dexRateCache caches Kraken USD rates across requests.
@@ -2455,7 +2483,6 @@ var dexRateCache = exchangerate.New(services.ExternalTransport())
 // via BTC/USD); if Kraken is unreachable it falls back to Bison Relay's feed
 // for DCR and BTC.
 func GetDcrdexRatesHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
@@ -2477,24 +2504,27 @@ func GetDcrdexRatesHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	json.NewEncoder(w).Encode(rates)
+	respondJSON(w, http.StatusOK, rates)
 }
 
 // ExportDcrdexSeedHandler returns the bisonw application seed for backup. The
 // app password must be re-entered in the request body (not taken from the
 // session) for this sensitive action. The seed is never persisted.
 func ExportDcrdexSeedHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		AppPass string `json:"appPass"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AppPass == "" {
-		http.Error(w, "appPass is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.AppPass == "" {
+		respondError(w, http.StatusBadRequest, "appPass is required")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -2504,19 +2534,18 @@ func ExportDcrdexSeedHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]string{"seed": seed})
+	respondJSON(w, http.StatusOK, map[string]string{"seed": seed})
 }
 
 // MarkDcrdexSeedBackedUpHandler records that the user has backed up the app seed,
 // clearing the unlock backup reminder. dcrdex keeps no such flag, so it lives in
 // the dashboard config.
 func MarkDcrdexSeedBackedUpHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	if err := setDcrdexSeedBackedUp(true); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // DiscoverDcrdexAccountHandler re-discovers the account on a DEX server (used
@@ -2525,22 +2554,25 @@ func MarkDcrdexSeedBackedUpHandler(w http.ResponseWriter, r *http.Request) {
 // fidelity bond. A successful discover records the account locally, so the UI
 // can then treat the account as registered and skip bond posting.
 func DiscoverDcrdexAccountHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host string `json:"host"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" {
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	appPass, ok := rpc.DcrdexAppPass()
 	if !ok {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return
 	}
 	client, err := rpc.DcrdexClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
@@ -2550,5 +2582,5 @@ func DiscoverDcrdexAccountHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"paid": paid})
+	respondJSON(w, http.StatusOK, map[string]bool{"paid": paid})
 }
@@ -28,12 +28,12 @@ import (
 func mmWebClient(w http.ResponseWriter) (*bisonw.WebClient, string, bool) {
 	appPass, set := rpc.DcrdexAppPass()
 	if !set {
-		http.Error(w, "DCRDEX is locked", http.StatusConflict)
+		respondError(w, http.StatusConflict, "DCRDEX is locked")
 		return nil, "", false
 	}
 	c, err := rpc.DcrdexWebClient()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, err.Error())
 		return nil, "", false
 	}
 	return c, appPass, true
@@ -41,7 +41,6 @@ func mmWebClient(w http.ResponseWriter) (*bisonw.WebClient, string, bool) {
 
 // GetDcrdexMMStatusHandler returns the market-making status (bots + CEX state).
 func GetDcrdexMMStatusHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, appPass, ok := mmWebClient(w)
 	if !ok {
 		return
@@ -56,7 +55,7 @@ func GetDcrdexMMStatusHandler(w http.ResponseWriter, r *http.Request) {
 	if len(status) == 0 {
 		status = json.RawMessage("null")
 	}
-	w.Write(status)
+	respondJSON(w, http.StatusOK, json.RawMessage(status))
 }
 
 // GetDcrdexMMMarketReportHandler returns the market report (oracle prices and
@@ -64,13 +63,12 @@ func GetDcrdexMMStatusHandler(w http.ResponseWriter, r *http.Request) {
 // The bot configuration UI uses it for the placements chart, the oracle table,
 // and lots-to-USD conversion.
 func GetDcrdexMMMarketReportHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	q := r.URL.Query()
 	host := q.Get("host")
 	baseID, err1 := strconv.ParseUint(q.Get("baseID"), 10, 32)
 	quoteID, err2 := strconv.ParseUint(q.Get("quoteID"), 10, 32)
 	if host == "" || err1 != nil || err2 != nil {
-		http.Error(w, "host, baseID and quoteID are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "host, baseID and quoteID are required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -87,7 +85,7 @@ func GetDcrdexMMMarketReportHandler(w http.ResponseWriter, r *http.Request) {
 	if len(report) == 0 {
 		report = json.RawMessage("null")
 	}
-	w.Write(report)
+	respondJSON(w, http.StatusOK, json.RawMessage(report))
 }
 
 // GetDcrdexMMRunLogsHandler returns a market-maker run's event log (the bot's
@@ -95,14 +93,13 @@ func GetDcrdexMMMarketReportHandler(w http.ResponseWriter, r *http.Request) {
 // identified by host/baseID/quoteID/startTime. n caps the events returned; the
 // optional refID pages older events (the oldest event id already held).
 func GetDcrdexMMRunLogsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	q := r.URL.Query()
 	host := q.Get("host")
 	baseID, err1 := strconv.ParseUint(q.Get("baseID"), 10, 32)
 	quoteID, err2 := strconv.ParseUint(q.Get("quoteID"), 10, 32)
 	startTime, err3 := strconv.ParseInt(q.Get("startTime"), 10, 64)
 	if host == "" || err1 != nil || err2 != nil || err3 != nil {
-		http.Error(w, "host, baseID, quoteID and startTime are required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "host, baseID, quoteID and startTime are required")
 		return
 	}
 	n, err := strconv.ParseUint(q.Get("n"), 10, 64)
@@ -129,13 +126,12 @@ func GetDcrdexMMRunLogsHandler(w http.ResponseWriter, r *http.Request) {
 	if len(logs) == 0 {
 		logs = json.RawMessage("null")
 	}
-	w.Write(logs)
+	respondJSON(w, http.StatusOK, json.RawMessage(logs))
 }
 
 // GetDcrdexMMArchivedRunsHandler returns the market-maker run history: past runs
 // (start time, market, profit), newest first, for the run-history view.
 func GetDcrdexMMArchivedRunsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	client, appPass, ok := mmWebClient(w)
 	if !ok {
 		return
@@ -150,17 +146,16 @@ func GetDcrdexMMArchivedRunsHandler(w http.ResponseWriter, r *http.Request) {
 	if len(runs) == 0 {
 		runs = json.RawMessage("[]")
 	}
-	w.Write(runs)
+	respondJSON(w, http.StatusOK, json.RawMessage(runs))
 }
 
 // UpdateDcrdexMMBotConfigHandler persists (and validates) a bot config. The
 // request body is a bisonw mm.BotConfig built by the frontend and forwarded
 // verbatim.
 func UpdateDcrdexMMBotConfigHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
 	if err != nil || len(body) == 0 {
-		http.Error(w, "config is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "config is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -173,19 +168,22 @@ func UpdateDcrdexMMBotConfigHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // RemoveDcrdexMMBotConfigHandler deletes a stored bot config.
 func RemoveDcrdexMMBotConfigHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host    string `json:"host"`
 		BaseID  uint32 `json:"baseID"`
 		QuoteID uint32 `json:"quoteID"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" {
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -198,16 +196,15 @@ func RemoveDcrdexMMBotConfigHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // UpdateDcrdexMMCexConfigHandler stores CEX API credentials. The request body is
 // a bisonw mm.CEXConfig {name, apiKey, apiSecret}.
 func UpdateDcrdexMMCexConfigHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<16))
 	if err != nil || len(body) == 0 {
-		http.Error(w, "config is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "config is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -220,17 +217,16 @@ func UpdateDcrdexMMCexConfigHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // StartDcrdexMMBotHandler starts a configured bot. The request body is a bisonw
 // mm.StartConfig (MarketWithHost plus optional alloc/autoRebalance). This spends
 // real funds; the frontend gates it behind an explicit confirmation.
 func StartDcrdexMMBotHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
 	if err != nil || len(body) == 0 {
-		http.Error(w, "start config is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "start config is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -243,19 +239,22 @@ func StartDcrdexMMBotHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
 
 // StopDcrdexMMBotHandler stops a running bot on the given market.
 func StopDcrdexMMBotHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	var req struct {
 		Host    string `json:"host"`
 		BaseID  uint32 `json:"baseID"`
 		QuoteID uint32 `json:"quoteID"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Host == "" {
-		http.Error(w, "host is required", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Host == "" {
+		respondError(w, http.StatusBadRequest, "host is required")
 		return
 	}
 	client, appPass, ok := mmWebClient(w)
@@ -268,5 +267,5 @@ func StopDcrdexMMBotHandler(w http.ResponseWriter, r *http.Request) {
 		dexWriteErr(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+	respondJSON(w, http.StatusOK, map[string]bool{"ok": true})
 }
@@ -28,19 +28,19 @@ func JoinDecredPulseHandler(w http.ResponseWriter, r *http.Request) {
 	// from brclientd's base64 encoding to the hex the bot expects.
 	raw, err := rpc.BrclientdUserPublicIdentity(ctx)
 	if err != nil {
-		http.Error(w, "could not read local identity: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "could not read local identity: "+err.Error())
 		return
 	}
 	var id struct {
 		Identity string `json:"identity"`
 	}
 	if err := json.Unmarshal(raw, &id); err != nil || id.Identity == "" {
-		http.Error(w, "could not determine local identity", http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "could not determine local identity")
 		return
 	}
 	idBytes, err := base64.StdEncoding.DecodeString(id.Identity)
 	if err != nil || len(idBytes) == 0 {
-		http.Error(w, "malformed local identity", http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "malformed local identity")
 		return
 	}
 	pubkeyHex := hex.EncodeToString(idBytes)
@@ -48,16 +48,15 @@ func JoinDecredPulseHandler(w http.ResponseWriter, r *http.Request) {
 	// Ask the bot for an invite (solving its proof-of-work challenge).
 	inviteKey, err := services.RequestDecredPulseInvite(ctx, pubkeyHex)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
 
 	// Redeem the invite to begin KX with the bot.
 	if err := rpc.BrclientdRedeemPaidInviteKey(ctx, inviteKey); err != nil {
-		http.Error(w, "could not redeem invite: "+err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, "could not redeem invite: "+err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
@@ -5,38 +5,44 @@
 package handlers
 
 import (
-	"context"
-	"encoding/json"
+	"encoding/hex"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 
+	"dcrpulse/internal/middleware"
 	"dcrpulse/internal/services"
 )
 
-// SearchHandler handles universal search requests
+// SearchHandler handles universal search requests. The optional
+// ?type=block|transaction|address forces how q is interpreted.
 func SearchHandler(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	if query == "" {
-		http.Error(w, "Missing search query", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Missing search query")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := explorerContext(r, 10*time.Second)
 	defer cancel()
 
-	result, err := services.UniversalSearch(ctx, query)
+	result, err := services.UniversalSearch(ctx, query, r.URL.Query().Get("type"))
+	if errors.Is(err, services.ErrInvalidSearchType) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		log.Printf("Search error: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	respondJSON(w, http.StatusOK, result)
 }
 
 // GetRecentBlocksHandler returns a list of recent blocks with pagination
@@ -62,18 +68,55 @@ func GetRecentBlocksHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := explorerContext(r, 15*time.Second)
 	defer cancel()
 
 	response, err := services.FetchRecentBlocksPaginated(ctx, page, pageSize)
 	if err != nil {
 		log.Printf("Error fetching recent blocks: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetBlockHeadersHandler returns the headers of every step-th block from
+// start through end.
+func GetBlockHeadersHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	start, end, step := int64(-1), int64(-1), int64(1)
+	for _, p := range []struct {
+		name string
+		dst  *int64
+	}{{"start", &start}, {"end", &end}, {"step", &step}} {
+		if v := q.Get(p.name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				respondError(w, http.StatusBadRequest, "Invalid "+p.name)
+				return
+			}
+			*p.dst = n
+		}
+	}
+	if start < 0 || end < 0 {
+		respondError(w, http.StatusBadRequest, "start and end are required")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	ctx, cancel := explorerContext(r, 60*time.Second)
+	defer cancel()
+
+	headers, err := services.FetchBlockHeaders(ctx, start, end, step)
+	switch {
+	case errors.Is(err, services.ErrInvalidHeaderRange):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case err != nil:
+		log.Printf("Error fetching block headers %d-%d: %v", start, end, err)
+		respondRPCError(w, err)
+	default:
+		respondJSON(w, http.StatusOK, headers)
+	}
 }
 
 // GetBlockByHeightHandler returns detailed block info by height
@@ -83,22 +126,24 @@ func GetBlockByHeightHandler(w http.ResponseWriter, r *http.Request) {
 
 	height, err := strconv.ParseInt(heightStr, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid block height", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Invalid block height")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := explorerContext(r, 10*time.Second)
 	defer cancel()
 
 	block, err := services.FetchBlockByHeight(ctx, height)
 	if err != nil {
 		log.Printf("Error fetching block %d: %v", height, err)
-		http.Error(w, "Block not found", http.StatusNotFound)
+		if respondExplorerTimeout(w, ctx) {
+			return
+		}
+		respondError(w, http.StatusNotFound, "Block not found")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(block)
+	respondJSON(w, http.StatusOK, block)
 }
 
 // GetBlockByHashHandler returns detailed block info by hash
@@ -107,22 +152,83 @@ func GetBlockByHashHandler(w http.ResponseWriter, r *http.Request) {
 	hash := vars["hash"]
 
 	if hash == "" {
-		http.Error(w, "Missing block hash", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Missing block hash")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := explorerContext(r, 10*time.Second)
 	defer cancel()
 
 	block, err := services.FetchBlockByHash(ctx, hash)
 	if err != nil {
 		log.Printf("Error fetching block %s: %v", hash, err)
-		http.Error(w, "Block not found", http.StatusNotFound)
+		if respondExplorerTimeout(w, ctx) {
+			return
+		}
+		respondError(w, http.StatusNotFound, "Block not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, block)
+}
+
+// GetBlockVotesHandler returns each vote in the block at height with its
+// parsed agenda choices and its choice on every tspend voted on there.
+func GetBlockVotesHandler(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseInt(mux.Vars(r)["height"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid block height")
+		return
+	}
+
+	ctx, cancel := explorerContext(r, 15*time.Second)
+	defer cancel()
+
+	votes, err := services.FetchBlockVotes(ctx, height)
+	if err != nil {
+		if errors.Is(err, services.ErrBlockNotFound) {
+			respondError(w, http.StatusNotFound, "Block not found")
+			return
+		}
+		log.Printf("Error fetching votes of block %d: %v", height, err)
+		respondRPCError(w, err)
 		return
 	}
+	respondJSON(w, http.StatusOK, votes)
+}
+
+// GetBlockTransactionsHandler returns a page of a block's transaction
+// summaries, selected with ?limit= and ?offset=.
+func GetBlockTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	hash := mux.Vars(r)["hash"]
+	limit := services.DefaultBlockTxLimit
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	offset := 0
+	if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o > 0 {
+		offset = o
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(block)
+	ctx, cancel := explorerContext(r, 15*time.Second)
+	defer cancel()
+
+	page, err := services.FetchBlockTransactions(ctx, hash, limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidHash):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrBlockNotFound):
+			respondError(w, http.StatusNotFound, "Block not found")
+		default:
+			log.Printf("Error fetching transactions of block %s: %v", hash, err)
+			if !respondExplorerTimeout(w, ctx) {
+				respondError(w, http.StatusBadGateway, err.Error())
+			}
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, page)
 }
 
 // GetTransactionHandler returns detailed transaction info
@@ -131,22 +237,151 @@ func GetTransactionHandler(w http.ResponseWriter, r *http.Request) {
 	txHash := vars["txhash"]
 
 	if txHash == "" {
-		http.Error(w, "Missing transaction hash", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Missing transaction hash")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := explorerContext(r, 10*time.Second)
 	defer cancel()
 
 	tx, err := services.FetchTransaction(ctx, txHash)
 	if err != nil {
 		log.Printf("Error fetching transaction %s: %v", txHash, err)
-		http.Error(w, "Transaction not found", http.StatusNotFound)
+		if errors.Is(err, services.ErrTxNotFound) {
+			respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+			return
+		}
+		if errors.Is(err, services.ErrTxUnavailable) {
+			respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+			return
+		}
+		if respondExplorerTimeout(w, ctx) {
+			return
+		}
+		respondError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tx)
+}
+
+// GetRawBlockHandler returns a block's serialized bytes as hex, or as
+// application/octet-stream with ?format=bin.
+func GetRawBlockHandler(w http.ResponseWriter, r *http.Request) {
+	hash := mux.Vars(r)["hash"]
+
+	ctx, cancel := explorerContext(r, 10*time.Second)
+	defer cancel()
+
+	rawHex, err := services.FetchRawBlockHex(ctx, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidHash):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrBlockNotFound):
+			respondError(w, http.StatusNotFound, "Block not found")
+		default:
+			log.Printf("Error fetching raw block %s: %v", hash, err)
+			if !respondExplorerTimeout(w, ctx) {
+				respondError(w, http.StatusBadGateway, err.Error())
+			}
+		}
+		return
+	}
+
+	respondRawHex(w, r, hash+".block", rawHex)
+}
+
+// GetRawTransactionHandler returns a transaction's serialized bytes as hex,
+// or as application/octet-stream with ?format=bin.
+func GetRawTransactionHandler(w http.ResponseWriter, r *http.Request) {
+	txHash := mux.Vars(r)["txhash"]
+
+	ctx, cancel := explorerContext(r, 10*time.Second)
+	defer cancel()
+
+	rawHex, err := services.FetchRawTransactionHex(ctx, txHash)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidHash):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrTxNotFound):
+			respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+		case errors.Is(err, services.ErrTxUnavailable):
+			respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+		default:
+			log.Printf("Error fetching raw transaction %s: %v", txHash, err)
+			if !respondExplorerTimeout(w, ctx) {
+				respondError(w, http.StatusBadGateway, err.Error())
+			}
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tx)
+	respondRawHex(w, r, txHash+".tx", rawHex)
+}
+
+// respondRawHex writes rawHex in the envelope as {"hex": ...}, or decoded as
+// an octet-stream download named filename when ?format=bin.
+func respondRawHex(w http.ResponseWriter, r *http.Request, filename, rawHex string) {
+	switch r.URL.Query().Get("format") {
+	case "", "hex":
+		respondJSON(w, http.StatusOK, map[string]string{"hex": rawHex})
+	case "bin":
+		raw, err := hex.DecodeString(rawHex)
+		if err != nil {
+			respondError(w, http.StatusBadGateway, "dcrd returned invalid hex")
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(raw)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(raw)
+	default:
+		respondError(w, http.StatusBadRequest, "format must be hex or bin")
+	}
+}
+
+// GetTicketLifecycleHandler returns a ticket's purchase, maturity, expiry and
+// vote/revocation stages
+func GetTicketLifecycleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hash := vars["hash"]
+
+	if hash == "" {
+		respondError(w, http.StatusBadRequest, "Missing ticket hash")
+		return
+	}
+
+	// Locating the spender of a ticket outside the wallet walks blocks, so
+	// allow more time than the single-object lookups.
+	ctx, cancel := explorerContext(r, 30*time.Second)
+	defer cancel()
+
+	lifecycle, err := services.FetchTicketLifecycle(ctx, hash)
+	if err != nil {
+		if errors.Is(err, services.ErrNotTicket) {
+			respondError(w, http.StatusNotFound, "Transaction is not a ticket")
+			return
+		}
+		if errors.Is(err, services.ErrTxNotFound) {
+			respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+			return
+		}
+		if errors.Is(err, services.ErrTxUnavailable) {
+			respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+			return
+		}
+		log.Printf("Error fetching ticket %s: %v", hash, err)
+		if respondExplorerTimeout(w, ctx) {
+			return
+		}
+		respondError(w, http.StatusNotFound, "Ticket not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, lifecycle)
 }
 
 // GetAddressHandler returns address information (limited without addrindex)
@@ -155,36 +390,234 @@ func GetAddressHandler(w http.ResponseWriter, r *http.Request) {
 	address := vars["address"]
 
 	if address == "" {
-		http.Error(w, "Missing address", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Missing address")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := explorerContext(r, 10*time.Second)
 	defer cancel()
 
 	info, err := services.FetchAddressInfo(ctx, address)
 	if err != nil {
 		log.Printf("Error fetching address info for %s: %v", address, err)
-		http.Error(w, "Failed to fetch address information", http.StatusInternalServerError)
+		respondRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, info)
+}
+
+// GetAddressTraceHandler follows where funds paid to an address went:
+// ?utxo=txid[:vout] names the outputs to start from and ?depth= bounds how
+// many spends are followed.
+func GetAddressTraceHandler(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	utxo := r.URL.Query().Get("utxo")
+	if address == "" || utxo == "" {
+		respondError(w, http.StatusBadRequest, "Missing address or utxo")
+		return
+	}
+	depth := 0
+	if v := r.URL.Query().Get("depth"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 0 {
+			respondError(w, http.StatusBadRequest, "Invalid depth")
+			return
+		}
+		depth = d
+	}
+
+	// Finding spenders walks blocks, so allow well beyond a single lookup.
+	ctx, cancel := explorerContext(r, 60*time.Second)
+	defer cancel()
+
+	trace, err := services.TraceAddressSpends(ctx, address, utxo, depth)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidOutpoint), errors.Is(err, services.ErrTraceNoOutputs):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrTxNotFound):
+			respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+		case errors.Is(err, services.ErrTxUnavailable):
+			respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+		default:
+			log.Printf("Error tracing address %s from %s: %v", address, utxo, err)
+			respondRPCError(w, err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, trace)
+}
+
+// GetVoteHistoryHandler returns the votes cast by tickets whose rewards pay
+// ?address, in blocks ?from..?to (default: roughly the last day).
+func GetVoteHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	address := q.Get("address")
+	if address == "" {
+		respondError(w, http.StatusBadRequest, "Missing address")
+		return
+	}
+	var from, to int64
+	for _, p := range []struct {
+		name string
+		dst  *int64
+	}{{"from", &from}, {"to", &to}} {
+		if v := q.Get(p.name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				respondError(w, http.StatusBadRequest, "Invalid "+p.name+" height")
+				return
+			}
+			*p.dst = n
+		}
+	}
+
+	// Walks up to a few thousand blocks on a cold cache.
+	ctx, cancel := explorerContext(r, 120*time.Second)
+	defer cancel()
+
+	history, err := services.FetchVoteHistory(ctx, address, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidVoteAddress), errors.Is(err, services.ErrInvalidVoteRange):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Printf("Error fetching vote history for %s: %v", address, err)
+			respondRPCError(w, err)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(info)
+	respondJSON(w, http.StatusOK, history)
 }
 
 // GetMempoolTransactionsHandler returns all current mempool transactions
 func GetMempoolTransactionsHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := explorerContext(r, 30*time.Second)
 	defer cancel()
 
 	mempool, err := services.FetchMempoolTransactions(ctx)
 	if err != nil {
 		log.Printf("Error fetching mempool transactions: %v", err)
-		http.Error(w, "Failed to fetch mempool transactions", http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(mempool)
+	respondJSON(w, http.StatusOK, mempool)
+}
+
+// StreamMempoolHandler pushes each transaction dcrd accepts into mempool to
+// the WebSocket client as it arrives.
+func StreamMempoolHandler(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: middleware.SameOriginWS}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade mempool WebSocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ch, unsubscribe := services.SubscribeMempoolEvents()
+	defer unsubscribe()
+
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
+	notify := discardWSReads(conn)
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeWSJSON(conn, ev); err != nil {
+				return
+			}
+		case <-notify:
+			return
+		}
+	}
+}
+
+// StreamBlocksHandler pushes a "block" event over WebSocket for each block
+// dcrd connects and a "reorg" event, with the treasury scan rollback, for
+// each one a reorg disconnects.
+func StreamBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: middleware.SameOriginWS}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade block WebSocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ch, unsubscribe := services.SubscribeBlockEvents()
+	defer unsubscribe()
+
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
+	notify := discardWSReads(conn)
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeWSJSON(conn, ev); err != nil {
+				return
+			}
+		case <-notify:
+			return
+		}
+	}
+}
+
+// StreamAddressHandler pushes an event over WebSocket whenever a transaction
+// paying to or spending from the address enters mempool or is mined.
+func StreamAddressHandler(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	ctx, cancel := explorerContext(r, 10*time.Second)
+	valid, err := services.ValidateNetworkAddress(ctx, address)
+	cancel()
+	if err != nil {
+		log.Printf("Error validating address %s: %v", address, err)
+		respondError(w, http.StatusInternalServerError, "Failed to validate address")
+		return
+	}
+	if !valid {
+		respondError(w, http.StatusBadRequest, "Invalid address for this network")
+		return
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: middleware.SameOriginWS}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade address WebSocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ch, unsubscribe := services.SubscribeAddressEvents(address)
+	defer unsubscribe()
+
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
+	notify := discardWSReads(conn)
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeWSJSON(conn, ev); err != nil {
+				return
+			}
+		case <-notify:
+			return
+		}
+	}
 }
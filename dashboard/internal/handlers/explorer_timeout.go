@@ -0,0 +1,51 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	explorerTimeoutMu sync.RWMutex
+	explorerTimeout   time.Duration
+)
+
+// SetExplorerTimeout bounds every explorer request to d, in place of each
+// route's own limit. Zero keeps the per-route limits.
+func SetExplorerTimeout(d time.Duration) {
+	explorerTimeoutMu.Lock()
+	defer explorerTimeoutMu.Unlock()
+	if d >= 0 {
+		explorerTimeout = d
+	}
+}
+
+// explorerContext derives an explorer request's context from the request's
+// own, so a client that goes away cancels its dcrd calls, bounded by the
+// configured explorer timeout or else by def.
+func explorerContext(r *http.Request, def time.Duration) (context.Context, context.CancelFunc) {
+	explorerTimeoutMu.RLock()
+	d := explorerTimeout
+	explorerTimeoutMu.RUnlock()
+	if d <= 0 {
+		d = def
+	}
+	return context.WithTimeout(r.Context(), d)
+}
+
+// respondExplorerTimeout writes a 504 when ctx ran out of time, for handlers
+// that otherwise report any failure as not found, and reports whether it did.
+func respondExplorerTimeout(w http.ResponseWriter, ctx context.Context) bool {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	respondError(w, http.StatusGatewayTimeout, "explorer request timed out")
+	return true
+}
@@ -6,10 +6,10 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -27,26 +27,48 @@ func GetAgendasHandler(w http.ResponseWriter, r *http.Request) {
 	agendas, err := services.ListAgendas(ctx)
 	if err != nil {
 		log.Printf("ListAgendas: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(agendas)
+	respondJSON(w, http.StatusOK, agendas)
+}
+
+// GetGovernanceDashboardHandler returns the treasury balance and recent
+// flow, the mempool TSpend standings, the agendas being voted on and the next
+// expenditure policy window in one response. Sections that failed are named
+// in meta, as on /api/dashboard.
+func GetGovernanceDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	data, failures, err := services.FetchGovernanceDashboard(r.Context())
+	if err != nil {
+		log.Printf("Error fetching governance dashboard: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
+		return
+	}
+
+	meta := types.DashboardMeta{FailedSections: []string{}}
+	if len(failures) > 0 {
+		for name := range failures {
+			meta.FailedSections = append(meta.FailedSections, name)
+		}
+		sort.Strings(meta.FailedSections)
+		meta.Errors = failures
+	}
+	respondJSONMeta(w, http.StatusOK, data, meta)
 }
 
 // SetAgendaChoiceHandler updates one agenda's vote preference.
 func SetAgendaChoiceHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.SetAgendaChoiceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.AgendaID == "" || req.ChoiceID == "" {
-		http.Error(w, "agendaID and choiceID required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "agendaID and choiceID required")
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	pass := zeroOnReturn([]byte(req.Passphrase))
@@ -68,26 +90,25 @@ func GetTreasuryKeyPoliciesHandler(w http.ResponseWriter, r *http.Request) {
 	policies, err := services.ListTreasuryKeyPolicies(ctx)
 	if err != nil {
 		log.Printf("ListTreasuryKeyPolicies: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(policies)
+	respondJSON(w, http.StatusOK, policies)
 }
 
 // SetTreasuryKeyPolicyHandler updates one PI-key policy.
 func SetTreasuryKeyPolicyHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.SetTreasuryKeyPolicyRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Key == "" {
-		http.Error(w, "key required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "key required")
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	pass := zeroOnReturn([]byte(req.Passphrase))
@@ -109,26 +130,25 @@ func GetTSpendPoliciesHandler(w http.ResponseWriter, r *http.Request) {
 	policies, err := services.ListTSpendPolicies(ctx)
 	if err != nil {
 		log.Printf("ListTSpendPolicies: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(policies)
+	respondJSON(w, http.StatusOK, policies)
 }
 
 // SetTSpendPolicyHandler updates one TSpend's policy.
 func SetTSpendPolicyHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.SetTSpendPolicyRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Hash == "" {
-		http.Error(w, "hash required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "hash required")
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	pass := zeroOnReturn([]byte(req.Passphrase))
@@ -143,6 +163,49 @@ func SetTSpendPolicyHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetVotingPolicyHandler returns the wallet's agenda choices, treasury key
+// policies and TSpend policies in one response.
+func GetVotingPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+	policy, err := services.GetVotingPolicy(ctx)
+	if err != nil {
+		log.Printf("GetVotingPolicy: %v", err)
+		respondRPCError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// SetVotingPolicyHandler applies any mix of agenda choices and treasury key
+// and TSpend policies. Nothing is changed when any of them is invalid.
+func SetVotingPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	var req types.SetVotingPolicyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Passphrase == "" {
+		respondError(w, http.StatusBadRequest, "passphrase required")
+		return
+	}
+	pass := zeroOnReturn([]byte(req.Passphrase))
+	defer pass.zero()
+	req.Passphrase = ""
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+	if err := services.SetVotingPolicy(ctx, req, pass.b); err != nil {
+		if errors.Is(err, services.ErrInvalidVotingPolicy) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writePassphraseAwareError(w, "SetVotingPolicy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // writeProposalsResponse encodes the proposals envelope (list + last-fetch time
 // + when a manual refresh is next allowed) at the given status.
 func writeProposalsResponse(w http.ResponseWriter, status int, proposals []types.Proposal, fetchedAt time.Time) {
@@ -154,9 +217,7 @@ func writeProposalsResponse(w http.ResponseWriter, status int, proposals []types
 		fetched = fetchedAt.Unix()
 		refreshAt = fetchedAt.Add(services.ProposalsRefreshCooldown).Unix()
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(types.ProposalsResponse{
+	respondJSON(w, status, types.ProposalsResponse{
 		Proposals:          proposals,
 		FetchedAt:          fetched,
 		RefreshAvailableAt: refreshAt,
@@ -171,7 +232,7 @@ func GetProposalsHandler(w http.ResponseWriter, r *http.Request) {
 		bucket = "voting"
 	}
 	if !services.IsProposalBucket(bucket) {
-		http.Error(w, "unknown proposal status", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "unknown proposal status")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), services.ProposalsFetchTimeout)
@@ -179,11 +240,11 @@ func GetProposalsHandler(w http.ResponseWriter, r *http.Request) {
 	proposals, fetchedAt, err := services.ListProposals(ctx, bucket)
 	if err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		log.Printf("ListProposals: %v", err)
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
 	writeProposalsResponse(w, http.StatusOK, proposals, fetchedAt)
@@ -198,7 +259,7 @@ func RefreshProposalsHandler(w http.ResponseWriter, r *http.Request) {
 		bucket = "voting"
 	}
 	if !services.IsProposalBucket(bucket) {
-		http.Error(w, "unknown proposal status", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "unknown proposal status")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), services.ProposalsFetchTimeout)
@@ -206,7 +267,7 @@ func RefreshProposalsHandler(w http.ResponseWriter, r *http.Request) {
 	proposals, fetchedAt, err := services.RefreshProposals(ctx, bucket)
 	if err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		if errors.Is(err, services.ErrProposalsRefreshCoolingDown) {
@@ -214,7 +275,7 @@ func RefreshProposalsHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		log.Printf("RefreshProposals: %v", err)
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
 	writeProposalsResponse(w, http.StatusOK, proposals, fetchedAt)
@@ -228,9 +289,7 @@ func writeProposalDetailResponse(w http.ResponseWriter, status int, detail *type
 		fetched = fetchedAt.Unix()
 		refreshAt = fetchedAt.Add(services.ProposalsRefreshCooldown).Unix()
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(types.ProposalDetailResponse{
+	respondJSON(w, status, types.ProposalDetailResponse{
 		Detail:             detail,
 		FetchedAt:          fetched,
 		RefreshAvailableAt: refreshAt,
@@ -242,7 +301,7 @@ func writeProposalDetailResponse(w http.ResponseWriter, status int, detail *type
 func GetProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 	token := strings.TrimSpace(mux.Vars(r)["token"])
 	if token == "" {
-		http.Error(w, "token required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "token required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), services.ProposalsFetchTimeout)
@@ -250,11 +309,11 @@ func GetProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 	detail, fetchedAt, err := services.GetProposalDetail(ctx, token)
 	if err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		log.Printf("GetProposalDetail(%s): %v", token, err)
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
 	writeProposalDetailResponse(w, http.StatusOK, detail, fetchedAt)
@@ -266,7 +325,7 @@ func GetProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 func RefreshProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 	token := strings.TrimSpace(mux.Vars(r)["token"])
 	if token == "" {
-		http.Error(w, "token required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "token required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), services.ProposalsFetchTimeout)
@@ -274,7 +333,7 @@ func RefreshProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 	detail, fetchedAt, err := services.RefreshProposalDetail(ctx, token)
 	if err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		if errors.Is(err, services.ErrProposalsRefreshCoolingDown) {
@@ -282,7 +341,7 @@ func RefreshProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		log.Printf("RefreshProposalDetail(%s): %v", token, err)
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
 	writeProposalDetailResponse(w, http.StatusOK, detail, fetchedAt)
@@ -295,7 +354,7 @@ func RefreshProposalDetailHandler(w http.ResponseWriter, r *http.Request) {
 func PrepareProposalVoteHandler(w http.ResponseWriter, r *http.Request) {
 	token := strings.TrimSpace(mux.Vars(r)["token"])
 	if token == "" {
-		http.Error(w, "token required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "token required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), services.ProposalsFetchTimeout)
@@ -303,30 +362,29 @@ func PrepareProposalVoteHandler(w http.ResponseWriter, r *http.Request) {
 	elig, err := services.PrepareProposalVote(ctx, token)
 	if err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		log.Printf("PrepareProposalVote(%s): %v", token, err)
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(elig)
+	respondJSON(w, http.StatusOK, elig)
 }
 
 // CastPoliteiaVoteHandler runs the sign + ballot-cast flow.
 func CastPoliteiaVoteHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.CastPoliteiaVoteRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Token == "" || req.VoteOption == "" {
-		http.Error(w, "token and voteOption required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "token and voteOption required")
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	pass := zeroOnReturn([]byte(req.Passphrase))
@@ -337,14 +395,13 @@ func CastPoliteiaVoteHandler(w http.ResponseWriter, r *http.Request) {
 	result, err := services.CastPoliteiaVote(ctx, req, pass.b)
 	if err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		writePassphraseAwareError(w, "CastPoliteiaVote", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	respondJSON(w, http.StatusOK, result)
 }
 
 // ---- shared local helpers --------------------------------------------------
@@ -368,9 +425,9 @@ func writePassphraseAwareError(w http.ResponseWriter, label string, err error) {
 	lower := strings.ToLower(msg)
 	switch {
 	case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-		http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+		respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 	default:
 		log.Printf("%s failed: %v", label, err)
-		http.Error(w, msg, http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, msg)
 	}
 }
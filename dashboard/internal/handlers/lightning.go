@@ -27,8 +27,7 @@ func LightningStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 	status := services.LightningStatus(ctx)
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(status)
+	respondJSON(w, http.StatusOK, status)
 }
 
 // LightningSetupHandler — creates the dedicated lightning dcrwallet
@@ -39,24 +38,24 @@ func LightningSetupHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if ready, reason := services.WalletReady(r.Context()); !ready {
-		http.Error(w, reason, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, reason)
 		return
 	}
 	var req types.LightningSetupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if len(req.Passphrase) < 8 {
-		http.Error(w, "passphrase must be at least 8 characters", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase must be at least 8 characters")
 		return
 	}
 	if len(req.Passphrase) > 1024 {
-		http.Error(w, "passphrase too long", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase too long")
 		return
 	}
 	passphrase := []byte(req.Passphrase)
@@ -116,20 +115,20 @@ func LightningSetupHandler(w http.ResponseWriter, r *http.Request) {
 // dcrlnd wallet is already initialised.
 func LightningUnlockHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningUnlockRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if len(req.Passphrase) < 8 {
-		http.Error(w, "passphrase must be at least 8 characters", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase must be at least 8 characters")
 		return
 	}
 	if len(req.Passphrase) > 1024 {
-		http.Error(w, "passphrase too long", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase too long")
 		return
 	}
 	passphrase := []byte(req.Passphrase)
@@ -158,8 +157,7 @@ func LightningInfoHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "GetLightningInfo", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(info)
+	respondJSON(w, http.StatusOK, info)
 }
 
 // LightningBalanceHandler — merged wallet + channel balance for the
@@ -172,8 +170,7 @@ func LightningBalanceHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "GetLightningBalance", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(bal)
+	respondJSON(w, http.StatusOK, bal)
 }
 
 // LightningActivityHandler — recent invoices + payments merged into
@@ -186,8 +183,7 @@ func LightningActivityHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "GetLightningActivity", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(act)
+	respondJSON(w, http.StatusOK, act)
 }
 
 func lightningWriteErr(w http.ResponseWriter, label string, err error) {
@@ -195,7 +191,7 @@ func lightningWriteErr(w http.ResponseWriter, label string, err error) {
 	lower := strings.ToLower(msg)
 	switch {
 	case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-		http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+		respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 	case services.LndStartupOrUnreachable(err),
 		strings.Contains(lower, "not available"),
 		strings.Contains(lower, "unreachable"),
@@ -203,10 +199,10 @@ func lightningWriteErr(w http.ResponseWriter, label string, err error) {
 		// dcrlnd down or still starting up: a friendly, log-derived message.
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
-		http.Error(w, services.DaemonStartupHint(ctx, services.LogComponentDcrlnd).Message, http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, services.DaemonStartupHint(ctx, services.LogComponentDcrlnd).Message)
 	default:
 		log.Printf("%s failed: %v", label, err)
-		http.Error(w, msg, http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, msg)
 	}
 }
 
@@ -221,23 +217,22 @@ func LightningChannelsHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "ListLightningChannels", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningOpenChannelHandler — ConnectPeer + OpenChannelSync.
 func LightningOpenChannelHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.OpenChannelRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.PeerURI == "" {
-		http.Error(w, "peerUri required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "peerUri required")
 		return
 	}
 	if req.LocalAtoms <= 0 {
-		http.Error(w, "localAtoms must be positive", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "localAtoms must be positive")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
@@ -247,20 +242,19 @@ func LightningOpenChannelHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "OpenLightningChannel", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningCloseChannelHandler — streaming CloseChannel, returns when
 // closePending is received.
 func LightningCloseChannelHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.CloseChannelRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.ChannelPoint == "" {
-		http.Error(w, "channelPoint required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "channelPoint required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
@@ -270,16 +264,14 @@ func LightningCloseChannelHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "CloseLightningChannel", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningPeerPresetsHandler — cached brseeder list (always non-empty
 // thanks to the hardcoded hub0 fallback).
 func LightningPeerPresetsHandler(w http.ResponseWriter, r *http.Request) {
 	presets := services.LightningPeerPresets(r.Context())
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]any{"presets": presets})
+	respondJSON(w, http.StatusOK, map[string]any{"presets": presets})
 }
 
 // LightningAutopilotStatusHandler — current autopilot active flag.
@@ -291,15 +283,14 @@ func LightningAutopilotStatusHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "GetLightningAutopilotStatus", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningAutopilotSetHandler — toggle autopilot.
 func LightningAutopilotSetHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.AutopilotStatus
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -321,17 +312,17 @@ func LightningAutopilotScoresHandler(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 		if len(pk) != 66 {
-			http.Error(w, "invalid pubkey", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "invalid pubkey")
 			return
 		}
 		if _, err := hex.DecodeString(pk); err != nil {
-			http.Error(w, "invalid pubkey", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "invalid pubkey")
 			return
 		}
 		pubkeys = append(pubkeys, pk)
 	}
 	if len(pubkeys) == 0 || len(pubkeys) > 100 {
-		http.Error(w, "pubkeys required (1-100)", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pubkeys required (1-100)")
 		return
 	}
 	ignoreLocalState := r.URL.Query().Get("ignoreLocalState") == "true"
@@ -345,8 +336,7 @@ func LightningAutopilotScoresHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "GetLightningAutopilotScores", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningGraphSearchHandler — substring search of DescribeGraph nodes.
@@ -359,8 +349,7 @@ func LightningGraphSearchHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "SearchLightningNodes", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningChannelEventsHandler — WebSocket; fans out dcrlnd's
@@ -376,6 +365,8 @@ func LightningChannelEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
@@ -383,7 +374,7 @@ func LightningChannelEventsHandler(w http.ResponseWriter, r *http.Request) {
 	events, err := services.SubscribeLightningChannelEvents(ctx)
 	if err != nil {
 		log.Printf("SubscribeLightningChannelEvents: %v", err)
-		_ = conn.WriteJSON(map[string]string{"error": err.Error()})
+		_ = writeWSJSON(conn, map[string]string{"error": err.Error()})
 		return
 	}
 
@@ -398,7 +389,7 @@ func LightningChannelEventsHandler(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	for ev := range events {
-		if err := conn.WriteJSON(ev); err != nil {
+		if err := writeWSJSON(conn, ev); err != nil {
 			return
 		}
 	}
@@ -439,8 +430,7 @@ func LightningNetworkHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("GetTopLightningNodes (best-effort): %v", terr)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // ---- Send tab --------------------------------------------------------------
@@ -452,12 +442,12 @@ func LightningDecodePayReqHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PayReq string `json:"payReq"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.PayReq) == "" {
-		http.Error(w, "payReq required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "payReq required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -467,8 +457,7 @@ func LightningDecodePayReqHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "DecodeLightningInvoice", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningSendPaymentHandler is a WebSocket endpoint that forwards
@@ -494,22 +483,24 @@ func LightningSendPaymentHandler(w http.ResponseWriter, r *http.Request) {
 	// Read the first text frame as the send request.
 	_ = conn.SetReadDeadline(timeFrom(r.Context(), 30*time.Second))
 	mt, raw, err := conn.ReadMessage()
-	_ = conn.SetReadDeadline(time.Time{})
+	// From here on the keep-alive owns the read deadline.
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 	if err != nil {
-		_ = conn.WriteJSON(map[string]string{"error": "no request received"})
+		_ = writeWSJSON(conn, map[string]string{"error": "no request received"})
 		return
 	}
 	if mt != websocket.TextMessage {
-		_ = conn.WriteJSON(map[string]string{"error": "request must be a text frame"})
+		_ = writeWSJSON(conn, map[string]string{"error": "request must be a text frame"})
 		return
 	}
 	var req types.LightningSendPaymentRequest
 	if err := json.Unmarshal(raw, &req); err != nil {
-		_ = conn.WriteJSON(map[string]string{"error": "invalid request body"})
+		_ = writeWSJSON(conn, map[string]string{"error": "invalid request body"})
 		return
 	}
 	if strings.TrimSpace(req.PayReq) == "" {
-		_ = conn.WriteJSON(map[string]string{"error": "payReq required"})
+		_ = writeWSJSON(conn, map[string]string{"error": "payReq required"})
 		return
 	}
 
@@ -530,11 +521,11 @@ func LightningSendPaymentHandler(w http.ResponseWriter, r *http.Request) {
 
 	snaps, err := services.StreamLightningPayment(ctx, &req)
 	if err != nil {
-		_ = conn.WriteJSON(map[string]string{"error": err.Error()})
+		_ = writeWSJSON(conn, map[string]string{"error": err.Error()})
 		return
 	}
 	for snap := range snaps {
-		if err := conn.WriteJSON(snap); err != nil {
+		if err := writeWSJSON(conn, snap); err != nil {
 			return
 		}
 	}
@@ -560,8 +551,7 @@ func LightningPaymentsHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "ListLightningPayments", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // ---- Receive tab -----------------------------------------------------------
@@ -570,16 +560,16 @@ func LightningPaymentsHandler(w http.ResponseWriter, r *http.Request) {
 // returns the canonical record.
 func LightningAddInvoiceHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningAddInvoiceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.ValueAtoms < 0 {
-		http.Error(w, "valueAtoms must be >= 0", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "valueAtoms must be >= 0")
 		return
 	}
 	if len(req.Memo) > 639 {
-		http.Error(w, "memo too long (max 639 chars)", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "memo too long (max 639 chars)")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -589,8 +579,7 @@ func LightningAddInvoiceHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "AddLightningInvoice", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(inv)
+	respondJSON(w, http.StatusOK, inv)
 }
 
 // LightningInvoicesHandler returns the wallet's invoice history for the
@@ -603,8 +592,7 @@ func LightningInvoicesHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "ListLightningInvoices", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningInvoiceEventsHandler is a WebSocket endpoint that forwards
@@ -620,6 +608,8 @@ func LightningInvoiceEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
@@ -636,11 +626,11 @@ func LightningInvoiceEventsHandler(w http.ResponseWriter, r *http.Request) {
 
 	events, err := services.StreamLightningInvoiceEvents(ctx)
 	if err != nil {
-		_ = conn.WriteJSON(map[string]string{"error": err.Error()})
+		_ = writeWSJSON(conn, map[string]string{"error": err.Error()})
 		return
 	}
 	for ev := range events {
-		if err := conn.WriteJSON(ev); err != nil {
+		if err := writeWSJSON(conn, ev); err != nil {
 			return
 		}
 	}
@@ -649,12 +639,12 @@ func LightningInvoiceEventsHandler(w http.ResponseWriter, r *http.Request) {
 // LightningCancelInvoiceHandler cancels an OPEN invoice.
 func LightningCancelInvoiceHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningCancelInvoiceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.PaymentHash) == "" {
-		http.Error(w, "paymentHash required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "paymentHash required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -679,26 +669,24 @@ func LightningBackupExportHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "ExportLightningChannelBackup", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // LightningBackupVerifyHandler validates a user-uploaded backup blob.
 func LightningBackupVerifyHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningVerifyBackupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.BackupBase64) == "" {
-		http.Error(w, "backupBase64 required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "backupBase64 required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 	resp := services.VerifyLightningChannelBackup(ctx, req.BackupBase64)
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningWatchtowersHandler lists registered watchtowers.
@@ -710,19 +698,18 @@ func LightningWatchtowersHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "ListLightningWatchtowers", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // LightningWatchtowerAddHandler registers a new watchtower.
 func LightningWatchtowerAddHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningAddTowerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.PubKeyHex) == "" || strings.TrimSpace(req.Address) == "" {
-		http.Error(w, "pubKeyHex and address required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pubKeyHex and address required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -737,12 +724,12 @@ func LightningWatchtowerAddHandler(w http.ResponseWriter, r *http.Request) {
 // LightningWatchtowerRemoveHandler deregisters a watchtower.
 func LightningWatchtowerRemoveHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningRemoveTowerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.PubKeyHex) == "" {
-		http.Error(w, "pubKeyHex required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pubKeyHex required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -758,7 +745,7 @@ func LightningWatchtowerRemoveHandler(w http.ResponseWriter, r *http.Request) {
 func LightningGraphNodeHandler(w http.ResponseWriter, r *http.Request) {
 	pubkey := strings.TrimSpace(r.URL.Query().Get("pubkey"))
 	if pubkey == "" {
-		http.Error(w, "pubkey query param required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pubkey query param required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -768,19 +755,18 @@ func LightningGraphNodeHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "QueryLightningNodeInfo", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // LightningGraphRoutesHandler queries candidate payment routes.
 func LightningGraphRoutesHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.LightningQueryRoutesRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if strings.TrimSpace(req.PubKey) == "" || req.AmtAtoms <= 0 {
-		http.Error(w, "pubKey + positive amtAtoms required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "pubKey + positive amtAtoms required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
@@ -790,8 +776,7 @@ func LightningGraphRoutesHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "QueryLightningRoutes", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // ---- Liquidity (inbound channel request) -----------------------------------
@@ -806,20 +791,19 @@ func LightningLiquidityDefaultsHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "GetLiquidityDefaults", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // LightningLiquidityEstimateHandler fetches the LP policy and estimated fee
 // for a channel size without paying anything.
 func LightningLiquidityEstimateHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.RequestLiquidityEstimateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.ChanSizeAtoms < 1000 {
-		http.Error(w, "chanSizeAtoms must be at least 1000 (0.00001 DCR)", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "chanSizeAtoms must be at least 1000 (0.00001 DCR)")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -829,20 +813,19 @@ func LightningLiquidityEstimateHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "EstimateLiquidityChannel", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // LightningLiquidityRequestHandler pays the liquidity provider and returns
 // once its channel back to this node is seen pending.
 func LightningLiquidityRequestHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.RequestLiquidityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.ChanSizeAtoms < 1000 {
-		http.Error(w, "chanSizeAtoms must be at least 1000 (0.00001 DCR)", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "chanSizeAtoms must be at least 1000 (0.00001 DCR)")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
@@ -852,6 +835,5 @@ func LightningLiquidityRequestHandler(w http.ResponseWriter, r *http.Request) {
 		lightningWriteErr(w, "RequestLiquidityChannel", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(out)
+	respondJSON(w, http.StatusOK, out)
 }
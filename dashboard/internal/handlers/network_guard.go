@@ -0,0 +1,27 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"dcrpulse/internal/services"
+)
+
+// RequireMatchingNetworks refuses wallet requests other than reads while
+// dcrd and dcrwallet are on different networks, since anything the wallet
+// builds or signs would be checked against the wrong chain. Reads still
+// work, so the dashboard can show what is wrong.
+func RequireMatchingNetworks(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead &&
+			strings.HasPrefix(r.URL.Path, "/api/wallet/") && services.NetworkMismatch() {
+			respondErrorCode(w, http.StatusConflict, ErrCodeNetworkMismatch, services.ErrNetworkMismatch.Error())
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
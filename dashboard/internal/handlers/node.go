@@ -5,52 +5,62 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"dcrpulse/internal/middleware"
 	"dcrpulse/internal/rpc"
 	"dcrpulse/internal/services"
+	"dcrpulse/internal/types"
 
 	"github.com/gorilla/websocket"
 )
 
 // GetDashboardDataHandler handles requests for complete dashboard data
 func GetDashboardDataHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.DcrdClient == nil {
-		http.Error(w, "RPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
-
-	data, err := services.FetchDashboardData()
+	data, failures, err := services.FetchDashboardData(r.Context())
 	if err != nil {
 		log.Printf("Error fetching dashboard data: %v", err)
 		respondDaemonError(w, r, services.LogComponentDcrd, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(data)
+	meta := types.DashboardMeta{FailedSections: []string{}}
+	if len(failures) > 0 {
+		for name := range failures {
+			meta.FailedSections = append(meta.FailedSections, name)
+		}
+		sort.Strings(meta.FailedSections)
+		meta.Errors = failures
+	}
+	respondJSONMeta(w, http.StatusOK, data, meta)
+}
+
+// GetOverviewHandler returns the small summary for clients that only need an
+// at-a-glance view. Sections whose backend is unavailable are omitted rather
+// than failing the request.
+func GetOverviewHandler(w http.ResponseWriter, r *http.Request) {
+	overview := services.FetchOverview(r.Context())
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(services.OverviewCacheTTL/time.Second)))
+	respondJSON(w, http.StatusOK, overview)
 }
 
 // GetNodeStatusHandler handles requests for node status
 func GetNodeStatusHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.DcrdClient == nil {
-		http.Error(w, "RPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
-
-	status, err := services.FetchNodeStatus()
+	status, err := services.FetchNodeStatus(r.Context())
 	if err != nil {
 		log.Printf("Error fetching node status: %v", err)
 		respondDaemonError(w, r, services.LogComponentDcrd, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
+	respondJSON(w, http.StatusOK, status)
 }
 
 // StreamNodeSyncHandler streams dcrd sync-progress snapshots over a WebSocket,
@@ -64,26 +74,17 @@ func StreamNodeSyncHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
-	if err := conn.WriteJSON(services.GetNodeSyncSnapshot()); err != nil {
+	if err := writeWSJSON(conn, services.GetNodeSyncSnapshot()); err != nil {
 		return
 	}
 
 	ch, unsubscribe := services.SubscribeNodeSyncEvents()
 	defer unsubscribe()
 
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
-
-	keepAlive := time.NewTicker(15 * time.Second)
-	defer keepAlive.Stop()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
@@ -91,11 +92,7 @@ func StreamNodeSyncHandler(w http.ResponseWriter, r *http.Request) {
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(snap); err != nil {
-				return
-			}
-		case <-keepAlive.C:
-			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := writeWSJSON(conn, snap); err != nil {
 				return
 			}
 		case <-notify:
@@ -106,38 +103,194 @@ func StreamNodeSyncHandler(w http.ResponseWriter, r *http.Request) {
 
 // GetBlockchainInfoHandler handles requests for blockchain information
 func GetBlockchainInfoHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.DcrdClient == nil {
-		http.Error(w, "RPC client not initialized", http.StatusServiceUnavailable)
+	info, err := services.FetchBlockchainInfo(r.Context())
+	if err != nil {
+		log.Printf("Error fetching blockchain info: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
 		return
 	}
 
-	info, err := services.FetchBlockchainInfo()
+	respondJSON(w, http.StatusOK, info)
+}
+
+// GetBlockchainTipHandler returns the best block's height, hash and times.
+func GetBlockchainTipHandler(w http.ResponseWriter, r *http.Request) {
+	tip, err := services.FetchBlockchainTip(r.Context())
 	if err != nil {
-		log.Printf("Error fetching blockchain info: %v", err)
+		log.Printf("Error fetching blockchain tip: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tip)
+}
+
+// GetTicketPoolHandler returns the live ticket pool's size and locked value.
+func GetTicketPoolHandler(w http.ResponseWriter, r *http.Request) {
+	pool, err := services.FetchTicketPool(r.Context())
+	if err != nil {
+		log.Printf("Error fetching ticket pool: %v", err)
 		respondDaemonError(w, r, services.LogComponentDcrd, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(info)
+	respondJSON(w, http.StatusOK, pool)
 }
 
-// GetPeersHandler handles requests for peer information
+// GetPeersHandler handles requests for peer information. With no query
+// parameters it returns the full peer list. Any of direction=inbound|outbound,
+// sort=ping|bytes|conntime or limit=N returns a PeerList instead, with the
+// filtered list alongside stats over every peer.
 func GetPeersHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.DcrdClient == nil {
-		http.Error(w, "RPC client not initialized", http.StatusServiceUnavailable)
+	q := r.URL.Query()
+	direction := strings.ToLower(strings.TrimSpace(q.Get("direction")))
+	sortBy := strings.ToLower(strings.TrimSpace(q.Get("sort")))
+	limit := 0
+	if direction != "" && direction != "inbound" && direction != "outbound" {
+		respondError(w, http.StatusBadRequest, "direction must be inbound or outbound")
+		return
+	}
+	if sortBy != "" && sortBy != "ping" && sortBy != "bytes" && sortBy != "conntime" {
+		respondError(w, http.StatusBadRequest, "sort must be ping, bytes or conntime")
 		return
 	}
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			respondError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
 
-	peers, err := services.FetchPeers()
+	peers, err := services.FetchPeers(r.Context())
 	if err != nil {
 		log.Printf("Error fetching peers: %v", err)
 		respondDaemonError(w, r, services.LogComponentDcrd, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(peers)
+	if direction == "" && sortBy == "" && limit == 0 {
+		respondJSON(w, http.StatusOK, peers)
+		return
+	}
+
+	list := types.PeerList{Peers: []types.Peer{}, Stats: peerStats(peers)}
+	for _, p := range peers {
+		if direction == "" || p.Inbound == (direction == "inbound") {
+			list.Peers = append(list.Peers, p)
+		}
+	}
+	switch sortBy {
+	case "ping":
+		// Fastest first; peers without a ping sample yet go last.
+		sort.SliceStable(list.Peers, func(i, j int) bool {
+			a, b := list.Peers[i].PingMs, list.Peers[j].PingMs
+			if a == 0 || b == 0 {
+				return b == 0 && a != 0
+			}
+			return a < b
+		})
+	case "bytes":
+		sort.SliceStable(list.Peers, func(i, j int) bool {
+			a, b := list.Peers[i], list.Peers[j]
+			return a.BytesSent+a.BytesRecv > b.BytesSent+b.BytesRecv
+		})
+	case "conntime":
+		// Longest connected first.
+		sort.SliceStable(list.Peers, func(i, j int) bool {
+			return list.Peers[i].ConnectedAt < list.Peers[j].ConnectedAt
+		})
+	}
+	if limit > 0 && len(list.Peers) > limit {
+		list.Peers = list.Peers[:limit]
+	}
+	respondJSON(w, http.StatusOK, list)
+}
+
+// GetNodeInfoHandler returns dcrd's version, connectivity and lower-bound
+// uptime, with dcrpulse's build.
+func GetNodeInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	info, err := services.FetchNodeInfo(ctx)
+	if err != nil {
+		log.Printf("Error fetching node info: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, info)
+}
+
+// GetNetworkSummaryHandler returns release, protocol, bandwidth and service
+// flag aggregates over dcrd's peers.
+func GetNetworkSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	summary, err := services.FetchNetworkSummary(r.Context())
+	if err != nil {
+		log.Printf("Error fetching network summary: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, summary)
+}
+
+func peerStats(peers []types.Peer) types.PeerStats {
+	stats := types.PeerStats{Total: len(peers)}
+	var pings []float64
+	for _, p := range peers {
+		if p.Inbound {
+			stats.Inbound++
+		} else {
+			stats.Outbound++
+		}
+		stats.TotalBytesSent += p.BytesSent
+		stats.TotalBytesRecv += p.BytesRecv
+		if p.PingMs > 0 {
+			pings = append(pings, p.PingMs)
+		}
+	}
+	if n := len(pings); n > 0 {
+		sort.Float64s(pings)
+		if n%2 == 1 {
+			stats.MedianPingMs = pings[n/2]
+		} else {
+			stats.MedianPingMs = (pings[n/2-1] + pings[n/2]) / 2
+		}
+	}
+	return stats
+}
+
+// GetNetworkHandler reports the network dcrd is running on and the chain
+// params dcrpulse derives from it.
+func GetNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	info, err := services.FetchActiveNetwork(ctx)
+	if err != nil {
+		log.Printf("Error fetching active network: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, info)
+}
+
+// GetConsensusParamsHandler returns the consensus parameters dcrpulse derived
+// from the detected network.
+func GetConsensusParamsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	params, err := services.FetchConsensusParams(ctx)
+	if err != nil {
+		log.Printf("Error fetching consensus params: %v", err)
+		respondDaemonError(w, r, services.LogComponentDcrd, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, params)
 }
 
 // HealthCheckHandler handles health check requests
@@ -150,6 +303,56 @@ func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 		"walletTLS":          rpc.WalletUsesTLS(),
 		"time":               time.Now(),
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
+	active, limit := WSConnections()
+	status["webSockets"] = map[string]int{"active": active, "max": limit}
+	match := services.NetworkMatchStatus()
+	status["networkMismatch"] = match.Mismatch
+	if match.DcrdNetwork != "" {
+		status["dcrdNetwork"] = match.DcrdNetwork
+	}
+	if match.WalletNetwork != "" {
+		status["walletNetwork"] = match.WalletNetwork
+	}
+	respondJSON(w, http.StatusOK, status)
+}
+
+// LivenessHandler answers 200 whenever the server can handle a request. It
+// checks no dependencies, so a node outage never gets the process restarted.
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]string{"status": "alive"})
+}
+
+// ReadinessHandler answers 200 when dcrd responds to getblockcount within the
+// probe timeout and 503 otherwise, with each dependency's state either way.
+func ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	readiness := services.CheckReadiness(r.Context())
+	if !readiness.Ready {
+		respondErrorData(w, http.StatusServiceUnavailable, "dcrd is not reachable", readiness)
+		return
+	}
+	respondJSON(w, http.StatusOK, readiness)
+}
+
+// ConnectRPCHandler reconfigures the dcrd and dcrwallet clients from the posted
+// settings. It answers 200 with each client's outcome even when some fail, so
+// the setup UI can point at the part of the configuration that is wrong.
+func ConnectRPCHandler(w http.ResponseWriter, r *http.Request) {
+	var req types.ConnectRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	resp, err := services.ConnectRPC(ctx, req)
+	if errors.Is(err, services.ErrNoConnectConfig) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondRPCError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
 }
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+
+	"dcrpulse/internal/services"
+)
+
+// nodeSyncingWriter marks a response as served while dcrd was syncing;
+// writeEnvelope flags its envelope with nodeSyncing.
+type nodeSyncingWriter struct {
+	http.ResponseWriter
+}
+
+// Flush passes through to the underlying writer.
+func (w *nodeSyncingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *nodeSyncingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WarnWhileNodeSyncing tags a route whose numbers come from dcrd's chain:
+// while dcrd is still in initial block download its responses carry
+// nodeSyncing in the envelope, since they reflect a stale tip. The sync
+// state is the cached node sync snapshot, so the check costs no RPC.
+func WarnWhileNodeSyncing(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if services.NodeSyncing() {
+			w = &nodeSyncingWriter{w}
+		}
+		h(w, r)
+	})
+}
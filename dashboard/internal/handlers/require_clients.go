@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+
+	"dcrpulse/internal/rpc"
+)
+
+// Dependency is an RPC client a route needs connected to do anything.
+type Dependency int
+
+const (
+	// NeedDcrd is dcrd's JSON-RPC client.
+	NeedDcrd Dependency = iota
+	// NeedDcrdNotify is dcrd's websocket notification client.
+	NeedDcrdNotify
+	// NeedWallet is dcrwallet's JSON-RPC client.
+	NeedWallet
+	// NeedWalletGrpc is dcrwallet's gRPC wallet service, set while a wallet
+	// is loaded.
+	NeedWalletGrpc
+	// NeedTxDecoder is dcrwallet's gRPC transaction decoder.
+	NeedTxDecoder
+	// NeedMixer is dcrwallet's gRPC account mixer.
+	NeedMixer
+)
+
+// connected reports whether d's client is set up.
+func (d Dependency) connected() bool {
+	switch d {
+	case NeedDcrd:
+		return rpc.DcrdClient != nil
+	case NeedDcrdNotify:
+		return rpc.DcrdNotifyClient != nil
+	case NeedWallet:
+		return rpc.WalletClient != nil
+	case NeedWalletGrpc:
+		return rpc.WalletGrpcClient != nil
+	case NeedTxDecoder:
+		return rpc.DecodeMessageClient != nil
+	case NeedMixer:
+		return rpc.AccountMixerClient != nil
+	}
+	return false
+}
+
+// String names d's client in the error a request gets without it.
+func (d Dependency) String() string {
+	switch d {
+	case NeedDcrd:
+		return "dcrd RPC client not connected"
+	case NeedDcrdNotify:
+		return "dcrd notification client not connected"
+	case NeedWallet:
+		return "dcrwallet RPC client not connected"
+	case NeedWalletGrpc:
+		return "wallet not loaded: dcrwallet gRPC client not connected"
+	case NeedTxDecoder:
+		return "wallet not loaded: dcrwallet transaction decoder not connected"
+	case NeedMixer:
+		return "dcrwallet account mixer client not connected"
+	}
+	return "unknown dependency"
+}
+
+// RequireClients refuses a request with 503 while any of deps is not
+// connected, naming the first that is missing, so the handler it wraps can
+// take its clients as given.
+func RequireClients(deps ...Dependency) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, d := range deps {
+				if !d.connected() {
+					respondErrorCode(w, http.StatusServiceUnavailable, ErrCodeClientUnavailable, d.String())
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Requires tags a route's handler with the clients it needs; see
+// RequireClients.
+func Requires(h http.HandlerFunc, deps ...Dependency) http.Handler {
+	return RequireClients(deps...)(h)
+}
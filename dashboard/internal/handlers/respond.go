@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"dcrpulse/internal/middleware"
+	"dcrpulse/internal/types"
+)
+
+// EnvelopeHeader marks a response body as a types.APIResponse envelope, so
+// clients can unwrap it without guessing from the body's shape. Middleware
+// writes its refusals with the same header.
+const EnvelopeHeader = middleware.EnvelopeHeader
+
+// Machine-readable error codes. Most errors use the code for their status;
+// handlers pass a specific one through respondErrorCode when clients need to
+// tell failures with the same status apart.
+const (
+	ErrCodeBadRequest      = "bad_request"
+	ErrCodeUnauthorized    = "unauthorized"
+	ErrCodeForbidden       = "forbidden"
+	ErrCodeNotFound        = "not_found"
+	ErrCodeConflict        = "conflict"
+	ErrCodeTooLarge        = "payload_too_large"
+	ErrCodeTooManyRequests = "too_many_requests"
+	ErrCodeInternal        = "internal_error"
+	ErrCodeNotImplemented  = "not_implemented"
+	ErrCodeBadGateway      = "bad_gateway"
+	ErrCodeUnavailable     = "service_unavailable"
+	ErrCodeTimeout         = "timeout"
+
+	// ErrCodeTxUnavailable marks a transaction dcrd can't look up, which
+	// for confirmed transactions usually means it runs without --txindex.
+	ErrCodeTxUnavailable = "tx_unavailable"
+
+	// ErrCodeNetworkMismatch marks a wallet operation refused because dcrd
+	// and dcrwallet are on different networks.
+	ErrCodeNetworkMismatch = "network_mismatch"
+
+	// ErrCodeClientUnavailable marks a request refused because an RPC client
+	// its route requires is not connected; the message names it.
+	ErrCodeClientUnavailable = "client_unavailable"
+
+	// ErrCodeNodeSyncing marks a request refused because dcrd is still in
+	// initial block download.
+	ErrCodeNodeSyncing = "node_syncing"
+)
+
+func errorCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodeTooLarge
+	case http.StatusTooManyRequests:
+		return ErrCodeTooManyRequests
+	case http.StatusNotImplemented:
+		return ErrCodeNotImplemented
+	case http.StatusBadGateway:
+		return ErrCodeBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return ErrCodeTimeout
+	}
+	if status >= 500 {
+		return ErrCodeInternal
+	}
+	return ErrCodeBadRequest
+}
+
+// respondJSON writes data in the standard envelope. A non-2xx status also
+// fills in the envelope's error from the status, keeping data as the
+// handler's structured error body.
+func respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeEnvelope(w, status, types.APIResponse{Data: data})
+}
+
+// respondJSONMeta is respondJSON with envelope meta, e.g. a types.PageMeta.
+func respondJSONMeta(w http.ResponseWriter, status int, data interface{}, meta interface{}) {
+	writeEnvelope(w, status, types.APIResponse{Data: data, Meta: meta})
+}
+
+// respondError writes an error envelope whose code is derived from status.
+func respondError(w http.ResponseWriter, status int, message string) {
+	respondErrorCode(w, status, errorCodeForStatus(status), message)
+}
+
+// respondErrorData writes an error envelope that also carries a structured
+// body, for handlers whose clients read fields off the failed response.
+func respondErrorData(w http.ResponseWriter, status int, message string, data interface{}) {
+	writeEnvelope(w, status, types.APIResponse{
+		Data:  data,
+		Error: &types.APIError{Code: errorCodeForStatus(status), Message: message},
+	})
+}
+
+// respondErrorCode writes an error envelope with an explicit code.
+func respondErrorCode(w http.ResponseWriter, status int, code, message string) {
+	writeEnvelope(w, status, types.APIResponse{
+		Error: &types.APIError{Code: code, Message: message},
+	})
+}
+
+func writeEnvelope(w http.ResponseWriter, status int, env types.APIResponse) {
+	if status >= 400 && env.Error == nil {
+		env.Error = &types.APIError{Code: errorCodeForStatus(status), Message: http.StatusText(status)}
+	}
+	if _, ok := w.(*nodeSyncingWriter); ok {
+		env.NodeSyncing = true
+	}
+	body, err := json.Marshal(env)
+	if err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(types.APIResponse{
+			Error: &types.APIError{Code: ErrCodeInternal, Message: "failed to encode response"},
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(EnvelopeHeader, "1")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
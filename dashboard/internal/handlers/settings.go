@@ -6,7 +6,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"strconv"
@@ -70,8 +69,7 @@ func GetSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.SettingsEnvelope{
+	respondJSON(w, http.StatusOK, types.SettingsEnvelope{
 		Wallet: &walletOut,
 		Global: &globalOut,
 	})
@@ -82,8 +80,8 @@ func GetSettingsHandler(w http.ResponseWriter, r *http.Request) {
 // files are preserved by the WalletCfg/GlobalCfg layers.
 func SaveSettingsHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.SettingsEnvelope
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -94,30 +92,30 @@ func SaveSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		network, err := services.CurrentNetwork(ctx)
 		if err != nil {
 			log.Printf("settings save: network lookup: %v", err)
-			http.Error(w, "network not available", http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, "network not available")
 			return
 		}
 		wc, err := config.LoadWalletCfg(network, services.CurrentWalletName())
 		if err != nil {
 			log.Printf("settings save: load wallet cfg: %v", err)
-			http.Error(w, "failed to load settings", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "failed to load settings")
 			return
 		}
 		if req.Wallet.GapLimit > 0 {
 			if err := wc.Set(config.KeyGapLimit, req.Wallet.GapLimit); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				respondError(w, http.StatusInternalServerError, err.Error())
 				return
 			}
 		}
 		if req.Wallet.CurrencyDisplay != "" {
 			if err := wc.Set("currency_display", req.Wallet.CurrencyDisplay); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				respondError(w, http.StatusInternalServerError, err.Error())
 				return
 			}
 		}
 		if err := wc.Save(); err != nil {
 			log.Printf("settings save: save wallet cfg: %v", err)
-			http.Error(w, "failed to save settings", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "failed to save settings")
 			return
 		}
 	}
@@ -126,7 +124,7 @@ func SaveSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		gc, err := config.LoadGlobalCfg()
 		if err != nil {
 			log.Printf("settings save: load global cfg: %v", err)
-			http.Error(w, "failed to load global settings", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "failed to load global settings")
 			return
 		}
 		allowed, _ := gc.AllowedExternalRequests()
@@ -137,12 +135,12 @@ func SaveSettingsHandler(w http.ResponseWriter, r *http.Request) {
 		allowed[config.ExternalRequestPoliteia] = req.Global.ExternalRequests.Politeia
 		allowed[config.ExternalRequestBrseeder] = req.Global.ExternalRequests.Brseeder
 		if err := gc.SetAllowedExternalRequests(allowed); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 		botURL := strings.TrimRight(strings.TrimSpace(req.Global.DecredPulseBotURL), "/")
 		if botURL != "" && !strings.HasPrefix(botURL, "http://") && !strings.HasPrefix(botURL, "https://") {
-			http.Error(w, "Decred Pulse bot URL must start with http:// or https://", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "Decred Pulse bot URL must start with http:// or https://")
 			return
 		}
 		botEnabled := true
@@ -154,17 +152,17 @@ func SaveSettingsHandler(w http.ResponseWriter, r *http.Request) {
 			err := services.CheckDecredPulseBotHealth(probeCtx, botURL)
 			probeCancel()
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadGateway)
+				respondError(w, http.StatusBadGateway, err.Error())
 				return
 			}
 		}
 		if err := gc.Set(config.KeyDecredPulseBotURL, botURL); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 		if err := gc.Save(); err != nil {
 			log.Printf("settings save: save global cfg: %v", err)
-			http.Error(w, "failed to save global settings", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "failed to save global settings")
 			return
 		}
 	}
@@ -175,20 +173,20 @@ func SaveSettingsHandler(w http.ResponseWriter, r *http.Request) {
 // ChangePassphraseHandler rotates the wallet's private passphrase.
 func ChangePassphraseHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.ChangePassphraseRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.NewPassphrase == "" {
-		http.Error(w, "newPassphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "newPassphrase required")
 		return
 	}
 	if len(req.NewPassphrase) < 8 {
-		http.Error(w, "new passphrase must be at least 8 characters", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "new passphrase must be at least 8 characters")
 		return
 	}
 	if len(req.OldPassphrase) > 1024 || len(req.NewPassphrase) > 1024 {
-		http.Error(w, "passphrase too long", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase too long")
 		return
 	}
 
@@ -211,10 +209,10 @@ func ChangePassphraseHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		default:
 			log.Printf("ChangePrivatePassphrase failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
@@ -242,11 +240,10 @@ func GetLogsHandler(w http.ResponseWriter, r *http.Request) {
 	out, err := services.TailLog(ctx, services.LogComponent(component), lines)
 	if err != nil {
 		log.Printf("TailLog(%s): %v", component, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{
+	respondJSON(w, http.StatusOK, map[string]any{
 		"component": component,
 		"lines":     out,
 	})
@@ -256,19 +253,19 @@ func GetLogsHandler(w http.ResponseWriter, r *http.Request) {
 // addresses under the requested gap limit. Long-running.
 func DiscoverAddressesHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.DiscoverUsageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if req.GapLimit == 0 {
 		req.GapLimit = 200
 	}
 	if req.GapLimit > 10000 {
-		http.Error(w, "gapLimit too large (max 10000)", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "gapLimit too large (max 10000)")
 		return
 	}
 
@@ -296,10 +293,10 @@ func DiscoverAddressesHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		default:
 			log.Printf("DiscoverUsage failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
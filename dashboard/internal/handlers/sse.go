@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"dcrpulse/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+// sseHeartbeat is how often an idle Server-Sent Events stream writes a
+// comment line, so proxies and the browser don't drop it as dead.
+const sseHeartbeat = 15 * time.Second
+
+// sseStream writes Server-Sent Events: each payload is one "data:" event
+// holding the same JSON the WebSocket and polling endpoints return.
+type sseStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// startSSE sends the event-stream headers. It reports false, having already
+// answered the request, if the connection can't be flushed per event.
+func startSSE(w http.ResponseWriter) (*sseStream, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, "Streaming not supported")
+		return nil, false
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	// Ask nginx-style proxies not to buffer the stream.
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &sseStream{w: w, flusher: flusher}, true
+}
+
+// event writes v as a data event.
+func (s *sseStream) event(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// heartbeat writes a comment line, which EventSource ignores.
+func (s *sseStream) heartbeat() error {
+	if _, err := fmt.Fprint(s.w, ": heartbeat\n\n"); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// StreamRescanProgressSSEHandler is the Server-Sent Events form of
+// /wallet/stream-rescan-progress, fed by the same shared sync broadcaster.
+func StreamRescanProgressSSEHandler(w http.ResponseWriter, r *http.Request) {
+	initial, ch, unsubscribe := subscribeSyncPayloads()
+	defer unsubscribe()
+
+	stream, ok := startSSE(w)
+	if !ok {
+		return
+	}
+	if err := stream.event(initial); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(sseHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case payload, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := stream.event(payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := stream.heartbeat(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// StreamTSpendScanProgressSSEHandler streams historical scan progress as
+// Server-Sent Events: an event each time the progress version changes, woken
+// by the same signal as /treasury/scan-progress/wait. The current progress
+// is sent first.
+func StreamTSpendScanProgressSSEHandler(w http.ResponseWriter, r *http.Request) {
+	progress, err := services.GetScanProgress()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	stream, ok := startSSE(w)
+	if !ok {
+		return
+	}
+	if err := stream.event(progress); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	since := progress.Version
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, sseHeartbeat)
+		progress := services.WaitScanProgress(waitCtx, since)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		var err error
+		if progress.Version != since {
+			since = progress.Version
+			err = stream.event(progress)
+		} else {
+			err = stream.heartbeat()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// StreamVoteParsingProgressSSEHandler streams a tspend's vote counting
+// progress as Server-Sent Events, starting with the current progress. Until
+// a count starts there is nothing to send, so the stream only carries
+// heartbeats.
+func StreamVoteParsingProgressSSEHandler(w http.ResponseWriter, r *http.Request) {
+	txHash := mux.Vars(r)["txhash"]
+	stream, ok := startSSE(w)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	var since uint64
+	if progress, exists := services.GetVoteParsingProgress(txHash); exists {
+		if err := stream.event(progress); err != nil {
+			return
+		}
+		since = progress.Version
+	}
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, sseHeartbeat)
+		progress, exists := services.WaitVoteParsingProgress(waitCtx, txHash, since)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		var err error
+		if exists && progress.Version != since {
+			since = progress.Version
+			err = stream.event(progress)
+		} else {
+			err = stream.heartbeat()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -6,7 +6,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"strings"
@@ -49,15 +48,14 @@ func ListVSPsHandler(w http.ResponseWriter, r *http.Request) {
 		envelope.UsedVSPs = used
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(envelope)
+	respondJSON(w, http.StatusOK, envelope)
 }
 
 // VSPInfoHandler probes one VSP host's /api/v3/vspinfo.
 func VSPInfoHandler(w http.ResponseWriter, r *http.Request) {
 	host := strings.TrimSpace(r.URL.Query().Get("host"))
 	if host == "" {
-		http.Error(w, "host query param required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "host query param required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 8*time.Second)
@@ -65,11 +63,10 @@ func VSPInfoHandler(w http.ResponseWriter, r *http.Request) {
 	info, err := services.GetVSPInfo(ctx, host)
 	if err != nil {
 		log.Printf("GetVSPInfo(%s) failed: %v", host, err)
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(info)
+	respondJSON(w, http.StatusOK, info)
 }
 
 // PurchaseTicketsHandler triggers a ticket purchase via dcrwallet.
@@ -78,20 +75,20 @@ func PurchaseTicketsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req types.PurchaseTicketsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if req.NumTickets == 0 {
-		http.Error(w, "numTickets must be > 0", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "numTickets must be > 0")
 		return
 	}
 	if req.VspHost == "" || req.VspPubkey == "" {
-		http.Error(w, "vspHost and vspPubkey required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "vspHost and vspPubkey required")
 		return
 	}
 
@@ -111,16 +108,14 @@ func PurchaseTicketsHandler(w http.ResponseWriter, r *http.Request) {
 	if _, mixed := services.TicketMixingParams(r.Context()); mixed {
 		if err := services.StartPurchaseWorker(req.Account, req.NumTickets, req.VspHost, req.VspPubkey, req.ChangeAccount, passphrase); err != nil {
 			if strings.Contains(strings.ToLower(err.Error()), "already in progress") {
-				http.Error(w, err.Error(), http.StatusConflict)
+				respondError(w, http.StatusConflict, err.Error())
 				return
 			}
 			log.Printf("StartPurchaseWorker failed: %v", err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			respondRPCError(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusAccepted)
-		json.NewEncoder(w).Encode(types.PurchaseTicketsAsyncResponse{Async: true})
+		respondJSON(w, http.StatusAccepted, types.PurchaseTicketsAsyncResponse{Async: true})
 		return
 	}
 
@@ -133,26 +128,24 @@ func PurchaseTicketsHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "already in progress"):
-			http.Error(w, msg, http.StatusConflict)
+			respondError(w, http.StatusConflict, msg)
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		case strings.Contains(lower, "insufficient"):
-			http.Error(w, msg, http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, msg)
 		default:
 			log.Printf("PurchaseTickets failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // PurchaseStatusHandler reports whether a background (mixed) purchase is running
 // plus the most recent terminal result, so a reloaded page can re-attach.
 func PurchaseStatusHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(services.PurchaseStatusSnapshot())
+	respondJSON(w, http.StatusOK, services.PurchaseStatusSnapshot())
 }
 
 // StreamPurchaseEventsHandler upgrades to WebSocket and streams manual ticket
@@ -167,9 +160,11 @@ func StreamPurchaseEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	for _, ev := range services.LastPurchaseEvents(200) {
-		if err := conn.WriteJSON(ev); err != nil {
+		if err := writeWSJSON(conn, ev); err != nil {
 			return
 		}
 	}
@@ -177,15 +172,7 @@ func StreamPurchaseEventsHandler(w http.ResponseWriter, r *http.Request) {
 	ch, unsubscribe := services.SubscribePurchaseEvents()
 	defer unsubscribe()
 
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
@@ -193,7 +180,7 @@ func StreamPurchaseEventsHandler(w http.ResponseWriter, r *http.Request) {
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(ev); err != nil {
+			if err := writeWSJSON(conn, ev); err != nil {
 				return
 			}
 		case <-notify:
@@ -209,11 +196,10 @@ func ListTicketsHandler(w http.ResponseWriter, r *http.Request) {
 	tickets, err := services.ListTickets(ctx)
 	if err != nil {
 		log.Printf("ListTickets failed: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tickets)
+	respondJSON(w, http.StatusOK, tickets)
 }
 
 // AutobuyerStatusHandler returns running flag + last error + persisted settings.
@@ -221,8 +207,7 @@ func AutobuyerStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 	status := services.AutobuyerStatusSnapshot(ctx)
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
+	respondJSON(w, http.StatusOK, status)
 }
 
 // GetAutobuyerSettingsHandler returns the persisted settings or null.
@@ -232,37 +217,36 @@ func GetAutobuyerSettingsHandler(w http.ResponseWriter, r *http.Request) {
 	settings, err := services.LoadAutobuyerSettings(ctx)
 	if err != nil {
 		log.Printf("LoadAutobuyerSettings: %v", err)
-		http.Error(w, "failed to load settings", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to load settings")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
 	if settings == nil {
-		w.Write([]byte("null"))
+		respondJSON(w, http.StatusOK, nil)
 		return
 	}
-	json.NewEncoder(w).Encode(settings)
+	respondJSON(w, http.StatusOK, settings)
 }
 
 // SaveAutobuyerSettingsHandler atomically persists settings to disk.
 func SaveAutobuyerSettingsHandler(w http.ResponseWriter, r *http.Request) {
 	var s types.AutobuyerSettings
-	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &s); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if s.VspHost == "" || s.VspPubkey == "" {
-		http.Error(w, "vspHost and vspPubkey required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "vspHost and vspPubkey required")
 		return
 	}
 	if s.BalanceToMaintain < 0 {
-		http.Error(w, "balanceToMaintain must be >= 0", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "balanceToMaintain must be >= 0")
 		return
 	}
 	saveCtx, saveCancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer saveCancel()
 	if err := services.SaveAutobuyerSettings(saveCtx, &s); err != nil {
 		log.Printf("SaveAutobuyerSettings: %v", err)
-		http.Error(w, "failed to save settings", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to save settings")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -274,20 +258,20 @@ func StartAutobuyerHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req types.StartAutobuyerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if req.VspHost == "" || req.VspPubkey == "" {
-		http.Error(w, "vspHost and vspPubkey required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "vspHost and vspPubkey required")
 		return
 	}
 	if req.BalanceToMaintain < 0 {
-		http.Error(w, "balanceToMaintain must be >= 0", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "balanceToMaintain must be >= 0")
 		return
 	}
 
@@ -304,12 +288,12 @@ func StartAutobuyerHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		case strings.Contains(lower, "already running"):
-			http.Error(w, msg, http.StatusConflict)
+			respondError(w, http.StatusConflict, msg)
 		default:
 			log.Printf("StartAutobuyer failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
@@ -333,9 +317,11 @@ func StreamAutobuyerEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	for _, ev := range services.LastAutobuyerEvents(200) {
-		if err := conn.WriteJSON(ev); err != nil {
+		if err := writeWSJSON(conn, ev); err != nil {
 			return
 		}
 	}
@@ -343,15 +329,7 @@ func StreamAutobuyerEventsHandler(w http.ResponseWriter, r *http.Request) {
 	ch, unsubscribe := services.SubscribeAutobuyerEvents()
 	defer unsubscribe()
 
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
@@ -359,7 +337,7 @@ func StreamAutobuyerEventsHandler(w http.ResponseWriter, r *http.Request) {
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(ev); err != nil {
+			if err := writeWSJSON(conn, ev); err != nil {
 				return
 			}
 		case <-notify:
@@ -371,16 +349,16 @@ func StreamAutobuyerEventsHandler(w http.ResponseWriter, r *http.Request) {
 // SyncFailedVSPTicketsHandler retries VSP fee payments for failed tickets.
 func SyncFailedVSPTicketsHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.SyncFailedVSPTicketsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if req.VspHost == "" || req.VspPubkey == "" {
-		http.Error(w, "vspHost and vspPubkey required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "vspHost and vspPubkey required")
 		return
 	}
 
@@ -400,30 +378,29 @@ func SyncFailedVSPTicketsHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		default:
 			log.Printf("SyncFailedVSPTickets failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(summary)
+	respondJSON(w, http.StatusOK, summary)
 }
 
 // ProcessUnmanagedVSPTicketsHandler re-associates untracked tickets with a VSP.
 func ProcessUnmanagedVSPTicketsHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.SyncFailedVSPTicketsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if req.VspHost == "" || req.VspPubkey == "" {
-		http.Error(w, "vspHost and vspPubkey required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "vspHost and vspPubkey required")
 		return
 	}
 
@@ -443,13 +420,12 @@ func ProcessUnmanagedVSPTicketsHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		default:
 			log.Printf("ProcessUnmanagedVSPTickets failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(summary)
+	respondJSON(w, http.StatusOK, summary)
 }
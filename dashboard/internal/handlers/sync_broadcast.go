@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/services"
+)
+
+// DefaultSyncPollInterval is how long the sync streams go without a sync
+// notification before the heights are read again, unless SetSyncPollInterval
+// overrides it.
+const DefaultSyncPollInterval = 10 * time.Second
+
+// syncBroadcaster renders the sync payload once per sync snapshot and fans it
+// out to every wallet sync stream. Rendering reads the wallet's and dcrd's
+// best heights, so streams sharing one rendering keep the node's load the
+// same however many browsers are connected. It runs while any stream is
+// subscribed.
+type syncBroadcaster struct {
+	stop context.CancelFunc
+	subs []chan map[string]interface{}
+	last map[string]interface{}
+}
+
+var (
+	syncHubMu        sync.Mutex
+	syncHub          *syncBroadcaster
+	syncPollInterval = DefaultSyncPollInterval
+)
+
+// SetSyncPollInterval sets how long the sync streams go without a sync
+// notification before the payload is rendered again from the current
+// snapshot. Zero keeps the default.
+func SetSyncPollInterval(d time.Duration) {
+	syncHubMu.Lock()
+	defer syncHubMu.Unlock()
+	if d > 0 {
+		syncPollInterval = d
+	}
+}
+
+// subscribeSyncPayloads returns the current sync payload, a channel of the
+// payloads after it and a cleanup func. The first subscriber starts the
+// broadcaster and renders the current payload; later ones share its last.
+// Payloads are shared between streams and must not be modified.
+func subscribeSyncPayloads() (map[string]interface{}, <-chan map[string]interface{}, func()) {
+	ch := make(chan map[string]interface{}, 8)
+	syncHubMu.Lock()
+	defer syncHubMu.Unlock()
+	if syncHub == nil {
+		// Subscribe before rendering, so no snapshot falls between the two.
+		events, unsubscribe := services.SubscribeSyncEvents()
+		ctx, cancel := context.WithCancel(context.Background())
+		syncHub = &syncBroadcaster{
+			stop: cancel,
+			last: snapshotPayload(services.GetSyncSnapshot()),
+		}
+		go syncHub.run(ctx, events, unsubscribe, syncPollInterval)
+	}
+	hub := syncHub
+	hub.subs = append(hub.subs, ch)
+	return hub.last, ch, func() {
+		syncHubMu.Lock()
+		defer syncHubMu.Unlock()
+		for i, sub := range hub.subs {
+			if sub == ch {
+				hub.subs = append(hub.subs[:i], hub.subs[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+		if len(hub.subs) == 0 && syncHub == hub {
+			hub.stop()
+			syncHub = nil
+		}
+	}
+}
+
+// run renders a payload for every sync snapshot, and from the current
+// snapshot when none has come for a poll interval, until ctx is done. A
+// polled payload is only sent when it differs from the last one.
+func (b *syncBroadcaster) run(ctx context.Context, events <-chan services.SyncSnapshot, unsubscribe func(), poll time.Duration) {
+	defer unsubscribe()
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case snap, ok := <-events:
+			if !ok {
+				return
+			}
+			b.publish(snapshotPayload(snap), true)
+			ticker.Reset(poll)
+		case <-ticker.C:
+			b.publish(snapshotPayload(services.GetSyncSnapshot()), false)
+		}
+	}
+}
+
+// publish sends payload to every subscriber, unless always is false and it
+// matches the last one. A subscriber whose buffer is full misses it.
+func (b *syncBroadcaster) publish(payload map[string]interface{}, always bool) {
+	syncHubMu.Lock()
+	defer syncHubMu.Unlock()
+	if !always && reflect.DeepEqual(payload, b.last) {
+		return
+	}
+	b.last = payload
+	for _, sub := range b.subs {
+		select {
+		case sub <- payload:
+		default:
+		}
+	}
+}
@@ -41,8 +41,7 @@ func GetThemesHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(store)
+	respondJSON(w, http.StatusOK, store)
 }
 
 // SaveThemesHandler persists the theme store. Custom themes are stored
@@ -50,16 +49,16 @@ func GetThemesHandler(w http.ResponseWriter, r *http.Request) {
 // frontend. The body size is bounded by the global JSON-body limit.
 func SaveThemesHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.ThemeStore
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Schema != themeSchemaVersion {
-		http.Error(w, "unsupported theme schema", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "unsupported theme schema")
 		return
 	}
 	if len(req.CustomThemes) > maxCustomThemes {
-		http.Error(w, "too many custom themes", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "too many custom themes")
 		return
 	}
 	if req.CustomThemes == nil {
@@ -69,16 +68,16 @@ func SaveThemesHandler(w http.ResponseWriter, r *http.Request) {
 	gc, err := config.LoadGlobalCfg()
 	if err != nil {
 		log.Printf("themes save: load global cfg: %v", err)
-		http.Error(w, "failed to load themes", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to load themes")
 		return
 	}
 	if err := gc.Set(config.KeyThemeStore, req); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	if err := gc.Save(); err != nil {
 		log.Printf("themes save: save global cfg: %v", err)
-		http.Error(w, "failed to save themes", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to save themes")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -32,17 +32,12 @@ func timestampArchive(w http.ResponseWriter) (*timestamp.Store, bool) {
 	store, err := timestamp.Archive()
 	if err != nil {
 		log.Printf("timestamp: open archive: %v", err)
-		http.Error(w, "failed to open timestamp archive", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to open timestamp archive")
 		return nil, false
 	}
 	return store, true
 }
 
-func writeJSON(w http.ResponseWriter, v any) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(v)
-}
-
 type createTimestampRequest struct {
 	Digest      string   `json:"digest"`
 	Filename    string   `json:"filename"`
@@ -58,13 +53,13 @@ type createTimestampRequest struct {
 // file itself is never sent; the browser hashes it and posts only the digest.
 func CreateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	var req createTimestampRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	digest := strings.ToLower(strings.TrimSpace(req.Digest))
 	if !timestamp.ValidDigest(digest) {
-		http.Error(w, "digest must be a 64-character hex sha256", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "digest must be a 64-character hex sha256")
 		return
 	}
 	store, ok := timestampArchive(w)
@@ -86,16 +81,14 @@ func CreateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	if err := store.Create(rec); err != nil {
 		if errors.Is(err, timestamp.ErrDuplicate) {
 			existing, _ := store.Get(digest)
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]any{
+			respondErrorData(w, http.StatusConflict, "this file is already in your archive", map[string]any{
 				"error":  "this file is already in your archive",
 				"record": existing,
 			})
 			return
 		}
 		log.Printf("timestamp: create %s: %v", digest, err)
-		http.Error(w, "failed to save record", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to save record")
 		return
 	}
 
@@ -127,7 +120,7 @@ func CreateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	final, _ := store.Get(digest)
-	writeJSON(w, final)
+	respondJSON(w, http.StatusOK, final)
 }
 
 // ListTimestampsHandler returns the archive, filtered/sorted by query params.
@@ -143,7 +136,7 @@ func ListTimestampsHandler(w http.ResponseWriter, r *http.Request) {
 		Tag:    q.Get("tag"),
 		Sort:   q.Get("sort"),
 	})
-	writeJSON(w, records)
+	respondJSON(w, http.StatusOK, records)
 }
 
 // GetTimestampHandler returns one record.
@@ -154,10 +147,10 @@ func GetTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	rec, err := store.Get(digestVar(r))
 	if err != nil {
-		http.Error(w, "record not found", http.StatusNotFound)
+		respondError(w, http.StatusNotFound, "record not found")
 		return
 	}
-	writeJSON(w, rec)
+	respondJSON(w, http.StatusOK, rec)
 }
 
 type updateTimestampRequest struct {
@@ -169,8 +162,8 @@ type updateTimestampRequest struct {
 // UpdateTimestampHandler edits the user-supplied metadata only.
 func UpdateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	var req updateTimestampRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	store, ok := timestampArchive(w)
@@ -185,15 +178,15 @@ func UpdateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 		return nil
 	})
 	if errors.Is(err, timestamp.ErrNotFound) {
-		http.Error(w, "record not found", http.StatusNotFound)
+		respondError(w, http.StatusNotFound, "record not found")
 		return
 	}
 	if err != nil {
-		http.Error(w, "failed to update record", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to update record")
 		return
 	}
 	rec, _ := store.Get(digest)
-	writeJSON(w, rec)
+	respondJSON(w, http.StatusOK, rec)
 }
 
 // DeleteTimestampHandler removes a record and its proof from the archive.
@@ -204,11 +197,11 @@ func DeleteTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	err := store.Delete(digestVar(r))
 	if errors.Is(err, timestamp.ErrNotFound) {
-		http.Error(w, "record not found", http.StatusNotFound)
+		respondError(w, http.StatusNotFound, "record not found")
 		return
 	}
 	if err != nil {
-		http.Error(w, "failed to delete record", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to delete record")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -223,7 +216,7 @@ func RetryTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	digest := digestVar(r)
 	rec, err := store.Get(digest)
 	if err != nil {
-		http.Error(w, "record not found", http.StatusNotFound)
+		respondError(w, http.StatusNotFound, "record not found")
 		return
 	}
 	ctx, cancel := reqCtx(r, 15*time.Second)
@@ -258,7 +251,7 @@ func RetryTimestampHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	final, _ := store.Get(digest)
-	writeJSON(w, final)
+	respondJSON(w, http.StatusOK, final)
 }
 
 // TimestampProofHandler downloads the self-contained proof JSON for a record.
@@ -269,11 +262,11 @@ func TimestampProofHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	rec, err := store.Get(digestVar(r))
 	if err != nil {
-		http.Error(w, "record not found", http.StatusNotFound)
+		respondError(w, http.StatusNotFound, "record not found")
 		return
 	}
 	if rec.Status != timestamp.StatusAnchored {
-		http.Error(w, "record is not anchored yet", http.StatusConflict)
+		respondError(w, http.StatusConflict, "record is not anchored yet")
 		return
 	}
 	ctx := r.Context()
@@ -281,6 +274,7 @@ func TimestampProofHandler(w http.ResponseWriter, r *http.Request) {
 	name := fmt.Sprintf("timestamp-proof-%s.json", short(rec.Digest))
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Content-Disposition", "attachment; filename=\""+name+"\"")
+	// A downloaded proof file, not an API response, so it isn't enveloped.
 	json.NewEncoder(w).Encode(proof)
 }
 
@@ -292,13 +286,13 @@ type verifyTimestampRequest struct {
 // (when anchored) the Decred chain via dcrpulse's own dcrd.
 func VerifyTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	var req verifyTimestampRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	digest := strings.ToLower(strings.TrimSpace(req.Digest))
 	if !timestamp.ValidDigest(digest) {
-		http.Error(w, "digest must be a 64-character hex sha256", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "digest must be a 64-character hex sha256")
 		return
 	}
 	store, ok := timestampArchive(w)
@@ -316,7 +310,7 @@ func VerifyTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	vr, err := timestamp.Verify(ctx, store.ClientID(), []string{digest})
 	if err != nil {
 		resp["dcrtimeError"] = err.Error()
-		writeJSON(w, resp)
+		respondJSON(w, http.StatusOK, resp)
 		return
 	}
 	res, found := vr[digest]
@@ -328,7 +322,7 @@ func VerifyTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	if resp["inArchive"] == true && found {
 		timestamp.ApplyResult(store, digest, res)
 	}
-	writeJSON(w, resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 type validateTimestampRequest struct {
@@ -343,8 +337,8 @@ type validateTimestampRequest struct {
 // digest is given, looked up from the archive.
 func ValidateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	var req validateTimestampRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	digest := strings.ToLower(strings.TrimSpace(req.Digest))
@@ -358,7 +352,7 @@ func ValidateTimestampHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := reqCtx(r, 15*time.Second)
 	defer cancel()
 	val := timestamp.ValidateProof(ctx, digest, req.MerkleRoot, req.MerklePath, req.TxID)
-	writeJSON(w, val)
+	respondJSON(w, http.StatusOK, val)
 }
 
 // RefreshTimestampsHandler triggers an immediate anchor poll, then returns the
@@ -371,7 +365,7 @@ func RefreshTimestampsHandler(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-	writeJSON(w, store.List(timestamp.Query{Sort: r.URL.Query().Get("sort")}))
+	respondJSON(w, http.StatusOK, store.List(timestamp.Query{Sort: r.URL.Query().Get("sort")}))
 }
 
 // TimestampStatusHandler reports feature health for the tab header. Pass ?ping=1
@@ -400,7 +394,7 @@ func TimestampStatusHandler(w http.ResponseWriter, r *http.Request) {
 			resp["reachable"] = true
 		}
 	}
-	writeJSON(w, resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // ExportTimestampsHandler downloads the full archive as a JSON array.
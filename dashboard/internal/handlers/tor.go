@@ -5,54 +5,48 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"dcrpulse/internal/services"
 	"dcrpulse/internal/types"
 )
 
-func torWriteJSON(w http.ResponseWriter, v any) {
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(v)
-}
-
 // GetTorHandler returns the current Tor toggle settings.
 func GetTorHandler(w http.ResponseWriter, r *http.Request) {
-	torWriteJSON(w, services.ReadTorSettings())
+	respondJSON(w, http.StatusOK, services.ReadTorSettings())
 }
 
 // SetTorHandler persists Tor settings, bumping the rev so the supervisors
 // relaunch their daemons with or without the proxy flags.
 func SetTorHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.TorSettings
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	out, err := services.WriteTorSettings(req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	torWriteJSON(w, out)
+	respondJSON(w, http.StatusOK, out)
 }
 
 // GetTorStatusHandler returns proxy reachability, per-daemon routing state, and
 // the dcrd onion address.
 func GetTorStatusHandler(w http.ResponseWriter, r *http.Request) {
-	torWriteJSON(w, services.TorStatusSnapshot())
+	respondJSON(w, http.StatusOK, services.TorStatusSnapshot())
 }
 
 // GetTorControlHandler returns live data from the Tor control port.
 func GetTorControlHandler(w http.ResponseWriter, r *http.Request) {
-	torWriteJSON(w, services.TorControlSnapshot())
+	respondJSON(w, http.StatusOK, services.TorControlSnapshot())
 }
 
 // TorNewIdentityHandler signals Tor to build fresh circuits (NEWNYM).
 func TorNewIdentityHandler(w http.ResponseWriter, r *http.Request) {
 	if err := services.TorNewIdentity(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		respondError(w, http.StatusBadGateway, err.Error())
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -6,14 +6,20 @@ package handlers
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"dcrpulse/internal/services"
+	"dcrpulse/internal/types"
+
+	"github.com/gorilla/mux"
 )
 
 // GetTreasuryInfoHandler returns current treasury status
@@ -24,12 +30,11 @@ func GetTreasuryInfoHandler(w http.ResponseWriter, r *http.Request) {
 	info, err := services.FetchTreasuryInfo(ctx)
 	if err != nil {
 		log.Printf("Error fetching treasury info: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(info)
+	respondJSON(w, http.StatusOK, info)
 }
 
 // GetTreasuryBalanceHistoryHandler returns the treasury balance-over-time
@@ -41,43 +46,187 @@ func GetTreasuryBalanceHistoryHandler(w http.ResponseWriter, r *http.Request) {
 	series, err := services.TreasuryBalanceHistory(ctx)
 	if err != nil {
 		log.Printf("Error fetching treasury balance history: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(series)
+	respondJSON(w, http.StatusOK, series)
+}
+
+// GetTreasuryFlowHandler returns treasury activity from the historical scan
+// results, bucketed by ?interval=month|week (default month).
+func GetTreasuryFlowHandler(w http.ResponseWriter, r *http.Request) {
+	flow, err := services.TreasuryFlow(r.URL.Query().Get("interval"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, flow)
 }
 
-// TriggerTSpendScanHandler triggers a historical blockchain scan for TSpends
+// GetTreasuryPayeesHandler returns the historical scan results totalled by
+// recipient address, largest total first.
+func GetTreasuryPayeesHandler(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, services.TreasuryPayees())
+}
+
+// TriggerTSpendScanHandler triggers a historical blockchain scan for TSpends.
+// The body selects a services.ScanProfile; no body is a full scan.
 func TriggerTSpendScanHandler(w http.ResponseWriter, r *http.Request) {
-	// Parse request body to get startHeight (optional)
-	var req struct {
-		StartHeight int64 `json:"startHeight"`
+	var req services.ScanProfile
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		// If no body or invalid, default to treasury activation height
-		req.StartHeight = 552448
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	startHeight, endHeight, err := services.TriggerHistoricalScan(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidScanMode), errors.Is(err, services.ErrInvalidScanRange):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrScanInProgress):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrNodeSyncing):
+			respondErrorCode(w, http.StatusServiceUnavailable, ErrCodeNodeSyncing, err.Error())
+		default:
+			log.Printf("Error triggering TSpend scan: %v", err)
+			respondRPCError(w, err)
+		}
+		return
 	}
 
-	// If startHeight is 0 or invalid, use treasury activation height
-	if req.StartHeight < 552448 {
-		req.StartHeight = 552448
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":     true,
+		"message":     fmt.Sprintf("Historical TSpend scan started from block %d to %d", startHeight, endHeight),
+		"startHeight": startHeight,
+		"endHeight":   endHeight,
+	})
+}
+
+// EstimateTSpendScanHandler estimates how long a historical scan would take,
+// without starting one. The query selects the scan as the scan-history body
+// does: mode (full, recent or range), from, to, blocks and days.
+func EstimateTSpendScanHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	profile := services.ScanProfile{Mode: q.Get("mode")}
+	for _, p := range []struct {
+		name string
+		dst  *int64
+	}{
+		{"from", &profile.StartHeight},
+		{"to", &profile.EndHeight},
+		{"blocks", &profile.Blocks},
+		{"days", &profile.Days},
+	} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			respondError(w, http.StatusBadRequest, "invalid "+p.name)
+			return
+		}
+		*p.dst = n
 	}
 
-	err := services.TriggerHistoricalScan(req.StartHeight)
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	est, err := services.EstimateHistoricalScan(ctx, profile)
 	if err != nil {
-		log.Printf("Error triggering TSpend scan: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, services.ErrInvalidScanMode), errors.Is(err, services.ErrInvalidScanRange):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Printf("Error estimating TSpend scan: %v", err)
+			respondDaemonError(w, r, services.LogComponentDcrd, err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, est)
+}
+
+// ScanTSpendHeightsHandler re-scans the heights and ranges in the body and
+// merges any new TSpends into the scan results, answering once done.
+func ScanTSpendHeightsHandler(w http.ResponseWriter, r *http.Request) {
+	var req types.ScanHeightsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	result, err := services.ScanHeights(ctx, req)
+	switch {
+	case errors.Is(err, services.ErrInvalidScanRange):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrScanInProgress):
+		respondError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		log.Printf("Error scanning TSpend heights: %v", err)
+		respondRPCError(w, err)
+	default:
+		respondJSON(w, http.StatusOK, result)
+	}
+}
+
+// ExportScanSnapshotHandler downloads the treasury scan results as a hashed
+// snapshot another instance can import.
+func ExportScanSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	snap, err := services.ExportScanSnapshot(ctx)
+	if err != nil {
+		log.Printf("Error exporting treasury scan snapshot: %v", err)
+		respondRPCError(w, err)
+		return
+	}
+	filename := fmt.Sprintf("dcrpulse-tspend-scan-%s-%d.json", snap.Network, snap.CoveredHeight)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": fmt.Sprintf("Historical TSpend scan started from block %d", req.StartHeight),
-	})
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(snap)
+}
+
+// DefaultScanSnapshotMaxBytes is the body cap of the snapshot import route
+// unless TREASURY_SNAPSHOT_MAX_BYTES overrides it. A mainnet snapshot's
+// treasurybase runs alone outgrow the API's general cap.
+const DefaultScanSnapshotMaxBytes = 64 << 20
+
+// ImportScanSnapshotHandler installs an exported snapshot as the treasury
+// scan results, once its checksum and blocks check out against dcrd.
+func ImportScanSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	var snap types.TreasuryScanSnapshot
+	if err := decodeJSON(r, &snap); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	// Each TSpend's block is checked against dcrd's chain.
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
+	defer cancel()
+
+	result, err := services.ImportScanSnapshot(ctx, &snap)
+	switch {
+	case errors.Is(err, services.ErrSnapshotHash), errors.Is(err, services.ErrSnapshotInvalid):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrSnapshotChain), errors.Is(err, services.ErrScanInProgress):
+		respondError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		log.Printf("Error importing treasury scan snapshot: %v", err)
+		respondRPCError(w, err)
+	default:
+		respondJSON(w, http.StatusOK, result)
+	}
 }
 
 // GetTSpendScanProgressHandler returns the current scan progress
@@ -85,36 +234,261 @@ func GetTSpendScanProgressHandler(w http.ResponseWriter, r *http.Request) {
 	progress, err := services.GetScanProgress()
 	if err != nil {
 		log.Printf("Error getting scan progress: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(progress)
+	respondJSON(w, http.StatusOK, progress)
+}
+
+// Long-poll timeouts for the progress wait endpoints, in seconds.
+const (
+	defaultProgressWait = 25
+	maxProgressWait     = 60
+)
+
+// progressWaitParams reads the since version and the timeout in seconds of a
+// progress long poll.
+func progressWaitParams(r *http.Request) (since uint64, wait time.Duration, err error) {
+	q := r.URL.Query()
+	if v := q.Get("since"); v != "" {
+		if since, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid since version")
+		}
+	}
+	secs := defaultProgressWait
+	if v := q.Get("timeout"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > maxProgressWait {
+			return 0, 0, fmt.Errorf("timeout must be 0-%d seconds", maxProgressWait)
+		}
+		secs = n
+	}
+	return since, time.Duration(secs) * time.Second, nil
+}
+
+// WaitTSpendScanProgressHandler is the long-poll form of scan progress for
+// clients that can't hold a WebSocket: it blocks until the progress version
+// differs from ?since=, or ?timeout= seconds pass, then returns the progress.
+func WaitTSpendScanProgressHandler(w http.ResponseWriter, r *http.Request) {
+	since, wait, err := progressWaitParams(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+
+	respondJSON(w, http.StatusOK, services.WaitScanProgress(ctx, since))
 }
 
-// GetTSpendScanResultsHandler returns the results from the last completed scan
+// GetTSpendScanResultsHandler returns the results from the last completed
+// scan, each with its Politeia proposal when one is linked, optionally only
+// those paying between ?minAmount= and ?maxAmount= DCR. The meta lists any
+// blocks the scan could not read, with the count and total returned.
 func GetTSpendScanResultsHandler(w http.ResponseWriter, r *http.Request) {
-	results := services.GetScanResults()
+	amounts, err := services.ParseAmountRange(r.URL.Query().Get("minAmount"), r.URL.Query().Get("maxAmount"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(results)
+	results, meta := services.FilterScanResults(amounts)
+	services.AttachProposalLinks(results)
+	respondJSONMeta(w, http.StatusOK, results, meta)
 }
 
-// GetMempoolTSpendsHandler returns active tspends currently in mempool
+// GetTSpendScanFoundHandler returns a consistent snapshot of the TSpends the
+// running or last scan has found, with the blocks it has read so far.
+func GetTSpendScanFoundHandler(w http.ResponseWriter, r *http.Request) {
+	found := services.ScanFound()
+	services.AttachProposalLinks(found.TSpends)
+	respondJSON(w, http.StatusOK, found)
+}
+
+// GetMempoolTSpendsHandler returns active tspends currently in mempool. With
+// ?changedSince=<meta.token from an earlier response> only the tspends that
+// changed since are returned, and meta.removed lists those that left.
 func GetMempoolTSpendsHandler(w http.ResponseWriter, r *http.Request) {
+	var changedSince uint64
+	if v := r.URL.Query().Get("changedSince"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid changedSince token")
+			return
+		}
+		changedSince = n
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	tspends, err := services.GetMempoolTSpends(ctx)
+	tspends, meta, err := services.GetMempoolTSpendsSince(ctx, changedSince)
 	if err != nil {
 		log.Printf("Error fetching mempool tspends: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tspends)
+	respondJSONMeta(w, http.StatusOK, tspends, meta)
+}
+
+// GetMempoolTSpendStandingsHandler returns each mempool tspend's vote
+// standing: its tally, approval, turnout and projected verdict.
+func GetMempoolTSpendStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	standings, err := services.GetMempoolTSpendStandings(ctx)
+	if err != nil {
+		log.Printf("Error fetching mempool tspend standings: %v", err)
+		respondRPCError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, standings)
+}
+
+// GetTSpendDetailHandler returns one tspend's amount, payees, block or
+// mempool state and vote breakdown.
+func GetTSpendDetailHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	txHash := mux.Vars(r)["txhash"]
+	detail, err := services.GetTSpendDetail(ctx, txHash)
+	switch {
+	case errors.Is(err, services.ErrInvalidHash):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrNotTSpend):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrTxNotFound):
+		respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+	case errors.Is(err, services.ErrTxUnavailable):
+		respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+	case err != nil:
+		log.Printf("Error fetching tspend %s: %v", txHash, err)
+		respondRPCError(w, err)
+	default:
+		respondJSON(w, http.StatusOK, detail)
+	}
+}
+
+// GetTicketTSpendVoteHandler returns how one ticket voted on a tspend.
+func GetTicketTSpendVoteHandler(w http.ResponseWriter, r *http.Request) {
+	// Without the wallet's record the window is read block by block.
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
+	defer cancel()
+
+	vars := mux.Vars(r)
+	vote, err := services.FetchTicketTSpendVote(ctx, vars["txhash"], vars["tickethash"])
+	switch {
+	case errors.Is(err, services.ErrInvalidHash):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrNotTSpend), errors.Is(err, services.ErrNotTicket):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrTxNotFound):
+		respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+	case errors.Is(err, services.ErrTxUnavailable):
+		respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+	case err != nil:
+		log.Printf("Error looking up ticket %s vote on tspend %s: %v", vars["tickethash"], vars["txhash"], err)
+		respondRPCError(w, err)
+	default:
+		respondJSON(w, http.StatusOK, vote)
+	}
+}
+
+// ExportTSpendVotesHandler streams a tspend's votes block by block across its
+// voting window, as CSV or, with ?format=json, a JSON array. Rows are sent as
+// blocks are scanned, so an error after the first one can only cut the
+// download short; it is logged.
+func ExportTSpendVotesHandler(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		respondError(w, http.StatusBadRequest, "format must be csv or json")
+		return
+	}
+
+	// An uncached window is read block by block from dcrd.
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	txHash := mux.Vars(r)["txhash"]
+	flusher, _ := w.(http.Flusher)
+	csvw := csv.NewWriter(w)
+	rows := 0
+	emit := func(b types.TSpendBlockVotes) error {
+		if rows == 0 {
+			filename := fmt.Sprintf("dcrpulse-tspend-votes-%s.%s", txHash, format)
+			if format == "csv" {
+				w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+			}
+			w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+			w.WriteHeader(http.StatusOK)
+			if format == "csv" {
+				csvw.Write([]string{"height", "yes", "no", "abstain"})
+			}
+		}
+		var err error
+		if format == "csv" {
+			err = csvw.Write([]string{
+				strconv.FormatInt(b.Height, 10),
+				strconv.Itoa(b.Yes),
+				strconv.Itoa(b.No),
+				strconv.Itoa(b.Abstain),
+			})
+		} else {
+			sep := ","
+			if rows == 0 {
+				sep = "["
+			}
+			var row []byte
+			if row, err = json.Marshal(b); err == nil {
+				_, err = fmt.Fprintf(w, "%s\n%s", sep, row)
+			}
+		}
+		rows++
+		if rows%50 == 0 {
+			csvw.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return err
+	}
+
+	err := services.ExportTSpendVotes(ctx, txHash, emit)
+	if rows == 0 {
+		switch {
+		case errors.Is(err, services.ErrInvalidHash):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrNotTSpend):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrTxNotFound):
+			respondError(w, http.StatusNotFound, services.ErrTxNotFound.Error())
+		case errors.Is(err, services.ErrTxUnavailable):
+			respondErrorCode(w, http.StatusNotFound, ErrCodeTxUnavailable, services.ErrTxUnavailable.Error())
+		case err != nil:
+			log.Printf("Error exporting votes for tspend %s: %v", txHash, err)
+			respondRPCError(w, err)
+		default:
+			respondError(w, http.StatusNotFound, "No blocks in the voting window")
+		}
+		return
+	}
+	if err != nil {
+		log.Printf("Vote export for tspend %s cut short after %d blocks: %v", txHash, rows, err)
+		return
+	}
+	if format == "json" {
+		fmt.Fprint(w, "\n]\n")
+	}
+	csvw.Flush()
 }
 
 // GetVoteParsingProgressHandler returns current vote counting progress for a tspend
@@ -122,7 +496,7 @@ func GetVoteParsingProgressHandler(w http.ResponseWriter, r *http.Request) {
 	// Get txhash from URL path
 	parts := strings.Split(r.URL.Path, "/")
 	if len(parts) < 2 {
-		http.Error(w, "Transaction hash required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Transaction hash required")
 		return
 	}
 	txHash := parts[len(parts)-2] // Get hash before /progress
@@ -130,14 +504,59 @@ func GetVoteParsingProgressHandler(w http.ResponseWriter, r *http.Request) {
 	progress, exists := services.GetVoteParsingProgress(txHash)
 	if !exists {
 		// No progress found - might be already cached or not started
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
+		respondJSON(w, http.StatusOK, map[string]interface{}{
 			"isParsing": false,
 			"message":   "No active parsing job",
 		})
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(progress)
+	respondJSON(w, http.StatusOK, progress)
+}
+
+// CancelVoteParsingHandler stops the tspend's running vote count, waiting
+// briefly for it to wind down, and returns its partial progress. The partial
+// tally is not cached.
+func CancelVoteParsingHandler(w http.ResponseWriter, r *http.Request) {
+	txHash := mux.Vars(r)["txhash"]
+	if !services.CancelVoteParsing(txHash) {
+		respondError(w, http.StatusNotFound, "No active parsing job")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	progress, exists := services.WaitVoteParsingStopped(ctx, txHash)
+	if !exists {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"isParsing": false,
+			"message":   "Vote count cancelled",
+		})
+		return
+	}
+	respondJSON(w, http.StatusOK, progress)
+}
+
+// WaitVoteParsingProgressHandler is the long-poll form of vote counting
+// progress: it blocks until the tspend's progress version differs from
+// ?since=, or ?timeout= seconds pass, then returns the progress.
+func WaitVoteParsingProgressHandler(w http.ResponseWriter, r *http.Request) {
+	since, wait, err := progressWaitParams(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+
+	txHash := mux.Vars(r)["txhash"]
+	progress, exists := services.WaitVoteParsingProgress(ctx, txHash, since)
+	if !exists {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"isParsing": false,
+			"message":   "No active parsing job",
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, progress)
 }
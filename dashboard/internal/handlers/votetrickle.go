@@ -6,7 +6,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
@@ -30,20 +29,20 @@ func StartVoteTrickleHandler(w http.ResponseWriter, r *http.Request) {
 		Bunches         int    `json:"bunches"`
 		Passphrase      string `json:"passphrase"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Token == "" || req.VoteOption == "" {
-		http.Error(w, "token and voteOption required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "token and voteOption required")
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	if req.DurationSeconds <= 0 {
-		http.Error(w, "durationSeconds must be > 0", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "durationSeconds must be > 0")
 		return
 	}
 
@@ -62,19 +61,19 @@ func StartVoteTrickleHandler(w http.ResponseWriter, r *http.Request) {
 	if err := services.StartVoteTrickle(ctx, req.Token, req.VoteOption,
 		time.Duration(req.DurationSeconds)*time.Second, req.Bunches, passphrase); err != nil {
 		if errors.Is(err, services.ErrPoliteiaDisabled) {
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			respondError(w, http.StatusServiceUnavailable, err.Error())
 			return
 		}
 		msg := err.Error()
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		case strings.Contains(lower, "already running"):
-			http.Error(w, msg, http.StatusConflict)
+			respondError(w, http.StatusConflict, msg)
 		default:
 			log.Printf("StartVoteTrickle failed: %v", err)
-			http.Error(w, msg, http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, msg)
 		}
 		return
 	}
@@ -86,7 +85,7 @@ func StartVoteTrickleHandler(w http.ResponseWriter, r *http.Request) {
 func StopVoteTrickleHandler(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 	if token == "" {
-		http.Error(w, "token required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "token required")
 		return
 	}
 	services.StopVoteTrickle(token)
@@ -96,8 +95,7 @@ func StopVoteTrickleHandler(w http.ResponseWriter, r *http.Request) {
 // VoteTrickleStatusHandler returns the live status of every trickle run (one per
 // proposal currently or recently trickling).
 func VoteTrickleStatusHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(services.VoteTrickleWorkersSnapshot())
+	respondJSON(w, http.StatusOK, services.VoteTrickleWorkersSnapshot())
 }
 
 // StreamVoteTrickleEventsHandler upgrades to WebSocket and streams events
@@ -112,9 +110,11 @@ func StreamVoteTrickleEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	for _, ev := range services.LastVoteTrickleEvents(200) {
-		if err := conn.WriteJSON(ev); err != nil {
+		if err := writeWSJSON(conn, ev); err != nil {
 			return
 		}
 	}
@@ -122,15 +122,7 @@ func StreamVoteTrickleEventsHandler(w http.ResponseWriter, r *http.Request) {
 	ch, unsubscribe := services.SubscribeVoteTrickleEvents()
 	defer unsubscribe()
 
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
@@ -138,7 +130,7 @@ func StreamVoteTrickleEventsHandler(w http.ResponseWriter, r *http.Request) {
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(ev); err != nil {
+			if err := writeWSJSON(conn, ev); err != nil {
 				return
 			}
 		case <-notify:
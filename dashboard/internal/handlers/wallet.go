@@ -26,6 +26,7 @@ import (
 
 	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
 
+	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 )
 
@@ -37,11 +38,13 @@ var (
 	rescanChannelsMutex  sync.Mutex
 )
 
-// startRescanViaGrpc initiates a blockchain rescan using gRPC and broadcasts progress
-func startRescanViaGrpc(beginHeight int32) {
+// startRescanViaGrpc initiates a blockchain rescan using gRPC and broadcasts
+// progress. It returns once the rescan ends, with an error when it could not
+// start or its stream broke before completing.
+func startRescanViaGrpc(beginHeight int32) error {
 	if rpc.WalletGrpcClient == nil {
 		log.Println("❌ Cannot start gRPC rescan: gRPC client not initialized")
-		return
+		return rpc.NotConnected("wallet gRPC client not available")
 	}
 
 	ctx := context.Background()
@@ -52,7 +55,7 @@ func startRescanViaGrpc(beginHeight int32) {
 	stream, err := rpc.WalletGrpcClient.Rescan(ctx, req)
 	if err != nil {
 		log.Printf("❌ Failed to start gRPC rescan: %v", err)
-		return
+		return fmt.Errorf("failed to start rescan: %w", err)
 	}
 
 	log.Println("✅ gRPC rescan stream started - broadcasting progress updates")
@@ -63,6 +66,8 @@ func startRescanViaGrpc(beginHeight int32) {
 	activeRescanMutex.Unlock()
 
 	// Receive and broadcast progress updates
+	var rescannedThrough int32
+	var streamErr error
 	for {
 		update, err := stream.Recv()
 		if err == io.EOF {
@@ -71,12 +76,14 @@ func startRescanViaGrpc(beginHeight int32) {
 		}
 		if err != nil {
 			log.Printf("❌ gRPC rescan stream error: %v", err)
+			streamErr = fmt.Errorf("rescan stream: %w", err)
 			break
 		}
 
 		// Update the unified SyncSnapshot so all subscribers (incl. the
 		// WebSocket fan-out below) see consistent rescan state.
 		services.MarkRescanProgress(update.RescannedThrough)
+		rescannedThrough = update.RescannedThrough
 
 		// Broadcast to all listening WebSocket clients (legacy channel —
 		// SyncSnapshot subscribers get the same data via the new path).
@@ -107,7 +114,14 @@ func startRescanViaGrpc(beginHeight int32) {
 	rescanChannelsMutex.Unlock()
 
 	services.MarkRescanFinished()
+	if streamErr != nil {
+		return streamErr
+	}
 	log.Println("✅ Rescan completed - all transactions imported")
+	services.NotifyWebhooks(services.WebhookEventRescanDone, types.WebhookRescanComplete{
+		RescannedThrough: rescannedThrough,
+	})
+	return nil
 }
 
 // subscribeToRescanUpdates creates a channel that receives rescan progress updates
@@ -136,29 +150,22 @@ func unsubscribeFromRescanUpdates(ch chan *pb.RescanResponse) {
 
 // GetWalletStatusHandler handles requests for wallet status
 func GetWalletStatusHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil {
-		http.Error(w, "Wallet RPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
-
 	// Check if dcrd is still syncing before attempting wallet operations
-	if rpc.DcrdClient != nil {
-		checkCtx, checkCancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer checkCancel()
+	checkCtx, checkCancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer checkCancel()
 
-		chainInfo, err := rpc.DcrdClient.GetBlockChainInfo(checkCtx)
-		if err != nil {
-			// dcrd is unreachable (down, starting, or running a database
-			// upgrade). Surface that rather than a confusing wallet error.
-			if services.IsDaemonUnreachable(err) {
-				respondDaemonError(w, r, services.LogComponentDcrd, err)
-				return
-			}
-		} else if chainInfo.InitialBlockDownload {
-			// Wallet RPC cannot serve data until dcrd finishes its IBD.
-			http.Error(w, "The Decred node is still downloading the blockchain. Your wallet will be available once the node finishes syncing.", http.StatusServiceUnavailable)
+	chainInfo, err := rpc.DcrdClient.GetBlockChainInfo(checkCtx)
+	if err != nil {
+		// dcrd is unreachable (down, starting, or running a database
+		// upgrade). Surface that rather than a confusing wallet error.
+		if services.IsDaemonUnreachable(err) {
+			respondDaemonError(w, r, services.LogComponentDcrd, err)
 			return
 		}
+	} else if chainInfo.InitialBlockDownload {
+		// Wallet RPC cannot serve data until dcrd finishes its IBD.
+		respondError(w, http.StatusServiceUnavailable, "The Decred node is still downloading the blockchain. Your wallet will be available once the node finishes syncing.")
+		return
 	}
 
 	status, err := services.FetchWalletStatus()
@@ -168,35 +175,48 @@ func GetWalletStatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
+	respondJSON(w, http.StatusOK, status)
 }
 
-// GetWalletDashboardHandler handles requests for complete wallet dashboard data
+// minConfParam reads the optional minConf query parameter: how many
+// confirmations an output needs to count as spendable.
+func minConfParam(r *http.Request) (int32, error) {
+	v := r.URL.Query().Get("minConf")
+	if v == "" {
+		return services.DefaultMinConf, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("minConf must be a non-negative integer")
+	}
+	return int32(n), nil
+}
+
+// GetWalletDashboardHandler handles requests for complete wallet dashboard
+// data. Query: minConf (default 1; 0 counts mempool outputs as spendable).
 func GetWalletDashboardHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil {
-		http.Error(w, "Wallet RPC client not initialized", http.StatusServiceUnavailable)
+	minConf, err := minConfParam(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Check if dcrd is still syncing before attempting wallet operations
-	if rpc.DcrdClient != nil {
-		checkCtx, checkCancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer checkCancel()
+	checkCtx, checkCancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer checkCancel()
 
-		chainInfo, err := rpc.DcrdClient.GetBlockChainInfo(checkCtx)
-		if err != nil {
-			// dcrd is unreachable (down, starting, or running a database
-			// upgrade). Surface that rather than a confusing wallet error.
-			if services.IsDaemonUnreachable(err) {
-				respondDaemonError(w, r, services.LogComponentDcrd, err)
-				return
-			}
-		} else if chainInfo.InitialBlockDownload {
-			// Wallet RPC cannot serve data until dcrd finishes its IBD.
-			http.Error(w, "The Decred node is still downloading the blockchain. Your wallet will be available once the node finishes syncing.", http.StatusServiceUnavailable)
+	chainInfo, err := rpc.DcrdClient.GetBlockChainInfo(checkCtx)
+	if err != nil {
+		// dcrd is unreachable (down, starting, or running a database
+		// upgrade). Surface that rather than a confusing wallet error.
+		if services.IsDaemonUnreachable(err) {
+			respondDaemonError(w, r, services.LogComponentDcrd, err)
 			return
 		}
+	} else if chainInfo.InitialBlockDownload {
+		// Wallet RPC cannot serve data until dcrd finishes its IBD.
+		respondError(w, http.StatusServiceUnavailable, "The Decred node is still downloading the blockchain. Your wallet will be available once the node finishes syncing.")
+		return
 	}
 
 	// Create a context with timeout to prevent hanging on slow RPC calls
@@ -212,7 +232,7 @@ func GetWalletDashboardHandler(w http.ResponseWriter, r *http.Request) {
 	resultChan := make(chan result, 1)
 
 	go func() {
-		data, err := services.FetchWalletDashboardDataWithContext(ctx)
+		data, err := services.FetchWalletDashboardDataWithMinConf(ctx, minConf)
 		resultChan <- result{data, err}
 	}()
 
@@ -222,18 +242,16 @@ func GetWalletDashboardHandler(w http.ResponseWriter, r *http.Request) {
 			log.Printf("Error fetching wallet dashboard data: %v", res.err)
 			// Return partial data if available
 			if res.data != nil {
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(res.data)
+				respondJSON(w, http.StatusOK, res.data)
 				return
 			}
 			respondDaemonError(w, r, services.LogComponentDcrwallet, res.err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(res.data)
+		respondJSON(w, http.StatusOK, res.data)
 	case <-ctx.Done():
 		log.Printf("Wallet dashboard request timed out")
-		http.Error(w, "Wallet dashboard request timed out - wallet may be rescanning", http.StatusRequestTimeout)
+		respondError(w, http.StatusRequestTimeout, "Wallet dashboard request timed out - wallet may be rescanning")
 	}
 }
 
@@ -244,32 +262,49 @@ func GetWalletDashboardHandler(w http.ResponseWriter, r *http.Request) {
 // the frontend also hides these actions for watch-only wallets.
 func rejectWatchOnly(w http.ResponseWriter, r *http.Request) bool {
 	if services.ActiveWalletIsWatchOnly(r.Context()) {
-		http.Error(w, "This wallet is watch-only and cannot spend or sign.", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "This wallet is watch-only and cannot spend or sign.")
 		return true
 	}
 	return false
 }
 
 func ImportXpubHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil {
-		http.Error(w, "Wallet RPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
-
 	var req types.ImportXpubRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
-	// Validate xpub format (Decred mainnet xpubs start with "dpub")
-	if !strings.HasPrefix(req.Xpub, "dpub") && !strings.HasPrefix(req.Xpub, "tpub") {
-		response := types.ImportXpubResponse{
-			Success: false,
-			Message: "Invalid xpub format. Decred mainnet xpubs must start with 'dpub'",
+	// Validate the key against the network dcrd is on, so a malformed or
+	// other-network xpub is rejected before dcrwallet sees it.
+	req.Xpub = strings.TrimSpace(req.Xpub)
+	if req.ValidateOnly {
+		previewCtx, previewCancel := context.WithTimeout(r.Context(), 10*time.Second)
+		preview, err := services.PreviewXpub(previewCtx, req.Xpub)
+		previewCancel()
+		switch {
+		case errors.Is(err, services.ErrXpubWrongNetwork) || errors.Is(err, services.ErrInvalidXpub):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case err != nil:
+			respondError(w, http.StatusServiceUnavailable, fmt.Sprintf("Cannot validate xpub: %v", err))
+		default:
+			respondJSON(w, http.StatusOK, types.ImportXpubResponse{
+				Success: true,
+				Message: "xpub is valid; nothing was imported",
+				Preview: preview,
+			})
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+		return
+	}
+	valCtx, valCancel := context.WithTimeout(r.Context(), 5*time.Second)
+	err := services.ValidateXpub(valCtx, req.Xpub)
+	valCancel()
+	if errors.Is(err, services.ErrXpubWrongNetwork) || errors.Is(err, services.ErrInvalidXpub) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusServiceUnavailable, fmt.Sprintf("Cannot validate xpub: %v", err))
 		return
 	}
 
@@ -278,11 +313,11 @@ func ImportXpubHandler(w http.ResponseWriter, r *http.Request) {
 	// unmixed/lightning/dex) or an existing account name.
 	accountName := strings.TrimSpace(req.AccountName)
 	if accountName == "" {
-		http.Error(w, "accountName required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "accountName required")
 		return
 	}
 	if len(accountName) > 50 {
-		http.Error(w, "accountName must be 50 characters or fewer", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "accountName must be 50 characters or fewer")
 		return
 	}
 	// AccountIndex is optional (only set when the user will spend from this account
@@ -291,19 +326,19 @@ func ImportXpubHandler(w http.ResponseWriter, r *http.Request) {
 	// signing ambiguous about which account to derive against.
 	if req.AccountIndex != nil {
 		if *req.AccountIndex >= 1<<31 {
-			http.Error(w, "accountIndex must be a BIP44 account index (0 to 2147483647)", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "accountIndex must be a BIP44 account index (0 to 2147483647)")
 			return
 		}
 		guardCtx, guardCancel := context.WithTimeout(r.Context(), 5*time.Second)
 		acct, used := services.Bip44IndexInUse(guardCtx, *req.AccountIndex)
 		guardCancel()
 		if used {
-			http.Error(w, fmt.Sprintf("BIP44 account index %d is already imported (wallet account %s)", *req.AccountIndex, acct), http.StatusConflict)
+			respondError(w, http.StatusConflict, fmt.Sprintf("BIP44 account index %d is already imported (wallet account %s)", *req.AccountIndex, acct))
 			return
 		}
 	}
 	if services.IsReservedAccountName(accountName) {
-		http.Error(w, fmt.Sprintf("%q is a reserved account name", accountName), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("%q is a reserved account name", accountName))
 		return
 	}
 	checkCtx, checkCancel := context.WithTimeout(r.Context(), 5*time.Second)
@@ -311,7 +346,7 @@ func ImportXpubHandler(w http.ResponseWriter, r *http.Request) {
 		for _, a := range accts {
 			if strings.EqualFold(a.AccountName, accountName) {
 				checkCancel()
-				http.Error(w, "An account with that name already exists", http.StatusConflict)
+				respondError(w, http.StatusConflict, "An account with that name already exists")
 				return
 			}
 		}
@@ -320,16 +355,18 @@ func ImportXpubHandler(w http.ResponseWriter, r *http.Request) {
 	// Reject re-importing a key that already backs an existing account, under
 	// any name or index (compared by pubkey, so metadata cannot mask it).
 	dupCtx, dupCancel := context.WithTimeout(r.Context(), 10*time.Second)
-	dupAcct, dup, dupErr := services.XpubAlreadyImported(dupCtx, strings.TrimSpace(req.Xpub))
+	dupAcct, dup, dupErr := services.XpubAlreadyImported(dupCtx, req.Xpub)
 	dupCancel()
 	if dupErr == nil && dup {
-		http.Error(w, fmt.Sprintf("this xpub is already imported as account %q", dupAcct), http.StatusConflict)
+		respondError(w, http.StatusConflict, fmt.Sprintf("this xpub is already imported as account %q", dupAcct))
 		return
 	}
 
 	// Import the xpub asynchronously
-	// We run it in a goroutine and return immediately so the frontend doesn't timeout
+	// We run it in a goroutine and return immediately so the frontend doesn't timeout;
+	// the returned job id tracks it via /wallet/importxpub/status/{id}.
 	log.Printf("Starting xpub import for account: %s", accountName)
+	jobID := services.NewXpubImportJob(accountName)
 
 	// Start the import in a goroutine
 	// WebSocket stream will automatically detect and show rescan progress
@@ -347,66 +384,109 @@ func ImportXpubHandler(w http.ResponseWriter, r *http.Request) {
 		result, err := rpc.WalletClient.RawRequest(ctx, "importxpub", params)
 		if err != nil {
 			log.Printf("Failed to import xpub: %v", err)
+			services.FailXpubImportJob(jobID, fmt.Errorf("importxpub: %w", err))
 			return
 		}
 		log.Printf("Xpub import completed: %v", string(result))
 
-		// If the user supplied a BIP44 account index, record it so offline signing
-		// derives against the right account on the device. The imported account's
-		// dcrwallet number (>= 2^31) is resolved by name. Omitted for a monitor-only
-		// xpub - no mapping is stored.
+		// Resolve the created account by name; if the user supplied a BIP44
+		// account index, record it so offline signing derives against the right
+		// account on the device. The imported account's dcrwallet number is
+		// >= 2^31. Omitted for a monitor-only xpub - no mapping is stored.
+		var acctNum *uint32
+		accts, aerr := services.FetchAllAccounts(ctx)
+		if aerr != nil {
+			log.Printf("Failed to resolve imported account %q: %v", accountName, aerr)
+		}
+		for _, a := range accts {
+			if strings.EqualFold(a.AccountName, accountName) {
+				n := a.AccountNumber
+				acctNum = &n
+				break
+			}
+		}
+		services.UpdateXpubImportJob(jobID, func(job *types.XpubImportStatus) {
+			job.Imported = true
+			job.AccountNum = acctNum
+		})
 		if req.AccountIndex != nil {
-			if accts, aerr := services.FetchAllAccounts(ctx); aerr == nil {
-				for _, a := range accts {
-					if strings.EqualFold(a.AccountName, accountName) {
-						if serr := services.SetXpubAccountIndex(ctx, a.AccountNumber, *req.AccountIndex); serr != nil {
-							log.Printf("Failed to record BIP44 index for account %q: %v", accountName, serr)
-						}
-						break
-					}
+			// Offline signing needs the index mapping, so an import that
+			// can't record it is not done even though the key is in.
+			var serr error
+			if acctNum == nil {
+				serr = fmt.Errorf("imported account %q not found", accountName)
+				if aerr != nil {
+					serr = fmt.Errorf("list accounts: %w", aerr)
 				}
+			} else {
+				serr = services.SetXpubAccountIndex(ctx, *acctNum, *req.AccountIndex)
+			}
+			if serr != nil {
+				log.Printf("Failed to record BIP44 index for account %q: %v", accountName, serr)
+				services.FailXpubImportJob(jobID, fmt.Errorf("record BIP44 index: %w", serr))
+				return
 			}
 		}
+		services.UpdateXpubImportJob(jobID, func(job *types.XpubImportStatus) {
+			job.State = services.XpubImportDiscovering
+		})
 
 		// Step 2: Discover address usage
 		log.Printf("Step 2/3: Discovering address usage across blockchain...")
 		_, err = rpc.WalletClient.RawRequest(ctx, "discoverusage", nil)
 		if err != nil {
 			log.Printf("Failed to discover address usage: %v", err)
+			services.FailXpubImportJob(jobID, fmt.Errorf("discoverusage: %w", err))
 			return
 		}
 		log.Printf("Address discovery completed - wallet database updated")
 
 		// Step 3: Wait for wallet to be ready, then rescan from block 0 via gRPC
 		log.Printf("Step 3/3: Waiting 5 seconds for wallet to load transaction filter...")
+		services.UpdateXpubImportJob(jobID, func(job *types.XpubImportStatus) {
+			job.State = services.XpubImportRescanning
+		})
 		time.Sleep(5 * time.Second)
 
 		// Start gRPC rescan from genesis
 		log.Printf("Starting gRPC rescan from block 0...")
-		startRescanViaGrpc(0)
+		if err := startRescanViaGrpc(0); err != nil {
+			services.FailXpubImportJob(jobID, err)
+			return
+		}
+		services.UpdateXpubImportJob(jobID, func(job *types.XpubImportStatus) {
+			job.State = services.XpubImportDone
+		})
 	}()
 
-	// Return immediately - the frontend will poll wallet status to track rescan progress
+	// Return immediately - the frontend polls the job (or wallet status) to track progress
 	response := types.ImportXpubResponse{
 		Success: true,
 		Message: fmt.Sprintf("Xpub import started for account '%s'. Now discovering addresses and rescanning blockchain. This typically takes 5-30 minutes.", accountName),
+		JobID:   jobID,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	respondJSON(w, http.StatusOK, response)
 }
 
-// RescanWalletHandler handles wallet rescan requests
-func RescanWalletHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil {
-		http.Error(w, "Wallet RPC client not initialized", http.StatusServiceUnavailable)
+// ImportXpubStatusHandler reports the progress of an import started by
+// ImportXpubHandler.
+func ImportXpubStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status, ok := services.GetXpubImportJob(mux.Vars(r)["id"])
+	if !ok {
+		respondError(w, http.StatusNotFound, "Unknown xpub import job")
 		return
 	}
+	respondJSON(w, http.StatusOK, status)
+}
 
+// RescanWalletHandler handles wallet rescan requests
+func RescanWalletHandler(w http.ResponseWriter, r *http.Request) {
+	// Without a start height the rescan begins at the wallet's birthday, or
+	// genesis when none was given on restore or open.
 	var req types.RescanRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		// Default to full rescan from genesis
-		req.BeginHeight = 0
+	if err := decodeJSON(r, &req); err != nil || req.BeginHeight == 0 {
+		req.BeginHeight = services.WalletRescanStart(r.Context())
 	}
 
 	// Start rescan in a goroutine - it's a long-running operation
@@ -436,7 +516,9 @@ func RescanWalletHandler(w http.ResponseWriter, r *http.Request) {
 
 		// Step 3: Start rescan via gRPC - this provides a progress stream
 		log.Printf("Starting gRPC rescan from block %d...", req.BeginHeight)
-		startRescanViaGrpc(int32(req.BeginHeight))
+		if err := startRescanViaGrpc(int32(req.BeginHeight)); err != nil {
+			log.Printf("Wallet rescan from block %d failed: %v", req.BeginHeight, err)
+		}
 	}()
 
 	// Return immediately so frontend can start polling for progress
@@ -445,24 +527,23 @@ func RescanWalletHandler(w http.ResponseWriter, r *http.Request) {
 		Message: fmt.Sprintf("Discovering addresses and rescanning blockchain from block %d. This may take 30+ minutes.", req.BeginHeight),
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetWalletSyncStatusHandler returns the wallet's coarse sync phase and
+// heights, for clients polling instead of holding a sync WebSocket open.
+func GetWalletSyncStatusHandler(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, services.FetchWalletSyncStatus(r.Context()))
 }
 
 func GetSyncProgressHandler(w http.ResponseWriter, r *http.Request) {
 	snap := services.GetSyncSnapshot()
 	payload := snapshotPayload(snap)
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(payload)
+	respondJSON(w, http.StatusOK, payload)
 }
 
 // ListTransactionsHandler handles requests for wallet transaction history
 func ListTransactionsHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil {
-		http.Error(w, "Wallet RPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
-
 	// Parse query parameters
 	query := r.URL.Query()
 	count := 50 // default
@@ -487,12 +568,35 @@ func ListTransactionsHandler(w http.ResponseWriter, r *http.Request) {
 	transactions, err := services.ListTransactions(ctx, count, from)
 	if err != nil {
 		log.Printf("Error listing transactions: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transactions)
+}
+
+// GetWalletTransactionHandler returns one wallet transaction with its inputs
+// and outputs attributed to the wallet's accounts, or 404 when the wallet has
+// no record of it.
+func GetWalletTransactionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	detail, err := services.GetWalletTransactionDetail(ctx, mux.Vars(r)["txhash"])
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidHash):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrWalletTxNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		default:
+			log.Printf("Error fetching wallet transaction: %v", err)
+			respondRPCError(w, err)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(transactions)
+	respondJSON(w, http.StatusOK, detail)
 }
 
 // ExportTransactionsHandler serves a Decrediton-format CSV export of the
@@ -500,34 +604,70 @@ func ListTransactionsHandler(w http.ResponseWriter, r *http.Request) {
 // transactions, tickets, votetime, balances, dailybalances; default
 // transactions). The CSV is built fully before sending so a failure mid-build
 // surfaces as a clean error instead of a truncated download.
+//
+// The transactions export can also be fetched in chunks, for histories too
+// large for one request: ?limit= (up to services.MaxExportChunk) or ?cursor=
+// selects a chunk of that many transactions (services.DefaultExportChunk by
+// default). The X-Next-Cursor header carries the cursor for the next chunk,
+// and is absent on the last; only the first chunk has the header row.
 func ExportTransactionsHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "Wallet gRPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
-
-	typ := r.URL.Query().Get("type")
+	q := r.URL.Query()
+	typ := q.Get("type")
 	if typ == "" {
 		typ = "transactions"
 	}
 	if !services.ExportTypes[typ] {
-		http.Error(w, "Unknown export type", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Unknown export type")
 		return
 	}
 
+	chunked := q.Has("cursor") || q.Has("limit")
+	if chunked && typ != "transactions" {
+		respondError(w, http.StatusBadRequest, "Only the transactions export can be fetched in chunks")
+		return
+	}
+	var after *services.ExportCursor
+	if v := q.Get("cursor"); v != "" {
+		c, err := services.ParseExportCursor(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		after = &c
+	}
+	limit := services.DefaultExportChunk
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > services.MaxExportChunk {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be 1-%d", services.MaxExportChunk))
+			return
+		}
+		limit = n
+	}
+
 	// Full-history replay (Balances/Daily Balances) can take well beyond the
 	// 10s used for the paged listing, so allow a generous window.
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
 	defer cancel()
 
 	var buf bytes.Buffer
-	if err := services.ExportWalletCSV(ctx, &buf, typ); err != nil {
+	var next *services.ExportCursor
+	var err error
+	if chunked {
+		next, err = services.ExportTransactionsChunk(ctx, &buf, after, limit)
+	} else {
+		err = services.ExportWalletCSV(ctx, &buf, typ)
+	}
+	if err != nil {
 		log.Printf("Error exporting %s CSV: %v", typ, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
 	filename := fmt.Sprintf("dcrpulse-%s-%d.csv", typ, time.Now().Unix())
+	if next != nil {
+		w.Header().Set("X-Next-Cursor", next.String())
+	}
 	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
 	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
 	w.Write(buf.Bytes())
@@ -543,39 +683,24 @@ func StreamRescanProgressHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
-	// Initial snapshot.
-	if err := conn.WriteJSON(snapshotPayload(services.GetSyncSnapshot())); err != nil {
+	initial, ch, unsubscribe := subscribeSyncPayloads()
+	defer unsubscribe()
+	if err := writeWSJSON(conn, initial); err != nil {
 		return
 	}
 
-	ch, unsubscribe := services.SubscribeSyncEvents()
-	defer unsubscribe()
-
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
-
-	keepAlive := time.NewTicker(15 * time.Second)
-	defer keepAlive.Stop()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
-		case snap, ok := <-ch:
+		case payload, ok := <-ch:
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(snapshotPayload(snap)); err != nil {
-				return
-			}
-		case <-keepAlive.C:
-			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := writeWSJSON(conn, payload); err != nil {
 				return
 			}
 		case <-notify:
@@ -585,13 +710,14 @@ func StreamRescanProgressHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // snapshotPayload renders a SyncSnapshot as the WebSocket / sync-progress JSON.
+// The coarse phase comes from services.WalletSyncStatusFor, the same source as
+// /wallet/sync-status.
 func snapshotPayload(snap services.SyncSnapshot) map[string]interface{} {
-	isRescanning := snap.Phase == services.SyncPhaseFetchingCfilters ||
-		snap.Phase == services.SyncPhaseFetchingHeaders ||
-		snap.Phase == services.SyncPhaseDiscoverAddresses ||
-		snap.Phase == services.SyncPhaseRescanning
+	status := services.WalletSyncStatusFor(context.Background(), snap)
+	isRescanning := status.Phase == types.WalletSyncHeaders ||
+		status.Phase == types.WalletSyncRescanning
 
-	scanHeight, chainHeight := phaseProgress(snap)
+	scanHeight, chainHeight := phaseProgress(snap, status.ChainHeight)
 	progress := snap.RescanProgressPc
 	switch snap.Phase {
 	case services.SyncPhaseRescanning:
@@ -646,7 +772,7 @@ func snapshotPayload(snap services.SyncSnapshot) map[string]interface{} {
 	default:
 		message = "Sync state unknown"
 	}
-	if !snap.DaemonConnected {
+	if status.Phase == types.WalletSyncDisconnected {
 		message = "Disconnected from dcrd"
 	}
 	return map[string]interface{}{
@@ -663,19 +789,12 @@ func snapshotPayload(snap services.SyncSnapshot) map[string]interface{} {
 		"headersCount":    snap.HeadersCount,
 		"firstHeaderTime": snap.FirstHeaderTime,
 		"lastHeaderTime":  snap.LastHeaderTime,
+		"syncStatus":      status,
 	}
 }
 
 // phaseProgress returns (numerator, denominator) for the progress bar in the current sync phase.
-func phaseProgress(snap services.SyncSnapshot) (int64, int64) {
-	chainTip := int64(0)
-	if rpc.DcrdClient != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		if h, err := rpc.DcrdClient.GetBlockCount(ctx); err == nil {
-			chainTip = h
-		}
-		cancel()
-	}
+func phaseProgress(snap services.SyncSnapshot, chainTip int64) (int64, int64) {
 	switch snap.Phase {
 	case services.SyncPhaseRescanning:
 		return int64(snap.RescanThrough), snap.RescanFrom
@@ -693,19 +812,69 @@ func phaseProgress(snap services.SyncSnapshot) (int64, int64) {
 }
 
 func GetAccountsHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
+	minConf, err := minConfParam(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
-	accounts, err := services.FetchAllAccounts(ctx)
+	accounts, err := services.FetchAllAccountsWithMinConf(ctx, minConf)
+	if err != nil {
+		respondRPCError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, accounts)
+}
+
+// ListWalletAddressesHandler returns a page of every address the wallet has
+// derived, with its usage. Query: account (number or name; default all),
+// used (true or false; default both), offset and limit. The meta carries the
+// page.
+func ListWalletAddressesHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var used *bool
+	if v := q.Get("used"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "used must be true or false")
+			return
+		}
+		used = &b
+	}
+	limit := services.DefaultAddressPageLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = min(n, services.MaxAddressPageLimit)
+	}
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			respondError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+		offset = n
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	addrs, total, err := services.ListWalletAddresses(ctx, q.Get("account"), used, offset, limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		if errors.Is(err, services.ErrAccountNotFound) {
+			respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		log.Printf("Error listing wallet addresses: %v", err)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(accounts)
+	respondJSONMeta(w, http.StatusOK, addrs, types.PageMeta{Total: total, Offset: offset, Limit: limit})
 }
 
 // importedAccountNumber is dcrwallet's reserved bucket for unencrypted
@@ -714,10 +883,6 @@ func GetAccountsHandler(w http.ResponseWriter, r *http.Request) {
 const importedAccountNumber uint32 = 2147483647
 
 func CreateAccountHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	if rejectWatchOnly(w, r) {
 		return
 	}
@@ -726,25 +891,25 @@ func CreateAccountHandler(w http.ResponseWriter, r *http.Request) {
 		AccountName string `json:"accountName"`
 		Passphrase  string `json:"passphrase"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	name := strings.TrimSpace(req.AccountName)
 	if name == "" {
-		http.Error(w, "accountName required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "accountName required")
 		return
 	}
 	if len(name) > 50 {
-		http.Error(w, "accountName must be 50 characters or fewer", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "accountName must be 50 characters or fewer")
 		return
 	}
 	if strings.EqualFold(name, "imported") {
-		http.Error(w, "'imported' is a reserved account name", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "'imported' is a reserved account name")
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	passphrase := []byte(req.Passphrase)
@@ -759,48 +924,42 @@ func CreateAccountHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		case strings.Contains(lower, "already"):
-			http.Error(w, "An account with that name already exists", http.StatusConflict)
+			respondError(w, http.StatusConflict, "An account with that name already exists")
 		default:
 			log.Printf("CreateAccount failed: %v", err)
-			http.Error(w, "create account failed", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "create account failed")
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]uint32{"accountNumber": num})
+	respondJSON(w, http.StatusOK, map[string]uint32{"accountNumber": num})
 }
 
 func RenameAccountHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
-
 	var req struct {
 		AccountNumber uint32 `json:"accountNumber"`
 		NewName       string `json:"newName"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	name := strings.TrimSpace(req.NewName)
 	if name == "" {
-		http.Error(w, "newName required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "newName required")
 		return
 	}
 	if len(name) > 50 {
-		http.Error(w, "newName must be 50 characters or fewer", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "newName must be 50 characters or fewer")
 		return
 	}
 	if services.IsReservedAccountName(name) {
-		http.Error(w, fmt.Sprintf("%q is a reserved account name", name), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("%q is a reserved account name", name))
 		return
 	}
 	if req.AccountNumber == importedAccountNumber {
-		http.Error(w, "the imported account cannot be renamed", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "the imported account cannot be renamed")
 		return
 	}
 
@@ -812,7 +971,7 @@ func RenameAccountHandler(w http.ResponseWriter, r *http.Request) {
 	if accts, aerr := services.FetchAllAccounts(ctx); aerr == nil {
 		for _, a := range accts {
 			if a.AccountNumber == req.AccountNumber && services.IsReservedAccountName(a.AccountName) {
-				http.Error(w, "this account is reserved and cannot be renamed", http.StatusBadRequest)
+				respondError(w, http.StatusBadRequest, "this account is reserved and cannot be renamed")
 				return
 			}
 		}
@@ -823,17 +982,16 @@ func RenameAccountHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "already"):
-			http.Error(w, "An account with that name already exists", http.StatusConflict)
+			respondError(w, http.StatusConflict, "An account with that name already exists")
 		case strings.Contains(lower, "not found"):
-			http.Error(w, "account not found", http.StatusNotFound)
+			respondError(w, http.StatusNotFound, "account not found")
 		default:
 			log.Printf("RenameAccount failed: %v", err)
-			http.Error(w, "rename failed", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "rename failed")
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	respondJSON(w, http.StatusOK, json.RawMessage("{}"))
 }
 
 type privacyStatusResponse struct {
@@ -845,16 +1003,12 @@ type privacyStatusResponse struct {
 }
 
 func PrivacyStatusHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
 	mixed, change, configured, err := services.FindPrivacyAccounts(ctx)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
@@ -869,17 +1023,12 @@ func PrivacyStatusHandler(w http.ResponseWriter, r *http.Request) {
 		resp.ChangeAccount = &c
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 func PrivacySetupHandler(w http.ResponseWriter, r *http.Request) {
 	if ready, reason := services.WalletReady(r.Context()); !ready {
-		http.Error(w, reason, http.StatusServiceUnavailable)
-		return
-	}
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
+		respondError(w, http.StatusServiceUnavailable, reason)
 		return
 	}
 	if rejectWatchOnly(w, r) {
@@ -889,12 +1038,12 @@ func PrivacySetupHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Passphrase string `json:"passphrase"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	passphrase := []byte(req.Passphrase)
@@ -909,31 +1058,26 @@ func PrivacySetupHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		default:
 			log.Printf("PrivacySetup failed: %v", err)
-			http.Error(w, "privacy setup failed", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "privacy setup failed")
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]uint32{
+	respondJSON(w, http.StatusOK, map[string]uint32{
 		"mixedAccount":  mixed,
 		"changeAccount": change,
 	})
 }
 
 func PrivacyStartHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.AccountMixerClient == nil {
-		http.Error(w, "mixer gRPC client not initialized", http.StatusServiceUnavailable)
-		return
-	}
 	if rejectWatchOnly(w, r) {
 		return
 	}
 
 	if services.IsMixerRunning() {
-		http.Error(w, "mixer already running", http.StatusConflict)
+		respondError(w, http.StatusConflict, "mixer already running")
 		return
 	}
 	// The mixer, the autobuyer, and a manual ticket purchase all spend the
@@ -941,23 +1085,23 @@ func PrivacyStartHandler(w http.ResponseWriter, r *http.Request) {
 	// (the autobuyer mixes its buys inline; a manual purchase pauses and
 	// restarts the mixer itself).
 	if services.IsAutobuyerRunning() {
-		http.Error(w, "stop the ticket autobuyer before starting the mixer", http.StatusConflict)
+		respondError(w, http.StatusConflict, "stop the ticket autobuyer before starting the mixer")
 		return
 	}
 	if services.IsTicketPurchaseInProgress() {
-		http.Error(w, "a ticket purchase is in progress; try again once it finishes", http.StatusConflict)
+		respondError(w, http.StatusConflict, "a ticket purchase is in progress; try again once it finishes")
 		return
 	}
 
 	var req struct {
 		Passphrase string `json:"passphrase"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.Passphrase == "" {
-		http.Error(w, "passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase required")
 		return
 	}
 	passphrase := []byte(req.Passphrase)
@@ -967,52 +1111,48 @@ func PrivacyStartHandler(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 	mixed, change, configured, err := services.FindPrivacyAccounts(ctx)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 	if !configured {
-		http.Error(w, "privacy not configured — run setup first", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "privacy not configured — run setup first")
 		return
 	}
 
 	if err := services.StartMixer(passphrase, mixed, 0, change); err != nil {
 		log.Printf("StartMixer failed: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	respondJSON(w, http.StatusOK, json.RawMessage("{}"))
 }
 
 func PrivacyStopHandler(w http.ResponseWriter, r *http.Request) {
 	services.StopMixer()
-	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	respondJSON(w, http.StatusOK, json.RawMessage("{}"))
 }
 
 // MixerDebugHandler reads or toggles MIXC + TKBY debug logging on dcrwallet.
 func MixerDebugHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]bool{"enabled": services.MixerDebugEnabled()})
+		respondJSON(w, http.StatusOK, map[string]bool{"enabled": services.MixerDebugEnabled()})
 		return
 	}
 	var req struct {
 		Enabled bool `json:"enabled"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 	if err := services.SetMixerDebug(ctx, req.Enabled); err != nil {
 		log.Printf("SetMixerDebug failed: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]bool{"enabled": req.Enabled})
+	respondJSON(w, http.StatusOK, map[string]bool{"enabled": req.Enabled})
 }
 
 func StreamMixerEventsHandler(w http.ResponseWriter, r *http.Request) {
@@ -1025,9 +1165,11 @@ func StreamMixerEventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	for _, ev := range services.LastMixerEvents(200) {
-		if err := conn.WriteJSON(ev); err != nil {
+		if err := writeWSJSON(conn, ev); err != nil {
 			return
 		}
 	}
@@ -1035,15 +1177,7 @@ func StreamMixerEventsHandler(w http.ResponseWriter, r *http.Request) {
 	ch, unsubscribe := services.SubscribeMixerEvents()
 	defer unsubscribe()
 
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
@@ -1051,7 +1185,7 @@ func StreamMixerEventsHandler(w http.ResponseWriter, r *http.Request) {
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(ev); err != nil {
+			if err := writeWSJSON(conn, ev); err != nil {
 				return
 			}
 		case <-notify:
@@ -1061,23 +1195,19 @@ func StreamMixerEventsHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetAccountExtendedPubKeyHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	accountStr := r.URL.Query().Get("accountNumber")
 	if accountStr == "" {
-		http.Error(w, "accountNumber required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "accountNumber required")
 		return
 	}
 	accountU64, err := strconv.ParseUint(accountStr, 10, 32)
 	if err != nil {
-		http.Error(w, "invalid accountNumber", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid accountNumber")
 		return
 	}
 	accountNum := uint32(accountU64)
 	if accountNum == importedAccountNumber {
-		http.Error(w, "the imported account has no extended pubkey", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "the imported account has no extended pubkey")
 		return
 	}
 
@@ -1087,32 +1217,26 @@ func GetAccountExtendedPubKeyHandler(w http.ResponseWriter, r *http.Request) {
 	xpub, err := services.GetAccountExtendedPubKey(ctx, accountNum)
 	if err != nil {
 		log.Printf("GetAccountExtendedPubKey failed: %v", err)
-		http.Error(w, "failed to fetch extended pubkey", http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, "failed to fetch extended pubkey")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"xpub": xpub})
+	respondJSON(w, http.StatusOK, map[string]string{"xpub": xpub})
 }
 
 func ValidateAddressHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	address := r.URL.Query().Get("address")
 	if address == "" {
-		http.Error(w, "address required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "address required")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 	resp, err := services.ValidateAddress(ctx, address)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("validate failed: %v", err), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, fmt.Sprintf("validate failed: %v", err))
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.ValidateAddressResponse{
+	respondJSON(w, http.StatusOK, types.ValidateAddressResponse{
 		IsValid:       resp.IsValid,
 		IsMine:        resp.IsMine,
 		AccountNumber: resp.AccountNumber,
@@ -1165,16 +1289,12 @@ func resolveTxOutputs(ctx context.Context, req *types.ConstructTransactionReques
 }
 
 func ConstructTransactionHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil || rpc.DecodeMessageClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	// Constructing an unsigned transaction uses no private keys, so it is allowed
 	// for watch-only wallets to export for offline signing. Signing stays gated in
 	// SignPublishTransactionHandler (and dcrwallet rejects signing without keys).
 	var req types.ConstructTransactionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -1183,21 +1303,21 @@ func ConstructTransactionHandler(w http.ResponseWriter, r *http.Request) {
 
 	recipients, err := resolveTxOutputs(ctx, &req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	cResp, err := services.ConstructTransaction(ctx, req.SourceAccount, recipients, req.SendAll)
 	if err != nil {
 		log.Printf("ConstructTransaction failed: %v", err)
-		http.Error(w, fmt.Sprintf("construct failed: %v", err), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, fmt.Sprintf("construct failed: %v", err))
 		return
 	}
 
 	decoded, err := services.DecodeRawTransaction(ctx, cResp.UnsignedTransaction)
 	if err != nil {
 		log.Printf("DecodeRawTransaction failed: %v", err)
-		http.Error(w, fmt.Sprintf("decode failed: %v", err), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, fmt.Sprintf("decode failed: %v", err))
 		return
 	}
 	var inputs, outputs int64
@@ -1217,8 +1337,7 @@ func ConstructTransactionHandler(w http.ResponseWriter, r *http.Request) {
 		change = decoded.Outputs[cResp.ChangeIndex].Value
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.ConstructTransactionResponse{
+	respondJSON(w, http.StatusOK, types.ConstructTransactionResponse{
 		UnsignedTxHex:       hex.EncodeToString(cResp.UnsignedTransaction),
 		InputsTotalAtoms:    inputs,
 		OutputsTotalAtoms:   outputs,
@@ -1230,30 +1349,26 @@ func ConstructTransactionHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func SignPublishTransactionHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	if rejectWatchOnly(w, r) {
 		return
 	}
 
 	var req types.SignPublishTransactionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.UnsignedTxHex == "" || req.Passphrase == "" {
-		http.Error(w, "unsignedTxHex and passphrase required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "unsignedTxHex and passphrase required")
 		return
 	}
 	if len(req.Passphrase) > 1024 {
-		http.Error(w, "passphrase too long", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "passphrase too long")
 		return
 	}
 	txBytes, err := hex.DecodeString(req.UnsignedTxHex)
 	if err != nil {
-		http.Error(w, "invalid unsigned tx hex", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid unsigned tx hex")
 		return
 	}
 	passphrase := []byte(req.Passphrase)
@@ -1268,33 +1383,28 @@ func SignPublishTransactionHandler(w http.ResponseWriter, r *http.Request) {
 		lower := strings.ToLower(msg)
 		switch {
 		case errors.Is(err, services.ErrSpendWhileMixing):
-			http.Error(w, msg, http.StatusConflict)
+			respondError(w, http.StatusConflict, msg)
 		case strings.Contains(lower, "watching only"), strings.Contains(lower, "watchingonly"):
-			http.Error(w, "This account is watch-only — cannot sign", http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, "This account is watch-only — cannot sign")
 		case strings.Contains(lower, "passphrase"), strings.Contains(lower, "decrypt"):
-			http.Error(w, "Wrong passphrase", http.StatusUnauthorized)
+			respondError(w, http.StatusUnauthorized, "Wrong passphrase")
 		default:
 			log.Printf("SignAndPublishTransaction failed: %v", err)
-			http.Error(w, "sign/publish failed", http.StatusInternalServerError)
+			respondError(w, http.StatusInternalServerError, "sign/publish failed")
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.SignPublishTransactionResponse{TxHash: txHash})
+	respondJSON(w, http.StatusOK, types.SignPublishTransactionResponse{TxHash: txHash})
 }
 
 func NextAddressHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletClient == nil || rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	accountStr := r.URL.Query().Get("account")
 	if accountStr == "" {
 		accountStr = "0"
 	}
 	accountU64, err := strconv.ParseUint(accountStr, 10, 32)
 	if err != nil {
-		http.Error(w, "invalid account", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid account")
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
@@ -1302,11 +1412,10 @@ func NextAddressHandler(w http.ResponseWriter, r *http.Request) {
 	addr, err := services.GetNextAddress(ctx, uint32(accountU64))
 	if err != nil {
 		log.Printf("NextAddress RPC failed: %v", err)
-		http.Error(w, fmt.Sprintf("failed to derive address: %v", err), http.StatusInternalServerError)
+		respondError(w, http.StatusInternalServerError, fmt.Sprintf("failed to derive address: %v", err))
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.NextAddressResponse{
+	respondJSON(w, http.StatusOK, types.NextAddressResponse{
 		Address:       addr,
 		AccountNumber: uint32(accountU64),
 	})
@@ -7,10 +7,8 @@ package handlers
 import (
 	"log"
 	"net/http"
-	"time"
 
 	"dcrpulse/internal/middleware"
-	"dcrpulse/internal/services"
 
 	"github.com/gorilla/websocket"
 )
@@ -18,7 +16,8 @@ import (
 // StreamRescanGrpcHandler streams the SyncSnapshot to WebSocket clients.
 // On connect: pushes the current snapshot immediately. Then forwards every
 // snapshot update as the RpcSync supervisor + user-initiated rescans feed
-// the snapshot. Replaces the previous heuristic polling + log-parsing path.
+// the snapshot, rendered once for all streams by the sync broadcaster.
+// Replaces the previous heuristic polling + log-parsing path.
 func StreamRescanGrpcHandler(w http.ResponseWriter, r *http.Request) {
 	upgrader := websocket.Upgrader{
 		CheckOrigin: middleware.SameOriginWS,
@@ -29,40 +28,26 @@ func StreamRescanGrpcHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer conn.Close()
+	stopKeepAlive := armWSKeepAlive(conn)
+	defer stopKeepAlive()
 
 	log.Println("🔌 WebSocket: Client connected for sync state stream")
 
-	if err := conn.WriteJSON(snapshotPayload(services.GetSyncSnapshot())); err != nil {
+	initial, ch, unsubscribe := subscribeSyncPayloads()
+	defer unsubscribe()
+	if err := writeWSJSON(conn, initial); err != nil {
 		return
 	}
 
-	ch, unsubscribe := services.SubscribeSyncEvents()
-	defer unsubscribe()
-
-	notify := make(chan struct{})
-	go func() {
-		defer close(notify)
-		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
-				return
-			}
-		}
-	}()
-
-	keepAlive := time.NewTicker(15 * time.Second)
-	defer keepAlive.Stop()
+	notify := discardWSReads(conn)
 
 	for {
 		select {
-		case snap, ok := <-ch:
+		case payload, ok := <-ch:
 			if !ok {
 				return
 			}
-			if err := conn.WriteJSON(snapshotPayload(snap)); err != nil {
-				return
-			}
-		case <-keepAlive.C:
-			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := writeWSJSON(conn, payload); err != nil {
 				return
 			}
 		case <-notify:
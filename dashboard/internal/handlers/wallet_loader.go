@@ -6,11 +6,14 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
+	"dcrpulse/internal/rpc"
 	"dcrpulse/internal/services"
 	"dcrpulse/internal/types"
 
@@ -33,9 +36,8 @@ func init() {
 // SeedWordsHandler returns the PGP wordlist used for seed mnemonics, sourced
 // from dcrwallet's pgpwordlist package (upstream source of truth).
 func SeedWordsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "public, max-age=86400")
-	json.NewEncoder(w).Encode(seedWordList)
+	respondJSON(w, http.StatusOK, seedWordList)
 }
 
 // WalletExistsHandler checks if a wallet database exists
@@ -46,12 +48,11 @@ func WalletExistsHandler(w http.ResponseWriter, r *http.Request) {
 	resp, err := services.CheckWalletExists(ctx)
 	if err != nil {
 		log.Printf("Error checking wallet existence: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // WalletLoadedHandler checks if a wallet is currently loaded and ready
@@ -68,41 +69,68 @@ func WalletLoadedHandler(w http.ResponseWriter, r *http.Request) {
 		resp.Error = err.Error()
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // GenerateSeedHandler generates a new cryptographic seed.
-// req.SeedLength is in BYTES. Zero (or unset) -> dcrwallet's recommended 32
-// bytes -> 33-word Decred-standard mnemonic.
+// req.SeedLength is in BYTES. Zero (or unset) -> the recommended 32 bytes ->
+// 33-word Decred-standard mnemonic; see services.ValidSeedLengths for the rest.
 func GenerateSeedHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
 	var req types.GenerateSeedRequest
-	_ = json.NewDecoder(r.Body).Decode(&req)
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
 
 	resp, err := services.GenerateSeed(ctx, req.SeedLength)
+	if errors.Is(err, services.ErrInvalidSeedLength) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		log.Printf("Error generating seed: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// VerifySeedHandler checks the user's written-down backup against the seed
+// generate-seed returned, before the wallet is created from it. The words are
+// never logged.
+func VerifySeedHandler(w http.ResponseWriter, r *http.Request) {
+	var req types.VerifySeedRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	resp, err := services.VerifySeed(req)
+	switch {
+	case errors.Is(err, services.ErrNoPendingSeed):
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // DecodeSeedHandler validates a user-supplied seed (mnemonic or hex) via the
 // SeedService.DecodeSeed gRPC and returns the canonical hex on success.
 func DecodeSeedHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.DecodeSeedRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	if req.UserInput == "" {
-		http.Error(w, "userInput required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "userInput required")
 		return
 	}
 
@@ -111,51 +139,55 @@ func DecodeSeedHandler(w http.ResponseWriter, r *http.Request) {
 
 	seedHex, err := services.DecodeSeed(ctx, req.UserInput)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.DecodeSeedResponse{SeedHex: seedHex})
+	respondJSON(w, http.StatusOK, types.DecodeSeedResponse{SeedHex: seedHex})
+}
+
+// validateWalletPassphrases checks a new wallet's passphrases, returning the
+// message to reject them with or "" when they are acceptable.
+func validateWalletPassphrases(public, confirmPublic, private, confirmPrivate string) string {
+	if private == "" {
+		return "Private passphrase is required"
+	}
+	if len(private) < 8 {
+		return "Private passphrase must be at least 8 characters"
+	}
+	if len(private) > 1024 || len(confirmPrivate) > 1024 ||
+		len(public) > 1024 || len(confirmPublic) > 1024 {
+		return "Passphrase too long"
+	}
+	if private != confirmPrivate {
+		return "Private passphrases do not match"
+	}
+	if public != "" {
+		if len(public) < 8 {
+			return "Public passphrase must be at least 8 characters"
+		}
+		if public != confirmPublic {
+			return "Public passphrases do not match"
+		}
+	}
+	return ""
 }
 
 // CreateWalletHandler creates a new wallet
 func CreateWalletHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.CreateWalletRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate input
-	if req.PrivatePassphrase == "" {
-		http.Error(w, "Private passphrase is required", http.StatusBadRequest)
-		return
-	}
-	if len(req.PrivatePassphrase) < 8 {
-		http.Error(w, "Private passphrase must be at least 8 characters", http.StatusBadRequest)
-		return
-	}
-	if len(req.PrivatePassphrase) > 1024 || len(req.ConfirmPrivatePassphrase) > 1024 ||
-		len(req.PublicPassphrase) > 1024 || len(req.ConfirmPublicPassphrase) > 1024 {
-		http.Error(w, "Passphrase too long", http.StatusBadRequest)
+	if msg := validateWalletPassphrases(req.PublicPassphrase, req.ConfirmPublicPassphrase,
+		req.PrivatePassphrase, req.ConfirmPrivatePassphrase); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
 		return
 	}
-	if req.PrivatePassphrase != req.ConfirmPrivatePassphrase {
-		http.Error(w, "Private passphrases do not match", http.StatusBadRequest)
-		return
-	}
-	if req.PublicPassphrase != "" {
-		if len(req.PublicPassphrase) < 8 {
-			http.Error(w, "Public passphrase must be at least 8 characters", http.StatusBadRequest)
-			return
-		}
-		if req.PublicPassphrase != req.ConfirmPublicPassphrase {
-			http.Error(w, "Public passphrases do not match", http.StatusBadRequest)
-			return
-		}
-	}
 	if req.SeedHex == "" {
-		http.Error(w, "Seed is required", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "Seed is required")
 		return
 	}
 
@@ -169,9 +201,7 @@ func CreateWalletHandler(w http.ResponseWriter, r *http.Request) {
 			Success: false,
 			Message: err.Error(),
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(resp)
+		respondErrorData(w, http.StatusInternalServerError, resp.Message, resp)
 		return
 	}
 
@@ -181,15 +211,64 @@ func CreateWalletHandler(w http.ResponseWriter, r *http.Request) {
 		Message: "Wallet created successfully",
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RestoreWalletHandler restores a wallet from its seed mnemonic. The wallet
+// is created with account discovery on, and the discovery sync rescans from
+// the optional birthday height (genesis when omitted) to find existing funds.
+func RestoreWalletHandler(w http.ResponseWriter, r *http.Request) {
+	var req types.RestoreWalletRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if msg := validateWalletPassphrases(req.PublicPassphrase, req.ConfirmPublicPassphrase,
+		req.PrivatePassphrase, req.ConfirmPrivatePassphrase); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if strings.TrimSpace(req.Mnemonic) == "" {
+		respondError(w, http.StatusBadRequest, "Mnemonic is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	if req.BirthHeight != nil && rpc.DcrdClient != nil {
+		if tip, err := rpc.DcrdClient.GetBlockCount(ctx); err == nil && int64(*req.BirthHeight) > tip {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("birthHeight %d is beyond the chain tip (%d)", *req.BirthHeight, tip))
+			return
+		}
+	}
+
+	err := services.RestoreWalletFromMnemonic(ctx, req.PublicPassphrase, req.PrivatePassphrase, req.Mnemonic, req.BirthHeight)
+	if errors.Is(err, services.ErrInvalidMnemonic) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Error restoring wallet: %v", err)
+		resp := types.CreateWalletResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+		respondErrorData(w, http.StatusInternalServerError, resp.Message, resp)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, types.CreateWalletResponse{
+		Success: true,
+		Message: "Wallet restored; discovering accounts and rescanning for existing funds",
+	})
 }
 
 // OpenWalletHandler opens an existing wallet
 func OpenWalletHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.OpenWalletRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -197,16 +276,29 @@ func OpenWalletHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	err := services.OpenWallet(ctx, req.PublicPassphrase)
+	if req.BeginHeight != nil && rpc.DcrdClient != nil {
+		if tip, err := rpc.DcrdClient.GetBlockCount(ctx); err == nil && int64(*req.BeginHeight) >= tip {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("beginHeight %d is not below the chain tip (%d)", *req.BeginHeight, tip))
+			return
+		}
+	}
+
+	err := services.OpenWallet(ctx, req.PublicPassphrase, req.BeginHeight)
+	if errors.Is(err, services.ErrWalletAlreadyOpen) {
+		respondJSON(w, http.StatusOK, types.OpenWalletResponse{
+			Success:     true,
+			Message:     "Wallet is already open",
+			AlreadyOpen: true,
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("Error opening wallet: %v", err)
 		resp := types.OpenWalletResponse{
 			Success: false,
 			Message: err.Error(),
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(resp)
+		respondErrorData(w, http.StatusUnauthorized, resp.Message, resp)
 		return
 	}
 
@@ -215,6 +307,42 @@ func OpenWalletHandler(w http.ResponseWriter, r *http.Request) {
 		Message: "Wallet opened successfully",
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ReloadWalletHandler force-closes and reopens the wallet with the given
+// public passphrase and restarts RPC sync. A wallet that is not open is just
+// opened.
+func ReloadWalletHandler(w http.ResponseWriter, r *http.Request) {
+	var req types.OpenWalletRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 45*time.Second)
+	defer cancel()
+
+	wasOpen, err := services.ReloadWallet(ctx, req.PublicPassphrase)
+	loaded, _ := services.CheckWalletLoaded(ctx)
+	snap := services.GetSyncSnapshot()
+	resp := types.ReloadWalletResponse{
+		Success:         err == nil,
+		WasOpen:         wasOpen,
+		Loaded:          loaded,
+		SyncPhase:       string(snap.Phase),
+		DaemonConnected: snap.DaemonConnected,
+	}
+	if err != nil {
+		log.Printf("Error reloading wallet: %v", err)
+		resp.Message = err.Error()
+		respondErrorData(w, http.StatusUnauthorized, resp.Message, resp)
+		return
+	}
+
+	resp.Message = "Wallet reopened; RPC sync restarting"
+	if !wasOpen {
+		resp.Message = "Wallet opened; RPC sync starting"
+	}
+	respondJSON(w, http.StatusOK, resp)
 }
@@ -7,7 +7,6 @@ package handlers
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,7 +14,6 @@ import (
 	"strings"
 	"time"
 
-	"dcrpulse/internal/rpc"
 	"dcrpulse/internal/services"
 	"dcrpulse/internal/types"
 )
@@ -47,18 +45,14 @@ func decodeSignedTxInput(b64, text string) ([]byte, error) {
 // into a preview for the user to verify. It uses no private keys and is allowed for
 // watch-only wallets.
 func DecodeSignedTransactionHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil || rpc.DecodeMessageClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	var req types.DecodeSignedTxRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	data, err := decodeSignedTxInput(req.SignedTxB64, req.SignedTx)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -71,33 +65,28 @@ func DecodeSignedTransactionHandler(w http.ResponseWriter, r *http.Request) {
 			respondDaemonError(w, r, services.LogComponentDcrwallet, err)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(preview)
+	respondJSON(w, http.StatusOK, preview)
 }
 
 // BroadcastSignedTransactionHandler publishes an already-signed transaction. It
 // uses no private keys and is allowed for watch-only wallets.
 func BroadcastSignedTransactionHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	var req types.BroadcastSignedTxRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	data, err := decodeSignedTxInput(req.SignedTxB64, req.SignedTx)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	txBytes, tx, err := services.ParseSignedTransaction(data)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	txid := tx.TxHash().String()
@@ -113,18 +102,16 @@ func BroadcastSignedTransactionHandler(w http.ResponseWriter, r *http.Request) {
 			respondDaemonError(w, r, services.LogComponentDcrwallet, err)
 		case reDuplicateTx.MatchString(low):
 			// Already broadcast: report success with the known txid.
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(types.BroadcastSignedTxResponse{TxHash: txid, AlreadyBroadcast: true})
+			respondJSON(w, http.StatusOK, types.BroadcastSignedTxResponse{TxHash: txid, AlreadyBroadcast: true})
 		case strings.Contains(low, "missing") || strings.Contains(low, "orphan") || strings.Contains(low, "spent"):
-			http.Error(w, err.Error(), http.StatusConflict)
+			respondError(w, http.StatusConflict, err.Error())
 		default:
 			log.Printf("BroadcastSignedTransaction failed: %v", err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			respondRPCError(w, err)
 		}
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.BroadcastSignedTxResponse{TxHash: txHash})
+	respondJSON(w, http.StatusOK, types.BroadcastSignedTxResponse{TxHash: txHash})
 }
 
 // ParseAccountExportHandler decodes a device account-export file (accounts.dcr)
@@ -132,13 +119,13 @@ func BroadcastSignedTransactionHandler(w http.ResponseWriter, r *http.Request) {
 // no keys are needed, and the file carries only public key material.
 func ParseAccountExportHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.ParseAccountExportRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(req.FileB64))
 	if err != nil || len(data) == 0 {
-		http.Error(w, "invalid base64 file data", http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, "invalid base64 file data")
 		return
 	}
 
@@ -147,7 +134,7 @@ func ParseAccountExportHandler(w http.ResponseWriter, r *http.Request) {
 
 	entries, err := services.ParseAccountExport(ctx, data)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	// The create-new-wallet wizard imports into a wallet that does not exist
@@ -155,8 +142,7 @@ func ParseAccountExportHandler(w http.ResponseWriter, r *http.Request) {
 	if !req.NewWallet {
 		services.AnnotateAccountExportConflicts(ctx, entries)
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.ParseAccountExportResponse{Entries: entries})
+	respondJSON(w, http.StatusOK, types.ParseAccountExportResponse{Entries: entries})
 }
 
 // DeviceBalanceHandler exports the wallet's per-account balances and the DCR/USD
@@ -164,10 +150,6 @@ func ParseAccountExportHandler(w http.ResponseWriter, r *http.Request) {
 // microSD file or a UR QR). It uses no private keys and is allowed for watch-only
 // wallets.
 func DeviceBalanceHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
@@ -177,24 +159,19 @@ func DeviceBalanceHandler(w http.ResponseWriter, r *http.Request) {
 			respondDaemonError(w, r, services.LogComponentDcrwallet, err)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(export)
+	respondJSON(w, http.StatusOK, export)
 }
 
 // BuildSignRequestHandler constructs an unsigned transaction and returns it as a
 // base64 CBOR SignRequest for an air-gapped hardware wallet to sign. It uses no
 // private keys and is allowed for watch-only wallets.
 func BuildSignRequestHandler(w http.ResponseWriter, r *http.Request) {
-	if rpc.WalletGrpcClient == nil || rpc.DecodeMessageClient == nil {
-		http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
-		return
-	}
 	var req types.ConstructTransactionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -203,7 +180,7 @@ func BuildSignRequestHandler(w http.ResponseWriter, r *http.Request) {
 
 	recipients, err := resolveTxOutputs(ctx, &req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -216,12 +193,11 @@ func BuildSignRequestHandler(w http.ResponseWriter, r *http.Request) {
 		// A missing BIP44 index mapping for an imported xpub account is a client
 		// fixable condition (re-import specifying the account index).
 		if strings.Contains(err.Error(), "BIP44 account index") {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			respondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(export)
+	respondJSON(w, http.StatusOK, export)
 }
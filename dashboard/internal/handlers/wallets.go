@@ -6,7 +6,7 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
@@ -29,12 +29,11 @@ func ListWalletsHandler(w http.ResponseWriter, r *http.Request) {
 	wallets, err := services.ListWallets(ctx)
 	if err != nil {
 		log.Printf("Error listing wallets: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{
+	respondJSON(w, http.StatusOK, map[string]any{
 		"wallets": wallets,
 		"active":  services.ActiveWalletName(),
 	})
@@ -46,8 +45,8 @@ func SelectWalletHandler(w http.ResponseWriter, r *http.Request) {
 		Name             string `json:"name"`
 		PublicPassphrase string `json:"publicPassphrase"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -66,8 +65,7 @@ func SelectWalletHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{"success": true, "active": services.ActiveWalletName()})
+	respondJSON(w, http.StatusOK, map[string]any{"success": true, "active": services.ActiveWalletName()})
 }
 
 // CloseWalletHandler closes the active wallet and returns to the wallet list.
@@ -75,21 +73,32 @@ func CloseWalletHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	if err := services.CloseActiveWallet(ctx); err != nil {
+	err := services.CloseActiveWallet(ctx)
+	if errors.Is(err, services.ErrWalletNotOpen) {
+		respondJSON(w, http.StatusOK, types.CloseWalletResponse{
+			Success:       true,
+			Message:       "No wallet was open",
+			AlreadyClosed: true,
+		})
+		return
+	}
+	if err != nil {
 		log.Printf("Error closing wallet: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		respondRPCError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{"success": true})
+	respondJSON(w, http.StatusOK, types.CloseWalletResponse{
+		Success: true,
+		Message: "Wallet closed successfully",
+	})
 }
 
 // CreateNamedWalletHandler creates a new named wallet and makes it active.
 func CreateNamedWalletHandler(w http.ResponseWriter, r *http.Request) {
 	var req types.CreateWalletRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -119,13 +128,10 @@ func CreateNamedWalletHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		if err := services.CreateNamedWatchOnlyWallet(ctx, name, req.PublicPassphrase, strings.TrimSpace(req.ExtendedPubKey), req.AccountIndex); err != nil {
 			log.Printf("Error creating watch-only wallet %q: %v", name, err)
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(types.CreateWalletResponse{Success: false, Message: err.Error()})
+			respondErrorData(w, http.StatusInternalServerError, err.Error(), types.CreateWalletResponse{Success: false, Message: err.Error()})
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(types.CreateWalletResponse{Success: true, Message: "Watch-only wallet created successfully"})
+		respondJSON(w, http.StatusOK, types.CreateWalletResponse{Success: true, Message: "Watch-only wallet created successfully"})
 		return
 	}
 
@@ -140,14 +146,11 @@ func CreateNamedWalletHandler(w http.ResponseWriter, r *http.Request) {
 
 	if err := services.CreateNamedWallet(ctx, name, req.PublicPassphrase, req.PrivatePassphrase, req.SeedHex, req.DiscoverAccounts); err != nil {
 		log.Printf("Error creating wallet %q: %v", name, err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(types.CreateWalletResponse{Success: false, Message: err.Error()})
+		respondErrorData(w, http.StatusInternalServerError, err.Error(), types.CreateWalletResponse{Success: false, Message: err.Error()})
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(types.CreateWalletResponse{Success: true, Message: "Wallet created successfully"})
+	respondJSON(w, http.StatusOK, types.CreateWalletResponse{Success: true, Message: "Wallet created successfully"})
 }
 
 // RenameWalletHandler renames a non-active, non-default wallet.
@@ -156,8 +159,8 @@ func RenameWalletHandler(w http.ResponseWriter, r *http.Request) {
 		From string `json:"from"`
 		To   string `json:"to"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -170,8 +173,7 @@ func RenameWalletHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{"success": true})
+	respondJSON(w, http.StatusOK, map[string]any{"success": true})
 }
 
 // DeleteWalletHandler backs up and removes a non-active wallet.
@@ -179,8 +181,8 @@ func DeleteWalletHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name string `json:"name"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
@@ -193,14 +195,11 @@ func DeleteWalletHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{"success": true})
+	respondJSON(w, http.StatusOK, map[string]any{"success": true})
 }
 
 func writeJSONError(w http.ResponseWriter, status int, msg string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]any{"success": false, "message": msg})
+	respondErrorData(w, status, msg, map[string]any{"success": false, "message": msg})
 }
 
 // validateCreateWalletPassphrases mirrors CreateWalletHandler's checks; returns
@@ -0,0 +1,190 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Default WebSocket keep-alive timings, used unless SetWSKeepAlive overrides
+// them.
+const (
+	DefaultWSPingInterval = 15 * time.Second
+	DefaultWSPongTimeout  = 45 * time.Second
+	DefaultWSWriteTimeout = 10 * time.Second
+)
+
+var (
+	wsTimingMu     sync.RWMutex
+	wsPingInterval = DefaultWSPingInterval
+	wsPongTimeout  = DefaultWSPongTimeout
+	wsWriteTimeout = DefaultWSWriteTimeout
+)
+
+// SetWSKeepAlive sets how often browser WebSockets are pinged, how long one
+// may go without a pong before it is dropped, and how long a single write
+// may block. Zero keeps a setting's default. A pong timeout that would not
+// outlast a ping interval is raised to three intervals.
+func SetWSKeepAlive(pingInterval, pongTimeout, writeTimeout time.Duration) {
+	wsTimingMu.Lock()
+	defer wsTimingMu.Unlock()
+	if pingInterval > 0 {
+		wsPingInterval = pingInterval
+	}
+	if pongTimeout > 0 {
+		wsPongTimeout = pongTimeout
+	}
+	if writeTimeout > 0 {
+		wsWriteTimeout = writeTimeout
+	}
+	if wsPongTimeout <= wsPingInterval {
+		log.Printf("Warning: WebSocket pong timeout %s does not outlast the %s ping interval; using %s",
+			wsPongTimeout, wsPingInterval, 3*wsPingInterval)
+		wsPongTimeout = 3 * wsPingInterval
+	}
+}
+
+// DefaultWSMaxConnections is how many browser WebSockets may be open at once
+// across every stream endpoint, unless SetWSMaxConnections overrides it.
+const DefaultWSMaxConnections = 64
+
+// wsRetryAfter is the Retry-After, in seconds, sent with an upgrade refused
+// for the connection limit.
+const wsRetryAfter = 5
+
+var (
+	wsMaxConnections atomic.Int64
+	wsActive         atomic.Int64
+)
+
+func init() {
+	wsMaxConnections.Store(DefaultWSMaxConnections)
+}
+
+// SetWSMaxConnections sets how many browser WebSockets may be open at once.
+// Zero keeps the default.
+func SetWSMaxConnections(n int) {
+	if n > 0 {
+		wsMaxConnections.Store(int64(n))
+	}
+}
+
+// WSConnections returns the open browser WebSockets and the limit on them.
+func WSConnections() (active, limit int) {
+	return int(wsActive.Load()), int(wsMaxConnections.Load())
+}
+
+// acquireWSSlot takes one of the WebSocket connection slots, reporting false
+// when all are in use.
+func acquireWSSlot() bool {
+	for {
+		n := wsActive.Load()
+		if n >= wsMaxConnections.Load() {
+			return false
+		}
+		if wsActive.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+// LimitWebSockets holds a connection slot for every WebSocket upgrade request
+// while its handler runs, which is the connection's lifetime since stream
+// handlers return once it closes. An upgrade over the limit is refused with
+// 503 and a Retry-After before the handler starts any tickers or goroutines;
+// other requests pass through.
+func LimitWebSockets(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !websocket.IsWebSocketUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !acquireWSSlot() {
+			active, limit := WSConnections()
+			log.Printf("Refusing WebSocket %s: %d of %d connections open", r.URL.Path, active, limit)
+			w.Header().Set("Retry-After", strconv.Itoa(wsRetryAfter))
+			respondError(w, http.StatusServiceUnavailable, "too many WebSocket connections, retry later")
+			return
+		}
+		defer wsActive.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func wsTimings() (ping, pong, write time.Duration) {
+	wsTimingMu.RLock()
+	defer wsTimingMu.RUnlock()
+	return wsPingInterval, wsPongTimeout, wsWriteTimeout
+}
+
+// wsWriteDeadline is the deadline for a write starting now.
+func wsWriteDeadline() time.Time {
+	_, _, write := wsTimings()
+	return time.Now().Add(write)
+}
+
+// armWSKeepAlive makes a half-open browser connection fail promptly instead
+// of on some later write. It sets a read deadline that every pong pushes
+// back and pings the client on an interval; a client that stops answering
+// fails the handler's next read, and a ping that can't be written closes the
+// connection. Pongs are only seen while something reads from conn, so the
+// handler must keep a read loop running (see discardWSReads). The returned
+// func stops the pinger.
+func armWSKeepAlive(conn *websocket.Conn) (stop func()) {
+	ping, pong, _ := wsTimings()
+	_ = conn.SetReadDeadline(time.Now().Add(pong))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pong))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		t := time.NewTicker(ping)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				// WriteControl is safe alongside the handler's own writes.
+				if err := conn.WriteControl(websocket.PingMessage, nil, wsWriteDeadline()); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// discardWSReads reads and drops client messages, for handlers that only
+// stream to the browser, so pongs and the close frame are processed. The
+// returned channel is closed once the connection is gone.
+func discardWSReads(conn *websocket.Conn) <-chan struct{} {
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	return gone
+}
+
+// writeWSJSON writes v as a text frame within the write timeout.
+func writeWSJSON(conn *websocket.Conn, v interface{}) error {
+	_ = conn.SetWriteDeadline(wsWriteDeadline())
+	return conn.WriteJSON(v)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func wsUpgradeRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/explorer/stream-blocks", nil)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	return r
+}
+
+func TestLimitWebSockets(t *testing.T) {
+	saved := wsMaxConnections.Load()
+	t.Cleanup(func() { wsMaxConnections.Store(saved) })
+	SetWSMaxConnections(1)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	h := LimitWebSockets(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		entered <- struct{}{}
+		<-release
+	}))
+
+	// The first upgrade holds the only slot while its handler runs.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(httptest.NewRecorder(), wsUpgradeRequest())
+	}()
+	<-entered
+	if active, limit := WSConnections(); active != 1 || limit != 1 {
+		t.Fatalf("WSConnections = %d, %d; want 1, 1", active, limit)
+	}
+
+	// A second upgrade is refused without reaching the handler.
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, wsUpgradeRequest())
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("second upgrade status = %d, want 503", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != strconv.Itoa(wsRetryAfter) {
+		t.Errorf("Retry-After = %q, want %d", got, wsRetryAfter)
+	}
+
+	// Other requests pass while the slots are taken.
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("plain request status = %d, want 200", rec.Code)
+	}
+
+	// Closing the first connection frees its slot for the next upgrade.
+	close(release)
+	<-done
+	if active, _ := WSConnections(); active != 0 {
+		t.Fatalf("active after the handler returned = %d, want 0", active)
+	}
+	go func() {
+		<-entered
+	}()
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, wsUpgradeRequest())
+	if rec.Code == http.StatusServiceUnavailable {
+		t.Error("upgrade refused after the slot was released")
+	}
+	if active, _ := WSConnections(); active != 0 {
+		t.Errorf("active after the second connection = %d, want 0", active)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"dcrpulse/internal/types"
+)
+
+// EnvelopeHeader marks a response body as a types.APIResponse envelope, so
+// clients can unwrap it without guessing from the body's shape.
+const EnvelopeHeader = "X-Dcrpulse-Envelope"
+
+// WriteError writes an error in the API envelope, for middleware that refuses
+// a request before it reaches a handler. code is one of the handlers
+// package's ErrCode values.
+func WriteError(w http.ResponseWriter, status int, code, message string) {
+	body, _ := json.Marshal(types.APIResponse{
+		Error: &types.APIError{Code: code, Message: message},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(EnvelopeHeader, "1")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
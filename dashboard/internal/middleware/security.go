@@ -77,12 +77,12 @@ func RequireSameOrigin(next http.Handler) http.Handler {
 		}
 		origin := r.Header.Get("Origin")
 		if origin == "" {
-			http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+			WriteError(w, http.StatusForbidden, "forbidden", "cross-origin request rejected")
 			return
 		}
 		u, err := url.Parse(origin)
 		if err != nil || u.Host != expectedHost(r) {
-			http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+			WriteError(w, http.StatusForbidden, "forbidden", "cross-origin request rejected")
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -117,17 +117,37 @@ func SecurityHeaders(next http.Handler) http.Handler {
 	})
 }
 
+// DefaultMaxBodyBytes is the request body cap LimitJSONBody is given unless
+// API_MAX_BODY_BYTES overrides it.
+const DefaultMaxBodyBytes = 1 << 20
+
+// BodyLimit is a body cap for one path in place of LimitJSONBody's own, for
+// routes that take documents rather than small JSON requests.
+type BodyLimit struct {
+	Path     string
+	MaxBytes int64
+}
+
 // LimitJSONBody caps request bodies on state-changing methods. Oversized
-// bodies surface as a read error inside handlers, which already return 4xx.
+// bodies surface as a read error inside handlers, which return 400 naming
+// the limit. A request whose path matches one of overrides gets that cap
+// instead.
 // Skipped for multipart uploads so file-attachment handlers can apply their
 // own (larger) limit.
-func LimitJSONBody(maxBytes int64) func(http.Handler) http.Handler {
+func LimitJSONBody(maxBytes int64, overrides ...BodyLimit) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.Method {
 			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
 				if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
-					r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+					limit := maxBytes
+					for _, o := range overrides {
+						if r.URL.Path == o.Path {
+							limit = o.MaxBytes
+							break
+						}
+					}
+					r.Body = http.MaxBytesReader(w, r.Body, limit)
 				}
 			}
 			next.ServeHTTP(w, r)
@@ -154,7 +174,7 @@ func RateLimit(name string, every time.Duration, burst int) func(http.Handler) h
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !lim.Allow() {
-				http.Error(w, "rate limit exceeded, retry later", http.StatusTooManyRequests)
+				WriteError(w, http.StatusTooManyRequests, "too_many_requests", "rate limit exceeded, retry later")
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -22,11 +22,12 @@ import (
 )
 
 var (
-	// DcrdClient is the RPC client for dcrd
-	DcrdClient *rpcclient.Client
+	// DcrdClient is the RPC client for dcrd, a *LimitedClient whose calls
+	// share the SetDcrdConcurrency limit
+	DcrdClient DcrdRPC
 
 	// WalletClient is the RPC client for dcrwallet (JSON-RPC)
-	WalletClient *rpcclient.Client
+	WalletClient WalletRPC
 
 	// WalletGrpcClient is the gRPC client for dcrwallet (for streaming)
 	WalletGrpcClient pb.WalletServiceClient
@@ -64,6 +65,16 @@ var (
 	WalletConfig Config
 )
 
+// clientRetireGrace is how long a JSON-RPC client replaced at runtime keeps
+// serving calls that picked it up before the swap; it is shut down after.
+const clientRetireGrace = 2 * time.Minute
+
+// retireClient shuts old down once clientRetireGrace has passed, so calls
+// already running on it finish instead of failing with ErrClientShutdown.
+func retireClient(old interface{ Shutdown() }) {
+	time.AfterFunc(clientRetireGrace, old.Shutdown)
+}
+
 // Config holds the RPC connection configuration
 type Config struct {
 	RPCHost     string
@@ -116,10 +127,18 @@ func InitDcrdClient(config Config) error {
 		Certificates: certs,
 	}
 
-	DcrdClient, err = rpcclient.New(connCfg, nil)
+	client, err := rpcclient.New(connCfg, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create RPC client: %v", err)
 	}
+	// Reconfigured at runtime: new calls go to the new client at once, and
+	// the previous one is released after in-flight calls have had time to
+	// finish.
+	old := DcrdClient
+	DcrdClient = &LimitedClient{Client: client}
+	if old != nil {
+		retireClient(old)
+	}
 
 	// Test connection
 	ctx := context.Background()
@@ -164,10 +183,15 @@ func InitWalletClient(config Config) error {
 		Certificates: certs,
 	}
 
-	WalletClient, err = rpcclient.New(connCfg, nil)
+	client, err := rpcclient.New(connCfg, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create wallet RPC client: %v", err)
 	}
+	old := WalletClient
+	WalletClient = &walletClient{Client: client}
+	if old != nil {
+		retireClient(old)
+	}
 
 	// Test connection with getinfo
 	ctx := context.Background()
@@ -230,6 +254,7 @@ func dialWalletGrpc(config GrpcConfig) error {
 	conn, err := grpc.Dial(
 		target,
 		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(classifyUnary),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create wallet gRPC connection: %v", err)
@@ -0,0 +1,244 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/rpcclient/v8"
+	"github.com/decred/dcrd/wire"
+)
+
+// DefaultDcrdConcurrency is the default cap on simultaneous dcrd calls. It
+// stays under dcrd's own rpcmaxconcurrentreqs default of 20 so dcrpulse never
+// fills the node's whole worker pool.
+const DefaultDcrdConcurrency = 16
+
+// dcrdSem holds one slot per in-flight dcrd call. Scans, vote walks and the
+// dashboard aggregation all share it, so bursts queue here instead of timing
+// out at the node.
+var (
+	dcrdSem      = make(chan struct{}, DefaultDcrdConcurrency)
+	dcrdInFlight atomic.Int64
+	dcrdQueued   atomic.Int64
+)
+
+// SetDcrdConcurrency sets the dcrd call limit; n <= 0 selects
+// DefaultDcrdConcurrency. Call it before InitDcrdClient.
+func SetDcrdConcurrency(n int) {
+	if n <= 0 {
+		n = DefaultDcrdConcurrency
+	}
+	dcrdSem = make(chan struct{}, n)
+}
+
+// DcrdCallStats reports the dcrd calls currently running, those waiting for
+// a slot, and the limit.
+func DcrdCallStats() (inFlight, queued, limit int) {
+	return int(dcrdInFlight.Load()), int(dcrdQueued.Load()), cap(dcrdSem)
+}
+
+// acquireDcrd waits for a call slot or for ctx to end. The returned func
+// releases the slot.
+func acquireDcrd(ctx context.Context) (func(), error) {
+	sem := dcrdSem
+	dcrdQueued.Add(1)
+	select {
+	case sem <- struct{}{}:
+		dcrdQueued.Add(-1)
+	case <-ctx.Done():
+		dcrdQueued.Add(-1)
+		return nil, ctx.Err()
+	}
+	dcrdInFlight.Add(1)
+	return func() {
+		dcrdInFlight.Add(-1)
+		<-sem
+	}, nil
+}
+
+// LimitedClient is the dcrd client with every call dcrpulse makes holding a
+// slot of the shared concurrency limit, and its errors classified. Methods
+// not overridden here bypass both, so a newly used call should be added.
+type LimitedClient struct {
+	*rpcclient.Client
+}
+
+func (c *LimitedClient) RawRequest(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.RawRequest(ctx, method, params))
+}
+
+func (c *LimitedClient) GetBlockCount(ctx context.Context) (int64, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return 0, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBlockCount(ctx))
+}
+
+func (c *LimitedClient) GetBlockHash(ctx context.Context, blockHeight int64) (*chainhash.Hash, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBlockHash(ctx, blockHeight))
+}
+
+func (c *LimitedClient) GetBlockChainInfo(ctx context.Context) (*chainjson.GetBlockChainInfoResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBlockChainInfo(ctx))
+}
+
+func (c *LimitedClient) GetTreasuryBalance(ctx context.Context, block *chainhash.Hash, verbose bool) (*chainjson.GetTreasuryBalanceResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetTreasuryBalance(ctx, block, verbose))
+}
+
+func (c *LimitedClient) GetTxOut(ctx context.Context, txHash *chainhash.Hash, index uint32, tree int8, mempool bool) (*chainjson.GetTxOutResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetTxOut(ctx, txHash, index, tree, mempool))
+}
+
+func (c *LimitedClient) GetBlockHeader(ctx context.Context, hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBlockHeader(ctx, hash))
+}
+
+func (c *LimitedClient) GetBlockHeaderVerbose(ctx context.Context, hash *chainhash.Hash) (*chainjson.GetBlockHeaderVerboseResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBlockHeaderVerbose(ctx, hash))
+}
+
+func (c *LimitedClient) GetTicketPoolValue(ctx context.Context) (dcrutil.Amount, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return 0, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetTicketPoolValue(ctx))
+}
+
+func (c *LimitedClient) GetPeerInfo(ctx context.Context) ([]chainjson.GetPeerInfoResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetPeerInfo(ctx))
+}
+
+func (c *LimitedClient) GetCoinSupply(ctx context.Context) (dcrutil.Amount, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return 0, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetCoinSupply(ctx))
+}
+
+func (c *LimitedClient) Version(ctx context.Context) (map[string]chainjson.VersionResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.Version(ctx))
+}
+
+func (c *LimitedClient) LiveTickets(ctx context.Context) ([]*chainhash.Hash, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.LiveTickets(ctx))
+}
+
+func (c *LimitedClient) GetRawTransactionVerbose(ctx context.Context, txHash *chainhash.Hash) (*chainjson.TxRawResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetRawTransactionVerbose(ctx, txHash))
+}
+
+func (c *LimitedClient) GetDifficulty(ctx context.Context) (float64, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return 0, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetDifficulty(ctx))
+}
+
+func (c *LimitedClient) GetConnectionCount(ctx context.Context) (int64, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return 0, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetConnectionCount(ctx))
+}
+
+func (c *LimitedClient) GetBlockVerbose(ctx context.Context, blockHash *chainhash.Hash, verboseTx bool) (*chainjson.GetBlockVerboseResult, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBlockVerbose(ctx, blockHash, verboseTx))
+}
+
+func (c *LimitedClient) GetBestBlockHash(ctx context.Context) (*chainhash.Hash, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, Classify(err)
+	}
+	defer release()
+	return classified(c.Client.GetBestBlockHash(ctx))
+}
+
+func (c *LimitedClient) GetBestBlock(ctx context.Context) (*chainhash.Hash, int64, error) {
+	release, err := acquireDcrd(ctx)
+	if err != nil {
+		return nil, 0, Classify(err)
+	}
+	defer release()
+	hash, height, err := c.Client.GetBestBlock(ctx)
+	return hash, height, Classify(err)
+}
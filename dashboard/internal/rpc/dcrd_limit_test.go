@@ -0,0 +1,43 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+// TestLimitedClientOverrides checks that every DcrdRPC call is declared on
+// LimitedClient, so none is promoted from rpcclient.Client and bypasses the
+// concurrency limit and error classification.
+func TestLimitedClientOverrides(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "dcrd_limit.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared := map[string]bool{}
+	for _, d := range f.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+		if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok {
+			if id, ok := star.X.(*ast.Ident); ok && id.Name == "LimitedClient" {
+				declared[fn.Name.Name] = true
+			}
+		}
+	}
+
+	iface := reflect.TypeOf((*DcrdRPC)(nil)).Elem()
+	for i := 0; i < iface.NumMethod(); i++ {
+		name := iface.Method(i).Name
+		if name != "Shutdown" && !declared[name] {
+			t.Errorf("LimitedClient does not override %s", name)
+		}
+	}
+}
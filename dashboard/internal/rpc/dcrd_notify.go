@@ -10,19 +10,25 @@ import (
 	"io/ioutil"
 	"log"
 
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	"github.com/decred/dcrd/rpcclient/v8"
+	"github.com/decred/dcrd/wire"
 )
 
 // DcrdNotifyClient is a second dcrd client in WebSocket mode, used only for
-// block-connected notifications (dcrd has no gRPC; the main DcrdClient runs in
-// HTTP POST mode, which cannot receive notifications). It pushes a callback on
-// each new block so the node sync progress can update without polling.
+// block-connected and tx-accepted notifications (dcrd has no gRPC; the main
+// DcrdClient runs in HTTP POST mode, which cannot receive notifications). It
+// pushes a callback on each new block so the node sync progress can update
+// without polling, and on each transaction accepted into mempool.
 var DcrdNotifyClient *rpcclient.Client
 
 // InitDcrdNotifyClient connects a websocket dcrd client and subscribes to block
-// notifications, invoking onBlock for each connected block. It re-subscribes on
-// every (re)connection so progress keeps flowing across dcrd restarts.
-func InitDcrdNotifyClient(config Config, onBlock func()) error {
+// and verbose new-transaction notifications, invoking onBlock with the header
+// of each connected block, onDisconnect with that of each block a reorg
+// removes from the main chain, and onTx for each transaction accepted into
+// mempool. It re-subscribes on every (re)connection so progress keeps flowing
+// across dcrd restarts.
+func InitDcrdNotifyClient(config Config, onBlock, onDisconnect func(*wire.BlockHeader), onTx func(*chainjson.TxRawResult)) error {
 	var certs []byte
 	var err error
 	if config.RPCCert != "" {
@@ -40,11 +46,26 @@ func InitDcrdNotifyClient(config Config, onBlock func()) error {
 				if err := DcrdNotifyClient.NotifyBlocks(context.Background()); err != nil {
 					log.Printf("dcrd notify: NotifyBlocks failed: %v", err)
 				}
+				if onTx != nil {
+					if err := DcrdNotifyClient.NotifyNewTransactions(context.Background(), true); err != nil {
+						log.Printf("dcrd notify: NotifyNewTransactions failed: %v", err)
+					}
+				}
+			}
+		},
+		OnTxAcceptedVerbose: func(tx *chainjson.TxRawResult) {
+			if onTx != nil && tx != nil {
+				onTx(tx)
 			}
 		},
-		OnBlockConnected: func(_ []byte, _ [][]byte) {
+		OnBlockConnected: func(header []byte, _ [][]byte) {
 			if onBlock != nil {
-				onBlock()
+				onBlock(decodeNotifiedHeader(header))
+			}
+		},
+		OnBlockDisconnected: func(header []byte) {
+			if onDisconnect != nil {
+				onDisconnect(decodeNotifiedHeader(header))
 			}
 		},
 	}
@@ -63,6 +84,17 @@ func InitDcrdNotifyClient(config Config, onBlock func()) error {
 	if err != nil {
 		return fmt.Errorf("failed to create dcrd notify client: %v", err)
 	}
-	log.Println("dcrd notification client connected (block-connected push)")
+	log.Println("dcrd notification client connected (block-connected and tx-accepted push)")
 	return nil
 }
+
+// decodeNotifiedHeader decodes a serialized block header from a block
+// notification, returning nil, after logging, when it can't.
+func decodeNotifiedHeader(b []byte) *wire.BlockHeader {
+	var header wire.BlockHeader
+	if err := header.FromBytes(b); err != nil {
+		log.Printf("dcrd notify: decode block header: %v", err)
+		return nil
+	}
+	return &header
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"syscall"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/rpcclient/v8"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Classes of failed dcrd and dcrwallet calls, tested with errors.Is. Errors
+// from the clients are classified as they are returned, keeping their
+// messages.
+var (
+	// ErrNotConnected is a call that never reached the daemon: the client is
+	// not set up, or the daemon is down or unreachable.
+	ErrNotConnected = errors.New("not connected")
+
+	// ErrRPC is a call the daemon answered with an error.
+	ErrRPC = errors.New("rpc error")
+
+	// ErrTimeout is a call that ran past its deadline.
+	ErrTimeout = errors.New("timeout")
+)
+
+// classifiedError is err tagged with the class it belongs to. It reads as
+// err, and errors.Is and errors.As see both.
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string   { return e.err.Error() }
+func (e *classifiedError) Unwrap() []error { return []error{e.class, e.err} }
+
+// NotConnected returns an ErrNotConnected error reading msg, for a client
+// that isn't set up, e.g. NotConnected("dcrd client not available").
+func NotConnected(msg string) error {
+	return &classifiedError{class: ErrNotConnected, err: errors.New(msg)}
+}
+
+// Classify tags err from a dcrd or dcrwallet call with its class. Errors
+// already classified, and cancellations, which say nothing about the daemon,
+// are returned as they are.
+func Classify(err error) error {
+	if err == nil || errors.Is(err, ErrNotConnected) || errors.Is(err, ErrRPC) ||
+		errors.Is(err, ErrTimeout) || errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+		return err
+	}
+	return &classifiedError{class: errorClass(err), err: err}
+}
+
+// errorClass picks err's class. Anything the client didn't fail on itself,
+// by connection or deadline, came back from the daemon.
+func errorClass(err error) error {
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable:
+			return ErrNotConnected
+		case codes.DeadlineExceeded:
+			return ErrTimeout
+		}
+	}
+	var netErr *net.OpError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout
+	case errors.Is(err, rpcclient.ErrClientNotConnected), errors.Is(err, rpcclient.ErrClientDisconnect),
+		errors.Is(err, rpcclient.ErrClientShutdown), errors.Is(err, syscall.ECONNREFUSED),
+		errors.As(err, &netErr):
+		return ErrNotConnected
+	}
+	return ErrRPC
+}
+
+// classified returns v with err classified, for wrapping a client call.
+func classified[T any](v T, err error) (T, error) {
+	return v, Classify(err)
+}
+
+// classifyUnary classifies the errors of the wallet's unary gRPC calls.
+func classifyUnary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return Classify(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// walletClient is the dcrwallet JSON-RPC client with its errors classified.
+type walletClient struct {
+	*rpcclient.Client
+}
+
+func (c *walletClient) RawRequest(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	return classified(c.Client.RawRequest(ctx, method, params))
+}
+
+func (c *walletClient) GetInfo(ctx context.Context) (*chainjson.InfoChainResult, error) {
+	return classified(c.Client.GetInfo(ctx))
+}
+
+func (c *walletClient) Version(ctx context.Context) (map[string]chainjson.VersionResult, error) {
+	return classified(c.Client.Version(ctx))
+}
+
+func (c *walletClient) GetBestBlock(ctx context.Context) (*chainhash.Hash, int64, error) {
+	hash, height, err := c.Client.GetBestBlock(ctx)
+	return hash, height, Classify(err)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/decred/dcrd/rpcclient/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassify(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	tests := []struct {
+		name  string
+		err   error
+		class error
+	}{
+		{"deadline", context.DeadlineExceeded, ErrTimeout},
+		{"wrapped deadline", fmt.Errorf("getblock: %w", context.DeadlineExceeded), ErrTimeout},
+		{"never connected", rpcclient.ErrClientNotConnected, ErrNotConnected},
+		{"refused", fmt.Errorf("post: %w", refused), ErrNotConnected},
+		{"node error", errors.New("-5: Block not found"), ErrRPC},
+		{"grpc unavailable", status.Error(codes.Unavailable, "connection refused"), ErrNotConnected},
+		{"grpc deadline", status.Error(codes.DeadlineExceeded, "deadline"), ErrTimeout},
+		{"grpc error", status.Error(codes.InvalidArgument, "bad passphrase"), ErrRPC},
+		{"not set up", NotConnected("dcrd client not available"), ErrNotConnected},
+	}
+	for _, test := range tests {
+		err := Classify(test.err)
+		if !errors.Is(err, test.class) {
+			t.Errorf("%s: %v is not %v", test.name, err, test.class)
+		}
+		if err.Error() != test.err.Error() {
+			t.Errorf("%s: message %q, want %q", test.name, err, test.err)
+		}
+	}
+
+	// Classified gRPC errors keep their status code.
+	err := Classify(status.Error(codes.InvalidArgument, "bad passphrase"))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	// Cancellations are left alone.
+	for _, err := range []error{nil, context.Canceled, status.Error(codes.Canceled, "canceled")} {
+		if got := Classify(err); got != err {
+			t.Errorf("Classify(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
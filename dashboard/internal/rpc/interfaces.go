@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// DcrdRPC is the part of the dcrd client dcrpulse calls. Services reach dcrd
+// only through DcrdClient, so tests can set it to a fake serving fixture
+// data. A newly used call is added here, and to LimitedClient.
+type DcrdRPC interface {
+	RawRequest(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error)
+	GetBlockCount(ctx context.Context) (int64, error)
+	GetBlockHash(ctx context.Context, blockHeight int64) (*chainhash.Hash, error)
+	GetBlockChainInfo(ctx context.Context) (*chainjson.GetBlockChainInfoResult, error)
+	GetTreasuryBalance(ctx context.Context, block *chainhash.Hash, verbose bool) (*chainjson.GetTreasuryBalanceResult, error)
+	GetTxOut(ctx context.Context, txHash *chainhash.Hash, index uint32, tree int8, mempool bool) (*chainjson.GetTxOutResult, error)
+	GetBlockHeader(ctx context.Context, hash *chainhash.Hash) (*wire.BlockHeader, error)
+	GetBlockHeaderVerbose(ctx context.Context, hash *chainhash.Hash) (*chainjson.GetBlockHeaderVerboseResult, error)
+	GetTicketPoolValue(ctx context.Context) (dcrutil.Amount, error)
+	GetPeerInfo(ctx context.Context) ([]chainjson.GetPeerInfoResult, error)
+	GetCoinSupply(ctx context.Context) (dcrutil.Amount, error)
+	Version(ctx context.Context) (map[string]chainjson.VersionResult, error)
+	LiveTickets(ctx context.Context) ([]*chainhash.Hash, error)
+	GetRawTransactionVerbose(ctx context.Context, txHash *chainhash.Hash) (*chainjson.TxRawResult, error)
+	GetDifficulty(ctx context.Context) (float64, error)
+	GetConnectionCount(ctx context.Context) (int64, error)
+	GetBlockVerbose(ctx context.Context, blockHash *chainhash.Hash, verboseTx bool) (*chainjson.GetBlockVerboseResult, error)
+	GetBestBlockHash(ctx context.Context) (*chainhash.Hash, error)
+	GetBestBlock(ctx context.Context) (*chainhash.Hash, int64, error)
+	Shutdown()
+}
+
+// WalletRPC is the part of the dcrwallet JSON-RPC client dcrpulse calls;
+// most wallet calls go through RawRequest.
+type WalletRPC interface {
+	RawRequest(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error)
+	GetInfo(ctx context.Context) (*chainjson.InfoChainResult, error)
+	Version(ctx context.Context) (map[string]chainjson.VersionResult, error)
+	GetBestBlock(ctx context.Context) (*chainhash.Hash, int64, error)
+	Shutdown()
+}
+
+var (
+	_ DcrdRPC   = (*LimitedClient)(nil)
+	_ WalletRPC = (*walletClient)(nil)
+)
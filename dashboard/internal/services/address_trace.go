@@ -0,0 +1,413 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// Address trace bounds. dcrd has no spend index, so each spent output's
+// spender is found by walking blocks forward from the output; the limits keep
+// one request from scanning the chain.
+const (
+	traceDefaultDepth   = 3
+	traceMaxDepth       = 6
+	traceMaxNodes       = 100
+	traceSpendScanLimit = 1024 // blocks walked past an output looking for its spender
+	traceBlockBudget    = 2048 // blocks fetched per trace
+)
+
+// Trace output statuses.
+const (
+	TraceUnspent     = "unspent"
+	TraceSpent       = "spent"
+	TraceUnresolved  = "unresolved"
+	TraceUntraced    = "untraced"
+	TraceUnspendable = "unspendable"
+)
+
+var (
+	// ErrInvalidOutpoint is returned for a utxo that is not "txid" or
+	// "txid:vout".
+	ErrInvalidOutpoint = errors.New("invalid utxo; expected txid or txid:vout")
+	// ErrTraceNoOutputs is returned when the root transaction has no
+	// output paying to the traced address.
+	ErrTraceNoOutputs = errors.New("transaction has no output paying to the address")
+)
+
+type traceOutpoint struct {
+	txid string
+	vout uint32
+}
+
+type traceSpend struct {
+	txid      string
+	blockHash string // "" for a mempool spend
+	height    int64
+}
+
+// traceTx is the part of a verbose transaction the spend index needs.
+type traceTx struct {
+	Txid string `json:"txid"`
+	Vin  []struct {
+		Txid string `json:"txid"`
+		Vout uint32 `json:"vout"`
+	} `json:"vin"`
+}
+
+// addressTracer holds one trace's spend index: every input of each block
+// (and, when needed, the mempool) it has read, keyed by the outpoint spent.
+type addressTracer struct {
+	tip            int64
+	spends         map[traceOutpoint]traceSpend
+	scanned        map[int64]bool
+	mempoolIndexed bool
+	trace          *types.AddressTrace
+}
+
+// TraceAddressSpends follows the outputs paying address in utxo ("txid" for
+// all of them, or "txid:vout" for one) through the transactions that spent
+// them, up to depth hops. Hitting a depth, node, block-scan or time limit
+// returns the partial graph marked truncated.
+func TraceAddressSpends(ctx context.Context, address, utxo string, depth int) (*types.AddressTrace, error) {
+	if depth <= 0 {
+		depth = traceDefaultDepth
+	}
+	if depth > traceMaxDepth {
+		depth = traceMaxDepth
+	}
+
+	txid, vout, hasVout, err := parseTraceOutpoint(utxo)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := getTransaction(ctx, txid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+
+	t := &addressTracer{
+		tip:     tip,
+		spends:  make(map[traceOutpoint]traceSpend),
+		scanned: make(map[int64]bool),
+		trace: &types.AddressTrace{
+			Address: address,
+			Root:    txid,
+			Depth:   depth,
+			Nodes:   []types.TraceNode{},
+			Edges:   []types.TraceEdge{},
+		},
+	}
+
+	type pending struct{ node, out int }
+	var queue []pending
+	root := traceNodeFromTx(tx, 0, int64(mapFloat(tx, "blockheight")))
+	for i, out := range root.Outputs {
+		if out.Status == TraceUnspendable || (hasVout && out.Vout != vout) {
+			continue
+		}
+		for _, a := range out.Addresses {
+			if a == address {
+				queue = append(queue, pending{0, i})
+				break
+			}
+		}
+	}
+	if len(queue) == 0 {
+		return nil, ErrTraceNoOutputs
+	}
+	t.trace.Nodes = append(t.trace.Nodes, root)
+	trees := map[string]int8{txid: traceTxTree(tx)}
+	nodeIndex := map[string]int{txid: 0}
+
+	for len(queue) > 0 {
+		if ctx.Err() != nil {
+			t.truncate("timed out")
+			break
+		}
+		p := queue[0]
+		queue = queue[1:]
+		node := t.trace.Nodes[p.node]
+		out := node.Outputs[p.out]
+
+		spend, status := t.findSpender(ctx, node.TxID, out.Vout, trees[node.TxID], node.BlockHeight)
+		t.trace.Nodes[p.node].Outputs[p.out].Status = status
+		if status != TraceSpent {
+			continue
+		}
+		t.trace.Nodes[p.node].Outputs[p.out].SpentBy = spend.txid
+		t.trace.Edges = append(t.trace.Edges, types.TraceEdge{
+			From:   node.TxID,
+			Vout:   out.Vout,
+			To:     spend.txid,
+			Amount: out.Amount,
+		})
+		if _, ok := nodeIndex[spend.txid]; ok {
+			continue
+		}
+		if len(t.trace.Nodes) >= traceMaxNodes {
+			t.truncate(fmt.Sprintf("node limit of %d reached", traceMaxNodes))
+			continue
+		}
+
+		raw, err := fetchRawTransactionInBlock(ctx, spend.txid, spend.blockHash)
+		if err != nil {
+			t.truncate(fmt.Sprintf("failed to get spending transaction %s: %v", spend.txid, err))
+			continue
+		}
+		var stx map[string]interface{}
+		if err := json.Unmarshal(raw, &stx); err != nil {
+			t.truncate(fmt.Sprintf("failed to decode spending transaction %s: %v", spend.txid, err))
+			continue
+		}
+		child := traceNodeFromTx(stx, node.Depth+1, spend.height)
+		nodeIndex[spend.txid] = len(t.trace.Nodes)
+		trees[spend.txid] = traceTxTree(stx)
+		t.trace.Nodes = append(t.trace.Nodes, child)
+		if child.Depth >= depth {
+			continue
+		}
+		for i, o := range child.Outputs {
+			if o.Status != TraceUnspendable {
+				queue = append(queue, pending{nodeIndex[spend.txid], i})
+			}
+		}
+	}
+
+	if !t.trace.Truncated {
+		for _, n := range t.trace.Nodes {
+			if n.Depth < depth {
+				continue
+			}
+			for _, o := range n.Outputs {
+				if o.Status == TraceUntraced {
+					t.truncate(fmt.Sprintf("depth limit of %d reached", depth))
+					break
+				}
+			}
+		}
+	}
+	t.trace.BlocksScanned = len(t.scanned)
+	return t.trace, nil
+}
+
+// truncate marks the trace partial, keeping the first reason.
+func (t *addressTracer) truncate(reason string) {
+	if t.trace.Truncated {
+		return
+	}
+	t.trace.Truncated = true
+	t.trace.TruncatedReason = reason
+}
+
+// findSpender resolves which transaction spent txid:vout. An output still in
+// the UTXO set is unspent; one spent in mempool is looked up there; otherwise
+// blocks are walked forward from the output's block.
+func (t *addressTracer) findSpender(ctx context.Context, txid string, vout uint32, tree int8, height int64) (traceSpend, string) {
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return traceSpend{}, TraceUnresolved
+	}
+	utxo, err := rpc.DcrdClient.GetTxOut(ctx, hash, vout, tree, true)
+	if err != nil {
+		return traceSpend{}, TraceUnresolved
+	}
+	if utxo != nil {
+		return traceSpend{}, TraceUnspent
+	}
+
+	key := traceOutpoint{txid, vout}
+	if s, ok := t.spends[key]; ok {
+		return s, TraceSpent
+	}
+
+	// Unspent when mempool is ignored means a mempool transaction spends it.
+	// A mempool transaction's outputs can only be spent in mempool too.
+	spentInMempool := height <= 0
+	if !spentInMempool {
+		confirmed, err := rpc.DcrdClient.GetTxOut(ctx, hash, vout, tree, false)
+		spentInMempool = err == nil && confirmed != nil
+	}
+	if spentInMempool {
+		t.indexMempool(ctx)
+		if s, ok := t.spends[key]; ok {
+			return s, TraceSpent
+		}
+		return traceSpend{}, TraceUnresolved
+	}
+
+	end := height + traceSpendScanLimit
+	if end > t.tip {
+		end = t.tip
+	}
+	for h := height; h <= end; h++ {
+		if ctx.Err() != nil {
+			break
+		}
+		if !t.indexBlock(ctx, h) {
+			t.truncate(fmt.Sprintf("block scan budget of %d reached", traceBlockBudget))
+			break
+		}
+		if s, ok := t.spends[key]; ok {
+			return s, TraceSpent
+		}
+	}
+	if end < t.tip && ctx.Err() == nil {
+		t.truncate(fmt.Sprintf("spender not found within %d blocks", traceSpendScanLimit))
+	}
+	return traceSpend{}, TraceUnresolved
+}
+
+// indexBlock adds every input of the block at height to the spend index. It
+// returns false once the block budget is spent.
+func (t *addressTracer) indexBlock(ctx context.Context, height int64) bool {
+	if t.scanned[height] {
+		return true
+	}
+	if len(t.scanned) >= traceBlockBudget {
+		return false
+	}
+	t.scanned[height] = true
+
+	blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return true
+	}
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(blockHash.String()),
+		json.RawMessage("true"),
+		json.RawMessage("true"),
+	})
+	if err != nil {
+		return true
+	}
+	var block struct {
+		Hash   string    `json:"hash"`
+		RawTx  []traceTx `json:"rawtx"`
+		RawSTx []traceTx `json:"rawstx"`
+	}
+	if err := json.Unmarshal(res, &block); err != nil {
+		return true
+	}
+	for _, tx := range append(block.RawTx, block.RawSTx...) {
+		t.indexTx(tx, traceSpend{txid: tx.Txid, blockHash: block.Hash, height: height})
+	}
+	return true
+}
+
+// indexMempool adds every mempool transaction's inputs to the spend index.
+// Mempool lookups work without --txindex.
+func (t *addressTracer) indexMempool(ctx context.Context) {
+	if t.mempoolIndexed {
+		return
+	}
+	t.mempoolIndexed = true
+
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getrawmempool", []json.RawMessage{})
+	if err != nil {
+		return
+	}
+	var hashes []string
+	if err := json.Unmarshal(res, &hashes); err != nil {
+		return
+	}
+	for _, h := range hashes {
+		raw, err := fetchRawTransaction(ctx, h)
+		if err != nil {
+			continue
+		}
+		var tx traceTx
+		if json.Unmarshal(raw, &tx) == nil {
+			t.indexTx(tx, traceSpend{txid: tx.Txid})
+		}
+	}
+}
+
+func (t *addressTracer) indexTx(tx traceTx, spend traceSpend) {
+	for _, in := range tx.Vin {
+		if in.Txid != "" {
+			t.spends[traceOutpoint{in.Txid, in.Vout}] = spend
+		}
+	}
+}
+
+// traceNodeFromTx lists a verbose transaction's outputs, all untraced except
+// the ones that can never be spent.
+func traceNodeFromTx(tx map[string]interface{}, depth int, height int64) types.TraceNode {
+	txid, _ := tx["txid"].(string)
+	node := types.TraceNode{
+		TxID:        txid,
+		Depth:       depth,
+		BlockHeight: height,
+		Outputs:     []types.TraceOutput{},
+	}
+	vout, _ := tx["vout"].([]interface{})
+	for i, v := range vout {
+		m, _ := v.(map[string]interface{})
+		spk, _ := m["scriptPubKey"].(map[string]interface{})
+		out := types.TraceOutput{
+			Vout:   uint32(i),
+			Amount: mapFloat(m, "value"),
+			Status: TraceUntraced,
+		}
+		if n, ok := m["n"].(float64); ok {
+			out.Vout = uint32(n)
+		}
+		if addrs, ok := spk["addresses"].([]interface{}); ok {
+			for _, a := range addrs {
+				if s, ok := a.(string); ok {
+					out.Addresses = append(out.Addresses, s)
+				}
+			}
+		}
+		switch spkType, _ := spk["type"].(string); spkType {
+		case "nulldata", "sstxcommitment":
+			out.Status = TraceUnspendable
+		}
+		node.Outputs = append(node.Outputs, out)
+	}
+	return node
+}
+
+// traceTxTree returns the tree (regular 0, stake 1) a transaction's outputs
+// live in, which gettxout needs to find them.
+func traceTxTree(tx map[string]interface{}) int8 {
+	switch classifyTransaction(tx).Kind {
+	case TxKindRegular, TxKindCoinbase:
+		return 0
+	}
+	return 1
+}
+
+// parseTraceOutpoint splits "txid" or "txid:vout".
+func parseTraceOutpoint(s string) (txid string, vout uint32, hasVout bool, err error) {
+	txid = s
+	if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		n, perr := strconv.ParseUint(s[i+1:], 10, 32)
+		if perr != nil {
+			return "", 0, false, ErrInvalidOutpoint
+		}
+		txid, vout, hasVout = s[:i], uint32(n), true
+	}
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return "", 0, false, ErrInvalidOutpoint
+	}
+	return hash.String(), vout, hasVout, nil
+}
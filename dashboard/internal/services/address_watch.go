@@ -0,0 +1,299 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+const (
+	// addressWatchQueueSize bounds the mempool transactions waiting to be
+	// matched against watched addresses; beyond it they are dropped.
+	addressWatchQueueSize = 256
+
+	// addressWatchMaxBlocks caps how many blocks one block-connected trigger
+	// catches up on, so a watcher opened during IBD doesn't replay the chain.
+	addressWatchMaxBlocks = 6
+)
+
+// addressWatch is every open stream watching one address.
+type addressWatch struct {
+	subs []chan types.AddressEvent
+}
+
+var (
+	addressWatchMu   sync.Mutex
+	addressWatches   = map[string]*addressWatch{}
+	addressTxQueue   = make(chan *chainjson.TxRawResult, addressWatchQueueSize)
+	addressBlockCh   = make(chan struct{}, 1)
+	addressWatchOnce sync.Once
+
+	// addressWatchHeight is the last block matched against watched addresses,
+	// reset when the last watcher leaves.
+	addressWatchHeight int64
+)
+
+// ValidateNetworkAddress asks dcrd whether address is valid for the network it
+// is running on.
+func ValidateNetworkAddress(ctx context.Context, address string) (bool, error) {
+	if rpc.DcrdClient == nil {
+		return false, rpc.NotConnected("dcrd client not available")
+	}
+	res, err := rpc.DcrdClient.RawRequest(ctx, "validateaddress", []json.RawMessage{
+		jsonStr(address),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to validate address: %w", err)
+	}
+	var v struct {
+		IsValid bool `json:"isvalid"`
+	}
+	if err := json.Unmarshal(res, &v); err != nil {
+		return false, fmt.Errorf("failed to parse validate response: %w", err)
+	}
+	return v.IsValid, nil
+}
+
+// SubscribeAddressEvents returns a channel receiving every mempool or mined
+// transaction that pays to or spends from address, and a cleanup func. All
+// watchers of an address share one registry entry, which is dropped when the
+// last of them unsubscribes.
+func SubscribeAddressEvents(address string) (<-chan types.AddressEvent, func()) {
+	addressWatchOnce.Do(func() { go runAddressWatcher() })
+
+	ch := make(chan types.AddressEvent, 32)
+	addressWatchMu.Lock()
+	w := addressWatches[address]
+	if w == nil {
+		w = &addressWatch{}
+		addressWatches[address] = w
+	}
+	w.subs = append(w.subs, ch)
+	addressWatchMu.Unlock()
+
+	return ch, func() {
+		addressWatchMu.Lock()
+		defer addressWatchMu.Unlock()
+		w := addressWatches[address]
+		if w == nil {
+			return
+		}
+		for i, sub := range w.subs {
+			if sub == ch {
+				w.subs = append(w.subs[:i], w.subs[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+		if len(w.subs) == 0 {
+			delete(addressWatches, address)
+		}
+		if len(addressWatches) == 0 {
+			addressWatchHeight = 0
+		}
+	}
+}
+
+// TriggerAddressWatchBlock asks the watcher to match newly connected blocks
+// (non-blocking, coalesced). Called from the dcrd block-connected handler.
+func TriggerAddressWatchBlock() {
+	if !addressWatchesActive() {
+		return
+	}
+	select {
+	case addressBlockCh <- struct{}{}:
+	default:
+	}
+}
+
+// queueAddressWatchTx hands a mempool transaction to the watcher. Matching
+// needs prevout lookups, so it is kept off dcrd's notification goroutine.
+func queueAddressWatchTx(tx *chainjson.TxRawResult) {
+	if !addressWatchesActive() {
+		return
+	}
+	select {
+	case addressTxQueue <- tx:
+	default:
+		log.Printf("Address watch: queue full, dropping mempool tx %s", tx.Txid)
+	}
+}
+
+func addressWatchesActive() bool {
+	addressWatchMu.Lock()
+	defer addressWatchMu.Unlock()
+	return len(addressWatches) > 0
+}
+
+func watchedAddresses() map[string]bool {
+	addressWatchMu.Lock()
+	defer addressWatchMu.Unlock()
+	out := make(map[string]bool, len(addressWatches))
+	for addr := range addressWatches {
+		out[addr] = true
+	}
+	return out
+}
+
+func publishAddressEvent(ev types.AddressEvent) {
+	addressWatchMu.Lock()
+	defer addressWatchMu.Unlock()
+	w := addressWatches[ev.Address]
+	if w == nil {
+		return
+	}
+	for _, sub := range w.subs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
+
+func runAddressWatcher() {
+	for {
+		select {
+		case tx := <-addressTxQueue:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			for _, ev := range matchAddressTx(ctx, tx, watchedAddresses()) {
+				ev.Status = "mempool"
+				publishAddressEvent(ev)
+			}
+			cancel()
+		case <-addressBlockCh:
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			if err := matchNewBlocks(ctx); err != nil {
+				log.Printf("Address watch: %v", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// matchNewBlocks matches every block since the last one seen, up to the tip,
+// and publishes confirmed events for watched addresses. With no previous block
+// (first watcher) it starts from the last addressWatchMaxBlocks blocks, so a
+// fresh stream also reports the address's recent confirmations.
+func matchNewBlocks(ctx context.Context) error {
+	if rpc.DcrdClient == nil {
+		return rpc.NotConnected("dcrd client not available")
+	}
+	_, tip, err := rpc.DcrdClient.GetBestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get best block: %w", err)
+	}
+	addressWatchMu.Lock()
+	start := addressWatchHeight + 1
+	addressWatchMu.Unlock()
+	if start == 1 || tip-start >= addressWatchMaxBlocks {
+		start = tip - addressWatchMaxBlocks + 1
+	}
+	// On a chain shorter than addressWatchMaxBlocks (a fresh simnet or
+	// regnet) that would go below height 1; the genesis block pays no one.
+	if start < 1 {
+		start = 1
+	}
+	for h := start; h <= tip; h++ {
+		hash, err := rpc.DcrdClient.GetBlockHash(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to get block hash at %d: %w", h, err)
+		}
+		block, err := rpc.DcrdClient.GetBlockVerbose(ctx, hash, true)
+		if err != nil {
+			return fmt.Errorf("failed to get block %s: %w", hash, err)
+		}
+		watched := watchedAddresses()
+		txs := append(block.RawTx, block.RawSTx...)
+		for i := range txs {
+			for _, ev := range matchAddressTx(ctx, &txs[i], watched) {
+				ev.Status = "confirmed"
+				ev.Confirmations = tip - h + 1
+				ev.BlockHeight = h
+				ev.BlockHash = block.Hash
+				ev.Time = block.Time
+				publishAddressEvent(ev)
+			}
+		}
+		addressWatchMu.Lock()
+		addressWatchHeight = h
+		addressWatchMu.Unlock()
+	}
+	return nil
+}
+
+// matchAddressTx returns one event per watched address tx pays to or spends
+// from. A spend is reported net of any change returned to the same address.
+// Spent amounts rely on getrawtransaction resolving the previous outputs, so
+// spends of confirmed outputs are only seen when dcrd runs with txindex.
+func matchAddressTx(ctx context.Context, tx *chainjson.TxRawResult, watched map[string]bool) []types.AddressEvent {
+	if len(watched) == 0 {
+		return nil
+	}
+	received := map[string]int64{}
+	sent := map[string]int64{}
+	for _, out := range tx.Vout {
+		for _, addr := range out.ScriptPubKey.Addresses {
+			if watched[addr] {
+				received[addr] += int64(math.Round(out.Value * 1e8))
+			}
+		}
+	}
+
+	prevTxs := map[string]*chainjson.TxRawResult{}
+	for _, in := range tx.Vin {
+		if in.Txid == "" || in.IsCoinBase() || in.IsStakeBase() || in.Treasurybase {
+			continue
+		}
+		prev, ok := prevTxs[in.Txid]
+		if !ok {
+			if hash, err := chainhash.NewHashFromStr(in.Txid); err == nil {
+				prev, _ = rpc.DcrdClient.GetRawTransactionVerbose(ctx, hash)
+			}
+			prevTxs[in.Txid] = prev
+		}
+		if prev == nil || int(in.Vout) >= len(prev.Vout) {
+			continue
+		}
+		for _, addr := range prev.Vout[in.Vout].ScriptPubKey.Addresses {
+			if watched[addr] {
+				sent[addr] += int64(math.Round(in.AmountIn * 1e8))
+			}
+		}
+	}
+
+	var events []types.AddressEvent
+	for addr := range watched {
+		in, out := received[addr], sent[addr]
+		if in == 0 && out == 0 {
+			continue
+		}
+		ev := types.AddressEvent{
+			Address: addr,
+			TxID:    tx.Txid,
+			Time:    time.Now().Unix(),
+		}
+		if out > in {
+			ev.Direction = "sent"
+			ev.Amount = float64(out-in) / 1e8
+		} else {
+			ev.Direction = "received"
+			ev.Amount = float64(in-out) / 1e8
+		}
+		events = append(events, ev)
+	}
+	return events
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"dcrpulse/internal/rpc"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+// blockWatchDcrd adds the best-block and verbose-block calls matchNewBlocks
+// makes to the fixture chain.
+type blockWatchDcrd struct {
+	fakeDcrd
+}
+
+func (f *blockWatchDcrd) GetBestBlock(ctx context.Context) (*chainhash.Hash, int64, error) {
+	return fakeBlockHash(f.height), f.height, nil
+}
+
+func (f *blockWatchDcrd) GetBlockVerbose(ctx context.Context, hash *chainhash.Hash, verboseTx bool) (*chainjson.GetBlockVerboseResult, error) {
+	return &chainjson.GetBlockVerboseResult{Hash: hash.String()}, nil
+}
+
+func TestMatchNewBlocksShortChain(t *testing.T) {
+	fake := &blockWatchDcrd{fakeDcrd{height: addressWatchMaxBlocks - 3}}
+	saved := rpc.DcrdClient
+	rpc.DcrdClient = fake
+	t.Cleanup(func() { rpc.DcrdClient = saved })
+
+	addressWatchMu.Lock()
+	savedHeight := addressWatchHeight
+	addressWatchHeight = 0
+	addressWatchMu.Unlock()
+	t.Cleanup(func() {
+		addressWatchMu.Lock()
+		addressWatchHeight = savedHeight
+		addressWatchMu.Unlock()
+	})
+
+	if err := matchNewBlocks(context.Background()); err != nil {
+		t.Fatalf("matchNewBlocks on a %d-block chain: %v", fake.height, err)
+	}
+	addressWatchMu.Lock()
+	defer addressWatchMu.Unlock()
+	if addressWatchHeight != fake.height {
+		t.Errorf("addressWatchHeight = %d, want %d", addressWatchHeight, fake.height)
+	}
+}
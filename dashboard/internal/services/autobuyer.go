@@ -117,9 +117,6 @@ func recordAutobuyerEvent(level, msg string) {
 
 // StartAutobuyer launches the ticket-autobuyer goroutine.
 func StartAutobuyer(settings *types.AutobuyerSettings, passphrase []byte) error {
-	if rpc.TicketBuyerClient == nil || rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC clients unavailable")
-	}
 	if settings == nil {
 		return fmt.Errorf("settings required")
 	}
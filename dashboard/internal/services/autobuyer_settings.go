@@ -104,7 +104,7 @@ func SaveAutobuyerSettings(ctx context.Context, s *types.AutobuyerSettings) erro
 // querying the wallet's gRPC Accounts list.
 func resolveAccountNumber(ctx context.Context, name string) (uint32, error) {
 	if rpc.WalletGrpcClient == nil {
-		return 0, fmt.Errorf("wallet gRPC client unavailable")
+		return 0, rpc.NotConnected("wallet gRPC client unavailable")
 	}
 	resp, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
 	if err != nil {
@@ -121,7 +121,7 @@ func resolveAccountNumber(ctx context.Context, name string) (uint32, error) {
 // resolveAccountName is the reverse lookup.
 func resolveAccountName(ctx context.Context, num uint32) (string, error) {
 	if rpc.WalletGrpcClient == nil {
-		return "", fmt.Errorf("wallet gRPC client unavailable")
+		return "", rpc.NotConnected("wallet gRPC client unavailable")
 	}
 	resp, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
 	if err != nil {
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+const (
+	// DefaultBlockTxLimit and MaxBlockTxLimit bound a block transaction page.
+	DefaultBlockTxLimit = 50
+	MaxBlockTxLimit     = 500
+)
+
+// FetchBlockTransactions returns one page of blockHash's transactions. The
+// whole block is read with a single verbose getblock, so it needs neither
+// per-transaction lookups nor --txindex.
+func FetchBlockTransactions(ctx context.Context, blockHash string, limit, offset int) (*types.BlockTransactions, error) {
+	if len(blockHash) != 64 || !isHex(blockHash) {
+		return nil, ErrInvalidHash
+	}
+	if limit <= 0 {
+		limit = DefaultBlockTxLimit
+	} else if limit > MaxBlockTxLimit {
+		limit = MaxBlockTxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(blockHash),
+		json.RawMessage("true"), // verbose
+		json.RawMessage("true"), // verbosetx
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("%s: %w", blockHash, ErrBlockNotFound)
+		}
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	var block struct {
+		Hash   string                   `json:"hash"`
+		Height int64                    `json:"height"`
+		RawTx  []map[string]interface{} `json:"rawtx"`
+		RawSTx []map[string]interface{} `json:"rawstx"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+
+	page := &types.BlockTransactions{
+		BlockHash:    block.Hash,
+		BlockHeight:  block.Height,
+		Transactions: []types.BlockTransaction{},
+		Offset:       offset,
+		Limit:        limit,
+		Total:        len(block.RawTx) + len(block.RawSTx),
+		RegularCount: len(block.RawTx),
+		StakeCount:   len(block.RawSTx),
+	}
+	for i := offset; i < page.Total && i < offset+limit; i++ {
+		if i < len(block.RawTx) {
+			page.Transactions = append(page.Transactions, blockTransaction(block.RawTx[i], "regular"))
+		} else {
+			page.Transactions = append(page.Transactions, blockTransaction(block.RawSTx[i-len(block.RawTx)], "stake"))
+		}
+	}
+	return page, nil
+}
+
+// blockTransaction summarizes a verbose transaction from getblock. Totals are
+// summed in atoms; coinbase and treasurybase inputs mint coins rather than
+// spend them, so those report no fee.
+func blockTransaction(tx map[string]interface{}, tree string) types.BlockTransaction {
+	c := classifyTransaction(tx)
+	txid, _ := tx["txid"].(string)
+	entry := types.BlockTransaction{
+		TxID:  txid,
+		Tree:  tree,
+		Kind:  c.Kind,
+		Label: c.Label,
+	}
+
+	var in, out int64
+	vin, _ := tx["vin"].([]interface{})
+	for _, v := range vin {
+		input, _ := v.(map[string]interface{})
+		amount, _ := input["amountin"].(float64)
+		if atoms, err := dcrutil.NewAmount(amount); err == nil {
+			in += int64(atoms)
+		}
+	}
+	vout, _ := tx["vout"].([]interface{})
+	for _, v := range vout {
+		output, _ := v.(map[string]interface{})
+		out += voutAtoms(output)
+	}
+	entry.TotalIn = atomsToCoin(in)
+	entry.TotalOut = atomsToCoin(out)
+	if c.Kind != TxKindCoinbase && c.Kind != TxKindTreasuryBase && in > out {
+		entry.Fee = atomsToCoin(in - out)
+	}
+
+	if hexStr, ok := tx["hex"].(string); ok {
+		entry.Size = len(hexStr) / 2
+	}
+	return entry
+}
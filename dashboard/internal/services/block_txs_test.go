@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import "testing"
+
+func TestBlockTransactionTotals(t *testing.T) {
+	output := func(value float64) interface{} {
+		return map[string]interface{}{
+			"value":        value,
+			"scriptPubKey": map[string]interface{}{"type": "pubkeyhash"},
+		}
+	}
+	regular := map[string]interface{}{
+		"txid": "aa",
+		"hex":  "00112233",
+		"vin": []interface{}{
+			map[string]interface{}{"txid": "bb", "amountin": 1.1},
+			map[string]interface{}{"txid": "cc", "amountin": 0.2},
+		},
+		"vout": []interface{}{output(1.0), output(0.2999)},
+	}
+	got := blockTransaction(regular, "regular")
+	if got.Kind != TxKindRegular || got.Size != 4 {
+		t.Errorf("got kind %q size %d, want %q 4", got.Kind, got.Size, TxKindRegular)
+	}
+	if got.TotalIn != 1.3 || got.TotalOut != 1.2999 || got.Fee != 0.0001 {
+		t.Errorf("got in %v out %v fee %v, want 1.3 1.2999 0.0001",
+			got.TotalIn, got.TotalOut, got.Fee)
+	}
+
+	coinbase := map[string]interface{}{
+		"txid": "dd",
+		"vin":  []interface{}{map[string]interface{}{"coinbase": "00", "amountin": 5.0}},
+		"vout": []interface{}{output(5.5)},
+	}
+	if got := blockTransaction(coinbase, "regular"); got.Kind != TxKindCoinbase || got.Fee != 0 {
+		t.Errorf("coinbase: got kind %q fee %v, want %q 0", got.Kind, got.Fee, TxKindCoinbase)
+	}
+}
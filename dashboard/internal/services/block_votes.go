@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// FetchBlockVotes returns the votes of the block at height, each with its
+// agenda choices and its choice on every tspend being voted on there, read
+// with the same parsers the vote history and tspend vote counts use.
+func FetchBlockVotes(ctx context.Context, height int64) (*types.BlockVotes, error) {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	if height > tip {
+		return nil, ErrBlockNotFound
+	}
+	blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block hash at %d: %w", height, err)
+	}
+	key := blockHash.String()
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(key),
+		json.RawMessage("true"), // verbose
+		json.RawMessage("true"), // verbosetx
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", key, err)
+	}
+	var block struct {
+		Time   int64                    `json:"time"`
+		RawSTx []map[string]interface{} `json:"rawstx"`
+	}
+	if err := json.Unmarshal(res, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+
+	// Mempool tspends are only a debugging aid here; without them the
+	// mined ones are still listed.
+	mempool, err := GetMempoolTSpends(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get mempool tspends: %v", err)
+	}
+	active := activeTSpendsAt(height, tspendVoteWindow(ctx), GetScanResults(), mempool)
+	hashes := make([]chainhash.Hash, 0, len(active))
+	for _, s := range active {
+		if h, err := chainhash.NewHashFromStr(s); err == nil {
+			hashes = append(hashes, *h)
+		}
+	}
+
+	out := &types.BlockVotes{
+		Height:        height,
+		Hash:          key,
+		Timestamp:     block.Time,
+		ActiveTSpends: active,
+		Votes:         []types.BlockVoteEntry{},
+	}
+	for _, tx := range block.RawSTx {
+		if !isVoteTransaction(tx) {
+			continue
+		}
+		entry := types.BlockVoteEntry{
+			VoteRecord:  parseBlockVote(params, tx, height, key, block.Time).record,
+			TSpendVotes: make([]types.TSpendVoteChoice, 0, len(hashes)),
+		}
+		for _, h := range hashes {
+			entry.TSpendVotes = append(entry.TSpendVotes, types.TSpendVoteChoice{
+				TSpendHash: h.String(),
+				Choice:     parseTSpendVote(tx, h).String(),
+			})
+		}
+		out.Votes = append(out.Votes, entry)
+	}
+	return out, nil
+}
+
+// activeTSpendsAt returns the tspends whose voting window, as the vote
+// counts take it, covers height: the window blocks up to a mined tspend's
+// block, or up to a mempool tspend's expiry. The hashes are sorted.
+func activeTSpendsAt(height, window int64, mined []types.TSpendHistory, mempool []types.TSpend) []string {
+	seen := map[string]bool{}
+	active := []string{}
+	add := func(hash string, end int64) {
+		if height >= end-window && height <= end && !seen[hash] {
+			seen[hash] = true
+			active = append(active, hash)
+		}
+	}
+	for _, t := range mined {
+		add(t.TxHash, t.BlockHeight)
+	}
+	for _, t := range mempool {
+		add(t.TxHash, t.ExpiryHeight)
+	}
+	sort.Strings(active)
+	return active
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// Roles of coinbase and treasurybase outputs in a CoinbaseBreakdown.
+const (
+	RewardRoleWork     = "work_reward"
+	RewardRoleTreasury = "treasury"
+	RewardRoleNullData = "nulldata"
+)
+
+// coinbaseBreakdown labels the outputs of a mined coinbase or treasurybase
+// transaction and sets them against the subsidy schedule at height. Before
+// treasury activation the coinbase's first output carries the treasury
+// subsidy; afterwards the treasurybase does. Miner outputs above the PoW
+// subsidy are the block's fees. The stake reward is not paid by either
+// transaction but by the block's votes, so it is reported for reference only.
+func coinbaseBreakdown(ctx context.Context, tx map[string]interface{}, kind string, height int64, blockHash string) (*types.CoinbaseBreakdown, error) {
+	hres, err := rpc.DcrdClient.RawRequest(ctx, "getblockheader", []json.RawMessage{
+		jsonStr(blockHash),
+		json.RawMessage("true"), // verbose
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %w", err)
+	}
+	var header struct {
+		Voters int `json:"voters"`
+	}
+	if err := json.Unmarshal(hres, &header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block header: %w", err)
+	}
+
+	sres, err := rpc.DcrdClient.RawRequest(ctx, "getblocksubsidy", []json.RawMessage{
+		json.RawMessage(fmt.Sprintf("%d", height)),
+		json.RawMessage(fmt.Sprintf("%d", header.Voters)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block subsidy: %w", err)
+	}
+	var subsidy struct {
+		Developer int64 `json:"developer"`
+		PoS       int64 `json:"pos"`
+		PoW       int64 `json:"pow"`
+		Total     int64 `json:"total"`
+	}
+	if err := json.Unmarshal(sres, &subsidy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block subsidy: %w", err)
+	}
+
+	// A treasurybase only exists once the treasury is active; for a coinbase
+	// an unresolved activation height is taken as active, as it is on every
+	// current network.
+	treasuryActive := true
+	if kind == TxKindCoinbase {
+		if tp, err := CurrentTreasuryParams(ctx); err == nil {
+			treasuryActive = treasuryActiveAt(height, tp.ActivationHeight)
+		}
+	}
+
+	breakdown := &types.CoinbaseBreakdown{
+		Height:          height,
+		Voters:          header.Voters,
+		Outputs:         []types.CoinbaseOutput{},
+		TreasuryActive:  treasuryActive,
+		TreasurySubsidy: atomsToCoin(subsidy.Developer),
+		StakeReward:     atomsToCoin(subsidy.PoS),
+		TotalSubsidy:    atomsToCoin(subsidy.Total),
+	}
+
+	var workAtoms int64
+	vout, _ := tx["vout"].([]interface{})
+	for i, v := range vout {
+		voutMap, _ := v.(map[string]interface{})
+		scriptType := outputScriptType(tx, i)
+		atoms := voutAtoms(voutMap)
+
+		out := types.CoinbaseOutput{
+			Index: uint32(i),
+			Value: atomsToCoin(atoms),
+		}
+		switch {
+		case scriptType == "nulldata":
+			out.Role = RewardRoleNullData
+		case kind == TxKindTreasuryBase,
+			kind == TxKindCoinbase && !treasuryActive && i == 0:
+			out.Role = RewardRoleTreasury
+		default:
+			out.Role = RewardRoleWork
+			workAtoms += atoms
+		}
+		if scriptPubKey, ok := voutMap["scriptPubKey"].(map[string]interface{}); ok {
+			if addresses, ok := scriptPubKey["addresses"].([]interface{}); ok && len(addresses) > 0 {
+				out.Address, _ = addresses[0].(string)
+			}
+		}
+		breakdown.Outputs = append(breakdown.Outputs, out)
+	}
+
+	if kind == TxKindCoinbase {
+		breakdown.WorkSubsidy = atomsToCoin(subsidy.PoW)
+		if fees := workAtoms - subsidy.PoW; fees > 0 {
+			breakdown.Fees = atomsToCoin(fees)
+		}
+	}
+	return breakdown, nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// ErrNoConnectConfig is returned when a connect request configures no client.
+var ErrNoConnectConfig = errors.New("no dcrd or wallet configuration provided")
+
+// Hooks main installs to start the background work it runs at startup for
+// clients configured by environment, so clients first brought up by
+// ConnectRPC get the same. They must be safe to call more than once.
+var (
+	onDcrdConnected       func()
+	onWalletGrpcConnected func()
+)
+
+// SetConnectHooks installs the functions ConnectRPC runs after dcrd and the
+// wallet gRPC client connect.
+func SetConnectHooks(dcrd, walletGrpc func()) {
+	onDcrdConnected = dcrd
+	onWalletGrpcConnected = walletGrpc
+}
+
+// ConnectRPC (re)initializes each client req configures and reports every
+// outcome separately: one client failing doesn't stop the others.
+func ConnectRPC(ctx context.Context, req types.ConnectRequest) (*types.ConnectResponse, error) {
+	if req.Dcrd == nil && req.Wallet == nil {
+		return nil, ErrNoConnectConfig
+	}
+
+	resp := &types.ConnectResponse{Success: true}
+	if req.Dcrd != nil {
+		resp.Dcrd = connectDcrd(*req.Dcrd)
+		if resp.Dcrd.Connected {
+			if network, err := CurrentNetwork(ctx); err == nil {
+				resp.Network = network
+			}
+		}
+	}
+	if req.Wallet != nil {
+		resp.WalletRPC = connectWalletRPC(ctx, req.Wallet.RPCEndpoint)
+		if req.Wallet.Cert != "" && req.Wallet.GrpcPort != "" {
+			resp.WalletGrpc = connectWalletGrpc(ctx, *req.Wallet)
+		}
+	}
+	for _, r := range []types.ClientConnectResult{resp.Dcrd, resp.WalletRPC, resp.WalletGrpc} {
+		if r.Attempted && !r.Connected {
+			resp.Success = false
+		}
+	}
+	resp.NetworkMismatch = CheckNetworkMatch(ctx).Mismatch
+	return resp, nil
+}
+
+// connectResult turns a client's init error into its result.
+func connectResult(err error) types.ClientConnectResult {
+	if err != nil {
+		return types.ClientConnectResult{Attempted: true, Error: err.Error()}
+	}
+	return types.ClientConnectResult{Attempted: true, Connected: true}
+}
+
+func rpcConfig(e types.RPCEndpoint) rpc.Config {
+	return rpc.Config{
+		RPCHost:     e.Host,
+		RPCPort:     e.Port,
+		RPCUser:     e.User,
+		RPCPassword: e.Password,
+		RPCCert:     e.Cert,
+	}
+}
+
+// connectDcrd replaces the dcrd client; InitDcrdClient already proves the
+// connection with getblockcount. The network and txindex state are re-detected
+// since the new node may be on a different chain or run with other options.
+func connectDcrd(e types.RPCEndpoint) types.ClientConnectResult {
+	if e.User == "" || e.Password == "" {
+		return connectResult(fmt.Errorf("dcrd RPC user and password are required"))
+	}
+	err := rpc.InitDcrdClient(rpcConfig(e))
+	forgetNetwork()
+	forgetTxIndex()
+	if err == nil && onDcrdConnected != nil {
+		onDcrdConnected()
+	}
+	return connectResult(err)
+}
+
+// connectWalletRPC replaces the dcrwallet JSON-RPC client. InitWalletClient
+// tolerates a failing getinfo (locked wallet), so reachability is checked
+// with version, which dcrwallet answers without a wallet loaded.
+func connectWalletRPC(ctx context.Context, e types.RPCEndpoint) types.ClientConnectResult {
+	if e.User == "" || e.Password == "" {
+		return connectResult(fmt.Errorf("wallet RPC user and password are required"))
+	}
+	if err := rpc.InitWalletClient(rpcConfig(e)); err != nil {
+		return connectResult(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := rpc.WalletClient.Version(ctx); err != nil {
+		return connectResult(fmt.Errorf("failed to connect to dcrwallet: %v", err))
+	}
+	return connectResult(nil)
+}
+
+// connectWalletGrpc re-dials dcrwallet's gRPC server with the RpcSync
+// supervisor parked, as a wallet switch does, and checks it answers a loader
+// call since the dial itself doesn't block.
+func connectWalletGrpc(ctx context.Context, e types.WalletEndpoint) types.ClientConnectResult {
+	PauseSync()
+	defer func() {
+		ResumeSync()
+		KickSync()
+	}()
+
+	rpc.CloseGrpcConnection()
+	err := rpc.InitWalletGrpcClient(rpc.GrpcConfig{
+		GrpcHost: e.Host,
+		GrpcPort: e.GrpcPort,
+		GrpcCert: e.Cert,
+	})
+	if err != nil {
+		return connectResult(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := rpc.WalletLoaderClient.WalletExists(ctx, &pb.WalletExistsRequest{}); err != nil {
+		return connectResult(fmt.Errorf("failed to reach dcrwallet gRPC: %v", err))
+	}
+	if onWalletGrpcConnected != nil {
+		onWalletGrpcConnected()
+	}
+	return connectResult(nil)
+}
@@ -7,6 +7,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -40,6 +41,10 @@ func FetchRecentBlocks(ctx context.Context, count int) ([]types.BlockSummary, er
 
 	for h := height; h >= startHeight; h-- {
 		block, err := FetchBlockSummaryByHeight(ctx, h)
+		if ctx.Err() != nil {
+			// Out of time: fail rather than return a page with holes.
+			return nil, rpc.Classify(ctx.Err())
+		}
 		if err != nil {
 			log.Printf("Warning: Failed to fetch block %d: %v", h, err)
 			continue
@@ -93,6 +98,10 @@ func FetchRecentBlocksPaginated(ctx context.Context, page int, pageSize int) (*t
 	blocks := make([]types.BlockSummary, 0, pageSize)
 	for h := startHeight; h >= endHeight; h-- {
 		block, err := FetchBlockSummaryByHeight(ctx, h)
+		if ctx.Err() != nil {
+			// Out of time: fail rather than return a page with holes.
+			return nil, rpc.Classify(ctx.Err())
+		}
 		if err != nil {
 			log.Printf("Warning: Failed to fetch block %d: %v", h, err)
 			continue
@@ -216,15 +225,31 @@ func FetchBlockByHash(ctx context.Context, hash string) (*types.BlockDetail, err
 		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
 	}
 
-	// Fetch full transaction details for each transaction ID
+	// Fetch full transaction details for each transaction ID. When dcrd runs
+	// without --txindex the lookups fail, so the block is re-read once with
+	// its transactions inline and the rest are served from that.
+	var blockTxs map[string]json.RawMessage
+	getTx := func(txID string) (json.RawMessage, error) {
+		if blockTxs == nil {
+			res, err := fetchRawTransaction(ctx, txID)
+			if !errors.Is(err, ErrTxUnavailable) {
+				return res, err
+			}
+			if blockTxs, err = fetchBlockRawTxs(ctx, rawBlock.Hash); err != nil {
+				return nil, err
+			}
+			setTxIndexMissing(true)
+		}
+		if res, ok := blockTxs[txID]; ok {
+			return res, nil
+		}
+		return nil, fmt.Errorf("%s: %w", txID, ErrTxUnavailable)
+	}
 	transactions := make([]types.TransactionSummary, 0, len(rawBlock.Tx)+len(rawBlock.STx))
 
 	// Process regular transactions
 	for _, txID := range rawBlock.Tx {
-		txResult, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
-			json.RawMessage(fmt.Sprintf(`"%s"`, txID)),
-			json.RawMessage(`1`), // verbose = 1 for decoded JSON
-		})
+		txResult, err := getTx(txID)
 		if err != nil {
 			log.Printf("Warning: failed to fetch transaction %s: %v", txID, err)
 			continue
@@ -244,10 +269,7 @@ func FetchBlockByHash(ctx context.Context, hash string) (*types.BlockDetail, err
 
 	// Process stake transactions
 	for _, txID := range rawBlock.STx {
-		txResult, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
-			json.RawMessage(fmt.Sprintf(`"%s"`, txID)),
-			json.RawMessage(`1`), // verbose = 1 for decoded JSON
-		})
+		txResult, err := getTx(txID)
 		if err != nil {
 			log.Printf("Warning: failed to fetch stake transaction %s: %v", txID, err)
 			continue
@@ -290,10 +312,7 @@ func FetchBlockByHash(ctx context.Context, hash string) (*types.BlockDetail, err
 // FetchTransaction gets detailed transaction info
 func FetchTransaction(ctx context.Context, txHash string) (*types.TransactionDetail, error) {
 	// Get raw transaction
-	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
-		jsonStr(txHash),
-		json.RawMessage(`1`), // verbose
-	})
+	result, err := fetchRawTransaction(ctx, txHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
@@ -343,6 +362,14 @@ func FetchTransaction(ctx context.Context, txHash string) (*types.TransactionDet
 		return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
 	}
 
+	// The map-based stake/treasury classifiers share code with the treasury
+	// scanner, so decode a second, untyped view for them.
+	var txMap map[string]interface{}
+	var classification *types.TxClassification
+	if err := json.Unmarshal(result, &txMap); err == nil {
+		classification = classifyTransaction(txMap)
+	}
+
 	// Convert inputs
 	inputs := make([]types.TxInput, 0, len(rawTx.Vin))
 	for _, vin := range rawTx.Vin {
@@ -379,6 +406,7 @@ func FetchTransaction(ctx context.Context, txHash string) (*types.TransactionDet
 				Addresses: vout.ScriptPubKey.Addresses,
 			},
 		}
+		describeScript(&output.ScriptPubKey)
 		outputs = append(outputs, output)
 		totalValue += vout.Value
 	}
@@ -423,6 +451,15 @@ func FetchTransaction(ctx context.Context, txHash string) (*types.TransactionDet
 		}
 	}
 
+	var breakdown *types.CoinbaseBreakdown
+	if classification != nil && rawTx.BlockHash != "" &&
+		(classification.Kind == TxKindCoinbase || classification.Kind == TxKindTreasuryBase) {
+		breakdown, err = coinbaseBreakdown(ctx, txMap, classification.Kind, rawTx.BlockHeight, rawTx.BlockHash)
+		if err != nil {
+			log.Printf("Warning: Could not build coinbase breakdown for %s: %v", rawTx.Txid, err)
+		}
+	}
+
 	return &types.TransactionDetail{
 		TransactionSummary: types.TransactionSummary{
 			TxID:          rawTx.Txid,
@@ -435,15 +472,17 @@ func FetchTransaction(ctx context.Context, txHash string) (*types.TransactionDet
 			Fee:           fee,
 			Size:          size,
 		},
-		Version:        rawTx.Version,
-		LockTime:       rawTx.LockTime,
-		Expiry:         rawTx.Expiry,
-		Inputs:         inputs,
-		Outputs:        outputs,
-		RawHex:         rawTx.Hex,
-		PoliteiaKey:    politeiaKey,
-		RecipientCount: recipientCount,
-		VotingInfo:     votingInfo,
+		Version:           rawTx.Version,
+		LockTime:          rawTx.LockTime,
+		Expiry:            rawTx.Expiry,
+		Inputs:            inputs,
+		Outputs:           outputs,
+		RawHex:            rawTx.Hex,
+		PoliteiaKey:       politeiaKey,
+		RecipientCount:    recipientCount,
+		VotingInfo:        votingInfo,
+		Classification:    classification,
+		CoinbaseBreakdown: breakdown,
 	}, nil
 }
 
@@ -469,10 +508,14 @@ func extractPoliteiaKey(vout []struct {
 	if firstOutput.ScriptPubKey.Type != "nulldata" {
 		return ""
 	}
+	return politeiaKeyFromScript(firstOutput.ScriptPubKey.Hex)
+}
 
+// politeiaKeyFromScript returns the 32-byte push of a tspend's OP_RETURN
+// script as hex, or "" for any other script.
+func politeiaKeyFromScript(hex string) string {
 	// Parse hex: format is "6a20" + 32-byte politeia key
 	// 6a = OP_RETURN, 20 = push 32 bytes (0x20 = 32 decimal)
-	hex := firstOutput.ScriptPubKey.Hex
 	if len(hex) < 68 { // 4 (6a20) + 64 (32 bytes hex) = 68 minimum
 		return ""
 	}
@@ -483,126 +526,139 @@ func extractPoliteiaKey(vout []struct {
 	}
 
 	// Extract the 32-byte politeia key (64 hex characters after "6a20")
-	politeiaKey := hex[4:68]
-	return politeiaKey
+	return hex[4:68]
 }
 
-// UniversalSearch auto-detects and searches for block/tx/address
-func UniversalSearch(ctx context.Context, query string) (*types.SearchResult, error) {
+// Search result types, which double as the values of UniversalSearch's
+// forceType.
+const (
+	SearchTypeBlock       = "block"
+	SearchTypeTransaction = "transaction"
+	SearchTypeAddress     = "address"
+	SearchTypeUnknown     = "unknown"
+)
+
+// Input kinds UniversalSearch detects in a query.
+const (
+	SearchKindHeight  = "height"
+	SearchKindHash    = "hash" // 64 hex characters: a block or transaction hash
+	SearchKindAddress = "address"
+	SearchKindUnknown = "unknown"
+)
+
+// ErrInvalidSearchType is returned by UniversalSearch for a forceType other
+// than block, transaction or address.
+var ErrInvalidSearchType = errors.New("type must be block, transaction or address")
+
+// UniversalSearch detects whether query is a block height, a hash or an
+// address and looks it up. A hash is probed as both a transaction and a block
+// and every match is returned, so an ambiguous query is never resolved by
+// guessing. forceType, when set, restricts the lookup to that result type.
+// Type, Found and Data describe the first match for callers that only route
+// on one.
+func UniversalSearch(ctx context.Context, query, forceType string) (*types.SearchResult, error) {
 	query = strings.TrimSpace(query)
+	switch forceType {
+	case "", SearchTypeBlock, SearchTypeTransaction, SearchTypeAddress:
+	default:
+		return nil, ErrInvalidSearchType
+	}
 
-	// Try to detect query type
-	searchType := detectSearchType(query)
+	kind := detectSearchKind(query)
+	result := &types.SearchResult{
+		Type:    SearchTypeUnknown,
+		Kind:    kind,
+		Matches: []types.SearchMatch{},
+	}
+	want := func(t string) bool { return forceType == "" || forceType == t }
+	addMatch := func(t string, data interface{}) {
+		if !result.Found {
+			result.Type, result.Found, result.Data = t, true, data
+		}
+		result.Matches = append(result.Matches, types.SearchMatch{Type: t, Data: data})
+	}
 
-	switch searchType {
-	case "block_height":
+	switch kind {
+	case SearchKindHeight:
+		if !want(SearchTypeBlock) {
+			break
+		}
 		height, _ := strconv.ParseInt(query, 10, 64)
-		block, err := FetchBlockByHeight(ctx, height)
-		if err != nil {
-			return &types.SearchResult{
-				Type:  "block",
-				Found: false,
-				Error: "Block not found",
-			}, nil
-		}
-		return &types.SearchResult{
-			Type:  "block",
-			Found: true,
-			Data:  block,
-		}, nil
-
-	case "tx_hash":
-		// Try as transaction first
-		tx, err := FetchTransaction(ctx, query)
-		if err == nil {
-			return &types.SearchResult{
-				Type:  "transaction",
-				Found: true,
-				Data:  tx,
-			}, nil
-		}
-
-		// If transaction not found, try as block hash
-		block, err := FetchBlockByHash(ctx, query)
-		if err == nil {
-			return &types.SearchResult{
-				Type:  "block",
-				Found: true,
-				Data:  block,
-			}, nil
-		}
-
-		// Neither found
-		return &types.SearchResult{
-			Type:  "unknown",
-			Found: false,
-			Error: "Transaction or block not found",
-		}, nil
+		if block, err := FetchBlockByHeight(ctx, height); err == nil {
+			addMatch(SearchTypeBlock, block)
+		}
 
-	case "block_hash":
-		block, err := FetchBlockByHash(ctx, query)
-		if err != nil {
-			return &types.SearchResult{
-				Type:  "block",
-				Found: false,
-				Error: "Block not found",
-			}, nil
-		}
-		return &types.SearchResult{
-			Type:  "block",
-			Found: true,
-			Data:  block,
-		}, nil
+	case SearchKindHash:
+		if want(SearchTypeTransaction) {
+			if tx, err := FetchTransaction(ctx, query); err == nil {
+				addMatch(SearchTypeTransaction, tx)
+			}
+		}
+		if want(SearchTypeBlock) {
+			if block, err := FetchBlockByHash(ctx, query); err == nil {
+				addMatch(SearchTypeBlock, block)
+			}
+		}
 
-	case "address":
+	case SearchKindAddress:
+		if !want(SearchTypeAddress) {
+			break
+		}
+		if !ValidNetworkAddress(ctx, query) {
+			result.Error = "Not a valid address for this network"
+			return result, nil
+		}
 		info, err := FetchAddressInfo(ctx, query)
 		if err != nil {
-			return &types.SearchResult{
-				Type:  "address",
-				Found: false,
-				Error: "Failed to fetch address information",
-			}, nil
-		}
-		return &types.SearchResult{
-			Type:  "address",
-			Found: true,
-			Data:  info,
-		}, nil
+			result.Error = "Failed to fetch address information"
+			return result, nil
+		}
+		addMatch(SearchTypeAddress, info)
 
 	default:
-		return &types.SearchResult{
-			Type:  "unknown",
-			Found: false,
-			Error: "Invalid search query. Enter a block height, transaction hash, or block hash.",
-		}, nil
+		result.Error = "Invalid search query. Enter a block height, transaction hash, block hash, or address."
+		return result, nil
+	}
+
+	if !result.Found {
+		switch {
+		case forceType != "":
+			result.Type = forceType
+			result.Error = fmt.Sprintf("No %s found for this query", forceType)
+		case kind == SearchKindHash:
+			result.Error = "Transaction or block not found"
+		case kind == SearchKindHeight:
+			result.Type = SearchTypeBlock
+			result.Error = "Block not found"
+		}
 	}
+	return result, nil
 }
 
 // Helper functions
 
-func detectSearchType(query string) string {
-	// Block height (1-7 digits)
-	if len(query) <= 7 {
-		if _, err := strconv.ParseInt(query, 10, 64); err == nil {
-			return "block_height"
-		}
+// detectSearchKind classifies query by shape alone. Addresses are only
+// recognised here; UniversalSearch validates them against the network.
+func detectSearchKind(query string) string {
+	switch {
+	case len(query) == 64 && isHex(query):
+		return SearchKindHash
+	case query != "" && len(query) <= 10 && isDigits(query):
+		return SearchKindHeight
+	case len(query) >= 26 && len(query) <= 36:
+		return SearchKindAddress
+	default:
+		return SearchKindUnknown
 	}
+}
 
-	// Transaction hash or block hash (64 hex characters)
-	if len(query) == 64 {
-		// Check if it's valid hex
-		if _, err := strconv.ParseUint(query, 16, 64); err == nil || isHex(query) {
-			// Try as transaction first, then block
-			return "tx_hash"
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
 		}
 	}
-
-	// Decred address (starts with D)
-	if strings.HasPrefix(query, "D") && len(query) >= 26 && len(query) <= 35 {
-		return "address"
-	}
-
-	return "unknown"
+	return true
 }
 
 func isHex(s string) bool {
@@ -852,10 +908,6 @@ func jsonStr(s string) json.RawMessage {
 // FetchAddressInfo gets limited information about an address
 // Note: This uses only basic RPC methods available without --addrindex
 func FetchAddressInfo(ctx context.Context, address string) (*types.AddressInfo, error) {
-	if rpc.DcrdClient == nil {
-		return nil, fmt.Errorf("dcrd client not available")
-	}
-
 	info := &types.AddressInfo{
 		Address:  address,
 		IsValid:  false,
@@ -884,11 +936,18 @@ func FetchAddressInfo(ctx context.Context, address string) (*types.AddressInfo,
 	if !info.IsValid {
 		return info, nil // Return early if invalid
 	}
+	if st := addressScriptType(ctx, address); st != "" {
+		info.ScriptType = st
+		info.ScriptClass, info.ScriptLabel = classifyScriptType(st)
+	}
 
 	// 2. Check if address exists on blockchain
 	existsResult, err := rpc.DcrdClient.RawRequest(ctx, "existsaddress", []json.RawMessage{
 		jsonStr(address),
 	})
+	if ctx.Err() != nil {
+		return nil, rpc.Classify(ctx.Err())
+	}
 	if err != nil {
 		log.Printf("Warning: Failed to check address existence: %v", err)
 	} else {
@@ -902,6 +961,9 @@ func FetchAddressInfo(ctx context.Context, address string) (*types.AddressInfo,
 	ticketsResult, err := rpc.DcrdClient.RawRequest(ctx, "ticketsforaddress", []json.RawMessage{
 		jsonStr(address),
 	})
+	if ctx.Err() != nil {
+		return nil, rpc.Classify(ctx.Err())
+	}
 	if err != nil {
 		log.Printf("Warning: Failed to get tickets for address: %v", err)
 	} else {
@@ -921,10 +983,6 @@ func FetchAddressInfo(ctx context.Context, address string) (*types.AddressInfo,
 
 // FetchMempoolTransactions retrieves all current mempool transactions
 func FetchMempoolTransactions(ctx context.Context) (*types.MempoolTransactions, error) {
-	if rpc.DcrdClient == nil {
-		return nil, fmt.Errorf("dcrd client not available")
-	}
-
 	// Get raw mempool transaction hashes
 	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawmempool", []json.RawMessage{})
 	if err != nil {
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+const (
+	// MaxBlockHeaders is the most headers one FetchBlockHeaders returns.
+	MaxBlockHeaders = 2000
+
+	// Header fetching runs headerFetchWorkers lookups at once, in batches
+	// of headerFetchBatch heights.
+	headerFetchWorkers = 8
+	headerFetchBatch   = 100
+)
+
+// ErrInvalidHeaderRange is returned for a header range that is inverted, has
+// a step below 1 or spans more than MaxBlockHeaders headers.
+var ErrInvalidHeaderRange = fmt.Errorf("invalid range; start must not exceed end, step must be at least 1 "+
+	"and the range is limited to %d headers", MaxBlockHeaders)
+
+// FetchBlockHeaders returns the headers of every step-th block from start
+// through end, which is clamped to the tip.
+func FetchBlockHeaders(ctx context.Context, start, end, step int64) ([]types.BlockHeaderSummary, error) {
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	heights, err := headerHeights(start, end, step, tip)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]types.BlockHeaderSummary, 0, len(heights))
+	var fetchErr error
+	fetchHeights(ctx, heights, headerFetchWorkers, headerFetchBatch, fetchBlockHeaderSummary,
+		func(height int64, h types.BlockHeaderSummary, err error) bool {
+			if err != nil {
+				fetchErr = fmt.Errorf("block %d: %w", height, err)
+				return false
+			}
+			headers = append(headers, h)
+			return true
+		})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, rpc.Classify(err)
+	}
+	return headers, nil
+}
+
+// headerHeights lists the heights FetchBlockHeaders reads: start, then every
+// step blocks through end, with end clamped to tip.
+func headerHeights(start, end, step, tip int64) ([]int64, error) {
+	if start < 0 || start > end || step < 1 {
+		return nil, ErrInvalidHeaderRange
+	}
+	if end > tip {
+		end = tip
+	}
+	if start > end {
+		return []int64{}, nil
+	}
+	if (end-start)/step+1 > MaxBlockHeaders {
+		return nil, ErrInvalidHeaderRange
+	}
+	heights := make([]int64, 0, (end-start)/step+1)
+	for h := start; h <= end; h += step {
+		heights = append(heights, h)
+	}
+	return heights, nil
+}
+
+// fetchBlockHeaderSummary reads the header of the block at height.
+func fetchBlockHeaderSummary(ctx context.Context, height int64) (types.BlockHeaderSummary, error) {
+	hash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return types.BlockHeaderSummary{}, err
+	}
+	header, err := rpc.DcrdClient.GetBlockHeaderVerbose(ctx, hash)
+	if err != nil {
+		return types.BlockHeaderSummary{}, err
+	}
+	return types.BlockHeaderSummary{
+		Height:     int64(header.Height),
+		Hash:       header.Hash,
+		Time:       header.Time,
+		Difficulty: header.Difficulty,
+		PoolSize:   header.PoolSize,
+		Size:       header.Size,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHeaderHeights(t *testing.T) {
+	tests := []struct {
+		start, end, step, tip int64
+		want                  []int64
+	}{
+		{10, 14, 1, 100, []int64{10, 11, 12, 13, 14}},
+		{10, 20, 4, 100, []int64{10, 14, 18}},
+		{95, 120, 2, 100, []int64{95, 97, 99}}, // end clamped to the tip
+		{150, 160, 1, 100, []int64{}},          // past the tip
+		{0, 3998, 2, 5000, nil},                // exactly MaxBlockHeaders
+	}
+	for _, test := range tests {
+		got, err := headerHeights(test.start, test.end, test.step, test.tip)
+		if err != nil {
+			t.Errorf("%d-%d/%d: %v", test.start, test.end, test.step, err)
+			continue
+		}
+		if test.want == nil {
+			if len(got) != MaxBlockHeaders {
+				t.Errorf("%d-%d/%d: %d heights, want %d", test.start, test.end, test.step, len(got), MaxBlockHeaders)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d-%d/%d: got %v, want %v", test.start, test.end, test.step, got, test.want)
+		}
+	}
+
+	for _, bad := range [][3]int64{{20, 10, 1}, {10, 20, 0}, {0, 4000, 2}, {-1, 5, 1}} {
+		if _, err := headerHeights(bad[0], bad[1], bad[2], 5000); !errors.Is(err, ErrInvalidHeaderRange) {
+			t.Errorf("%v: err = %v, want ErrInvalidHeaderRange", bad, err)
+		}
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"dcrpulse/internal/types"
 
 	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
-	"github.com/decred/dcrd/chaincfg/v3"
 )
 
 // ---- Consensus agendas -----------------------------------------------------
@@ -27,45 +26,9 @@ import (
 // definitions) with the wallet's current VoteChoices to populate
 // CurrentChoice per agenda.
 func ListAgendas(ctx context.Context) ([]types.Agenda, error) {
-	if rpc.DcrdClient == nil || rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("rpc clients not initialized")
-	}
-
-	// dcrd getvoteinfo expects the current stake version. We always pass
-	// the latest defined version: dcrd handles "future" versions by
-	// returning the most recent live deployment. v7 covers the Phase 1
-	// agendas (changesubsidysplit, blake3pow, maxtreasuryspend). Hardcode
-	// for now; revisit on next consensus upgrade.
-	const stakeVersion = 9
-	rawVI, err := rpc.DcrdClient.RawRequest(ctx, "getvoteinfo", []json.RawMessage{
-		json.RawMessage(fmt.Sprintf("%d", stakeVersion)),
-	})
+	vi, err := fetchVoteInfo(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("getvoteinfo: %w", err)
-	}
-	var vi struct {
-		Currentheight int64 `json:"currentheight"`
-		Agendas       []struct {
-			ID             string `json:"id"`
-			Description    string `json:"description"`
-			Mask           uint64 `json:"mask"`
-			Starttime      int64  `json:"starttime"`
-			Expiretime     int64  `json:"expiretime"`
-			Status         string `json:"status"`
-			Quorumprogress float64 `json:"quorumprogress"`
-			Choices        []struct {
-				ID          string  `json:"id"`
-				Description string  `json:"description"`
-				Bits        uint16  `json:"bits"`
-				Isabstain   bool    `json:"isabstain"`
-				Isno        bool    `json:"isno"`
-				Count       uint32  `json:"count"`
-				Progress    float64 `json:"progress"`
-			} `json:"choices"`
-		} `json:"agendas"`
-	}
-	if err := json.Unmarshal(rawVI, &vi); err != nil {
-		return nil, fmt.Errorf("decode getvoteinfo: %w", err)
+		return nil, err
 	}
 
 	// Current choices from the wallet.
@@ -100,12 +63,61 @@ func ListAgendas(ctx context.Context) ([]types.Agenda, error) {
 	return out, nil
 }
 
+// voteInfo is dcrd's getvoteinfo result.
+type voteInfo struct {
+	Currentheight int64  `json:"currentheight"`
+	Startheight   int64  `json:"startheight"`
+	Endheight     int64  `json:"endheight"`
+	Quorum        uint32 `json:"quorum"`
+	Totalvotes    uint32 `json:"totalvotes"`
+	Agendas       []struct {
+		ID             string  `json:"id"`
+		Description    string  `json:"description"`
+		Mask           uint64  `json:"mask"`
+		Starttime      int64   `json:"starttime"`
+		Expiretime     int64   `json:"expiretime"`
+		Status         string  `json:"status"`
+		Quorumprogress float64 `json:"quorumprogress"`
+		Choices        []struct {
+			ID          string  `json:"id"`
+			Description string  `json:"description"`
+			Bits        uint16  `json:"bits"`
+			Isabstain   bool    `json:"isabstain"`
+			Isno        bool    `json:"isno"`
+			Count       uint32  `json:"count"`
+			Progress    float64 `json:"progress"`
+		} `json:"choices"`
+	} `json:"agendas"`
+}
+
+// fetchVoteInfo asks dcrd for the agendas of the current deployment and
+// their votes in the current rule change interval.
+func fetchVoteInfo(ctx context.Context) (*voteInfo, error) {
+	if rpc.DcrdClient == nil {
+		return nil, rpc.NotConnected("dcrd client not initialized")
+	}
+	// dcrd getvoteinfo expects the current stake version. We always pass
+	// the latest defined version: dcrd handles "future" versions by
+	// returning the most recent live deployment. v7 covers the Phase 1
+	// agendas (changesubsidysplit, blake3pow, maxtreasuryspend). Hardcode
+	// for now; revisit on next consensus upgrade.
+	const stakeVersion = 9
+	rawVI, err := rpc.DcrdClient.RawRequest(ctx, "getvoteinfo", []json.RawMessage{
+		json.RawMessage(fmt.Sprintf("%d", stakeVersion)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getvoteinfo: %w", err)
+	}
+	var vi voteInfo
+	if err := json.Unmarshal(rawVI, &vi); err != nil {
+		return nil, fmt.Errorf("decode getvoteinfo: %w", err)
+	}
+	return &vi, nil
+}
+
 // SetAgendaChoice updates one agenda's vote preference. The wallet is
 // briefly unlocked, the choice is applied, then re-locked.
 func SetAgendaChoice(ctx context.Context, agendaID, choiceID string, passphrase []byte) error {
-	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC unavailable")
-	}
 	if err := unlockForVote(ctx, passphrase); err != nil {
 		return err
 	}
@@ -132,21 +144,10 @@ func SetAgendaChoice(ctx context.Context, agendaID, choiceID string, passphrase
 // app/constants/decred.js:75-78 where the second mainnet key sits
 // commented out.
 func sanctionedPiKeys(ctx context.Context) ([]string, error) {
-	network, err := CurrentNetwork(ctx)
+	params, err := CurrentChainParams(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var params *chaincfg.Params
-	switch network {
-	case "mainnet":
-		params = chaincfg.MainNetParams()
-	case "testnet":
-		params = chaincfg.TestNet3Params()
-	case "simnet":
-		params = chaincfg.SimNetParams()
-	default:
-		return nil, fmt.Errorf("unsupported network %q", network)
-	}
 	if len(params.PiKeys) == 0 {
 		return nil, nil
 	}
@@ -215,9 +216,6 @@ func SetTreasuryKeyPolicy(ctx context.Context, keyHex, policy string, passphrase
 // ---- Treasury (per-TSpend hash) -------------------------------------------
 
 func ListTSpendPolicies(ctx context.Context) ([]types.TSpendPolicy, error) {
-	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC unavailable")
-	}
 	resp, err := rpc.VotingClient.TSpendPolicies(ctx, &pb.TSpendPoliciesRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("TSpendPolicies: %w", err)
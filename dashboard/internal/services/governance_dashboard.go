@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"golang.org/x/sync/errgroup"
+)
+
+// governanceFlowBuckets is how many of the latest monthly treasury flow
+// buckets the governance dashboard carries.
+const governanceFlowBuckets = 6
+
+// FetchGovernanceDashboard gathers the treasury balance and recent flow, the
+// mempool TSpends' vote standings, the agendas being voted on and the next
+// treasury expenditure policy window, concurrently. Like FetchDashboardData,
+// a section that fails is left empty and reported in the returned map of
+// section name to error; the error is returned only when every section
+// failed.
+func FetchGovernanceDashboard(ctx context.Context) (*types.GovernanceDashboard, map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dashboardTimeout)
+	defer cancel()
+
+	data := &types.GovernanceDashboard{
+		RecentFlow: []types.TreasuryFlowBucket{},
+		TSpends:    []types.TSpendStanding{},
+		Agendas:    []types.AgendaProgress{},
+	}
+	var (
+		mu       sync.Mutex
+		failures = map[string]string{}
+		firstErr error
+		sections int
+	)
+	section := func(name string, fetch func() error) func() error {
+		sections++
+		return func() error {
+			if err := fetch(); err != nil {
+				log.Printf("Governance dashboard: %s unavailable: %v", name, err)
+				mu.Lock()
+				failures[name] = err.Error()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+			// Never fail the group, as in FetchDashboardData.
+			return nil
+		}
+	}
+
+	// Each closure writes only its own fields of data, so they need no lock.
+	var g errgroup.Group
+	g.Go(section("treasuryBalance", func() error {
+		balance, err := getTreasuryBalance(ctx)
+		if err == nil {
+			data.TreasuryBalance = atomsToCoin(balance)
+			data.TreasuryBalanceAtoms = balance
+			data.TreasuryBalanceDCR = formatDCR(balance)
+		}
+		return err
+	}))
+	g.Go(section("recentFlow", func() error {
+		flow, err := TreasuryFlow(FlowIntervalMonth)
+		if err == nil {
+			data.RecentFlow = lastFlowBuckets(flow.Buckets, governanceFlowBuckets)
+		}
+		return err
+	}))
+	g.Go(section("tspends", func() error {
+		v, err := GetMempoolTSpendStandings(ctx)
+		if err == nil && v != nil {
+			data.TSpends = v
+		}
+		return err
+	}))
+	g.Go(section("agendas", func() error {
+		vi, err := fetchVoteInfo(ctx)
+		if err == nil {
+			data.Agendas = agendaProgress(vi)
+			data.RuleChangeInterval = &types.RuleChangeInterval{
+				StartHeight: vi.Startheight,
+				EndHeight:   vi.Endheight,
+				Quorum:      vi.Quorum,
+				TotalVotes:  vi.Totalvotes,
+			}
+		}
+		return err
+	}))
+	g.Go(section("policyWindow", func() error {
+		params, err := CurrentChainParams(ctx)
+		if err != nil {
+			return err
+		}
+		if rpc.DcrdClient == nil {
+			return rpc.NotConnected("dcrd client not initialized")
+		}
+		_, tip, err := rpc.DcrdClient.GetBestBlock(ctx)
+		if err != nil {
+			return err
+		}
+		w := treasuryPolicyWindow(params, tip)
+		data.PolicyWindow = &w
+		return nil
+	}))
+	g.Wait()
+
+	if len(failures) == sections {
+		return nil, failures, firstErr
+	}
+	return data, failures, nil
+}
+
+// lastFlowBuckets returns the latest n of buckets, which are in time order.
+func lastFlowBuckets(buckets []types.TreasuryFlowBucket, n int) []types.TreasuryFlowBucket {
+	if len(buckets) > n {
+		return buckets[len(buckets)-n:]
+	}
+	return buckets
+}
+
+// agendaProgress picks the agendas of vi still being decided, those voting
+// or locked in, with each choice's votes.
+func agendaProgress(vi *voteInfo) []types.AgendaProgress {
+	out := []types.AgendaProgress{}
+	for _, a := range vi.Agendas {
+		if a.Status != "started" && a.Status != "lockedin" {
+			continue
+		}
+		choices := make([]types.AgendaChoiceProgress, 0, len(a.Choices))
+		for _, c := range a.Choices {
+			choices = append(choices, types.AgendaChoiceProgress{
+				ID:        c.ID,
+				IsAbstain: c.Isabstain,
+				IsNo:      c.Isno,
+				Count:     c.Count,
+				Progress:  c.Progress,
+			})
+		}
+		out = append(out, types.AgendaProgress{
+			ID:             a.ID,
+			Description:    a.Description,
+			Status:         a.Status,
+			ExpireTime:     a.Expiretime,
+			QuorumProgress: a.Quorumprogress,
+			Choices:        choices,
+		})
+	}
+	return out
+}
+
+// treasuryPolicyWindow places the expenditure policy window for the first
+// TVI block after tip, the next block a TSpend can be mined in: the
+// TreasuryExpenditureWindow vote windows (TVI * multiplier blocks each)
+// that precede it.
+func treasuryPolicyWindow(params *chaincfg.Params, tip int64) types.TreasuryPolicyWindow {
+	tvi := int64(params.TreasuryVoteInterval)
+	next := (tip/tvi + 1) * tvi
+	blocks := int64(params.TreasuryVoteInterval * params.TreasuryVoteIntervalMultiplier *
+		params.TreasuryExpenditureWindow)
+	return types.TreasuryPolicyWindow{
+		CurrentHeight:  tip,
+		NextTVIHeight:  next,
+		BlocksUntilTVI: next - tip,
+		WindowBlocks:   blocks,
+		WindowStart:    max(next-blocks, 0),
+		WindowEnd:      next - 1,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestTreasuryPolicyWindow(t *testing.T) {
+	// Mainnet: TVI 288, multiplier 12, expenditure window 2.
+	params := chaincfg.MainNetParams()
+	tests := []struct {
+		tip  int64
+		want types.TreasuryPolicyWindow
+	}{
+		{1000000, types.TreasuryPolicyWindow{
+			CurrentHeight: 1000000, NextTVIHeight: 1000224, BlocksUntilTVI: 224,
+			WindowBlocks: 6912, WindowStart: 993312, WindowEnd: 1000223,
+		}},
+		// On a TVI block the next one is a full interval away.
+		{1000224, types.TreasuryPolicyWindow{
+			CurrentHeight: 1000224, NextTVIHeight: 1000512, BlocksUntilTVI: 288,
+			WindowBlocks: 6912, WindowStart: 993600, WindowEnd: 1000511,
+		}},
+		// The window doesn't reach before genesis.
+		{10, types.TreasuryPolicyWindow{
+			CurrentHeight: 10, NextTVIHeight: 288, BlocksUntilTVI: 278,
+			WindowBlocks: 6912, WindowStart: 0, WindowEnd: 287,
+		}},
+	}
+	for _, test := range tests {
+		if got := treasuryPolicyWindow(params, test.tip); got != test.want {
+			t.Errorf("tip %d: got %+v, want %+v", test.tip, got, test.want)
+		}
+	}
+}
+
+func TestLastFlowBuckets(t *testing.T) {
+	buckets := make([]types.TreasuryFlowBucket, 8)
+	for i := range buckets {
+		buckets[i].TSpendCount = i
+	}
+	got := lastFlowBuckets(buckets, 6)
+	if len(got) != 6 || got[0].TSpendCount != 2 || got[5].TSpendCount != 7 {
+		t.Errorf("last 6 of 8 = %+v", got)
+	}
+	if got := lastFlowBuckets(buckets[:3], 6); len(got) != 3 {
+		t.Errorf("last 6 of 3 has %d buckets", len(got))
+	}
+}
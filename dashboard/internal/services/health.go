@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// readinessTimeout bounds each readiness probe call, so a hung dependency
+// fails the probe instead of stalling it.
+const readinessTimeout = 2 * time.Second
+
+// CheckReadiness probes dcrd with getblockcount and the wallet JSON-RPC with
+// version, concurrently. Only dcrd decides Ready.
+func CheckReadiness(ctx context.Context) types.Readiness {
+	var r types.Readiness
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if rpc.DcrdClient == nil {
+			return
+		}
+		r.Dcrd = probeDependency(ctx, func(ctx context.Context) error {
+			_, err := rpc.DcrdClient.GetBlockCount(ctx)
+			return err
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		if rpc.WalletClient == nil {
+			return
+		}
+		r.WalletRPC = probeDependency(ctx, func(ctx context.Context) error {
+			_, err := rpc.WalletClient.Version(ctx)
+			return err
+		})
+	}()
+	wg.Wait()
+	r.Ready = r.Dcrd.Up
+	return r
+}
+
+// probeDependency times one probe call made under readinessTimeout.
+func probeDependency(ctx context.Context, probe func(context.Context) error) types.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+	start := time.Now()
+	err := probe(ctx)
+	status := types.DependencyStatus{
+		Configured: true,
+		Up:         err == nil,
+		LatencyMs:  time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
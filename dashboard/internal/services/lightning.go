@@ -62,7 +62,7 @@ func sentinelPath() string {
 // per-account passphrase so the LN setup wizard takes one input.
 func SetupLightningAccount(ctx context.Context, passphrase []byte) (uint32, error) {
 	if rpc.WalletGrpcClient == nil {
-		return 0, fmt.Errorf("dcrwallet gRPC unavailable")
+		return 0, rpc.NotConnected("dcrwallet gRPC unavailable")
 	}
 
 	// Existing-account lookup first — re-running the wizard after a
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/types"
+
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+var (
+	mempoolSubsMu sync.Mutex
+	mempoolSubs   []chan types.MempoolTxEvent
+)
+
+// SubscribeMempoolEvents returns a channel that receives every transaction
+// dcrd accepts into mempool, and a cleanup func.
+func SubscribeMempoolEvents() (<-chan types.MempoolTxEvent, func()) {
+	ch := make(chan types.MempoolTxEvent, 64)
+	mempoolSubsMu.Lock()
+	mempoolSubs = append(mempoolSubs, ch)
+	mempoolSubsMu.Unlock()
+	return ch, func() {
+		mempoolSubsMu.Lock()
+		defer mempoolSubsMu.Unlock()
+		for i, sub := range mempoolSubs {
+			if sub == ch {
+				mempoolSubs = append(mempoolSubs[:i], mempoolSubs[i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}
+}
+
+// PublishMempoolTx is the dcrd tx-accepted notification callback. It
+// summarizes the transaction and fans it out to subscribers, dropping the
+// event for any subscriber whose buffer is full. It also feeds the address
+// watcher.
+func PublishMempoolTx(tx *chainjson.TxRawResult) {
+	queueAddressWatchTx(tx)
+
+	mempoolSubsMu.Lock()
+	n := len(mempoolSubs)
+	mempoolSubsMu.Unlock()
+	if n == 0 {
+		return
+	}
+
+	ev := mempoolTxEvent(tx)
+	mempoolSubsMu.Lock()
+	for _, sub := range mempoolSubs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+	mempoolSubsMu.Unlock()
+}
+
+func mempoolTxEvent(tx *chainjson.TxRawResult) types.MempoolTxEvent {
+	ev := types.MempoolTxEvent{
+		TxID:     tx.Txid,
+		Size:     len(tx.Hex) / 2,
+		Received: time.Now().Unix(),
+	}
+
+	var in, out int64
+	for _, vin := range tx.Vin {
+		in += int64(math.Round(vin.AmountIn * 1e8))
+	}
+	for _, vout := range tx.Vout {
+		out += int64(math.Round(vout.Value * 1e8))
+	}
+	if fee := in - out; fee > 0 {
+		ev.FeeAtoms = fee
+		if ev.Size > 0 {
+			ev.FeeRate = float64(fee) / 1e8 / float64(ev.Size) * 1000
+		}
+	}
+
+	// Round-trip through JSON so the map-based classifiers see the same shape
+	// as a getrawtransaction result.
+	ev.Type = TxKindRegular
+	if b, err := json.Marshal(tx); err == nil {
+		var m map[string]interface{}
+		if json.Unmarshal(b, &m) == nil {
+			c := classifyTransaction(m)
+			ev.Type, ev.Label = c.Kind, c.Label
+		}
+	}
+	if ev.Label == "" {
+		ev.Label = txKindLabels[ev.Type]
+	}
+	return ev
+}
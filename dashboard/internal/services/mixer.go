@@ -29,9 +29,6 @@ func MixerDebugEnabled() bool {
 // SetMixerDebug calls dcrwallet's debuglevel JSON-RPC to toggle MIXC + TKBY
 // between debug and info, and tracks the resulting state locally.
 func SetMixerDebug(ctx context.Context, enabled bool) error {
-	if rpc.WalletClient == nil {
-		return fmt.Errorf("wallet client not initialized")
-	}
 	levelSpec := "MIXC=info,TKBY=info"
 	if enabled {
 		levelSpec = "MIXC=debug,TKBY=debug"
@@ -154,7 +151,7 @@ func setMixerErr(msg string) {
 // is owned by this function for the duration of the call.
 func StartMixer(passphrase []byte, mixedAccount, mixedBranch, changeAccount uint32) error {
 	if rpc.AccountMixerClient == nil {
-		return fmt.Errorf("mixer gRPC client unavailable")
+		return rpc.NotConnected("mixer gRPC client unavailable")
 	}
 
 	mixerMu.Lock()
@@ -7,18 +7,53 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"sync"
 
 	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 )
 
 var (
 	networkMu  sync.Mutex
 	networkVal string
+
+	treasuryParamsMu  sync.Mutex
+	treasuryParamsVal *TreasuryParams
+	// treasuryParamsGen is bumped whenever the cached params are dropped, so
+	// a resolution begun before that doesn't cache a stale answer.
+	treasuryParamsGen uint64
 )
 
-// CurrentNetwork returns "mainnet" or "testnet", lazily resolved via
+// mainnetTreasuryActivationHeight is the block the DCP-0006 treasury agenda
+// activated at on mainnet (May 2021). It is the last-resort fallback on
+// mainnet when the height can't be resolved from dcrd.
+const mainnetTreasuryActivationHeight = 552448
+
+// treasuryActivationOverride, when positive, is used as the treasury
+// activation height instead of resolving it.
+var treasuryActivationOverride int64
+
+// SetTreasuryActivationHeight fixes the treasury activation height, for
+// networks where it can be neither read from dcrd nor derived from the chain
+// parameters. Zero restores detection.
+func SetTreasuryActivationHeight(height int64) {
+	treasuryParamsMu.Lock()
+	defer treasuryParamsMu.Unlock()
+	if height < 0 {
+		height = 0
+	}
+	treasuryActivationOverride = height
+	treasuryParamsVal = nil
+	treasuryParamsGen++
+}
+
+// CurrentNetwork returns "mainnet", "testnet", "simnet" or "regnet", or for
+// any other chain dcrd's own name for it lowercased, lazily resolved via
 // dcrd's getblockchaininfo. Only a successful resolution is cached for
 // the process lifetime, since the chain identity doesn't change at
 // runtime; a failed lookup (e.g. dcrd not yet reachable at startup) is
@@ -30,7 +65,7 @@ func CurrentNetwork(ctx context.Context) (string, error) {
 		return networkVal, nil
 	}
 	if rpc.DcrdClient == nil {
-		return "", fmt.Errorf("dcrd client not initialized")
+		return "", rpc.NotConnected("dcrd client not initialized")
 	}
 	info, err := rpc.DcrdClient.GetBlockChainInfo(ctx)
 	if err != nil {
@@ -44,8 +79,291 @@ func CurrentNetwork(ctx context.Context) (string, error) {
 		networkVal = "testnet"
 	case strings.Contains(chain, "sim"):
 		networkVal = "simnet"
+	case strings.Contains(chain, "reg"):
+		networkVal = "regnet"
 	default:
 		networkVal = chain
 	}
 	return networkVal, nil
 }
+
+// CurrentChainParams returns the chaincfg parameters for the network dcrd is
+// running on, resolved through CurrentNetwork.
+func CurrentChainParams(ctx context.Context) (*chaincfg.Params, error) {
+	network, err := CurrentNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch network {
+	case "mainnet":
+		return chaincfg.MainNetParams(), nil
+	case "testnet":
+		return chaincfg.TestNet3Params(), nil
+	case "simnet":
+		return chaincfg.SimNetParams(), nil
+	case "regnet":
+		return chaincfg.RegNetParams(), nil
+	default:
+		return nil, fmt.Errorf("unsupported network %q", network)
+	}
+}
+
+// TreasuryParams holds the treasury constants for the active network.
+type TreasuryParams struct {
+	// ActivationHeight is the first block with the treasury agenda active.
+	ActivationHeight int64
+	// VoteInterval is the TVI. Per DCP-0006 a TSpend may only be mined in a
+	// block whose height is a non-zero multiple of it.
+	VoteInterval int64
+	// VoteWindow is the number of blocks (TVI * multiplier) a TSpend can be
+	// voted on before its expiry.
+	VoteWindow int64
+}
+
+// CurrentTreasuryParams resolves the treasury constants for the network dcrd
+// is on. Intervals come from chaincfg; the activation height is an agenda
+// vote outcome rather than a chain parameter, so unless
+// SetTreasuryActivationHeight fixed it, it is read from dcrd's treasury
+// deployment state, or found by probing gettreasurybalance (which fails below
+// activation). When dcrd can't tell, treasuryActivationFromParams supplies
+// it where the network's parameters determine it. Only a successful
+// resolution is cached.
+func CurrentTreasuryParams(ctx context.Context) (TreasuryParams, error) {
+	// The activation probe can take a score of dcrd calls, so it runs
+	// without the lock; concurrent callers may each resolve, and the first
+	// to finish is cached.
+	treasuryParamsMu.Lock()
+	if treasuryParamsVal != nil {
+		tp := *treasuryParamsVal
+		treasuryParamsMu.Unlock()
+		return tp, nil
+	}
+	activation, gen := treasuryActivationOverride, treasuryParamsGen
+	treasuryParamsMu.Unlock()
+
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return TreasuryParams{}, err
+	}
+	if activation == 0 {
+		activation, err = resolveTreasuryActivation(ctx)
+	}
+	if err != nil {
+		fallback, ok := treasuryActivationFromParams(params)
+		if !ok {
+			return TreasuryParams{}, fmt.Errorf("%w (set TREASURY_ACTIVATION_HEIGHT to configure it)", err)
+		}
+		log.Printf("Treasury activation height: %v; using %d from the %s parameters", err, fallback, params.Name)
+		activation = fallback
+	}
+	tp := TreasuryParams{
+		ActivationHeight: activation,
+		VoteInterval:     int64(params.TreasuryVoteInterval),
+		VoteWindow:       int64(params.TreasuryVoteInterval * params.TreasuryVoteIntervalMultiplier),
+	}
+
+	treasuryParamsMu.Lock()
+	defer treasuryParamsMu.Unlock()
+	if treasuryParamsGen != gen {
+		return tp, nil
+	}
+	if treasuryParamsVal == nil {
+		treasuryParamsVal = &tp
+	}
+	return *treasuryParamsVal, nil
+}
+
+// treasuryActivationFromParams returns the treasury activation height the
+// network's parameters imply, if they do. On mainnet it is the recorded vote
+// outcome. Where the agenda's result is forced (simnet, regnet) it activates
+// as early as the rule-change rules allow: deployment windows of
+// RuleChangeActivationInterval blocks start at StakeValidationHeight, the
+// first window is the earliest the vote can start, the next is locked in and
+// the rules are active from the one after. A network that really voted, like
+// testnet, has no answer here.
+func treasuryActivationFromParams(params *chaincfg.Params) (int64, bool) {
+	if params.Net == chaincfg.MainNetParams().Net {
+		return mainnetTreasuryActivationHeight, true
+	}
+	for _, deployments := range params.Deployments {
+		for _, d := range deployments {
+			if d.Vote.Id != chaincfg.VoteIDTreasury {
+				continue
+			}
+			if d.ForcedChoiceID != "yes" {
+				return 0, false
+			}
+			window := int64(params.RuleChangeActivationInterval)
+			return params.StakeValidationHeight + 3*window, true
+		}
+	}
+	return 0, false
+}
+
+// resolveTreasuryActivation asks dcrd for the treasury agenda's activation
+// height. dcrd only lists agendas of the current deployment version, so when
+// the treasury deployment is absent the lowest block that gettreasurybalance
+// accepts is found by binary search instead. Only dcrd's "treasury inactive"
+// reply counts as a block before activation; any other failure ends the
+// search with an error rather than skewing it.
+func resolveTreasuryActivation(ctx context.Context) (int64, error) {
+	if rpc.DcrdClient == nil {
+		return 0, rpc.NotConnected("dcrd client not initialized")
+	}
+	info, err := rpc.DcrdClient.GetBlockChainInfo(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get blockchain info: %w", err)
+	}
+	if d, ok := info.Deployments["treasury"]; ok && d.Status == "active" && d.Since > 0 {
+		return d.Since, nil
+	}
+
+	treasuryActiveAt := func(h int64) (bool, error) {
+		hash, err := rpc.DcrdClient.GetBlockHash(ctx, h)
+		if err != nil {
+			return false, err
+		}
+		_, err = rpc.DcrdClient.GetTreasuryBalance(ctx, hash, false)
+		switch {
+		case err == nil:
+			return true, nil
+		case isTreasuryInactive(err):
+			return false, nil
+		}
+		return false, err
+	}
+	tip := info.Blocks
+	active, err := treasuryActiveAt(tip)
+	if err != nil {
+		return 0, fmt.Errorf("probe treasury at tip: %w", err)
+	}
+	if !active {
+		return 0, fmt.Errorf("treasury agenda is not active at height %d", tip)
+	}
+	lo, hi := int64(1), tip
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := treasuryActiveAt(mid)
+		if err != nil {
+			return 0, fmt.Errorf("probe treasury at %d: %w", mid, err)
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// isTreasuryInactive reports whether err is dcrd's reply to
+// gettreasurybalance for a block before the treasury agenda activated.
+func isTreasuryInactive(err error) bool {
+	return strings.Contains(err.Error(), "Treasury inactive")
+}
+
+// DetectNetwork resolves and logs the active network at startup so the
+// params are cached before the first request needs them. Failures are logged;
+// the lazy resolvers retry on demand.
+func DetectNetwork(ctx context.Context) {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		log.Printf("Warning: could not detect dcrd network at startup: %v", err)
+		return
+	}
+	log.Printf("Detected network: %s", params.Name)
+	if tp, err := CurrentTreasuryParams(ctx); err != nil {
+		log.Printf("Warning: could not resolve treasury params: %v", err)
+	} else {
+		log.Printf("Treasury: activation height %d, TVI %d, vote window %d blocks", tp.ActivationHeight, tp.VoteInterval, tp.VoteWindow)
+	}
+}
+
+// forgetNetwork drops the cached network and treasury params so they are
+// resolved again, for when dcrd is reconfigured at runtime.
+func forgetNetwork() {
+	networkMu.Lock()
+	networkVal = ""
+	networkMu.Unlock()
+	treasuryParamsMu.Lock()
+	treasuryParamsVal = nil
+	treasuryParamsGen++
+	treasuryParamsMu.Unlock()
+}
+
+// FetchActiveNetwork describes the detected network and the parameters derived
+// from it.
+func FetchActiveNetwork(ctx context.Context) (*types.ActiveNetwork, error) {
+	network, err := CurrentNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &types.ActiveNetwork{
+		Network:       network,
+		ParamsName:    params.Name,
+		AddressPrefix: params.NetworkAddressPrefix,
+		DefaultPort:   params.DefaultPort,
+	}
+	if tp, err := CurrentTreasuryParams(ctx); err == nil {
+		out.TreasuryActivationHeight = tp.ActivationHeight
+		out.TreasuryVoteInterval = tp.VoteInterval
+		out.TreasuryVoteWindow = tp.VoteWindow
+	}
+	return out, nil
+}
+
+// FetchConsensusParams returns the consensus parameters in use for the
+// detected network.
+func FetchConsensusParams(ctx context.Context) (*types.ConsensusParams, error) {
+	network, err := CurrentNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &types.ConsensusParams{
+		Network:                        network,
+		ParamsName:                     params.Name,
+		TreasuryVoteInterval:           params.TreasuryVoteInterval,
+		TreasuryVoteIntervalMultiplier: params.TreasuryVoteIntervalMultiplier,
+		TicketMaturity:                 params.TicketMaturity,
+		StakeValidationHeight:          params.StakeValidationHeight,
+		CoinbaseMaturity:               params.CoinbaseMaturity,
+		TargetTimePerBlock:             int64(params.TargetTimePerBlock.Seconds()),
+	}
+	if tp, err := CurrentTreasuryParams(ctx); err != nil {
+		out.TreasuryError = err.Error()
+	} else {
+		out.TreasuryActivationHeight = tp.ActivationHeight
+		out.TreasuryVoteWindow = tp.VoteWindow
+	}
+	return out, nil
+}
+
+// ValidNetworkAddress reports whether addr decodes as an address of the
+// active network, checksum and all.
+func ValidNetworkAddress(ctx context.Context, addr string) bool {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		params = chaincfg.MainNetParams()
+	}
+	_, err = stdaddr.DecodeAddress(addr, params)
+	return err == nil
+}
+
+// IsNetworkAddress reports whether addr carries the active network's address
+// prefix. It is a cheap routing check; dcrd's validateaddress remains the
+// authority on validity.
+func IsNetworkAddress(ctx context.Context, addr string) bool {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return strings.HasPrefix(addr, chaincfg.MainNetParams().NetworkAddressPrefix)
+	}
+	return strings.HasPrefix(addr, params.NetworkAddressPrefix)
+}
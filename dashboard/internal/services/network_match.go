@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// ErrNetworkMismatch is returned for wallet operations refused because dcrd
+// and dcrwallet run on different networks.
+var ErrNetworkMismatch = errors.New("dcrd and dcrwallet are on different networks; " +
+	"wallet operations are disabled until both use the same one")
+
+// networkCheckTimeout bounds each side's network lookup.
+const networkCheckTimeout = 5 * time.Second
+
+var (
+	networkMatchMu sync.Mutex
+	networkMatch   types.NetworkMatch
+)
+
+// CheckNetworkMatch asks dcrd and dcrwallet which network each runs on and
+// records whether they differ, logging an error when they do. A side that
+// can't be asked is left unknown, which is not a mismatch. Called after
+// either client is (re)initialized.
+func CheckNetworkMatch(ctx context.Context) types.NetworkMatch {
+	var dcrdNet, walletNet wire.CurrencyNet
+	if rpc.DcrdClient != nil {
+		net, err := dcrdCurrentNet(ctx)
+		if err != nil {
+			log.Printf("Warning: could not read dcrd's network: %v", err)
+		}
+		dcrdNet = net
+	}
+	if rpc.WalletClient != nil || rpc.WalletGrpcClient != nil {
+		net, err := walletCurrentNet(ctx)
+		if err != nil {
+			log.Printf("Warning: could not read dcrwallet's network: %v", err)
+		}
+		walletNet = net
+	}
+
+	match := compareNetworks(dcrdNet, walletNet)
+	if match.Mismatch {
+		log.Printf("ERROR: dcrd is on %s but dcrwallet is on %s. Wallet operations are disabled "+
+			"until both are configured for the same network.", match.DcrdNetwork, match.WalletNetwork)
+	}
+	networkMatchMu.Lock()
+	networkMatch = match
+	networkMatchMu.Unlock()
+	return match
+}
+
+// NetworkMatchStatus returns the result of the last CheckNetworkMatch.
+func NetworkMatchStatus() types.NetworkMatch {
+	networkMatchMu.Lock()
+	defer networkMatchMu.Unlock()
+	return networkMatch
+}
+
+// NetworkMismatch reports whether the last CheckNetworkMatch found dcrd and
+// dcrwallet on different networks.
+func NetworkMismatch() bool {
+	return NetworkMatchStatus().Mismatch
+}
+
+// compareNetworks names both networks, 0 standing for unknown, and reports
+// a mismatch only when both are known and differ.
+func compareNetworks(dcrd, wallet wire.CurrencyNet) types.NetworkMatch {
+	return types.NetworkMatch{
+		DcrdNetwork:   currencyNetName(dcrd),
+		WalletNetwork: currencyNetName(wallet),
+		Mismatch:      dcrd != 0 && wallet != 0 && dcrd != wallet,
+	}
+}
+
+// currencyNetName names net after its chain parameters, or its number when
+// it matches none; the empty string stands for unknown.
+func currencyNetName(net wire.CurrencyNet) string {
+	if net == 0 {
+		return ""
+	}
+	for _, p := range []*chaincfg.Params{
+		chaincfg.MainNetParams(), chaincfg.TestNet3Params(),
+		chaincfg.SimNetParams(), chaincfg.RegNetParams(),
+	} {
+		if p.Net == net {
+			return p.Name
+		}
+	}
+	return net.String()
+}
+
+// dcrdCurrentNet asks dcrd for its network with getcurrentnet.
+func dcrdCurrentNet(ctx context.Context) (wire.CurrencyNet, error) {
+	ctx, cancel := context.WithTimeout(ctx, networkCheckTimeout)
+	defer cancel()
+	raw, err := rpc.DcrdClient.RawRequest(ctx, "getcurrentnet", nil)
+	if err != nil {
+		return 0, fmt.Errorf("getcurrentnet: %w", err)
+	}
+	return decodeCurrentNet(raw)
+}
+
+// walletCurrentNet asks dcrwallet for its network, through the gRPC Network
+// call when that client is up and getcurrentnet otherwise.
+func walletCurrentNet(ctx context.Context) (wire.CurrencyNet, error) {
+	ctx, cancel := context.WithTimeout(ctx, networkCheckTimeout)
+	defer cancel()
+	if rpc.WalletGrpcClient != nil {
+		resp, err := rpc.WalletGrpcClient.Network(ctx, &pb.NetworkRequest{})
+		if err == nil {
+			return wire.CurrencyNet(resp.GetActiveNetwork()), nil
+		}
+		if rpc.WalletClient == nil {
+			return 0, fmt.Errorf("Network: %w", err)
+		}
+	}
+	raw, err := rpc.WalletClient.RawRequest(ctx, "getcurrentnet", nil)
+	if err != nil {
+		return 0, fmt.Errorf("getcurrentnet: %w", err)
+	}
+	return decodeCurrentNet(raw)
+}
+
+func decodeCurrentNet(raw json.RawMessage) (wire.CurrencyNet, error) {
+	var net uint32
+	if err := json.Unmarshal(raw, &net); err != nil {
+		return 0, fmt.Errorf("decode getcurrentnet: %w", err)
+	}
+	return wire.CurrencyNet(net), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/decred/dcrd/wire"
+)
+
+func TestCompareNetworks(t *testing.T) {
+	tests := []struct {
+		name           string
+		dcrd, wallet   wire.CurrencyNet
+		dcrdN, walletN string
+		mismatch       bool
+	}{
+		{"same", wire.MainNet, wire.MainNet, "mainnet", "mainnet", false},
+		{"different", wire.MainNet, wire.TestNet3, "mainnet", "testnet3", true},
+		{"wallet unknown", wire.SimNet, 0, "simnet", "", false},
+		{"both unknown", 0, 0, "", "", false},
+		{"unnamed", wire.CurrencyNet(7), wire.RegNet, wire.CurrencyNet(7).String(), "regnet", true},
+	}
+	for _, test := range tests {
+		got := compareNetworks(test.dcrd, test.wallet)
+		if got.DcrdNetwork != test.dcrdN || got.WalletNetwork != test.walletN || got.Mismatch != test.mismatch {
+			t.Errorf("%s: got %+v", test.name, got)
+		}
+	}
+}
+
+func TestDcrdCurrentNet(t *testing.T) {
+	useFakeDcrd(t, &fakeDcrd{raw: map[string]func([]json.RawMessage) (json.RawMessage, error){
+		"getcurrentnet": func([]json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage("2979310197"), nil
+		},
+	}})
+	net, err := dcrdCurrentNet(context.Background())
+	if err != nil || net != wire.TestNet3 {
+		t.Fatalf("got %v, %v; want testnet3", net, err)
+	}
+}
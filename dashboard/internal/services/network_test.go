@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"dcrpulse/internal/rpc"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+func TestTreasuryActivationFromParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *chaincfg.Params
+		height int64
+		ok     bool
+	}{
+		{"mainnet", chaincfg.MainNetParams(), 552448, true},
+		// Forced agenda: SVH 144 plus three 320-block windows.
+		{"simnet", chaincfg.SimNetParams(), 1104, true},
+		// Voted for real, so only dcrd or configuration can say.
+		{"testnet", chaincfg.TestNet3Params(), 0, false},
+	}
+	for _, test := range tests {
+		height, ok := treasuryActivationFromParams(test.params)
+		if height != test.height || ok != test.ok {
+			t.Errorf("%s: got %d, %v, want %d, %v", test.name, height, ok, test.height, test.ok)
+		}
+	}
+}
+
+// treasuryProbeDcrd answers gettreasurybalance as dcrd does for a chain whose
+// treasury activated at activation, failing with fail at the heights in it.
+type treasuryProbeDcrd struct {
+	fakeDcrd
+	activation int64
+	fail       map[int64]error
+}
+
+func (f *treasuryProbeDcrd) GetBlockChainInfo(ctx context.Context) (*chainjson.GetBlockChainInfoResult, error) {
+	return &chainjson.GetBlockChainInfoResult{Blocks: f.height}, nil
+}
+
+func (f *treasuryProbeDcrd) GetTreasuryBalance(ctx context.Context, block *chainhash.Hash, verbose bool) (*chainjson.GetTreasuryBalanceResult, error) {
+	var height int64
+	for h := int64(0); h <= f.height; h++ {
+		if *fakeBlockHash(h) == *block {
+			height = h
+			break
+		}
+	}
+	if err := f.fail[height]; err != nil {
+		return nil, err
+	}
+	if height < f.activation {
+		return nil, fmt.Errorf("-5: Treasury inactive for block %s", block)
+	}
+	return &chainjson.GetTreasuryBalanceResult{Height: height}, nil
+}
+
+func TestResolveTreasuryActivation(t *testing.T) {
+	f := &treasuryProbeDcrd{fakeDcrd: fakeDcrd{height: 4000}, activation: 1234}
+	saved := rpc.DcrdClient
+	rpc.DcrdClient = f
+	t.Cleanup(func() { rpc.DcrdClient = saved })
+
+	got, err := resolveTreasuryActivation(context.Background())
+	if err != nil || got != 1234 {
+		t.Fatalf("resolveTreasuryActivation = %d, %v; want 1234", got, err)
+	}
+
+	// A probe that fails for any other reason ends the search rather than
+	// reading as a block before activation. The search's first probe below
+	// the tip is at 2000.
+	timeout := errors.New("dcrd request timed out")
+	f.fail = map[int64]error{2000: timeout}
+	if got, err := resolveTreasuryActivation(context.Background()); !errors.Is(err, timeout) {
+		t.Errorf("resolveTreasuryActivation with a failed probe = %d, %v; want the failure", got, err)
+	}
+}
+
+func TestIsTreasuryInactive(t *testing.T) {
+	if !isTreasuryInactive(errors.New("-5: Treasury inactive for block 00ab")) {
+		t.Error("dcrd's treasury inactive reply not recognised")
+	}
+	for _, msg := range []string{"-5: Block not found: 00ab", "connection refused", "context deadline exceeded"} {
+		if isTreasuryInactive(errors.New(msg)) {
+			t.Errorf("%q taken for treasury inactive", msg)
+		}
+	}
+}
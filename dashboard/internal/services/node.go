@@ -12,6 +12,7 @@ import (
 	"math"
 	"net"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +20,9 @@ import (
 	"dcrpulse/internal/rpc"
 	"dcrpulse/internal/types"
 	"dcrpulse/internal/utils"
+
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -28,57 +32,104 @@ var (
 	syncMutex   sync.Mutex
 )
 
-func FetchDashboardData() (*types.DashboardData, error) {
-	nodeStatus, err := FetchNodeStatus()
-	if err != nil {
-		return nil, err
-	}
-
-	blockchainInfo, err := FetchBlockchainInfo()
-	if err != nil {
-		return nil, err
-	}
-
-	networkInfo, err := FetchNetworkInfo()
-	if err != nil {
-		return nil, err
-	}
-
-	peers, err := FetchPeers()
-	if err != nil {
-		return nil, err
-	}
-
-	supplyInfo, err := FetchSupplyInfo()
-	if err != nil {
-		return nil, err
+// dashboardTimeout bounds the whole dashboard aggregation; every section's
+// RPCs share it.
+const dashboardTimeout = 20 * time.Second
+
+// FetchDashboardData assembles the dashboard from its sections concurrently,
+// so it takes as long as the slowest section rather than their sum. A section
+// that fails is left zero-valued and named in the returned failures (section
+// -> error); an error is returned only when every section failed.
+func FetchDashboardData(ctx context.Context) (*types.DashboardData, map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dashboardTimeout)
+	defer cancel()
+
+	data := &types.DashboardData{Peers: []types.Peer{}}
+	var (
+		mu       sync.Mutex
+		failures = map[string]string{}
+		firstErr error
+		sections int
+	)
+	section := func(name string, fetch func() error) func() error {
+		sections++
+		return func() error {
+			if err := fetch(); err != nil {
+				log.Printf("Dashboard: %s unavailable: %v", name, err)
+				mu.Lock()
+				failures[name] = err.Error()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+			// Never fail the group: one section's error must not cancel the
+			// others' shared context.
+			return nil
+		}
 	}
 
-	stakingInfo, err := FetchStakingInfo()
-	if err != nil {
-		return nil, err
-	}
+	// Each closure writes only its own field of data, so they need no lock.
+	var g errgroup.Group
+	g.Go(section("nodeStatus", func() error {
+		v, err := FetchNodeStatus(ctx)
+		if err == nil {
+			data.NodeStatus = *v
+		}
+		return err
+	}))
+	g.Go(section("blockchainInfo", func() error {
+		v, err := FetchBlockchainInfo(ctx)
+		if err == nil {
+			data.BlockchainInfo = *v
+		}
+		return err
+	}))
+	g.Go(section("networkInfo", func() error {
+		v, err := FetchNetworkInfo(ctx)
+		if err == nil {
+			data.NetworkInfo = *v
+		}
+		return err
+	}))
+	g.Go(section("peers", func() error {
+		v, err := FetchPeers(ctx)
+		if err == nil {
+			data.Peers = v
+		}
+		return err
+	}))
+	g.Go(section("supplyInfo", func() error {
+		v, err := FetchSupplyInfo(ctx)
+		if err == nil {
+			data.SupplyInfo = *v
+		}
+		return err
+	}))
+	g.Go(section("stakingInfo", func() error {
+		v, err := FetchStakingInfo(ctx)
+		if err == nil {
+			data.StakingInfo = *v
+		}
+		return err
+	}))
+	g.Go(section("mempoolInfo", func() error {
+		v, err := FetchMempoolInfo(ctx)
+		if err == nil {
+			data.MempoolInfo = *v
+		}
+		return err
+	}))
+	g.Wait()
 
-	mempoolInfo, err := FetchMempoolInfo()
-	if err != nil {
-		return nil, err
+	if len(failures) == sections {
+		return nil, failures, firstErr
 	}
-
-	return &types.DashboardData{
-		NodeStatus:     *nodeStatus,
-		BlockchainInfo: *blockchainInfo,
-		NetworkInfo:    *networkInfo,
-		Peers:          peers,
-		SupplyInfo:     *supplyInfo,
-		StakingInfo:    *stakingInfo,
-		MempoolInfo:    *mempoolInfo,
-		LastUpdate:     time.Now(),
-	}, nil
+	data.LastUpdate = time.Now()
+	return data, failures, nil
 }
 
-func FetchNodeStatus() (*types.NodeStatus, error) {
-	ctx := context.Background()
-
+func FetchNodeStatus(ctx context.Context) (*types.NodeStatus, error) {
 	// Get version info using version command
 	versionInfo, err := rpc.DcrdClient.Version(ctx)
 	if err != nil {
@@ -164,17 +215,52 @@ func FetchNodeStatus() (*types.NodeStatus, error) {
 		syncProgress = 0
 	}
 
+	txIndexMissing, txIndexMessage := TxIndexMissing()
+	rpcInFlight, rpcQueued, rpcLimit := rpc.DcrdCallStats()
+
 	return &types.NodeStatus{
-		Status:       status,
-		SyncProgress: syncProgress,
-		Version:      fmt.Sprintf("v%d.%d.%d", versionInfo["dcrd"].Major, versionInfo["dcrd"].Minor, versionInfo["dcrd"].Patch),
-		SyncPhase:    syncPhase,
-		SyncMessage:  syncMessage,
+		Status:         status,
+		SyncProgress:   syncProgress,
+		Version:        fmt.Sprintf("v%d.%d.%d", versionInfo["dcrd"].Major, versionInfo["dcrd"].Minor, versionInfo["dcrd"].Patch),
+		SyncPhase:      syncPhase,
+		SyncMessage:    syncMessage,
+		TxIndexMissing: txIndexMissing,
+		TxIndexMessage: txIndexMessage,
+		RPCInFlight:    rpcInFlight,
+		RPCQueued:      rpcQueued,
+		RPCLimit:       rpcLimit,
+
+		NodeSyncing:          chainInfo.InitialBlockDownload,
+		VerificationProgress: chainInfo.VerificationProgress,
 	}, nil
 }
 
-func FetchBlockchainInfo() (*types.BlockchainInfo, error) {
-	ctx := context.Background()
+// FetchBlockchainTip returns the best block from its verbose header: two dcrd
+// calls, for clients polling for a new tip.
+func FetchBlockchainTip(ctx context.Context) (*types.BlockchainTip, error) {
+	hash, err := rpc.DcrdClient.GetBestBlockHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := rpc.DcrdClient.GetBlockHeaderVerbose(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BlockchainTip{
+		Height:     int64(header.Height),
+		Hash:       header.Hash,
+		Time:       header.Time,
+		MedianTime: header.MedianTime,
+	}, nil
+}
+
+// medianTimeBlocks is how many blocks ending at the tip the median time is
+// taken over, as in dcrd.
+const medianTimeBlocks = 11
+
+// FetchBlockchainInfo returns getblockchaininfo with the tip's recent blocks
+// and the fields derived from its headers.
+func FetchBlockchainInfo(ctx context.Context) (*types.BlockchainInfo, error) {
 	info, err := rpc.DcrdClient.GetBlockChainInfo(ctx)
 	if err != nil {
 		return nil, err
@@ -194,42 +280,125 @@ func FetchBlockchainInfo() (*types.BlockchainInfo, error) {
 	timeSinceBlock := time.Since(blockHeader.Timestamp)
 	blockTime := fmt.Sprintf("%dm %ds", int(timeSinceBlock.Minutes()), int(timeSinceBlock.Seconds())%60)
 
-	// Fetch last 3 blocks for the recent blocks list
+	// Walk back from the tip for the median time; the first 3 headers are
+	// the recent blocks list.
 	recentBlocks := make([]types.RecentBlock, 0, 3)
-	currentHeight := info.Blocks
-	for i := int64(0); i < 3 && currentHeight-i >= 0; i++ {
-		blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, currentHeight-i)
+	timestamps := []time.Time{blockHeader.Timestamp}
+	recentBlocks = append(recentBlocks, types.RecentBlock{
+		Height:    int64(blockHeader.Height),
+		Hash:      bestBlockHash.String(),
+		Timestamp: blockHeader.Timestamp.Unix(),
+	})
+	header := blockHeader
+	for len(timestamps) < medianTimeBlocks && header.Height > 0 {
+		prevHash := header.PrevBlock
+		header, err = rpc.DcrdClient.GetBlockHeader(ctx, &prevHash)
 		if err != nil {
-			log.Printf("Warning: Failed to get block hash for height %d: %v", currentHeight-i, err)
-			continue
+			log.Printf("Warning: Failed to get block header for hash %s: %v", prevHash.String(), err)
+			break
 		}
-
-		header, err := rpc.DcrdClient.GetBlockHeader(ctx, blockHash)
-		if err != nil {
-			log.Printf("Warning: Failed to get block header for hash %s: %v", blockHash.String(), err)
-			continue
+		timestamps = append(timestamps, header.Timestamp)
+		if len(recentBlocks) < 3 {
+			recentBlocks = append(recentBlocks, types.RecentBlock{
+				Height:    int64(header.Height),
+				Hash:      prevHash.String(),
+				Timestamp: header.Timestamp.Unix(),
+			})
 		}
-
-		recentBlocks = append(recentBlocks, types.RecentBlock{
-			Height:    currentHeight - i,
-			Hash:      blockHash.String(),
-			Timestamp: header.Timestamp.Unix(),
-		})
 	}
 
-	return &types.BlockchainInfo{
+	syncPercent := chainSyncPercent(info)
+	out := &types.BlockchainInfo{
 		BlockHeight:  info.Blocks,
 		BlockHash:    bestBlockHash.String(),
 		Difficulty:   float64(info.Difficulty),
 		ChainSize:    0, // Would need to calculate from disk usage
 		BlockTime:    blockTime,
 		RecentBlocks: recentBlocks,
-	}, nil
+
+		Chain:                info.Chain,
+		Headers:              info.Headers,
+		SyncHeight:           info.SyncHeight,
+		ChainWork:            info.ChainWork,
+		VerificationProgress: info.VerificationProgress,
+		InitialBlockDownload: info.InitialBlockDownload,
+
+		MedianTime:      medianTime(timestamps).Unix(),
+		SyncPercent:     syncPercent,
+		SyncPercentText: formatSyncPercent(syncPercent),
+	}
+
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		log.Printf("Warning: No chain params for difficulty adjustments: %v", err)
+		return out, nil
+	}
+	height := int64(blockHeader.Height)
+	// Since blake3pow (DCP-0011) the work difficulty is recalculated every
+	// block; before it, once per WorkDiffWindowSize blocks.
+	workWindow := params.WorkDiffWindowSize
+	if agenda, ok := info.Deployments["blake3pow"]; ok && agenda.Status == chainjson.AgendaInfoStatusActive {
+		workWindow = 1
+	}
+	out.NextWorkDiffBlocks = blocksToRetarget(height, workWindow)
+	out.NextWorkDiffSeconds = secondsToBlocks(out.NextWorkDiffBlocks, params.TargetTimePerBlock, timeSinceBlock)
+	out.NextStakeDiffBlocks = blocksToRetarget(height, params.StakeDiffWindowSize)
+	out.NextStakeDiffSeconds = secondsToBlocks(out.NextStakeDiffBlocks, params.TargetTimePerBlock, timeSinceBlock)
+	return out, nil
 }
 
-func FetchNetworkInfo() (*types.NetworkInfo, error) {
-	ctx := context.Background()
+// medianTime returns the median of timestamps, as dcrd computes the median
+// time past: the middle of the sorted list, the later one for an even count.
+func medianTime(timestamps []time.Time) time.Time {
+	if len(timestamps) == 0 {
+		return time.Time{}
+	}
+	sorted := append([]time.Time(nil), timestamps...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	return sorted[len(sorted)/2]
+}
 
+// blocksToRetarget returns how many blocks after height the next block whose
+// height is a multiple of window is: the first block mined at a new
+// difficulty.
+func blocksToRetarget(height, window int64) int64 {
+	if window <= 1 {
+		return 1
+	}
+	return window - height%window
+}
+
+// secondsToBlocks estimates the seconds until blocks more blocks are mined at
+// one per target, sinceTip having passed since the tip.
+func secondsToBlocks(blocks int64, target, sinceTip time.Duration) int64 {
+	return max(int64((time.Duration(blocks)*target - sinceTip).Seconds()), 0)
+}
+
+// chainSyncPercent is how far dcrd is through its initial block download, as
+// a percentage.
+func chainSyncPercent(info *chainjson.GetBlockChainInfoResult) float64 {
+	if !info.InitialBlockDownload {
+		return 100
+	}
+	var pct float64
+	if info.VerificationProgress > 0 {
+		pct = info.VerificationProgress * 100
+	} else if info.SyncHeight > 0 {
+		pct = float64(info.Blocks) / float64(info.SyncHeight) * 100
+	}
+	return math.Min(pct, 100)
+}
+
+// formatSyncPercent renders a sync percentage for display, without
+// rounding a chain that is nearly synced up to 100%.
+func formatSyncPercent(pct float64) string {
+	if pct >= 100 {
+		return "100%"
+	}
+	return fmt.Sprintf("%.2f%%", math.Floor(pct*100)/100)
+}
+
+func FetchNetworkInfo(ctx context.Context) (*types.NetworkInfo, error) {
 	// Get peer count
 	peerCount := 0
 	peerInfo, err := rpc.DcrdClient.GetPeerInfo(ctx)
@@ -257,8 +426,7 @@ func FetchNetworkInfo() (*types.NetworkInfo, error) {
 	}, nil
 }
 
-func FetchPeers() ([]types.Peer, error) {
-	ctx := context.Background()
+func FetchPeers(ctx context.Context) ([]types.Peer, error) {
 	peerInfo, err := rpc.DcrdClient.GetPeerInfo(ctx)
 	if err != nil {
 		return nil, err
@@ -315,6 +483,11 @@ func FetchPeers() ([]types.Peer, error) {
 			IsSyncNode: p.SyncNode,
 			Inbound:    p.Inbound,
 			Tor:        tor,
+
+			PingMs:      p.PingTime / 1000,
+			BytesSent:   p.BytesSent,
+			BytesRecv:   p.BytesRecv,
+			ConnectedAt: p.ConnTime,
 		})
 	}
 
@@ -323,9 +496,7 @@ func FetchPeers() ([]types.Peer, error) {
 
 // formatDuration formats a duration in seconds to a human-readable string
 
-func FetchSupplyInfo() (*types.SupplyInfo, error) {
-	ctx := context.Background()
-
+func FetchSupplyInfo(ctx context.Context) (*types.SupplyInfo, error) {
 	// Get real circulating supply from dcrd - direct RPC method
 	circulatingSupply := "N/A"
 	stakedSupply := "N/A"
@@ -361,8 +532,8 @@ func FetchSupplyInfo() (*types.SupplyInfo, error) {
 	// Pass nil for hash (gets latest) and false for verbose
 	treasuryBalanceResult, err := rpc.DcrdClient.GetTreasuryBalance(ctx, nil, false)
 	if err == nil && treasuryBalanceResult.Balance > 0 {
-		// Balance is in atoms (uint64), convert to DCR by dividing by 1e8
-		treasuryBalanceDCR := float64(treasuryBalanceResult.Balance) / 1e8
+		// Balance is in atoms (uint64)
+		treasuryBalanceDCR := atomsToCoin(int64(treasuryBalanceResult.Balance))
 		// Format with 2 decimal places and commas
 		treasuryBalance = utils.FormatDCRAmountWithDecimals(treasuryBalanceDCR, 2)
 	}
@@ -377,9 +548,7 @@ func FetchSupplyInfo() (*types.SupplyInfo, error) {
 	}, nil
 }
 
-func FetchStakingInfo() (*types.StakingInfo, error) {
-	ctx := context.Background()
-
+func FetchStakingInfo(ctx context.Context) (*types.StakingInfo, error) {
 	// Check if node is fully synced before calling TicketPoolValue
 	chainInfo, err := rpc.DcrdClient.GetBlockChainInfo(ctx)
 	isSynced := err == nil && !chainInfo.InitialBlockDownload
@@ -459,9 +628,7 @@ func FetchStakingInfo() (*types.StakingInfo, error) {
 	}, nil
 }
 
-func FetchMempoolInfo() (*types.MempoolInfo, error) {
-	ctx := context.Background()
-
+func FetchMempoolInfo(ctx context.Context) (*types.MempoolInfo, error) {
 	// Use getmempoolinfo RPC to get actual mempool statistics
 	result, err := rpc.DcrdClient.RawRequest(ctx, "getmempoolinfo", []json.RawMessage{})
 	if err != nil {
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+	"dcrpulse/internal/utils"
+
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+// FetchNodeInfo reports the software dcrd runs and its connectivity, from
+// version and getnetworkinfo, along with dcrpulse's own build. dcrd has no
+// uptime call, so its uptime is bounded below by its longest-lived peer
+// connection, and left out when it has no peers.
+func FetchNodeInfo(ctx context.Context) (*types.NodeInfo, error) {
+	versions, err := rpc.DcrdClient.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getnetworkinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network info: %w", err)
+	}
+	var netInfo chainjson.GetNetworkInfoResult
+	if err := json.Unmarshal(res, &netInfo); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network info: %w", err)
+	}
+
+	info := &types.NodeInfo{
+		Version:         semverString(versions["dcrd"]),
+		JSONRPCVersion:  semverString(versions["dcrdjsonrpcapi"]),
+		UserAgent:       netInfo.SubVersion,
+		ProtocolVersion: netInfo.ProtocolVersion,
+		Connections:     netInfo.Connections,
+		RelayFee:        netInfo.RelayFee,
+		TimeOffset:      netInfo.TimeOffset,
+		LocalServices:   netInfo.LocalServices,
+		Dcrpulse:        dcrpulseBuild(),
+	}
+	// The uptime is only a nicety; a failed peer list doesn't fail the rest.
+	if peers, err := rpc.DcrdClient.GetPeerInfo(ctx); err == nil {
+		if since := minNodeUptime(peers, time.Now().Unix()); since > 0 {
+			info.MinUptimeSeconds = since
+			info.MinUptime = utils.FormatDuration(since)
+		}
+	}
+	return info, nil
+}
+
+// semverString formats a version result as dcrd prints it, e.g.
+// "2.0.6-pre+release", or "" for a missing one.
+func semverString(v chainjson.VersionResult) string {
+	if v.VersionString != "" {
+		return v.VersionString
+	}
+	if v == (chainjson.VersionResult{}) {
+		return ""
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.BuildMetadata != "" {
+		s += "+" + v.BuildMetadata
+	}
+	return s
+}
+
+// minNodeUptime returns how long, as of now, dcrd has been up at least: the
+// age of its oldest peer connection, or 0 with no peers.
+func minNodeUptime(peers []chainjson.GetPeerInfoResult, now int64) int64 {
+	var oldest int64
+	for _, p := range peers {
+		if p.ConnTime > 0 && (oldest == 0 || p.ConnTime < oldest) {
+			oldest = p.ConnTime
+		}
+	}
+	if oldest == 0 || oldest > now {
+		return 0
+	}
+	return now - oldest
+}
+
+// dcrpulseBuild reads this binary's version and VCS stamp from its embedded
+// build info.
+func dcrpulseBuild() types.BuildInfo {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return types.BuildInfo{Version: "unknown"}
+	}
+	return buildInfoFrom(bi)
+}
+
+// buildInfoFrom extracts the module version, Go version and VCS settings of
+// bi. A "go build" of a checkout has version "(devel)" and carries the commit
+// instead.
+func buildInfoFrom(bi *debug.BuildInfo) types.BuildInfo {
+	b := types.BuildInfo{
+		Version:   strings.TrimSpace(bi.Main.Version),
+		GoVersion: bi.GoVersion,
+	}
+	if b.Version == "" {
+		b.Version = "unknown"
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			b.Commit = s.Value
+		case "vcs.time":
+			b.CommitTime = s.Value
+		case "vcs.modified":
+			b.Modified = s.Value == "true"
+		}
+	}
+	return b
+}
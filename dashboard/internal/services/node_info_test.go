@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"runtime/debug"
+	"testing"
+
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+func TestMinNodeUptime(t *testing.T) {
+	peers := []chainjson.GetPeerInfoResult{{ConnTime: 900}, {ConnTime: 400}, {ConnTime: 0}}
+	if got := minNodeUptime(peers, 1000); got != 600 {
+		t.Errorf("minNodeUptime = %d, want 600", got)
+	}
+	if got := minNodeUptime(nil, 1000); got != 0 {
+		t.Errorf("minNodeUptime without peers = %d, want 0", got)
+	}
+}
+
+func TestSemverString(t *testing.T) {
+	v := chainjson.VersionResult{Major: 2, Minor: 0, Patch: 6, Prerelease: "pre", BuildMetadata: "release"}
+	if got := semverString(v); got != "2.0.6-pre+release" {
+		t.Errorf("semverString = %q", got)
+	}
+	if got := semverString(chainjson.VersionResult{}); got != "" {
+		t.Errorf("semverString of nothing = %q, want empty", got)
+	}
+}
+
+func TestBuildInfoFrom(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.23.4",
+		Main:      debug.Module{Version: "(devel)"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	b := buildInfoFrom(bi)
+	if b.Version != "(devel)" || b.Commit != "abc123" || b.CommitTime == "" || !b.Modified || b.GoVersion != "go1.23.4" {
+		t.Errorf("buildInfoFrom = %+v", b)
+	}
+}
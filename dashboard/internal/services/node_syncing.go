@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNodeSyncing is returned for a historical scan refused because dcrd is
+// still in initial block download and its chain is incomplete.
+var ErrNodeSyncing = errors.New("dcrd is still syncing; a scan now would cover an incomplete chain")
+
+var (
+	nodeSyncGateMu sync.RWMutex
+	// scanRequiresSync refuses historical scans while dcrd is syncing.
+	scanRequiresSync bool
+)
+
+// ConfigureScanRequiresSync sets whether a historical treasury scan is
+// refused while dcrd is still syncing. Off by default: the scan then runs
+// against the chain dcrd has so far.
+func ConfigureScanRequiresSync(require bool) {
+	nodeSyncGateMu.Lock()
+	defer nodeSyncGateMu.Unlock()
+	scanRequiresSync = require
+}
+
+// NodeSyncing reports whether dcrd was in initial block download at the last
+// node sync refresh. It reads the cached snapshot, so it costs no RPC, and is
+// false until a refresh has succeeded.
+func NodeSyncing() bool {
+	return GetNodeSyncSnapshot().Status == "syncing"
+}
+
+// checkScanNodeSynced returns ErrNodeSyncing when scans wait for a synced
+// node and dcrd is not.
+func checkScanNodeSynced() error {
+	nodeSyncGateMu.RLock()
+	require := scanRequiresSync
+	nodeSyncGateMu.RUnlock()
+	if require && NodeSyncing() {
+		return ErrNodeSyncing
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckScanNodeSynced(t *testing.T) {
+	nodeSyncMu.Lock()
+	saved := nodeSyncSnap
+	nodeSyncMu.Unlock()
+	t.Cleanup(func() {
+		nodeSyncMu.Lock()
+		nodeSyncSnap = saved
+		nodeSyncMu.Unlock()
+		ConfigureScanRequiresSync(false)
+	})
+	setStatus := func(status string) {
+		nodeSyncMu.Lock()
+		nodeSyncSnap = NodeSyncSnapshot{Status: status}
+		nodeSyncMu.Unlock()
+	}
+
+	setStatus("syncing")
+	if err := checkScanNodeSynced(); err != nil {
+		t.Errorf("not required: %v", err)
+	}
+	ConfigureScanRequiresSync(true)
+	if err := checkScanNodeSynced(); !errors.Is(err, ErrNodeSyncing) {
+		t.Errorf("syncing: err = %v, want ErrNodeSyncing", err)
+	}
+	setStatus("running")
+	if err := checkScanNodeSynced(); err != nil {
+		t.Errorf("synced: %v", err)
+	}
+	// No refresh yet: the node isn't known to be syncing.
+	setStatus("")
+	if err := checkScanNodeSynced(); err != nil {
+		t.Errorf("unknown: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMedianTime(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	var stamps []time.Time
+	// Out of order, as block timestamps may be.
+	for _, off := range []int64{50, 10, 40, 20, 30} {
+		stamps = append(stamps, base.Add(time.Duration(off)*time.Second))
+	}
+	if got := medianTime(stamps); !got.Equal(base.Add(30 * time.Second)) {
+		t.Errorf("odd count: got %v", got.Sub(base))
+	}
+	stamps = append(stamps, base.Add(60*time.Second))
+	if got := medianTime(stamps); !got.Equal(base.Add(40 * time.Second)) {
+		t.Errorf("even count: got %v", got.Sub(base))
+	}
+	if !medianTime(nil).IsZero() {
+		t.Error("no timestamps: want zero time")
+	}
+}
+
+func TestDifficultyRetarget(t *testing.T) {
+	tests := []struct {
+		height, window, want int64
+	}{
+		{height: 1000, window: 144, want: 8}, // next at 1008
+		{height: 1151, window: 144, want: 1},
+		{height: 1152, window: 144, want: 144},
+		{height: 1152, window: 1, want: 1},
+	}
+	for _, tc := range tests {
+		if got := blocksToRetarget(tc.height, tc.window); got != tc.want {
+			t.Errorf("blocksToRetarget(%d, %d) = %d, want %d", tc.height, tc.window, got, tc.want)
+		}
+	}
+
+	if got := secondsToBlocks(2, 5*time.Minute, 90*time.Second); got != 510 {
+		t.Errorf("secondsToBlocks: got %d, want 510", got)
+	}
+	if got := secondsToBlocks(1, 5*time.Minute, 10*time.Minute); got != 0 {
+		t.Errorf("overdue block: got %d, want 0", got)
+	}
+}
+
+func TestFormatSyncPercent(t *testing.T) {
+	tests := map[float64]string{
+		100:    "100%",
+		99.999: "99.99%",
+		42.5:   "42.50%",
+		0:      "0.00%",
+	}
+	for pct, want := range tests {
+		if got := formatSyncPercent(pct); got != want {
+			t.Errorf("formatSyncPercent(%v) = %q, want %q", pct, got, want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// OverviewCacheTTL is how long one overview is served to every poller
+	// before the next request rebuilds it.
+	OverviewCacheTTL = 2 * time.Second
+	// overviewTimeout bounds a rebuild; a section still waiting on its
+	// backend by then is left out.
+	overviewTimeout = 5 * time.Second
+)
+
+var (
+	overviewMu   sync.Mutex
+	overviewData *types.Overview
+)
+
+// FetchOverview returns the at-a-glance summary: chain height, sync and peers
+// from dcrd, sync state and balance from dcrwallet, and the treasury balance
+// with the number of tspends voting in mempool. Sections are fetched
+// concurrently and one whose client is not connected or whose RPCs fail is
+// omitted. The result is cached for OverviewCacheTTL, and concurrent callers
+// on a stale cache wait for a single rebuild.
+func FetchOverview(ctx context.Context) *types.Overview {
+	overviewMu.Lock()
+	defer overviewMu.Unlock()
+
+	if overviewData != nil && time.Since(overviewData.LastUpdate) < OverviewCacheTTL {
+		return overviewData
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, overviewTimeout)
+	defer cancel()
+
+	data := &types.Overview{}
+	section := func(name string, fetch func() error) func() error {
+		return func() error {
+			if err := fetch(); err != nil {
+				log.Printf("Overview: %s unavailable: %v", name, err)
+			}
+			return nil
+		}
+	}
+
+	// Each closure writes only its own field of data, so they need no lock.
+	var g errgroup.Group
+	if rpc.DcrdClient != nil {
+		g.Go(section("node", func() error {
+			v, err := fetchOverviewNode(ctx)
+			data.Node = v
+			return err
+		}))
+		g.Go(section("treasury", func() error {
+			v, err := fetchOverviewTreasury(ctx)
+			data.Treasury = v
+			return err
+		}))
+	}
+	if rpc.WalletClient != nil {
+		g.Go(section("wallet", func() error {
+			v, err := fetchOverviewWallet(ctx)
+			data.Wallet = v
+			return err
+		}))
+	}
+	g.Wait()
+
+	data.LastUpdate = time.Now()
+	overviewData = data
+	return data
+}
+
+func fetchOverviewNode(ctx context.Context) (*types.OverviewNode, error) {
+	info, err := rpc.DcrdClient.GetBlockChainInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain info: %w", err)
+	}
+	peers, err := rpc.DcrdClient.GetConnectionCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection count: %w", err)
+	}
+
+	// Same measure as FetchNodeStatus, without its sync-rate bookkeeping.
+	return &types.OverviewNode{
+		Height:      info.Blocks,
+		SyncPercent: chainSyncPercent(info),
+		PeerCount:   peers,
+	}, nil
+}
+
+func fetchOverviewTreasury(ctx context.Context) (*types.OverviewTreasury, error) {
+	balance, err := getTreasuryBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The mempool scan is cached for mempoolTSpendRefresh, so polling the
+	// overview does not rescan mempool every time.
+	tspends, err := GetMempoolTSpends(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan mempool for tspends: %w", err)
+	}
+	return &types.OverviewTreasury{
+		BalanceAtoms:  balance,
+		BalanceDCR:    formatDCR(balance),
+		ActiveTSpends: len(tspends),
+	}, nil
+}
+
+// fetchOverviewWallet reports the wallet synced when it is connected to dcrd
+// and its sync has completed, the same test FetchWalletStatus applies.
+func fetchOverviewWallet(ctx context.Context) (*types.OverviewWallet, error) {
+	raw, err := rpc.WalletClient.RawRequest(ctx, "getbalance", []json.RawMessage{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	var balance struct {
+		CumulativeTotal float64 `json:"cumulativetotal"`
+	}
+	if err := json.Unmarshal(raw, &balance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal balance: %w", err)
+	}
+
+	snap := GetSyncSnapshot()
+	connected := snap.DaemonConnected
+	if !connected {
+		if raw, err := rpc.WalletClient.RawRequest(ctx, "walletinfo", nil); err == nil {
+			var wi struct {
+				DaemonConnected bool `json:"daemonconnected"`
+			}
+			if json.Unmarshal(raw, &wi) == nil {
+				connected = wi.DaemonConnected
+			}
+		}
+	}
+	return &types.OverviewWallet{
+		Synced:       connected && snap.Phase == SyncPhaseSynced,
+		TotalBalance: balance.CumulativeTotal,
+	}, nil
+}
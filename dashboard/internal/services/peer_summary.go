@@ -0,0 +1,165 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+	"dcrpulse/internal/utils"
+
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// FetchNetworkSummary aggregates dcrd's peer list: how many peers run each
+// dcrd release and protocol version, the bandwidth of inbound and outbound
+// peers, and how many advertise each service flag.
+func FetchNetworkSummary(ctx context.Context) (*types.NetworkSummary, error) {
+	peers, err := rpc.DcrdClient.GetPeerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary := summarizePeers(peers)
+	return &summary, nil
+}
+
+// summarizePeers computes the network summary of peers. Version buckets are
+// newest first, as are protocol versions; service flags keep wire's order.
+func summarizePeers(peers []chainjson.GetPeerInfoResult) types.NetworkSummary {
+	s := types.NetworkSummary{
+		PeerCount: len(peers),
+		Versions:  []types.PeerVersionBucket{},
+		Protocols: []types.PeerProtocolBucket{},
+		Services:  []types.PeerServiceCount{},
+	}
+	versions := map[string]*types.PeerVersionBucket{}
+	protocols := map[uint32]int{}
+	services := map[wire.ServiceFlag]int{}
+	for _, p := range peers {
+		bw := &s.Outbound
+		if p.Inbound {
+			bw = &s.Inbound
+		}
+		bw.Peers++
+		bw.BytesSent += p.BytesSent
+		bw.BytesRecv += p.BytesRecv
+
+		version := utils.ExtractDcrdVersion(p.SubVer)
+		b, ok := versions[version]
+		if !ok {
+			b = &types.PeerVersionBucket{Version: version, UserAgents: []string{}}
+			versions[version] = b
+		}
+		b.Count++
+		if !containsString(b.UserAgents, p.SubVer) {
+			b.UserAgents = append(b.UserAgents, p.SubVer)
+		}
+		protocols[p.Version]++
+
+		// dcrd formats the flags as a zero-padded decimal number.
+		if flags, err := strconv.ParseUint(strings.TrimSpace(p.Services), 10, 64); err == nil {
+			for bit := wire.ServiceFlag(1); bit != 0; bit <<= 1 {
+				if wire.ServiceFlag(flags)&bit != 0 {
+					services[bit]++
+				}
+			}
+		}
+	}
+
+	for _, b := range versions {
+		sort.Strings(b.UserAgents)
+		if s.PeerCount > 0 {
+			b.Percent = float64(b.Count) / float64(s.PeerCount) * 100
+		}
+		s.Versions = append(s.Versions, *b)
+	}
+	sort.Slice(s.Versions, func(i, j int) bool {
+		if c := compareDcrdVersions(s.Versions[i].Version, s.Versions[j].Version); c != 0 {
+			return c > 0
+		}
+		return s.Versions[i].Version < s.Versions[j].Version
+	})
+	for _, b := range s.Versions {
+		if b.Version == "unknown" {
+			continue
+		}
+		s.LatestVersion, s.LatestCount = b.Version, b.Count
+		if s.PeerCount > 0 {
+			s.LatestPercent = b.Percent
+		}
+		break
+	}
+
+	for v, n := range protocols {
+		s.Protocols = append(s.Protocols, types.PeerProtocolBucket{Version: v, Count: n})
+	}
+	sort.Slice(s.Protocols, func(i, j int) bool { return s.Protocols[i].Version > s.Protocols[j].Version })
+
+	flags := make([]wire.ServiceFlag, 0, len(services))
+	for f := range services {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i] < flags[j] })
+	for _, f := range flags {
+		s.Services = append(s.Services, types.PeerServiceCount{Flag: f.String(), Count: services[f]})
+	}
+	return s
+}
+
+// compareDcrdVersions orders two dcrd release versions such as "2.0.5" or
+// "2.1.0-pre" by their numeric parts, returning -1, 0 or 1. A pre-release
+// sorts before its release; "unknown" sorts before any version.
+func compareDcrdVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "unknown" {
+		return -1
+	}
+	if b == "unknown" {
+		return 1
+	}
+	aCore, aPre, _ := strings.Cut(strings.SplitN(a, "+", 2)[0], "-")
+	bCore, bPre, _ := strings.Cut(strings.SplitN(b, "+", 2)[0], "-")
+	aParts, bParts := strings.Split(aCore, "."), strings.Split(bCore, ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return strings.Compare(aPre, bPre)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
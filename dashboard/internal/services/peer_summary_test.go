@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"dcrpulse/internal/types"
+
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+func TestSummarizePeers(t *testing.T) {
+	peers := []chainjson.GetPeerInfoResult{
+		{SubVer: "/dcrwire:1.0.0/dcrd:2.0.5/", Version: 11, Services: "00000005", Inbound: true, BytesSent: 10, BytesRecv: 20},
+		{SubVer: "/dcrwire:1.0.0/dcrd:2.1.0/", Version: 12, Services: "00000005", BytesSent: 1, BytesRecv: 2},
+		{SubVer: "/dcrwire:1.0.0/dcrd:2.1.0/", Version: 12, Services: "00000001", BytesSent: 3, BytesRecv: 4},
+		{SubVer: "/dcrwire:1.0.0/dcrd:2.0.10/", Version: 11, Services: "00000001", Inbound: true},
+		{SubVer: "/other:1.0/", Version: 10, Services: "bogus"},
+	}
+	s := summarizePeers(peers)
+	if s.PeerCount != 5 || s.LatestVersion != "2.1.0" || s.LatestCount != 2 || s.LatestPercent != 40 {
+		t.Errorf("latest %s: %d of %d (%.0f%%)", s.LatestVersion, s.LatestCount, s.PeerCount, s.LatestPercent)
+	}
+	var order []string
+	for _, b := range s.Versions {
+		order = append(order, b.Version)
+	}
+	if want := []string{"2.1.0", "2.0.10", "2.0.5", "unknown"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("versions %v, want %v", order, want)
+	}
+	if s.Inbound != (types.PeerBandwidth{Peers: 2, BytesSent: 10, BytesRecv: 20}) ||
+		s.Outbound != (types.PeerBandwidth{Peers: 3, BytesSent: 4, BytesRecv: 6}) {
+		t.Errorf("inbound %+v, outbound %+v", s.Inbound, s.Outbound)
+	}
+	if want := []types.PeerProtocolBucket{{Version: 12, Count: 2}, {Version: 11, Count: 2}, {Version: 10, Count: 1}}; !reflect.DeepEqual(s.Protocols, want) {
+		t.Errorf("protocols %+v", s.Protocols)
+	}
+	if want := []types.PeerServiceCount{{Flag: "SFNodeNetwork", Count: 4}, {Flag: "SFNodeCF", Count: 2}}; !reflect.DeepEqual(s.Services, want) {
+		t.Errorf("services %+v", s.Services)
+	}
+}
+
+func TestCompareDcrdVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.0.10", "2.0.9", 1},
+		{"2.1.0-pre", "2.1.0", -1},
+		{"2.1.0+dev", "2.1.0", 0},
+		{"unknown", "1.0.0", -1},
+		{"1.8", "1.8.0", 0},
+	}
+	for _, test := range tests {
+		if got := compareDcrdVersions(test.a, test.b); got != test.want {
+			t.Errorf("compare(%s, %s) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -27,8 +27,11 @@ import (
 	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
 )
 
+// politeiaBaseURL is the Politeia API root. ConfigureProposalLinks can point
+// it at another instance.
+var politeiaBaseURL = "https://proposals.decred.org/api"
+
 const (
-	politeiaBaseURL          = "https://proposals.decred.org/api"
 	politeiaTimeout          = 30 * time.Second
 	politeiaCastTimeout      = 60 * time.Second
 	politeiaSignMessagesChunk = 100
@@ -530,10 +533,6 @@ func PrepareProposalVote(ctx context.Context, token string) (*types.VoteEligibil
 		return out, nil
 	}
 
-	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC unavailable")
-	}
-
 	ctx, cancel := context.WithTimeout(ctx, ProposalsFetchTimeout)
 	defer cancel()
 
@@ -730,7 +729,7 @@ func buildSignedVotes(ctx context.Context, token, voteOption string, passphrase
 		return nil, nil, ErrPoliteiaDisabled
 	}
 	if rpc.WalletGrpcClient == nil {
-		return nil, nil, fmt.Errorf("wallet gRPC unavailable")
+		return nil, nil, rpc.NotConnected("wallet gRPC unavailable")
 	}
 
 	// Reuse the owned-ticket set + options computed when the user opened the
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// progressSignal wakes long-poll waiters when progress state changes. It is
+// a condition variable that can also give up when a context ends: broadcast
+// closes the channel every current waiter is blocked on.
+type progressSignal struct {
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+// broadcast wakes every waiter.
+func (s *progressSignal) broadcast() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.changed != nil {
+		close(s.changed)
+		s.changed = nil
+	}
+}
+
+// waitUntil blocks until ready reports true, re-checking after every
+// broadcast, or until ctx is done. It reports whether ready was met.
+func (s *progressSignal) waitUntil(ctx context.Context, ready func() bool) bool {
+	for {
+		// Take the channel before checking, so a broadcast between the
+		// check and the select is not missed.
+		s.mu.Lock()
+		if s.changed == nil {
+			s.changed = make(chan struct{})
+		}
+		ch := s.changed
+		s.mu.Unlock()
+
+		if ready() {
+			return true
+		}
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProgressSignal(t *testing.T) {
+	var s progressSignal
+	var version atomic.Uint64
+
+	// Already ready: returns without a broadcast.
+	if !s.waitUntil(context.Background(), func() bool { return true }) {
+		t.Fatal("ready condition: got false")
+	}
+
+	// Woken by a broadcast after the change.
+	done := make(chan bool)
+	go func() {
+		done <- s.waitUntil(context.Background(), func() bool { return version.Load() != 0 })
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.broadcast() // spurious: the condition still fails
+	version.Store(1)
+	s.broadcast()
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Fatal("after broadcast: got false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter not woken by broadcast")
+	}
+
+	// Gives up when the context ends.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if s.waitUntil(ctx, func() bool { return false }) {
+		t.Fatal("timed out wait: got true")
+	}
+}
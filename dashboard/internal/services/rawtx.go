@@ -0,0 +1,239 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"dcrpulse/internal/rpc"
+)
+
+// ErrTxUnavailable is returned when dcrd has no information about a
+// transaction and does not run with --txindex. Such a node only knows mempool
+// transactions, so every confirmed transaction outside a known block is
+// unavailable.
+var ErrTxUnavailable = errors.New("transaction not available from dcrd: it is " +
+	"neither in the mempool nor in the transaction index (confirmed " +
+	"transactions require dcrd to run with --txindex)")
+
+// ErrTxNotFound is returned when dcrd runs with --txindex and has no
+// information about a transaction, so it is neither mined nor in the mempool.
+var ErrTxNotFound = errors.New("transaction not found")
+
+// ErrInvalidHash is returned for a block or transaction hash that is not 64
+// hex characters.
+var ErrInvalidHash = errors.New("invalid hash: expected 64 hex characters")
+
+// ErrBlockNotFound is returned when dcrd does not know a block hash.
+var ErrBlockNotFound = errors.New("block not found")
+
+// txIndexMissing is set once dcrd proves to run without a transaction index:
+// either it says so, or a transaction it could not look up was found in its
+// block. It is cleared when a confirmed lookup succeeds. txIndexEnabled is
+// getinfo's txindex flag, read once per dcrd connection to tell a lookup miss
+// on an indexed node (an unknown txid) from one on an unindexed node.
+var (
+	txIndexMu      sync.RWMutex
+	txIndexMissing bool
+	txIndexEnabled *bool
+)
+
+// TxIndexMissing reports whether dcrd has been observed running without
+// --txindex, and if so a message explaining what is affected.
+func TxIndexMissing() (bool, string) {
+	txIndexMu.RLock()
+	defer txIndexMu.RUnlock()
+	if !txIndexMissing {
+		return false, ""
+	}
+	return true, "dcrd is running without --txindex: explorer and treasury " +
+		"lookups of confirmed transactions are incomplete. Restart dcrd " +
+		"with --txindex to enable them."
+}
+
+func setTxIndexMissing(missing bool) {
+	txIndexMu.Lock()
+	txIndexMissing = missing
+	txIndexMu.Unlock()
+}
+
+// dcrdHasTxIndex reports whether dcrd runs with --txindex. The answer is
+// cached until forgetTxIndex.
+func dcrdHasTxIndex(ctx context.Context) (bool, error) {
+	txIndexMu.RLock()
+	known := txIndexEnabled
+	txIndexMu.RUnlock()
+	if known != nil {
+		return *known, nil
+	}
+
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getinfo", nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get info: %w", err)
+	}
+	var info struct {
+		TxIndex bool `json:"txindex"`
+	}
+	if err := json.Unmarshal(res, &info); err != nil {
+		return false, fmt.Errorf("failed to unmarshal getinfo: %w", err)
+	}
+	txIndexMu.Lock()
+	txIndexEnabled = &info.TxIndex
+	if !info.TxIndex {
+		txIndexMissing = true
+	}
+	txIndexMu.Unlock()
+	return info.TxIndex, nil
+}
+
+// forgetTxIndex drops the cached txindex flag, for when dcrd is reconfigured
+// at runtime.
+func forgetTxIndex() {
+	txIndexMu.Lock()
+	txIndexEnabled = nil
+	txIndexMu.Unlock()
+}
+
+// isTxLookupMiss reports whether err is dcrd's "no information" or
+// txindex-disabled reply to getrawtransaction.
+func isTxLookupMiss(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "No information available about transaction") ||
+		strings.Contains(msg, "txindex")
+}
+
+// fetchRawTransaction returns getrawtransaction's verbose result for txHash.
+// A lookup miss is reported as ErrTxNotFound when dcrd has a transaction
+// index, and as ErrTxUnavailable otherwise or when that can't be told.
+func fetchRawTransaction(ctx context.Context, txHash string) (json.RawMessage, error) {
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
+		jsonStr(txHash),
+		json.RawMessage("1"), // verbose
+	})
+	if err != nil {
+		if !isTxLookupMiss(err) {
+			return nil, err
+		}
+		if strings.Contains(err.Error(), "txindex") {
+			setTxIndexMissing(true)
+		} else if indexed, ierr := dcrdHasTxIndex(ctx); ierr == nil && indexed {
+			return nil, fmt.Errorf("%s: %w", txHash, ErrTxNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", txHash, ErrTxUnavailable)
+	}
+
+	var confirmed struct {
+		BlockHash string `json:"blockhash"`
+	}
+	if json.Unmarshal(result, &confirmed) == nil && confirmed.BlockHash != "" {
+		setTxIndexMissing(false)
+	}
+	return result, nil
+}
+
+// fetchRawTransactionInBlock is fetchRawTransaction for a transaction known to
+// be mined in blockHash. When dcrd can't look the transaction up directly, it
+// is read from the verbose block instead, which works without --txindex.
+func fetchRawTransactionInBlock(ctx context.Context, txHash, blockHash string) (json.RawMessage, error) {
+	result, err := fetchRawTransaction(ctx, txHash)
+	if !errors.Is(err, ErrTxUnavailable) || blockHash == "" {
+		return result, err
+	}
+
+	blockTxs, berr := fetchBlockRawTxs(ctx, blockHash)
+	if berr != nil {
+		return nil, fmt.Errorf("%w (block fallback: %v)", err, berr)
+	}
+	tx, ok := blockTxs[txHash]
+	if !ok {
+		return nil, err
+	}
+	setTxIndexMissing(true)
+	return tx, nil
+}
+
+// fetchBlockRawTxs returns every regular and stake transaction of blockHash in
+// getrawtransaction's verbose form, keyed by txid.
+func fetchBlockRawTxs(ctx context.Context, blockHash string) (map[string]json.RawMessage, error) {
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(blockHash),
+		json.RawMessage("true"), // verbose
+		json.RawMessage("true"), // verbosetx
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	var block struct {
+		RawTx  []json.RawMessage `json:"rawtx"`
+		RawSTx []json.RawMessage `json:"rawstx"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+
+	txs := make(map[string]json.RawMessage, len(block.RawTx)+len(block.RawSTx))
+	for _, raw := range append(block.RawTx, block.RawSTx...) {
+		var id struct {
+			Txid string `json:"txid"`
+		}
+		if json.Unmarshal(raw, &id) == nil && id.Txid != "" {
+			txs[id.Txid] = raw
+		}
+	}
+	return txs, nil
+}
+
+// FetchRawTransactionHex returns the serialized transaction as hex, as
+// getrawtransaction reports it with verbose=0. Like fetchRawTransaction it
+// needs --txindex for confirmed transactions.
+func FetchRawTransactionHex(ctx context.Context, txHash string) (string, error) {
+	if len(txHash) != 64 || !isHex(txHash) {
+		return "", ErrInvalidHash
+	}
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
+		jsonStr(txHash),
+		json.RawMessage("0"), // verbose
+	})
+	if err != nil {
+		if isTxLookupMiss(err) {
+			return "", fmt.Errorf("%s: %w", txHash, ErrTxUnavailable)
+		}
+		return "", err
+	}
+	var txHex string
+	if err := json.Unmarshal(result, &txHex); err != nil {
+		return "", fmt.Errorf("failed to unmarshal transaction: %w", err)
+	}
+	return txHex, nil
+}
+
+// FetchRawBlockHex returns the serialized block as hex, as getblock reports
+// it with verbose=false.
+func FetchRawBlockHex(ctx context.Context, blockHash string) (string, error) {
+	if len(blockHash) != 64 || !isHex(blockHash) {
+		return "", ErrInvalidHash
+	}
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(blockHash),
+		json.RawMessage("false"), // verbose
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "", fmt.Errorf("%s: %w", blockHash, ErrBlockNotFound)
+		}
+		return "", err
+	}
+	var blockHex string
+	if err := json.Unmarshal(result, &blockHex); err != nil {
+		return "", fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+	return blockHex, nil
+}
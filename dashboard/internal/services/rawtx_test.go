@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFetchRawTransactionMiss(t *testing.T) {
+	txHash := strings.Repeat("ab", 32)
+	noInfo := errors.New("-5: No information available about transaction " + txHash)
+	for _, c := range []struct {
+		name    string
+		txindex string
+		want    error
+	}{
+		{"indexed", `{"txindex":true}`, ErrTxNotFound},
+		{"unindexed", `{"txindex":false}`, ErrTxUnavailable},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			forgetTxIndex()
+			t.Cleanup(func() {
+				forgetTxIndex()
+				setTxIndexMissing(false)
+			})
+			infoCalls := 0
+			useFakeDcrd(t, &fakeDcrd{raw: map[string]func([]json.RawMessage) (json.RawMessage, error){
+				"getrawtransaction": func([]json.RawMessage) (json.RawMessage, error) {
+					return nil, noInfo
+				},
+				"getinfo": func([]json.RawMessage) (json.RawMessage, error) {
+					infoCalls++
+					return json.RawMessage(c.txindex), nil
+				},
+			}})
+
+			for i := 0; i < 2; i++ {
+				if _, err := fetchRawTransaction(context.Background(), txHash); !errors.Is(err, c.want) {
+					t.Fatalf("fetchRawTransaction = %v, want %v", err, c.want)
+				}
+			}
+			if infoCalls != 1 {
+				t.Errorf("getinfo called %d times, want once", infoCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	rootCtxMu sync.RWMutex
+	rootCtx   = context.Background()
+)
+
+// SetRootContext sets the server-lifetime context that background jobs (the
+// historical TSpend scan, async vote counts) derive from, so cancelling it on
+// shutdown stops them.
+func SetRootContext(ctx context.Context) {
+	rootCtxMu.Lock()
+	rootCtx = ctx
+	rootCtxMu.Unlock()
+}
+
+// RootContext returns the context set by SetRootContext, or
+// context.Background() before it is set.
+func RootContext() context.Context {
+	rootCtxMu.RLock()
+	defer rootCtxMu.RUnlock()
+	return rootCtx
+}
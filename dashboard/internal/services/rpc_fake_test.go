@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"dcrpulse/internal/rpc"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// fakeDcrd serves dcrd calls from fixture data: a chain of height blocks
+// whose hashes come from fakeBlockHash, and raw handlers keyed by method.
+// Calls it doesn't implement panic on the nil embedded DcrdRPC.
+type fakeDcrd struct {
+	rpc.DcrdRPC
+	height int64
+	raw    map[string]func(params []json.RawMessage) (json.RawMessage, error)
+}
+
+// useFakeDcrd makes f the dcrd client for the rest of the test.
+func useFakeDcrd(t *testing.T, f *fakeDcrd) {
+	t.Helper()
+	saved := rpc.DcrdClient
+	rpc.DcrdClient = f
+	t.Cleanup(func() { rpc.DcrdClient = saved })
+}
+
+// fakeBlockHash is the fixture chain's hash of the block at height.
+func fakeBlockHash(height int64) *chainhash.Hash {
+	var h chainhash.Hash
+	h[0], h[1], h[2] = byte(height), byte(height>>8), byte(height>>16)
+	return &h
+}
+
+func (f *fakeDcrd) GetBlockCount(ctx context.Context) (int64, error) {
+	return f.height, nil
+}
+
+func (f *fakeDcrd) GetBlockHash(ctx context.Context, height int64) (*chainhash.Hash, error) {
+	if height < 0 || height > f.height {
+		return nil, fmt.Errorf("block height %d out of range", height)
+	}
+	return fakeBlockHash(height), nil
+}
+
+func (f *fakeDcrd) RawRequest(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	handle, ok := f.raw[method]
+	if !ok {
+		return nil, fmt.Errorf("fake dcrd: unexpected %s", method)
+	}
+	return handle(params)
+}
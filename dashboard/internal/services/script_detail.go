@@ -0,0 +1,158 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+)
+
+// Script classes, the coarse kind of an output script shown next to dcrd's
+// script type.
+const (
+	ScriptClassP2PKH            = "p2pkh"
+	ScriptClassP2PK             = "p2pk"
+	ScriptClassP2SH             = "p2sh"
+	ScriptClassMultisig         = "multisig"
+	ScriptClassNullData         = "nulldata"
+	ScriptClassTicket           = "ticket"
+	ScriptClassTicketCommitment = "ticket-commitment"
+	ScriptClassTicketChange     = "ticket-change"
+	ScriptClassVoteReward       = "vote-reward"
+	ScriptClassRevocation       = "revocation"
+	ScriptClassTreasuryAdd      = "treasury-add"
+	ScriptClassTreasuryGen      = "treasury-gen"
+	ScriptClassNonStandard      = "nonstandard"
+)
+
+// Null data payload kinds.
+const (
+	NullDataTreasuryVotes = "treasury-votes"
+	NullDataText          = "text"
+	NullDataBinary        = "binary"
+)
+
+// classifyScriptType maps a dcrd script type to its class and a readable
+// label. dcrd names stake and treasury scripts by what they pay to, as in
+// "stakegen-pubkeyhash" or "treasurygen-scripthash", and older releases used
+// the bare "stakesubmission" and "sstxchange"; both forms are taken. The
+// signature algorithm of a non-ECDSA pubkey script is added to its label.
+func classifyScriptType(scriptType string) (class, label string) {
+	base, payTo, _ := strings.Cut(scriptType, "-")
+	switch base {
+	case "pubkeyhash":
+		class, label = ScriptClassP2PKH, "Pay to pubkey hash"
+	case "pubkey":
+		class, label = ScriptClassP2PK, "Pay to pubkey"
+	case "scripthash":
+		class, label = ScriptClassP2SH, "Pay to script hash"
+	case "multisig":
+		class, label = ScriptClassMultisig, "Bare multisig"
+	case "nulldata":
+		class, label = ScriptClassNullData, "Null data (OP_RETURN)"
+	case "stakesubmission":
+		class, label = ScriptClassTicket, "Ticket submission"
+	case "sstxcommitment":
+		class, label = ScriptClassTicketCommitment, "Ticket commitment"
+	case "stakechange", "sstxchange":
+		class, label = ScriptClassTicketChange, "Ticket change"
+	case "stakegen":
+		class, label = ScriptClassVoteReward, "Vote reward"
+	case "stakerevoke":
+		class, label = ScriptClassRevocation, "Revocation"
+	case "treasuryadd":
+		class, label = ScriptClassTreasuryAdd, "Treasury add"
+	case "treasurygen":
+		class, label = ScriptClassTreasuryGen, "Treasury spend payout"
+	default:
+		return ScriptClassNonStandard, "Non-standard"
+	}
+	switch {
+	case payTo == "ed25519":
+		label += " (Ed25519)"
+	case strings.HasPrefix(payTo, "schnorr"):
+		label += " (Schnorr)"
+	case payTo == "pubkeyhash":
+		label += " to pubkey hash"
+	case payTo == "scripthash":
+		label += " to script hash"
+	}
+	return class, label
+}
+
+// decodeNullData returns the data an OP_RETURN script carries, or nil when
+// scriptHex isn't one. The pushes after OP_RETURN are joined; a treasury vote
+// payload is recognised, and one that is printable UTF-8 is also returned as
+// text.
+func decodeNullData(scriptHex string) *types.NullDataPayload {
+	script, err := hex.DecodeString(scriptHex)
+	if err != nil || len(script) == 0 || script[0] != txscript.OP_RETURN {
+		return nil
+	}
+	var data []byte
+	tokenizer := txscript.MakeScriptTokenizer(0, script[1:])
+	for tokenizer.Next() {
+		data = append(data, tokenizer.Data()...)
+	}
+	if tokenizer.Err() != nil {
+		return nil
+	}
+	payload := &types.NullDataPayload{Hex: hex.EncodeToString(data), Size: len(data), Kind: NullDataBinary}
+	switch {
+	case decodeTreasuryVotes(script) != nil:
+		payload.Kind = NullDataTreasuryVotes
+	case len(data) > 0 && printableText(data):
+		payload.Kind = NullDataText
+		payload.Text = string(data)
+	}
+	return payload
+}
+
+// printableText reports whether b is valid UTF-8 made only of printable
+// characters and spaces.
+func printableText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// describeScript fills the class, label and null data payload of s from its
+// dcrd type and hex.
+func describeScript(s *types.Script) {
+	s.Class, s.Label = classifyScriptType(s.Type)
+	if s.Class == ScriptClassNullData {
+		s.NullData = decodeNullData(s.Hex)
+	}
+}
+
+// addressScriptType returns the type of the script paying to address on the
+// active network, as dcrd names it, or "" when it doesn't decode.
+func addressScriptType(ctx context.Context, address string) string {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return ""
+	}
+	addr, err := stdaddr.DecodeAddress(address, params)
+	if err != nil {
+		return ""
+	}
+	version, script := addr.PaymentScript()
+	return stdscript.DetermineScriptType(version, script).String()
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestClassifyScriptType(t *testing.T) {
+	tests := []struct {
+		scriptType, class, label string
+	}{
+		{"pubkeyhash", ScriptClassP2PKH, "Pay to pubkey hash"},
+		{"pubkeyhash-schnorr-secp256k1", ScriptClassP2PKH, "Pay to pubkey hash (Schnorr)"},
+		{"pubkey-ed25519", ScriptClassP2PK, "Pay to pubkey (Ed25519)"},
+		{"scripthash", ScriptClassP2SH, "Pay to script hash"},
+		{"stakesubmission", ScriptClassTicket, "Ticket submission"},
+		{"stakegen-scripthash", ScriptClassVoteReward, "Vote reward to script hash"},
+		{"treasurygen-pubkeyhash", ScriptClassTreasuryGen, "Treasury spend payout to pubkey hash"},
+		{"treasuryadd", ScriptClassTreasuryAdd, "Treasury add"},
+		{"sstxcommitment", ScriptClassTicketCommitment, "Ticket commitment"},
+		{"", ScriptClassNonStandard, "Non-standard"},
+	}
+	for _, test := range tests {
+		class, label := classifyScriptType(test.scriptType)
+		if class != test.class || label != test.label {
+			t.Errorf("%q: got %s %q, want %s %q", test.scriptType, class, label, test.class, test.label)
+		}
+	}
+}
+
+func TestDecodeNullData(t *testing.T) {
+	// OP_RETURN OP_DATA_5 "hello"
+	text := "6a05" + hex.EncodeToString([]byte("hello"))
+	if p := decodeNullData(text); p == nil || p.Kind != NullDataText || p.Text != "hello" || p.Size != 5 {
+		t.Errorf("text payload = %+v", p)
+	}
+
+	// OP_RETURN OP_DATA_35 "TV" <hash> yes
+	votes := "6a23" + hex.EncodeToString([]byte("TV")) + strings.Repeat("ab", 32) + "01"
+	if p := decodeNullData(votes); p == nil || p.Kind != NullDataTreasuryVotes || p.Text != "" {
+		t.Errorf("treasury vote payload = %+v", p)
+	}
+
+	if p := decodeNullData("6a0400ff0102"); p == nil || p.Kind != NullDataBinary || p.Hex != "00ff0102" {
+		t.Errorf("binary payload = %+v", p)
+	}
+	// A bare OP_RETURN carries nothing.
+	if p := decodeNullData("6a"); p == nil || p.Size != 0 || p.Kind != NullDataBinary {
+		t.Errorf("empty payload = %+v", p)
+	}
+	for _, s := range []string{"76a914", "6a05aa", "zz"} {
+		if p := decodeNullData(s); p != nil {
+			t.Errorf("%s: got %+v, want nil", s, p)
+		}
+	}
+}
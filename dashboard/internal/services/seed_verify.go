@@ -0,0 +1,137 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+// pendingSeedTTL is how long a generated seed stays available for backup
+// verification if no wallet is created from it.
+const pendingSeedTTL = 30 * time.Minute
+
+// seedVerifyMaxFailures is how many failed VerifySeed checks a pending seed
+// survives. Each failure names the wrong positions, so unlimited retries
+// would let a caller recover the seed word by word; after this many the seed
+// is dropped and a new one must be generated.
+const seedVerifyMaxFailures = 3
+
+// pendingSeed is the mnemonic of the last seed GenerateSeed returned, held in
+// memory only so the user can prove they wrote it down before the wallet is
+// created. It is dropped on wallet creation, on expiry, after
+// seedVerifyMaxFailures failed checks, or when a new seed is generated, and is
+// never persisted or logged.
+var (
+	pendingSeedMu       sync.Mutex
+	pendingSeedWords    []string
+	pendingSeedHex      string
+	pendingSeedExpires  time.Time
+	pendingSeedFailures int
+)
+
+// VerifySeed errors. ErrNoPendingSeed means there is no generated seed to
+// check against (none generated, expired, or already used); ErrInvalidSeedCheck
+// is a malformed request.
+var (
+	ErrNoPendingSeed    = errors.New("no generated seed to verify against; generate a new seed")
+	ErrInvalidSeedCheck = errors.New("invalid seed check")
+)
+
+func setPendingSeed(mnemonic, seedHex string) {
+	pendingSeedMu.Lock()
+	defer pendingSeedMu.Unlock()
+	pendingSeedWords = strings.Fields(mnemonic)
+	pendingSeedHex = seedHex
+	pendingSeedExpires = time.Now().Add(pendingSeedTTL)
+	pendingSeedFailures = 0
+}
+
+func clearPendingSeed() {
+	pendingSeedMu.Lock()
+	defer pendingSeedMu.Unlock()
+	clearPendingSeedLocked()
+}
+
+func clearPendingSeedLocked() {
+	pendingSeedWords = nil
+	pendingSeedHex = ""
+	pendingSeedExpires = time.Time{}
+	pendingSeedFailures = 0
+}
+
+// isPendingSeed reports whether seed is the one GenerateSeed just returned,
+// i.e. a brand-new seed with no history on chain.
+func isPendingSeed(seed []byte) bool {
+	pendingSeedMu.Lock()
+	defer pendingSeedMu.Unlock()
+	if pendingSeedHex == "" || time.Now().After(pendingSeedExpires) {
+		return false
+	}
+	return strings.EqualFold(hex.EncodeToString(seed), pendingSeedHex)
+}
+
+// VerifySeed checks the user's re-entered words against the generated seed.
+// req carries either the full mnemonic or individual words by 0-based index;
+// the response lists the indices that are wrong (including, for a full
+// mnemonic, missing or extra positions) without echoing any words. A failed
+// check uses up one of seedVerifyMaxFailures attempts; the last one drops the
+// pending seed.
+func VerifySeed(req types.VerifySeedRequest) (*types.VerifySeedResponse, error) {
+	pendingSeedMu.Lock()
+	defer pendingSeedMu.Unlock()
+	if pendingSeedWords != nil && time.Now().After(pendingSeedExpires) {
+		clearPendingSeedLocked()
+	}
+	want := pendingSeedWords
+	if want == nil {
+		return nil, ErrNoPendingSeed
+	}
+
+	resp := &types.VerifySeedResponse{WrongIndices: []int{}, WordCount: len(want)}
+	if strings.TrimSpace(req.Mnemonic) != "" {
+		got := strings.Fields(req.Mnemonic)
+		n := len(want)
+		if len(got) > n {
+			n = len(got)
+		}
+		for i := 0; i < n; i++ {
+			if i >= len(want) || i >= len(got) || !strings.EqualFold(got[i], want[i]) {
+				resp.WrongIndices = append(resp.WrongIndices, i)
+			}
+		}
+		resp.Checked = len(want)
+	} else {
+		if len(req.Words) == 0 {
+			return nil, fmt.Errorf("%w: mnemonic or words required", ErrInvalidSeedCheck)
+		}
+		for _, w := range req.Words {
+			if w.Index < 0 || w.Index >= len(want) {
+				return nil, fmt.Errorf("%w: word index %d out of range (0-%d)", ErrInvalidSeedCheck, w.Index, len(want)-1)
+			}
+			if !strings.EqualFold(strings.TrimSpace(w.Word), want[w.Index]) {
+				resp.WrongIndices = append(resp.WrongIndices, w.Index)
+			}
+		}
+		resp.Checked = len(req.Words)
+	}
+	resp.Valid = len(resp.WrongIndices) == 0
+	if !resp.Valid {
+		pendingSeedFailures++
+		if pendingSeedFailures >= seedVerifyMaxFailures {
+			clearPendingSeedLocked()
+		}
+	}
+	if pendingSeedWords != nil {
+		resp.AttemptsLeft = seedVerifyMaxFailures - pendingSeedFailures
+	}
+	return resp, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestVerifySeedAttemptLimit(t *testing.T) {
+	t.Cleanup(clearPendingSeed)
+	setPendingSeed("alpha bravo charlie", "00")
+	wrong := types.VerifySeedRequest{Words: []types.SeedWordCheck{{Index: 1, Word: "delta"}}}
+
+	for left := seedVerifyMaxFailures - 1; left > 0; left-- {
+		resp, err := VerifySeed(wrong)
+		if err != nil {
+			t.Fatalf("VerifySeed: %v", err)
+		}
+		if resp.Valid || resp.AttemptsLeft != left {
+			t.Fatalf("failed check = valid %v, %d left; want false, %d", resp.Valid, resp.AttemptsLeft, left)
+		}
+	}
+
+	// A passing check does not use up an attempt.
+	resp, err := VerifySeed(types.VerifySeedRequest{Mnemonic: "Alpha bravo charlie"})
+	if err != nil || !resp.Valid || resp.AttemptsLeft != 1 {
+		t.Fatalf("passing check = %+v, %v; want valid with 1 left", resp, err)
+	}
+
+	// The last allowed failure drops the seed.
+	resp, err = VerifySeed(wrong)
+	if err != nil || resp.AttemptsLeft != 0 {
+		t.Fatalf("last failure = %+v, %v; want 0 left", resp, err)
+	}
+	if _, err := VerifySeed(wrong); !errors.Is(err, ErrNoPendingSeed) {
+		t.Errorf("check after the limit = %v, want ErrNoPendingSeed", err)
+	}
+	if isPendingSeed([]byte{0}) {
+		t.Error("dropped seed still reported as pending")
+	}
+
+	// A new seed starts with a fresh allowance.
+	setPendingSeed("alpha bravo charlie", "00")
+	if resp, err := VerifySeed(wrong); err != nil || resp.AttemptsLeft != seedVerifyMaxFailures-1 {
+		t.Errorf("first failure on a new seed = %+v, %v", resp, err)
+	}
+}
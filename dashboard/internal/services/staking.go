@@ -24,7 +24,6 @@ import (
 
 	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
 	"github.com/decred/dcrd/chaincfg/chainhash"
-	"github.com/decred/dcrd/chaincfg/v3"
 )
 
 const (
@@ -455,9 +454,6 @@ func recordPurchaseEvent(level, msg string) {
 // frontend over the purchase-events WebSocket. The passphrase is copied because
 // the goroutine outlives the request and the caller zeroes its own slice.
 func StartPurchaseWorker(account, numTickets uint32, vspHost, vspPubkey string, changeAccount uint32, passphrase []byte) error {
-	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC client not initialized")
-	}
 	if numTickets == 0 {
 		return fmt.Errorf("numTickets must be > 0")
 	}
@@ -528,7 +524,7 @@ func PurchaseTickets(ctx context.Context, account, numTickets uint32, vspHost, v
 // caller owns the single-flight guard (see tryBeginTicketPurchase).
 func purchaseTicketsCore(ctx context.Context, account, numTickets uint32, vspHost, vspPubkey string, changeAccount uint32, passphrase []byte) (*types.PurchaseTicketsResponse, error) {
 	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	if numTickets == 0 {
 		return nil, fmt.Errorf("numTickets must be > 0")
@@ -625,7 +621,7 @@ func purchaseTicketsCore(ctx context.Context, account, numTickets uint32, vspHos
 // still returned, just without a FeeStatus value.
 func ListTickets(ctx context.Context) ([]types.TicketRecord, error) {
 	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 
 	stream, err := rpc.WalletGrpcClient.GetTickets(ctx, &pb.GetTicketsRequest{})
@@ -675,21 +671,10 @@ func ListTickets(ctx context.Context) ([]types.TicketRecord, error) {
 // blocks a ticket must age before it becomes live), or 0 if the network can't
 // be resolved so callers can skip the annotation.
 func currentTicketMaturity(ctx context.Context) int32 {
-	network, err := CurrentNetwork(ctx)
+	params, err := CurrentChainParams(ctx)
 	if err != nil {
 		return 0
 	}
-	var params *chaincfg.Params
-	switch network {
-	case "mainnet":
-		params = chaincfg.MainNetParams()
-	case "testnet":
-		params = chaincfg.TestNet3Params()
-	case "simnet":
-		params = chaincfg.SimNetParams()
-	default:
-		return 0
-	}
 	return int32(params.TicketMaturity)
 }
 
@@ -814,9 +799,6 @@ func countFeeStatuses(m map[string]string) types.VSPFeeStatusCounts {
 // pattern from PurchaseTickets. The SyncVSPFailedTickets RPC returns no data,
 // so progress is reported via before/after fee-status snapshots.
 func SyncFailedVSPTickets(ctx context.Context, vspHost, vspPubkey string, account, changeAccount uint32, passphrase []byte) (*types.SyncFailedVSPTicketsResponse, error) {
-	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
-	}
 	if vspHost == "" || vspPubkey == "" {
 		return nil, fmt.Errorf("vspHost and vspPubkey are required")
 	}
@@ -868,9 +850,6 @@ func SyncFailedVSPTickets(ctx context.Context, vspHost, vspPubkey string, accoun
 // genuinely-unpaid ones, while tickets the VSP does not recognize are skipped.
 // Mirrors Decrediton's processUnmanagedTickets (one user-selected VSP per run).
 func ProcessUnmanagedVSPTickets(ctx context.Context, vspHost, vspPubkey string, account, changeAccount uint32, passphrase []byte) (*types.SyncFailedVSPTicketsResponse, error) {
-	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
-	}
 	if vspHost == "" || vspPubkey == "" {
 		return nil, fmt.Errorf("vspHost and vspPubkey are required")
 	}
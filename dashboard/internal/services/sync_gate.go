@@ -20,6 +20,8 @@ var (
 
 	syncCancelMu sync.Mutex
 	syncCancelFn context.CancelFunc
+
+	syncKick = make(chan struct{}, 1)
 )
 
 // PauseSync marks a wallet switch as owning the RpcSync slot and cancels any
@@ -46,3 +48,16 @@ func RegisterSyncCancel(cancel context.CancelFunc) {
 	syncCancelFn = cancel
 	syncCancelMu.Unlock()
 }
+
+// KickSync asks the supervisor to reconnect RpcSync now instead of waiting
+// out its retry backoff.
+func KickSync() {
+	select {
+	case syncKick <- struct{}{}:
+	default:
+	}
+}
+
+// SyncKicked is signalled by KickSync; the supervisor selects on it while
+// backing off.
+func SyncKicked() <-chan struct{} { return syncKick }
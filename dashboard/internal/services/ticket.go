@@ -0,0 +1,386 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// ErrNotTicket is returned by FetchTicketLifecycle when the hash resolves to a
+// transaction that is not a ticket purchase.
+var ErrNotTicket = errors.New("transaction is not a ticket purchase")
+
+// ticketSpendScanLimit caps how many blocks after maturity FetchTicketLifecycle
+// reads looking for the vote or revocation that spent a ticket the wallet
+// doesn't know about. dcrd has no spend index, so each is a verbose getblock.
+// A live ticket is picked with the same small chance every block, so a vote
+// is likeliest early in the ticket's life; past these blocks the spender is
+// reported unknown rather than searched for over the whole expiry window.
+const ticketSpendScanLimit = 256
+
+// FetchTicketLifecycle assembles a ticket's purchase, maturity, expiry and
+// vote/revocation stages. The spending transaction is resolved from the
+// wallet when the ticket is one of ours, and otherwise from the blocks it is
+// likeliest in; see findTicketSpender.
+func FetchTicketLifecycle(ctx context.Context, ticketHash string) (*types.TicketLifecycle, error) {
+	hash, err := chainhash.NewHashFromStr(ticketHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket hash: %w", err)
+	}
+	tx, err := getTransaction(ctx, hash.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if !isTicketPurchase(tx) {
+		return nil, ErrNotTicket
+	}
+
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+
+	lc := &types.TicketLifecycle{
+		TicketHash: hash.String(),
+		Price:      ticketPrice(tx),
+	}
+	purchaseHeight := int64(mapFloat(tx, "blockheight"))
+	if purchaseHeight <= 0 {
+		lc.Status = "unmined"
+		return lc, nil
+	}
+	blockHash, _ := tx["blockhash"].(string)
+	lc.Purchase = types.TicketStage{
+		Height:    purchaseHeight,
+		Time:      int64(mapFloat(tx, "blocktime")),
+		BlockHash: blockHash,
+		TxHash:    hash.String(),
+		Reached:   true,
+	}
+
+	maturityHeight := purchaseHeight + int64(params.TicketMaturity)
+	expiryHeight := maturityHeight + int64(params.TicketExpiry)
+	lc.Maturity = types.TicketStage{Height: maturityHeight, Reached: tip >= maturityHeight}
+	lc.Expiry = types.TicketStage{Height: expiryHeight, Reached: tip >= expiryHeight}
+	if lc.Maturity.Reached {
+		lc.Maturity.Time = blockTimeAt(ctx, maturityHeight)
+	}
+	if lc.Expiry.Reached {
+		lc.Expiry.Time = blockTimeAt(ctx, expiryHeight)
+	}
+
+	// An unspent stake-tree output 0 means the ticket has not voted or been
+	// revoked yet.
+	out, err := rpc.DcrdClient.GetTxOut(ctx, hash, 0, 1, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket output: %w", err)
+	}
+	if out != nil {
+		switch {
+		case !lc.Maturity.Reached:
+			lc.Status = "immature"
+		case lc.Expiry.Reached:
+			lc.Status = "expired"
+		default:
+			lc.Status = "live"
+		}
+		return lc, nil
+	}
+
+	spenderHash := walletTicketSpender(ctx, hash)
+	var spender map[string]interface{}
+	if spenderHash != "" {
+		if spender, err = getTransaction(ctx, spenderHash); err != nil {
+			log.Printf("Warning: Failed to get spender %s of ticket %s: %v", spenderHash, hash, err)
+		}
+	} else {
+		spender = findTicketSpender(ctx, hash.String(), maturityHeight+1, expiryHeight+1, tip)
+	}
+	if spender == nil {
+		lc.Status = "unknown"
+		lc.SpenderHash = spenderHash
+		lc.Note = fmt.Sprintf("ticket is spent but its vote or revocation was not found within %d blocks of maturity or where an expired ticket is revoked; dcrd has no spend index to look further", ticketSpendScanLimit)
+		return lc, nil
+	}
+
+	spentHash, _ := spender["txid"].(string)
+	spentBlock, _ := spender["blockhash"].(string)
+	stage := types.TicketStage{
+		Height:    int64(mapFloat(spender, "blockheight")),
+		Time:      int64(mapFloat(spender, "blocktime")),
+		BlockHash: spentBlock,
+		TxHash:    spentHash,
+		Reached:   true,
+	}
+	lc.SpenderHash = spentHash
+	if isVoteTransaction(spender) {
+		lc.Status = "voted"
+		vote := &types.TicketVote{TicketStage: stage}
+		vote.VotedBlockHash, vote.VotedBlockHeight = voteBlockRef(spender)
+		vote.VoteBits, vote.VoteVersion = voteBitsAndVersion(spender)
+		lc.Vote = vote
+		return lc, nil
+	}
+	lc.Revocation = &stage
+	lc.Status = revocationStatus(stage.Height, expiryHeight)
+	return lc, nil
+}
+
+// revocationStatus classifies a ticket revoked at height: revoked at or after
+// its expiry height it expired unselected; revoked earlier it was selected
+// and missed its vote.
+func revocationStatus(height, expiryHeight int64) string {
+	if height >= expiryHeight {
+		return "expired"
+	}
+	return "missed"
+}
+
+// isTicketPurchase reports whether tx is a ticket purchase (SStx), whose first
+// output is the stake submission.
+func isTicketPurchase(tx map[string]interface{}) bool {
+	return outputScriptType(tx, 0) == "stakesubmission"
+}
+
+// isRevocation reports whether tx is a ticket revocation (SSRtx).
+func isRevocation(tx map[string]interface{}) bool {
+	vout, _ := tx["vout"].([]interface{})
+	for i := range vout {
+		if outputScriptType(tx, i) == "stakerevoke" {
+			return true
+		}
+	}
+	return false
+}
+
+// outputScriptType returns the scriptPubKey type of vout[i], or "".
+func outputScriptType(tx map[string]interface{}, i int) string {
+	vout, ok := tx["vout"].([]interface{})
+	if !ok || i >= len(vout) {
+		return ""
+	}
+	out, _ := vout[i].(map[string]interface{})
+	spk, _ := out["scriptPubKey"].(map[string]interface{})
+	t, _ := spk["type"].(string)
+	return t
+}
+
+// outputScriptHex returns the scriptPubKey hex of vout[i], or "".
+func outputScriptHex(tx map[string]interface{}, i int) string {
+	vout, ok := tx["vout"].([]interface{})
+	if !ok || i >= len(vout) {
+		return ""
+	}
+	out, _ := vout[i].(map[string]interface{})
+	spk, _ := out["scriptPubKey"].(map[string]interface{})
+	h, _ := spk["hex"].(string)
+	return h
+}
+
+// inputPrevTxid returns the previous txid spent by vin[i], or "".
+func inputPrevTxid(tx map[string]interface{}, i int) string {
+	vin, ok := tx["vin"].([]interface{})
+	if !ok || i >= len(vin) {
+		return ""
+	}
+	in, _ := vin[i].(map[string]interface{})
+	txid, _ := in["txid"].(string)
+	return txid
+}
+
+func mapFloat(m map[string]interface{}, key string) float64 {
+	v, _ := m[key].(float64)
+	return v
+}
+
+func ticketPrice(tx map[string]interface{}) float64 {
+	vout, _ := tx["vout"].([]interface{})
+	if len(vout) == 0 {
+		return 0
+	}
+	out, _ := vout[0].(map[string]interface{})
+	v, _ := out["value"].(float64)
+	return v
+}
+
+// voteBlockRef decodes an SSGen's first output, OP_RETURN <32-byte block hash>
+// <4-byte LE height>, into the block the vote approves.
+func voteBlockRef(tx map[string]interface{}) (string, int64) {
+	script, err := hex.DecodeString(outputScriptHex(tx, 0))
+	// 0x6a OP_RETURN, 0x24 push 36 bytes.
+	if err != nil || len(script) != 38 || script[0] != 0x6a || script[1] != 0x24 {
+		return "", 0
+	}
+	var h chainhash.Hash
+	copy(h[:], script[2:34])
+	return h.String(), int64(binary.LittleEndian.Uint32(script[34:38]))
+}
+
+// voteBitsAndVersion decodes an SSGen's second output, OP_RETURN <2-byte LE
+// vote bits> [<4-byte LE vote version>].
+func voteBitsAndVersion(tx map[string]interface{}) (uint16, uint32) {
+	script, err := hex.DecodeString(outputScriptHex(tx, 1))
+	if err != nil || len(script) < 4 || script[0] != 0x6a {
+		return 0, 0
+	}
+	data := script[2:]
+	if int(script[1]) != len(data) || len(data) < 2 {
+		return 0, 0
+	}
+	bits := binary.LittleEndian.Uint16(data[:2])
+	var version uint32
+	if len(data) >= 6 {
+		version = binary.LittleEndian.Uint32(data[2:6])
+	}
+	return bits, version
+}
+
+// blockTimeAt returns the timestamp of the block at height, or 0.
+func blockTimeAt(ctx context.Context, height int64) int64 {
+	hash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return 0
+	}
+	hdr, err := rpc.DcrdClient.GetBlockHeader(ctx, hash)
+	if err != nil {
+		return 0
+	}
+	return hdr.Timestamp.Unix()
+}
+
+// walletTicketSpender returns the spending transaction hash for a ticket the
+// loaded wallet tracks, or "" when there is no wallet or it isn't ours.
+func walletTicketSpender(ctx context.Context, hash *chainhash.Hash) string {
+	if rpc.WalletGrpcClient == nil {
+		return ""
+	}
+	resp, err := rpc.WalletGrpcClient.GetTicket(ctx, &pb.GetTicketRequest{TicketHash: hash[:]})
+	if err != nil {
+		return ""
+	}
+	s := resp.GetTicket().GetSpender()
+	if s == nil {
+		return ""
+	}
+	h, err := chainhash.NewHash(s.GetHash())
+	if err != nil {
+		return ""
+	}
+	return h.String()
+}
+
+// findTicketSpender looks for the vote (which spends the ticket in vin[1]) or
+// the revocation (vin[0]) in the blocks it is likeliest in: the block right
+// after expiry, where an expired ticket's automatic revocation lands, a block
+// the vote history has already parsed a vote of the ticket in, and then the
+// first ticketSpendScanLimit blocks from startHeight, read concurrently like
+// a vote count's. It returns nil when none of them has it.
+func findTicketSpender(ctx context.Context, ticketHash string, startHeight, expiryRevokeHeight, tip int64) map[string]interface{} {
+	if expiryRevokeHeight <= tip {
+		if tx := ticketSpenderInBlock(ctx, ticketHash, expiryRevokeHeight); tx != nil {
+			return tx
+		}
+	}
+	if h, ok := cachedTicketVoteHeight(ticketHash); ok {
+		if tx := ticketSpenderInBlock(ctx, ticketHash, h); tx != nil {
+			return tx
+		}
+	}
+	end := startHeight + ticketSpendScanLimit - 1
+	if end > tip {
+		end = tip
+	}
+	heights := make([]int64, 0, max(end-startHeight+1, 0))
+	for h := startHeight; h <= end; h++ {
+		heights = append(heights, h)
+	}
+	var found map[string]interface{}
+	fetchHeights(ctx, heights, voteScanWorkers, voteScanBatch,
+		func(ctx context.Context, h int64) (map[string]interface{}, error) {
+			return ticketSpenderInBlock(ctx, ticketHash, h), nil
+		},
+		func(_ int64, tx map[string]interface{}, _ error) bool {
+			found = tx
+			return tx == nil
+		})
+	return found
+}
+
+// cachedTicketVoteHeight returns the height of a block in the vote history's
+// cache holding a vote of ticketHash.
+func cachedTicketVoteHeight(ticketHash string) (int64, bool) {
+	voteBlockCacheMu.Lock()
+	defer voteBlockCacheMu.Unlock()
+	for _, votes := range voteBlockCache {
+		for _, v := range votes {
+			if strings.EqualFold(v.record.TicketHash, ticketHash) {
+				return v.record.BlockHeight, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func ticketSpenderInBlock(ctx context.Context, ticketHash string, height int64) map[string]interface{} {
+	blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return nil
+	}
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(blockHash.String()),
+		json.RawMessage("true"),
+		json.RawMessage("true"),
+	})
+	if err != nil {
+		return nil
+	}
+	var block struct {
+		Hash   string                   `json:"hash"`
+		Height int64                    `json:"height"`
+		Time   int64                    `json:"time"`
+		RawSTx []map[string]interface{} `json:"rawstx"`
+	}
+	if err := json.Unmarshal(res, &block); err != nil {
+		return nil
+	}
+	tx := ticketSpenderIn(block.RawSTx, ticketHash)
+	if tx == nil {
+		return nil
+	}
+	// Block-embedded transactions omit their own block fields.
+	tx["blockhash"] = block.Hash
+	tx["blockheight"] = float64(block.Height)
+	tx["blocktime"] = float64(block.Time)
+	return tx
+}
+
+// ticketSpenderIn returns the vote (which spends the ticket in vin[1]) or the
+// revocation (vin[0]) of ticketHash among a block's stake transactions.
+func ticketSpenderIn(rawSTx []map[string]interface{}, ticketHash string) map[string]interface{} {
+	for _, tx := range rawSTx {
+		if (isVoteTransaction(tx) && strings.EqualFold(inputPrevTxid(tx, 1), ticketHash)) ||
+			(isRevocation(tx) && strings.EqualFold(inputPrevTxid(tx, 0), ticketHash)) {
+			return tx
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestRevocationStatus(t *testing.T) {
+	const expiry = 41000
+	for _, c := range []struct {
+		height int64
+		want   string
+	}{
+		{expiry - 1, "missed"},
+		{expiry, "expired"},
+		{expiry + 1, "expired"},
+	} {
+		if got := revocationStatus(c.height, expiry); got != c.want {
+			t.Errorf("revocationStatus(%d, %d) = %q, want %q", c.height, expiry, got, c.want)
+		}
+	}
+}
+
+func TestCachedTicketVoteHeight(t *testing.T) {
+	ticket := strings.Repeat("ab", 32)
+	voteBlockCacheMu.Lock()
+	voteBlockCache["testblock"] = []blockVote{
+		{record: types.VoteRecord{TicketHash: strings.Repeat("cd", 32), BlockHeight: 10}},
+		{record: types.VoteRecord{TicketHash: ticket, BlockHeight: 12}},
+	}
+	voteBlockCacheMu.Unlock()
+	defer func() {
+		voteBlockCacheMu.Lock()
+		delete(voteBlockCache, "testblock")
+		voteBlockCacheMu.Unlock()
+	}()
+
+	if h, ok := cachedTicketVoteHeight(strings.ToUpper(ticket)); !ok || h != 12 {
+		t.Errorf("cachedTicketVoteHeight = %d, %v; want 12, true", h, ok)
+	}
+	if _, ok := cachedTicketVoteHeight(strings.Repeat("ef", 32)); ok {
+		t.Error("found a vote for a ticket not in the cache")
+	}
+}
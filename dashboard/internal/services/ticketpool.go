@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+// The last ticket pool summary, served until the best block changes.
+var (
+	ticketPoolMu    sync.Mutex
+	ticketPoolCache *types.TicketPool
+)
+
+// FetchTicketPool returns the live ticket pool's size and the DCR locked in
+// it at the best block. The value is the sum of the live tickets' purchase
+// prices from getticketpoolvalue; while dcrd is still in initial block
+// download, where that call is unsafe, it is approximated as the pool size
+// times the current ticket price and flagged as such.
+func FetchTicketPool(ctx context.Context) (*types.TicketPool, error) {
+	chainInfo, err := rpc.DcrdClient.GetBlockChainInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketPoolMu.Lock()
+	defer ticketPoolMu.Unlock()
+	if c := ticketPoolCache; c != nil && c.Hash == chainInfo.BestBlockHash {
+		pool := *c
+		return &pool, nil
+	}
+
+	hash, err := chainhash.NewHashFromStr(chainInfo.BestBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := rpc.DcrdClient.GetBlockHeaderVerbose(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	price, err := dcrutil.NewAmount(header.SBits)
+	if err != nil {
+		return nil, err
+	}
+
+	var value dcrutil.Amount
+	exact := !chainInfo.InitialBlockDownload
+	if exact {
+		if value, err = rpc.DcrdClient.GetTicketPoolValue(ctx); err != nil {
+			return nil, err
+		}
+	}
+	pool := ticketPoolSummary(int64(header.Height), header.Hash, header.PoolSize, int64(price), int64(value), exact)
+	ticketPoolCache = pool
+	result := *pool
+	return &result, nil
+}
+
+// ticketPoolSummary builds the pool summary from its size, the current ticket
+// price and, when exact, the pool value; otherwise the value is size times
+// price.
+func ticketPoolSummary(height int64, hash string, size uint32, priceAtoms, valueAtoms int64, exact bool) *types.TicketPool {
+	if !exact {
+		valueAtoms = int64(size) * priceAtoms
+	}
+	pool := &types.TicketPool{
+		Height:      height,
+		Hash:        hash,
+		Size:        size,
+		ValueAtoms:  valueAtoms,
+		Value:       atomsToCoin(valueAtoms),
+		ValueDCR:    formatDCR(valueAtoms),
+		TicketPrice: atomsToCoin(priceAtoms),
+		Approximate: !exact,
+	}
+	if size > 0 {
+		pool.AverageTicketPrice = atomsToCoin(valueAtoms / int64(size))
+	}
+	return pool
+}
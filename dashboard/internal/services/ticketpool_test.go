@@ -0,0 +1,27 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import "testing"
+
+func TestTicketPoolSummary(t *testing.T) {
+	const coin = 1e8
+	// 40960 tickets worth 8,000,000 DCR, bought at 195.3125 DCR on average;
+	// the current price is 200 DCR.
+	p := ticketPoolSummary(900000, "00ab", 40960, 200*coin, 8000000*coin, true)
+	if p.Approximate || p.ValueDCR != "8000000.00000000" || p.AverageTicketPrice != 195.3125 || p.TicketPrice != 200 {
+		t.Errorf("exact pool = %+v", p)
+	}
+
+	// Without the pool value, size times the current price.
+	p = ticketPoolSummary(900000, "00ab", 40960, 200*coin, 0, false)
+	if !p.Approximate || p.ValueAtoms != 8192000*coin || p.AverageTicketPrice != 200 {
+		t.Errorf("approximate pool = %+v", p)
+	}
+
+	if p := ticketPoolSummary(1, "00", 0, 2*coin, 0, true); p.AverageTicketPrice != 0 || p.Value != 0 {
+		t.Errorf("empty pool = %+v", p)
+	}
+}
@@ -7,41 +7,77 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"runtime/debug"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"dcrpulse/internal/rpc"
 	"dcrpulse/internal/types"
-)
 
-// Constants for treasury
-const (
-	TreasuryActivationHeight = 552448 // Block where treasury was first activated (May 2021)
-
-	// TreasuryVoteInterval is mainnet's TVI. Per DCP-0006 a TSpend may only be
-	// mined in a block whose height is a non-zero multiple of the TVI (dcrd
-	// standalone.IsTreasuryVoteInterval / ErrNotTVI), so only these blocks can
-	// contain a TSpend and the historical scan can stride by it. Mainnet-
-	// specific, like TreasuryActivationHeight.
-	TreasuryVoteInterval = 288
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
 )
 
 // Global scan state
 var (
 	scanMutex         sync.RWMutex
 	isScanRunning     bool
+	scanStartHeight   int64
+	scanMode          string
 	currentScanHeight int64
+	scanCancel        context.CancelFunc
+	scanCancelled     bool
 	totalScanHeight   int64
 	tspendFoundCount  int
 	scanResults       []types.TSpendHistory
+	scanFailedHeights []int64               // Blocks that could not be read after retries
 	newTSpendBuffer   []types.TSpendHistory // Buffer for TSpends found since last progress check
+	scanTreasuryBase  []treasuryBaseInflow  // Treasurybase paid in, per visited block
+	scanStartedAt     time.Time
+	scanRateSamples   []scanRateSample // Recent progress, for a smoothed scan rate
+	scanSignal        progressSignal   // Wakes WaitScanProgress callers
+
+	// scanCoveredHeight is the last block of the chain the results cover
+	// without a break: the end of the last full scan to finish, widened by
+	// recent and range scans that reach past it, then moved on block by block
+	// by the auto-scan. Zero while no such scan has finished.
+	scanCoveredHeight int64
+
+	// scanCoveredFrom is the first block the results cover: the start of
+	// the last full scan to finish, or of a recent or range scan that reaches
+	// before it. A full scan's is the treasury activation height. Zero while
+	// no such scan has finished.
+	scanCoveredFrom int64
+
+	// scanVersion is bumped on every progress change. It starts at 1 so a
+	// long poll with since=0 always returns at once.
+	scanVersion uint64 = 1
+)
+
+// scanRateWindow is how far back the scan rate is averaged, so one slow
+// block fetch doesn't swing the ETA.
+const scanRateWindow = 30 * time.Second
+
+// A block the historical scan can't read is retried this many times in all,
+// with the delay doubling from scanRetryDelay, before it is recorded as failed.
+const (
+	scanBlockAttempts = 3
+	scanRetryDelay    = time.Second
 )
 
-// FetchTreasuryInfo gets current treasury status including balance and active TSpends
-// Note: Historical TSpends are tracked in frontend localStorage, not fetched here
+type scanRateSample struct {
+	at     time.Time
+	height int64
+}
+
+// FetchTreasuryInfo gets current treasury status including balance and active TSpends.
+// TotalSpent and TotalAdded are summed from the scan results; TotalsComplete
+// says whether those cover the chain since activation.
 func FetchTreasuryInfo(ctx context.Context) (*types.TreasuryInfo, error) {
 	// Get current treasury balance
 	balance, err := getTreasuryBalance(ctx)
@@ -51,7 +87,7 @@ func FetchTreasuryInfo(ctx context.Context) (*types.TreasuryInfo, error) {
 	}
 
 	// Scan mempool for active TSpends (pending votes)
-	activeTSpends, err := scanMempoolForTSpends(ctx)
+	activeTSpends, err := GetMempoolTSpends(ctx)
 	if err != nil {
 		log.Printf("Warning: Failed to scan mempool for TSpends: %v", err)
 		activeTSpends = []types.TSpend{}
@@ -61,124 +97,69 @@ func FetchTreasuryInfo(ctx context.Context) (*types.TreasuryInfo, error) {
 		activeTSpends = []types.TSpend{}
 	}
 
+	// Without the activation height the totals can't be called lifetime
+	// ones, but they are still what the scan found.
+	var activation int64
+	if tp, err := CurrentTreasuryParams(ctx); err == nil {
+		activation = tp.ActivationHeight
+	}
+	totals := scanTreasuryTotals(activation)
+
 	return &types.TreasuryInfo{
-		Balance:       balance,
+		Balance:       atomsToCoin(balance),
+		BalanceAtoms:  balance,
+		BalanceDCR:    formatDCR(balance),
 		BalanceUSD:    0, // TODO: Add USD conversion if needed
-		TotalAdded:    0, // Tracked in frontend localStorage
-		TotalSpent:    0, // Tracked in frontend localStorage
+		TotalAdded:    atomsToCoin(totals.added),
+		TotalSpent:    atomsToCoin(totals.spent),
 		ActiveTSpends: activeTSpends,
 		RecentTSpends: []types.TSpendHistory{}, // Not used - data comes from localStorage
 		LastUpdate:    time.Now(),
+
+		TotalSpentAtoms: totals.spent,
+		TSpendCount:     totals.count,
+		TotalsComplete:  totals.complete,
 	}, nil
 }
 
-// getTreasuryBalance retrieves current treasury balance from dcrd
-func getTreasuryBalance(ctx context.Context) (float64, error) {
-	if rpc.DcrdClient == nil {
-		return 0, fmt.Errorf("dcrd client not available")
-	}
-
+// getTreasuryBalance retrieves current treasury balance from dcrd, in atoms
+func getTreasuryBalance(ctx context.Context) (int64, error) {
 	treasuryBalance, err := rpc.DcrdClient.GetTreasuryBalance(ctx, nil, false)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get treasury balance: %w", err)
 	}
 
-	// Convert atoms to DCR
-	balanceDCR := float64(treasuryBalance.Balance) / 1e8
-	return balanceDCR, nil
+	return int64(treasuryBalance.Balance), nil
 }
 
-// GetMempoolTSpends retrieves active tspends from mempool with voting info
-func GetMempoolTSpends(ctx context.Context) ([]types.TSpend, error) {
-	return scanMempoolForTSpends(ctx)
-}
-
-// scanMempoolForTSpends scans the mempool for active treasury spend transactions
-func scanMempoolForTSpends(ctx context.Context) ([]types.TSpend, error) {
-	if rpc.DcrdClient == nil {
-		return nil, fmt.Errorf("dcrd client not available")
-	}
-
-	// Get raw mempool with verbose=true
-	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawmempool", []json.RawMessage{
-		json.RawMessage("true"), // verbose
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get mempool: %w", err)
-	}
-
-	// Parse mempool response
-	var mempoolMap map[string]interface{}
-	if err := json.Unmarshal(result, &mempoolMap); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal mempool: %w", err)
-	}
+// First-seen tip heights of mempool tspends, so vote progress for an in-flight
+// tspend is measured from a stable start across polls.
+var (
+	tspendFirstSeenMu sync.Mutex
+	tspendFirstSeen   = make(map[string]int64)
+)
 
-	var tspends []types.TSpend
-	currentHeight, err := rpc.DcrdClient.GetBlockCount(ctx)
-	if err != nil {
-		log.Printf("Warning: Failed to get current height: %v", err)
-		currentHeight = 0
-	}
-
-	// Check each transaction
-	for txHash := range mempoolMap {
-		// Get transaction details
-		tx, err := getTransaction(ctx, txHash)
-		if err != nil {
-			log.Printf("Warning: Failed to get transaction %s: %v", txHash, err)
-			continue
-		}
-
-		// Check if it's a treasury spend
-		if isTreasurySpend(tx) {
-			tspend := extractTSpendInfo(tx, currentHeight)
-			if tspend != nil {
-				tspends = append(tspends, *tspend)
-			}
-		}
+// recordTSpendFirstSeen stores height as txHash's first-seen height unless one
+// is already recorded, and returns the stored height.
+func recordTSpendFirstSeen(txHash string, height int64) int64 {
+	tspendFirstSeenMu.Lock()
+	defer tspendFirstSeenMu.Unlock()
+	if h, ok := tspendFirstSeen[txHash]; ok {
+		return h
 	}
+	tspendFirstSeen[txHash] = height
+	return height
+}
 
-	// Attach yes/no vote tallies cheaply: gettreasuryspendvotes returns the
-	// running counts for the given tspend hashes in a single RPC call (no
-	// per-block vote scan needed). Best-effort; tspends are still returned if
-	// this fails.
-	if len(tspends) > 0 {
-		hashes := make([]string, len(tspends))
-		for i := range tspends {
-			hashes[i] = tspends[i].TxHash
-		}
-		if hb, err := json.Marshal(hashes); err == nil {
-			res, err := rpc.DcrdClient.RawRequest(ctx, "gettreasuryspendvotes", []json.RawMessage{
-				json.RawMessage("null"),
-				json.RawMessage(hb),
-			})
-			if err != nil {
-				log.Printf("Warning: gettreasuryspendvotes: %v", err)
-			} else {
-				var vr struct {
-					Votes []struct {
-						Hash     string `json:"hash"`
-						YesVotes int64  `json:"yesvotes"`
-						NoVotes  int64  `json:"novotes"`
-					} `json:"votes"`
-				}
-				if json.Unmarshal(res, &vr) == nil {
-					tally := make(map[string][2]int64, len(vr.Votes))
-					for _, v := range vr.Votes {
-						tally[v.Hash] = [2]int64{v.YesVotes, v.NoVotes}
-					}
-					for i := range tspends {
-						if t, ok := tally[tspends[i].TxHash]; ok {
-							tspends[i].YesVotes = t[0]
-							tspends[i].NoVotes = t[1]
-						}
-					}
-				}
-			}
+// pruneTSpendFirstSeen forgets tspends that have left the mempool.
+func pruneTSpendFirstSeen(mempool map[string]bool) {
+	tspendFirstSeenMu.Lock()
+	defer tspendFirstSeenMu.Unlock()
+	for txHash := range tspendFirstSeen {
+		if _, ok := mempool[txHash]; !ok {
+			delete(tspendFirstSeen, txHash)
 		}
 	}
-
-	return tspends, nil
 }
 
 // Treasury balance-over-time series, sampled at a coarse cadence and cached.
@@ -197,10 +178,6 @@ const (
 // to tip at ~monthly cadence (plus the tip). Cheap: ~1 + 2/sample RPC calls
 // (~120 total). Cached in-process for balanceHistTTL.
 func TreasuryBalanceHistory(ctx context.Context) ([]types.BalanceSample, error) {
-	if rpc.DcrdClient == nil {
-		return nil, fmt.Errorf("dcrd client not available")
-	}
-
 	balanceHistMu.RLock()
 	if balanceHistData != nil && time.Since(balanceHistAt) < balanceHistTTL {
 		cached := balanceHistData
@@ -214,8 +191,13 @@ func TreasuryBalanceHistory(ctx context.Context) ([]types.BalanceSample, error)
 		return nil, fmt.Errorf("get block count: %w", err)
 	}
 
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("treasury params: %w", err)
+	}
+
 	var out []types.BalanceSample
-	for h := int64(TreasuryActivationHeight); h <= tip; h += balanceSampleStride {
+	for h := tp.ActivationHeight; h <= tip; h += balanceSampleStride {
 		s, err := balanceSampleAt(ctx, h)
 		if err != nil {
 			log.Printf("Warning: treasury balance sample at %d: %v", h, err)
@@ -259,18 +241,17 @@ func balanceSampleAt(ctx context.Context, h int64) (*types.BalanceSample, error)
 	}
 	_ = json.Unmarshal(hres, &hdr)
 	return &types.BalanceSample{
-		Height:  h,
-		Time:    hdr.Time,
-		Balance: float64(bal.Balance) / 1e8,
+		Height:       h,
+		Time:         hdr.Time,
+		Balance:      atomsToCoin(int64(bal.Balance)),
+		BalanceAtoms: int64(bal.Balance),
 	}, nil
 }
 
-// getTransaction retrieves transaction details
+// getTransaction retrieves transaction details. It fails with ErrTxUnavailable
+// for confirmed transactions when dcrd runs without --txindex.
 func getTransaction(ctx context.Context, txHash string) (map[string]interface{}, error) {
-	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
-		json.RawMessage(fmt.Sprintf(`"%s"`, txHash)),
-		json.RawMessage("1"), // verbose
-	})
+	result, err := fetchRawTransaction(ctx, txHash)
 	if err != nil {
 		return nil, err
 	}
@@ -285,6 +266,10 @@ func getTransaction(ctx context.Context, txHash string) (map[string]interface{},
 
 // isTreasurySpend checks if a transaction is a treasury spend (not treasurybase)
 func isTreasurySpend(tx map[string]interface{}) bool {
+	if isTreasuryBase(tx) {
+		return false
+	}
+
 	// Method 1: Check for "treasuryspend" field in vin (MOST RELIABLE)
 	// Real TSpend transactions have this special field instead of txid/vout
 	vin, ok := tx["vin"].([]interface{})
@@ -337,72 +322,57 @@ func isTreasurySpend(tx map[string]interface{}) bool {
 	return false
 }
 
-// extractTSpendInfo extracts TSpend information from a transaction
-func extractTSpendInfo(tx map[string]interface{}, currentHeight int64) *types.TSpend {
+// extractTSpendInfo extracts TSpend information from a transaction. target is
+// the network's block time, used to date the expiry; 0 leaves it undated.
+func extractTSpendInfo(tx map[string]interface{}, currentHeight int64, target time.Duration) *types.TSpend {
 	txid, _ := tx["txid"].(string)
 	expiry, _ := tx["expiry"].(float64)
 
-	// Calculate amount from outputs
-	amount := 0.0
-	payee := ""
-	vout, _ := tx["vout"].([]interface{})
-	for _, v := range vout {
-		voutMap, _ := v.(map[string]interface{})
-		value, _ := voutMap["value"].(float64)
-		amount += value
-
-		// Try to get payee address
-		if scriptPubKey, ok := voutMap["scriptPubKey"].(map[string]interface{}); ok {
-			if addresses, ok := scriptPubKey["addresses"].([]interface{}); ok && len(addresses) > 0 {
-				if addr, ok := addresses[0].(string); ok {
-					payee = addr
-				}
-			}
-		}
-	}
+	atoms, payees := sumTSpendOutputs(tx)
 
 	expiryHeight := int64(expiry)
 	blocksRemaining := expiryHeight - currentHeight
 
 	return &types.TSpend{
 		TxHash:          txid,
-		Amount:          amount,
-		Payee:           payee,
+		Amount:          atomsToCoin(atoms),
+		AmountAtoms:     atoms,
+		AmountDCR:       formatDCR(atoms),
+		Payee:           lastPayee(payees),
+		Payees:          payees,
 		ExpiryHeight:    expiryHeight,
 		CurrentHeight:   currentHeight,
 		BlocksRemaining: blocksRemaining,
+		EstimatedExpiry: estimateTSpendExpiry(currentHeight, blocksRemaining, target, time.Now()),
 		Status:          "voting",
 		DetectedAt:      time.Now(),
 	}
 }
 
+// estimateTSpendExpiry dates a mempool TSpend's expiry: blocksRemaining
+// blocks of target each from now. It is nil without a known tip height or
+// block time, and now once the expiry is reached.
+func estimateTSpendExpiry(currentHeight, blocksRemaining int64, target time.Duration, now time.Time) *time.Time {
+	if currentHeight <= 0 || target <= 0 {
+		return nil
+	}
+	at := now.Add(time.Duration(max(blocksRemaining, 0)) * target).UTC().Truncate(time.Second)
+	return &at
+}
+
 // extractTSpendHistory extracts historical TSpend information
 func extractTSpendHistory(tx map[string]interface{}, blockHeight int64, blockHash string, blockTime int64) *types.TSpendHistory {
 	txid, _ := tx["txid"].(string)
 
-	// Calculate amount from outputs
-	amount := 0.0
-	payee := ""
-	vout, _ := tx["vout"].([]interface{})
-	for _, v := range vout {
-		voutMap, _ := v.(map[string]interface{})
-		value, _ := voutMap["value"].(float64)
-		amount += value
-
-		// Try to get payee address
-		if scriptPubKey, ok := voutMap["scriptPubKey"].(map[string]interface{}); ok {
-			if addresses, ok := scriptPubKey["addresses"].([]interface{}); ok && len(addresses) > 0 {
-				if addr, ok := addresses[0].(string); ok {
-					payee = addr
-				}
-			}
-		}
-	}
+	atoms, payees := sumTSpendOutputs(tx)
 
 	return &types.TSpendHistory{
 		TxHash:      txid,
-		Amount:      amount,
-		Payee:       payee,
+		Amount:      atomsToCoin(atoms),
+		AmountAtoms: atoms,
+		AmountDCR:   formatDCR(atoms),
+		Payee:       lastPayee(payees),
+		Payees:      payees,
 		BlockHeight: blockHeight,
 		BlockHash:   blockHash,
 		Timestamp:   time.Unix(blockTime, 0),
@@ -410,152 +380,398 @@ func extractTSpendHistory(tx map[string]interface{}, blockHeight int64, blockHas
 	}
 }
 
-// TriggerHistoricalScan starts a background scan of the blockchain for all TSpends
-func TriggerHistoricalScan(startHeight int64) error {
+// TriggerHistoricalScan starts a background scan of the blockchain for
+// TSpends over the heights profile selects and returns them. The start is
+// clamped up to the network's treasury activation height. With
+// ConfigureScanRequiresSync on, it is refused while dcrd is still syncing.
+func TriggerHistoricalScan(ctx context.Context, profile ScanProfile) (startHeight, endHeight int64, err error) {
+	if err := checkScanNodeSynced(); err != nil {
+		return 0, 0, err
+	}
+	mode, startHeight, endHeight, heights, err := planHistoricalScan(ctx, profile)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	scanCtx, err := beginScan(RootContext(), mode, startHeight, endHeight)
+	if err != nil {
+		return 0, 0, err
+	}
+	go scanHistoricalTSpendsBackground(scanCtx, startHeight, endHeight, heights)
+	return startHeight, endHeight, nil
+}
+
+// planHistoricalScan resolves profile against the chain tip into the scan's
+// mode, range and the heights it visits.
+func planHistoricalScan(ctx context.Context, profile ScanProfile) (mode string, startHeight, endHeight int64, heights []int64, err error) {
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		return "", 0, 0, nil, fmt.Errorf("treasury params: %w", err)
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return "", 0, 0, nil, fmt.Errorf("chain params: %w", err)
+	}
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return "", 0, 0, nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	startHeight, endHeight, err = resolveScanProfile(profile, tip, tp.ActivationHeight,
+		int64(params.TargetTimePerBlock/time.Second))
+	if err != nil {
+		return "", 0, 0, nil, err
+	}
+	heights = scanHeights(profile, startHeight, endHeight, tp.VoteInterval)
+	mode = profile.Mode
+	if mode == "" {
+		mode = ScanModeFull
+	}
+	return mode, startHeight, endHeight, heights, nil
+}
+
+// beginScan claims the scan state for a scan of startHeight to endHeight,
+// failing with ErrScanInProgress while another runs. A heights scan adds to
+// the existing results; any other mode reads into a new stage that replaces
+// them, or for a recent or range scan the part in its window, once it
+// finishes (see scanStage). The returned context is cancelled by
+// CancelHistoricalScan or when parent is.
+func beginScan(parent context.Context, mode string, startHeight, endHeight int64) (context.Context, error) {
 	scanMutex.Lock()
+	defer scanMutex.Unlock()
 	if isScanRunning {
-		scanMutex.Unlock()
-		return fmt.Errorf("scan already in progress")
+		return nil, ErrScanInProgress
 	}
 	isScanRunning = true
 
-	// Validate startHeight
-	if startHeight < TreasuryActivationHeight {
-		startHeight = TreasuryActivationHeight
-	}
-
+	scanMode = mode
+	scanStartHeight = startHeight
 	currentScanHeight = startHeight
-	tspendFoundCount = 0
-	scanResults = []types.TSpendHistory{}
+	totalScanHeight = endHeight
+	if mode == ScanModeHeights {
+		// A re-scan of specific blocks adds to the existing results.
+		tspendFoundCount = len(scanResults)
+	} else {
+		tspendFoundCount = 0
+		scanStaging = &scanStage{results: []types.TSpendHistory{}}
+	}
 	newTSpendBuffer = []types.TSpendHistory{}
-	scanMutex.Unlock()
-
-	go scanHistoricalTSpendsBackground(startHeight)
-	return nil
+	scanCancelled = false
+	scanStartedAt = time.Now()
+	scanRateSamples = []scanRateSample{{at: scanStartedAt, height: startHeight}}
+	scanCtx, cancel := context.WithCancel(parent)
+	scanCancel = cancel
+	scanChangedLocked()
+	return scanCtx, nil
 }
 
-// scanHistoricalTSpendsBackground performs the historical scan in the background
-func scanHistoricalTSpendsBackground(startHeight int64) {
-	ctx := context.Background()
-
-	currentHeight, err := rpc.DcrdClient.GetBlockCount(ctx)
-	if err != nil {
-		log.Printf("Error getting block count for scan: %v", err)
-		scanMutex.Lock()
-		isScanRunning = false
-		scanMutex.Unlock()
-		return
+// CancelHistoricalScan stops a running historical scan. The scan stops at the
+// next block boundary, merging the TSpends found so far into the previous
+// results rather than replacing them, and leaving the
+// progress height at the last fully scanned block so a later scan can resume
+// after it. It reports whether a scan was running.
+func CancelHistoricalScan() bool {
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+	if !isScanRunning || scanCancel == nil {
+		return false
 	}
+	scanCancel()
+	return true
+}
 
-	scanMutex.Lock()
-	totalScanHeight = currentHeight
-	scanMutex.Unlock()
+// scanHistoricalTSpendsBackground performs the historical scan in the
+// background, visiting heights in order (see scanHeights) and stopping after
+// the last or when ctx is cancelled. A block that still can't be read after
+// scanBlockAttempts is recorded as a failed height rather than skipped
+// silently; one that is read is taken off that list.
+func scanHistoricalTSpendsBackground(ctx context.Context, startHeight, endHeight int64, heights []int64) {
+	log.Printf("Starting historical TSpend scan of %d blocks from %d to %d", len(heights), startHeight, endHeight)
 
-	// TSpends may only be mined in blocks on a treasury-vote-interval (TVI)
-	// boundary (height % TVI == 0), so stride by the TVI and skip the ~99.7%
-	// of blocks that cannot contain one. Align the start up to the first TVI
-	// boundary at/after startHeight.
-	firstTVI := startHeight
-	if firstTVI < 1 {
-		firstTVI = 1
-	}
-	if rem := firstTVI % TreasuryVoteInterval; rem != 0 {
-		firstTVI += TreasuryVoteInterval - rem
+	// Treasurybase inflows are counted only by scans that walk the chain in
+	// order; a heights re-scan revisits blocks already counted.
+	scanMutex.RLock()
+	mode := scanMode
+	scanMutex.RUnlock()
+	var tbase *treasuryBaseCounter
+	if mode != ScanModeHeights {
+		params, perr := CurrentChainParams(ctx)
+		tp, terr := CurrentTreasuryParams(ctx)
+		if perr != nil || terr != nil {
+			log.Printf("Warning: Historical scan will not count treasurybase inflows: %v", errors.Join(perr, terr))
+		} else {
+			tbase = newTreasuryBaseCounter(params, tp.ActivationHeight, startHeight)
+		}
 	}
 
-	log.Printf("Starting historical TSpend scan from block %d to %d (TVI stride %d)", firstTVI, currentHeight, TreasuryVoteInterval)
+	// lastScanned is the last block whose transactions were fully checked; a
+	// cancelled scan rewinds the progress height to it.
+	lastScanned := startHeight - 1
+	for i, h := range heights {
+		if ctx.Err() != nil {
+			break
+		}
+		if i > 0 && i%scanLogInterval == 0 {
+			scanMutex.RLock()
+			found := tspendFoundCount
+			scanMutex.RUnlock()
+			log.Printf("Historical TSpend scan at block %d (%d of %d blocks), %d TSpends found so far", h, i, len(heights), found)
+		}
 
-	for h := firstTVI; h <= currentHeight; h += TreasuryVoteInterval {
 		// Update progress
 		scanMutex.Lock()
 		currentScanHeight = h
+		recordScanRateLocked(h)
+		scanChangedLocked()
 		scanMutex.Unlock()
 
-		blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, h)
+		block, err := fetchScanBlockRetry(ctx, h)
 		if err != nil {
-			log.Printf("Warning: Failed to get block hash at height %d: %v", h, err)
+			if ctx.Err() != nil {
+				break
+			}
+			log.Printf("Warning: Historical scan could not read block %d: %v", h, err)
+			scanMutex.Lock()
+			failed := scanFailedLocked()
+			*failed = addScanHeight(*failed, h)
+			scanChangedLocked()
+			scanMutex.Unlock()
+			lastScanned = h
 			continue
 		}
 
-		// verbose=true + verbosetx=true returns every tx's full vin/vout inline
-		// (rawtx/rawstx), so no per-transaction getrawtransaction call is needed.
-		blockResult, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
-			json.RawMessage(fmt.Sprintf(`"%s"`, blockHash.String())),
-			json.RawMessage("true"),
-			json.RawMessage("true"),
-		})
-		if err != nil {
-			continue
+		scanMutex.Lock()
+		failed := scanFailedLocked()
+		failedBefore := len(*failed)
+		*failed = removeScanHeight(*failed, h)
+		changed := len(*failed) != failedBefore
+		if addScanBlockLocked(block, tbase) {
+			changed = true
 		}
-
-		var block struct {
-			Hash   string                   `json:"hash"`
-			Height int64                    `json:"height"`
-			Time   int64                    `json:"time"`
-			RawTx  []map[string]interface{} `json:"rawtx"`
-			RawSTx []map[string]interface{} `json:"rawstx"`
+		if changed {
+			scanChangedLocked()
 		}
+		scanMutex.Unlock()
+		scanDebugf("Historical scan read block %d: %d transactions", h, len(block.RawTx)+len(block.RawSTx))
+		lastScanned = h
+	}
+
+	cancelled := ctx.Err() != nil
+	scanMutex.Lock()
+	if cancelled {
+		currentScanHeight = lastScanned
+		abandonScanStageLocked()
+	} else {
+		commitScanStageLocked(endHeight, tbase)
+	}
+	finishScanLocked(ctx)
+	found := tspendFoundCount
+	failed := append([]int64(nil), scanFailedHeights...)
+	scanMutex.Unlock()
+
+	if cancelled {
+		log.Printf("Historical TSpend scan cancelled after block %d. Found %d TSpends", lastScanned, found)
+		return
+	}
+	if len(failed) > 0 {
+		log.Printf("Historical TSpend scan incomplete. Found %d TSpends; blocks %v could not be read", found, failed)
+	} else {
+		log.Printf("Historical TSpend scan complete. Found %d TSpends", found)
+	}
+	NotifyWebhooks(WebhookEventTSpendScanDone, types.WebhookScanComplete{
+		StartHeight:   startHeight,
+		EndHeight:     endHeight,
+		TSpendFound:   found,
+		FailedHeights: failed,
+	})
+}
 
-		if err := json.Unmarshal(blockResult, &block); err != nil {
+// addScanBlockLocked adds the tspends in block not already in the results,
+// and its treasurybase run when tbase is counting them, to the running scan's
+// stage if it has one. It reports whether any tspend was added. It must be
+// called with scanMutex held.
+func addScanBlockLocked(block *scanBlock, tbase *treasuryBaseCounter) bool {
+	added := false
+	var tbaseAtoms int64
+	tbaseFound := false
+	for _, tx := range append(block.RawTx, block.RawSTx...) {
+		if isTreasuryBase(tx) {
+			tbaseAtoms, tbaseFound = treasuryBaseAtoms(tx), true
 			continue
 		}
-
-		allTxs := append(block.RawTx, block.RawSTx...)
-		for _, tx := range allTxs {
-			if isTreasurySpend(tx) {
-				history := extractTSpendHistory(tx, block.Height, block.Hash, block.Time)
-				if history != nil {
-					scanMutex.Lock()
-					scanResults = append(scanResults, *history)
-					newTSpendBuffer = append(newTSpendBuffer, *history)
-					tspendFoundCount++
-					log.Printf("TSpend found at height %d: %s (amount: %.2f DCR)", block.Height, history.TxHash, history.Amount)
-					scanMutex.Unlock()
-				}
-			}
+		if !isTreasurySpend(tx) {
+			continue
+		}
+		history := extractTSpendHistory(tx, block.Height, block.Hash, block.Time)
+		if history == nil || scanResultKnownLocked(history.TxHash) {
+			continue
+		}
+		results := scanResultsLocked()
+		*results = append(*results, *history)
+		newTSpendBuffer = append(newTSpendBuffer, *history)
+		tspendFoundCount++
+		added = true
+		log.Printf("TSpend found at height %d: %s (amount: %.2f DCR)", block.Height, history.TxHash, history.Amount)
+	}
+	if tbase != nil {
+		if in, ok := tbase.add(block.Height, time.Unix(block.Time, 0), tbaseAtoms, tbaseFound); ok {
+			tbaseRuns := scanTreasuryBaseLocked()
+			*tbaseRuns = append(*tbaseRuns, in)
 		}
 	}
+	return added
+}
 
-	scanMutex.Lock()
+// finishScanLocked marks the scan stopped and releases its context. It must
+// be called with scanMutex held, in the same critical section that clears
+// isScanRunning so a new scan can't have installed its own cancel func yet.
+func finishScanLocked(ctx context.Context) {
 	isScanRunning = false
-	scanMutex.Unlock()
+	scanCancelled = ctx.Err() != nil
+	if scanCancel != nil {
+		scanCancel()
+		scanCancel = nil
+	}
+	scanChangedLocked()
+}
+
+// scanChangedLocked moves the scan progress on to a new version and wakes
+// WaitScanProgress callers. It must be called with scanMutex held.
+func scanChangedLocked() {
+	scanVersion++
+	scanSignal.broadcast()
+}
 
-	log.Printf("Historical TSpend scan complete. Found %d TSpends", tspendFoundCount)
+// recordScanRateLocked adds a progress sample and drops those older than
+// scanRateWindow, keeping the newest of them as the window's start. It must
+// be called with scanMutex held.
+func recordScanRateLocked(height int64) {
+	now := time.Now()
+	scanRateSamples = append(scanRateSamples, scanRateSample{at: now, height: height})
+	drop := 0
+	for drop+1 < len(scanRateSamples) && now.Sub(scanRateSamples[drop+1].at) >= scanRateWindow {
+		drop++
+	}
+	scanRateSamples = scanRateSamples[drop:]
+}
+
+// scanRateLocked returns the scan rate in chain blocks covered per second
+// over the recent window, falling back to the average since the scan started.
+// It must be called with scanMutex held.
+func scanRateLocked() float64 {
+	if n := len(scanRateSamples); n >= 2 {
+		first, last := scanRateSamples[0], scanRateSamples[n-1]
+		if secs := last.at.Sub(first.at).Seconds(); secs > 0 {
+			return float64(last.height-first.height) / secs
+		}
+	}
+	if secs := time.Since(scanStartedAt).Seconds(); !scanStartedAt.IsZero() && secs > 0 {
+		return float64(currentScanHeight-scanStartHeight) / secs
+	}
+	return 0
 }
 
 // GetScanProgress returns the current scan progress
 func GetScanProgress() (*types.TSpendScanProgress, error) {
 	scanMutex.Lock()
 	defer scanMutex.Unlock()
+	return scanProgressLocked(), nil
+}
+
+// WaitScanProgress blocks until the scan progress version differs from since,
+// then returns the progress as GetScanProgress does. When ctx ends first, the
+// unchanged progress is returned.
+func WaitScanProgress(ctx context.Context, since uint64) *types.TSpendScanProgress {
+	scanSignal.waitUntil(ctx, func() bool {
+		scanMutex.RLock()
+		defer scanMutex.RUnlock()
+		return scanVersion != since
+	})
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+	return scanProgressLocked()
+}
+
+// scanProgressLocked builds the progress report and empties newTSpendBuffer
+// into it. It must be called with scanMutex held.
+func scanProgressLocked() *types.TSpendScanProgress {
 
 	progress := 0.0
-	if totalScanHeight > TreasuryActivationHeight {
-		progress = float64(currentScanHeight-TreasuryActivationHeight) / float64(totalScanHeight-TreasuryActivationHeight) * 100
+	if totalScanHeight > scanStartHeight {
+		progress = float64(currentScanHeight-scanStartHeight) / float64(totalScanHeight-scanStartHeight) * 100
 	}
 
 	message := "Scanning blockchain for treasury spends..."
 	if !isScanRunning {
-		if tspendFoundCount > 0 {
+		if scanCancelled {
+			message = fmt.Sprintf("Scan cancelled at block %d. Found %d treasury spends", currentScanHeight, tspendFoundCount)
+		} else if len(scanFailedHeights) > 0 {
+			message = fmt.Sprintf("Scan incomplete: %d blocks could not be read. Found %d treasury spends; "+
+				"re-scan the failed heights to fill the gaps", len(scanFailedHeights), tspendFoundCount)
+		} else if tspendFoundCount > 0 {
 			message = fmt.Sprintf("Scan complete. Found %d treasury spends", tspendFoundCount)
 		} else {
 			message = "No scan in progress"
 		}
 	}
 
+	// The ETA uses the rate of chain height covered, since the scan strides
+	// by TVI rather than visiting every block.
+	var rate float64
+	var eta int
+	if isScanRunning {
+		rate = scanRateLocked()
+		if remaining := totalScanHeight - currentScanHeight; rate > 0 && remaining > 0 {
+			eta = int(float64(remaining) / rate)
+		}
+	}
+
 	// Get new TSpends and clear the buffer
 	newTSpends := make([]types.TSpendHistory, len(newTSpendBuffer))
 	copy(newTSpends, newTSpendBuffer)
 	newTSpendBuffer = []types.TSpendHistory{} // Clear buffer after copying
 
 	return &types.TSpendScanProgress{
-		IsScanning:    isScanRunning,
-		CurrentHeight: currentScanHeight,
-		TotalHeight:   totalScanHeight,
-		Progress:      progress,
-		TSpendFound:   tspendFoundCount,
-		NewTSpends:    newTSpends,
-		Message:       message,
-	}, nil
+		IsScanning:                isScanRunning,
+		Mode:                      scanMode,
+		StartHeight:               scanStartHeight,
+		CurrentHeight:             currentScanHeight,
+		TotalHeight:               totalScanHeight,
+		Progress:                  progress,
+		TSpendFound:               tspendFoundCount,
+		NewTSpends:                newTSpends,
+		Message:                   message,
+		Cancelled:                 scanCancelled,
+		Rate:                      rate,
+		EstimatedSecondsRemaining: eta,
+		FailedHeights:             append([]int64{}, *scanFailedLocked()...),
+		CoveredHeight:             scanCoveredHeight,
+		Version:                   scanVersion,
+	}
+}
+
+// ScanFound returns the TSpends found by the running or last scan, in block
+// order, with the blocks it has covered so far, all read at one point in the
+// scan. Unlike GetScanProgress and WaitScanProgress it leaves the new-TSpend
+// buffer alone.
+func ScanFound() *types.TSpendScanFound {
+	scanMutex.RLock()
+	defer scanMutex.RUnlock()
+	results := *scanResultsLocked()
+	found := make([]types.TSpendHistory, len(results))
+	copy(found, results)
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].BlockHeight < found[j].BlockHeight
+	})
+	return &types.TSpendScanFound{
+		IsScanning:     isScanRunning,
+		Mode:           scanMode,
+		StartHeight:    scanStartHeight,
+		ScannedThrough: currentScanHeight,
+		EndHeight:      totalScanHeight,
+		Count:          len(found),
+		TSpends:        found,
+		Version:        scanVersion,
+	}
 }
 
 // GetScanResults returns the results from the last completed scan
@@ -569,13 +785,32 @@ func GetScanResults() []types.TSpendHistory {
 	return results
 }
 
+// scanTreasuryBaseInflows returns the treasurybase inflows the last scan
+// counted, in height order.
+func scanTreasuryBaseInflows() []treasuryBaseInflow {
+	scanMutex.RLock()
+	defer scanMutex.RUnlock()
+	return append([]treasuryBaseInflow(nil), scanTreasuryBase...)
+}
+
+// ScanFailedHeights returns the blocks the last scan could not read, lowest
+// first. Results are incomplete until they are re-scanned.
+func ScanFailedHeights() []int64 {
+	scanMutex.RLock()
+	defer scanMutex.RUnlock()
+	return append([]int64{}, scanFailedHeights...)
+}
+
 // Vote counting and caching
 var (
 	votingCache         = make(map[string]*types.TSpendVotingInfo)
+	voteBlocksCache     = make(map[string][]types.TSpendBlockVotes) // Per-block votes of votingCache entries
 	votingCacheMutex    sync.RWMutex
 	voteParsingProgress = make(map[string]*types.VoteParsingProgress)
+	voteProgressVersion uint64 // Last Version handed out, guarded by progressMutex
 	progressMutex       sync.RWMutex
-	parsingJobs         = make(map[string]bool) // Track active parsing jobs
+	voteProgressSignal  progressSignal
+	parsingJobs         = make(map[string]context.CancelFunc) // Active parsing jobs and their cancel funcs
 	jobsMutex           sync.RWMutex
 )
 
@@ -583,17 +818,14 @@ var (
 func GetTSpendVotingInfo(ctx context.Context, txHash string, blockHeight int64, expiry uint32, inMempool bool) (*types.TSpendVotingInfo, error) {
 	// Check cache first (only for confirmed tspends)
 	if !inMempool {
-		votingCacheMutex.RLock()
-		if cached, ok := votingCache[txHash]; ok {
-			votingCacheMutex.RUnlock()
+		if cached, ok := cachedVotingInfo(txHash); ok {
 			return cached, nil
 		}
-		votingCacheMutex.RUnlock()
 	}
 
 	// Check if parsing is already in progress
 	jobsMutex.RLock()
-	isJobRunning := parsingJobs[txHash]
+	_, isJobRunning := parsingJobs[txHash]
 	jobsMutex.RUnlock()
 
 	if isJobRunning {
@@ -605,7 +837,7 @@ func GetTSpendVotingInfo(ctx context.Context, txHash string, blockHeight int64,
 		if hasProgress {
 			// Return voting info with current progress
 			return &types.TSpendVotingInfo{
-				VotingStartBlock: progress.CurrentBlock - int64(float64(progress.CurrentBlock-blockHeight+2880)*(progress.Progress/100.0)),
+				VotingStartBlock: progress.CurrentBlock - int64(float64(progress.CurrentBlock-blockHeight+tspendVoteWindow(ctx))*(progress.Progress/100.0)),
 				VotingEndBlock:   blockHeight,
 				YesVotes:         progress.YesVotes,
 				NoVotes:          progress.NoVotes,
@@ -618,15 +850,16 @@ func GetTSpendVotingInfo(ctx context.Context, txHash string, blockHeight int64,
 
 	// Start async vote counting for confirmed tspends
 	if !inMempool && !isJobRunning {
+		jobCtx, cancel := context.WithCancel(RootContext())
 		jobsMutex.Lock()
-		parsingJobs[txHash] = true
+		parsingJobs[txHash] = cancel
 		jobsMutex.Unlock()
 
-		go calculateTSpendVotesAsync(context.Background(), txHash, blockHeight, expiry, inMempool)
+		go calculateTSpendVotesAsync(jobCtx, txHash, blockHeight, expiry, inMempool)
 
 		// Return initial empty state - frontend will poll for progress
 		return &types.TSpendVotingInfo{
-			VotingStartBlock: blockHeight - 2880,
+			VotingStartBlock: blockHeight - tspendVoteWindow(ctx),
 			VotingEndBlock:   blockHeight,
 			VotingComplete:   false,
 			InMempool:        inMempool,
@@ -642,6 +875,16 @@ func GetTSpendVotingInfo(ctx context.Context, txHash string, blockHeight int64,
 	return votingInfo, nil
 }
 
+// tspendVoteWindow returns the network's TSpend voting window in blocks,
+// falling back to mainnet's when the params can't be resolved.
+func tspendVoteWindow(ctx context.Context) int64 {
+	if tp, err := CurrentTreasuryParams(ctx); err == nil {
+		return tp.VoteWindow
+	}
+	mp := chaincfg.MainNetParams()
+	return int64(mp.TreasuryVoteInterval * mp.TreasuryVoteIntervalMultiplier)
+}
+
 // GetVoteParsingProgress retrieves current progress for a tspend vote counting job
 func GetVoteParsingProgress(txHash string) (*types.VoteParsingProgress, bool) {
 	progressMutex.RLock()
@@ -650,50 +893,94 @@ func GetVoteParsingProgress(txHash string) (*types.VoteParsingProgress, bool) {
 	return progress, ok
 }
 
-// calculateTSpendVotes counts votes for a tspend in the voting period
-func calculateTSpendVotes(ctx context.Context, txHash string, blockHeight int64, expiry uint32, inMempool bool) (*types.TSpendVotingInfo, error) {
-	if rpc.DcrdClient == nil {
-		return nil, fmt.Errorf("dcrd client not available")
+// WaitVoteParsingProgress blocks until txHash has vote counting progress whose
+// version differs from since, then returns it. When ctx ends first, whatever
+// progress exists is returned, unchanged.
+func WaitVoteParsingProgress(ctx context.Context, txHash string, since uint64) (*types.VoteParsingProgress, bool) {
+	voteProgressSignal.waitUntil(ctx, func() bool {
+		progress, ok := GetVoteParsingProgress(txHash)
+		return ok && progress.Version != since
+	})
+	return GetVoteParsingProgress(txHash)
+}
+
+// WaitVoteParsingStopped blocks until txHash's vote count is no longer
+// parsing, as after CancelVoteParsing, and returns its last progress. When
+// ctx ends first, whatever progress exists is returned.
+func WaitVoteParsingStopped(ctx context.Context, txHash string) (*types.VoteParsingProgress, bool) {
+	voteProgressSignal.waitUntil(ctx, func() bool {
+		progress, ok := GetVoteParsingProgress(txHash)
+		return !ok || !progress.IsParsing
+	})
+	return GetVoteParsingProgress(txHash)
+}
+
+// setVoteParsingProgress records txHash's progress under a new version and
+// wakes WaitVoteParsingProgress callers. Finished entries are pruned here,
+// as pruneVoteProgressLocked describes.
+func setVoteParsingProgress(txHash string, progress *types.VoteParsingProgress) {
+	progressMutex.Lock()
+	voteProgressVersion++
+	progress.Version = voteProgressVersion
+	voteParsingProgress[txHash] = progress
+	now := time.Now()
+	if progress.IsParsing {
+		delete(voteProgressDone, txHash)
+	} else {
+		voteProgressDone[txHash] = now
 	}
+	pruneVoteProgressLocked(now)
+	progressMutex.Unlock()
+	voteProgressSignal.broadcast()
+}
 
+// calculateTSpendVotes counts votes for a tspend in the voting period
+func calculateTSpendVotes(ctx context.Context, txHash string, blockHeight int64, expiry uint32, inMempool bool) (*types.TSpendVotingInfo, error) {
 	currentHeight, err := rpc.DcrdClient.GetBlockCount(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current height: %w", err)
 	}
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("treasury params: %w", err)
+	}
 
 	// Determine voting period
-	var votingStartBlock, votingEndBlock int64
+	var votingStartBlock, votingEndBlock, windowEnd int64
 	var votingComplete bool
 
 	if inMempool {
-		// For mempool tspends, estimate start block (could be when first seen, but we'll use a lookback)
-		// Typical voting period is ~2880 blocks (TVI - Treasury Vote Interval)
-		votingStartBlock = currentHeight - 2880
-		if votingStartBlock < 0 {
-			votingStartBlock = 0
+		// For mempool tspends, count from the height the tspend was first
+		// seen through the current tip; voting runs until expiry, or one
+		// voting window (TVI * multiplier) when no expiry is known.
+		votingStartBlock = recordTSpendFirstSeen(txHash, currentHeight)
+		votingEndBlock = currentHeight
+		windowEnd = int64(expiry)
+		if windowEnd <= votingStartBlock {
+			windowEnd = votingStartBlock + tp.VoteWindow
 		}
-		votingEndBlock = int64(expiry)
 		votingComplete = false
 	} else {
 		// For confirmed tspends, voting ended when mined
 		// Start block is approximately voting interval before
-		votingStartBlock = blockHeight - 2880
-		if votingStartBlock < TreasuryActivationHeight {
-			votingStartBlock = TreasuryActivationHeight
+		votingStartBlock = blockHeight - tp.VoteWindow
+		if votingStartBlock < tp.ActivationHeight {
+			votingStartBlock = tp.ActivationHeight
 		}
 		votingEndBlock = blockHeight
 		votingComplete = true
 	}
 
 	// Count votes in the range
-	yesVotes, noVotes, err := countTSpendVotesInRange(ctx, txHash, votingStartBlock, votingEndBlock)
+	tally, err := countTSpendVotesInRange(ctx, txHash, votingStartBlock, votingEndBlock)
 	if err != nil {
 		log.Printf("Warning: Failed to count votes: %v", err)
 		// Return partial data even if vote counting fails
-		yesVotes, noVotes = 0, 0
+		tally = stakeTally{}
 	}
-
+	yesVotes, noVotes := tally.yes, tally.no
 	votesCast := yesVotes + noVotes
+	ticketsPerBlock := chainTicketsPerBlock(ctx)
 
 	// Calculate statistics
 	var approvalRate, turnoutRate float64
@@ -701,9 +988,12 @@ func calculateTSpendVotes(ctx context.Context, txHash string, blockHeight int64,
 		approvalRate = float64(yesVotes) / float64(votesCast) * 100
 	}
 
-	// Estimate eligible votes based on ticket pool (simplified)
-	// In reality, this would need to check ticket pool size during voting period
-	eligibleVotes := int(float64(votingEndBlock-votingStartBlock) * 5) // Approximate: 5 votes per block
+	// Every scanned block had TicketsPerBlock vote slots; a slot no vote
+	// filled is a missed vote, so eligible counts them too.
+	eligibleVotes := tally.blocks * ticketsPerBlock
+	if tally.blocks == 0 {
+		eligibleVotes = int(votingEndBlock-votingStartBlock) * ticketsPerBlock
+	}
 	if eligibleVotes > 0 {
 		turnoutRate = float64(votesCast) / float64(eligibleVotes) * 100
 	}
@@ -715,13 +1005,16 @@ func calculateTSpendVotes(ctx context.Context, txHash string, blockHeight int64,
 	// Get timestamps
 	startTime, endTime := getBlockTimestamps(ctx, votingStartBlock, votingEndBlock)
 
-	return &types.TSpendVotingInfo{
+	info := &types.TSpendVotingInfo{
 		VotingStartBlock: votingStartBlock,
 		VotingEndBlock:   votingEndBlock,
 		YesVotes:         yesVotes,
 		NoVotes:          noVotes,
 		EligibleVotes:    eligibleVotes,
 		VotesCast:        votesCast,
+		AbstainVotes:     tally.votes - votesCast,
+		MissedVotes:      tally.missed(ticketsPerBlock),
+		Revocations:      tally.revocations,
 		QuorumRequired:   quorumRequired,
 		ApprovalRate:     approvalRate,
 		TurnoutRate:      turnoutRate,
@@ -730,35 +1023,125 @@ func calculateTSpendVotes(ctx context.Context, txHash string, blockHeight int64,
 		InMempool:        inMempool,
 		VotingStartTime:  startTime,
 		VotingEndTime:    endTime,
-	}, nil
+	}
+	if inMempool {
+		projectTSpendVotes(info, windowEnd)
+		if params, err := CurrentChainParams(ctx); err == nil {
+			info.PassProjection = tspendPassProjection(params, yesVotes, noVotes)
+		}
+	}
+	return info, nil
+}
+
+// tspendPassProjection applies dcrd's tspend vote check (checkTSpendHasVotes)
+// to yes and no votes: the quorum is a fraction of TicketsPerBlock times the
+// full voting window, and yes must be at least the required fraction of the
+// votes cast, both rounded down as dcrd does.
+func tspendPassProjection(params *chaincfg.Params, yes, no int) *types.TSpendPassProjection {
+	maxVotes := uint64(params.TicketsPerBlock) * params.TreasuryVoteInterval * params.TreasuryVoteIntervalMultiplier
+	quorum := maxVotes * params.TreasuryVoteQuorumMultiplier / params.TreasuryVoteQuorumDivisor
+	reqMul, reqDiv := params.TreasuryVoteRequiredMultiplier, params.TreasuryVoteRequiredDivisor
+	approved := func(yes, cast uint64) bool {
+		return yes >= cast*reqMul/reqDiv
+	}
+
+	y, cast := uint64(yes), uint64(yes+no)
+	p := &types.TSpendPassProjection{
+		Quorum:           int(quorum),
+		RequiredApproval: float64(reqMul) / float64(reqDiv) * 100,
+	}
+	if cast < quorum {
+		p.QuorumVotesNeeded = int(quorum - cast)
+	}
+	// Each extra yes vote also adds to the votes cast: find the fewest k
+	// with y+k >= (cast+k) * reqMul / reqDiv.
+	if !approved(y, cast) && reqMul < reqDiv {
+		k := (reqMul*cast - reqDiv*y) / (reqDiv - reqMul)
+		for !approved(y+k, cast+k) {
+			k++
+		}
+		p.YesVotesNeeded = int(k)
+	}
+	p.WouldPassNow = p.QuorumVotesNeeded == 0 && p.YesVotesNeeded == 0
+	return p
+}
+
+// projectTSpendVotes fills info's projection fields by scaling the tally so
+// far over the elapsed part of the voting window, which ends at windowEnd.
+func projectTSpendVotes(info *types.TSpendVotingInfo, windowEnd int64) {
+	elapsed := info.VotingEndBlock - info.VotingStartBlock + 1
+	window := windowEnd - info.VotingStartBlock + 1
+	if elapsed > window {
+		elapsed = window
+	}
+	info.FirstSeenHeight = info.VotingStartBlock
+	info.ElapsedBlocks = elapsed
+	info.WindowBlocks = window
+	if elapsed <= 0 || window <= 0 {
+		return
+	}
+	info.WindowProgress = float64(elapsed) / float64(window) * 100
+
+	// Same estimates as the current tally: ~5 votes per block, with the
+	// approval ratio assumed to hold for the rest of the window.
+	info.ProjectedVotesCast = int(float64(info.VotesCast) * float64(window) / float64(elapsed))
+	if eligible := window * 5; eligible > 0 {
+		info.ProjectedTurnoutRate = float64(info.ProjectedVotesCast) / float64(eligible) * 100
+	}
+	info.ProjectedApprovalRate = info.ApprovalRate
 }
 
-// calculateTSpendVotesAsync calculates votes asynchronously with progress tracking
+// CancelVoteParsing stops the async vote count for txHash, if one is running.
+// The partial tally is never cached, so the next request starts a fresh count.
+func CancelVoteParsing(txHash string) bool {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+	cancel, ok := parsingJobs[txHash]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// calculateTSpendVotesAsync calculates votes asynchronously with progress
+// tracking. It stops when ctx is cancelled.
 func calculateTSpendVotesAsync(ctx context.Context, txHash string, blockHeight int64, expiry uint32, inMempool bool) {
 	defer func() {
-		// Clean up job tracking
+		// Clean up job tracking. This also runs when the count panics; the
+		// panic is logged rather than taking the server down, and the job
+		// is left failed so the next request starts it over.
+		if r := recover(); r != nil {
+			log.Printf("Vote counting for tspend %s panicked: %v\n%s", txHash, r, debug.Stack())
+			setVoteParsingProgress(txHash, &types.VoteParsingProgress{
+				Message: "Vote counting failed",
+			})
+		}
 		jobsMutex.Lock()
-		delete(parsingJobs, txHash)
+		if cancel, ok := parsingJobs[txHash]; ok {
+			cancel()
+			delete(parsingJobs, txHash)
+		}
 		jobsMutex.Unlock()
 	}()
 
-	if rpc.DcrdClient == nil {
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		log.Printf("Warning: vote count for %s: treasury params: %v", txHash, err)
 		return
 	}
 
 	// Determine voting period
 	var votingStartBlock, votingEndBlock int64
-	votingStartBlock = blockHeight - 2880
-	if votingStartBlock < TreasuryActivationHeight {
-		votingStartBlock = TreasuryActivationHeight
+	votingStartBlock = blockHeight - tp.VoteWindow
+	if votingStartBlock < tp.ActivationHeight {
+		votingStartBlock = tp.ActivationHeight
 	}
 	votingEndBlock = blockHeight
 
 	totalBlocks := votingEndBlock - votingStartBlock + 1 // +1 because we scan inclusively
 
 	// Initialize progress
-	progressMutex.Lock()
-	voteParsingProgress[txHash] = &types.VoteParsingProgress{
+	setVoteParsingProgress(txHash, &types.VoteParsingProgress{
 		IsParsing:     true,
 		Progress:      0,
 		CurrentBlock:  votingStartBlock,
@@ -767,11 +1150,11 @@ func calculateTSpendVotesAsync(ctx context.Context, txHash string, blockHeight i
 		NoVotes:       0,
 		EstimatedTime: int(totalBlocks / 10), // Rough estimate: 10 blocks/sec
 		Message:       "Starting vote count...",
-	}
-	progressMutex.Unlock()
+	})
 
 	// Count votes with progress updates
-	yesVotes, noVotes := 0, 0
+	var tally stakeTally
+	var blocks []types.TSpendBlockVotes // Per-block breakdown, for ExportTSpendVotes
 	startTime := time.Now()
 
 	// Limit scan range for performance
@@ -780,49 +1163,23 @@ func calculateTSpendVotesAsync(ctx context.Context, txHash string, blockHeight i
 		votingStartBlock = votingEndBlock - maxScanRange
 	}
 
-	for height := votingStartBlock; height <= votingEndBlock; height++ {
-		blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
-		if err != nil {
-			continue
+	lastCounted := votingStartBlock - 1
+	fetchStakeTxRange(ctx, votingStartBlock, votingEndBlock, fetchBlockStakeTxs, func(height int64, rawSTx []map[string]interface{}, err error) bool {
+		if ctx.Err() != nil {
+			// Lookups in this block may have been cut short; don't count it.
+			return false
 		}
-
-		blockResult, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
-			json.RawMessage(fmt.Sprintf(`"%s"`, blockHash.String())),
-			json.RawMessage("true"),
-			json.RawMessage("false"),
-		})
 		if err != nil {
-			continue
+			scanDebugf("Vote count for %s skipping block %d: %v", txHash, height, err)
+			return true
 		}
+		b := tally.addBlock(rawSTx, txHash)
+		b.Height = height
+		blocks = append(blocks, b)
 
-		var block struct {
-			STx []string `json:"stx"`
-		}
-		if err := json.Unmarshal(blockResult, &block); err != nil {
-			continue
-		}
-
-		// Check each stake transaction for votes
-		for _, stxHash := range block.STx {
-			tx, err := getTransaction(ctx, stxHash)
-			if err != nil {
-				continue
-			}
-
-			if !isVoteTransaction(tx) {
-				continue
-			}
-
-			vote := parseTSpendVote(tx, txHash)
-			if vote == "yes" {
-				yesVotes++
-			} else if vote == "no" {
-				noVotes++
-			}
-		}
-
-		// Update progress every 50 blocks
-		if height%50 == 0 || height == votingEndBlock {
+		// Update progress every voteProgressInterval blocks
+		if height%voteProgressInterval == 0 || height == votingEndBlock {
+			scanDebugf("Vote count for %s at block %d of %d: %d yes, %d no", txHash, height, votingEndBlock, tally.yes, tally.no)
 			blocksProcessed := height - votingStartBlock + 1 // +1 because we count inclusively
 			progress := float64(blocksProcessed) / float64(totalBlocks) * 100
 			elapsed := time.Since(startTime).Seconds()
@@ -833,29 +1190,47 @@ func calculateTSpendVotesAsync(ctx context.Context, txHash string, blockHeight i
 				estimatedTime = int(float64(blocksRemaining) * timePerBlock)
 			}
 
-			progressMutex.Lock()
-			voteParsingProgress[txHash] = &types.VoteParsingProgress{
+			setVoteParsingProgress(txHash, &types.VoteParsingProgress{
 				IsParsing:     height < votingEndBlock,
 				Progress:      progress,
 				CurrentBlock:  height,
 				TotalBlocks:   totalBlocks,
-				YesVotes:      yesVotes,
-				NoVotes:       noVotes,
+				YesVotes:      tally.yes,
+				NoVotes:       tally.no,
 				EstimatedTime: estimatedTime,
 				Message:       fmt.Sprintf("Scanning block %d of %d...", height, votingEndBlock),
-			}
-			progressMutex.Unlock()
+			})
 		}
+		lastCounted = height
+		return true
+	})
+
+	if ctx.Err() != nil {
+		// Leave the tally as of the last fully counted block and don't cache
+		// it: a partial count would otherwise be served as the final result.
+		setVoteParsingProgress(txHash, &types.VoteParsingProgress{
+			IsParsing:    false,
+			Progress:     float64(lastCounted-votingStartBlock+1) / float64(totalBlocks) * 100,
+			CurrentBlock: lastCounted,
+			TotalBlocks:  totalBlocks,
+			YesVotes:     tally.yes,
+			NoVotes:      tally.no,
+			Message:      fmt.Sprintf("Vote count cancelled at block %d", lastCounted),
+		})
+		log.Printf("Vote counting cancelled for tspend %s at block %d", txHash, lastCounted)
+		return
 	}
 
 	// Calculate final statistics
+	yesVotes, noVotes := tally.yes, tally.no
 	votesCast := yesVotes + noVotes
+	ticketsPerBlock := chainTicketsPerBlock(ctx)
 	var approvalRate, turnoutRate float64
 	if votesCast > 0 {
 		approvalRate = float64(yesVotes) / float64(votesCast) * 100
 	}
 
-	eligibleVotes := int(float64(totalBlocks) * 5)
+	eligibleVotes := tally.blocks * ticketsPerBlock
 	if eligibleVotes > 0 {
 		turnoutRate = float64(votesCast) / float64(eligibleVotes) * 100
 	}
@@ -873,6 +1248,9 @@ func calculateTSpendVotesAsync(ctx context.Context, txHash string, blockHeight i
 		NoVotes:          noVotes,
 		EligibleVotes:    eligibleVotes,
 		VotesCast:        votesCast,
+		AbstainVotes:     tally.votes - votesCast,
+		MissedVotes:      tally.missed(ticketsPerBlock),
+		Revocations:      tally.revocations,
 		QuorumRequired:   quorumRequired,
 		ApprovalRate:     approvalRate,
 		TurnoutRate:      turnoutRate,
@@ -884,30 +1262,28 @@ func calculateTSpendVotesAsync(ctx context.Context, txHash string, blockHeight i
 	}
 
 	// Cache the result
-	votingCacheMutex.Lock()
-	votingCache[txHash] = finalResult
-	votingCacheMutex.Unlock()
+	cacheVoteCount(txHash, finalResult, blocks)
 
 	// Mark progress as complete
-	progressMutex.Lock()
-	voteParsingProgress[txHash] = &types.VoteParsingProgress{
+	setVoteParsingProgress(txHash, &types.VoteParsingProgress{
 		IsParsing:     false,
 		Progress:      100,
 		CurrentBlock:  votingEndBlock,
 		TotalBlocks:   totalBlocks,
-		YesVotes:      yesVotes,
-		NoVotes:       noVotes,
+		YesVotes:      tally.yes,
+		NoVotes:       tally.no,
 		EstimatedTime: 0,
 		Message:       "Vote counting complete",
-	}
-	progressMutex.Unlock()
+	})
 
 	log.Printf("Vote counting complete for tspend %s: %d yes, %d no (%.1f%% approval)",
 		txHash, yesVotes, noVotes, approvalRate)
 }
 
-// countTSpendVotesInRange scans blocks and counts votes for a specific tspend
-func countTSpendVotesInRange(ctx context.Context, txHash string, startHeight, endHeight int64) (yesVotes int, noVotes int, err error) {
+// countTSpendVotesInRange scans blocks and tallies votes for a specific tspend
+// along with the window's missed votes and revocations.
+func countTSpendVotesInRange(ctx context.Context, txHash string, startHeight, endHeight int64) (stakeTally, error) {
+	var tally stakeTally
 	// Limit the scan range for performance
 	maxScanRange := int64(3000)
 	if endHeight-startHeight > maxScanRange {
@@ -915,52 +1291,97 @@ func countTSpendVotesInRange(ctx context.Context, txHash string, startHeight, en
 	}
 
 	// Scan blocks in range
-	for height := startHeight; height <= endHeight; height++ {
-		blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
-		if err != nil {
-			continue
-		}
-
-		// Get block with stake transactions
-		blockResult, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
-			json.RawMessage(fmt.Sprintf(`"%s"`, blockHash.String())),
-			json.RawMessage("true"),
-			json.RawMessage("false"),
-		})
-		if err != nil {
-			continue
+	fetchStakeTxRange(ctx, startHeight, endHeight, fetchBlockStakeTxs, func(_ int64, rawSTx []map[string]interface{}, err error) bool {
+		if err == nil {
+			tally.addBlock(rawSTx, txHash)
 		}
+		return true
+	})
 
-		var block struct {
-			STx []string `json:"stx"` // Stake transactions
-		}
-		if err := json.Unmarshal(blockResult, &block); err != nil {
-			continue
-		}
+	return tally, nil
+}
 
-		// Check each stake transaction for votes on this tspend
-		for _, stxHash := range block.STx {
-			tx, err := getTransaction(ctx, stxHash)
-			if err != nil {
-				continue
-			}
+// fetchBlockStakeTxs returns the stake transactions of the block at height.
+// verbosetx=true returns them inline (rawstx), so votes are read from the
+// block itself and need no txindex.
+func fetchBlockStakeTxs(ctx context.Context, height int64) ([]map[string]interface{}, error) {
+	blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block hash at %d: %w", height, err)
+	}
+	blockResult, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		json.RawMessage(fmt.Sprintf(`"%s"`, blockHash.String())),
+		json.RawMessage("true"),
+		json.RawMessage("true"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+	var block struct {
+		RawSTx []map[string]interface{} `json:"rawstx"`
+	}
+	if err := json.Unmarshal(blockResult, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block %d: %w", height, err)
+	}
+	return block.RawSTx, nil
+}
 
-			// Check if it's a vote transaction
-			if !isVoteTransaction(tx) {
-				continue
-			}
+// stakeTally is what a scan of blocks' stake trees found: the votes on one
+// tspend, and every vote and revocation, from which the vote slots no ticket
+// filled follow.
+type stakeTally struct {
+	yes, no     int
+	votes       int // All SSGen, whether or not they voted on the tspend
+	revocations int
+	blocks      int
+}
 
-			// Parse vote for this tspend
-			vote := parseTSpendVote(tx, txHash)
-			if vote == "yes" {
-				yesVotes++
-			} else if vote == "no" {
-				noVotes++
+// addBlock counts one block's stake transactions and returns that block's
+// votes on the tspend; Height is left for the caller to set.
+func (t *stakeTally) addBlock(rawSTx []map[string]interface{}, tspendHash string) types.TSpendBlockVotes {
+	t.blocks++
+	// A hash that doesn't parse matches no vote: every vote abstains.
+	var tspend chainhash.Hash
+	if h, err := chainhash.NewHashFromStr(tspendHash); err == nil {
+		tspend = *h
+	}
+	var b types.TSpendBlockVotes
+	for _, tx := range rawSTx {
+		switch {
+		case isVoteTransaction(tx):
+			t.votes++
+			switch parseTSpendVote(tx, tspend) {
+			case tspendYes:
+				t.yes++
+				b.Yes++
+			case tspendNo:
+				t.no++
+				b.No++
+			default:
+				b.Abstain++
 			}
+		case isRevocation(tx):
+			t.revocations++
 		}
 	}
+	return b
+}
+
+// missed returns the vote slots in the counted blocks that no vote filled.
+func (t *stakeTally) missed(ticketsPerBlock int) int {
+	if m := t.blocks*ticketsPerBlock - t.votes; m > 0 {
+		return m
+	}
+	return 0
+}
 
-	return yesVotes, noVotes, nil
+// chainTicketsPerBlock returns the network's votes per block, falling back to
+// mainnet's when the params can't be resolved.
+func chainTicketsPerBlock(ctx context.Context) int {
+	if params, err := CurrentChainParams(ctx); err == nil {
+		return int(params.TicketsPerBlock)
+	}
+	return int(chaincfg.MainNetParams().TicketsPerBlock)
 }
 
 // isVoteTransaction checks if a transaction is a vote (SSGen)
@@ -980,121 +1401,6 @@ func isVoteTransaction(tx map[string]interface{}) bool {
 	return hasStakebase
 }
 
-// parseTSpendVote attempts to parse vote bits to determine vote on tspend
-func parseTSpendVote(tx map[string]interface{}, tspendHash string) string {
-	// Get vout to extract vote bits
-	vout, ok := tx["vout"].([]interface{})
-	if !ok || len(vout) < 2 {
-		return "unknown"
-	}
-
-	// Vote transactions have multiple OP_RETURN outputs
-	// We need to find the one that contains the tspend hash
-	for _, output := range vout {
-		voutMap, ok := output.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		scriptPubKey, ok := voutMap["scriptPubKey"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		scriptType, _ := scriptPubKey["type"].(string)
-		if scriptType != "nulldata" {
-			continue
-		}
-
-		// Get hex data
-		hexData, ok := scriptPubKey["hex"].(string)
-		if !ok || hexData == "" {
-			continue
-		}
-
-		// Check if this output contains tspend vote data
-		vote := parseVoteBitsForTSpend(hexData, tspendHash)
-		if vote != "unknown" {
-			return vote
-		}
-	}
-
-	return "unknown"
-}
-
-// parseVoteBitsForTSpend extracts tspend vote from vote bits hex
-// Treasury spend votes are encoded in OP_RETURN outputs that contain:
-// [OP_RETURN][length][prefix][tspend_hash_reversed][vote_bits]
-func parseVoteBitsForTSpend(hexData string, tspendHash string) string {
-	// Decode hex to bytes
-	if len(hexData) < 4 {
-		return "unknown"
-	}
-
-	// Skip OP_RETURN opcode (0x6a) and length byte
-	dataStart := 4
-	if len(hexData) <= dataStart {
-		return "unknown"
-	}
-
-	dataHex := hexData[dataStart:]
-
-	// TSpend votes have a 2-byte prefix before the hash
-	// Format: [2 bytes prefix][32 bytes tspend hash][1+ bytes vote bits]
-	// Skip the first 2 bytes (4 hex chars)
-	if len(dataHex) < 4 {
-		return "unknown"
-	}
-
-	dataWithoutPrefix := dataHex[4:]
-
-	// Tspend hash is 32 bytes (64 hex chars)
-	if len(dataWithoutPrefix) < 64 {
-		return "unknown"
-	}
-
-	// Extract the hash portion (first 64 hex chars = 32 bytes)
-	hashHex := dataWithoutPrefix[:64]
-
-	// Reverse the hash bytes to match tspend format
-	// Transaction hashes are stored in reverse byte order
-	reversedHash := reverseHexBytes(hashHex)
-
-	// Check if this matches our tspend hash
-	if !strings.EqualFold(reversedHash, tspendHash) {
-		return "unknown"
-	}
-
-	// Found matching tspend! Now extract vote bits
-	// Vote bits follow the hash
-	if len(dataWithoutPrefix) < 66 { // Need at least 2 more hex chars for vote byte
-		return "unknown"
-	}
-
-	// Get the vote byte (after the 64-char hash)
-	voteHex := dataWithoutPrefix[64:66]
-	var voteByte byte
-	fmt.Sscanf(voteHex, "%02x", &voteByte)
-
-	// Extract the vote choice from the byte
-	// Tspend votes use 2 bits: 00=abstain, 01=yes, 10=no, 11=invalid
-	// The vote bits are in the lower 2 bits
-	voteChoice := voteByte & 0x03
-
-	switch voteChoice {
-	case 0x00:
-		return "abstain"
-	case 0x01:
-		return "yes"
-	case 0x02:
-		return "no"
-	case 0x03:
-		return "invalid"
-	default:
-		return "unknown"
-	}
-}
-
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -1102,24 +1408,6 @@ func min(a, b int) int {
 	return b
 }
 
-// reverseHexBytes reverses a hex string by bytes
-// Input: "abcd1234" -> Output: "3412cdab"
-func reverseHexBytes(hexStr string) string {
-	if len(hexStr)%2 != 0 {
-		return hexStr
-	}
-
-	result := make([]byte, len(hexStr))
-	for i := 0; i < len(hexStr); i += 2 {
-		// Copy each byte pair in reverse order
-		srcPos := len(hexStr) - i - 2
-		result[i] = hexStr[srcPos]
-		result[i+1] = hexStr[srcPos+1]
-	}
-
-	return string(result)
-}
-
 // getBlockTimestamps retrieves timestamps for start and end blocks
 func getBlockTimestamps(ctx context.Context, startHeight, endHeight int64) (time.Time, time.Time) {
 	var startTime, endTime time.Time
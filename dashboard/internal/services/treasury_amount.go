@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+
+	"dcrpulse/internal/types"
+)
+
+// Treasury amounts are summed as int64 atoms and converted to DCR only when a
+// response is built. dcrd's verbose "value" fields are floats; each one is
+// rounded to its exact atom count before summing, so totals over many
+// outputs carry no floating-point drift.
+
+// voutAtoms returns the atom value of one verbose transaction output.
+func voutAtoms(vout map[string]interface{}) int64 {
+	value, _ := vout["value"].(float64)
+	amt, err := dcrutil.NewAmount(value)
+	if err != nil {
+		return 0
+	}
+	return int64(amt)
+}
+
+// sumTSpendOutputs returns a verbose treasury spend's paying (treasurygen)
+// outputs and their total in atoms. The OP_RETURN output carries no value
+// to a recipient and is left out.
+func sumTSpendOutputs(tx map[string]interface{}) (atoms int64, payees []types.TSpendPayee) {
+	payees = treasuryGenPayees(tx)
+	for _, p := range payees {
+		atoms += p.AmountAtoms
+	}
+	return atoms, payees
+}
+
+// lastPayee is the address of the last paying output, the value the single
+// Payee field has always reported.
+func lastPayee(payees []types.TSpendPayee) string {
+	if len(payees) == 0 {
+		return ""
+	}
+	return payees[len(payees)-1].Address
+}
+
+// atomsToCoin converts atoms to a DCR float for the numeric response fields.
+func atomsToCoin(atoms int64) float64 {
+	return dcrutil.Amount(atoms).ToCoin()
+}
+
+// formatDCR renders atoms as an exact DCR decimal string with all 8 places,
+// e.g. "1234.50000000".
+func formatDCR(atoms int64) string {
+	sign := ""
+	if atoms < 0 {
+		sign = "-"
+		atoms = -atoms
+	}
+	return fmt.Sprintf("%s%d.%08d", sign, atoms/dcrutil.AtomsPerCoin, atoms%dcrutil.AtomsPerCoin)
+}
+
+// ErrInvalidAmountRange is an amount filter that isn't a non-negative DCR
+// amount, or whose minimum is above its maximum.
+var ErrInvalidAmountRange = errors.New("invalid amount range")
+
+// AmountRange bounds a tspend's total in atoms, inclusive; a nil bound is
+// open.
+type AmountRange struct {
+	Min *int64
+	Max *int64
+}
+
+// ParseAmountRange reads a minimum and maximum given in DCR, either of which
+// may be empty to leave that side open.
+func ParseAmountRange(minDCR, maxDCR string) (AmountRange, error) {
+	var r AmountRange
+	for _, b := range []struct {
+		name  string
+		value string
+		dst   **int64
+	}{{"minAmount", minDCR, &r.Min}, {"maxAmount", maxDCR, &r.Max}} {
+		if b.value == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(b.value, 64)
+		if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+			return AmountRange{}, fmt.Errorf("%w: %s %q", ErrInvalidAmountRange, b.name, b.value)
+		}
+		amt, err := dcrutil.NewAmount(f)
+		if err != nil {
+			return AmountRange{}, fmt.Errorf("%w: %s %q", ErrInvalidAmountRange, b.name, b.value)
+		}
+		atoms := int64(amt)
+		*b.dst = &atoms
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return AmountRange{}, fmt.Errorf("%w: minAmount above maxAmount", ErrInvalidAmountRange)
+	}
+	return r, nil
+}
+
+// contains reports whether atoms is within r.
+func (r AmountRange) contains(atoms int64) bool {
+	return (r.Min == nil || atoms >= *r.Min) && (r.Max == nil || atoms <= *r.Max)
+}
+
+// FilterTSpendsByAmount returns the tspends whose total is within r, in
+// their order, and the sum of those totals in atoms.
+func FilterTSpendsByAmount(tspends []types.TSpendHistory, r AmountRange) ([]types.TSpendHistory, int64) {
+	filtered := make([]types.TSpendHistory, 0, len(tspends))
+	var total int64
+	for _, t := range tspends {
+		if r.contains(t.AmountAtoms) {
+			filtered = append(filtered, t)
+			total += t.AmountAtoms
+		}
+	}
+	return filtered, total
+}
+
+// FilterScanResults returns the scan results whose total is within r, with
+// the meta describing them.
+func FilterScanResults(r AmountRange) ([]types.TSpendHistory, types.ScanResultsMeta) {
+	results, total := FilterTSpendsByAmount(GetScanResults(), r)
+	failed := ScanFailedHeights()
+	return results, types.ScanResultsMeta{
+		FailedHeights: failed,
+		Complete:      len(failed) == 0,
+		Count:         len(results),
+		Total:         atomsToCoin(total),
+		TotalAtoms:    total,
+		TotalDCR:      formatDCR(total),
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+// TestSumTSpendOutputsExact sums 10,000 outputs of 0.1 DCR. Adding the float
+// values drifts off 1000 DCR; the atom total is exact.
+func TestSumTSpendOutputsExact(t *testing.T) {
+	const n = 10000
+	vout := make([]interface{}, n)
+	var floatSum float64
+	for i := range vout {
+		vout[i] = map[string]interface{}{
+			"value": 0.1,
+			"scriptPubKey": map[string]interface{}{
+				"type":      "treasurygenpubkeyhash",
+				"addresses": []interface{}{"DsPayee"},
+			},
+		}
+		floatSum += 0.1
+	}
+	if floatSum == 1000 {
+		t.Fatalf("float sum unexpectedly exact; test no longer demonstrates drift")
+	}
+
+	atoms, _ := sumTSpendOutputs(map[string]interface{}{"vout": vout})
+	if atoms != 1000e8 {
+		t.Fatalf("atom sum = %d, want %d", atoms, int64(1000e8))
+	}
+	if got := formatDCR(atoms); got != "1000.00000000" {
+		t.Fatalf("formatDCR = %q, want 1000.00000000", got)
+	}
+}
+
+func TestFormatDCR(t *testing.T) {
+	tests := []struct {
+		atoms int64
+		want  string
+	}{
+		{0, "0.00000000"},
+		{1, "0.00000001"},
+		{123456789012, "1234.56789012"},
+		{-150000000, "-1.50000000"},
+	}
+	for _, tc := range tests {
+		if got := formatDCR(tc.atoms); got != tc.want {
+			t.Errorf("formatDCR(%d) = %q, want %q", tc.atoms, got, tc.want)
+		}
+	}
+}
+
+func TestEstimateTSpendExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := estimateTSpendExpiry(1000, 288, 5*time.Minute, now); got == nil || !got.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("expiry in 288 mainnet blocks = %v, want a day on", got)
+	}
+	if got := estimateTSpendExpiry(1000, -3, 5*time.Minute, now); got == nil || !got.Equal(now) {
+		t.Errorf("past expiry = %v, want now", got)
+	}
+	if estimateTSpendExpiry(0, 288, 5*time.Minute, now) != nil || estimateTSpendExpiry(1000, 288, 0, now) != nil {
+		t.Error("expiry dated without a tip height or block time")
+	}
+}
+
+func TestFilterTSpendsByAmount(t *testing.T) {
+	tspends := []types.TSpendHistory{
+		{TxHash: "a", AmountAtoms: 50e8},
+		{TxHash: "b", AmountAtoms: 1000e8},
+		{TxHash: "c", AmountAtoms: 25000e8},
+	}
+	tests := []struct {
+		min, max string
+		want     []string
+		total    int64
+	}{
+		{"", "", []string{"a", "b", "c"}, 26050e8},
+		{"1000", "", []string{"b", "c"}, 26000e8},
+		{"", "1000", []string{"a", "b"}, 1050e8},
+		{"50.00000001", "24999.99999999", []string{"b"}, 1000e8},
+		{"30000", "", nil, 0},
+	}
+	for _, tt := range tests {
+		r, err := ParseAmountRange(tt.min, tt.max)
+		if err != nil {
+			t.Fatalf("ParseAmountRange(%q, %q): %v", tt.min, tt.max, err)
+		}
+		got, total := FilterTSpendsByAmount(tspends, r)
+		var hashes []string
+		for _, g := range got {
+			hashes = append(hashes, g.TxHash)
+		}
+		if len(hashes) != len(tt.want) || total != tt.total {
+			t.Errorf("min %q max %q: got %v total %d, want %v total %d", tt.min, tt.max, hashes, total, tt.want, tt.total)
+			continue
+		}
+		for i := range hashes {
+			if hashes[i] != tt.want[i] {
+				t.Errorf("min %q max %q: got %v, want %v", tt.min, tt.max, hashes, tt.want)
+				break
+			}
+		}
+	}
+
+	for _, bad := range [][2]string{{"-1", ""}, {"", "abc"}, {"NaN", ""}, {"", "Inf"}, {"10", "5"}} {
+		if _, err := ParseAmountRange(bad[0], bad[1]); !errors.Is(err, ErrInvalidAmountRange) {
+			t.Errorf("ParseAmountRange(%q, %q) = %v, want ErrInvalidAmountRange", bad[0], bad[1], err)
+		}
+	}
+}
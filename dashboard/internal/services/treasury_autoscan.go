@@ -0,0 +1,152 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// autoScanTimeout bounds one catch-up run of the auto-scan.
+const autoScanTimeout = 5 * time.Minute
+
+// The auto-scan extends the scan results to each new block as dcrd reports
+// it, so one full scan is enough to keep them current. It is off unless
+// ConfigureTreasuryAutoScan turns it on.
+var (
+	autoScanEnabled bool
+	autoScanCh      = make(chan struct{}, 1)
+	autoScanOnce    sync.Once
+)
+
+// ConfigureTreasuryAutoScan turns the auto-scan on or off.
+func ConfigureTreasuryAutoScan(enabled bool) {
+	autoScanEnabled = enabled
+}
+
+// TriggerTreasuryAutoScan asks the auto-scan to catch up with the chain tip
+// (non-blocking, coalesced). Called from the dcrd block-connected handler.
+func TriggerTreasuryAutoScan() {
+	if !autoScanEnabled {
+		return
+	}
+	autoScanOnce.Do(func() { go runTreasuryAutoScan() })
+	select {
+	case autoScanCh <- struct{}{}:
+	default:
+	}
+}
+
+func runTreasuryAutoScan() {
+	for range autoScanCh {
+		ctx, cancel := context.WithTimeout(RootContext(), autoScanTimeout)
+		if err := autoScanNewBlocks(ctx); err != nil {
+			log.Printf("Treasury auto-scan: %v", err)
+		}
+		cancel()
+	}
+}
+
+// autoScanNewBlocks scans the blocks after scanCoveredHeight through the tip
+// into the results. Until a full, recent or range scan has finished there is
+// nothing to extend, and while any scan runs it is left to reach the tip: a
+// block the auto-scan has read is merged only if the results still end just
+// before it, so a block is never counted twice. A block that can't be read
+// stops the run, to be retried on the next block. Blocks a reorg orphaned
+// are rolled back first, as checkAutoScanChain describes.
+func autoScanNewBlocks(ctx context.Context) error {
+	if rpc.DcrdClient == nil {
+		return rpc.NotConnected("dcrd client not available")
+	}
+	scanMutex.RLock()
+	covered, running := scanCoveredHeight, isScanRunning
+	scanMutex.RUnlock()
+	if covered == 0 || running {
+		return nil
+	}
+
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block count: %w", err)
+	}
+	if err := checkAutoScanChain(ctx, tip); err != nil {
+		return err
+	}
+	scanMutex.RLock()
+	covered, running = scanCoveredHeight, isScanRunning
+	scanMutex.RUnlock()
+	if covered == 0 || running || tip <= covered {
+		return nil
+	}
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		return fmt.Errorf("treasury params: %w", err)
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return fmt.Errorf("chain params: %w", err)
+	}
+
+	// As in the historical scan only TVI blocks can hold a tspend; the
+	// treasurybase of the blocks between is counted at the next one read.
+	for _, h := range scanHeights(ScanProfile{}, covered+1, tip, tp.VoteInterval) {
+		block, err := fetchScanBlockRetry(ctx, h)
+		if err != nil {
+			return fmt.Errorf("could not read block %d: %w", h, err)
+		}
+		if !mergeAutoScanBlock(block, covered, params, tp.ActivationHeight) {
+			return nil
+		}
+		scanDebugf("Treasury auto-scan read block %d", h)
+		covered = h
+	}
+	coverAutoScanThrough(covered, tip)
+	return nil
+}
+
+// mergeAutoScanBlock adds block's tspends and treasurybase run to the results
+// and moves scanCoveredHeight on to it, provided no scan is running and the
+// results still end at after. It reports whether it did.
+func mergeAutoScanBlock(block *scanBlock, after int64, params *chaincfg.Params, activation int64) bool {
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+	if isScanRunning || scanCoveredHeight != after {
+		return false
+	}
+	addScanBlockLocked(block, newTreasuryBaseCounter(params, activation, treasuryBaseNextLocked()))
+	scanCoveredHeight = block.Height
+	scanLastRead = scanBlockRef{height: block.Height, hash: block.Hash}
+	scanChangedLocked()
+	return true
+}
+
+// coverAutoScanThrough moves scanCoveredHeight from after on to tip, over
+// blocks that can't hold a tspend, under the same conditions as
+// mergeAutoScanBlock.
+func coverAutoScanThrough(after, tip int64) {
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+	if isScanRunning || scanCoveredHeight != after || tip <= after {
+		return
+	}
+	scanCoveredHeight = tip
+	scanChangedLocked()
+}
+
+// treasuryBaseNextLocked is the first block the results' treasurybase inflows
+// don't count yet. It must be called with scanMutex held.
+func treasuryBaseNextLocked() int64 {
+	if n := len(scanTreasuryBase); n > 0 {
+		return scanTreasuryBase[n-1].height + 1
+	}
+	return scanStartHeight
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestMergeAutoScanBlock(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	const activation = 552448
+	tvi := int64(params.TreasuryVoteInterval)
+	covered := int64(activation + 10*tvi)
+
+	scanMutex.Lock()
+	saved := struct {
+		results  []types.TSpendHistory
+		tbase    []treasuryBaseInflow
+		covered  int64
+		running  bool
+		found    int
+		buffered []types.TSpendHistory
+		last     scanBlockRef
+	}{scanResults, scanTreasuryBase, scanCoveredHeight, isScanRunning, tspendFoundCount, newTSpendBuffer, scanLastRead}
+	scanResults = []types.TSpendHistory{{TxHash: "aa", BlockHeight: covered}}
+	scanTreasuryBase = []treasuryBaseInflow{{height: covered, blocks: 1}}
+	scanCoveredHeight = covered
+	isScanRunning = false
+	tspendFoundCount = 1
+	newTSpendBuffer = nil
+	scanMutex.Unlock()
+	t.Cleanup(func() {
+		scanMutex.Lock()
+		scanResults, scanTreasuryBase, scanCoveredHeight = saved.results, saved.tbase, saved.covered
+		isScanRunning, tspendFoundCount, newTSpendBuffer = saved.running, saved.found, saved.buffered
+		scanLastRead = saved.last
+		scanMutex.Unlock()
+	})
+
+	out := func(typ string) interface{} {
+		return map[string]interface{}{"value": 1.0, "scriptPubKey": map[string]interface{}{"type": typ}}
+	}
+	tspend := func(txid string) map[string]interface{} {
+		return map[string]interface{}{
+			"txid":    txid,
+			"version": 3.0,
+			"vin":     []interface{}{map[string]interface{}{"treasuryspend": "00"}},
+			"vout":    []interface{}{out("nulldata"), out("treasurygen-pubkeyhash")},
+		}
+	}
+	next := covered + tvi
+	block := &scanBlock{
+		Hash:   "bb",
+		Height: next,
+		// "aa" is already in the results and is not added again.
+		RawSTx: []map[string]interface{}{tspend("aa"), tspend("cc")},
+	}
+
+	// Results that have moved on since the block was read are left alone.
+	if mergeAutoScanBlock(block, covered-tvi, params, activation) {
+		t.Fatal("merged onto stale results")
+	}
+	scanMutex.Lock()
+	isScanRunning = true
+	scanMutex.Unlock()
+	if mergeAutoScanBlock(block, covered, params, activation) {
+		t.Fatal("merged while a scan runs")
+	}
+	scanMutex.Lock()
+	isScanRunning = false
+	scanMutex.Unlock()
+
+	if !mergeAutoScanBlock(block, covered, params, activation) {
+		t.Fatal("block not merged")
+	}
+	// Merging the same block again would count it twice.
+	if mergeAutoScanBlock(block, covered, params, activation) {
+		t.Fatal("block merged twice")
+	}
+
+	scanMutex.RLock()
+	if scanCoveredHeight != next {
+		t.Errorf("covered height = %d, want %d", scanCoveredHeight, next)
+	}
+	if scanLastRead != (scanBlockRef{height: next, hash: "bb"}) {
+		t.Errorf("last read = %+v, want bb at %d", scanLastRead, next)
+	}
+	if len(scanResults) != 2 || scanResults[1].TxHash != "cc" || scanResults[1].BlockHeight != next {
+		t.Errorf("results = %+v, want aa then cc at %d", scanResults, next)
+	}
+	if tspendFoundCount != 2 || len(newTSpendBuffer) != 1 {
+		t.Errorf("found %d, buffered %d; want 2, 1", tspendFoundCount, len(newTSpendBuffer))
+	}
+	// The treasurybase run picks up after the last one counted; with no
+	// treasurybase in the block, its scheduled amount is counted.
+	if len(scanTreasuryBase) != 2 {
+		t.Errorf("treasurybase runs = %d, want 2", len(scanTreasuryBase))
+	} else if in := scanTreasuryBase[1]; in.height != next || in.blocks != tvi {
+		t.Errorf("treasurybase run ends %d over %d blocks, want %d over %d", in.height, in.blocks, next, tvi)
+	}
+	scanMutex.RUnlock()
+
+	coverAutoScanThrough(next, next+5)
+	if scanCoveredHeight != next+5 {
+		t.Errorf("covered height = %d, want %d", scanCoveredHeight, next+5)
+	}
+}
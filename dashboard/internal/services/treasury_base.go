@@ -0,0 +1,157 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// isTreasuryBase reports whether tx is a treasurybase: the version 3 stake
+// transaction that pays a block's treasury subsidy in. dcrd marks its only
+// input "treasurybase", and its first output is the treasuryadd (OP_TADD)
+// that credits the treasury.
+func isTreasuryBase(tx map[string]interface{}) bool {
+	version, _ := tx["version"].(float64)
+	return version == 3 &&
+		inputHasField(tx, "treasurybase") &&
+		outputScriptType(tx, 0) == "treasuryadd"
+}
+
+// treasuryBaseAtoms returns the amount a treasurybase pays in.
+func treasuryBaseAtoms(tx map[string]interface{}) int64 {
+	vout, _ := tx["vout"].([]interface{})
+	if len(vout) == 0 {
+		return 0
+	}
+	out, _ := vout[0].(map[string]interface{})
+	return voutAtoms(out)
+}
+
+// treasuryBaseInflow is the treasurybase paid in over a run of blocks ending
+// at a block the historical scan visited, dated by that block.
+type treasuryBaseInflow struct {
+	height int64 // Last block of the run
+	time   time.Time
+	blocks int64
+	atoms  int64
+}
+
+// treasuryBaseCounter totals treasurybase inflows for a scan that strides
+// through the chain. Only the visited blocks are read, so the blocks between
+// them are counted from the subsidy schedule: with the treasury agenda active
+// the treasury subsidy is a fixed share of the block subsidy whatever the
+// number of votes, so the schedule is exact. A visited block's own
+// treasurybase is taken as read, and a disagreement is logged.
+type treasuryBaseCounter struct {
+	subsidy    *standalone.SubsidyCache
+	voters     uint16
+	activation int64
+	next       int64 // First block not yet counted
+}
+
+// newTreasuryBaseCounter starts counting at start, or at the treasury
+// activation height if that is later: no block before it has a treasurybase.
+func newTreasuryBaseCounter(params *chaincfg.Params, activation, start int64) *treasuryBaseCounter {
+	return &treasuryBaseCounter{
+		subsidy:    standalone.NewSubsidyCache(params),
+		voters:     params.TicketsPerBlock,
+		activation: activation,
+		next:       max(start, activation),
+	}
+}
+
+// treasuryActiveAt reports whether the treasury agenda is active at height,
+// given the network's activation height. Before it, the treasury subsidy was
+// paid by the coinbase to the organization's address, and nothing flowed into
+// the treasury account.
+func treasuryActiveAt(height, activation int64) bool {
+	return activation > 0 && height >= activation
+}
+
+// scheduled returns the treasury subsidy the block at height pays into the
+// treasury account: none before activation.
+func (c *treasuryBaseCounter) scheduled(height int64) int64 {
+	if !treasuryActiveAt(height, c.activation) {
+		return 0
+	}
+	return c.subsidy.CalcTreasurySubsidy(height, c.voters, true)
+}
+
+// add counts the blocks from the last one counted through height, where the
+// scan read observed atoms in the block's treasurybase (found false when it
+// has none), and returns the run. It reports false for a block before
+// activation, which has nothing to count.
+func (c *treasuryBaseCounter) add(height int64, blockTime time.Time, observed int64, found bool) (treasuryBaseInflow, bool) {
+	if !treasuryActiveAt(height, c.activation) {
+		return treasuryBaseInflow{}, false
+	}
+	in := treasuryBaseInflow{height: height, time: blockTime}
+	for h := c.next; h < height; h++ {
+		in.atoms += c.scheduled(h)
+		in.blocks++
+	}
+	want := c.scheduled(height)
+	switch {
+	case !found:
+		log.Printf("Warning: Block %d has no treasurybase; counting the scheduled %d atoms", height, want)
+		observed = want
+	case observed != want:
+		log.Printf("Warning: Block %d treasurybase pays %d atoms, schedule says %d", height, observed, want)
+	}
+	in.atoms += observed
+	in.blocks++
+	c.next = height + 1
+	return in, true
+}
+
+// splice returns runs with window, the runs a recent or range scan counted,
+// put in place of the blocks they cover. A run reaching into the window from
+// either side is cut at its edge, the part outside recounted from the
+// schedule: the part before keeps none of its observed last block and is
+// dated by the window's first run, the part after keeps its own.
+func (c *treasuryBaseCounter) splice(runs, window []treasuryBaseInflow) []treasuryBaseInflow {
+	from := window[0].height - window[0].blocks + 1
+	to := window[len(window)-1].height
+	var before, after []treasuryBaseInflow
+	for _, in := range runs {
+		first := in.height - in.blocks + 1
+		if first < from {
+			head := in
+			if in.height >= from {
+				head = treasuryBaseInflow{height: from - 1, time: window[0].time}
+				for h := first; h < from; h++ {
+					head.atoms += c.scheduled(h)
+					head.blocks++
+				}
+			}
+			before = append(before, head)
+		}
+		if in.height > to {
+			tail := in
+			if first <= to {
+				tail.blocks = in.height - to
+				for h := first; h <= to; h++ {
+					tail.atoms -= c.scheduled(h)
+				}
+			}
+			after = append(after, tail)
+		}
+	}
+	out := append(before, window...)
+	return append(out, after...)
+}
+
+// sumTreasuryBase totals inflows in atoms.
+func sumTreasuryBase(inflows []treasuryBaseInflow) int64 {
+	var atoms int64
+	for _, in := range inflows {
+		atoms += in.atoms
+	}
+	return atoms
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestIsTreasuryBase(t *testing.T) {
+	out := func(typ string) interface{} {
+		return map[string]interface{}{"value": 1.5, "scriptPubKey": map[string]interface{}{"type": typ}}
+	}
+	tbase := map[string]interface{}{
+		"version": 3.0,
+		"vin":     []interface{}{map[string]interface{}{"treasurybase": true}},
+		"vout":    []interface{}{out("treasuryadd"), out("nulldata")},
+	}
+	tspend := map[string]interface{}{
+		"version": 3.0,
+		"vin":     []interface{}{map[string]interface{}{"treasuryspend": "00"}},
+		"vout":    []interface{}{out("nulldata"), out("treasurygen-pubkeyhash")},
+	}
+	tadd := map[string]interface{}{
+		"version": 3.0,
+		"vin":     []interface{}{map[string]interface{}{"txid": "ab"}},
+		"vout":    []interface{}{out("treasuryadd")},
+	}
+
+	if !isTreasuryBase(tbase) || isTreasurySpend(tbase) {
+		t.Error("treasurybase: want base, not spend")
+	}
+	if got := treasuryBaseAtoms(tbase); got != 1.5e8 {
+		t.Errorf("treasuryBaseAtoms = %d, want 150000000", got)
+	}
+	if isTreasuryBase(tspend) || !isTreasurySpend(tspend) {
+		t.Error("tspend: want spend, not base")
+	}
+	if isTreasuryBase(tadd) {
+		t.Error("tadd: want not base")
+	}
+}
+
+func TestTreasuryBaseCounter(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	const activation = 552448
+	c := newTreasuryBaseCounter(params, activation, activation-1000)
+
+	if _, ok := c.add(activation-10, time.Time{}, 0, false); ok {
+		t.Fatal("block before activation counted")
+	}
+
+	// The first visited block's run reaches back to activation.
+	h := int64(activation + 287)
+	per := c.scheduled(h)
+	in, ok := c.add(h, time.Time{}, per, true)
+	if !ok || in.blocks != 288 || in.height != h {
+		t.Fatalf("first run = %+v, want 288 blocks ending at %d", in, h)
+	}
+	if in.atoms != 288*per {
+		t.Errorf("first run atoms = %d, want %d", in.atoms, 288*per)
+	}
+
+	// The next run crosses a subsidy reduction at 552960, and the visited
+	// block's own treasurybase is taken as read.
+	var want int64
+	for x := h + 1; x < h+288; x++ {
+		want += c.scheduled(x)
+	}
+	want += 12345
+	in, _ = c.add(h+288, time.Time{}, 12345, true)
+	if in.blocks != 288 || in.atoms != want {
+		t.Errorf("second run = %+v, want 288 blocks, %d atoms", in, want)
+	}
+	if c.scheduled(h+1) == c.scheduled(h+288) {
+		t.Error("second run does not cross a subsidy reduction")
+	}
+}
+
+func TestTreasuryBaseCounterActivationBoundary(t *testing.T) {
+	for _, params := range []*chaincfg.Params{chaincfg.MainNetParams(), chaincfg.SimNetParams()} {
+		activation, ok := treasuryActivationFromParams(params)
+		if !ok {
+			t.Fatalf("%s: no activation height", params.Name)
+		}
+		if treasuryActiveAt(activation-1, activation) || !treasuryActiveAt(activation, activation) {
+			t.Errorf("%s: active either side of %d is wrong", params.Name, activation)
+		}
+
+		c := newTreasuryBaseCounter(params, activation, activation-10)
+		if got := c.scheduled(activation - 1); got != 0 {
+			t.Errorf("%s: scheduled just below activation = %d, want 0", params.Name, got)
+		}
+		// After activation the treasury gets its full tenth of the block
+		// subsidy, whatever the votes.
+		full := c.subsidy.CalcBlockSubsidy(activation) / 10
+		if got := c.scheduled(activation); got != full {
+			t.Errorf("%s: scheduled at activation = %d, want %d", params.Name, got, full)
+		}
+
+		// A run visited just past activation counts nothing from before it.
+		in, ok := c.add(activation+1, time.Time{}, full, true)
+		if !ok || in.blocks != 2 || in.atoms != c.scheduled(activation)+full {
+			t.Errorf("%s: run across activation = %+v, want 2 blocks", params.Name, in)
+		}
+	}
+	if treasuryActiveAt(100, 0) {
+		t.Error("unknown activation taken as active")
+	}
+}
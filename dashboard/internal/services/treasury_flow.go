@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+// Treasury flow bucket intervals.
+const (
+	FlowIntervalMonth = "month"
+	FlowIntervalWeek  = "week"
+)
+
+// ErrInvalidFlowInterval is returned by TreasuryFlow for an interval other
+// than FlowIntervalMonth or FlowIntervalWeek.
+var ErrInvalidFlowInterval = errors.New("interval must be month or week")
+
+// TreasuryFlow buckets the historical scan results by interval. It reads only
+// the stored scan results and makes no RPC calls; until a scan has run the
+// series is empty.
+func TreasuryFlow(interval string) (*types.TreasuryFlow, error) {
+	if interval == "" {
+		interval = FlowIntervalMonth
+	}
+	if interval != FlowIntervalMonth && interval != FlowIntervalWeek {
+		return nil, ErrInvalidFlowInterval
+	}
+	inflows := scanTreasuryBaseInflows()
+	return &types.TreasuryFlow{
+		Interval:          interval,
+		Buckets:           bucketTreasuryFlow(GetScanResults(), inflows, interval),
+		TreasuryBaseTotal: atomsToCoin(sumTreasuryBase(inflows)),
+	}, nil
+}
+
+// bucketTreasuryFlow sums spends and treasurybase inflows into contiguous
+// interval buckets from the earliest to the latest of either, accumulating
+// the cumulative net. An inflow is dated by the visited block that ends its
+// run, so it can land up to one stride after some of the blocks it covers.
+func bucketTreasuryFlow(spends []types.TSpendHistory, inflows []treasuryBaseInflow, interval string) []types.TreasuryFlowBucket {
+	buckets := []types.TreasuryFlowBucket{}
+	if len(spends) == 0 && len(inflows) == 0 {
+		return buckets
+	}
+	sort.Slice(spends, func(i, j int) bool {
+		return spends[i].Timestamp.Before(spends[j].Timestamp)
+	})
+	sort.Slice(inflows, func(i, j int) bool {
+		return inflows[i].time.Before(inflows[j].time)
+	})
+
+	var first, latest time.Time
+	widen := func(t time.Time) {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	for _, s := range spends {
+		widen(s.Timestamp)
+	}
+	for _, in := range inflows {
+		widen(in.time)
+	}
+	start := flowBucketStart(first, interval)
+	last := flowBucketStart(latest, interval)
+	for t := start; !t.After(last); t = nextFlowBucket(t, interval) {
+		buckets = append(buckets, types.TreasuryFlowBucket{Start: t})
+	}
+	bucketOf := func(t time.Time) int {
+		bs := flowBucketStart(t, interval)
+		return sort.Search(len(buckets), func(i int) bool { return !buckets[i].Start.Before(bs) })
+	}
+
+	// Sum in atoms; converting per bucket keeps the cumulative net exact.
+	spent := make([]int64, len(buckets))
+	for _, s := range spends {
+		i := bucketOf(s.Timestamp)
+		spent[i] += s.AmountAtoms
+		buckets[i].TSpendCount++
+	}
+	tbase := make([]int64, len(buckets))
+	for _, in := range inflows {
+		tbase[bucketOf(in.time)] += in.atoms
+	}
+
+	var cumulative int64
+	for i := range buckets {
+		net := tbase[i] - spent[i]
+		cumulative += net
+		buckets[i].TreasuryBase = atomsToCoin(tbase[i])
+		buckets[i].Spent = atomsToCoin(spent[i])
+		buckets[i].Net = atomsToCoin(net)
+		buckets[i].CumulativeNet = atomsToCoin(cumulative)
+	}
+	return buckets
+}
+
+// flowBucketStart returns the UTC start of the month, or of the ISO week
+// (Monday), containing t.
+func flowBucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	if interval == FlowIntervalWeek {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		return day.AddDate(0, 0, -offset)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func nextFlowBucket(t time.Time, interval string) time.Time {
+	if interval == FlowIntervalWeek {
+		return t.AddDate(0, 0, 7)
+	}
+	return t.AddDate(0, 1, 0)
+}
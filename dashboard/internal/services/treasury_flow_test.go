@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+func TestBucketTreasuryFlowMonthFillsGaps(t *testing.T) {
+	spends := []types.TSpendHistory{
+		{AmountAtoms: 30e8, Timestamp: time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)},
+		{AmountAtoms: 10e8, Timestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{AmountAtoms: 5e8, Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	got := bucketTreasuryFlow(spends, nil, FlowIntervalMonth)
+	if len(got) != 3 {
+		t.Fatalf("got %d buckets, want 3 (Jan-Mar)", len(got))
+	}
+	want := []struct {
+		month      time.Month
+		spent      float64
+		count      int
+		cumulative float64
+	}{
+		{time.January, 15, 2, -15},
+		{time.February, 0, 0, -15},
+		{time.March, 30, 1, -45},
+	}
+	for i, w := range want {
+		b := got[i]
+		if b.Start.Month() != w.month || b.Start.Day() != 1 {
+			t.Errorf("bucket %d starts %v, want 1 %v", i, b.Start, w.month)
+		}
+		if b.Spent != w.spent || b.TSpendCount != w.count || b.Net != -w.spent || b.CumulativeNet != w.cumulative {
+			t.Errorf("bucket %d = %+v, want spent %v count %d cumulative %v", i, b, w.spent, w.count, w.cumulative)
+		}
+	}
+}
+
+func TestFlowBucketStartWeek(t *testing.T) {
+	// 2024-03-03 is a Sunday; its ISO week starts Monday 2024-02-26.
+	got := flowBucketStart(time.Date(2024, 3, 3, 18, 0, 0, 0, time.UTC), FlowIntervalWeek)
+	if want := time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("week start = %v, want %v", got, want)
+	}
+}
+
+func TestBucketTreasuryFlowEmpty(t *testing.T) {
+	if got := bucketTreasuryFlow(nil, nil, FlowIntervalWeek); got == nil || len(got) != 0 {
+		t.Fatalf("got %v, want empty non-nil slice", got)
+	}
+}
+
+func TestBucketTreasuryFlowTreasuryBase(t *testing.T) {
+	spends := []types.TSpendHistory{
+		{AmountAtoms: 10e8, Timestamp: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	inflows := []treasuryBaseInflow{
+		{time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), atoms: 4e8},
+		{time: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), atoms: 3e8},
+	}
+	got := bucketTreasuryFlow(spends, inflows, FlowIntervalMonth)
+	if len(got) != 3 {
+		t.Fatalf("got %d buckets, want 3 (Jan-Mar)", len(got))
+	}
+	want := []struct {
+		tbase, spent, cumulative float64
+	}{
+		{3, 0, 3},
+		{0, 10, -7},
+		{4, 0, -3},
+	}
+	for i, w := range want {
+		b := got[i]
+		if b.TreasuryBase != w.tbase || b.Spent != w.spent || b.CumulativeNet != w.cumulative {
+			t.Errorf("bucket %d = %+v, want treasurybase %v spent %v cumulative %v", i, b, w.tbase, w.spent, w.cumulative)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+const (
+	// mempoolTSpendRefresh is how long a mempool scan is served from cache
+	// before the next poll rescans.
+	mempoolTSpendRefresh = 10 * time.Second
+	// mempoolTSpendLogSize caps the change log that changedSince deltas are
+	// built from; older tokens get the full set instead.
+	mempoolTSpendLogSize = 256
+)
+
+// mempoolTSpendChange is one change-log entry: a tspend that appeared, left
+// the mempool, or whose vote tally changed. Which one is read off the current
+// set when a delta is built.
+type mempoolTSpendChange struct {
+	token  uint64
+	txHash string
+}
+
+// Incremental mempool scan state. Every mempool transaction is classified
+// once; later scans only fetch hashes that newly appeared and evict those
+// that left. The token starts at the process start time so tokens from an
+// earlier run are never mistaken for current ones.
+//
+// mempoolTSpendRefreshMu serializes refreshes, whose dcrd calls run without
+// mempoolTSpendMu so readers keep getting the previous set meanwhile. The
+// state is only written with both held, so a refresh may read it under
+// mempoolTSpendRefreshMu alone.
+var (
+	mempoolTSpendRefreshMu sync.Mutex
+	mempoolTSpendMu        sync.Mutex
+	mempoolKnownTxs        = make(map[string]bool) // mempool tx hash -> is a tspend
+	mempoolTSpendSet       = make(map[string]*types.TSpend)
+	mempoolTSpendHeight    int64
+	mempoolTSpendToken     uint64
+	mempoolTSpendFloor     uint64 // highest token no longer in the change log
+	mempoolTSpendChanges   []mempoolTSpendChange
+	mempoolTSpendAt        time.Time
+)
+
+// GetMempoolTSpends retrieves active tspends from mempool with voting info
+func GetMempoolTSpends(ctx context.Context) ([]types.TSpend, error) {
+	tspends, _, err := GetMempoolTSpendsSince(ctx, 0)
+	return tspends, err
+}
+
+// GetMempoolTSpendsSince returns the mempool tspends that appeared or whose
+// vote tally changed after token changedSince, with the tspends that left the
+// mempool listed in the meta. A changedSince of 0, or one the change log no
+// longer covers, returns the full set.
+func GetMempoolTSpendsSince(ctx context.Context, changedSince uint64) ([]types.TSpend, *types.MempoolTSpendMeta, error) {
+	if err := refreshMempoolTSpends(ctx); err != nil {
+		return nil, nil, err
+	}
+	mempoolTSpendMu.Lock()
+	defer mempoolTSpendMu.Unlock()
+
+	meta := &types.MempoolTSpendMeta{
+		Token:         mempoolTSpendToken,
+		CurrentHeight: mempoolTSpendHeight,
+	}
+	if changedSince == 0 || changedSince < mempoolTSpendFloor || changedSince > mempoolTSpendToken {
+		meta.Full = true
+		tspends := make([]types.TSpend, 0, len(mempoolTSpendSet))
+		for _, ts := range mempoolTSpendSet {
+			tspends = append(tspends, *ts)
+		}
+		sortTSpends(tspends)
+		return tspends, meta, nil
+	}
+
+	tspends := []types.TSpend{}
+	seen := make(map[string]bool)
+	for _, c := range mempoolTSpendChanges {
+		if c.token <= changedSince || seen[c.txHash] {
+			continue
+		}
+		seen[c.txHash] = true
+		if ts, ok := mempoolTSpendSet[c.txHash]; ok {
+			tspends = append(tspends, *ts)
+		} else {
+			meta.Removed = append(meta.Removed, c.txHash)
+		}
+	}
+	sortTSpends(tspends)
+	return tspends, meta, nil
+}
+
+// refreshMempoolTSpends brings the cached tspend set up to date with the
+// mempool unless it was refreshed within mempoolTSpendRefresh. A tspend is
+// recorded as changed when it appears or leaves, when its tally moves, and
+// when a new block moves its height, blocks remaining and expiry estimate.
+func refreshMempoolTSpends(ctx context.Context) error {
+	mempoolTSpendRefreshMu.Lock()
+	defer mempoolTSpendRefreshMu.Unlock()
+
+	if !mempoolTSpendAt.IsZero() && time.Since(mempoolTSpendAt) < mempoolTSpendRefresh {
+		return nil
+	}
+	mempoolTSpendMu.Lock()
+	if mempoolTSpendToken == 0 {
+		mempoolTSpendToken = uint64(time.Now().UnixNano())
+		mempoolTSpendFloor = mempoolTSpendToken
+	}
+	mempoolTSpendMu.Unlock()
+
+	// Only the hashes are needed to spot new and evicted transactions.
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getrawmempool", []json.RawMessage{
+		json.RawMessage("false"), // verbose
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get mempool: %w", err)
+	}
+	var hashes []string
+	if err := json.Unmarshal(result, &hashes); err != nil {
+		return fmt.Errorf("failed to unmarshal mempool: %w", err)
+	}
+
+	currentHeight, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get current height: %v", err)
+		currentHeight = 0
+	}
+	// The network's block time dates each TSpend's expiry.
+	var target time.Duration
+	if params, err := CurrentChainParams(ctx); err == nil {
+		target = params.TargetTimePerBlock
+	}
+
+	inMempool := make(map[string]bool, len(hashes))
+	classified := make(map[string]bool)
+	added := make(map[string]*types.TSpend)
+	for _, txHash := range hashes {
+		inMempool[txHash] = true
+		if _, known := mempoolKnownTxs[txHash]; known {
+			continue
+		}
+		// A failed lookup leaves the hash unknown so the next scan retries.
+		tx, err := getTransaction(ctx, txHash)
+		if err != nil {
+			log.Printf("Warning: Failed to get transaction %s: %v", txHash, err)
+			continue
+		}
+		isTSpend := isTreasurySpend(tx)
+		classified[txHash] = isTSpend
+		if !isTSpend {
+			continue
+		}
+		if tspend := extractTSpendInfo(tx, currentHeight, target); tspend != nil {
+			added[txHash] = tspend
+		}
+	}
+	tallyHashes := make([]string, 0, len(mempoolTSpendSet)+len(added))
+	for txHash := range mempoolTSpendSet {
+		if inMempool[txHash] {
+			tallyHashes = append(tallyHashes, txHash)
+		}
+	}
+	for txHash := range added {
+		tallyHashes = append(tallyHashes, txHash)
+	}
+	tallies := fetchMempoolTSpendTallies(ctx, tallyHashes)
+
+	mempoolTSpendMu.Lock()
+	for txHash, isTSpend := range classified {
+		mempoolKnownTxs[txHash] = isTSpend
+	}
+	for txHash, tspend := range added {
+		mempoolTSpendSet[txHash] = tspend
+		recordMempoolTSpendChangeLocked(txHash)
+	}
+	for txHash, isTSpend := range mempoolKnownTxs {
+		if inMempool[txHash] {
+			continue
+		}
+		delete(mempoolKnownTxs, txHash)
+		if isTSpend {
+			delete(mempoolTSpendSet, txHash)
+			recordMempoolTSpendChangeLocked(txHash)
+		}
+	}
+
+	if currentHeight > 0 {
+		pruneTSpendFirstSeen(inMempool)
+		now := time.Now()
+		for txHash, ts := range mempoolTSpendSet {
+			recordTSpendFirstSeen(txHash, currentHeight)
+			// The expiry estimate is re-dated only with the height, so a
+			// tspend is not reported as changed on every poll.
+			remaining := ts.ExpiryHeight - currentHeight
+			if ts.CurrentHeight == currentHeight && ts.BlocksRemaining == remaining &&
+				(ts.EstimatedExpiry != nil || target <= 0) {
+				continue
+			}
+			ts.CurrentHeight = currentHeight
+			ts.BlocksRemaining = remaining
+			ts.EstimatedExpiry = estimateTSpendExpiry(currentHeight, remaining, target, now)
+			if added[txHash] == nil {
+				recordMempoolTSpendChangeLocked(txHash)
+			}
+		}
+		mempoolTSpendHeight = currentHeight
+	}
+
+	for txHash, tally := range tallies {
+		ts, ok := mempoolTSpendSet[txHash]
+		if !ok || (ts.YesVotes == tally.yes && ts.NoVotes == tally.no) {
+			continue
+		}
+		ts.YesVotes = tally.yes
+		ts.NoVotes = tally.no
+		if added[txHash] == nil {
+			recordMempoolTSpendChangeLocked(txHash)
+		}
+	}
+	mempoolTSpendAt = time.Now()
+
+	tspends := make([]types.TSpend, 0, len(mempoolTSpendSet))
+	for _, ts := range mempoolTSpendSet {
+		tspends = append(tspends, *ts)
+	}
+	mempoolTSpendMu.Unlock()
+
+	notifyNewMempoolTSpends(tspends)
+	return nil
+}
+
+// mempoolTSpendTally is one tspend's running yes and no vote counts.
+type mempoolTSpendTally struct {
+	yes, no int64
+}
+
+// fetchMempoolTSpendTallies reads the vote tallies of hashes cheaply:
+// gettreasuryspendvotes returns the running counts for the given tspend
+// hashes in a single RPC call (no per-block vote scan needed). Best-effort;
+// it returns nil on failure.
+func fetchMempoolTSpendTallies(ctx context.Context, hashes []string) map[string]mempoolTSpendTally {
+	if len(hashes) == 0 {
+		return nil
+	}
+	hb, err := json.Marshal(hashes)
+	if err != nil {
+		return nil
+	}
+	res, err := rpc.DcrdClient.RawRequest(ctx, "gettreasuryspendvotes", []json.RawMessage{
+		json.RawMessage("null"),
+		json.RawMessage(hb),
+	})
+	if err != nil {
+		log.Printf("Warning: gettreasuryspendvotes: %v", err)
+		return nil
+	}
+	var vr struct {
+		Votes []struct {
+			Hash     string `json:"hash"`
+			YesVotes int64  `json:"yesvotes"`
+			NoVotes  int64  `json:"novotes"`
+		} `json:"votes"`
+	}
+	if json.Unmarshal(res, &vr) != nil {
+		return nil
+	}
+	tallies := make(map[string]mempoolTSpendTally, len(vr.Votes))
+	for _, v := range vr.Votes {
+		tallies[v.Hash] = mempoolTSpendTally{yes: v.YesVotes, no: v.NoVotes}
+	}
+	return tallies
+}
+
+// recordMempoolTSpendChangeLocked appends a change under a new token, dropping
+// the oldest entry once the log is full.
+func recordMempoolTSpendChangeLocked(txHash string) {
+	mempoolTSpendToken++
+	mempoolTSpendChanges = append(mempoolTSpendChanges, mempoolTSpendChange{
+		token:  mempoolTSpendToken,
+		txHash: txHash,
+	})
+	if n := len(mempoolTSpendChanges) - mempoolTSpendLogSize; n > 0 {
+		mempoolTSpendFloor = mempoolTSpendChanges[n-1].token
+		mempoolTSpendChanges = append([]mempoolTSpendChange(nil), mempoolTSpendChanges[n:]...)
+	}
+}
+
+// sortTSpends orders tspends oldest-detected first, for a stable listing.
+func sortTSpends(tspends []types.TSpend) {
+	sort.Slice(tspends, func(i, j int) bool {
+		if !tspends[i].DetectedAt.Equal(tspends[j].DetectedAt) {
+			return tspends[i].DetectedAt.Before(tspends[j].DetectedAt)
+		}
+		return tspends[i].TxHash < tspends[j].TxHash
+	})
+}
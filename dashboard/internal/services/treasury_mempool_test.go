@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestMempoolTSpendChangesOnNewBlock(t *testing.T) {
+	txHash := strings.Repeat("ab", 32)
+	mempoolTSpendRefreshMu.Lock()
+	mempoolTSpendMu.Lock()
+	savedKnown, savedSet, savedAt := mempoolKnownTxs, mempoolTSpendSet, mempoolTSpendAt
+	mempoolKnownTxs = map[string]bool{txHash: true}
+	mempoolTSpendSet = map[string]*types.TSpend{txHash: {
+		TxHash:          txHash,
+		ExpiryHeight:    200,
+		CurrentHeight:   100,
+		BlocksRemaining: 100,
+	}}
+	mempoolTSpendMu.Unlock()
+	mempoolTSpendRefreshMu.Unlock()
+	networkMu.Lock()
+	networkVal = "simnet"
+	networkMu.Unlock()
+	t.Cleanup(func() {
+		forgetNetwork()
+		mempoolTSpendRefreshMu.Lock()
+		mempoolTSpendMu.Lock()
+		mempoolKnownTxs, mempoolTSpendSet, mempoolTSpendAt = savedKnown, savedSet, savedAt
+		mempoolTSpendMu.Unlock()
+		mempoolTSpendRefreshMu.Unlock()
+	})
+
+	fake := &fakeDcrd{height: 101, raw: map[string]func([]json.RawMessage) (json.RawMessage, error){
+		"getrawmempool": func([]json.RawMessage) (json.RawMessage, error) {
+			return json.Marshal([]string{txHash})
+		},
+		"gettreasuryspendvotes": func([]json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(`{"votes":[{"hash":"` + txHash + `","yesvotes":0,"novotes":0}]}`), nil
+		},
+	}}
+	useFakeDcrd(t, fake)
+	refresh := func() {
+		t.Helper()
+		mempoolTSpendMu.Lock()
+		mempoolTSpendAt = mempoolTSpendAt.AddDate(0, 0, -1)
+		mempoolTSpendMu.Unlock()
+		if err := refreshMempoolTSpends(context.Background()); err != nil {
+			t.Fatalf("refreshMempoolTSpends: %v", err)
+		}
+	}
+
+	refresh()
+	_, meta, err := GetMempoolTSpendsSince(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := meta.Token
+
+	// A new block moves the height and blocks remaining, so the tspend is
+	// part of the next delta.
+	fake.height = 102
+	refresh()
+	tspends, meta, err := GetMempoolTSpendsSince(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Full || len(tspends) != 1 {
+		t.Fatalf("delta after a new block = %d tspends (full %v), want 1", len(tspends), meta.Full)
+	}
+	ts := tspends[0]
+	if ts.CurrentHeight != 102 || ts.BlocksRemaining != 98 || ts.EstimatedExpiry == nil {
+		t.Errorf("tspend after a new block = height %d, %d remaining, expiry %v",
+			ts.CurrentHeight, ts.BlocksRemaining, ts.EstimatedExpiry)
+	}
+
+	// Without a new block nothing changed.
+	refresh()
+	tspends, _, err = GetMempoolTSpendsSince(context.Background(), meta.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tspends) != 0 {
+		t.Errorf("delta without a new block = %d tspends, want 0", len(tspends))
+	}
+}
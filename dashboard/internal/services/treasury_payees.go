@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"sort"
+
+	"dcrpulse/internal/types"
+)
+
+// TreasuryPayees totals the historical scan results by recipient, largest
+// total first. Like TreasuryFlow it reads only the stored results.
+func TreasuryPayees() []types.TreasuryPayee {
+	return aggregateTreasuryPayees(GetScanResults())
+}
+
+// aggregateTreasuryPayees attributes each tspend output to its address. A
+// history entry without per-output payees credits its whole amount to Payee.
+func aggregateTreasuryPayees(history []types.TSpendHistory) []types.TreasuryPayee {
+	byAddr := make(map[string]*types.TreasuryPayee)
+	for _, h := range history {
+		payees := h.Payees
+		if len(payees) == 0 && h.Payee != "" {
+			payees = []types.TSpendPayee{{Address: h.Payee, AmountAtoms: h.AmountAtoms}}
+		}
+		counted := make(map[string]bool, len(payees))
+		for _, p := range payees {
+			agg, ok := byAddr[p.Address]
+			if !ok {
+				agg = &types.TreasuryPayee{Address: p.Address}
+				byAddr[p.Address] = agg
+			}
+			agg.TotalAtoms += p.AmountAtoms
+			if counted[p.Address] {
+				continue
+			}
+			counted[p.Address] = true
+			agg.SpendCount++
+			if agg.FirstSeen.IsZero() || h.Timestamp.Before(agg.FirstSeen) {
+				agg.FirstSeen = h.Timestamp
+				agg.FirstTxHash = h.TxHash
+			}
+			if h.Timestamp.After(agg.LastSeen) {
+				agg.LastSeen = h.Timestamp
+				agg.LastTxHash = h.TxHash
+			}
+		}
+	}
+
+	result := make([]types.TreasuryPayee, 0, len(byAddr))
+	for _, agg := range byAddr {
+		agg.Total = atomsToCoin(agg.TotalAtoms)
+		agg.TotalDCR = formatDCR(agg.TotalAtoms)
+		result = append(result, *agg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalAtoms != result[j].TotalAtoms {
+			return result[i].TotalAtoms > result[j].TotalAtoms
+		}
+		return result[i].Address < result[j].Address
+	})
+	return result
+}
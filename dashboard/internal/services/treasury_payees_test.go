@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+func TestAggregateTreasuryPayees(t *testing.T) {
+	jan := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	history := []types.TSpendHistory{{
+		TxHash:    "mar",
+		Timestamp: mar,
+		Payees: []types.TSpendPayee{
+			{Address: "DsA", AmountAtoms: 100},
+			{Address: "DsB", AmountAtoms: 500},
+			{Address: "DsA", AmountAtoms: 50},
+		},
+	}, {
+		TxHash:    "jan",
+		Timestamp: jan,
+		Payees:    []types.TSpendPayee{{Address: "DsA", AmountAtoms: 400}},
+	}, {
+		TxHash:      "legacy",
+		Timestamp:   jan,
+		Payee:       "DsC",
+		AmountAtoms: 10,
+	}}
+
+	got := aggregateTreasuryPayees(history)
+	if len(got) != 3 {
+		t.Fatalf("got %d payees, want 3", len(got))
+	}
+	a, b, c := got[0], got[1], got[2]
+	if a.Address != "DsA" || a.TotalAtoms != 550 || a.SpendCount != 2 {
+		t.Errorf("first = %+v, want DsA 550 atoms over 2 spends", a)
+	}
+	if a.FirstTxHash != "jan" || a.LastTxHash != "mar" || !a.FirstSeen.Equal(jan) || !a.LastSeen.Equal(mar) {
+		t.Errorf("DsA seen %s (%v) to %s (%v), want jan to mar", a.FirstTxHash, a.FirstSeen, a.LastTxHash, a.LastSeen)
+	}
+	if b.Address != "DsB" || b.TotalAtoms != 500 || b.SpendCount != 1 {
+		t.Errorf("second = %+v, want DsB 500 atoms", b)
+	}
+	if c.Address != "DsC" || c.TotalAtoms != 10 || c.TotalDCR != "0.00000010" {
+		t.Errorf("third = %+v, want DsC 10 atoms", c)
+	}
+}
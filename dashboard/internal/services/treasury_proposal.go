@@ -0,0 +1,318 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+// How a tspend was tied to a proposal, reported as ProposalLink.Method.
+const (
+	ProposalLinkMapping   = "mapping"
+	ProposalLinkHeuristic = "heuristic"
+)
+
+const (
+	// proposalCandidatesTTL is how long the approved-proposal list used for
+	// matching is reused before it is fetched again in the background.
+	proposalCandidatesTTL = 1 * time.Hour
+	// proposalCandidatesRetry spaces out fetches after one fails, so an
+	// unreachable Politeia is not retried on every lookup.
+	proposalCandidatesRetry = 10 * time.Minute
+	// proposalPaymentGrace is how long after a proposal's end date a tspend
+	// can still be paying for its work: contractors invoice monthly in
+	// arrears and the treasury vote takes a further week or more.
+	proposalPaymentGrace = 90 * 24 * time.Hour
+)
+
+// proposalCandidate is an approved proposal's funding window, read from its
+// proposalmetadata.json.
+type proposalCandidate struct {
+	token string
+	name  string
+	start time.Time
+	end   time.Time
+}
+
+// proposalMapping is the explicit mapping file: tspend hashes and payee
+// addresses, each to a proposal token.
+type proposalMapping struct {
+	TSpends map[string]string `json:"tspends"`
+	Payees  map[string]string `json:"payees"`
+}
+
+var (
+	proposalLinkMu       sync.Mutex
+	proposalLinksEnabled bool
+	proposalMapPath      string
+	proposalMap          proposalMapping
+	proposalMapModTime   time.Time
+
+	proposalCandidatesMu       sync.Mutex
+	proposalCandidates         []proposalCandidate
+	proposalCandidatesAt       time.Time
+	proposalCandidatesFailAt   time.Time
+	proposalCandidatesFetching bool
+)
+
+// ConfigureProposalLinks turns on tying tspends to Politeia proposals. apiURL,
+// when set, replaces the Politeia API root for every Politeia request, and
+// mapPath names an optional JSON file of explicit links:
+//
+//	{"tspends": {"<txhash>": "<token>"}, "payees": {"<address>": "<token>"}}
+//
+// The file is re-read when it changes. Proposal titles and the time-window
+// heuristic also need the Politeia toggle in Settings to be on.
+func ConfigureProposalLinks(enabled bool, apiURL, mapPath string) {
+	if apiURL != "" {
+		u, err := url.Parse(apiURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			log.Printf("Proposal links: ignoring invalid Politeia API URL %q", apiURL)
+		} else {
+			politeiaBaseURL = strings.TrimSuffix(u.String(), "/")
+		}
+	}
+
+	proposalLinkMu.Lock()
+	defer proposalLinkMu.Unlock()
+	proposalLinksEnabled = enabled
+	proposalMapPath = mapPath
+	if enabled && mapPath != "" {
+		if _, err := loadProposalMapLocked(); err != nil {
+			log.Printf("Proposal links: %v", err)
+		}
+	}
+}
+
+// LinkTSpendProposal returns the proposal a tspend paid at paidAt funds, or
+// nil when linkage is off or no single proposal matches. An entry in the
+// mapping file, by hash and then by payee, wins; otherwise the tspend is
+// linked to the one approved proposal whose funding window, plus
+// proposalPaymentGrace, contains paidAt. Budgets are in USD and tspends in
+// DCR, so amounts are not compared. Never blocks on Politeia: the proposal
+// list is fetched in the background and, until it arrives or while Politeia
+// is unreachable, only mapped links are returned, without titles.
+func LinkTSpendProposal(txHash string, paidAt time.Time, payees []types.TSpendPayee) *types.ProposalLink {
+	mapping, ok := proposalLinkMapping()
+	if !ok {
+		return nil
+	}
+	var candidates []proposalCandidate
+	if PoliteiaEnabled() {
+		candidates = cachedProposalCandidates()
+	}
+	return linkTSpendProposal(mapping, candidates, txHash, paidAt, payees)
+}
+
+// AttachProposalLinks sets Proposal on each scanned tspend.
+func AttachProposalLinks(history []types.TSpendHistory) {
+	mapping, ok := proposalLinkMapping()
+	if !ok {
+		return
+	}
+	var candidates []proposalCandidate
+	if PoliteiaEnabled() {
+		candidates = cachedProposalCandidates()
+	}
+	for i := range history {
+		h := &history[i]
+		h.Proposal = linkTSpendProposal(mapping, candidates, h.TxHash, h.Timestamp, h.Payees)
+	}
+}
+
+func linkTSpendProposal(mapping proposalMapping, candidates []proposalCandidate, txHash string, paidAt time.Time, payees []types.TSpendPayee) *types.ProposalLink {
+	token := mapping.TSpends[txHash]
+	if token == "" {
+		for _, p := range payees {
+			if token = mapping.Payees[p.Address]; token != "" {
+				break
+			}
+		}
+	}
+	if token != "" {
+		link := &types.ProposalLink{Token: token, URL: proposalURL(token), Method: ProposalLinkMapping}
+		for _, c := range candidates {
+			if c.token == token {
+				link.Title = c.name
+				break
+			}
+		}
+		return link
+	}
+
+	c, ok := matchProposalWindow(candidates, paidAt)
+	if !ok {
+		return nil
+	}
+	return &types.ProposalLink{
+		Token:  c.token,
+		Title:  c.name,
+		URL:    proposalURL(c.token),
+		Method: ProposalLinkHeuristic,
+	}
+}
+
+// matchProposalWindow returns the only candidate whose funding window, with
+// proposalPaymentGrace after its end, contains t. Overlapping windows are
+// ambiguous and match nothing.
+func matchProposalWindow(candidates []proposalCandidate, t time.Time) (proposalCandidate, bool) {
+	if t.IsZero() {
+		return proposalCandidate{}, false
+	}
+	var match proposalCandidate
+	n := 0
+	for _, c := range candidates {
+		if c.start.IsZero() || c.end.IsZero() {
+			continue
+		}
+		if t.Before(c.start) || t.After(c.end.Add(proposalPaymentGrace)) {
+			continue
+		}
+		match = c
+		n++
+	}
+	return match, n == 1
+}
+
+// proposalURL is the proposal's page on the Politeia instance being queried.
+func proposalURL(token string) string {
+	return strings.TrimSuffix(politeiaBaseURL, "/api") + "/record/" + token
+}
+
+// proposalLinkMapping returns the current mapping file contents, re-reading
+// it if it changed, and whether linkage is enabled at all.
+func proposalLinkMapping() (proposalMapping, bool) {
+	proposalLinkMu.Lock()
+	defer proposalLinkMu.Unlock()
+	if !proposalLinksEnabled {
+		return proposalMapping{}, false
+	}
+	if proposalMapPath == "" {
+		return proposalMapping{}, true
+	}
+	m, err := loadProposalMapLocked()
+	if err != nil {
+		log.Printf("Proposal links: %v", err)
+	}
+	return m, true
+}
+
+// loadProposalMapLocked returns the mapping file, parsing it again only when
+// its modification time moved. On error the last good mapping is kept.
+func loadProposalMapLocked() (proposalMapping, error) {
+	fi, err := os.Stat(proposalMapPath)
+	if err != nil {
+		return proposalMap, fmt.Errorf("mapping file: %w", err)
+	}
+	if fi.ModTime().Equal(proposalMapModTime) {
+		return proposalMap, nil
+	}
+	raw, err := os.ReadFile(proposalMapPath)
+	if err != nil {
+		return proposalMap, fmt.Errorf("mapping file: %w", err)
+	}
+	var m proposalMapping
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return proposalMap, fmt.Errorf("mapping file %s: %w", proposalMapPath, err)
+	}
+	proposalMap = m
+	proposalMapModTime = fi.ModTime()
+	return proposalMap, nil
+}
+
+// cachedProposalCandidates returns the approved proposals fetched so far and
+// starts a background refresh when they are stale.
+func cachedProposalCandidates() []proposalCandidate {
+	proposalCandidatesMu.Lock()
+	defer proposalCandidatesMu.Unlock()
+	stale := proposalCandidatesAt.IsZero() || time.Since(proposalCandidatesAt) > proposalCandidatesTTL
+	retrying := !proposalCandidatesFailAt.IsZero() && time.Since(proposalCandidatesFailAt) < proposalCandidatesRetry
+	if stale && !retrying && !proposalCandidatesFetching {
+		proposalCandidatesFetching = true
+		go refreshProposalCandidates()
+	}
+	return proposalCandidates
+}
+
+func refreshProposalCandidates() {
+	ctx, cancel := context.WithTimeout(context.Background(), ProposalsFetchTimeout)
+	defer cancel()
+	candidates, err := fetchProposalCandidates(ctx)
+
+	proposalCandidatesMu.Lock()
+	defer proposalCandidatesMu.Unlock()
+	proposalCandidatesFetching = false
+	if err != nil {
+		log.Printf("Proposal links: failed to fetch approved proposals: %v", err)
+		proposalCandidatesFailAt = time.Now()
+		return
+	}
+	proposalCandidates = candidates
+	proposalCandidatesAt = time.Now()
+	proposalCandidatesFailAt = time.Time{}
+}
+
+// fetchProposalCandidates reads every approved proposal's metadata, five
+// tokens per records request as fetchAndCacheProposals does.
+func fetchProposalCandidates(ctx context.Context) ([]proposalCandidate, error) {
+	inv, err := piInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens := inv.Vetted["approved"]
+
+	var candidates []proposalCandidate
+	for i := 0; i < len(tokens); i += 5 {
+		chunk := tokens[i:min(i+5, len(tokens))]
+		recs, err := piRecordsBatch(ctx, chunk, []string{"proposalmetadata.json"})
+		if err != nil {
+			return nil, err
+		}
+		for token, rec := range recs {
+			if c, ok := proposalCandidateFromRecord(token, rec); ok {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func proposalCandidateFromRecord(token string, rec piRecord) (proposalCandidate, bool) {
+	for _, f := range rec.Files {
+		if f.Name != "proposalmetadata.json" {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(f.Payload)
+		if err != nil {
+			return proposalCandidate{}, false
+		}
+		var meta struct {
+			Name      string `json:"name"`
+			StartDate int64  `json:"startdate"`
+			EndDate   int64  `json:"enddate"`
+		}
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return proposalCandidate{}, false
+		}
+		c := proposalCandidate{token: token, name: meta.Name}
+		if meta.StartDate > 0 && meta.EndDate > 0 {
+			c.start = time.Unix(meta.StartDate, 0)
+			c.end = time.Unix(meta.EndDate, 0)
+		}
+		return c, true
+	}
+	return proposalCandidate{}, false
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+func TestLinkTSpendProposal(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	candidates := []proposalCandidate{
+		{token: "aaaa", name: "Marketing", start: day(2023, 1, 1), end: day(2023, 6, 30)},
+		{token: "bbbb", name: "Development", start: day(2023, 5, 1), end: day(2023, 12, 31)},
+		{token: "cccc", name: "Legacy"}, // no funding window
+	}
+	mapping := proposalMapping{
+		TSpends: map[string]string{"tx-mapped": "cccc"},
+		Payees:  map[string]string{"Dspayee": "bbbb"},
+	}
+
+	tests := []struct {
+		name   string
+		txHash string
+		paidAt time.Time
+		payees []types.TSpendPayee
+		token  string
+		method string
+		title  string
+	}{
+		{"mapped by hash", "tx-mapped", day(2023, 3, 1), nil, "cccc", ProposalLinkMapping, "Legacy"},
+		{"mapped by payee", "tx", day(2020, 1, 1), []types.TSpendPayee{{Address: "Dsother"}, {Address: "Dsayee"}, {Address: "Dspayee"}}, "bbbb", ProposalLinkMapping, "Development"},
+		{"single window", "tx", day(2023, 3, 1), nil, "aaaa", ProposalLinkHeuristic, "Marketing"},
+		{"within grace", "tx", day(2024, 2, 1), nil, "bbbb", ProposalLinkHeuristic, "Development"},
+		{"overlapping windows", "tx", day(2023, 6, 1), nil, "", "", ""},
+		{"outside every window", "tx", day(2022, 1, 1), nil, "", "", ""},
+		{"no time", "tx", time.Time{}, nil, "", "", ""},
+	}
+	for _, test := range tests {
+		link := linkTSpendProposal(mapping, candidates, test.txHash, test.paidAt, test.payees)
+		if test.token == "" {
+			if link != nil {
+				t.Errorf("%s: got link to %s, want none", test.name, link.Token)
+			}
+			continue
+		}
+		if link == nil {
+			t.Errorf("%s: got no link, want %s", test.name, test.token)
+			continue
+		}
+		if link.Token != test.token || link.Method != test.method || link.Title != test.title {
+			t.Errorf("%s: got %s/%s/%q, want %s/%s/%q", test.name,
+				link.Token, link.Method, link.Title, test.token, test.method, test.title)
+		}
+	}
+
+	// A mapped token outside the fetched proposals still links, untitled.
+	link := linkTSpendProposal(proposalMapping{TSpends: map[string]string{"tx": "dddd"}}, nil, "tx", time.Time{}, nil)
+	if link == nil || link.Token != "dddd" || link.Title != "" || link.URL != proposalURL("dddd") {
+		t.Errorf("unfetched mapping: got %+v", link)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/wire"
+)
+
+// Block stream event types.
+const (
+	BlockEventBlock = "block"
+	BlockEventReorg = "reorg"
+)
+
+// reorgParamsTimeout bounds the treasury params lookup of a rollback.
+const reorgParamsTimeout = 5 * time.Second
+
+var (
+	blockSubsMu sync.Mutex
+	blockSubs   []chan types.BlockEvent
+)
+
+// scanBlockRef names a block the scan results were extended with.
+type scanBlockRef struct {
+	height int64
+	hash   string
+}
+
+// scanLastRead is the last block the auto-scan merged, checked against the
+// chain before the next run; guarded by scanMutex.
+var scanLastRead scanBlockRef
+
+// SubscribeBlockEvents returns a channel that receives a BlockEvent for every
+// block dcrd connects or disconnects, and a cleanup func.
+func SubscribeBlockEvents() (<-chan types.BlockEvent, func()) {
+	ch := make(chan types.BlockEvent, 16)
+	blockSubsMu.Lock()
+	blockSubs = append(blockSubs, ch)
+	blockSubsMu.Unlock()
+	return ch, func() {
+		blockSubsMu.Lock()
+		defer blockSubsMu.Unlock()
+		for i, sub := range blockSubs {
+			if sub == ch {
+				blockSubs = append(blockSubs[:i], blockSubs[i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}
+}
+
+// publishBlockEvent fans ev out to subscribers, dropping it for any whose
+// buffer is full.
+func publishBlockEvent(ev types.BlockEvent) {
+	blockSubsMu.Lock()
+	defer blockSubsMu.Unlock()
+	for _, sub := range blockSubs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
+
+// PublishBlockConnected is the dcrd block-connected notification callback
+// for the block stream.
+func PublishBlockConnected(header *wire.BlockHeader) {
+	if header == nil {
+		return
+	}
+	publishBlockEvent(types.BlockEvent{
+		Type:   BlockEventBlock,
+		Height: int64(header.Height),
+		Hash:   header.BlockHash().String(),
+	})
+}
+
+// HandleBlockDisconnected is the dcrd block-disconnected notification
+// callback. dcrd disconnects the orphaned blocks tip first before connecting
+// the new chain, so rolling the treasury results back to each one leaves
+// them ending before the fork by the time the auto-scan reads the new
+// blocks.
+func HandleBlockDisconnected(header *wire.BlockHeader) {
+	if header == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(RootContext(), reorgParamsTimeout)
+	defer cancel()
+	height, hash := int64(header.Height), header.BlockHash().String()
+	rb := rollbackTreasuryScan(ctx, height)
+	logTreasuryRollback(fmt.Sprintf("block %d (%s) disconnected", height, hash), rb)
+	publishBlockEvent(types.BlockEvent{
+		Type:     BlockEventReorg,
+		Height:   height,
+		Hash:     hash,
+		Rollback: &rb,
+	})
+}
+
+// checkAutoScanChain reports whether the block the auto-scan last merged is
+// still in the main chain, which ends at tip, rolling the results back to
+// its height when it isn't. This catches a reorg whose disconnect
+// notifications were missed, or that landed while a run was reading the
+// orphaned blocks. Only that block is checked: a reorg would have to be
+// deeper than a TVI to reach the one read before it.
+func checkAutoScanChain(ctx context.Context, tip int64) error {
+	scanMutex.RLock()
+	last := scanLastRead
+	scanMutex.RUnlock()
+	if last.hash == "" {
+		return nil
+	}
+	if last.height <= tip {
+		hash, err := rpc.DcrdClient.GetBlockHash(ctx, last.height)
+		if err != nil {
+			return fmt.Errorf("failed to get block hash at %d: %w", last.height, err)
+		}
+		if hash.String() == last.hash {
+			return nil
+		}
+	}
+	rb := rollbackTreasuryScan(ctx, last.height)
+	logTreasuryRollback(fmt.Sprintf("block %d (%s) is no longer in the main chain", last.height, last.hash), rb)
+	publishBlockEvent(types.BlockEvent{
+		Type:     BlockEventReorg,
+		Height:   last.height,
+		Hash:     last.hash,
+		Rollback: &rb,
+	})
+	return nil
+}
+
+func logTreasuryRollback(reason string, rb types.TreasuryRollback) {
+	if len(rb.TSpends) == 0 && rb.TreasuryBaseRuns == 0 && len(rb.RescanHeights) == 0 {
+		return
+	}
+	log.Printf("Reorg: %s; rolled back %d TSpends %v and %d treasurybase runs from block %d",
+		reason, len(rb.TSpends), rb.TSpends, rb.TreasuryBaseRuns, rb.FromHeight)
+	if len(rb.RescanHeights) > 0 {
+		log.Printf("Reorg: the running scan had read blocks %v; re-scan them once it finishes", rb.RescanHeights)
+	}
+}
+
+// rollbackTreasuryScan removes what the scan results took from blocks at
+// from and above, along with the cached vote counts of TSpends mined there.
+func rollbackTreasuryScan(ctx context.Context, from int64) types.TreasuryRollback {
+	var tvi int64
+	if tp, err := CurrentTreasuryParams(ctx); err == nil {
+		tvi = tp.VoteInterval
+	}
+	scanMutex.Lock()
+	rb := rollbackTreasuryScanLocked(from, tvi)
+	scanMutex.Unlock()
+
+	votingCacheMutex.Lock()
+	for txHash, info := range votingCache {
+		if info.VotingEndBlock >= from {
+			dropVoteCountLocked(txHash)
+		}
+	}
+	for _, txHash := range rb.TSpends {
+		dropVoteCountLocked(txHash)
+	}
+	votingCacheMutex.Unlock()
+	return rb
+}
+
+// rollbackTreasuryScanLocked drops the TSpends mined from from on, from both
+// the results and a running scan's stage. Unless a heights scan is adding to
+// the results, the treasurybase runs ending there go too and the covered
+// height is moved back before from, so the auto-scan reads the new chain.
+// A running scan keeps its own treasurybase count, which the schedule makes
+// the same on either chain; the TVI blocks it already read from from on
+// (tvi, when known, picks them out) are recorded as failed so a heights
+// re-scan picks up the new chain's TSpends. It must be called with
+// scanMutex held.
+func rollbackTreasuryScanLocked(from, tvi int64) types.TreasuryRollback {
+	rb := types.TreasuryRollback{FromHeight: from, TSpends: []string{}}
+	trim := func(results []types.TSpendHistory) []types.TSpendHistory {
+		kept := make([]types.TSpendHistory, 0, len(results))
+		for _, r := range results {
+			if r.BlockHeight >= from {
+				if !containsString(rb.TSpends, r.TxHash) {
+					rb.TSpends = append(rb.TSpends, r.TxHash)
+				}
+				continue
+			}
+			kept = append(kept, r)
+		}
+		return kept
+	}
+	// tspendFoundCount counts the stage while a staged scan runs and the
+	// results otherwise; only removals from that one move it.
+	before := len(scanResults)
+	scanResults = trim(scanResults)
+	removed := before - len(scanResults)
+	if scanStaging != nil {
+		before = len(scanStaging.results)
+		scanStaging.results = trim(scanStaging.results)
+		removed = before - len(scanStaging.results)
+	}
+	buffered := make([]types.TSpendHistory, 0, len(newTSpendBuffer))
+	for _, r := range newTSpendBuffer {
+		if r.BlockHeight < from {
+			buffered = append(buffered, r)
+		}
+	}
+	newTSpendBuffer = buffered
+	tspendFoundCount -= removed
+
+	if isScanRunning && tvi > 0 && currentScanHeight >= from {
+		failed := scanFailedLocked()
+		for _, h := range scanHeights(ScanProfile{}, from, currentScanHeight, tvi) {
+			*failed = addScanHeight(*failed, h)
+			rb.RescanHeights = append(rb.RescanHeights, h)
+		}
+	}
+	if !isScanRunning || scanStaging != nil {
+		n := len(scanTreasuryBase)
+		for n > 0 && scanTreasuryBase[n-1].height >= from {
+			n--
+		}
+		rb.TreasuryBaseRuns = len(scanTreasuryBase) - n
+		scanTreasuryBase = scanTreasuryBase[:n]
+		if scanCoveredHeight >= from {
+			scanCoveredHeight = from - 1
+		}
+	}
+	if scanLastRead.height >= from {
+		scanLastRead = scanBlockRef{}
+	}
+	if len(rb.TSpends) > 0 || rb.TreasuryBaseRuns > 0 || len(rb.RescanHeights) > 0 {
+		scanChangedLocked()
+	}
+	return rb
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestRollbackTreasuryScan(t *testing.T) {
+	const tvi = 288
+	scanMutex.Lock()
+	saved := struct {
+		results  []types.TSpendHistory
+		tbase    []treasuryBaseInflow
+		covered  int64
+		running  bool
+		current  int64
+		found    int
+		buffered []types.TSpendHistory
+		failed   []int64
+		last     scanBlockRef
+		staging  *scanStage
+	}{scanResults, scanTreasuryBase, scanCoveredHeight, isScanRunning, currentScanHeight,
+		tspendFoundCount, newTSpendBuffer, scanFailedHeights, scanLastRead, scanStaging}
+	scanMutex.Unlock()
+	t.Cleanup(func() {
+		scanMutex.Lock()
+		scanResults, scanTreasuryBase, scanCoveredHeight = saved.results, saved.tbase, saved.covered
+		isScanRunning, currentScanHeight, tspendFoundCount = saved.running, saved.current, saved.found
+		newTSpendBuffer, scanFailedHeights, scanLastRead = saved.buffered, saved.failed, saved.last
+		scanStaging = saved.staging
+		scanMutex.Unlock()
+	})
+
+	reset := func() {
+		scanResults = []types.TSpendHistory{
+			{TxHash: "aa", BlockHeight: 10 * tvi},
+			{TxHash: "bb", BlockHeight: 11 * tvi},
+			{TxHash: "cc", BlockHeight: 12 * tvi},
+		}
+		newTSpendBuffer = []types.TSpendHistory{scanResults[2]}
+		scanTreasuryBase = []treasuryBaseInflow{{height: 10 * tvi}, {height: 11 * tvi}, {height: 12 * tvi}}
+		scanCoveredHeight = 12*tvi + 5
+		scanLastRead = scanBlockRef{height: 12 * tvi, hash: "orphan"}
+		scanFailedHeights = nil
+		scanStaging = nil
+		tspendFoundCount = 3
+	}
+
+	// The auto-scan's results end before the fork, to be read again.
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+	reset()
+	isScanRunning = false
+	rb := rollbackTreasuryScanLocked(11*tvi-3, tvi)
+	if !reflect.DeepEqual(rb.TSpends, []string{"bb", "cc"}) || rb.TreasuryBaseRuns != 2 || rb.RescanHeights != nil {
+		t.Errorf("rollback = %+v", rb)
+	}
+	if len(scanResults) != 1 || len(newTSpendBuffer) != 0 || len(scanTreasuryBase) != 1 || tspendFoundCount != 1 {
+		t.Errorf("left %d results, %d buffered, %d treasurybase runs, %d found",
+			len(scanResults), len(newTSpendBuffer), len(scanTreasuryBase), tspendFoundCount)
+	}
+	if scanCoveredHeight != 11*tvi-4 || scanLastRead != (scanBlockRef{}) {
+		t.Errorf("covered %d, last read %+v", scanCoveredHeight, scanLastRead)
+	}
+
+	// A fork past everything read changes nothing but the covered height.
+	reset()
+	if rb := rollbackTreasuryScanLocked(12*tvi+3, tvi); len(rb.TSpends) != 0 || rb.TreasuryBaseRuns != 0 {
+		t.Errorf("rollback past the results = %+v", rb)
+	}
+	if scanCoveredHeight != 12*tvi+2 || scanLastRead.hash != "orphan" {
+		t.Errorf("covered %d, last read %+v", scanCoveredHeight, scanLastRead)
+	}
+
+	// A running scan keeps its treasurybase count and has the TVI blocks it
+	// read past the fork marked for a re-scan.
+	reset()
+	isScanRunning = true
+	currentScanHeight = 12 * tvi
+	rb = rollbackTreasuryScanLocked(11*tvi-3, tvi)
+	if !reflect.DeepEqual(rb.RescanHeights, []int64{11 * tvi, 12 * tvi}) || rb.TreasuryBaseRuns != 0 {
+		t.Errorf("rollback during a scan = %+v", rb)
+	}
+	if !reflect.DeepEqual(scanFailedHeights, rb.RescanHeights) || len(scanTreasuryBase) != 3 {
+		t.Errorf("failed heights %v, %d treasurybase runs", scanFailedHeights, len(scanTreasuryBase))
+	}
+
+	// A staged scan's found count tracks its stage, not the committed
+	// results the rollback also trims.
+	reset()
+	isScanRunning = true
+	currentScanHeight = 11*tvi + 10
+	scanStaging = &scanStage{results: []types.TSpendHistory{
+		{TxHash: "ee", BlockHeight: 10 * tvi},
+		{TxHash: "dd", BlockHeight: 11 * tvi},
+	}}
+	tspendFoundCount = 2
+	rb = rollbackTreasuryScanLocked(11*tvi-3, tvi)
+	if !reflect.DeepEqual(rb.TSpends, []string{"bb", "cc", "dd"}) {
+		t.Errorf("rollback during a staged scan = %+v", rb)
+	}
+	if tspendFoundCount != 1 || len(scanStaging.results) != 1 || len(scanResults) != 1 {
+		t.Errorf("found %d, %d staged, %d results; want 1, 1, 1",
+			tspendFoundCount, len(scanStaging.results), len(scanResults))
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dcrpulse/internal/types"
+	"dcrpulse/internal/utils"
+)
+
+// scanEstimateSamples is how many blocks EstimateHistoricalScan reads.
+const scanEstimateSamples = 8
+
+// EstimateHistoricalScan estimates how long the scan profile selects would
+// take, without starting it. It reads a sample of the scan's blocks spread
+// over its range through the scan's own fetch path, checks their
+// transactions as the scan does, and extrapolates the time per block to
+// every block the scan would visit. Block sizes vary over the chain, so the
+// spread matters more than the sample size.
+func EstimateHistoricalScan(ctx context.Context, profile ScanProfile) (*types.TSpendScanEstimate, error) {
+	mode, startHeight, endHeight, heights, err := planHistoricalScan(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := sampleScanHeights(heights, scanEstimateSamples)
+	began := time.Now()
+	for _, h := range sample {
+		block, err := fetchScanBlock(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("sample block %d: %w", h, err)
+		}
+		for _, tx := range append(block.RawTx, block.RawSTx...) {
+			if !isTreasuryBase(tx) && isTreasurySpend(tx) {
+				extractTSpendHistory(tx, block.Height, block.Hash, block.Time)
+			}
+		}
+	}
+	elapsed := time.Since(began)
+
+	est := &types.TSpendScanEstimate{
+		Mode:          mode,
+		StartHeight:   startHeight,
+		EndHeight:     endHeight,
+		Blocks:        len(heights),
+		SampledBlocks: len(sample),
+	}
+	if len(sample) > 0 {
+		est.SecondsPerBlock = elapsed.Seconds() / float64(len(sample))
+		est.EstimatedSeconds = int64(est.SecondsPerBlock * float64(len(heights)))
+	}
+	est.Estimated = utils.FormatDuration(est.EstimatedSeconds)
+	return est, nil
+}
+
+// sampleScanHeights picks up to n of heights evenly spaced from first to
+// last.
+func sampleScanHeights(heights []int64, n int) []int64 {
+	if len(heights) <= n {
+		return heights
+	}
+	if n == 1 {
+		return heights[:1]
+	}
+	sample := make([]int64, n)
+	for i := range sample {
+		sample[i] = heights[i*(len(heights)-1)/(n-1)]
+	}
+	return sample
+}
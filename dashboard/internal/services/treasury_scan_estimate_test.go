@@ -0,0 +1,31 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSampleScanHeights(t *testing.T) {
+	var heights []int64
+	for h := int64(100); h < 200; h += 10 {
+		heights = append(heights, h)
+	}
+	tests := []struct {
+		n    int
+		want []int64
+	}{
+		{n: 20, want: heights},
+		{n: 1, want: []int64{100}},
+		{n: 2, want: []int64{100, 190}},
+		{n: 4, want: []int64{100, 130, 160, 190}},
+	}
+	for _, tc := range tests {
+		if got := sampleScanHeights(heights, tc.n); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("n=%d: got %v, want %v", tc.n, got, tc.want)
+		}
+	}
+}
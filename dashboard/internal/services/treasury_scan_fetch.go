@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"dcrpulse/internal/rpc"
+)
+
+// scanBlock is the part of a verbose getblock the historical scan reads.
+type scanBlock struct {
+	Hash   string                   `json:"hash"`
+	Height int64                    `json:"height"`
+	Time   int64                    `json:"time"`
+	RawTx  []map[string]interface{} `json:"rawtx"`
+	RawSTx []map[string]interface{} `json:"rawstx"`
+}
+
+// fetchScanBlockRetry is fetchScanBlock retried up to scanBlockAttempts
+// times with a doubling delay, so a transient dcrd hiccup doesn't leave a
+// gap in the scan.
+func fetchScanBlockRetry(ctx context.Context, height int64) (*scanBlock, error) {
+	delay := scanRetryDelay
+	for attempt := 1; ; attempt++ {
+		block, err := fetchScanBlock(ctx, height)
+		if err == nil || ctx.Err() != nil || attempt == scanBlockAttempts {
+			return block, err
+		}
+		scanDebugf("Reading block %d failed (attempt %d/%d), retrying in %s: %v",
+			height, attempt, scanBlockAttempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// fetchScanBlock reads the block at height with every transaction inline.
+func fetchScanBlock(ctx context.Context, height int64) (*scanBlock, error) {
+	blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block hash: %w", err)
+	}
+
+	// verbose=true + verbosetx=true returns every tx's full vin/vout inline
+	// (rawtx/rawstx), so no per-transaction getrawtransaction call is needed.
+	result, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(blockHash.String()),
+		json.RawMessage("true"),
+		json.RawMessage("true"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	var block scanBlock
+	if err := json.Unmarshal(result, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+	return &block, nil
+}
+
+// scanResultKnownLocked reports whether txHash is already in the results the
+// running scan adds to, which a heights re-scan can revisit. It must be called
+// with scanMutex held.
+func scanResultKnownLocked(txHash string) bool {
+	return scanHistoryHas(*scanResultsLocked(), txHash)
+}
+
+// addScanHeight inserts h into the sorted heights unless already present.
+func addScanHeight(heights []int64, h int64) []int64 {
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] >= h })
+	if i < len(heights) && heights[i] == h {
+		return heights
+	}
+	heights = append(heights, 0)
+	copy(heights[i+1:], heights[i:])
+	heights[i] = h
+	return heights
+}
+
+// removeScanHeight drops h from the sorted heights.
+func removeScanHeight(heights []int64, h int64) []int64 {
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] >= h })
+	if i == len(heights) || heights[i] != h {
+		return heights
+	}
+	return append(heights[:i], heights[i+1:]...)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// maxScanHeightsBlocks caps how many blocks one ScanHeights call visits, since
+// it runs while the caller waits. Larger gaps belong in a range scan.
+const maxScanHeightsBlocks = 500
+
+// ScanHeights scans the blocks req selects for tspends and merges any not
+// already found into the scan results, taking each block it reads off the
+// failed-heights list. Unlike TriggerHistoricalScan it runs to completion
+// before returning, so it is meant for patching the gaps a scan reported.
+func ScanHeights(ctx context.Context, req types.ScanHeightsRequest) (*types.ScanHeightsResult, error) {
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("treasury params: %w", err)
+	}
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	heights, err := expandScanHeights(req, tip, tp.ActivationHeight, tp.VoteInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := heights[0], heights[len(heights)-1]
+	scanCtx, err := beginScan(ctx, ScanModeHeights, start, end)
+	if err != nil {
+		return nil, err
+	}
+	scanMutex.RLock()
+	before := len(scanResults)
+	scanMutex.RUnlock()
+
+	scanHistoricalTSpendsBackground(scanCtx, start, end, heights)
+
+	scanMutex.RLock()
+	defer scanMutex.RUnlock()
+	result := &types.ScanHeightsResult{
+		Scanned:       len(heights),
+		NewTSpends:    append([]types.TSpendHistory{}, scanResults[before:]...),
+		FailedHeights: []int64{},
+		Cancelled:     scanCancelled,
+	}
+	result.NewTSpendCount = len(result.NewTSpends)
+	for _, h := range heights {
+		for _, f := range scanFailedHeights {
+			if f == h {
+				result.FailedHeights = append(result.FailedHeights, h)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// expandScanHeights turns req into the sorted, deduplicated heights to scan,
+// each within activation..tip. Ranges contribute only their TVI blocks, the
+// only ones a tspend can be mined in.
+func expandScanHeights(req types.ScanHeightsRequest, tip, activation, tvi int64) ([]int64, error) {
+	heights := append([]int64(nil), req.Heights...)
+	for _, r := range req.Ranges {
+		if r.Start > r.End {
+			return nil, fmt.Errorf("%w: range %d-%d is reversed", ErrInvalidScanRange, r.Start, r.End)
+		}
+		if r.Start < activation || r.End > tip {
+			return nil, fmt.Errorf("%w: range %d-%d is outside %d-%d", ErrInvalidScanRange, r.Start, r.End, activation, tip)
+		}
+		if r.End-r.Start+1 > maxScanHeightsBlocks*tvi {
+			return nil, fmt.Errorf("%w: range %d-%d is too long; use a range scan", ErrInvalidScanRange, r.Start, r.End)
+		}
+		heights = append(heights, scanHeights(ScanProfile{}, r.Start, r.End, tvi)...)
+	}
+	for _, h := range heights {
+		if h < activation || h > tip {
+			return nil, fmt.Errorf("%w: height %d is outside %d-%d", ErrInvalidScanRange, h, activation, tip)
+		}
+	}
+	heights = scanHeights(ScanProfile{Mode: ScanModeHeights, Heights: heights}, 0, 0, tvi)
+	if len(heights) == 0 {
+		return nil, fmt.Errorf("%w: no block in the request can hold a tspend", ErrInvalidScanRange)
+	}
+	if len(heights) > maxScanHeightsBlocks {
+		return nil, fmt.Errorf("%w: %d blocks requested, at most %d per call", ErrInvalidScanRange, len(heights), maxScanHeightsBlocks)
+	}
+	return heights, nil
+}
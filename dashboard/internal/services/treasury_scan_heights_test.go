@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestExpandScanHeights(t *testing.T) {
+	const (
+		tip        = 10000
+		activation = 1000
+		tvi        = 288
+	)
+	tests := []struct {
+		name string
+		req  types.ScanHeightsRequest
+		want []int64
+		err  error
+	}{
+		{"heights", types.ScanHeightsRequest{Heights: []int64{2016, 1152, 2016}}, []int64{1152, 2016}, nil},
+		{"range", types.ScanHeightsRequest{Ranges: []types.HeightRange{{Start: 1100, End: 1800}}}, []int64{1152, 1440, 1728}, nil},
+		{"merged", types.ScanHeightsRequest{Heights: []int64{1440}, Ranges: []types.HeightRange{{Start: 1400, End: 1500}}}, []int64{1440}, nil},
+		{"below activation", types.ScanHeightsRequest{Heights: []int64{576}}, nil, ErrInvalidScanRange},
+		{"past tip", types.ScanHeightsRequest{Heights: []int64{tip + 1}}, nil, ErrInvalidScanRange},
+		{"reversed range", types.ScanHeightsRequest{Ranges: []types.HeightRange{{Start: 2000, End: 1500}}}, nil, ErrInvalidScanRange},
+		{"range without a tvi block", types.ScanHeightsRequest{Ranges: []types.HeightRange{{Start: 1200, End: 1300}}}, nil, ErrInvalidScanRange},
+		{"empty", types.ScanHeightsRequest{}, nil, ErrInvalidScanRange},
+	}
+	for _, test := range tests {
+		got, err := expandScanHeights(test.req, tip, activation, tvi)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
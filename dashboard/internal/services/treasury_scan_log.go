@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import "log"
+
+// Defaults for ConfigureScanLogging.
+const (
+	DefaultScanLogInterval      = 1000
+	DefaultVoteProgressInterval = 50
+)
+
+// How chatty the historical scan and the vote counter are. Found tspends,
+// unreadable blocks and completion are always logged; the historical scan
+// also logs its position every scanLogInterval blocks it visits. Retries and
+// per-block detail are logged only when scanDebug is set. The vote counter
+// publishes its progress every voteProgressInterval blocks.
+var (
+	scanDebug            bool
+	scanLogInterval      = DefaultScanLogInterval
+	voteProgressInterval = int64(DefaultVoteProgressInterval)
+)
+
+// ConfigureScanLogging sets scan debug logging, the historical scan's
+// progress log interval and the vote counter's progress interval, both in
+// blocks. Non-positive intervals keep the defaults. Call it before any scan
+// starts.
+func ConfigureScanLogging(debug bool, logInterval, voteInterval int) {
+	scanDebug = debug
+	if logInterval > 0 {
+		scanLogInterval = logInterval
+	}
+	if voteInterval > 0 {
+		voteProgressInterval = int64(voteInterval)
+	}
+}
+
+// scanDebugf logs per-block scan detail when scan debug logging is on.
+func scanDebugf(format string, args ...interface{}) {
+	if scanDebug {
+		log.Printf("Debug: "+format, args...)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Historical scan modes accepted by TriggerHistoricalScan.
+const (
+	// ScanModeFull scans from the treasury activation height (or StartHeight,
+	// if later) to the tip.
+	ScanModeFull = "full"
+	// ScanModeRecent scans the last Blocks blocks, or the last Days days'
+	// worth of blocks, up to the tip.
+	ScanModeRecent = "recent"
+	// ScanModeRange scans StartHeight through EndHeight inclusive.
+	ScanModeRange = "range"
+	// ScanModeHeights re-scans exactly the blocks in Heights, typically a
+	// previous scan's failed heights, and merges what it finds into the
+	// existing results.
+	ScanModeHeights = "heights"
+)
+
+// defaultRecentScanDays is the recent-mode window when neither Blocks nor
+// Days is given.
+const defaultRecentScanDays = 30
+
+var (
+	// ErrInvalidScanMode is returned for a mode other than full, recent or
+	// range.
+	ErrInvalidScanMode = errors.New("invalid scan mode: expected full, recent, range or heights")
+	// ErrInvalidScanRange is returned when a scan's bounds are out of order,
+	// outside the chain, or end before the treasury activated.
+	ErrInvalidScanRange = errors.New("invalid scan range")
+	// ErrScanInProgress is returned when a historical scan is already running.
+	ErrScanInProgress = errors.New("scan already in progress")
+)
+
+// ScanProfile selects the heights a historical scan covers. Which fields
+// apply depends on Mode; an empty Mode is full.
+type ScanProfile struct {
+	Mode        string  `json:"mode"`
+	StartHeight int64   `json:"startHeight"`
+	EndHeight   int64   `json:"endHeight"`
+	Blocks      int64   `json:"blocks"`
+	Days        int64   `json:"days"`
+	Heights     []int64 `json:"heights"`
+}
+
+// resolveScanProfile turns p into the inclusive heights to scan, given the
+// chain tip, the treasury activation height and the target block time in
+// seconds. A start before activation is raised to it, since no tspend can
+// be mined earlier.
+func resolveScanProfile(p ScanProfile, tip, activation, blockSeconds int64) (start, end int64, err error) {
+	switch p.Mode {
+	case "", ScanModeFull:
+		start, end = p.StartHeight, tip
+		if start > tip {
+			return 0, 0, fmt.Errorf("%w: start %d is past the tip %d", ErrInvalidScanRange, start, tip)
+		}
+	case ScanModeRecent:
+		blocks := p.Blocks
+		if blocks <= 0 {
+			days := p.Days
+			if days <= 0 {
+				days = defaultRecentScanDays
+			}
+			blocks = days * 86400 / blockSeconds
+		}
+		start, end = tip-blocks+1, tip
+	case ScanModeRange:
+		start, end = p.StartHeight, p.EndHeight
+		if start < 0 || start > end {
+			return 0, 0, fmt.Errorf("%w: start %d must be between 0 and end %d", ErrInvalidScanRange, start, end)
+		}
+		if end > tip {
+			return 0, 0, fmt.Errorf("%w: end %d is past the tip %d", ErrInvalidScanRange, end, tip)
+		}
+		if end < activation {
+			return 0, 0, fmt.Errorf("%w: end %d is before treasury activation at %d", ErrInvalidScanRange, end, activation)
+		}
+	case ScanModeHeights:
+		if len(p.Heights) == 0 {
+			return 0, 0, fmt.Errorf("%w: no heights given", ErrInvalidScanRange)
+		}
+		start, end = p.Heights[0], p.Heights[0]
+		for _, h := range p.Heights {
+			if h < activation || h > tip {
+				return 0, 0, fmt.Errorf("%w: height %d is outside %d-%d", ErrInvalidScanRange, h, activation, tip)
+			}
+			if h < start {
+				start = h
+			}
+			if h > end {
+				end = h
+			}
+		}
+		return start, end, nil
+	default:
+		return 0, 0, ErrInvalidScanMode
+	}
+	if start < activation {
+		start = activation
+	}
+	return start, end, nil
+}
+
+// scanHeights lists the blocks a scan of p visits. TSpends can only be mined
+// on a treasury-vote-interval boundary, so a span from start to end visits
+// just its TVI blocks; heights mode visits its own list, sorted and deduped.
+func scanHeights(p ScanProfile, start, end, tvi int64) []int64 {
+	if p.Mode == ScanModeHeights {
+		heights := append([]int64(nil), p.Heights...)
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+		n := 0
+		for i, h := range heights {
+			if i == 0 || h != heights[n-1] {
+				heights[n] = h
+				n++
+			}
+		}
+		return heights[:n]
+	}
+	first := start
+	if first < 1 {
+		first = 1
+	}
+	if rem := first % tvi; rem != 0 {
+		first += tvi - rem
+	}
+	var heights []int64
+	for h := first; h <= end; h += tvi {
+		heights = append(heights, h)
+	}
+	return heights
+}
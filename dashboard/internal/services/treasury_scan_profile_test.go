@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResolveScanProfile(t *testing.T) {
+	const (
+		tip          = 1000000
+		activation   = 552448
+		blockSeconds = 300
+	)
+	tests := []struct {
+		name       string
+		profile    ScanProfile
+		start, end int64
+		err        error
+	}{
+		{"default", ScanProfile{}, activation, tip, nil},
+		{"full from height", ScanProfile{Mode: ScanModeFull, StartHeight: 900000}, 900000, tip, nil},
+		{"full past tip", ScanProfile{Mode: ScanModeFull, StartHeight: tip + 1}, 0, 0, ErrInvalidScanRange},
+		{"recent blocks", ScanProfile{Mode: ScanModeRecent, Blocks: 100}, tip - 99, tip, nil},
+		{"recent days", ScanProfile{Mode: ScanModeRecent, Days: 1}, tip - 287, tip, nil},
+		{"recent default", ScanProfile{Mode: ScanModeRecent}, tip - 30*288 + 1, tip, nil},
+		{"recent clamped", ScanProfile{Mode: ScanModeRecent, Blocks: tip}, activation, tip, nil},
+		{"range", ScanProfile{Mode: ScanModeRange, StartHeight: 600000, EndHeight: 700000}, 600000, 700000, nil},
+		{"range clamped", ScanProfile{Mode: ScanModeRange, StartHeight: 1, EndHeight: 600000}, activation, 600000, nil},
+		{"range reversed", ScanProfile{Mode: ScanModeRange, StartHeight: 700000, EndHeight: 600000}, 0, 0, ErrInvalidScanRange},
+		{"range past tip", ScanProfile{Mode: ScanModeRange, StartHeight: 600000, EndHeight: tip + 1}, 0, 0, ErrInvalidScanRange},
+		{"range before activation", ScanProfile{Mode: ScanModeRange, StartHeight: 1, EndHeight: 1000}, 0, 0, ErrInvalidScanRange},
+		{"heights", ScanProfile{Mode: ScanModeHeights, Heights: []int64{600048, 552960}}, 552960, 600048, nil},
+		{"heights empty", ScanProfile{Mode: ScanModeHeights}, 0, 0, ErrInvalidScanRange},
+		{"heights before activation", ScanProfile{Mode: ScanModeHeights, Heights: []int64{1000}}, 0, 0, ErrInvalidScanRange},
+		{"unknown mode", ScanProfile{Mode: "quick"}, 0, 0, ErrInvalidScanMode},
+	}
+	for _, test := range tests {
+		start, end, err := resolveScanProfile(test.profile, tip, activation, blockSeconds)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if start != test.start || end != test.end {
+			t.Errorf("%s: got %d-%d, want %d-%d", test.name, start, end, test.start, test.end)
+		}
+	}
+}
+
+func TestScanHeights(t *testing.T) {
+	got := scanHeights(ScanProfile{}, 300, 1000, 288)
+	if want := []int64{576, 864}; !reflect.DeepEqual(got, want) {
+		t.Errorf("span: got %v, want %v", got, want)
+	}
+	got = scanHeights(ScanProfile{Mode: ScanModeHeights, Heights: []int64{864, 576, 864}}, 576, 864, 288)
+	if want := []int64{576, 864}; !reflect.DeepEqual(got, want) {
+		t.Errorf("heights: got %v, want %v", got, want)
+	}
+}
+
+func TestScanFailedHeightList(t *testing.T) {
+	var failed []int64
+	for _, h := range []int64{864, 288, 576, 288} {
+		failed = addScanHeight(failed, h)
+	}
+	if want := []int64{288, 576, 864}; !reflect.DeepEqual(failed, want) {
+		t.Fatalf("add: got %v, want %v", failed, want)
+	}
+	failed = removeScanHeight(failed, 576)
+	failed = removeScanHeight(failed, 1000)
+	if want := []int64{288, 864}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("remove: got %v, want %v", failed, want)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"sort"
+
+	"dcrpulse/internal/types"
+)
+
+// scanStage is what a full, recent or range scan has read so far. Those scans
+// replace the results (a recent or range scan only within its own window; see
+// commitScanStageLocked), but only once they finish: until then the previous
+// results, treasurybase runs, failed heights and covered height stay as they
+// were, so a scan that is cancelled or started over never leaves them empty.
+// A heights scan adds to the results directly and has no stage.
+type scanStage struct {
+	results []types.TSpendHistory
+	tbase   []treasuryBaseInflow
+	failed  []int64
+}
+
+// scanStaging is the running scan's stage, nil when none is staged; guarded
+// by scanMutex.
+var scanStaging *scanStage
+
+// scanResultsLocked returns the results the running scan adds to: its stage's
+// when it has one, else the results themselves. It must be called with
+// scanMutex held.
+func scanResultsLocked() *[]types.TSpendHistory {
+	if scanStaging != nil {
+		return &scanStaging.results
+	}
+	return &scanResults
+}
+
+// scanTreasuryBaseLocked is scanResultsLocked for the treasurybase runs.
+func scanTreasuryBaseLocked() *[]treasuryBaseInflow {
+	if scanStaging != nil {
+		return &scanStaging.tbase
+	}
+	return &scanTreasuryBase
+}
+
+// scanFailedLocked is scanResultsLocked for the failed heights.
+func scanFailedLocked() *[]int64 {
+	if scanStaging != nil {
+		return &scanStaging.failed
+	}
+	return &scanFailedHeights
+}
+
+// commitScanStageLocked swaps the stage of a scan that ran to endHeight in
+// for the results. A full scan replaces them all; a recent or range scan
+// replaces only its own window, from scanStartHeight to endHeight, keeping the
+// TSpends, treasurybase runs and failed heights outside it, and widens the
+// covered range rather than resetting it. A TSpend of the previous results in
+// a block the scan couldn't read is kept, since the scan can't say it is gone.
+// tbase is the scan's treasurybase counter, nil when it counted none. It must
+// be called with scanMutex held.
+func commitScanStageLocked(endHeight int64, tbase *treasuryBaseCounter) {
+	stage := scanStaging
+	if stage == nil {
+		return
+	}
+	scanStaging = nil
+	start := scanStartHeight
+	incremental := scanMode == ScanModeRecent || scanMode == ScanModeRange
+	inWindow := func(h int64) bool { return !incremental || (h >= start && h <= endHeight) }
+
+	results := stage.results
+	for _, r := range scanResults {
+		keep := !inWindow(r.BlockHeight) || containsScanHeight(stage.failed, r.BlockHeight)
+		if keep && !scanHistoryHas(results, r.TxHash) {
+			results = append(results, r)
+		}
+	}
+	sortScanResults(results)
+	scanResults = results
+
+	failed := stage.failed
+	for _, h := range scanFailedHeights {
+		if !inWindow(h) {
+			failed = addScanHeight(failed, h)
+		}
+	}
+	scanFailedHeights = failed
+
+	switch {
+	case !incremental:
+		scanTreasuryBase = stage.tbase
+	case tbase != nil && len(stage.tbase) > 0:
+		scanTreasuryBase = tbase.splice(scanTreasuryBase, stage.tbase)
+	}
+
+	if incremental {
+		scanCoveredFrom, scanCoveredHeight = widenScanCoverage(scanCoveredFrom, scanCoveredHeight, start, endHeight)
+	} else {
+		scanCoveredFrom, scanCoveredHeight = start, endHeight
+	}
+	scanLastRead = scanBlockRef{}
+	tspendFoundCount = len(scanResults)
+}
+
+// widenScanCoverage returns the covered range after a scan of start to end,
+// given the covered range from to covered: the union of the two when they
+// overlap or touch, else whichever of them ends later, as the covered range
+// has no breaks.
+func widenScanCoverage(from, covered, start, end int64) (int64, int64) {
+	switch {
+	case covered == 0 || start > covered+1:
+		return start, end
+	case end < from-1:
+		return from, covered
+	}
+	if start < from {
+		from = start
+	}
+	return from, max(covered, end)
+}
+
+// abandonScanStageLocked drops the stage of a cancelled scan, keeping the
+// previous results with any TSpends the scan found merged in. Its
+// treasurybase runs and failed heights cover only part of its range, so they
+// go with it. It must be called with scanMutex held.
+func abandonScanStageLocked() {
+	stage := scanStaging
+	if stage == nil {
+		return
+	}
+	scanStaging = nil
+	merged := false
+	for _, r := range stage.results {
+		if !scanHistoryHas(scanResults, r.TxHash) {
+			scanResults = append(scanResults, r)
+			merged = true
+		}
+	}
+	if merged {
+		sortScanResults(scanResults)
+	}
+}
+
+// scanHistoryHas reports whether results holds txHash.
+func scanHistoryHas(results []types.TSpendHistory, txHash string) bool {
+	for _, r := range results {
+		if r.TxHash == txHash {
+			return true
+		}
+	}
+	return false
+}
+
+// containsScanHeight reports whether the sorted heights hold h.
+func containsScanHeight(heights []int64, h int64) bool {
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] >= h })
+	return i < len(heights) && heights[i] == h
+}
+
+func sortScanResults(results []types.TSpendHistory) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].BlockHeight < results[j].BlockHeight
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestScanStage(t *testing.T) {
+	keepScanState(t)
+
+	reset := func() {
+		scanResults = []types.TSpendHistory{{TxHash: "aa", BlockHeight: 100}, {TxHash: "bb", BlockHeight: 200}}
+		scanTreasuryBase = []treasuryBaseInflow{{height: 100}}
+		scanFailedHeights = nil
+		scanCoveredHeight = 250
+		scanCoveredFrom = 50
+		scanStartHeight = 50
+		scanMode = ScanModeFull
+		scanStaging = &scanStage{results: []types.TSpendHistory{}}
+	}
+
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+
+	// While the scan runs, the previous results stay put.
+	reset()
+	*scanResultsLocked() = append(*scanResultsLocked(), types.TSpendHistory{TxHash: "cc", BlockHeight: 300})
+	*scanFailedLocked() = []int64{200}
+	if !reflect.DeepEqual(scanResultHashes(scanResults), []string{"aa", "bb"}) || scanFailedHeights != nil || scanCoveredHeight != 250 {
+		t.Fatalf("results %v, failed %v, covered %d during the scan", scanResultHashes(scanResults), scanFailedHeights, scanCoveredHeight)
+	}
+
+	// A finished scan replaces them, keeping the TSpend at the height it
+	// couldn't read.
+	commitScanStageLocked(400, nil)
+	if !reflect.DeepEqual(scanResultHashes(scanResults), []string{"bb", "cc"}) {
+		t.Errorf("committed results %v", scanResultHashes(scanResults))
+	}
+	if scanStaging != nil || scanCoveredHeight != 400 || len(scanTreasuryBase) != 0 ||
+		!reflect.DeepEqual(scanFailedHeights, []int64{200}) || tspendFoundCount != 2 {
+		t.Errorf("committed stage %v, covered %d, %d treasurybase runs, failed %v, found %d",
+			scanStaging, scanCoveredHeight, len(scanTreasuryBase), scanFailedHeights, tspendFoundCount)
+	}
+
+	// A cancelled scan adds what it found and changes nothing else.
+	reset()
+	scanStaging.results = []types.TSpendHistory{{TxHash: "bb", BlockHeight: 200}, {TxHash: "zz", BlockHeight: 150}}
+	scanStaging.failed = []int64{300}
+	abandonScanStageLocked()
+	if !reflect.DeepEqual(scanResultHashes(scanResults), []string{"aa", "zz", "bb"}) {
+		t.Errorf("results after a cancel %v", scanResultHashes(scanResults))
+	}
+	if scanStaging != nil || scanCoveredHeight != 250 || len(scanTreasuryBase) != 1 || scanFailedHeights != nil {
+		t.Errorf("after a cancel: stage %v, covered %d, %d treasurybase runs, failed %v",
+			scanStaging, scanCoveredHeight, len(scanTreasuryBase), scanFailedHeights)
+	}
+}
+
+func TestScanStageIncremental(t *testing.T) {
+	keepScanState(t)
+
+	params := chaincfg.MainNetParams()
+	const activation = 552448
+	counter := newTreasuryBaseCounter(params, activation, activation)
+	run := func(first, last int64) treasuryBaseInflow {
+		in := treasuryBaseInflow{height: last, blocks: last - first + 1}
+		for h := first; h <= last; h++ {
+			in.atoms += counter.scheduled(h)
+		}
+		return in
+	}
+
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+
+	// A full scan from activation.
+	scanMode, scanStartHeight = ScanModeFull, activation
+	scanStaging = &scanStage{
+		results: []types.TSpendHistory{
+			{TxHash: "old", BlockHeight: activation + 288},
+			{TxHash: "gone", BlockHeight: activation + 2016},
+		},
+		tbase: []treasuryBaseInflow{
+			run(activation, activation+999),
+			run(activation+1000, activation+1999),
+			run(activation+2000, activation+2999),
+		},
+		failed: []int64{activation + 576},
+	}
+	commitScanStageLocked(activation+2999, nil)
+	fullInflow := sumTreasuryBase(scanTreasuryBase)
+
+	// Then a recent scan of its last 1500 blocks, which no longer finds
+	// "gone" but finds a new TSpend.
+	scanMode, scanStartHeight = ScanModeRecent, activation+1500
+	scanStaging = &scanStage{
+		results: []types.TSpendHistory{{TxHash: "new", BlockHeight: activation + 2880}},
+		tbase: []treasuryBaseInflow{
+			run(activation+1500, activation+2303),
+			run(activation+2304, activation+3200),
+		},
+	}
+	commitScanStageLocked(activation+3200, counter)
+
+	if got := scanResultHashes(scanResults); !reflect.DeepEqual(got, []string{"old", "new"}) {
+		t.Errorf("results after the recent scan %v, want [old new]", got)
+	}
+	if !reflect.DeepEqual(scanFailedHeights, []int64{activation + 576}) {
+		t.Errorf("failed heights %v, want the full scan's outside the window", scanFailedHeights)
+	}
+	if scanCoveredFrom != activation || scanCoveredHeight != activation+3200 {
+		t.Errorf("covered %d-%d, want %d-%d", scanCoveredFrom, scanCoveredHeight, activation, activation+3200)
+	}
+	if tspendFoundCount != 2 {
+		t.Errorf("found %d, want 2", tspendFoundCount)
+	}
+
+	// The treasurybase runs before the window survive, the one reaching
+	// into it cut at its start, and the inflow is the full scan's plus the
+	// blocks the recent scan added past it.
+	var blocks int64
+	for i, in := range scanTreasuryBase {
+		if first := in.height - in.blocks + 1; i > 0 && first != scanTreasuryBase[i-1].height+1 {
+			t.Errorf("treasurybase run %d starts at %d after a run ending at %d", i, first, scanTreasuryBase[i-1].height)
+		}
+		blocks += in.blocks
+	}
+	if blocks != 3201 || scanTreasuryBase[0].height != activation+999 || scanTreasuryBase[1].height != activation+1499 {
+		t.Errorf("treasurybase runs %+v cover %d blocks, want 3201", scanTreasuryBase, blocks)
+	}
+	if want := fullInflow + sumTreasuryBase([]treasuryBaseInflow{run(activation+3000, activation+3200)}); sumTreasuryBase(scanTreasuryBase) != want {
+		t.Errorf("treasurybase inflow %d, want %d", sumTreasuryBase(scanTreasuryBase), want)
+	}
+}
+
+// keepScanState restores the scan state once t is done.
+func keepScanState(t *testing.T) {
+	scanMutex.Lock()
+	saved := struct {
+		results []types.TSpendHistory
+		tbase   []treasuryBaseInflow
+		failed  []int64
+		covered int64
+		from    int64
+		start   int64
+		mode    string
+		found   int
+		stage   *scanStage
+	}{scanResults, scanTreasuryBase, scanFailedHeights, scanCoveredHeight, scanCoveredFrom,
+		scanStartHeight, scanMode, tspendFoundCount, scanStaging}
+	scanMutex.Unlock()
+	t.Cleanup(func() {
+		scanMutex.Lock()
+		scanResults, scanTreasuryBase, scanFailedHeights = saved.results, saved.tbase, saved.failed
+		scanCoveredHeight, scanCoveredFrom = saved.covered, saved.from
+		scanStartHeight, scanMode = saved.start, saved.mode
+		tspendFoundCount, scanStaging = saved.found, saved.stage
+		scanMutex.Unlock()
+	})
+}
+
+func scanResultHashes(results []types.TSpendHistory) []string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.TxHash)
+	}
+	return out
+}
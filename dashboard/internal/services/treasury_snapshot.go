@@ -0,0 +1,241 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// scanSnapshotVersion is the format of the scan snapshots this build writes
+// and reads. Version 2 added CoveredFrom; version 1 snapshots lack it, and
+// are refused rather than imported as covering the chain from genesis.
+const scanSnapshotVersion = 2
+
+var (
+	// ErrSnapshotHash is a snapshot whose contents don't hash to its Hash:
+	// corrupted or edited without updating the checksum.
+	ErrSnapshotHash = errors.New("snapshot hash does not match its contents")
+	// ErrSnapshotInvalid is a snapshot that is malformed, or from another
+	// network or format version.
+	ErrSnapshotInvalid = errors.New("invalid snapshot")
+	// ErrSnapshotChain is a snapshot whose blocks aren't on dcrd's chain.
+	ErrSnapshotChain = errors.New("snapshot does not match the chain")
+)
+
+// ExportScanSnapshot captures the treasury scan results, with their
+// treasurybase runs, failed heights and covered height, as a snapshot another
+// instance can import. Hash is the SHA-256 of the snapshot with Hash empty,
+// an integrity checksum against corruption in transit or storage; anyone
+// editing the file can recompute it, so it proves nothing about who made it.
+// The import's checks against dcrd's chain are what stop a doctored one.
+func ExportScanSnapshot(ctx context.Context) (*types.TreasuryScanSnapshot, error) {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scanMutex.RLock()
+	snap := &types.TreasuryScanSnapshot{
+		Version:       scanSnapshotVersion,
+		Network:       params.Name,
+		CoveredFrom:   scanCoveredFrom,
+		CoveredHeight: scanCoveredHeight,
+		TSpends:       append([]types.TSpendHistory{}, scanResults...),
+		TreasuryBase:  make([]types.TreasuryBaseRun, 0, len(scanTreasuryBase)),
+		FailedHeights: append([]int64{}, scanFailedHeights...),
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+	for _, in := range scanTreasuryBase {
+		snap.TreasuryBase = append(snap.TreasuryBase, types.TreasuryBaseRun{
+			Height: in.height, Time: in.time, Blocks: in.blocks, Atoms: in.atoms,
+		})
+	}
+	// The auto-scan records the block it moved the covered height to.
+	if scanLastRead.height == scanCoveredHeight {
+		snap.CoveredHash = scanLastRead.hash
+	}
+	scanMutex.RUnlock()
+
+	if snap.CoveredHeight > 0 && snap.CoveredHash == "" {
+		hash, err := rpc.DcrdClient.GetBlockHash(ctx, snap.CoveredHeight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block hash at %d: %w", snap.CoveredHeight, err)
+		}
+		snap.CoveredHash = hash.String()
+	}
+	snap.Hash = scanSnapshotHash(*snap)
+	return snap, nil
+}
+
+// ImportScanSnapshot replaces the treasury scan results with snap, priming an
+// instance without scanning the chain. The hash must match, the snapshot
+// must be for dcrd's network, and every block it names must be on dcrd's
+// chain at its height. It is refused while a scan runs.
+func ImportScanSnapshot(ctx context.Context, snap *types.TreasuryScanSnapshot) (*types.TreasuryScanSnapshotImport, error) {
+	// The version goes first: an older format hashes differently, so its
+	// hash would otherwise hide why it is refused.
+	if err := checkScanSnapshotVersion(snap.Version); err != nil {
+		return nil, err
+	}
+	if snap.Hash != scanSnapshotHash(*snap) {
+		return nil, ErrSnapshotHash
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tp, err := CurrentTreasuryParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	if err := checkScanSnapshot(snap, params.Name, tip, tp.ActivationHeight, tp.VoteInterval); err != nil {
+		return nil, err
+	}
+
+	// Every named block must still be the chain's block at its height.
+	blocks := map[int64]string{}
+	if snap.CoveredHeight > 0 {
+		blocks[snap.CoveredHeight] = snap.CoveredHash
+	}
+	for _, t := range snap.TSpends {
+		blocks[t.BlockHeight] = t.BlockHash
+	}
+	for height, want := range blocks {
+		hash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block hash at %d: %w", height, err)
+		}
+		if hash.String() != want {
+			return nil, fmt.Errorf("%w: block %d is %s, not %s", ErrSnapshotChain, height, hash, want)
+		}
+	}
+
+	results := append([]types.TSpendHistory{}, snap.TSpends...)
+	sortScanResults(results)
+	tbase := make([]treasuryBaseInflow, 0, len(snap.TreasuryBase))
+	for _, r := range snap.TreasuryBase {
+		tbase = append(tbase, treasuryBaseInflow{height: r.Height, time: r.Time, blocks: r.Blocks, atoms: r.Atoms})
+	}
+	failed := append([]int64{}, snap.FailedHeights...)
+	sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+
+	scanMutex.Lock()
+	defer scanMutex.Unlock()
+	if isScanRunning {
+		return nil, ErrScanInProgress
+	}
+	scanResults = results
+	scanTreasuryBase = tbase
+	scanFailedHeights = failed
+	scanCoveredHeight = snap.CoveredHeight
+	scanCoveredFrom = snap.CoveredFrom
+	// The auto-scan checks the block it merged last for a reorg; the
+	// snapshot's covered block stands in for it.
+	scanLastRead = scanBlockRef{}
+	if snap.CoveredHeight > 0 {
+		scanLastRead = scanBlockRef{height: snap.CoveredHeight, hash: snap.CoveredHash}
+	}
+	tspendFoundCount = len(results)
+	newTSpendBuffer = []types.TSpendHistory{}
+	scanChangedLocked()
+	return &types.TreasuryScanSnapshotImport{
+		TSpends:          len(results),
+		TreasuryBaseRuns: len(tbase),
+		CoveredHeight:    snap.CoveredHeight,
+	}, nil
+}
+
+// checkScanSnapshot validates what of snap can be checked without dcrd's
+// blocks: its version and network, and that its heights lie between
+// treasury activation and the tip, with each TSpend on a TVI block and
+// nothing past the covered height.
+func checkScanSnapshot(snap *types.TreasuryScanSnapshot, network string, tip, activation, tvi int64) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrSnapshotInvalid, fmt.Sprintf(format, args...))
+	}
+	if err := checkScanSnapshotVersion(snap.Version); err != nil {
+		return err
+	}
+	switch {
+	case snap.Network != network:
+		return invalid("taken on %s, dcrd is on %s", snap.Network, network)
+	case snap.CoveredHeight < 0 || snap.CoveredHeight > tip:
+		return invalid("covered height %d is past the tip %d", snap.CoveredHeight, tip)
+	case snap.CoveredHeight > 0 && (len(snap.CoveredHash) != 64 || !isHex(snap.CoveredHash)):
+		return invalid("covered block hash %q", snap.CoveredHash)
+	case snap.CoveredFrom < 0 || snap.CoveredFrom > snap.CoveredHeight:
+		return invalid("covered range %d-%d", snap.CoveredFrom, snap.CoveredHeight)
+	}
+	last := tip
+	if snap.CoveredHeight > 0 {
+		last = snap.CoveredHeight
+	}
+	seen := map[string]bool{}
+	for _, t := range snap.TSpends {
+		switch {
+		case len(t.TxHash) != 64 || !isHex(t.TxHash):
+			return invalid("tspend hash %q", t.TxHash)
+		case seen[t.TxHash]:
+			return invalid("tspend %s listed twice", t.TxHash)
+		case t.BlockHeight < activation || t.BlockHeight > last:
+			return invalid("tspend %s at height %d, outside %d-%d", t.TxHash, t.BlockHeight, activation, last)
+		case tvi > 0 && t.BlockHeight%tvi != 0:
+			return invalid("tspend %s at height %d, not a TVI block", t.TxHash, t.BlockHeight)
+		case len(t.BlockHash) != 64 || !isHex(t.BlockHash):
+			return invalid("tspend %s block hash %q", t.TxHash, t.BlockHash)
+		}
+		seen[t.TxHash] = true
+	}
+	prev := activation - 1
+	for _, r := range snap.TreasuryBase {
+		if r.Height <= prev || r.Height > last || r.Blocks <= 0 || r.Atoms < 0 {
+			return invalid("treasurybase run ending at %d", r.Height)
+		}
+		prev = r.Height
+	}
+	for _, h := range snap.FailedHeights {
+		if h < 0 || h > tip {
+			return invalid("failed height %d", h)
+		}
+	}
+	return nil
+}
+
+// checkScanSnapshotVersion refuses a snapshot format other than this build's,
+// naming what to do about a version 1 snapshot.
+func checkScanSnapshotVersion(version int) error {
+	switch version {
+	case scanSnapshotVersion:
+		return nil
+	case 1:
+		return fmt.Errorf("%w: version 1 snapshots don't record the first block they cover; export a new one", ErrSnapshotInvalid)
+	}
+	return fmt.Errorf("%w: version %d, want %d", ErrSnapshotInvalid, version, scanSnapshotVersion)
+}
+
+// scanSnapshotHash is the hex SHA-256 of snap's JSON with Hash empty. It is
+// unkeyed: a checksum, not a signature.
+func scanSnapshotHash(snap types.TreasuryScanSnapshot) string {
+	snap.Hash = ""
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
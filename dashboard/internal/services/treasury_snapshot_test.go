@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+func testScanSnapshot() *types.TreasuryScanSnapshot {
+	snap := &types.TreasuryScanSnapshot{
+		Version:       scanSnapshotVersion,
+		Network:       "mainnet",
+		CoveredFrom:   500,
+		CoveredHeight: 900,
+		CoveredHash:   strings.Repeat("c", 64),
+		TSpends: []types.TSpendHistory{{
+			TxHash:      strings.Repeat("a", 64),
+			AmountAtoms: 12345678901,
+			Amount:      123.45678901,
+			BlockHeight: 576,
+			BlockHash:   strings.Repeat("b", 64),
+			Timestamp:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600)),
+		}},
+		TreasuryBase: []types.TreasuryBaseRun{
+			{Height: 600, Blocks: 100, Atoms: 5000},
+			{Height: 700, Blocks: 100, Atoms: 5000},
+		},
+		FailedHeights: []int64{650},
+		CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	snap.Hash = scanSnapshotHash(*snap)
+	return snap
+}
+
+func TestScanSnapshotHash(t *testing.T) {
+	snap := testScanSnapshot()
+	b, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back types.TreasuryScanSnapshot
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if got := scanSnapshotHash(back); got != snap.Hash {
+		t.Fatalf("hash after a JSON round trip = %s, want %s", got, snap.Hash)
+	}
+
+	back.TSpends[0].AmountAtoms++
+	if scanSnapshotHash(back) == snap.Hash {
+		t.Fatal("hash unchanged by a tampered amount")
+	}
+}
+
+func TestCheckScanSnapshot(t *testing.T) {
+	const tip, activation, tvi = 1000, 500, 288
+
+	if err := checkScanSnapshot(testScanSnapshot(), "mainnet", tip, activation, tvi); err != nil {
+		t.Fatalf("valid snapshot: %v", err)
+	}
+	tests := []struct {
+		name   string
+		mutate func(*types.TreasuryScanSnapshot)
+	}{
+		{"version 1", func(s *types.TreasuryScanSnapshot) { s.Version = 1 }},
+		{"future version", func(s *types.TreasuryScanSnapshot) { s.Version = scanSnapshotVersion + 1 }},
+		{"network", func(s *types.TreasuryScanSnapshot) { s.Network = "testnet3" }},
+		{"covered past tip", func(s *types.TreasuryScanSnapshot) { s.CoveredHeight = tip + 1 }},
+		{"covered hash", func(s *types.TreasuryScanSnapshot) { s.CoveredHash = "zz" }},
+		{"covered from past covered", func(s *types.TreasuryScanSnapshot) { s.CoveredFrom = 901 }},
+		{"tspend before activation", func(s *types.TreasuryScanSnapshot) { s.TSpends[0].BlockHeight = 288 }},
+		{"tspend past covered", func(s *types.TreasuryScanSnapshot) { s.CoveredHeight = 575 }},
+		{"tspend off TVI", func(s *types.TreasuryScanSnapshot) { s.TSpends[0].BlockHeight = 577 }},
+		{"duplicate tspend", func(s *types.TreasuryScanSnapshot) { s.TSpends = append(s.TSpends, s.TSpends[0]) }},
+		{"tspend block hash", func(s *types.TreasuryScanSnapshot) { s.TSpends[0].BlockHash = "" }},
+		{"unordered runs", func(s *types.TreasuryScanSnapshot) { s.TreasuryBase[1].Height = 600 }},
+		{"run before activation", func(s *types.TreasuryScanSnapshot) { s.TreasuryBase[0].Height = 400 }},
+		{"failed height", func(s *types.TreasuryScanSnapshot) { s.FailedHeights = []int64{tip + 1} }},
+	}
+	for _, tt := range tests {
+		snap := testScanSnapshot()
+		tt.mutate(snap)
+		err := checkScanSnapshot(snap, "mainnet", tip, activation, tvi)
+		if !errors.Is(err, ErrSnapshotInvalid) {
+			t.Errorf("%s: err = %v, want ErrSnapshotInvalid", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// Vote counts behind the mempool standings, by tspend hash. A count is
+// reused until the tip moves past the height it was taken at, so polling the
+// standings rescans nothing between blocks.
+var (
+	standingsMu    sync.Mutex
+	standingsVotes = make(map[string]*types.TSpendVotingInfo)
+)
+
+// GetMempoolTSpendStandings returns every mempool tspend with its vote so far,
+// counted from the height it was first seen through the tip, and whether it
+// would pass now and by the end of its window at the same pace.
+func GetMempoolTSpendStandings(ctx context.Context) ([]types.TSpendStanding, error) {
+	tspends, err := GetMempoolTSpends(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	standingsMu.Lock()
+	defer standingsMu.Unlock()
+
+	active := make(map[string]bool, len(tspends))
+	standings := make([]types.TSpendStanding, 0, len(tspends))
+	for _, ts := range tspends {
+		active[ts.TxHash] = true
+		info, ok := standingsVotes[ts.TxHash]
+		if !ok || info.VotingEndBlock < ts.CurrentHeight {
+			counted, err := calculateTSpendVotes(ctx, ts.TxHash, 0, uint32(ts.ExpiryHeight), true)
+			if err != nil {
+				log.Printf("Warning: counting votes on mempool tspend %s: %v", ts.TxHash, err)
+				if !ok {
+					continue
+				}
+			} else {
+				info = counted
+				standingsVotes[ts.TxHash] = info
+			}
+		}
+		standings = append(standings, tspendStanding(ts, info, params))
+	}
+	for txHash := range standingsVotes {
+		if !active[txHash] {
+			delete(standingsVotes, txHash)
+		}
+	}
+	return standings, nil
+}
+
+// tspendStanding combines a mempool tspend with its vote count. The end of
+// window verdict applies dcrd's rules to the projected votes cast, split at
+// the approval rate so far.
+func tspendStanding(ts types.TSpend, info *types.TSpendVotingInfo, params *chaincfg.Params) types.TSpendStanding {
+	s := types.TSpendStanding{
+		TxHash:          ts.TxHash,
+		AmountDCR:       ts.AmountDCR,
+		Payee:           ts.Payee,
+		ExpiryHeight:    ts.ExpiryHeight,
+		BlocksRemaining: ts.BlocksRemaining,
+		FirstSeenHeight: info.FirstSeenHeight,
+		YesVotes:        info.YesVotes,
+		NoVotes:         info.NoVotes,
+		ApprovalRate:    info.ApprovalRate,
+		TurnoutRate:     info.TurnoutRate,
+		WindowProgress:  info.WindowProgress,
+		Now:             tspendPassProjection(params, info.YesVotes, info.NoVotes),
+	}
+	projected := info.ProjectedVotesCast
+	if projected < info.VotesCast {
+		projected = info.VotesCast
+	}
+	yes := int(math.Round(float64(projected) * info.ApprovalRate / 100))
+	s.ProjectedPass = tspendPassProjection(params, yes, projected-yes).WouldPassNow
+	return s
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestTSpendStanding(t *testing.T) {
+	// Mainnet needs 3456 votes cast and 60% yes. A quarter of the way
+	// through the window, 1000 votes at 75% yes pass neither check yet, but
+	// 4000 votes at the same split would.
+	params := chaincfg.MainNetParams()
+	ts := types.TSpend{TxHash: "ab", ExpiryHeight: 1000, BlocksRemaining: 600}
+	info := &types.TSpendVotingInfo{
+		FirstSeenHeight:    100,
+		YesVotes:           750,
+		NoVotes:            250,
+		VotesCast:          1000,
+		ApprovalRate:       75,
+		ProjectedVotesCast: 4000,
+		WindowProgress:     25,
+	}
+	s := tspendStanding(ts, info, params)
+	if s.TxHash != "ab" || s.BlocksRemaining != 600 || s.FirstSeenHeight != 100 || s.YesVotes != 750 {
+		t.Errorf("standing = %+v", s)
+	}
+	if s.Now.WouldPassNow || s.Now.QuorumVotesNeeded != 2456 {
+		t.Errorf("now = %+v, want short of quorum by 2456", *s.Now)
+	}
+	if !s.ProjectedPass {
+		t.Error("projected to fail, want pass")
+	}
+
+	// At 50% yes the projection fails on approval however many votes come.
+	info.YesVotes, info.NoVotes, info.ApprovalRate = 500, 500, 50
+	if tspendStanding(ts, info, params).ProjectedPass {
+		t.Error("50% yes projected to pass")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// Ticket vote statuses reported by FetchTicketTSpendVote.
+const (
+	// TicketVoteVoted is a ticket that voted within the tspend's window;
+	// Choice says how.
+	TicketVoteVoted = "voted"
+	// TicketVoteNotVoted is a ticket that was live for part of the window
+	// but cast no vote in it.
+	TicketVoteNotVoted = "not-voted"
+	// TicketVoteNotLive is a ticket that was never live during the window.
+	TicketVoteNotLive = "not-live"
+)
+
+// FetchTicketTSpendVote reports how ticketHash voted on tspendHash: the
+// choice of its vote when that vote was cast in the tspend's voting window
+// (as tspendVoteRange places it), or why it has none there. The vote is
+// resolved from the wallet when the ticket is one of ours, and otherwise by
+// scanning the stake trees of the blocks the ticket was live in within the
+// window.
+func FetchTicketTSpendVote(ctx context.Context, tspendHash, ticketHash string) (*types.TicketTSpendVote, error) {
+	if len(tspendHash) != 64 || !isHex(tspendHash) || len(ticketHash) != 64 || !isHex(ticketHash) {
+		return nil, ErrInvalidHash
+	}
+	tspend, err := chainhash.NewHashFromStr(tspendHash)
+	if err != nil {
+		return nil, ErrInvalidHash
+	}
+	ticket, err := chainhash.NewHashFromStr(ticketHash)
+	if err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	tspendTx, err := getTSpendTransaction(ctx, tspendHash)
+	if err != nil {
+		return nil, err
+	}
+	if !isTreasurySpend(tspendTx) {
+		return nil, fmt.Errorf("%s: %w", tspendHash, ErrNotTSpend)
+	}
+	ticketTx, err := getTransaction(ctx, ticketHash)
+	if err != nil {
+		return nil, err
+	}
+	if !isTicketPurchase(ticketTx) {
+		return nil, fmt.Errorf("%s: %w", ticketHash, ErrNotTicket)
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash, _ := tspendTx["blockhash"].(string)
+	start, end, err := tspendVoteRange(ctx, tspendHash, int64(mapFloat(tspendTx, "blockheight")), blockHash == "")
+	if err != nil {
+		return nil, err
+	}
+	res := &types.TicketTSpendVote{
+		TSpendHash:  tspend.String(),
+		TicketHash:  ticket.String(),
+		Status:      TicketVoteNotLive,
+		WindowStart: start,
+		WindowEnd:   end,
+	}
+	purchaseHeight := int64(mapFloat(ticketTx, "blockheight"))
+	if purchaseHeight <= 0 {
+		res.Note = "ticket is not mined"
+		return res, nil
+	}
+	// A ticket can be called to vote from the block after it matures through
+	// its expiry height.
+	maturity := purchaseHeight + int64(params.TicketMaturity)
+	res.LiveFrom = maturity + 1
+	res.LiveUntil = maturity + int64(params.TicketExpiry)
+	from, to := max(start, res.LiveFrom), end
+	if res.LiveUntil < to {
+		to = res.LiveUntil
+	}
+	if from > to {
+		return res, nil
+	}
+	res.Status = TicketVoteNotVoted
+
+	// An unspent ticket has not voted at all.
+	out, err := rpc.DcrdClient.GetTxOut(ctx, ticket, 0, 1, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket output: %w", err)
+	}
+	if out != nil {
+		return res, nil
+	}
+
+	var spender map[string]interface{}
+	if h := walletTicketSpender(ctx, ticket); h != "" {
+		if spender, err = getTransaction(ctx, h); err != nil {
+			log.Printf("Warning: Failed to get spender %s of ticket %s: %v", h, ticketHash, err)
+		}
+	}
+	if spender == nil {
+		spender = scanTicketSpender(ctx, res.TicketHash, from, to)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	if spender == nil || !isVoteTransaction(spender) {
+		return res, nil
+	}
+	height := int64(mapFloat(spender, "blockheight"))
+	if height < from || height > to {
+		return res, nil
+	}
+	res.Status = TicketVoteVoted
+	res.Choice = parseTSpendVote(spender, *tspend).String()
+	res.VoteHash, _ = spender["txid"].(string)
+	res.VoteHeight = height
+	return res, nil
+}
+
+// scanTicketSpender looks through the stake trees of blocks from through to
+// for the vote or revocation spending ticketHash, returning it with its
+// block height set, or nil when none of them holds it.
+func scanTicketSpender(ctx context.Context, ticketHash string, from, to int64) map[string]interface{} {
+	var spender map[string]interface{}
+	fetchStakeTxRange(ctx, from, to, fetchBlockStakeTxs, func(height int64, rawSTx []map[string]interface{}, err error) bool {
+		if err != nil {
+			log.Printf("Warning: Ticket vote lookup could not read block %d: %v", height, err)
+			return true
+		}
+		if tx := ticketSpenderIn(rawSTx, ticketHash); tx != nil {
+			tx["blockheight"] = float64(height)
+			spender = tx
+			return false
+		}
+		return true
+	})
+	return spender
+}
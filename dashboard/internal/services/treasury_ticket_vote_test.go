@@ -0,0 +1,24 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import "testing"
+
+func TestTicketSpenderIn(t *testing.T) {
+	const ticket = "aa00000000000000000000000000000000000000000000000000000000000000"
+	input := func(txid string) map[string]interface{} { return map[string]interface{}{"txid": txid} }
+	vote := map[string]interface{}{
+		"txid": "vote",
+		"vin":  []interface{}{map[string]interface{}{"stakebase": "0000"}, input(ticket)},
+		"vout": []interface{}{},
+	}
+	other := map[string]interface{}{"txid": "other", "vin": []interface{}{input(ticket)}}
+	if got := ticketSpenderIn([]map[string]interface{}{other, vote}, ticket); got == nil || got["txid"] != "vote" {
+		t.Errorf("spender = %v, want the vote", got)
+	}
+	if got := ticketSpenderIn([]map[string]interface{}{vote}, "bb"+ticket[2:]); got != nil {
+		t.Errorf("spender of another ticket = %v, want none", got)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"sync"
+
+	"dcrpulse/internal/types"
+)
+
+// treasuryTotals is what the scan results add up to: the atoms their
+// TSpends paid out and their treasurybase runs paid in.
+type treasuryTotals struct {
+	version  uint64 // scanVersion the sums were taken at
+	spent    int64
+	added    int64
+	count    int
+	complete bool
+}
+
+// The totals are summed again only once the scan results have moved on to a
+// new version, so polling treasury info doesn't walk the results each time.
+var (
+	treasuryTotalsMu    sync.Mutex
+	treasuryTotalsCache treasuryTotals
+)
+
+// scanTreasuryTotals returns the lifetime treasury totals of the scan
+// results. They are complete when the results run from activation to their
+// covered height with no block left unread; before that they cover only
+// what the scans have read. TAdds are not scanned, so added is the
+// treasurybase inflow.
+func scanTreasuryTotals(activation int64) treasuryTotals {
+	scanMutex.RLock()
+	defer scanMutex.RUnlock()
+	treasuryTotalsMu.Lock()
+	defer treasuryTotalsMu.Unlock()
+
+	if treasuryTotalsCache.version != scanVersion {
+		treasuryTotalsCache = sumTreasuryTotals(scanResults, scanTreasuryBase)
+		treasuryTotalsCache.version = scanVersion
+	}
+	totals := treasuryTotalsCache
+	totals.complete = scanTotalsComplete(activation, scanCoveredFrom, scanCoveredHeight, len(scanFailedHeights))
+	return totals
+}
+
+// sumTreasuryTotals adds up the spends of results and the inflows of tbase.
+func sumTreasuryTotals(results []types.TSpendHistory, tbase []treasuryBaseInflow) treasuryTotals {
+	totals := treasuryTotals{count: len(results), added: sumTreasuryBase(tbase)}
+	for _, r := range results {
+		totals.spent += r.AmountAtoms
+	}
+	return totals
+}
+
+// scanTotalsComplete reports whether results covering coveredFrom through
+// coveredHeight, with failed blocks unread, account for the treasury's whole
+// history since activation.
+func scanTotalsComplete(activation, coveredFrom, coveredHeight int64, failed int) bool {
+	return activation > 0 && coveredHeight > 0 && coveredFrom > 0 && coveredFrom <= activation && failed == 0
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestSumTreasuryTotals(t *testing.T) {
+	results := []types.TSpendHistory{{AmountAtoms: 150e8}, {AmountAtoms: 0.5e8}}
+	tbase := []treasuryBaseInflow{{height: 600, blocks: 100, atoms: 30e8}, {height: 700, blocks: 100, atoms: 29e8}}
+	got := sumTreasuryTotals(results, tbase)
+	if got.spent != 150.5e8 || got.added != 59e8 || got.count != 2 {
+		t.Errorf("totals = %+v, want spent 150.5 DCR, added 59 DCR, 2 tspends", got)
+	}
+}
+
+func TestScanTotalsComplete(t *testing.T) {
+	const activation = 552448
+	tests := []struct {
+		name                      string
+		activation, from, covered int64
+		failed                    int
+		want                      bool
+	}{
+		{"full scan", activation, activation, 900000, 0, true},
+		{"no scan", activation, 0, 0, 0, false},
+		{"recent scan", activation, 880000, 900000, 0, false},
+		{"unread blocks", activation, activation, 900000, 2, false},
+		{"unknown activation", 0, activation, 900000, 0, false},
+	}
+	for _, tt := range tests {
+		if got := scanTotalsComplete(tt.activation, tt.from, tt.covered, tt.failed); got != tt.want {
+			t.Errorf("%s: complete = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// ErrNotTSpend is returned by GetTSpendDetail for a transaction that exists
+// but is not a treasury spend.
+var ErrNotTSpend = errors.New("transaction is not a treasury spend")
+
+// GetTSpendDetail returns a tspend's amount, payees, block or mempool state
+// and vote breakdown. The voting info comes from GetTSpendVotingInfo, so for
+// a mined tspend the first request starts the vote count in the background
+// and later requests return it from cache. A tspend carries no on-chain
+// reference to a Politeia proposal; PoliteiaKey is its raw OP_RETURN push,
+// and Proposal is set only when LinkTSpendProposal can tie it to one.
+func GetTSpendDetail(ctx context.Context, txHash string) (*types.TSpendDetail, error) {
+	if len(txHash) != 64 || !isHex(txHash) {
+		return nil, ErrInvalidHash
+	}
+	tx, err := getTSpendTransaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if !isTreasurySpend(tx) {
+		return nil, fmt.Errorf("%s: %w", txHash, ErrNotTSpend)
+	}
+
+	currentHeight, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+
+	blockHash, _ := tx["blockhash"].(string)
+	blockHeight, _ := tx["blockheight"].(float64)
+	blockTime, _ := tx["blocktime"].(float64)
+	confirmations, _ := tx["confirmations"].(float64)
+	expiry, _ := tx["expiry"].(float64)
+
+	atoms, payees := sumTSpendOutputs(tx)
+	detail := &types.TSpendDetail{
+		TxHash:        txHash,
+		InMempool:     blockHash == "",
+		Amount:        atomsToCoin(atoms),
+		AmountAtoms:   atoms,
+		AmountDCR:     formatDCR(atoms),
+		Payees:        payees,
+		Confirmations: int64(confirmations),
+		ExpiryHeight:  int64(expiry),
+		CurrentHeight: currentHeight,
+		PoliteiaKey:   tspendPoliteiaKey(tx),
+	}
+	if detail.InMempool {
+		detail.Status = "voting"
+		detail.BlocksRemaining = detail.ExpiryHeight - currentHeight
+		if detail.BlocksRemaining <= 0 {
+			detail.Status = "expired"
+			detail.BlocksRemaining = 0
+		}
+	} else {
+		// A tspend can only be mined once its vote passed.
+		detail.Status = "approved"
+		detail.BlockHeight = int64(blockHeight)
+		detail.BlockHash = blockHash
+		if blockTime > 0 {
+			t := time.Unix(int64(blockTime), 0)
+			detail.BlockTime = &t
+		}
+	}
+
+	paidAt := time.Now()
+	if detail.BlockTime != nil {
+		paidAt = *detail.BlockTime
+	}
+	detail.Proposal = LinkTSpendProposal(txHash, paidAt, payees)
+
+	info, err := GetTSpendVotingInfo(ctx, txHash, detail.BlockHeight, uint32(expiry), detail.InMempool)
+	if err != nil {
+		log.Printf("Warning: Could not get voting info for tspend %s: %v", txHash, err)
+	} else {
+		detail.VotingInfo = info
+	}
+	return detail, nil
+}
+
+// getTSpendTransaction is getTransaction for a tspend. Without --txindex a
+// mined tspend found by the historical scan is read from its block instead.
+func getTSpendTransaction(ctx context.Context, txHash string) (map[string]interface{}, error) {
+	var blockHash string
+	for _, h := range GetScanResults() {
+		if h.TxHash == txHash {
+			blockHash = h.BlockHash
+			break
+		}
+	}
+	result, err := fetchRawTransactionInBlock(ctx, txHash, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	var tx map[string]interface{}
+	if err := json.Unmarshal(result, &tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// tspendPoliteiaKey reads the OP_RETURN push a tspend carries as its first
+// output.
+func tspendPoliteiaKey(tx map[string]interface{}) string {
+	vout, _ := tx["vout"].([]interface{})
+	if len(vout) == 0 {
+		return ""
+	}
+	first, _ := vout[0].(map[string]interface{})
+	spk, _ := first["scriptPubKey"].(map[string]interface{})
+	if t, _ := spk["type"].(string); t != "nulldata" {
+		return ""
+	}
+	hex, _ := spk["hex"].(string)
+	return politeiaKeyFromScript(hex)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"container/list"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+// Defaults for ConfigureVoteCache.
+const (
+	DefaultVoteCacheEntries = 256
+	DefaultVoteProgressTTL  = time.Hour
+)
+
+// The finished vote counts in votingCache and voteBlocksCache are kept for the
+// voteCacheMax tspends used most recently. Progress entries of finished or
+// cancelled counts are dropped voteProgressTTL after they finished, or oldest
+// first past voteCacheMax; those of running counts are kept, and are bounded
+// by the jobs running.
+var (
+	voteCacheMax    = DefaultVoteCacheEntries
+	voteProgressTTL = DefaultVoteProgressTTL
+
+	// voteCacheOrder lists cached txhashes, most recently used first;
+	// guarded by votingCacheMutex.
+	voteCacheOrder = list.New()
+	voteCacheElems = make(map[string]*list.Element)
+
+	// voteProgressDone is when each finished progress entry finished;
+	// guarded by progressMutex.
+	voteProgressDone = make(map[string]time.Time)
+
+	// voteCacheUsed is when each cached txhash was last used; guarded by
+	// votingCacheMutex.
+	voteCacheUsed = make(map[string]time.Time)
+
+	// Retention of cached counts, set by ConfigureVoteRetention; guarded by
+	// votingCacheMutex. A zero voteRetention keeps counts until evicted.
+	voteRetention      time.Duration
+	voteRetainVerdicts = true
+	voteRetentionOnce  sync.Once
+)
+
+// voteRetentionEvery is how often the retention policy is applied.
+const voteRetentionEvery = time.Hour
+
+// ConfigureVoteCache sets how many tspends' vote counts are kept and how long
+// a finished count's progress stays readable. Non-positive values keep the
+// defaults.
+func ConfigureVoteCache(maxEntries int, progressTTL time.Duration) {
+	votingCacheMutex.Lock()
+	if maxEntries > 0 {
+		voteCacheMax = maxEntries
+	}
+	evictVoteCacheLocked()
+	votingCacheMutex.Unlock()
+
+	progressMutex.Lock()
+	if progressTTL > 0 {
+		voteProgressTTL = progressTTL
+	}
+	pruneVoteProgressLocked(time.Now())
+	progressMutex.Unlock()
+}
+
+// cachedVotingInfo returns txHash's finished vote count and marks it used.
+func cachedVotingInfo(txHash string) (*types.TSpendVotingInfo, bool) {
+	votingCacheMutex.Lock()
+	defer votingCacheMutex.Unlock()
+	info, ok := votingCache[txHash]
+	if ok {
+		touchVoteCacheLocked(txHash)
+	}
+	return info, ok
+}
+
+// cachedVoteBlocks returns txHash's per-block votes and marks them used.
+func cachedVoteBlocks(txHash string) ([]types.TSpendBlockVotes, bool) {
+	votingCacheMutex.Lock()
+	defer votingCacheMutex.Unlock()
+	blocks, ok := voteBlocksCache[txHash]
+	if ok {
+		touchVoteCacheLocked(txHash)
+	}
+	return blocks, ok
+}
+
+// cacheVoteCount stores a finished count: info, when non-nil, and its
+// per-block votes, evicting the least recently used past voteCacheMax.
+func cacheVoteCount(txHash string, info *types.TSpendVotingInfo, blocks []types.TSpendBlockVotes) {
+	votingCacheMutex.Lock()
+	defer votingCacheMutex.Unlock()
+	if info != nil {
+		votingCache[txHash] = info
+	}
+	voteBlocksCache[txHash] = blocks
+	touchVoteCacheLocked(txHash)
+	evictVoteCacheLocked()
+}
+
+// touchVoteCacheLocked moves txHash to the front of the use order. It must be
+// called with votingCacheMutex held.
+func touchVoteCacheLocked(txHash string) {
+	voteCacheUsed[txHash] = time.Now()
+	if e, ok := voteCacheElems[txHash]; ok {
+		voteCacheOrder.MoveToFront(e)
+		return
+	}
+	voteCacheElems[txHash] = voteCacheOrder.PushFront(txHash)
+}
+
+// evictVoteCacheLocked drops the least recently used counts past
+// voteCacheMax. It must be called with votingCacheMutex held.
+func evictVoteCacheLocked() {
+	for voteCacheOrder.Len() > voteCacheMax {
+		dropVoteCountLocked(voteCacheOrder.Back().Value.(string))
+	}
+}
+
+// dropVoteCountLocked removes txHash's count from the cache. It must be
+// called with votingCacheMutex held.
+func dropVoteCountLocked(txHash string) {
+	if e, ok := voteCacheElems[txHash]; ok {
+		voteCacheOrder.Remove(e)
+	}
+	delete(voteCacheElems, txHash)
+	delete(voteCacheUsed, txHash)
+	delete(votingCache, txHash)
+	delete(voteBlocksCache, txHash)
+}
+
+// ConfigureVoteRetention sets how many days a cached count may go unused
+// before CompactVoteCache prunes it; 0 keeps counts until the cache evicts
+// them. With keepVerdicts the prune drops only the per-block votes and keeps
+// the vote totals, which are final once the tspend is mined and small.
+func ConfigureVoteRetention(days int, keepVerdicts bool) {
+	votingCacheMutex.Lock()
+	defer votingCacheMutex.Unlock()
+	voteRetention = time.Duration(max(days, 0)) * 24 * time.Hour
+	voteRetainVerdicts = keepVerdicts
+}
+
+// StartVoteRetention applies the retention policy every voteRetentionEvery
+// until ctx ends. Safe to call more than once; later calls no-op.
+func StartVoteRetention(ctx context.Context) {
+	voteRetentionOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(voteRetentionEvery)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if c := CompactVoteCache(); c.CountsDropped+c.BreakdownsDropped > 0 {
+						log.Printf("Vote cache retention: dropped %d counts and %d per-block breakdowns",
+							c.CountsDropped, c.BreakdownsDropped)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// CompactVoteCache applies the retention policy now: counts unused for
+// longer than the retention period lose their per-block votes, or are
+// dropped entirely unless verdicts are kept, and finished progress entries
+// past their TTL are dropped. Treasury scan results are never pruned.
+func CompactVoteCache() types.VoteCacheCompaction {
+	now := time.Now()
+	votingCacheMutex.Lock()
+	res := compactVoteCacheLocked(now)
+	votingCacheMutex.Unlock()
+
+	progressMutex.Lock()
+	before := len(voteParsingProgress)
+	pruneVoteProgressLocked(now)
+	res.ProgressDropped = before - len(voteParsingProgress)
+	progressMutex.Unlock()
+	return res
+}
+
+// compactVoteCacheLocked prunes the counts the retention policy no longer
+// keeps as of now. It must be called with votingCacheMutex held.
+func compactVoteCacheLocked(now time.Time) types.VoteCacheCompaction {
+	var res types.VoteCacheCompaction
+	if voteRetention > 0 {
+		for txHash, used := range voteCacheUsed {
+			if now.Sub(used) <= voteRetention {
+				continue
+			}
+			if !voteRetainVerdicts || votingCache[txHash] == nil {
+				dropVoteCountLocked(txHash)
+				res.CountsDropped++
+			} else if _, ok := voteBlocksCache[txHash]; ok {
+				delete(voteBlocksCache, txHash)
+				res.BreakdownsDropped++
+			}
+		}
+	}
+	res.CountsKept = len(votingCache)
+	return res
+}
+
+// pruneVoteProgressLocked drops finished progress entries older than
+// voteProgressTTL, then the oldest finished ones past voteCacheMax. It must
+// be called with progressMutex held.
+func pruneVoteProgressLocked(now time.Time) {
+	for txHash, done := range voteProgressDone {
+		if now.Sub(done) > voteProgressTTL {
+			delete(voteProgressDone, txHash)
+			delete(voteParsingProgress, txHash)
+		}
+	}
+	for len(voteProgressDone) > voteCacheMax {
+		var oldest string
+		var oldestAt time.Time
+		for txHash, done := range voteProgressDone {
+			if oldest == "" || done.Before(oldestAt) {
+				oldest, oldestAt = txHash, done
+			}
+		}
+		delete(voteProgressDone, oldest)
+		delete(voteParsingProgress, oldest)
+	}
+}
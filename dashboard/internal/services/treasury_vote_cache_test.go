@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+func TestVoteCacheBounded(t *testing.T) {
+	hash := func(i int) string { return fmt.Sprintf("tx%03d", i) }
+	savedMax, savedTTL := voteCacheMax, voteProgressTTL
+	defer func() {
+		ConfigureVoteCache(savedMax, savedTTL)
+		progressMutex.Lock()
+		for _, txHash := range []string{"running", "old", hash(47), hash(48), hash(49)} {
+			delete(voteParsingProgress, txHash)
+			delete(voteProgressDone, txHash)
+		}
+		progressMutex.Unlock()
+	}()
+	ConfigureVoteCache(3, time.Hour)
+
+	for i := 0; i < 50; i++ {
+		cacheVoteCount(hash(i), &types.TSpendVotingInfo{}, nil)
+		if i == 47 {
+			// Used again: outlives the two cached after it.
+			cachedVotingInfo(hash(45))
+		}
+	}
+	votingCacheMutex.RLock()
+	if len(votingCache) != 3 || len(voteBlocksCache) != 3 || voteCacheOrder.Len() != 3 {
+		t.Errorf("cache sizes %d/%d/%d, want 3", len(votingCache), len(voteBlocksCache), voteCacheOrder.Len())
+	}
+	for _, i := range []int{45, 48, 49} {
+		if _, ok := votingCache[hash(i)]; !ok {
+			t.Errorf("%s evicted", hash(i))
+		}
+	}
+	votingCacheMutex.RUnlock()
+
+	// A running count's progress is kept; finished ones are capped.
+	setVoteParsingProgress("running", &types.VoteParsingProgress{IsParsing: true})
+	for i := 0; i < 50; i++ {
+		setVoteParsingProgress(hash(i), &types.VoteParsingProgress{})
+	}
+	progressMutex.RLock()
+	_, running := voteParsingProgress["running"]
+	size := len(voteParsingProgress)
+	progressMutex.RUnlock()
+	if !running {
+		t.Error("running count's progress evicted")
+	}
+	if size != 4 {
+		t.Errorf("progress size %d, want 4", size)
+	}
+
+	// Finished progress past the TTL is dropped.
+	progressMutex.Lock()
+	voteParsingProgress["old"] = &types.VoteParsingProgress{}
+	voteProgressDone["old"] = time.Now().Add(-2 * time.Hour)
+	pruneVoteProgressLocked(time.Now())
+	_, old := voteParsingProgress["old"]
+	progressMutex.Unlock()
+	if old {
+		t.Error("expired progress kept")
+	}
+}
+
+func TestCompactVoteCache(t *testing.T) {
+	savedRetention, savedVerdicts := voteRetention, voteRetainVerdicts
+	defer func() {
+		votingCacheMutex.Lock()
+		for _, txHash := range []string{"fresh", "stale", "stalePartial"} {
+			dropVoteCountLocked(txHash)
+		}
+		voteRetention, voteRetainVerdicts = savedRetention, savedVerdicts
+		votingCacheMutex.Unlock()
+	}()
+	cacheVoteCount("fresh", &types.TSpendVotingInfo{}, []types.TSpendBlockVotes{{Height: 1}})
+	cacheVoteCount("stale", &types.TSpendVotingInfo{}, []types.TSpendBlockVotes{{Height: 1}})
+	// An export's breakdown without totals has nothing to keep.
+	cacheVoteCount("stalePartial", nil, []types.TSpendBlockVotes{{Height: 1}})
+
+	now := time.Now()
+	votingCacheMutex.Lock()
+	voteCacheUsed["stale"] = now.Add(-8 * 24 * time.Hour)
+	voteCacheUsed["stalePartial"] = now.Add(-8 * 24 * time.Hour)
+
+	// No retention period: nothing goes.
+	voteRetention = 0
+	if res := compactVoteCacheLocked(now); res.CountsDropped+res.BreakdownsDropped != 0 {
+		t.Errorf("without retention: %+v", res)
+	}
+
+	// Keeping verdicts, the stale count loses only its breakdown.
+	setRetention := func(days int, keep bool) {
+		voteRetention, voteRetainVerdicts = time.Duration(days)*24*time.Hour, keep
+	}
+	setRetention(7, true)
+	res := compactVoteCacheLocked(now)
+	if res.CountsDropped != 1 || res.BreakdownsDropped != 1 {
+		t.Errorf("keeping verdicts: %+v", res)
+	}
+	if _, ok := votingCache["stale"]; !ok {
+		t.Error("stale verdict dropped")
+	}
+	if _, ok := voteBlocksCache["stale"]; ok {
+		t.Error("stale breakdown kept")
+	}
+	if _, ok := voteBlocksCache["fresh"]; !ok {
+		t.Error("fresh breakdown dropped")
+	}
+
+	// Otherwise the whole count goes.
+	setRetention(7, false)
+	if res := compactVoteCacheLocked(now); res.CountsDropped != 1 {
+		t.Errorf("dropping verdicts: %+v", res)
+	}
+	if _, ok := votingCache["stale"]; ok {
+		t.Error("stale verdict kept")
+	}
+	if _, ok := votingCache["fresh"]; !ok {
+		t.Error("fresh verdict dropped")
+	}
+	votingCacheMutex.Unlock()
+}
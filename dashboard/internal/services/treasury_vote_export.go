@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+// ExportTSpendVotes calls emit with each block of txHash's voting window, in
+// height order, with the votes on it found in that block. A mined tspend
+// whose vote count already finished is served from cache; otherwise the
+// window is scanned as calculateTSpendVotes does, emitting each block as it
+// is read, and a complete scan of a mined tspend is cached. Unlike the vote
+// count, a block that can't be read fails the export rather than being left
+// out. Errors from emit stop the export and are returned.
+func ExportTSpendVotes(ctx context.Context, txHash string, emit func(types.TSpendBlockVotes) error) error {
+	if len(txHash) != 64 || !isHex(txHash) {
+		return ErrInvalidHash
+	}
+	if cached, ok := cachedVoteBlocks(txHash); ok {
+		for _, b := range cached {
+			if err := emit(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tx, err := getTSpendTransaction(ctx, txHash)
+	if err != nil {
+		return err
+	}
+	if !isTreasurySpend(tx) {
+		return fmt.Errorf("%s: %w", txHash, ErrNotTSpend)
+	}
+	blockHash, _ := tx["blockhash"].(string)
+	blockHeight, _ := tx["blockheight"].(float64)
+	inMempool := blockHash == ""
+
+	start, end, err := tspendVoteRange(ctx, txHash, int64(blockHeight), inMempool)
+	if err != nil {
+		return err
+	}
+
+	var tally stakeTally
+	blocks := make([]types.TSpendBlockVotes, 0, end-start+1)
+	for height := start; height <= end; height++ {
+		rawSTx, err := fetchBlockStakeTxs(ctx, height)
+		if err != nil {
+			return err
+		}
+		b := tally.addBlock(rawSTx, txHash)
+		b.Height = height
+		if err := emit(b); err != nil {
+			return err
+		}
+		blocks = append(blocks, b)
+	}
+
+	if !inMempool {
+		cacheVoteCount(txHash, nil, blocks)
+	}
+	return nil
+}
+
+// tspendVoteRange returns the blocks calculateTSpendVotes counts for a tspend:
+// the voting window up to the block that mined it, or for one still in the
+// mempool, from when it was first seen to the tip. The window is capped at
+// the same 3000 blocks.
+func tspendVoteRange(ctx context.Context, txHash string, blockHeight int64, inMempool bool) (int64, int64, error) {
+	var start, end int64
+	if inMempool {
+		tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get current height: %w", err)
+		}
+		start = recordTSpendFirstSeen(txHash, tip)
+		end = tip
+	} else {
+		tp, err := CurrentTreasuryParams(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("treasury params: %w", err)
+		}
+		start = blockHeight - tp.VoteWindow
+		if start < tp.ActivationHeight {
+			start = tp.ActivationHeight
+		}
+		end = blockHeight
+	}
+	maxScanRange := int64(3000)
+	if end-start > maxScanRange {
+		start = end - maxScanRange
+	}
+	return start, end, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestExportTSpendVotesMempool(t *testing.T) {
+	const tspend = "1111111111111111111111111111111111111111111111111111111111111111"
+
+	// A vote's treasury vote output: OP_RETURN, push 35, "TV", the hash
+	// (the same in either byte order) and the vote byte.
+	vote := func(choice string) map[string]interface{} {
+		script := "6a23" + "5456" + tspend + choice
+		return map[string]interface{}{
+			"vin": []interface{}{map[string]interface{}{"stakebase": "0000"}},
+			"vout": []interface{}{
+				map[string]interface{}{"scriptPubKey": map[string]interface{}{"type": "stakegen"}},
+				map[string]interface{}{"scriptPubKey": map[string]interface{}{"type": "nulldata", "hex": script}},
+			},
+		}
+	}
+	yes, no := vote("01"), vote("02")
+	abstain := map[string]interface{}{
+		"vin": []interface{}{map[string]interface{}{"stakebase": "0000"}},
+	}
+	stakeTxs := map[string][]map[string]interface{}{
+		fakeBlockHash(100).String(): {yes, yes, no},
+		fakeBlockHash(101).String(): {yes, abstain},
+		fakeBlockHash(102).String(): {no},
+	}
+
+	useFakeDcrd(t, &fakeDcrd{
+		height: 102,
+		raw: map[string]func([]json.RawMessage) (json.RawMessage, error){
+			"getrawtransaction": func(params []json.RawMessage) (json.RawMessage, error) {
+				// In the mempool: no blockhash.
+				return json.Marshal(map[string]interface{}{
+					"txid": tspend,
+					"vin":  []interface{}{map[string]interface{}{"treasuryspend": ""}},
+				})
+			},
+			"getblock": func(params []json.RawMessage) (json.RawMessage, error) {
+				var hash string
+				if err := json.Unmarshal(params[0], &hash); err != nil {
+					return nil, err
+				}
+				stx, ok := stakeTxs[hash]
+				if !ok {
+					return nil, fmt.Errorf("no block %s", hash)
+				}
+				return json.Marshal(map[string]interface{}{"rawstx": stx})
+			},
+		},
+	})
+	recordTSpendFirstSeen(tspend, 100)
+	defer pruneTSpendFirstSeen(nil)
+
+	var got []types.TSpendBlockVotes
+	err := ExportTSpendVotes(context.Background(), tspend, func(b types.TSpendBlockVotes) error {
+		got = append(got, b)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.TSpendBlockVotes{
+		{Height: 100, Yes: 2, No: 1},
+		{Height: 101, Yes: 1, Abstain: 1},
+		{Height: 102, No: 1},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// Still voting: not cached.
+	if _, ok := cachedVoteBlocks(tspend); ok {
+		t.Error("mempool tspend's votes cached")
+	}
+
+	// A block dcrd can't serve fails the export.
+	delete(stakeTxs, fakeBlockHash(101).String())
+	err = ExportTSpendVotes(context.Background(), tspend, func(types.TSpendBlockVotes) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "101") {
+		t.Errorf("missing block: got %v", err)
+	}
+}
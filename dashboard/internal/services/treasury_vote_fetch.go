@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// Defaults for ConfigureVoteScanner.
+const (
+	DefaultVoteScanWorkers = 4
+	DefaultVoteScanBatch   = 32
+)
+
+// How the vote counter reads a voting window: batches of voteScanBatch
+// blocks, each fetched by up to voteScanWorkers concurrent getblock calls.
+// The dcrd client's own concurrency limit still applies on top.
+var (
+	voteScanWorkers = DefaultVoteScanWorkers
+	voteScanBatch   = DefaultVoteScanBatch
+)
+
+// ConfigureVoteScanner sets the vote counter's block fetch workers and batch
+// size. Non-positive values keep the defaults; one worker fetches serially.
+// Call it before any vote count starts.
+func ConfigureVoteScanner(workers, batch int) {
+	if workers > 0 {
+		voteScanWorkers = workers
+	}
+	if batch > 0 {
+		voteScanBatch = batch
+	}
+}
+
+// stakeTxFetcher returns the stake transactions of the block at height, as
+// fetchBlockStakeTxs does.
+type stakeTxFetcher func(ctx context.Context, height int64) ([]map[string]interface{}, error)
+
+// fetchStakeTxRange fetches the stake transactions of blocks start through
+// end with the vote scanner's workers and batch size, and hands each block to
+// emit in height order, so tallies and progress advance exactly as in a
+// serial scan. It stops when emit returns false or ctx ends between batches.
+func fetchStakeTxRange(ctx context.Context, start, end int64, fetch stakeTxFetcher,
+	emit func(height int64, rawSTx []map[string]interface{}, err error) bool) {
+
+	heights := make([]int64, 0, end-start+1)
+	for h := start; h <= end; h++ {
+		heights = append(heights, h)
+	}
+	fetchHeights(ctx, heights, voteScanWorkers, voteScanBatch, fetch, emit)
+}
+
+// fetchHeights calls fetch for each of heights, batch heights at a time with
+// up to workers calls running at once, and hands the results to emit in the
+// order of heights. It stops when emit returns false or ctx ends between
+// batches.
+func fetchHeights[T any](ctx context.Context, heights []int64, workers, batch int,
+	fetch func(ctx context.Context, height int64) (T, error),
+	emit func(height int64, v T, err error) bool) {
+
+	type fetched struct {
+		v   T
+		err error
+	}
+	results := make([]fetched, batch)
+	for from := 0; from < len(heights); from += batch {
+		if ctx.Err() != nil {
+			return
+		}
+		to := from + batch
+		if to > len(heights) {
+			to = len(heights)
+		}
+
+		indexes := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers && w < to-from; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					r := &results[i-from]
+					r.v, r.err = fetch(ctx, heights[i])
+				}
+			}()
+		}
+		for i := from; i < to; i++ {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+
+		for i := from; i < to; i++ {
+			r := results[i-from]
+			if !emit(heights[i], r.v, r.err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// useVoteScanner sets the vote scanner's workers and batch size for the rest
+// of the test.
+func useVoteScanner(tb testing.TB, workers, batch int) {
+	tb.Helper()
+	savedWorkers, savedBatch := voteScanWorkers, voteScanBatch
+	voteScanWorkers, voteScanBatch = workers, batch
+	tb.Cleanup(func() { voteScanWorkers, voteScanBatch = savedWorkers, savedBatch })
+}
+
+// stakeTxsAt is a fake block fetch: block h holds h%3 votes, and blocks
+// divisible by 7 can't be read.
+func stakeTxsAt(_ context.Context, h int64) ([]map[string]interface{}, error) {
+	if h%7 == 0 {
+		return nil, fmt.Errorf("block %d unreadable", h)
+	}
+	return make([]map[string]interface{}, h%3), nil
+}
+
+func TestFetchStakeTxRange(t *testing.T) {
+	useVoteScanner(t, 5, 4)
+
+	var heights []int64
+	fetchStakeTxRange(context.Background(), 10, 30, stakeTxsAt, func(h int64, rawSTx []map[string]interface{}, err error) bool {
+		if want := int64(10 + len(heights)); h != want {
+			t.Fatalf("emitted block %d, want %d", h, want)
+		}
+		heights = append(heights, h)
+		if (err != nil) != (h%7 == 0) || len(rawSTx) != int(h%3) && err == nil {
+			t.Errorf("block %d: %d txs, err %v", h, len(rawSTx), err)
+		}
+		return true
+	})
+	if len(heights) != 21 {
+		t.Errorf("emitted %d blocks, want 21", len(heights))
+	}
+
+	// Stopping in the middle of a batch emits nothing after it.
+	var last int64
+	fetchStakeTxRange(context.Background(), 10, 30, stakeTxsAt, func(h int64, _ []map[string]interface{}, _ error) bool {
+		last = h
+		return h < 15
+	})
+	if last != 15 {
+		t.Errorf("stopped after block %d, want 15", last)
+	}
+
+	// A cancelled context stops before the next batch.
+	ctx, cancel := context.WithCancel(context.Background())
+	fetchStakeTxRange(ctx, 10, 30, func(ctx context.Context, h int64) ([]map[string]interface{}, error) {
+		cancel()
+		return nil, errors.New("cancelled")
+	}, func(h int64, _ []map[string]interface{}, _ error) bool {
+		last = h
+		return true
+	})
+	if last != 13 {
+		t.Errorf("cancelled scan emitted through block %d, want 13", last)
+	}
+}
+
+// BenchmarkFetchStakeTxRange reads a 288-block window where each getblock
+// takes 200µs, serially and with the default worker pool.
+func BenchmarkFetchStakeTxRange(b *testing.B) {
+	slowFetch := func(ctx context.Context, h int64) ([]map[string]interface{}, error) {
+		time.Sleep(200 * time.Microsecond)
+		return stakeTxsAt(ctx, h)
+	}
+	for _, bench := range []struct {
+		name    string
+		workers int
+	}{
+		{"serial", 1},
+		{"parallel", DefaultVoteScanWorkers},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			useVoteScanner(b, bench.workers, DefaultVoteScanBatch)
+			for i := 0; i < b.N; i++ {
+				fetchStakeTxRange(context.Background(), 1, 288, slowFetch, func(int64, []map[string]interface{}, error) bool {
+					return true
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"encoding/hex"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/txscript/v4"
+)
+
+// tspendVoteChoice is a vote's choice on one tspend.
+type tspendVoteChoice uint8
+
+const (
+	// tspendAbstain is a vote that doesn't name the tspend.
+	tspendAbstain tspendVoteChoice = iota
+	tspendYes
+	tspendNo
+	// tspendInvalid is a vote byte other than yes or no, which consensus
+	// rejects.
+	tspendInvalid
+)
+
+func (c tspendVoteChoice) String() string {
+	switch c {
+	case tspendYes:
+		return "yes"
+	case tspendNo:
+		return "no"
+	case tspendInvalid:
+		return "invalid"
+	default:
+		return "abstain"
+	}
+}
+
+// Treasury vote encoding (DCP-0006). A vote carrying treasury votes has an
+// output OP_RETURN <"TV" tspend-hash vote [tspend-hash vote ...]>: each
+// tspend's 32-byte hash in internal byte order, the reverse of its txid
+// string, followed by 0x01 for yes or 0x02 for no. Up to 7 tspends fit, so
+// the push is OP_PUSHDATA1 past 2 of them.
+const (
+	treasuryVoteYes   = 0x01
+	treasuryVoteNo    = 0x02
+	treasuryVoteEntry = chainhash.HashSize + 1
+)
+
+// treasuryVote is one entry of a vote's treasury vote output.
+type treasuryVote struct {
+	tspend chainhash.Hash
+	choice tspendVoteChoice // tspendYes, tspendNo or tspendInvalid
+}
+
+// decodeTreasuryVotes returns the entries of a treasury vote output script,
+// or nil when script is not one: not a single push after OP_RETURN, no "TV"
+// marker, or a payload that isn't whole entries.
+func decodeTreasuryVotes(script []byte) []treasuryVote {
+	if len(script) < 2 || script[0] != txscript.OP_RETURN {
+		return nil
+	}
+	var data []byte
+	switch op := script[1]; {
+	case op >= txscript.OP_DATA_1 && op <= txscript.OP_DATA_75:
+		data = script[2:]
+		if len(data) != int(op) {
+			return nil
+		}
+	case op == txscript.OP_PUSHDATA1 && len(script) > 2:
+		data = script[3:]
+		if len(data) != int(script[2]) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if len(data) < 2 || data[0] != 'T' || data[1] != 'V' {
+		return nil
+	}
+	data = data[2:]
+	if len(data) == 0 || len(data)%treasuryVoteEntry != 0 {
+		return nil
+	}
+
+	votes := make([]treasuryVote, 0, len(data)/treasuryVoteEntry)
+	for ; len(data) > 0; data = data[treasuryVoteEntry:] {
+		var v treasuryVote
+		copy(v.tspend[:], data[:chainhash.HashSize])
+		switch data[chainhash.HashSize] {
+		case treasuryVoteYes:
+			v.choice = tspendYes
+		case treasuryVoteNo:
+			v.choice = tspendNo
+		default:
+			v.choice = tspendInvalid
+		}
+		votes = append(votes, v)
+	}
+	return votes
+}
+
+// treasuryVoteFor returns the choice votes make on tspend: abstain when it
+// isn't named.
+func treasuryVoteFor(votes []treasuryVote, tspend chainhash.Hash) tspendVoteChoice {
+	for _, v := range votes {
+		if v.tspend == tspend {
+			return v.choice
+		}
+	}
+	return tspendAbstain
+}
+
+// parseTSpendVote returns a vote transaction's choice on tspend, read from
+// whichever of its null data outputs is the treasury vote output.
+func parseTSpendVote(tx map[string]interface{}, tspend chainhash.Hash) tspendVoteChoice {
+	vout, _ := tx["vout"].([]interface{})
+	for i := range vout {
+		if outputScriptType(tx, i) != "nulldata" {
+			continue
+		}
+		script, err := hex.DecodeString(outputScriptHex(tx, i))
+		if err != nil {
+			continue
+		}
+		if votes := decodeTreasuryVotes(script); votes != nil {
+			return treasuryVoteFor(votes, tspend)
+		}
+	}
+	return tspendAbstain
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// treasuryVoteFixture is a vote output script and the choice it makes on
+// tspend. Entries is how many tspends it votes on, 0 when it isn't a
+// treasury vote output.
+type treasuryVoteFixture struct {
+	Name    string `json:"name"`
+	Script  string `json:"script"`
+	TSpend  string `json:"tspend"`
+	Choice  string `json:"choice"`
+	Entries int    `json:"entries"`
+}
+
+// loadTreasuryVoteFixtures reads testdata/treasury_vote_scripts.json. The
+// scripts are in the consensus encoding, each tspend hash in internal byte
+// order, so one holding a txid string's bytes as written names a different
+// tspend.
+func loadTreasuryVoteFixtures(t *testing.T) []treasuryVoteFixture {
+	t.Helper()
+	data, err := os.ReadFile("testdata/treasury_vote_scripts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixtures []treasuryVoteFixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatal(err)
+	}
+	return fixtures
+}
+
+func TestDecodeTreasuryVotes(t *testing.T) {
+	for _, f := range loadTreasuryVoteFixtures(t) {
+		t.Run(f.Name, func(t *testing.T) {
+			script, err := hex.DecodeString(f.Script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tspend, err := chainhash.NewHashFromStr(f.TSpend)
+			if err != nil {
+				t.Fatal(err)
+			}
+			votes := decodeTreasuryVotes(script)
+			if len(votes) != f.Entries {
+				t.Fatalf("decoded %d entries, want %d", len(votes), f.Entries)
+			}
+			if got := treasuryVoteFor(votes, *tspend).String(); got != f.Choice {
+				t.Errorf("choice %s, want %s", got, f.Choice)
+			}
+
+			// The same script in a vote transaction, after the block
+			// reference and vote bits outputs.
+			tx := map[string]interface{}{
+				"vin": []interface{}{map[string]interface{}{"stakebase": "0000"}},
+				"vout": []interface{}{
+					nullDataOutput("6a24" + strings.Repeat("00", 36)),
+					nullDataOutput("6a0401000000"),
+					nullDataOutput(f.Script),
+				},
+			}
+			if got := parseTSpendVote(tx, *tspend).String(); got != f.Choice {
+				t.Errorf("parseTSpendVote: choice %s, want %s", got, f.Choice)
+			}
+		})
+	}
+}
+
+func nullDataOutput(scriptHex string) map[string]interface{} {
+	return map[string]interface{}{
+		"scriptPubKey": map[string]interface{}{"type": "nulldata", "hex": scriptHex},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestStakeTally(t *testing.T) {
+	vote := map[string]interface{}{
+		"vin": []interface{}{map[string]interface{}{"stakebase": "0000"}},
+	}
+	revocation := map[string]interface{}{
+		"vin": []interface{}{map[string]interface{}{"txid": "ab"}},
+		"vout": []interface{}{map[string]interface{}{
+			"scriptPubKey": map[string]interface{}{"type": "stakerevoke"},
+		}},
+	}
+	ticket := map[string]interface{}{
+		"vin": []interface{}{map[string]interface{}{"txid": "cd"}},
+		"vout": []interface{}{map[string]interface{}{
+			"scriptPubKey": map[string]interface{}{"type": "stakesubmission"},
+		}},
+	}
+
+	var tally stakeTally
+	tally.addBlock([]map[string]interface{}{vote, vote, vote, vote, vote, ticket}, "")
+	tally.addBlock([]map[string]interface{}{vote, vote, vote, revocation, revocation}, "")
+	if tally.blocks != 2 || tally.votes != 8 || tally.revocations != 2 {
+		t.Fatalf("got blocks=%d votes=%d revocations=%d, want 2, 8, 2",
+			tally.blocks, tally.votes, tally.revocations)
+	}
+	if got := tally.missed(5); got != 2 {
+		t.Errorf("missed(5) = %d, want 2", got)
+	}
+	if got := tally.missed(3); got != 0 {
+		t.Errorf("missed(3) = %d, want 0", got)
+	}
+}
+
+func TestTSpendPassProjection(t *testing.T) {
+	// Mainnet: 5 votes per block over a 288*12 block window, a 20% quorum
+	// of 3456 votes and 60% yes required.
+	params := chaincfg.MainNetParams()
+	tests := []struct {
+		yes, no      int
+		pass         bool
+		quorumNeeded int
+		yesNeeded    int
+		wantQuorum   int
+		wantApproval float64
+	}{
+		{3000, 0, false, 456, 0, 3456, 60},
+		{3000, 2000, true, 0, 0, 3456, 60},
+		{2400, 1600, true, 0, 0, 3456, 60},
+		{2000, 2000, false, 0, 1000, 3456, 60},
+		{5000, 100, true, 0, 0, 3456, 60},
+	}
+	for _, test := range tests {
+		p := tspendPassProjection(params, test.yes, test.no)
+		if p.Quorum != test.wantQuorum || p.RequiredApproval != test.wantApproval {
+			t.Fatalf("thresholds = %d, %v; want %d, %v", p.Quorum, p.RequiredApproval, test.wantQuorum, test.wantApproval)
+		}
+		if p.WouldPassNow != test.pass || p.QuorumVotesNeeded != test.quorumNeeded || p.YesVotesNeeded != test.yesNeeded {
+			t.Errorf("%d yes, %d no: pass %v, needed quorum %d, yes %d; want %v, %d, %d", test.yes, test.no,
+				p.WouldPassNow, p.QuorumVotesNeeded, p.YesVotesNeeded, test.pass, test.quorumNeeded, test.yesNeeded)
+		}
+	}
+}
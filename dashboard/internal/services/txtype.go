@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"strings"
+
+	"dcrpulse/internal/types"
+)
+
+// Transaction kinds reported in TransactionDetail.Classification.
+const (
+	TxKindRegular      = "regular"
+	TxKindCoinbase     = "coinbase"
+	TxKindTicket       = "ticket"
+	TxKindVote         = "vote"
+	TxKindRevocation   = "revocation"
+	TxKindTreasuryBase = "treasurybase"
+	TxKindTreasuryAdd  = "treasuryadd"
+	TxKindTSpend       = "tspend"
+)
+
+var txKindLabels = map[string]string{
+	TxKindRegular:      "Regular Transaction",
+	TxKindCoinbase:     "Coinbase",
+	TxKindTicket:       "Ticket Purchase",
+	TxKindVote:         "Vote",
+	TxKindRevocation:   "Ticket Revocation",
+	TxKindTreasuryBase: "Treasury Base",
+	TxKindTreasuryAdd:  "Treasury Add",
+	TxKindTSpend:       "Treasury Spend",
+}
+
+// classifyTransaction determines a verbose getrawtransaction result's kind and
+// fills in the fields specific to it. Unlike categorizeTransaction it keeps
+// treasury adds apart from treasurybase and doesn't apply the CoinJoin
+// heuristic, so the kind maps one-to-one onto a consensus transaction type.
+func classifyTransaction(tx map[string]interface{}) *types.TxClassification {
+	c := &types.TxClassification{}
+	switch {
+	case isTreasurySpend(tx):
+		c.Kind = TxKindTSpend
+		c.Payees = treasuryGenPayees(tx)
+	case isTreasuryBase(tx):
+		c.Kind = TxKindTreasuryBase
+	case isVoteTransaction(tx):
+		c.Kind = TxKindVote
+		c.TicketHash = inputPrevTxid(tx, 1)
+		c.VotedBlockHash, c.VotedBlockHeight = voteBlockRef(tx)
+		c.VoteBits, _ = voteBitsAndVersion(tx)
+	case isTicketPurchase(tx):
+		c.Kind = TxKindTicket
+		c.TicketPrice = ticketPrice(tx)
+	case isRevocation(tx):
+		c.Kind = TxKindRevocation
+		c.TicketHash = inputPrevTxid(tx, 0)
+	case outputScriptType(tx, 0) == "treasuryadd":
+		c.Kind = TxKindTreasuryAdd
+		c.TreasuryAddAmount = ticketPrice(tx)
+	case inputHasField(tx, "coinbase"):
+		c.Kind = TxKindCoinbase
+	default:
+		c.Kind = TxKindRegular
+	}
+	c.Label = txKindLabels[c.Kind]
+	return c
+}
+
+// inputHasField reports whether vin[0] carries the given key, which is how
+// dcrd marks coinbase, stakebase and treasurybase inputs.
+func inputHasField(tx map[string]interface{}, key string) bool {
+	vin, ok := tx["vin"].([]interface{})
+	if !ok || len(vin) == 0 {
+		return false
+	}
+	in, _ := vin[0].(map[string]interface{})
+	_, has := in[key]
+	return has
+}
+
+// treasuryGenPayees lists the addresses paid by a TSpend's treasurygen
+// outputs, in output order.
+func treasuryGenPayees(tx map[string]interface{}) []types.TSpendPayee {
+	var payees []types.TSpendPayee
+	vout, _ := tx["vout"].([]interface{})
+	for _, v := range vout {
+		out, _ := v.(map[string]interface{})
+		spk, _ := out["scriptPubKey"].(map[string]interface{})
+		t, _ := spk["type"].(string)
+		if !strings.Contains(t, "treasurygen") {
+			continue
+		}
+		atoms := voutAtoms(out)
+		addrs, _ := spk["addresses"].([]interface{})
+		for _, a := range addrs {
+			if addr, ok := a.(string); ok {
+				payees = append(payees, types.TSpendPayee{
+					Address:     addr,
+					Amount:      atomsToCoin(atoms),
+					AmountAtoms: atoms,
+				})
+			}
+		}
+	}
+	return payees
+}
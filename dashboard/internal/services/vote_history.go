@@ -0,0 +1,236 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// Vote history bounds. Votes are found by walking blocks, so one request
+// covers at most voteHistoryMaxRange blocks; parsed blocks are cached by hash
+// so overlapping queries, for any address, don't fetch them again.
+const (
+	voteHistoryDefaultRange = 288 // about a day of blocks on mainnet
+	voteHistoryMaxRange     = 4096
+	voteHistoryCacheBlocks  = 16384
+)
+
+var (
+	// ErrInvalidVoteAddress is returned for an address that does not
+	// decode on the active network.
+	ErrInvalidVoteAddress = errors.New("invalid address for this network")
+	// ErrInvalidVoteRange is returned for a from/to range that is inverted
+	// or wider than voteHistoryMaxRange blocks.
+	ErrInvalidVoteRange = fmt.Errorf("invalid range; from must not exceed to and the range is limited to %d blocks", voteHistoryMaxRange)
+)
+
+// blockVote is one vote parsed from a block, with the addresses its rewards
+// pay so it can be matched against any address.
+type blockVote struct {
+	record      types.VoteRecord
+	rewardAddrs []string
+}
+
+// Parsed votes by block hash, evicted oldest-first past voteHistoryCacheBlocks.
+var (
+	voteBlockCacheMu    sync.Mutex
+	voteBlockCache      = make(map[string][]blockVote)
+	voteBlockCacheOrder []string
+)
+
+// FetchVoteHistory returns the votes cast in blocks from..to by tickets whose
+// vote rewards pay address, which is how a ticket's owner (or a stakepool's
+// reward address) is identified on chain. Agenda choices are decoded against
+// the network's deployments for the vote's version and treasury spend votes
+// from its treasury vote output. A to of 0 means the tip and a from of 0 means
+// voteHistoryDefaultRange blocks before to.
+func FetchVoteHistory(ctx context.Context, address string, from, to int64) (*types.VoteHistory, error) {
+	if !ValidNetworkAddress(ctx, address) {
+		return nil, ErrInvalidVoteAddress
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := rpc.DcrdClient.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	if to <= 0 || to > tip {
+		to = tip
+	}
+	if from <= 0 {
+		from = max(to-voteHistoryDefaultRange+1, 1)
+	}
+	if from > to || to-from+1 > voteHistoryMaxRange {
+		return nil, ErrInvalidVoteRange
+	}
+
+	history := &types.VoteHistory{
+		Address:    address,
+		FromHeight: from,
+		ToHeight:   to,
+		Votes:      []types.VoteRecord{},
+	}
+	for h := from; h <= to; h++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		votes, err := blockVotes(ctx, params, h)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range votes {
+			for _, a := range v.rewardAddrs {
+				if a == address {
+					history.Votes = append(history.Votes, v.record)
+					break
+				}
+			}
+		}
+	}
+	return history, nil
+}
+
+// blockVotes returns the parsed votes of the block at height, from the cache
+// when the block hash is already known.
+func blockVotes(ctx context.Context, params *chaincfg.Params, height int64) ([]blockVote, error) {
+	blockHash, err := rpc.DcrdClient.GetBlockHash(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block hash at %d: %w", height, err)
+	}
+	key := blockHash.String()
+
+	voteBlockCacheMu.Lock()
+	votes, ok := voteBlockCache[key]
+	voteBlockCacheMu.Unlock()
+	if ok {
+		return votes, nil
+	}
+
+	res, err := rpc.DcrdClient.RawRequest(ctx, "getblock", []json.RawMessage{
+		jsonStr(key),
+		json.RawMessage("true"), // verbose
+		json.RawMessage("true"), // verbosetx
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", key, err)
+	}
+	var block struct {
+		Time   int64                    `json:"time"`
+		RawSTx []map[string]interface{} `json:"rawstx"`
+	}
+	if err := json.Unmarshal(res, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+
+	votes = []blockVote{}
+	for _, tx := range block.RawSTx {
+		if !isVoteTransaction(tx) {
+			continue
+		}
+		votes = append(votes, parseBlockVote(params, tx, height, key, block.Time))
+	}
+
+	voteBlockCacheMu.Lock()
+	if _, ok := voteBlockCache[key]; !ok {
+		voteBlockCache[key] = votes
+		voteBlockCacheOrder = append(voteBlockCacheOrder, key)
+		if n := len(voteBlockCacheOrder) - voteHistoryCacheBlocks; n > 0 {
+			for _, old := range voteBlockCacheOrder[:n] {
+				delete(voteBlockCache, old)
+			}
+			voteBlockCacheOrder = append([]string(nil), voteBlockCacheOrder[n:]...)
+		}
+	}
+	voteBlockCacheMu.Unlock()
+	return votes, nil
+}
+
+// parseBlockVote decodes one SSGen: the ticket it spends, its vote bits and
+// version, the agenda choices they select and any treasury spend votes.
+func parseBlockVote(params *chaincfg.Params, tx map[string]interface{}, height int64, blockHash string, blockTime int64) blockVote {
+	txid, _ := tx["txid"].(string)
+	bits, version := voteBitsAndVersion(tx)
+	v := blockVote{record: types.VoteRecord{
+		TxHash:         txid,
+		TicketHash:     inputPrevTxid(tx, 1),
+		BlockHeight:    height,
+		BlockHash:      blockHash,
+		Timestamp:      blockTime,
+		VoteBits:       bits,
+		VoteVersion:    version,
+		ApprovesParent: bits&0x01 != 0,
+		Agendas:        agendaVoteChoices(params, bits, version),
+	}}
+
+	vout, _ := tx["vout"].([]interface{})
+	for i, o := range vout {
+		out, _ := o.(map[string]interface{})
+		spk, _ := out["scriptPubKey"].(map[string]interface{})
+		switch outputScriptType(tx, i) {
+		case "stakegen":
+			addrs, _ := spk["addresses"].([]interface{})
+			for _, a := range addrs {
+				if s, ok := a.(string); ok {
+					v.rewardAddrs = append(v.rewardAddrs, s)
+				}
+			}
+		case "nulldata":
+			if i < 2 {
+				continue // block reference and vote bits
+			}
+			scriptHex, _ := spk["hex"].(string)
+			v.record.TSpends = append(v.record.TSpends, treasuryVoteChoices(scriptHex)...)
+		}
+	}
+	return v
+}
+
+// agendaVoteChoices decodes vote bits against the deployments defined for the
+// vote's version. Versions the params don't define yield none.
+func agendaVoteChoices(params *chaincfg.Params, bits uint16, version uint32) []types.AgendaVoteChoice {
+	var choices []types.AgendaVoteChoice
+	for _, d := range params.Deployments[version] {
+		for _, c := range d.Vote.Choices {
+			if bits&d.Vote.Mask == c.Bits {
+				choices = append(choices, types.AgendaVoteChoice{
+					AgendaID: d.Vote.Id,
+					Choice:   c.Id,
+				})
+				break
+			}
+		}
+	}
+	return choices
+}
+
+// treasuryVoteChoices decodes a vote's treasury vote output; see
+// decodeTreasuryVotes.
+func treasuryVoteChoices(scriptHex string) []types.TSpendVoteChoice {
+	script, err := hex.DecodeString(scriptHex)
+	if err != nil {
+		return nil
+	}
+	var choices []types.TSpendVoteChoice
+	for _, v := range decodeTreasuryVotes(script) {
+		choices = append(choices, types.TSpendVoteChoice{
+			TSpendHash: v.tspend.String(),
+			Choice:     v.choice.String(),
+		})
+	}
+	return choices
+}
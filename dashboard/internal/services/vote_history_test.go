@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestTreasuryVoteChoices(t *testing.T) {
+	yes := strings.Repeat("11", 32)
+	no := strings.Repeat("22", 32)
+	// OP_RETURN, push 68: "TV" + (hash, 0x01) + (hash, 0x02).
+	script := "6a44" + "5456" + yes + "01" + no + "02"
+	got := treasuryVoteChoices(script)
+	if len(got) != 2 {
+		t.Fatalf("got %d choices, want 2", len(got))
+	}
+	if got[0].TSpendHash != yes || got[0].Choice != "yes" {
+		t.Errorf("first choice = %+v, want %s yes", got[0], yes)
+	}
+	if got[1].TSpendHash != no || got[1].Choice != "no" {
+		t.Errorf("second choice = %+v, want %s no", got[1], no)
+	}
+
+	if got := treasuryVoteChoices("6a0401000000"); got != nil {
+		t.Errorf("vote bits output decoded as treasury votes: %+v", got)
+	}
+}
+
+func TestAgendaVoteChoices(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	var version uint32
+	var dep chaincfg.ConsensusDeployment
+	for v, deps := range params.Deployments {
+		if len(deps) > 0 {
+			version, dep = v, deps[0]
+			break
+		}
+	}
+	var yes chaincfg.Choice
+	for _, c := range dep.Vote.Choices {
+		if !c.IsAbstain && !c.IsNo {
+			yes = c
+		}
+	}
+
+	for _, c := range agendaVoteChoices(params, 0x01|yes.Bits, version) {
+		if c.AgendaID == dep.Vote.Id {
+			if c.Choice != yes.Id {
+				t.Fatalf("agenda %s choice = %s, want %s", c.AgendaID, c.Choice, yes.Id)
+			}
+			return
+		}
+	}
+	t.Fatalf("agenda %s missing from decoded choices", dep.Vote.Id)
+}
+
+func TestActiveTSpendsAt(t *testing.T) {
+	mined := []types.TSpendHistory{
+		{TxHash: "b", BlockHeight: 1000},
+		{TxHash: "a", BlockHeight: 1100},
+	}
+	mempool := []types.TSpend{{TxHash: "c", ExpiryHeight: 1300}, {TxHash: "a", ExpiryHeight: 1100}}
+	for _, c := range []struct {
+		height int64
+		want   string
+	}{
+		{899, ""},
+		{900, "b"},
+		{1000, "a,b"},
+		{1001, "a"},
+		{1200, "c"},
+		{1301, ""},
+	} {
+		got := strings.Join(activeTSpendsAt(c.height, 100, mined, mempool), ",")
+		if got != c.want {
+			t.Errorf("activeTSpendsAt(%d) = %q, want %q", c.height, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+)
+
+// ErrInvalidVotingPolicy is returned when a voting-policy update names an
+// agenda, choice, key, hash or policy the wallet can't take.
+var ErrInvalidVotingPolicy = errors.New("invalid voting policy")
+
+// treasuryKeyLen is the length of a treasury key: a compressed secp256k1
+// public key.
+const treasuryKeyLen = 33
+
+// GetVotingPolicy returns the wallet's agenda choices with the agendas dcrd
+// tracks, and its treasury key and TSpend policies.
+func GetVotingPolicy(ctx context.Context) (*types.VotingPolicy, error) {
+	agendas, err := ListAgendas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := ListTreasuryKeyPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tspends, err := ListTSpendPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &types.VotingPolicy{
+		Agendas:             agendas,
+		TreasuryKeyPolicies: keys,
+		TSpendPolicies:      tspends,
+	}, nil
+}
+
+// votingPolicyPlan is a validated voting-policy update in wallet gRPC form.
+type votingPolicyPlan struct {
+	choices []*pb.SetVoteChoicesRequest_Choice
+	keys    []*pb.SetTreasuryPolicyRequest
+	tspends []*pb.SetTSpendPolicyRequest
+}
+
+// SetVotingPolicy applies req under a single unlock, then pushes the result
+// to the wallet's VSPs. The whole request is validated first, so nothing is
+// changed when any part of it would fail with ErrInvalidVotingPolicy.
+func SetVotingPolicy(ctx context.Context, req types.SetVotingPolicyRequest, passphrase []byte) error {
+	var agendas []types.Agenda
+	if len(req.Agendas) > 0 {
+		var err error
+		if agendas, err = ListAgendas(ctx); err != nil {
+			return err
+		}
+	}
+	plan, err := planVotingPolicy(req, agendas)
+	if err != nil {
+		return err
+	}
+
+	if err := unlockForVote(ctx, passphrase); err != nil {
+		return err
+	}
+	defer lockAfterVote()
+
+	if len(plan.choices) > 0 {
+		if _, err := rpc.VotingClient.SetVoteChoices(ctx, &pb.SetVoteChoicesRequest{Choices: plan.choices}); err != nil {
+			return fmt.Errorf("SetVoteChoices: %w", err)
+		}
+	}
+	for _, k := range plan.keys {
+		if _, err := rpc.VotingClient.SetTreasuryPolicy(ctx, k); err != nil {
+			return fmt.Errorf("SetTreasuryPolicy: %w", err)
+		}
+	}
+	for _, t := range plan.tspends {
+		if _, err := rpc.VotingClient.SetTSpendPolicy(ctx, t); err != nil {
+			return fmt.Errorf("SetTSpendPolicy: %w", err)
+		}
+	}
+	syncVoteChoicesToVSP(ctx)
+	return nil
+}
+
+// planVotingPolicy checks req against the agendas dcrd tracks and the policy
+// values dcrwallet accepts, and converts it for the wallet gRPC calls.
+func planVotingPolicy(req types.SetVotingPolicyRequest, agendas []types.Agenda) (*votingPolicyPlan, error) {
+	if len(req.Agendas)+len(req.TreasuryKeyPolicies)+len(req.TSpendPolicies) == 0 {
+		return nil, fmt.Errorf("%w: nothing to update", ErrInvalidVotingPolicy)
+	}
+	known := make(map[string]types.Agenda, len(agendas))
+	for _, a := range agendas {
+		known[a.ID] = a
+	}
+
+	plan := &votingPolicyPlan{}
+	for _, c := range req.Agendas {
+		agenda, ok := known[c.AgendaID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown agenda %q", ErrInvalidVotingPolicy, c.AgendaID)
+		}
+		if !agendaHasChoice(agenda, c.ChoiceID) {
+			return nil, fmt.Errorf("%w: agenda %s has no choice %q", ErrInvalidVotingPolicy, c.AgendaID, c.ChoiceID)
+		}
+		plan.choices = append(plan.choices, &pb.SetVoteChoicesRequest_Choice{
+			AgendaId: c.AgendaID,
+			ChoiceId: c.ChoiceID,
+		})
+	}
+	for _, k := range req.TreasuryKeyPolicies {
+		key, err := hex.DecodeString(strings.TrimSpace(k.Key))
+		if err != nil || len(key) != treasuryKeyLen {
+			return nil, fmt.Errorf("%w: treasury key %q is not a compressed public key", ErrInvalidVotingPolicy, k.Key)
+		}
+		if err := validatePolicy(k.Policy); err != nil {
+			return nil, fmt.Errorf("%w: treasury key %s: %v", ErrInvalidVotingPolicy, k.Key, err)
+		}
+		plan.keys = append(plan.keys, &pb.SetTreasuryPolicyRequest{Key: key, Policy: k.Policy})
+	}
+	for _, t := range req.TSpendPolicies {
+		hash, err := chainhash.NewHashFromStr(strings.TrimSpace(t.Hash))
+		if err != nil || len(strings.TrimSpace(t.Hash)) != chainhash.MaxHashStringSize {
+			return nil, fmt.Errorf("%w: tspend hash %q is not 64 hex characters", ErrInvalidVotingPolicy, t.Hash)
+		}
+		if err := validatePolicy(t.Policy); err != nil {
+			return nil, fmt.Errorf("%w: tspend %s: %v", ErrInvalidVotingPolicy, t.Hash, err)
+		}
+		// chainhash stores hashes little-endian, the order dcrwallet expects.
+		plan.tspends = append(plan.tspends, &pb.SetTSpendPolicyRequest{Hash: hash[:], Policy: t.Policy})
+	}
+	return plan, nil
+}
+
+// agendaHasChoice reports whether choiceID is one of agenda's choices.
+func agendaHasChoice(agenda types.Agenda, choiceID string) bool {
+	for _, c := range agenda.Choices {
+		if c.ID == choiceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"dcrpulse/internal/types"
+)
+
+func TestPlanVotingPolicy(t *testing.T) {
+	agendas := []types.Agenda{{
+		ID: "blake3pow",
+		Choices: []types.AgendaChoice{
+			{ID: "abstain", IsAbstain: true},
+			{ID: "no", IsNo: true},
+			{ID: "yes"},
+		},
+	}}
+	key := "03" + strings.Repeat("f6", 32)
+	hash := "01" + strings.Repeat("00", 31)
+
+	plan, err := planVotingPolicy(types.SetVotingPolicyRequest{
+		Agendas:             []types.AgendaChoiceSetting{{AgendaID: "blake3pow", ChoiceID: "yes"}},
+		TreasuryKeyPolicies: []types.TreasuryKeyPolicy{{Key: key, Policy: "no"}},
+		TSpendPolicies:      []types.TSpendPolicy{{Hash: hash, Policy: "abstain"}},
+	}, agendas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.choices) != 1 || plan.choices[0].GetAgendaId() != "blake3pow" || plan.choices[0].GetChoiceId() != "yes" {
+		t.Errorf("choices = %v", plan.choices)
+	}
+	if len(plan.keys) != 1 || len(plan.keys[0].GetKey()) != 33 || plan.keys[0].GetPolicy() != "no" {
+		t.Errorf("keys = %v", plan.keys)
+	}
+	// The hash goes to dcrwallet little-endian: the display's first byte last.
+	wantHash := append(bytes.Repeat([]byte{0}, 31), 1)
+	if len(plan.tspends) != 1 || !bytes.Equal(plan.tspends[0].GetHash(), wantHash) {
+		t.Errorf("tspends = %v", plan.tspends)
+	}
+
+	bad := []struct {
+		name string
+		req  types.SetVotingPolicyRequest
+	}{
+		{"empty", types.SetVotingPolicyRequest{}},
+		{"unknown agenda", types.SetVotingPolicyRequest{
+			Agendas: []types.AgendaChoiceSetting{{AgendaID: "nosuch", ChoiceID: "yes"}},
+		}},
+		{"unknown choice", types.SetVotingPolicyRequest{
+			Agendas: []types.AgendaChoiceSetting{{AgendaID: "blake3pow", ChoiceID: "maybe"}},
+		}},
+		{"short key", types.SetVotingPolicyRequest{
+			TreasuryKeyPolicies: []types.TreasuryKeyPolicy{{Key: "03f6", Policy: "yes"}},
+		}},
+		{"key policy", types.SetVotingPolicyRequest{
+			TreasuryKeyPolicies: []types.TreasuryKeyPolicy{{Key: key, Policy: "maybe"}},
+		}},
+		{"short hash", types.SetVotingPolicyRequest{
+			TSpendPolicies: []types.TSpendPolicy{{Hash: "01", Policy: "yes"}},
+		}},
+		{"tspend policy", types.SetVotingPolicyRequest{
+			TSpendPolicies: []types.TSpendPolicy{{Hash: hash, Policy: ""}},
+		}},
+	}
+	for _, test := range bad {
+		if _, err := planVotingPolicy(test.req, agendas); !errors.Is(err, ErrInvalidVotingPolicy) {
+			t.Errorf("%s: err = %v, want ErrInvalidVotingPolicy", test.name, err)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"log"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -226,12 +227,33 @@ func Bip44IndexInUse(ctx context.Context, index uint32) (string, bool) {
 	return "", false
 }
 
+// DefaultMinConf is the confirmations an output needs before the balance
+// endpoints count it as spendable, dcrwallet's own default. With 0, mempool
+// outputs count too.
+const DefaultMinConf = 1
+
+// getBalanceParams are getbalance's parameters for every account ("*") at
+// minConf confirmations.
+func getBalanceParams(minConf int32) []json.RawMessage {
+	return []json.RawMessage{
+		json.RawMessage(`"*"`),
+		json.RawMessage(strconv.FormatInt(int64(minConf), 10)),
+	}
+}
+
 func FetchWalletDashboardData() (*types.WalletDashboardData, error) {
 	ctx := context.Background()
 	return FetchWalletDashboardDataWithContext(ctx)
 }
 
 func FetchWalletDashboardDataWithContext(ctx context.Context) (*types.WalletDashboardData, error) {
+	return FetchWalletDashboardDataWithMinConf(ctx, DefaultMinConf)
+}
+
+// FetchWalletDashboardDataWithMinConf is FetchWalletDashboardDataWithContext
+// with the balances counting only outputs with at least minConf
+// confirmations as spendable.
+func FetchWalletDashboardDataWithMinConf(ctx context.Context, minConf int32) (*types.WalletDashboardData, error) {
 	walletStatus, err := FetchWalletStatus()
 	if err != nil {
 		return nil, err
@@ -260,12 +282,12 @@ func FetchWalletDashboardDataWithContext(ctx context.Context) (*types.WalletDash
 	stakingChan := make(chan stakingResult, 1)
 
 	go func() {
-		info, err := FetchAccountInfoWithContext(ctx)
+		info, err := FetchAccountInfoWithMinConf(ctx, minConf)
 		accountChan <- accountResult{info, err}
 	}()
 
 	go func() {
-		accts, err := FetchAllAccounts(ctx)
+		accts, err := FetchAllAccountsWithMinConf(ctx, minConf)
 		accountsChan <- accountsResult{accts, err}
 	}()
 
@@ -322,8 +344,14 @@ func FetchAccountInfo() (*types.AccountInfo, error) {
 }
 
 func FetchAccountInfoWithContext(ctx context.Context) (*types.AccountInfo, error) {
-	// Get balance using getbalance (no arguments for all accounts)
-	result, err := rpc.WalletClient.RawRequest(ctx, "getbalance", []json.RawMessage{})
+	return FetchAccountInfoWithMinConf(ctx, DefaultMinConf)
+}
+
+// FetchAccountInfoWithMinConf returns the wallet-wide balances, counting only
+// outputs with at least minConf confirmations as spendable.
+func FetchAccountInfoWithMinConf(ctx context.Context, minConf int32) (*types.AccountInfo, error) {
+	// Get balance using getbalance across all accounts
+	result, err := rpc.WalletClient.RawRequest(ctx, "getbalance", getBalanceParams(minConf))
 	if err != nil {
 		log.Printf("Warning: Failed to get balance: %v", err)
 		return &types.AccountInfo{
@@ -408,8 +436,14 @@ func FetchAccountInfoWithContext(ctx context.Context) (*types.AccountInfo, error
 }
 
 func FetchAllAccounts(ctx context.Context) ([]types.AccountInfo, error) {
+	return FetchAllAccountsWithMinConf(ctx, DefaultMinConf)
+}
+
+// FetchAllAccountsWithMinConf returns every account with its balances,
+// counting only outputs with at least minConf confirmations as spendable.
+func FetchAllAccountsWithMinConf(ctx context.Context, minConf int32) ([]types.AccountInfo, error) {
 	// Get all accounts and their balances using getbalance RPC
-	result, err := rpc.WalletClient.RawRequest(ctx, "getbalance", []json.RawMessage{})
+	result, err := rpc.WalletClient.RawRequest(ctx, "getbalance", getBalanceParams(minConf))
 	if err != nil {
 		log.Printf("Warning: Failed to get accounts: %v", err)
 		return []types.AccountInfo{}, nil
@@ -492,7 +526,7 @@ func FetchAllAccounts(ctx context.Context) ([]types.AccountInfo, error) {
 // UnlockAccount. Returns the new account number.
 func CreateAccount(ctx context.Context, accountName string, passphrase []byte) (uint32, error) {
 	if rpc.WalletGrpcClient == nil {
-		return 0, fmt.Errorf("wallet gRPC unavailable")
+		return 0, rpc.NotConnected("wallet gRPC unavailable")
 	}
 	resp, err := rpc.WalletGrpcClient.NextAccount(ctx, &pb.NextAccountRequest{
 		Passphrase:  passphrase,
@@ -517,7 +551,7 @@ func CreateAccount(ctx context.Context, accountName string, passphrase []byte) (
 // accounts that are already per-account-encrypted — it's a no-op then.
 func ensureAccountEncrypted(ctx context.Context, accountNumber uint32, passphrase []byte) error {
 	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC unavailable")
+		return rpc.NotConnected("wallet gRPC unavailable")
 	}
 	acctsResp, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
 	if err != nil {
@@ -573,7 +607,7 @@ func ensureAllAccountsEncrypted(ctx context.Context, passphrase []byte) error {
 // needed (the default account on a fresh wallet isn't encrypted yet).
 func unlockAccountForSpend(ctx context.Context, accountNumber uint32, passphrase []byte) error {
 	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC client not initialized")
+		return rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	acctsResp, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
 	if err == nil {
@@ -735,7 +769,7 @@ func relockAccount(accountNumber uint32, onErr func(string)) {
 // material.
 func RenameAccount(ctx context.Context, accountNumber uint32, newName string) error {
 	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC unavailable")
+		return rpc.NotConnected("wallet gRPC unavailable")
 	}
 	_, err := rpc.WalletGrpcClient.RenameAccount(ctx, &pb.RenameAccountRequest{
 		AccountNumber: accountNumber,
@@ -748,7 +782,7 @@ func RenameAccount(ctx context.Context, accountNumber uint32, newName string) er
 // account. Used for watch-only export. No passphrase needed — it's a public key.
 func GetAccountExtendedPubKey(ctx context.Context, accountNumber uint32) (string, error) {
 	if rpc.WalletGrpcClient == nil {
-		return "", fmt.Errorf("wallet gRPC unavailable")
+		return "", rpc.NotConnected("wallet gRPC unavailable")
 	}
 	resp, err := rpc.WalletGrpcClient.GetAccountExtendedPubKey(ctx, &pb.GetAccountExtendedPubKeyRequest{
 		AccountNumber: accountNumber,
@@ -1119,7 +1153,7 @@ func ListTransactions(ctx context.Context, count, from int) (*types.TransactionL
 		if len(group.Entries) > 1 {
 			isMixed := false
 			if rpcTx.TxType == "regular" {
-				isMixed = isCoinJoinTransaction(ctx, rpcTx.TxID)
+				isMixed = isCoinJoinTransaction(ctx, rpcTx.TxID, rpcTx.BlockHash)
 			}
 
 			var netAmount float64
@@ -1245,7 +1279,7 @@ func ListTransactions(ctx context.Context, count, from int) (*types.TransactionL
 
 		isMixed := false
 		if rpcTx.TxType == "regular" {
-			isMixed = isCoinJoinTransaction(ctx, rpcTx.TxID)
+			isMixed = isCoinJoinTransaction(ctx, rpcTx.TxID, rpcTx.BlockHash)
 		}
 
 		txGroupKey := func(txid, category string) string {
@@ -1355,7 +1389,7 @@ func ListTransactions(ctx context.Context, count, from int) (*types.TransactionL
 	// read directly from the vote transaction instead.
 	for i := range transactions {
 		if transactions[i].TxType == "vote" {
-			if reward, ok := voteStakebaseReward(ctx, transactions[i].TxID); ok {
+			if reward, ok := voteStakebaseReward(ctx, transactions[i].TxID, transactions[i].BlockHash); ok {
 				transactions[i].Amount = reward
 			}
 		}
@@ -1408,7 +1442,7 @@ func ListTransactions(ctx context.Context, count, from int) (*types.TransactionL
 // getTransactionNetAmount returns wallet's net position (credits - debits) using gettransaction
 func getTransactionNetAmount(ctx context.Context, txHash string) (float64, error) {
 	if rpc.WalletClient == nil {
-		return 0, fmt.Errorf("wallet client not available")
+		return 0, rpc.NotConnected("wallet client not available")
 	}
 
 	result, err := rpc.WalletClient.RawRequest(ctx, "gettransaction", []json.RawMessage{
@@ -1433,16 +1467,14 @@ func getTransactionNetAmount(ctx context.Context, txHash string) (float64, error
 // voteStakebaseReward returns a vote (SSGen) transaction's stakebase input value,
 // which is the staking reward returned to the ticket. The first input of a vote
 // is the stakebase; its amountin is the reward, read directly from dcrd. Returns
-// false when dcrd is unavailable or the tx is not a vote.
-func voteStakebaseReward(ctx context.Context, txHash string) (float64, bool) {
+// false when dcrd is unavailable or the tx is not a vote. blockHash, when
+// known, lets the lookup work on a dcrd without --txindex.
+func voteStakebaseReward(ctx context.Context, txHash, blockHash string) (float64, bool) {
 	if rpc.DcrdClient == nil {
 		return 0, false
 	}
 
-	rawTxResult, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
-		json.RawMessage(fmt.Sprintf(`"%s"`, txHash)),
-		json.RawMessage("1"),
-	})
+	rawTxResult, err := fetchRawTransactionInBlock(ctx, txHash, blockHash)
 	if err != nil {
 		log.Printf("Vote reward lookup failed for %s: getrawtransaction error: %v", txHash, err)
 		return 0, false
@@ -1468,16 +1500,13 @@ func voteStakebaseReward(ctx context.Context, txHash string) (float64, bool) {
 }
 
 // isCoinJoinTransaction detects CoinJoin by analyzing tx structure (3+ inputs/outputs, matching amounts)
-func isCoinJoinTransaction(ctx context.Context, txHash string) bool {
+func isCoinJoinTransaction(ctx context.Context, txHash, blockHash string) bool {
 	if rpc.DcrdClient == nil {
 		log.Printf("CoinJoin check skipped for %s: no dcrd connection", txHash)
 		return false
 	}
 
-	rawTxResult, err := rpc.DcrdClient.RawRequest(ctx, "getrawtransaction", []json.RawMessage{
-		json.RawMessage(fmt.Sprintf(`"%s"`, txHash)),
-		json.RawMessage("1"),
-	})
+	rawTxResult, err := fetchRawTransactionInBlock(ctx, txHash, blockHash)
 	if err != nil {
 		log.Printf("CoinJoin check failed for %s: getrawtransaction error: %v", txHash, err)
 		return false
@@ -1563,7 +1592,7 @@ func isVSPFeeTransaction(tx types.Transaction, allTransactions []types.Transacti
 
 func GetNextAddress(ctx context.Context, account uint32) (string, error) {
 	if rpc.WalletGrpcClient == nil {
-		return "", fmt.Errorf("wallet gRPC client not initialized")
+		return "", rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	resp, err := rpc.WalletGrpcClient.NextAddress(ctx, &pb.NextAddressRequest{
 		Account:   account,
@@ -1578,14 +1607,14 @@ func GetNextAddress(ctx context.Context, account uint32) (string, error) {
 
 func ValidateAddress(ctx context.Context, address string) (*pb.ValidateAddressResponse, error) {
 	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	return rpc.WalletGrpcClient.ValidateAddress(ctx, &pb.ValidateAddressRequest{Address: address})
 }
 
 func ConstructTransaction(ctx context.Context, sourceAccount uint32, outputs []types.TxRecipient, sendAll bool) (*pb.ConstructTransactionResponse, error) {
 	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	if len(outputs) == 0 {
 		return nil, fmt.Errorf("at least one output is required")
@@ -1625,7 +1654,7 @@ func ConstructTransaction(ctx context.Context, sourceAccount uint32, outputs []t
 
 func DecodeRawTransaction(ctx context.Context, txBytes []byte) (*pb.DecodedTransaction, error) {
 	if rpc.DecodeMessageClient == nil {
-		return nil, fmt.Errorf("decode message gRPC client not initialized")
+		return nil, rpc.NotConnected("decode message gRPC client not initialized")
 	}
 	resp, err := rpc.DecodeMessageClient.DecodeRawTransaction(ctx, &pb.DecodeRawTransactionRequest{SerializedTransaction: txBytes})
 	if err != nil {
@@ -1641,9 +1670,6 @@ func DecodeRawTransaction(ctx context.Context, txBytes []byte) (*pb.DecodedTrans
 var ErrSpendWhileMixing = fmt.Errorf("stop the privacy mixer or ticket autobuyer before sending a transaction")
 
 func SignAndPublishTransaction(ctx context.Context, sourceAccount uint32, unsignedTxBytes []byte, passphrase []byte) (string, error) {
-	if rpc.WalletGrpcClient == nil {
-		return "", fmt.Errorf("wallet gRPC client not initialized")
-	}
 	if IsMixerRunning() || IsAutobuyerRunning() {
 		return "", ErrSpendWhileMixing
 	}
@@ -1692,10 +1718,6 @@ func SignAndPublishTransaction(ctx context.Context, sourceAccount uint32, unsign
 // per-account-encrypted already (set at creation by CreateAccount).
 // The caller is expected to zero both byte slices after this returns.
 func ChangePrivatePassphrase(ctx context.Context, oldPass, newPass []byte) error {
-	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC client not initialized")
-	}
-
 	if _, err := rpc.WalletGrpcClient.ChangePassphrase(ctx, &pb.ChangePassphraseRequest{
 		Key:           pb.ChangePassphraseRequest_PRIVATE,
 		OldPassphrase: oldPass,
@@ -1737,7 +1759,7 @@ func ChangePrivatePassphrase(ctx context.Context, oldPass, newPass []byte) error
 // during a restore, before accounts are per-account-encrypted (runDiscoveryRpcSync).
 func DiscoverUsage(ctx context.Context, passphrase []byte, gapLimit uint32) error {
 	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC client not initialized")
+		return rpc.NotConnected("wallet gRPC client not initialized")
 	}
 
 	unlockCtx, unlockCancel := context.WithTimeout(ctx, 10*time.Second)
@@ -143,7 +143,7 @@ func XpubAlreadyImported(ctx context.Context, dpub string) (string, bool, error)
 	}
 	cand := candidate.SerializedPubKey()
 	if rpc.WalletGrpcClient == nil {
-		return "", false, fmt.Errorf("wallet gRPC client not initialized")
+		return "", false, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	accts, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
 	if err != nil {
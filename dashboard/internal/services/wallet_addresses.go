@@ -0,0 +1,208 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+)
+
+// Page sizes for ListWalletAddresses.
+const (
+	DefaultAddressPageLimit = 100
+	MaxAddressPageLimit     = 1000
+)
+
+// ErrAccountNotFound is returned for an account number or name the wallet
+// does not have.
+var ErrAccountNotFound = errors.New("account not found")
+
+// walletImportedAccount is dcrwallet's bucket for imported private keys and
+// scripts. It has no extended key, so its addresses can't be enumerated.
+const walletImportedAccount = uint32(1)<<31 - 1
+
+// derivedAddrCache keeps the addresses already derived for an account branch,
+// keyed by xpub and branch, so later pages and polls only derive new indexes.
+var (
+	derivedAddrMu    sync.Mutex
+	derivedAddrCache = map[string][]string{}
+)
+
+// ListWalletAddresses returns one page of the addresses the wallet has derived
+// for its accounts, in account, branch and index order, and how many match in
+// all. The key counts the wallet reports through gRPC Accounts bound each
+// branch; addresses are derived from the account xpub. Used and Received come
+// from the wallet's credits, unconfirmed included. account, when non-empty, is
+// an account number or name; used, when non-nil, keeps only used or unused
+// addresses.
+func ListWalletAddresses(ctx context.Context, account string, used *bool, offset, limit int) ([]types.WalletAddress, int, error) {
+	if rpc.WalletGrpcClient == nil || rpc.WalletClient == nil {
+		return nil, 0, fmt.Errorf("wallet not loaded")
+	}
+	resp, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	accounts := resp.Accounts
+	if account != "" {
+		accounts = filterAccount(accounts, account)
+		if len(accounts) == 0 {
+			return nil, 0, fmt.Errorf("%s: %w", account, ErrAccountNotFound)
+		}
+	}
+
+	received, err := receivedByAddress(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var all []types.WalletAddress
+	for _, a := range accounts {
+		if a.AccountNumber == walletImportedAccount {
+			continue
+		}
+		xpub, err := GetAccountExtendedPubKey(ctx, a.AccountNumber)
+		if err != nil {
+			return nil, 0, fmt.Errorf("account %d xpub: %w", a.AccountNumber, err)
+		}
+		counts := [2]uint32{a.ExternalKeyCount, a.InternalKeyCount}
+		for branch, count := range counts {
+			addrs, err := deriveBranchAddresses(xpub, uint32(branch), count, params)
+			if err != nil {
+				return nil, 0, fmt.Errorf("account %d: %w", a.AccountNumber, err)
+			}
+			for i, addr := range addrs {
+				if addr == "" {
+					continue
+				}
+				r := received[addr]
+				wa := types.WalletAddress{
+					Address:     addr,
+					Account:     a.AccountNumber,
+					AccountName: a.AccountName,
+					Branch:      uint32(branch),
+					Index:       uint32(i),
+					Used:        r.TxCount > 0,
+					Received:    r.Amount,
+					TxCount:     r.TxCount,
+				}
+				if used != nil && wa.Used != *used {
+					continue
+				}
+				all = append(all, wa)
+			}
+		}
+	}
+
+	total := len(all)
+	if offset >= total {
+		return []types.WalletAddress{}, total, nil
+	}
+	return all[offset:min(offset+limit, total)], total, nil
+}
+
+// filterAccount returns the account whose number or name is account.
+func filterAccount(accounts []*pb.AccountsResponse_Account, account string) []*pb.AccountsResponse_Account {
+	n, nerr := strconv.ParseUint(account, 10, 32)
+	for _, a := range accounts {
+		if (nerr == nil && a.AccountNumber == uint32(n)) || a.AccountName == account {
+			return []*pb.AccountsResponse_Account{a}
+		}
+	}
+	return nil
+}
+
+type addressReceipts struct {
+	Amount  float64
+	TxCount int
+}
+
+// receivedByAddress totals the wallet's credits per address with
+// listreceivedbyaddress at zero confirmations.
+func receivedByAddress(ctx context.Context) (map[string]addressReceipts, error) {
+	raw, err := rpc.WalletClient.RawRequest(ctx, "listreceivedbyaddress", []json.RawMessage{
+		json.RawMessage("0"), // minconf
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list received amounts: %w", err)
+	}
+	var entries []struct {
+		Address string   `json:"address"`
+		Amount  float64  `json:"amount"`
+		TxIDs   []string `json:"txids"`
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal received amounts: %w", err)
+	}
+	out := make(map[string]addressReceipts, len(entries))
+	for _, e := range entries {
+		out[e.Address] = addressReceipts{Amount: e.Amount, TxCount: len(e.TxIDs)}
+	}
+	return out, nil
+}
+
+// deriveBranchAddresses returns the P2PKH addresses of indexes [0, count) on
+// branch (0 external, 1 internal) of the account xpub.
+func deriveBranchAddresses(xpub string, branch, count uint32, params *chaincfg.Params) ([]string, error) {
+	key := xpub + "/" + strconv.FormatUint(uint64(branch), 10)
+	derivedAddrMu.Lock()
+	cached := derivedAddrCache[key]
+	derivedAddrMu.Unlock()
+	if uint32(len(cached)) >= count {
+		return cached[:count], nil
+	}
+
+	acct, err := hdkeychain.NewKeyFromString(xpub, params)
+	if err != nil {
+		return nil, fmt.Errorf("parse account xpub: %w", err)
+	}
+	branchKey, err := acct.Child(branch)
+	if err != nil {
+		return nil, err
+	}
+	addrs := append(make([]string, 0, count), cached...)
+	for i := uint32(len(cached)); i < count; i++ {
+		child, err := branchKey.Child(i)
+		if errors.Is(err, hdkeychain.ErrInvalidChild) {
+			// The wallet skips an index that derives an invalid key too;
+			// keep the slot so positions still equal indexes.
+			addrs = append(addrs, "")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+			dcrutil.Hash160(child.SerializedPubKey()), params)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr.String())
+	}
+
+	derivedAddrMu.Lock()
+	if len(addrs) > len(derivedAddrCache[key]) {
+		derivedAddrCache[key] = addrs
+	}
+	derivedAddrMu.Unlock()
+	return addrs, nil
+}
@@ -91,7 +91,7 @@ func deviceBalanceRate(ctx context.Context) float64 {
 // in atoms. It uses no private keys and works for watch-only wallets.
 func BuildDeviceBalance(ctx context.Context) (*types.DeviceBalanceExport, error) {
 	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	acctsResp, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{})
 	if err != nil {
@@ -15,7 +15,6 @@ import (
 
 	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
 	"github.com/decred/dcrd/chaincfg/chainhash"
-	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
 	"github.com/decred/dcrd/wire"
 
@@ -38,9 +37,6 @@ var ExportTypes = map[string]bool{
 // final line), string cells double-quoted, atom amounts rendered as DCR with 8
 // decimals, timestamps in UTC ISO8601 (...Z), and nil cells left empty.
 func ExportWalletCSV(ctx context.Context, w io.Writer, typ string) error {
-	if rpc.WalletGrpcClient == nil {
-		return fmt.Errorf("wallet gRPC client not initialized")
-	}
 	cw := &csvWriter{w: w}
 	var err error
 	switch typ {
@@ -184,29 +180,46 @@ func newExportTx(t *pb.TransactionDetails, height int32, blockTime int64) export
 // fetchMinedExportTxs streams the wallet's full mined transaction history in
 // ascending block order.
 func fetchMinedExportTxs(ctx context.Context) ([]exportTx, error) {
+	var out []exportTx
+	err := forEachMinedExportTx(ctx, 0, func(tx exportTx, _ int) bool {
+		out = append(out, tx)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// forEachMinedExportTx streams the wallet's mined transactions from block
+// startHeight on, in ascending block order, passing each with its index among
+// the block's wallet transactions. It stops early when fn returns false.
+func forEachMinedExportTx(ctx context.Context, startHeight int32, fn func(tx exportTx, index int) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	stream, err := rpc.WalletGrpcClient.GetTransactions(ctx, &pb.GetTransactionsRequest{
-		StartingBlockHeight: 0,
+		StartingBlockHeight: startHeight,
 		EndingBlockHeight:   -1,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var out []exportTx
 	for {
 		resp, rerr := stream.Recv()
 		if rerr == io.EOF {
-			break
+			return nil
 		}
 		if rerr != nil {
-			return nil, rerr
+			return rerr
 		}
 		if mb := resp.GetMinedTransactions(); mb != nil {
-			for _, t := range mb.GetTransactions() {
-				out = append(out, newExportTx(t, mb.GetHeight(), mb.GetTimestamp()))
+			for i, t := range mb.GetTransactions() {
+				if !fn(newExportTx(t, mb.GetHeight(), mb.GetTimestamp()), i) {
+					return nil
+				}
 			}
 		}
 	}
-	return out, nil
 }
 
 // exportTransactions writes the Transactions CSV:
@@ -216,27 +229,35 @@ func exportTransactions(ctx context.Context, cw *csvWriter) error {
 	if err != nil {
 		return err
 	}
+	writeTransactionsHeader(cw)
+	for _, tx := range txs {
+		writeTransactionRow(cw, tx)
+	}
+	return nil
+}
+
+func writeTransactionsHeader(cw *csvWriter) {
 	cw.writeLine(
 		csvQuote("time"), csvQuote("hash"), csvQuote("type"), csvQuote("direction"),
 		csvQuote("fee"), csvQuote("amount"), csvQuote("credits"), csvQuote("debits"),
 	)
-	for _, tx := range txs {
-		dir := ""
-		if tx.direction != "" {
-			dir = csvQuote(tx.direction)
-		}
-		cw.writeLine(
-			csvQuote(csvTimeUTC(tx.timestamp)),
-			csvQuote(tx.hash),
-			csvQuote(tx.txType),
-			dir,
-			csvAmount(tx.fee),
-			csvAmount(tx.amount),
-			csvAmount(tx.creditsSum),
-			csvAmount(tx.debitsSum),
-		)
+}
+
+func writeTransactionRow(cw *csvWriter, tx exportTx) {
+	dir := ""
+	if tx.direction != "" {
+		dir = csvQuote(tx.direction)
 	}
-	return nil
+	cw.writeLine(
+		csvQuote(csvTimeUTC(tx.timestamp)),
+		csvQuote(tx.hash),
+		csvQuote(tx.txType),
+		dir,
+		csvAmount(tx.fee),
+		csvAmount(tx.amount),
+		csvAmount(tx.creditsSum),
+		csvAmount(tx.debitsSum),
+	)
 }
 
 // exportTicket is a normalized ticket for the Tickets/Vote Time exports,
@@ -404,21 +425,10 @@ type exportChainParams struct {
 }
 
 func loadExportChainParams(ctx context.Context) (exportChainParams, error) {
-	network, err := CurrentNetwork(ctx)
+	p, err := CurrentChainParams(ctx)
 	if err != nil {
 		return exportChainParams{}, err
 	}
-	var p *chaincfg.Params
-	switch network {
-	case "mainnet":
-		p = chaincfg.MainNetParams()
-	case "testnet":
-		p = chaincfg.TestNet3Params()
-	case "simnet":
-		p = chaincfg.SimNetParams()
-	default:
-		return exportChainParams{}, fmt.Errorf("unknown network %q", network)
-	}
 	return exportChainParams{
 		ticketMaturity:     int32(p.TicketMaturity),
 		coinbaseMaturity:   int32(p.CoinbaseMaturity),
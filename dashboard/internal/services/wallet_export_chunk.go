@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"dcrpulse/internal/rpc"
+)
+
+// Chunk sizes, in transactions, of a chunked transactions export.
+const (
+	DefaultExportChunk = 5000
+	MaxExportChunk     = 50000
+)
+
+// ErrInvalidExportCursor is returned for a cursor ExportCursor.String didn't
+// produce.
+var ErrInvalidExportCursor = errors.New("invalid export cursor")
+
+// ExportCursor is where a chunked transactions export stopped: the last
+// transaction written, by block height and its index among the wallet's
+// transactions in that block.
+type ExportCursor struct {
+	Height int32
+	Index  int
+}
+
+// String encodes the cursor as "height:index".
+func (c ExportCursor) String() string {
+	return fmt.Sprintf("%d:%d", c.Height, c.Index)
+}
+
+// ParseExportCursor decodes a cursor from ExportCursor.String.
+func ParseExportCursor(s string) (ExportCursor, error) {
+	h, i, ok := strings.Cut(s, ":")
+	if !ok {
+		return ExportCursor{}, ErrInvalidExportCursor
+	}
+	height, herr := strconv.ParseInt(h, 10, 32)
+	index, ierr := strconv.Atoi(i)
+	if herr != nil || ierr != nil || height < 0 || index < 0 {
+		return ExportCursor{}, ErrInvalidExportCursor
+	}
+	return ExportCursor{Height: int32(height), Index: index}, nil
+}
+
+// ExportTransactionsChunk writes the Transactions CSV rows of up to limit
+// mined transactions after the one at after, or from the start of the
+// history when after is nil. Only the first chunk carries the header row, so
+// the chunks concatenate into the full export. It returns the cursor to pass
+// for the next chunk, nil once the history is done.
+func ExportTransactionsChunk(ctx context.Context, w io.Writer, after *ExportCursor, limit int) (*ExportCursor, error) {
+	if rpc.WalletGrpcClient == nil {
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
+	}
+	cw := &csvWriter{w: w}
+	chunk := exportChunk{after: after, limit: limit}
+	var start int32
+	if after == nil {
+		writeTransactionsHeader(cw)
+	} else {
+		start = after.Height
+	}
+	err := forEachMinedExportTx(ctx, start, func(tx exportTx, index int) bool {
+		write, more := chunk.take(tx.height, index)
+		if write {
+			writeTransactionRow(cw, tx)
+		}
+		return more && cw.err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cw.err != nil {
+		return nil, cw.err
+	}
+	return chunk.next, nil
+}
+
+// exportChunk picks one chunk's transactions out of the mined history as it
+// streams past in order.
+type exportChunk struct {
+	after   *ExportCursor
+	limit   int
+	written int
+	last    ExportCursor
+	next    *ExportCursor // Set once a transaction past a full chunk is seen
+}
+
+// take reports whether the transaction at height and index belongs in the
+// chunk, and whether to keep reading: the chunk ends at the first
+// transaction past limit, which proves there is a next chunk.
+func (c *exportChunk) take(height int32, index int) (write, more bool) {
+	if a := c.after; a != nil && (height < a.Height || height == a.Height && index <= a.Index) {
+		return false, true
+	}
+	if c.written == c.limit {
+		next := c.last
+		c.next = &next
+		return false, false
+	}
+	c.written++
+	c.last = ExportCursor{Height: height, Index: index}
+	return true, true
+}
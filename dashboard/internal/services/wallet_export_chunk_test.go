@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseExportCursor(t *testing.T) {
+	c := ExportCursor{Height: 812345, Index: 3}
+	got, err := ParseExportCursor(c.String())
+	if err != nil || got != c {
+		t.Fatalf("round trip of %v: got %v, %v", c, got, err)
+	}
+	for _, s := range []string{"", "812345", "a:1", "1:b", "-1:0", "1:-1", "99999999999:0"} {
+		if _, err := ParseExportCursor(s); !errors.Is(err, ErrInvalidExportCursor) {
+			t.Errorf("%q: err = %v, want ErrInvalidExportCursor", s, err)
+		}
+	}
+}
+
+func TestExportChunk(t *testing.T) {
+	// The mined history as streamed: three wallet transactions at 10, two
+	// at 12, one at 15.
+	history := []ExportCursor{{10, 0}, {10, 1}, {10, 2}, {12, 0}, {12, 1}, {15, 0}}
+
+	// run streams the history from after's block, as the export does, and
+	// returns the transactions written and the next cursor.
+	run := func(after *ExportCursor, limit int) ([]ExportCursor, *ExportCursor) {
+		c := exportChunk{after: after, limit: limit}
+		var written []ExportCursor
+		for _, tx := range history {
+			if after != nil && tx.Height < after.Height {
+				continue
+			}
+			write, more := c.take(tx.Height, tx.Index)
+			if write {
+				written = append(written, tx)
+			}
+			if !more {
+				break
+			}
+		}
+		return written, c.next
+	}
+
+	// Chunks of two walk the history without gaps or repeats.
+	var all []ExportCursor
+	var after *ExportCursor
+	for chunks := 0; ; chunks++ {
+		if chunks > len(history) {
+			t.Fatal("chunks never end")
+		}
+		written, next := run(after, 2)
+		all = append(all, written...)
+		if next == nil {
+			break
+		}
+		after = next
+	}
+	if !reflect.DeepEqual(all, history) {
+		t.Errorf("chunked history = %v, want %v", all, history)
+	}
+
+	// A chunk that ends exactly at the end of the history has no next.
+	if _, next := run(&ExportCursor{12, 0}, 2); next != nil {
+		t.Errorf("last chunk: next = %v, want nil", *next)
+	}
+}
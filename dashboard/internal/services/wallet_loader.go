@@ -7,6 +7,7 @@ package services
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -19,8 +20,24 @@ import (
 	"dcrpulse/internal/types"
 
 	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// ErrWalletAlreadyOpen is returned by OpenWallet when dcrwallet already has
+// the wallet loaded. The wallet is usable, so callers treat it as success.
+var ErrWalletAlreadyOpen = errors.New("wallet is already open")
+
+// ErrWalletNotOpen is returned by CloseWallet when dcrwallet has no wallet
+// loaded. There is nothing to close, so callers treat it as success.
+var ErrWalletNotOpen = errors.New("no wallet is open")
+
+// ErrRpcSyncAlreadyRunning is returned by EnsureRpcSync when dcrwallet
+// rejects the stream because another RpcSync already owns its single sync
+// slot. The wallet is still syncing, so this is not a disconnect.
+var ErrRpcSyncAlreadyRunning = errors.New("RPC sync already running in dcrwallet")
+
 // restoreDiscoveryActive guards dcrwallet's single RpcSync slot during a
 // restore. dcrwallet permits only one syncer at a time. When a wallet is
 // restored from seed, runDiscoveryRpcSync must own that slot so it can run
@@ -48,7 +65,7 @@ func RestoreDiscoveryActive() bool { return restoreDiscoveryActive.Load() }
 // CheckWalletExists checks if a wallet database exists
 func CheckWalletExists(ctx context.Context) (*types.WalletExistsResponse, error) {
 	if rpc.WalletLoaderClient == nil {
-		return nil, fmt.Errorf("wallet loader client not initialized")
+		return nil, rpc.NotConnected("wallet loader client not initialized")
 	}
 
 	req := &pb.WalletExistsRequest{}
@@ -62,12 +79,51 @@ func CheckWalletExists(ctx context.Context) (*types.WalletExistsResponse, error)
 	}, nil
 }
 
+// ErrInvalidSeedLength is returned by GenerateSeed for a seed length outside
+// ValidSeedLengths.
+var ErrInvalidSeedLength = errors.New("invalid seed length")
+
+// ValidSeedLengths lists the seed lengths, in bytes, GenerateSeed accepts:
+// 16 to 64 bytes (128 to 512 bits, the BIP32 bounds) in 32-bit steps. The
+// mnemonic has one PGP word per byte plus a checksum word, so the word counts
+// are 17, 21, ... 65; the 32-byte default gives the standard 33 words.
+func ValidSeedLengths() []uint32 {
+	var lengths []uint32
+	for n := uint32(hdkeychain.MinSeedBytes); n <= hdkeychain.MaxSeedBytes; n += 4 {
+		lengths = append(lengths, n)
+	}
+	return lengths
+}
+
+// normalizeSeedLength maps zero to the recommended 32 bytes and rejects any
+// length not in ValidSeedLengths.
+func normalizeSeedLength(seedLength uint32) (uint32, error) {
+	if seedLength == 0 {
+		return hdkeychain.RecommendedSeedLen, nil
+	}
+	for _, n := range ValidSeedLengths() {
+		if n == seedLength {
+			return seedLength, nil
+		}
+	}
+	valid := make([]string, 0, len(ValidSeedLengths()))
+	for _, n := range ValidSeedLengths() {
+		valid = append(valid, fmt.Sprintf("%d (%d words)", n, n+1))
+	}
+	return 0, fmt.Errorf("%w: %d bytes; valid lengths in bytes are %s",
+		ErrInvalidSeedLength, seedLength, strings.Join(valid, ", "))
+}
+
 // GenerateSeed generates a new cryptographically secure seed.
-// seedLength is in BYTES. Zero passes through to dcrwallet, which uses its
-// RecommendedSeedLen (32 bytes -> 33-word mnemonic).
+// seedLength is in BYTES and must be one of ValidSeedLengths; zero means the
+// recommended 32 bytes (33-word mnemonic).
 func GenerateSeed(ctx context.Context, seedLength uint32) (*types.GenerateSeedResponse, error) {
+	seedLength, err := normalizeSeedLength(seedLength)
+	if err != nil {
+		return nil, err
+	}
 	if rpc.SeedServiceClient == nil {
-		return nil, fmt.Errorf("seed service client not initialized")
+		return nil, rpc.NotConnected("seed service client not initialized")
 	}
 
 	req := &pb.GenerateRandomSeedRequest{
@@ -79,6 +135,7 @@ func GenerateSeed(ctx context.Context, seedLength uint32) (*types.GenerateSeedRe
 		return nil, fmt.Errorf("failed to generate seed: %w", err)
 	}
 
+	setPendingSeed(resp.SeedMnemonic, resp.SeedHex)
 	return &types.GenerateSeedResponse{
 		SeedMnemonic: resp.SeedMnemonic,
 		SeedHex:      resp.SeedHex,
@@ -90,7 +147,7 @@ func GenerateSeed(ctx context.Context, seedLength uint32) (*types.GenerateSeedRe
 // accepts both via a single field.
 func DecodeSeed(ctx context.Context, userInput string) (string, error) {
 	if rpc.SeedServiceClient == nil {
-		return "", fmt.Errorf("seed service client not initialized")
+		return "", rpc.NotConnected("seed service client not initialized")
 	}
 	resp, err := rpc.SeedServiceClient.DecodeSeed(ctx, &pb.DecodeSeedRequest{UserInput: userInput})
 	if err != nil {
@@ -99,20 +156,56 @@ func DecodeSeed(ctx context.Context, userInput string) (string, error) {
 	return hex.EncodeToString(resp.DecodedSeed), nil
 }
 
+// ErrInvalidMnemonic is returned by RestoreWalletFromMnemonic for a phrase
+// that is not a valid seed mnemonic (unknown word or bad checksum).
+var ErrInvalidMnemonic = errors.New("invalid seed mnemonic")
+
 // CreateNewWallet creates a new wallet with the provided passphrases and seed.
 // When discoverAccounts is true (restoring from an existing seed), the
 // post-create RpcSync runs with DiscoverAccounts enabled and the private
 // passphrase so dcrwallet rescans the chain and rebuilds the address index.
+//
+// Discovery is also forced on for any seed that is not the one GenerateSeed
+// just handed out: only a freshly generated seed is known to have no history,
+// and skipping discovery for a reused seed leaves its funds invisible until a
+// manual rescan.
 func CreateNewWallet(ctx context.Context, publicPass, privatePass, seedHex string, discoverAccounts bool) error {
-	if rpc.WalletLoaderClient == nil {
-		return fmt.Errorf("wallet loader client not initialized")
-	}
-
 	// Decode seed hex to bytes
 	seedBytes, err := hex.DecodeString(seedHex)
 	if err != nil {
 		return fmt.Errorf("invalid seed hex: %w", err)
 	}
+	if !discoverAccounts && !isPendingSeed(seedBytes) {
+		log.Println("Seed was not generated by this session; enabling account discovery")
+		discoverAccounts = true
+	}
+	return createWallet(ctx, publicPass, privatePass, seedBytes, discoverAccounts, nil)
+}
+
+// RestoreWalletFromMnemonic restores a wallet from its seed words. The words
+// are decoded (and checksum-verified) by dcrwallet's seed service, then the
+// wallet is created with account discovery on. birthHeight, when set, is
+// recorded as the wallet's birthday so the discovery sync rescans from there
+// instead of from genesis.
+func RestoreWalletFromMnemonic(ctx context.Context, publicPass, privatePass, mnemonic string, birthHeight *uint32) error {
+	if rpc.SeedServiceClient == nil {
+		return rpc.NotConnected("seed service client not initialized")
+	}
+	words := strings.Fields(mnemonic)
+	if len(words) < 2 {
+		return fmt.Errorf("%w: expected the seed's words separated by spaces", ErrInvalidMnemonic)
+	}
+	resp, err := rpc.SeedServiceClient.DecodeSeed(ctx, &pb.DecodeSeedRequest{UserInput: strings.Join(words, " ")})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMnemonic, err)
+	}
+	return createWallet(ctx, publicPass, privatePass, resp.DecodedSeed, true, birthHeight)
+}
+
+func createWallet(ctx context.Context, publicPass, privatePass string, seedBytes []byte, discoverAccounts bool, birthHeight *uint32) error {
+	if rpc.WalletLoaderClient == nil {
+		return rpc.NotConnected("wallet loader client not initialized")
+	}
 
 	log.Printf("Creating wallet with seed length: %d bytes", len(seedBytes))
 
@@ -135,11 +228,21 @@ func CreateNewWallet(ctx context.Context, publicPass, privatePass, seedHex strin
 		PrivatePassphrase: []byte(privatePass),
 		Seed:              seedBytes,
 	}
+	if birthHeight != nil {
+		req.SetBirthHeight = true
+		req.BirthHeight = *birthHeight
+		log.Printf("Wallet birthday set to block %d", *birthHeight)
+	}
 
-	_, err = rpc.WalletLoaderClient.CreateWallet(ctx, req)
+	_, err := rpc.WalletLoaderClient.CreateWallet(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to create wallet: %w", err)
 	}
+	// The creation flow is over; the generated seed is no longer verifiable.
+	clearPendingSeed()
+	if birthHeight != nil {
+		recordBirthHeight(ctx, *birthHeight)
+	}
 
 	// Per-account passphrases are set differently for a fresh wallet vs a restore:
 	//
@@ -165,7 +268,7 @@ func CreateNewWallet(ctx context.Context, publicPass, privatePass, seedHex strin
 	// once this stream ends.
 	if discoverAccounts {
 		discoveryLaunched = true
-		go runDiscoveryRpcSync(privatePass)
+		go runDiscoveryRpcSync([]byte(privatePass))
 	}
 
 	return nil
@@ -177,7 +280,7 @@ func CreateNewWallet(ctx context.Context, publicPass, privatePass, seedHex strin
 // for it. The supervisor's normal RpcSync discovers used addresses under the key.
 func CreateWatchOnlyWallet(ctx context.Context, publicPass, xpub string) error {
 	if rpc.WalletLoaderClient == nil {
-		return fmt.Errorf("wallet loader client not initialized")
+		return rpc.NotConnected("wallet loader client not initialized")
 	}
 	if !strings.HasPrefix(xpub, "dpub") && !strings.HasPrefix(xpub, "tpub") {
 		return fmt.Errorf("invalid extended public key: must start with dpub (mainnet) or tpub (testnet)")
@@ -195,7 +298,10 @@ func CreateWatchOnlyWallet(ctx context.Context, publicPass, xpub string) error {
 	return nil
 }
 
-func runDiscoveryRpcSync(privatePass string) {
+// runDiscoveryRpcSync owns privatePass and wipes it when discovery is done,
+// so the passphrase does not linger in memory for the daemon's lifetime.
+func runDiscoveryRpcSync(privatePass []byte) {
+	defer clear(privatePass)
 	// Release the RpcSync slot for the supervisor once this discovery stream ends.
 	defer EndRestoreDiscovery()
 	if rpc.WalletLoaderClient == nil {
@@ -217,7 +323,7 @@ func runDiscoveryRpcSync(privatePass string) {
 		Password:          []byte(rpc.DcrdConfig.RPCPassword),
 		Certificate:       cert,
 		DiscoverAccounts:  true,
-		PrivatePassphrase: []byte(privatePass),
+		PrivatePassphrase: privatePass,
 	}
 	// Run discovery on a cancellable context so we can stop the stream once the
 	// initial discovery+sync reaches SYNCED. dcrwallet keeps the wallet unlocked
@@ -253,22 +359,33 @@ func runDiscoveryRpcSync(privatePass string) {
 	// Decrediton's setAccountsPass-on-SYNCED. Best-effort: log on failure.
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	if err := ensureAllAccountsEncrypted(ctx, []byte(privatePass)); err != nil {
+	if err := ensureAllAccountsEncrypted(ctx, privatePass); err != nil {
 		log.Printf("Discovery RPC sync: set account passphrases: %v", err)
 	}
 }
 
-// OpenWallet opens an existing wallet with the provided public passphrase
-func OpenWallet(ctx context.Context, publicPass string) error {
+// OpenWallet opens an existing wallet with the provided public passphrase.
+// A wallet dcrwallet already has loaded yields ErrWalletAlreadyOpen.
+// beginHeight, when set, is recorded as the wallet's birthday once it is
+// open, so the rescans the dashboard starts for it skip the blocks before.
+func OpenWallet(ctx context.Context, publicPass string, beginHeight *uint32) error {
+	err := openWallet(ctx, publicPass)
+	if beginHeight != nil && (err == nil || errors.Is(err, ErrWalletAlreadyOpen)) {
+		recordBirthHeight(ctx, *beginHeight)
+	}
+	return err
+}
+
+func openWallet(ctx context.Context, publicPass string) error {
 	if rpc.WalletLoaderClient == nil {
-		return fmt.Errorf("wallet loader client not initialized")
+		return rpc.NotConnected("wallet loader client not initialized")
 	}
 
 	// First check if wallet is already loaded to avoid unnecessary open attempts
 	loaded, err := CheckWalletLoaded(ctx)
 	if err == nil && loaded {
 		log.Println("Wallet is already loaded and ready")
-		return nil
+		return ErrWalletAlreadyOpen
 	}
 
 	log.Println("Opening wallet...")
@@ -279,24 +396,65 @@ func OpenWallet(ctx context.Context, publicPass string) error {
 
 	resp, err := rpc.WalletLoaderClient.OpenWallet(ctx, req)
 	if err != nil {
-		// Check if wallet is already opened
-		if strings.Contains(err.Error(), "already opened") {
+		// dcrwallet's loader reports an open wallet as errors.Exist, which
+		// its gRPC server translates to AlreadyExists.
+		if status.Code(err) == codes.AlreadyExists {
 			log.Println("Wallet already opened")
-		} else {
-			return fmt.Errorf("failed to open wallet: %w", err)
+			return ErrWalletAlreadyOpen
 		}
-	} else {
-		log.Println("Wallet opened successfully")
-		// dcrwallet authoritatively reports watching-only here; cache it for the
-		// active wallet so it survives restarts that skip this open path.
-		cacheWatchOnly(ctx, resp.GetWatchingOnly())
+		return fmt.Errorf("failed to open wallet: %w", err)
 	}
+	log.Println("Wallet opened successfully")
+	// dcrwallet authoritatively reports watching-only here; cache it for the
+	// active wallet so it survives restarts that skip this open path.
+	cacheWatchOnly(ctx, resp.GetWatchingOnly())
 
 	// RpcSync is kicked + supervised by SuperviseRpcSync in main.go.
 
 	return nil
 }
 
+// isRpcSyncAlreadyRunning reports whether err is dcrwallet rejecting an
+// RpcSync because the wallet is already synchronizing. That is the only
+// FailedPrecondition RpcSync returns before it starts streaming.
+func isRpcSyncAlreadyRunning(err error) bool {
+	return status.Code(err) == codes.FailedPrecondition
+}
+
+// ReloadWallet force-closes the open wallet, if any, and reopens it with
+// publicPass, then restarts RpcSync. It is the recovery path for a wallet
+// whose sync or gRPC session has wedged. wasOpen reports whether a wallet
+// was closed first; if the reopen fails the wallet is left closed.
+func ReloadWallet(ctx context.Context, publicPass string) (wasOpen bool, err error) {
+	if rpc.WalletLoaderClient == nil {
+		return false, rpc.NotConnected("wallet loader client not initialized")
+	}
+
+	// Park the supervisor and cancel its stream so the close does not race
+	// an RpcSync that still holds the wallet.
+	PauseSync()
+	defer func() {
+		ResumeSync()
+		KickSync()
+	}()
+
+	wasOpen, _ = CheckWalletLoaded(ctx)
+	if wasOpen {
+		log.Println("Reloading wallet: closing current session")
+		closeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := CloseWallet(closeCtx)
+		cancel()
+		if err != nil && !errors.Is(err, ErrWalletNotOpen) {
+			return wasOpen, err
+		}
+	}
+
+	if err := OpenWallet(ctx, publicPass, nil); err != nil && !errors.Is(err, ErrWalletAlreadyOpen) {
+		return wasOpen, err
+	}
+	return wasOpen, nil
+}
+
 // cacheWatchOnly persists dcrwallet's authoritative watching-only flag (from
 // OpenWalletResponse) into the ACTIVE wallet's config, so the value survives
 // dashboard restarts that hit the already-loaded short-circuit above. Per-wallet
@@ -327,11 +485,62 @@ func cacheWatchOnly(ctx context.Context, watching bool) {
 	}
 }
 
+// recordBirthHeight stores height as the active wallet's birthday in its
+// config. dcrwallet takes a birthday only when a wallet is created, and its
+// RpcSync has no start height, so this is what lets later rescans of an
+// existing wallet start past genesis.
+func recordBirthHeight(ctx context.Context, height uint32) {
+	name := ActiveWalletName()
+	if name == "" {
+		return
+	}
+	network, err := CurrentNetwork(ctx)
+	if err != nil {
+		return
+	}
+	cfg, err := config.LoadWalletCfg(network, name)
+	if err != nil {
+		return
+	}
+	if err := cfg.Set(config.KeyBirthHeight, height); err != nil {
+		log.Printf("birth height: set failed for %s: %v", name, err)
+		return
+	}
+	if err := cfg.Save(); err != nil {
+		log.Printf("birth height: save failed for %s: %v", name, err)
+		return
+	}
+	log.Printf("Wallet %s birthday recorded at block %d", name, height)
+}
+
+// WalletRescanStart returns the block a rescan of the active wallet starts
+// at: its recorded birthday, or 0 when none is known.
+func WalletRescanStart(ctx context.Context) int32 {
+	name := ActiveWalletName()
+	if name == "" {
+		return 0
+	}
+	network, err := CurrentNetwork(ctx)
+	if err != nil {
+		return 0
+	}
+	cfg, err := config.LoadWalletCfg(network, name)
+	if err != nil {
+		return 0
+	}
+	var height uint32
+	if present, _ := cfg.Get(config.KeyBirthHeight, &height); !present {
+		return 0
+	}
+	return int32(height)
+}
+
 // EnsureRpcSync opens an RpcSync stream and dispatches notifications until
-// ctx is cancelled or the stream errors.
+// ctx is cancelled or the stream errors. If dcrwallet is already syncing it
+// returns ErrRpcSyncAlreadyRunning.
 func EnsureRpcSync(ctx context.Context) error {
 	if rpc.WalletLoaderClient == nil {
-		return fmt.Errorf("wallet loader client not initialized")
+		return rpc.NotConnected("wallet loader client not initialized")
 	}
 
 	var cert []byte
@@ -355,28 +564,35 @@ func EnsureRpcSync(ctx context.Context) error {
 
 	stream, err := rpc.WalletLoaderClient.RpcSync(ctx, req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already") {
-			log.Println("RPC sync already running in dcrwallet — will resync on next opportunity")
-			return nil
+		if isRpcSyncAlreadyRunning(err) {
+			return ErrRpcSyncAlreadyRunning
 		}
 		return fmt.Errorf("open RpcSync stream: %w", err)
 	}
 
 	log.Printf("RPC sync stream open to dcrd at %s", networkAddr)
 
+	// A server-streaming call reports the handler's rejection on the first
+	// Recv, so the already-syncing case usually surfaces here.
+	received := false
 	for {
 		resp, err := stream.Recv()
 		if err != nil {
+			if !received && isRpcSyncAlreadyRunning(err) {
+				return ErrRpcSyncAlreadyRunning
+			}
 			return fmt.Errorf("RpcSync stream ended: %w", err)
 		}
+		received = true
 		ApplyRpcSyncNotification(resp)
 	}
 }
 
-// CloseWallet closes the currently open wallet
+// CloseWallet closes the currently open wallet. With none loaded it returns
+// ErrWalletNotOpen.
 func CloseWallet(ctx context.Context) error {
 	if rpc.WalletLoaderClient == nil {
-		return fmt.Errorf("wallet loader client not initialized")
+		return rpc.NotConnected("wallet loader client not initialized")
 	}
 
 	log.Println("Closing wallet...")
@@ -384,6 +600,12 @@ func CloseWallet(ctx context.Context) error {
 	req := &pb.CloseWalletRequest{}
 	_, err := rpc.WalletLoaderClient.CloseWallet(ctx, req)
 	if err != nil {
+		// dcrwallet's loader reports an unopened wallet as errors.Invalid,
+		// which its gRPC server translates to FailedPrecondition.
+		if status.Code(err) == codes.FailedPrecondition {
+			log.Println("No wallet open to close")
+			return ErrWalletNotOpen
+		}
 		return fmt.Errorf("failed to close wallet: %w", err)
 	}
 
@@ -394,7 +616,7 @@ func CloseWallet(ctx context.Context) error {
 // CheckWalletLoaded checks if a wallet is currently loaded and ready
 func CheckWalletLoaded(ctx context.Context) (bool, error) {
 	if rpc.WalletGrpcClient == nil {
-		return false, fmt.Errorf("wallet gRPC client not initialized")
+		return false, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 
 	// Try to ping the wallet service - this only works if wallet is loaded
@@ -415,10 +637,10 @@ func CheckWalletLoaded(ctx context.Context) (bool, error) {
 func OpenWalletWithRetry(publicPass string, maxRetries int) error {
 	for i := 0; i < maxRetries; i++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		err := OpenWallet(ctx, publicPass)
+		err := OpenWallet(ctx, publicPass, nil)
 		cancel()
 
-		if err == nil {
+		if err == nil || errors.Is(err, ErrWalletAlreadyOpen) {
 			return nil
 		}
 
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSeedLength(t *testing.T) {
+	tests := []struct {
+		name string
+		in   uint32
+		want uint32
+		err  bool
+	}{
+		{"zero defaults to recommended", 0, 32, false},
+		{"minimum", 16, 16, false},
+		{"valid non-default", 24, 24, false},
+		{"maximum", 64, 64, false},
+		{"word count instead of bytes", 33, 0, true},
+		{"below minimum", 12, 0, true},
+		{"above maximum", 68, 0, true},
+	}
+	for _, tc := range tests {
+		got, err := normalizeSeedLength(tc.in)
+		if tc.err {
+			if !errors.Is(err, ErrInvalidSeedLength) {
+				t.Errorf("%s: got err %v, want ErrInvalidSeedLength", tc.name, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%s: got (%d, %v), want (%d, nil)", tc.name, got, err, tc.want)
+		}
+	}
+}
+
+func TestNormalizeSeedLengthErrorListsValues(t *testing.T) {
+	_, err := normalizeSeedLength(33)
+	if err == nil {
+		t.Fatal("expected an error for 33 bytes")
+	}
+	for _, want := range []string{"16 (17 words)", "32 (33 words)", "64 (65 words)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not list %q", err, want)
+		}
+	}
+}
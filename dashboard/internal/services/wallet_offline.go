@@ -190,7 +190,7 @@ func PreviewSignedTransaction(ctx context.Context, data []byte) (*types.SignedTx
 // the wallet's network backend. It uses no private keys.
 func BroadcastSignedTransaction(ctx context.Context, signedTxBytes []byte) (string, error) {
 	if rpc.WalletGrpcClient == nil {
-		return "", fmt.Errorf("wallet gRPC client not initialized")
+		return "", rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	resp, err := rpc.WalletGrpcClient.PublishTransaction(ctx, &pb.PublishTransactionRequest{
 		SignedTransaction: signedTxBytes,
@@ -163,20 +163,7 @@ func encodeSignRequest(sr *signRequest) []byte {
 // hdChainParams maps the wallet's network to the chaincfg params hdkeychain
 // needs to parse an extended public key.
 func hdChainParams(ctx context.Context) (*chaincfg.Params, error) {
-	network, err := CurrentNetwork(ctx)
-	if err != nil {
-		return nil, err
-	}
-	switch network {
-	case "mainnet":
-		return chaincfg.MainNetParams(), nil
-	case "testnet":
-		return chaincfg.TestNet3Params(), nil
-	case "simnet":
-		return chaincfg.SimNetParams(), nil
-	default:
-		return nil, fmt.Errorf("unknown network %q", network)
-	}
+	return CurrentChainParams(ctx)
 }
 
 // accountFingerprint computes the device-checked wrong-wallet marker: the
@@ -222,7 +209,7 @@ func prevoutInfo(ctx context.Context, prevHash chainhash.Hash, prevIndex uint32)
 // each input is enriched with its prevout script and derivation path.
 func BuildSignRequest(ctx context.Context, sourceAccount uint32, outputs []types.TxRecipient, sendAll bool) (*types.SignRequestExport, error) {
 	if rpc.WalletGrpcClient == nil {
-		return nil, fmt.Errorf("wallet gRPC client not initialized")
+		return nil, rpc.NotConnected("wallet gRPC client not initialized")
 	}
 	cResp, err := ConstructTransaction(ctx, sourceAccount, outputs, sendAll)
 	if err != nil {
@@ -6,6 +6,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -74,7 +75,7 @@ func SwitchWallet(ctx context.Context, name, publicPass string) error {
 	if err := rpc.WaitForWalletDaemon(ctx); err != nil {
 		return fmt.Errorf("wait for daemon after switch: %w", err)
 	}
-	if err := OpenWallet(ctx, publicPass); err != nil {
+	if err := OpenWallet(ctx, publicPass, nil); err != nil && !errors.Is(err, ErrWalletAlreadyOpen) {
 		return err
 	}
 	touchLastAccess(network, name)
@@ -105,20 +106,28 @@ func reconnectStackServices(ctx context.Context, name string) {
 }
 
 // CloseActiveWallet closes the current wallet and idles the supervisor so the UI
-// returns to the wallet list.
+// returns to the wallet list. The active wallet is cleared even when dcrwallet
+// had none loaded, which is then reported with ErrWalletNotOpen.
 func CloseActiveWallet(ctx context.Context) error {
 	PauseSync()
 	defer ResumeSync()
 
 	closeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	if err := CloseWallet(closeCtx); err != nil {
-		log.Printf("Close active wallet (continuing): %v", err)
+	closeErr := CloseWallet(closeCtx)
+	if closeErr != nil && !errors.Is(closeErr, ErrWalletNotOpen) {
+		log.Printf("Close active wallet (continuing): %v", closeErr)
 	}
 	cancel()
 
 	// The previous profile's DEX session secret must not leak into the next.
 	rpc.ClearDcrdexAppPass()
-	return ClearActiveWallet()
+	if err := ClearActiveWallet(); err != nil {
+		return err
+	}
+	if errors.Is(closeErr, ErrWalletNotOpen) {
+		return ErrWalletNotOpen
+	}
+	return nil
 }
 
 // CreateNamedWallet creates a new seed-based wallet under the given name,
@@ -166,7 +175,7 @@ func CreateNamedWatchOnlyWallet(ctx context.Context, name, publicPass, xpub stri
 	// CreateWatchingOnlyWallet opens the wallet; ensure it is loaded for the
 	// supervisor's sync, then tag the per-wallet config. dcrwallet reports
 	// WatchingOnly=true, so the OpenWallet capture reconfirms it on every open.
-	if err := OpenWallet(ctx, publicPass); err != nil {
+	if err := OpenWallet(ctx, publicPass, nil); err != nil && !errors.Is(err, ErrWalletAlreadyOpen) {
 		log.Printf("Watch-only create: ensure open: %v", err)
 	}
 	cacheWatchOnly(ctx, true)
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+)
+
+const (
+	// walletSyncRateWindow is how far back the wallet's height rate is
+	// averaged; a wallet that hasn't advanced over a full window is stalled.
+	walletSyncRateWindow = 60 * time.Second
+	// walletSyncMinSpan is the shortest sample span an ETA is given for.
+	walletSyncMinSpan = 5 * time.Second
+)
+
+// Wallet height samples for the sync ETA, fed by every status request and
+// sync stream push.
+var (
+	walletSyncRateMu      sync.Mutex
+	walletSyncRateSamples []scanRateSample
+)
+
+// FetchWalletSyncStatus returns the wallet's sync state from the current
+// SyncSnapshot and the wallet's and chain's best heights.
+func FetchWalletSyncStatus(ctx context.Context) types.WalletSyncStatus {
+	return WalletSyncStatusFor(ctx, GetSyncSnapshot())
+}
+
+// WalletSyncStatusFor is FetchWalletSyncStatus for a given snapshot, so the
+// sync streams classify each snapshot they push the same way the polled
+// endpoint does. A height that cannot be fetched is reported as zero.
+func WalletSyncStatusFor(ctx context.Context, snap SyncSnapshot) types.WalletSyncStatus {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var walletHeight, chainHeight int64
+	if rpc.DcrdClient != nil {
+		if h, err := rpc.DcrdClient.GetBlockCount(ctx); err == nil {
+			chainHeight = h
+		}
+	}
+	if rpc.WalletClient != nil {
+		if _, h, err := rpc.WalletClient.GetBestBlock(ctx); err == nil {
+			walletHeight = h
+		}
+	}
+	status := classifyWalletSync(snap, walletHeight, chainHeight)
+	samples := recordWalletSyncHeight(time.Now(), walletHeight)
+	status.BlocksPerSecond, status.EstimatedSecondsRemaining, status.ETAState =
+		walletSyncETA(samples, status)
+	return status
+}
+
+// recordWalletSyncHeight adds a wallet height sample, dropping those older
+// than walletSyncRateWindow but keeping the newest of them as the window's
+// start, and returns a copy of the samples. A height lower than the last
+// sample (another wallet was opened) restarts the window.
+func recordWalletSyncHeight(now time.Time, height int64) []scanRateSample {
+	walletSyncRateMu.Lock()
+	defer walletSyncRateMu.Unlock()
+
+	if height <= 0 {
+		return append([]scanRateSample(nil), walletSyncRateSamples...)
+	}
+	if n := len(walletSyncRateSamples); n > 0 && height < walletSyncRateSamples[n-1].height {
+		walletSyncRateSamples = nil
+	}
+	walletSyncRateSamples = append(walletSyncRateSamples, scanRateSample{at: now, height: height})
+	drop := 0
+	for drop+1 < len(walletSyncRateSamples) && now.Sub(walletSyncRateSamples[drop+1].at) >= walletSyncRateWindow {
+		drop++
+	}
+	walletSyncRateSamples = walletSyncRateSamples[drop:]
+	return append([]scanRateSample(nil), walletSyncRateSamples...)
+}
+
+// walletSyncETA derives the height rate and time to reach the chain tip from
+// samples. Only the syncing-blocks phase advances the wallet height, so other
+// phases still behind the tip get no estimate.
+func walletSyncETA(samples []scanRateSample, status types.WalletSyncStatus) (float64, int64, types.WalletSyncETAState) {
+	if status.Synced || (status.WalletHeight > 0 && status.WalletHeight >= status.ChainHeight) {
+		return 0, 0, types.WalletSyncETADone
+	}
+	n := len(samples)
+	if n < 2 {
+		return 0, 0, types.WalletSyncETAUnknown
+	}
+	first, last := samples[0], samples[n-1]
+	span := last.at.Sub(first.at)
+	if span < walletSyncMinSpan {
+		return 0, 0, types.WalletSyncETAUnknown
+	}
+	rate := float64(last.height-first.height) / span.Seconds()
+	if status.Phase != types.WalletSyncBlocks {
+		return rate, 0, types.WalletSyncETAUnknown
+	}
+	if rate <= 0 {
+		if span >= walletSyncRateWindow {
+			return 0, 0, types.WalletSyncETAStalled
+		}
+		return 0, 0, types.WalletSyncETAUnknown
+	}
+	return rate, int64(float64(status.BlocksBehind)/rate + 0.5), types.WalletSyncETAEstimated
+}
+
+// classifyWalletSync collapses the detailed RpcSync phase into the coarse
+// phases the UI shows. A wallet that reported SYNCED but trails the chain by
+// more than a block (new blocks not yet processed) is syncing-blocks.
+func classifyWalletSync(snap SyncSnapshot, walletHeight, chainHeight int64) types.WalletSyncStatus {
+	status := types.WalletSyncStatus{
+		WalletHeight: walletHeight,
+		ChainHeight:  chainHeight,
+		DetailPhase:  string(snap.Phase),
+	}
+	if chainHeight > walletHeight && walletHeight > 0 {
+		status.BlocksBehind = chainHeight - walletHeight
+	}
+
+	switch {
+	case !snap.DaemonConnected:
+		status.Phase = types.WalletSyncDisconnected
+	case snap.Phase == SyncPhaseFetchingCfilters || snap.Phase == SyncPhaseFetchingHeaders:
+		status.Phase = types.WalletSyncHeaders
+	case snap.Phase == SyncPhaseDiscoverAddresses || snap.Phase == SyncPhaseRescanning:
+		status.Phase = types.WalletSyncRescanning
+	case snap.Phase == SyncPhaseSynced && status.BlocksBehind <= 1:
+		status.Phase = types.WalletSyncSynced
+	default:
+		status.Phase = types.WalletSyncBlocks
+	}
+	status.Synced = status.Phase == types.WalletSyncSynced
+	return status
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dcrpulse/internal/types"
+)
+
+func TestWalletSyncETA(t *testing.T) {
+	t0 := time.Unix(1700000000, 0)
+	behind := types.WalletSyncStatus{
+		Phase:        types.WalletSyncBlocks,
+		WalletHeight: 1100,
+		ChainHeight:  2100,
+		BlocksBehind: 1000,
+	}
+	advancing := []scanRateSample{
+		{at: t0, height: 1000},
+		{at: t0.Add(10 * time.Second), height: 1100},
+	}
+	flat := []scanRateSample{
+		{at: t0, height: 1100},
+		{at: t0.Add(walletSyncRateWindow), height: 1100},
+	}
+
+	tests := []struct {
+		name    string
+		samples []scanRateSample
+		status  types.WalletSyncStatus
+		rate    float64
+		eta     int64
+		state   types.WalletSyncETAState
+	}{{
+		name:    "estimated",
+		samples: advancing,
+		status:  behind,
+		rate:    10,
+		eta:     100,
+		state:   types.WalletSyncETAEstimated,
+	}, {
+		name:    "wallet ahead of chain",
+		samples: advancing,
+		status:  types.WalletSyncStatus{Phase: types.WalletSyncBlocks, WalletHeight: 2101, ChainHeight: 2100},
+		state:   types.WalletSyncETADone,
+	}, {
+		name:    "synced",
+		samples: advancing,
+		status:  types.WalletSyncStatus{Synced: true, Phase: types.WalletSyncSynced, WalletHeight: 2099, ChainHeight: 2100, BlocksBehind: 1},
+		state:   types.WalletSyncETADone,
+	}, {
+		name:    "single sample",
+		samples: advancing[:1],
+		status:  behind,
+		state:   types.WalletSyncETAUnknown,
+	}, {
+		name:    "span too short",
+		samples: []scanRateSample{{at: t0, height: 1000}, {at: t0.Add(time.Second), height: 1100}},
+		status:  behind,
+		state:   types.WalletSyncETAUnknown,
+	}, {
+		name:    "no progress yet",
+		samples: []scanRateSample{{at: t0, height: 1100}, {at: t0.Add(10 * time.Second), height: 1100}},
+		status:  behind,
+		state:   types.WalletSyncETAUnknown,
+	}, {
+		name:    "stalled",
+		samples: flat,
+		status:  behind,
+		state:   types.WalletSyncETAStalled,
+	}, {
+		name:    "headers phase",
+		samples: flat,
+		status:  types.WalletSyncStatus{Phase: types.WalletSyncHeaders, WalletHeight: 1100, ChainHeight: 2100, BlocksBehind: 1000},
+		state:   types.WalletSyncETAUnknown,
+	}}
+	for _, test := range tests {
+		rate, eta, state := walletSyncETA(test.samples, test.status)
+		if rate != test.rate || eta != test.eta || state != test.state {
+			t.Errorf("%s: got (%v, %d, %s), want (%v, %d, %s)", test.name,
+				rate, eta, state, test.rate, test.eta, test.state)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrWalletTxNotFound is returned for a transaction the wallet has no record
+// of.
+var ErrWalletTxNotFound = errors.New("transaction not found in wallet")
+
+// GetWalletTransactionDetail returns a wallet transaction with each input and
+// output attributed to the wallet's accounts, from dcrwallet's gRPC
+// GetTransaction.
+func GetWalletTransactionDetail(ctx context.Context, txHash string) (*types.WalletTransactionDetail, error) {
+	hash, err := chainhash.NewHashFromStr(txHash)
+	if err != nil || len(txHash) != 64 {
+		return nil, ErrInvalidHash
+	}
+	resp, err := rpc.WalletGrpcClient.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionHash: hash[:]})
+	if status.Code(err) == codes.NotFound || (err == nil && resp.Transaction == nil) {
+		return nil, fmt.Errorf("%s: %w", txHash, ErrWalletTxNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get transaction %s: %w", txHash, err)
+	}
+
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(resp.Transaction.Transaction)); err != nil {
+		return nil, fmt.Errorf("decode transaction %s: %w", txHash, err)
+	}
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Names are a convenience: without them accounts are still numbered.
+	names := map[uint32]string{}
+	if accts, err := rpc.WalletGrpcClient.Accounts(ctx, &pb.AccountsRequest{}); err == nil {
+		for _, a := range accts.Accounts {
+			names[a.AccountNumber] = a.AccountName
+		}
+	}
+
+	detail := walletTxDetail(resp.Transaction, &msgTx, params, names)
+	detail.Confirmations = resp.Confirmations
+	if len(resp.BlockHash) > 0 {
+		detail.BlockHash = hashHex(resp.BlockHash)
+	}
+	return detail, nil
+}
+
+// walletTxDetail attributes msgTx's inputs and outputs using the wallet's
+// debits (its inputs) and credits (its outputs) in details. An output paying
+// an internal-branch address back to a wallet that funded the transaction is
+// change, and left out of the amount sent. When the wallet funded every input
+// the amount sent is what the other outputs pay; when it funded only some,
+// as in a mix, the other outputs aren't all its payments, so it is the
+// wallet's debits less its credits and the fee.
+func walletTxDetail(details *pb.TransactionDetails, msgTx *wire.MsgTx, params *chaincfg.Params, names map[uint32]string) *types.WalletTransactionDetail {
+	debits := make(map[uint32]*pb.TransactionDetails_Input, len(details.Debits))
+	for _, d := range details.Debits {
+		debits[d.Index] = d
+	}
+	credits := make(map[uint32]*pb.TransactionDetails_Output, len(details.Credits))
+	for _, c := range details.Credits {
+		credits[c.Index] = c
+	}
+
+	nets := map[uint32]*types.WalletTxAccountNet{}
+	accountNet := func(account uint32) *types.WalletTxAccountNet {
+		n, ok := nets[account]
+		if !ok {
+			n = &types.WalletTxAccountNet{Account: account, AccountName: names[account]}
+			nets[account] = n
+		}
+		return n
+	}
+
+	out := &types.WalletTransactionDetail{
+		TxID:    msgTx.TxHash().String(),
+		TxType:  txTypeName(details.TransactionType),
+		Time:    details.Timestamp,
+		Size:    msgTx.SerializeSize(),
+		Fee:     dcrutil.Amount(details.Fee).ToCoin(),
+		Inputs:  make([]types.WalletTxInput, 0, len(msgTx.TxIn)),
+		Outputs: make([]types.WalletTxOutput, 0, len(msgTx.TxOut)),
+	}
+
+	for i, in := range msgTx.TxIn {
+		input := types.WalletTxInput{
+			Index:     uint32(i),
+			PrevTxID:  in.PreviousOutPoint.Hash.String(),
+			PrevIndex: in.PreviousOutPoint.Index,
+			PrevTree:  in.PreviousOutPoint.Tree,
+			Amount:    dcrutil.Amount(in.ValueIn).ToCoin(),
+		}
+		if d, ok := debits[uint32(i)]; ok {
+			account := d.PreviousAccount
+			input.Owned = true
+			input.Account = &account
+			input.AccountName = names[account]
+			input.Amount = dcrutil.Amount(d.PreviousAmount).ToCoin()
+			accountNet(account).Debit += input.Amount
+		}
+		out.Inputs = append(out.Inputs, input)
+	}
+
+	var changeAtoms, externalAtoms int64
+	for i, txOut := range msgTx.TxOut {
+		scriptType, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, params)
+		output := types.WalletTxOutput{
+			Index:      uint32(i),
+			Amount:     dcrutil.Amount(txOut.Value).ToCoin(),
+			ScriptType: scriptType.String(),
+		}
+		if len(addrs) > 0 {
+			output.Address = addrs[0].String()
+		}
+		if c, ok := credits[uint32(i)]; ok {
+			account := c.Account
+			output.Owned = true
+			output.Account = &account
+			output.AccountName = names[account]
+			output.Internal = c.Internal
+			if c.Address != "" {
+				output.Address = c.Address
+			}
+			output.Change = c.Internal && len(details.Debits) > 0
+			accountNet(account).Credit += output.Amount
+		}
+		switch {
+		case output.Change:
+			changeAtoms += txOut.Value
+		case !output.Owned:
+			externalAtoms += txOut.Value
+		}
+		out.Outputs = append(out.Outputs, output)
+	}
+	out.ChangeAmount = dcrutil.Amount(changeAtoms).ToCoin()
+	switch {
+	case len(details.Debits) == 0:
+	case len(details.Debits) == len(msgTx.TxIn):
+		out.AmountSent = dcrutil.Amount(externalAtoms).ToCoin()
+	default:
+		var debitAtoms, creditAtoms int64
+		for _, d := range details.Debits {
+			debitAtoms += d.PreviousAmount
+		}
+		for _, c := range details.Credits {
+			creditAtoms += c.Amount
+		}
+		if sent := debitAtoms - creditAtoms - details.Fee; sent > 0 {
+			out.AmountSent = dcrutil.Amount(sent).ToCoin()
+		}
+	}
+
+	out.Accounts = make([]types.WalletTxAccountNet, 0, len(nets))
+	for _, n := range nets {
+		n.Net = n.Credit - n.Debit
+		out.Net += n.Net
+		out.Accounts = append(out.Accounts, *n)
+	}
+	sort.Slice(out.Accounts, func(i, j int) bool { return out.Accounts[i].Account < out.Accounts[j].Account })
+	return out
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"testing"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+func TestWalletTxDetail(t *testing.T) {
+	// Spends one output of account 1 and one not the wallet's, pays 3 DCR
+	// to account 0, 1.5 DCR of change back to account 1 and 0.4999 DCR
+	// elsewhere.
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0, wire.TxTreeRegular), 4e8, nil))
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{2}, 3, wire.TxTreeRegular), 1e8, nil))
+	msgTx.AddTxOut(wire.NewTxOut(3e8, []byte{0x6a}))
+	msgTx.AddTxOut(wire.NewTxOut(1.5e8, []byte{0x6a}))
+	msgTx.AddTxOut(wire.NewTxOut(0.4999e8, []byte{0x6a}))
+	details := &pb.TransactionDetails{
+		Debits: []*pb.TransactionDetails_Input{
+			{Index: 0, PreviousAccount: 1, PreviousAmount: 4e8},
+		},
+		Credits: []*pb.TransactionDetails_Output{
+			{Index: 0, Account: 0, Amount: 3e8, Address: "DsRecv"},
+			{Index: 1, Account: 1, Amount: 1.5e8, Internal: true, Address: "DsChange"},
+		},
+		Fee: 1e4,
+	}
+	names := map[uint32]string{0: "default", 1: "savings"}
+
+	got := walletTxDetail(details, msgTx, chaincfg.MainNetParams(), names)
+	if got.TxID != msgTx.TxHash().String() || got.Fee != 0.0001 {
+		t.Errorf("txid %s fee %v", got.TxID, got.Fee)
+	}
+	if in := got.Inputs[0]; !in.Owned || in.Account == nil || *in.Account != 1 || in.AccountName != "savings" {
+		t.Errorf("input 0 = %+v, want savings", in)
+	}
+	if in := got.Inputs[1]; in.Owned || in.Account != nil || in.PrevIndex != 3 || in.Amount != 1 {
+		t.Errorf("input 1 = %+v, want foreign 1 DCR", in)
+	}
+	if out := got.Outputs[1]; !out.Owned || !out.Internal || !out.Change || out.Address != "DsChange" {
+		t.Errorf("output 1 = %+v, want change", out)
+	}
+	if out := got.Outputs[2]; out.Owned || out.Account != nil || out.ScriptType != "nulldata" {
+		t.Errorf("output 2 = %+v, want foreign", out)
+	}
+
+	if len(got.Accounts) != 2 {
+		t.Fatalf("got %d accounts, want 2", len(got.Accounts))
+	}
+	if a := got.Accounts[0]; a.Account != 0 || a.Credit != 3 || a.Debit != 0 || a.Net != 3 {
+		t.Errorf("account 0 = %+v", a)
+	}
+	if a := got.Accounts[1]; a.Account != 1 || a.Credit != 1.5 || a.Debit != 4 || a.Net != -2.5 {
+		t.Errorf("account 1 = %+v", a)
+	}
+	if got.Net != 0.5 {
+		t.Errorf("net %v, want 0.5", got.Net)
+	}
+	// The wallet funded one of two inputs and got more back than it put in.
+	if got.AmountSent != 0 || got.ChangeAmount != 1.5 {
+		t.Errorf("sent %v change %v, want 0 and 1.5", got.AmountSent, got.ChangeAmount)
+	}
+}
+
+func TestWalletTxDetailAmountSent(t *testing.T) {
+	// Spends 10 DCR of account 0, paying 2.5 DCR away and 7.4999 DCR of
+	// change back.
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0, wire.TxTreeRegular), 10e8, nil))
+	msgTx.AddTxOut(wire.NewTxOut(7.4999e8, []byte{0x6a}))
+	msgTx.AddTxOut(wire.NewTxOut(2.5e8, []byte{0x6a}))
+	details := &pb.TransactionDetails{
+		Debits:  []*pb.TransactionDetails_Input{{Index: 0, PreviousAccount: 0, PreviousAmount: 10e8}},
+		Credits: []*pb.TransactionDetails_Output{{Index: 0, Account: 0, Amount: 7.4999e8, Internal: true}},
+		Fee:     1e4,
+	}
+	got := walletTxDetail(details, msgTx, chaincfg.MainNetParams(), nil)
+	if got.AmountSent != 2.5 || got.ChangeAmount != 7.4999 {
+		t.Errorf("sent %v change %v, want 2.5 and 7.4999", got.AmountSent, got.ChangeAmount)
+	}
+	if !got.Outputs[0].Change || got.Outputs[1].Change {
+		t.Errorf("change flags %v %v, want output 0 only", got.Outputs[0].Change, got.Outputs[1].Change)
+	}
+
+	// Received from elsewhere: an internal-branch credit is not change.
+	received := &pb.TransactionDetails{
+		Credits: []*pb.TransactionDetails_Output{{Index: 1, Account: 0, Amount: 2.5e8, Internal: true}},
+	}
+	got = walletTxDetail(received, msgTx, chaincfg.MainNetParams(), nil)
+	if got.AmountSent != 0 || got.ChangeAmount != 0 || got.Outputs[1].Change {
+		t.Errorf("received: sent %v change %v flag %v", got.AmountSent, got.ChangeAmount, got.Outputs[1].Change)
+	}
+}
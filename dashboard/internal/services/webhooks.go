@@ -0,0 +1,334 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/rpc"
+	"dcrpulse/internal/types"
+
+	pb "decred.org/dcrwallet/v5/rpc/walletrpc"
+)
+
+// Webhook event types. A webhook URL subscribes to a subset of these; an
+// empty subset means every event.
+const (
+	WebhookEventTSpendMempool  = "tspend.mempool"
+	WebhookEventTSpendScanDone = "tspend.scan_complete"
+	WebhookEventWalletTx       = "wallet.tx"
+	WebhookEventRescanDone     = "wallet.rescan_complete"
+)
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of the request body,
+// keyed with the configured secret, so receivers can verify authenticity.
+const WebhookSignatureHeader = "X-Dcrpulse-Signature"
+
+const (
+	webhookAttempts     = 5
+	webhookBaseBackoff  = 2 * time.Second
+	webhookQueueSize    = 64
+	webhookPostTimeout  = 10 * time.Second
+	webhookTxRetryDelay = 30 * time.Second
+)
+
+// webhookTarget is one configured receiver, the events it wants and the
+// queue its delivery worker drains.
+type webhookTarget struct {
+	url    string
+	events map[string]bool
+	queue  chan webhookDelivery
+}
+
+func (t webhookTarget) wants(event string) bool {
+	return len(t.events) == 0 || t.events[event]
+}
+
+var (
+	webhookMu      sync.RWMutex
+	webhookTargets []webhookTarget
+	webhookSecret  []byte
+	// webhookStop cancels the workers of the current targets, so a second
+	// ConfigureWebhooks replaces them instead of leaving them running.
+	webhookStop context.CancelFunc
+
+	// seenMempoolTSpends is the TSpend set from the previous mempool scan, so a
+	// TSpend fires tspend.mempool once when it first appears, not every poll.
+	seenMempoolTSpendsMu sync.Mutex
+	seenMempoolTSpends   map[string]bool
+)
+
+type webhookDelivery struct {
+	url   string
+	body  []byte
+	event string
+}
+
+// ConfigureWebhooks parses the webhook spec and starts one delivery worker per
+// receiver, each stopping when ctx is done. The spec is a whitespace-separated
+// list of URLs, each optionally followed by "|" and a comma-separated event
+// filter, e.g.
+//
+//	https://a.example/hook|tspend.mempool,tspend.scan_complete https://b.example/all
+//
+// An empty spec disables webhooks. Invalid entries are logged and skipped.
+func ConfigureWebhooks(ctx context.Context, spec, secret string) {
+	targets := parseWebhookSpec(spec)
+	if len(targets) == 0 {
+		return
+	}
+	if secret == "" {
+		log.Println("Webhooks: no secret configured; requests will be unsigned")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	for i := range targets {
+		targets[i].queue = make(chan webhookDelivery, webhookQueueSize)
+		go runWebhookWorker(ctx, targets[i].queue)
+	}
+	webhookMu.Lock()
+	if webhookStop != nil {
+		webhookStop()
+	}
+	webhookTargets = targets
+	webhookSecret = []byte(secret)
+	webhookStop = cancel
+	webhookMu.Unlock()
+	log.Printf("Webhooks: %d receiver(s) configured", len(targets))
+}
+
+// parseWebhookSpec parses a ConfigureWebhooks spec into its targets, without
+// queues. Entries whose URL is not absolute http(s) are logged and skipped.
+func parseWebhookSpec(spec string) []webhookTarget {
+	var targets []webhookTarget
+	for _, entry := range strings.Fields(spec) {
+		rawURL, filter, _ := strings.Cut(entry, "|")
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			log.Printf("Webhooks: ignoring invalid URL %q", rawURL)
+			continue
+		}
+		t := webhookTarget{url: u.String()}
+		if filter != "" {
+			t.events = map[string]bool{}
+			for _, ev := range strings.Split(filter, ",") {
+				if ev = strings.TrimSpace(ev); ev != "" {
+					t.events[ev] = true
+				}
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// WebhooksEnabled reports whether at least one receiver is configured.
+func WebhooksEnabled() bool {
+	webhookMu.RLock()
+	defer webhookMu.RUnlock()
+	return len(webhookTargets) > 0
+}
+
+// NotifyWebhooks queues an event for every receiver subscribed to it. It never
+// blocks: when a receiver's queue is full the event is dropped for that
+// receiver and logged.
+func NotifyWebhooks(event string, data interface{}) {
+	webhookMu.RLock()
+	targets := webhookTargets
+	webhookMu.RUnlock()
+	if len(targets) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(types.WebhookEvent{
+		Event:     event,
+		Timestamp: time.Now().Unix(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("Webhooks: marshal %s: %v", event, err)
+		return
+	}
+	for _, t := range targets {
+		if !t.wants(event) {
+			continue
+		}
+		select {
+		case t.queue <- webhookDelivery{url: t.url, body: body, event: event}:
+		default:
+			log.Printf("Webhooks: queue full, dropping %s for %s", event, t.url)
+		}
+	}
+}
+
+// runWebhookWorker delivers one receiver's queued events in order, so a slow
+// or dead receiver backs up only its own queue.
+func runWebhookWorker(ctx context.Context, queue <-chan webhookDelivery) {
+	client := &http.Client{Timeout: webhookPostTimeout}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-queue:
+			deliverWebhook(ctx, client, d)
+		}
+	}
+}
+
+// deliverWebhook POSTs one event, retrying with exponential backoff on a
+// transport error or non-2xx response. It gives up early when ctx is done.
+func deliverWebhook(ctx context.Context, client *http.Client, d webhookDelivery) {
+	webhookMu.RLock()
+	secret := webhookSecret
+	webhookMu.RUnlock()
+
+	backoff := webhookBaseBackoff
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		err := postWebhook(ctx, client, d, secret)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		if attempt == webhookAttempts {
+			log.Printf("Webhooks: giving up on %s to %s after %d attempts: %v", d.event, d.url, attempt, err)
+			return
+		}
+		log.Printf("Webhooks: %s to %s failed (attempt %d/%d), retrying in %s: %v", d.event, d.url, attempt, webhookAttempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func postWebhook(ctx context.Context, client *http.Client, d webhookDelivery, secret []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Dcrpulse-Event", d.event)
+	if len(secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+signWebhook(secret, d.body))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signWebhook returns the hex HMAC-SHA256 of body keyed with secret.
+func signWebhook(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyNewMempoolTSpends fires tspend.mempool for every TSpend not present
+// in the previous scan and remembers the current set for the next one.
+func notifyNewMempoolTSpends(tspends []types.TSpend) {
+	if !WebhooksEnabled() {
+		return
+	}
+	current := make(map[string]bool, len(tspends))
+	seenMempoolTSpendsMu.Lock()
+	var fresh []types.TSpend
+	for _, ts := range tspends {
+		current[ts.TxHash] = true
+		if !seenMempoolTSpends[ts.TxHash] {
+			fresh = append(fresh, ts)
+		}
+	}
+	seenMempoolTSpends = current
+	seenMempoolTSpendsMu.Unlock()
+
+	// TSpends already in mempool when dcrpulse starts are reported on the first
+	// scan, since a receiver has no other way to learn about them.
+	for _, ts := range fresh {
+		NotifyWebhooks(WebhookEventTSpendMempool, ts)
+	}
+}
+
+// StartWalletTxWebhooks follows dcrwallet's TransactionNotifications stream
+// and fires wallet.tx for every new mined or unmined wallet transaction. It
+// is a no-op when no webhooks are configured, and re-subscribes after the
+// stream drops (wallet switch, daemon restart).
+func StartWalletTxWebhooks(ctx context.Context) {
+	if !WebhooksEnabled() {
+		return
+	}
+	go func() {
+		for ctx.Err() == nil {
+			if client := rpc.WalletGrpcClient; client != nil && !SyncPaused() {
+				if err := followWalletTransactions(ctx, client); err != nil && ctx.Err() == nil {
+					log.Printf("Webhooks: wallet transaction stream ended: %v", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(webhookTxRetryDelay):
+			}
+		}
+	}()
+}
+
+func followWalletTransactions(ctx context.Context, client pb.WalletServiceClient) error {
+	stream, err := client.TransactionNotifications(ctx, &pb.TransactionNotificationsRequest{})
+	if err != nil {
+		return fmt.Errorf("TransactionNotifications: %w", err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		for _, tx := range resp.UnminedTransactions {
+			NotifyWebhooks(WebhookEventWalletTx, walletTxEvent(tx, nil))
+		}
+		for _, blk := range resp.AttachedBlocks {
+			for _, tx := range blk.Transactions {
+				NotifyWebhooks(WebhookEventWalletTx, walletTxEvent(tx, blk))
+			}
+		}
+	}
+}
+
+func walletTxEvent(tx *pb.TransactionDetails, blk *pb.BlockDetails) types.WebhookWalletTx {
+	ev := types.WebhookWalletTx{
+		TxHash:   reversedHex(tx.GetHash()),
+		Type:     strings.ToLower(tx.GetTransactionType().String()),
+		FeeAtoms: tx.GetFee(),
+	}
+	for _, c := range tx.GetCredits() {
+		ev.CreditAtoms += c.GetAmount()
+	}
+	for _, d := range tx.GetDebits() {
+		ev.DebitAtoms += d.GetPreviousAmount()
+	}
+	if blk != nil {
+		ev.BlockHeight = blk.GetHeight()
+		ev.BlockHash = reversedHex(blk.GetHash())
+	}
+	return ev
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSignWebhook(t *testing.T) {
+	// RFC 4231 test case 2.
+	got := signWebhook([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("signWebhook = %s, want %s", got, want)
+	}
+}
+
+func TestParseWebhookSpec(t *testing.T) {
+	targets := parseWebhookSpec("https://a.example/hook|tspend.mempool,, wallet.tx " +
+		"ftp://bad.example/x http:///nohost https://b.example/all " +
+		"http://c.example/empty|")
+	if len(targets) != 3 {
+		t.Fatalf("parsed %d targets, want 3: %+v", len(targets), targets)
+	}
+
+	a := targets[0]
+	if a.url != "https://a.example/hook" {
+		t.Errorf("first url = %q", a.url)
+	}
+	if !a.wants(WebhookEventTSpendMempool) || a.wants(WebhookEventWalletTx) || len(a.events) != 1 {
+		t.Errorf("first filter = %v, want only %s", a.events, WebhookEventTSpendMempool)
+	}
+	for _, tg := range targets[1:] {
+		if !tg.wants(WebhookEventWalletTx) || !tg.wants(WebhookEventRescanDone) {
+			t.Errorf("%s should want every event, filter = %v", tg.url, tg.events)
+		}
+	}
+	if targets[1].url != "https://b.example/all" || targets[2].url != "http://c.example/empty" {
+		t.Errorf("urls = %q, %q", targets[1].url, targets[2].url)
+	}
+	if got := parseWebhookSpec("  "); len(got) != 0 {
+		t.Errorf("blank spec parsed to %+v", got)
+	}
+}
+
+func TestWebhookReceiversIndependent(t *testing.T) {
+	webhookMu.Lock()
+	savedTargets, savedSecret, savedStop := webhookTargets, webhookSecret, webhookStop
+	webhookStop = nil
+	webhookMu.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		webhookMu.Lock()
+		webhookTargets, webhookSecret, webhookStop = savedTargets, savedSecret, savedStop
+		webhookMu.Unlock()
+	})
+
+	// The dead receiver fails every attempt, leaving its worker in backoff.
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer dead.Close()
+	got := make(chan string, 4)
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got <- r.Header.Get(WebhookSignatureHeader)
+	}))
+	defer live.Close()
+
+	ConfigureWebhooks(ctx, dead.URL+" "+live.URL, "secret")
+	NotifyWebhooks(WebhookEventTSpendScanDone, nil)
+	NotifyWebhooks(WebhookEventTSpendScanDone, nil)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case sig := <-got:
+			if len(sig) != len("sha256=")+64 {
+				t.Errorf("signature header = %q", sig)
+			}
+		case <-time.After(webhookBaseBackoff):
+			t.Fatalf("live receiver got %d of 2 events while the dead one was retrying", i)
+		}
+	}
+}
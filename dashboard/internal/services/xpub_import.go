@@ -0,0 +1,173 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"dcrpulse/internal/types"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/hdkeychain/v3"
+)
+
+// Xpub import job states, in the order a successful import passes through them.
+const (
+	XpubImportImporting   = "importing"
+	XpubImportDiscovering = "discovering"
+	XpubImportRescanning  = "rescanning"
+	XpubImportDone        = "done"
+	XpubImportFailed      = "failed"
+)
+
+// xpubImportRetention is how long a finished import job stays queryable.
+const xpubImportRetention = time.Hour
+
+var (
+	xpubImportMu   sync.Mutex
+	xpubImportJobs = map[string]*types.XpubImportStatus{}
+)
+
+// ValidateXpub errors. ErrXpubWrongNetwork is a well-formed extended key for a
+// different network than dcrd's; ErrInvalidXpub is anything else unusable.
+var (
+	ErrXpubWrongNetwork = errors.New("xpub is for a different network")
+	ErrInvalidXpub      = errors.New("invalid xpub")
+)
+
+// ValidateXpub parses xpub against the active network's HD key versions and
+// rejects private keys, so a typo or a testnet key never reaches dcrwallet.
+func ValidateXpub(ctx context.Context, xpub string) error {
+	_, _, err := parseXpub(ctx, xpub)
+	return err
+}
+
+// xpubPreviewAddresses is how many external addresses PreviewXpub derives.
+const xpubPreviewAddresses = 5
+
+// PreviewXpub validates xpub as ValidateXpub does and describes the account
+// importing it would create: the key's fingerprint, its BIP44 account index
+// when it is an account-level key, its first external addresses, and the
+// account already holding it, if any. Nothing is imported.
+func PreviewXpub(ctx context.Context, xpub string) (*types.XpubPreview, error) {
+	key, params, err := parseXpub(ctx, xpub)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := deriveBranchAddresses(xpub, 0, xpubPreviewAddresses, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXpub, err)
+	}
+	preview := &types.XpubPreview{
+		Network:     params.Name,
+		Fingerprint: hex.EncodeToString(dcrutil.Hash160(key.SerializedPubKey())[:4]),
+		Depth:       key.Depth(),
+		Addresses:   addrs,
+	}
+	// An account key sits at m/44'/coin'/account', a hardened child at depth 3.
+	if n := key.ChildNum(); key.Depth() == 3 && n >= hdkeychain.HardenedKeyStart {
+		index := n - hdkeychain.HardenedKeyStart
+		preview.AccountIndex = &index
+	}
+	if acct, dup, err := XpubAlreadyImported(ctx, xpub); err == nil && dup {
+		preview.ImportedAs = acct
+	}
+	return preview, nil
+}
+
+// parseXpub parses xpub for the network dcrd is on, rejecting private keys.
+func parseXpub(ctx context.Context, xpub string) (*hdkeychain.ExtendedKey, *chaincfg.Params, error) {
+	params, err := CurrentChainParams(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := hdkeychain.NewKeyFromString(xpub, params)
+	if errors.Is(err, hdkeychain.ErrWrongNetwork) {
+		return nil, nil, fmt.Errorf("%w (dcrd is on %s)", ErrXpubWrongNetwork, params.Name)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidXpub, err)
+	}
+	if key.IsPrivate() {
+		return nil, nil, fmt.Errorf("%w: an extended private key was given, import the public key instead", ErrInvalidXpub)
+	}
+	return key, params, nil
+}
+
+// NewXpubImportJob registers an import for accountName and returns its id.
+func NewXpubImportJob(accountName string) string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		b = []byte(fmt.Sprintf("%08x", time.Now().UnixNano()))
+	}
+	id := hex.EncodeToString(b)
+	now := time.Now().Unix()
+
+	xpubImportMu.Lock()
+	defer xpubImportMu.Unlock()
+	pruneXpubImportJobsLocked()
+	xpubImportJobs[id] = &types.XpubImportStatus{
+		ID:          id,
+		AccountName: accountName,
+		State:       XpubImportImporting,
+		StartedAt:   now,
+		UpdatedAt:   now,
+	}
+	return id
+}
+
+// UpdateXpubImportJob applies update to the job under the registry lock.
+func UpdateXpubImportJob(id string, update func(*types.XpubImportStatus)) {
+	xpubImportMu.Lock()
+	defer xpubImportMu.Unlock()
+	job := xpubImportJobs[id]
+	if job == nil {
+		return
+	}
+	update(job)
+	job.RescanRunning = job.State == XpubImportRescanning
+	job.UpdatedAt = time.Now().Unix()
+}
+
+// FailXpubImportJob marks the job failed with err.
+func FailXpubImportJob(id string, err error) {
+	UpdateXpubImportJob(id, func(job *types.XpubImportStatus) {
+		job.State = XpubImportFailed
+		job.Error = err.Error()
+	})
+}
+
+// GetXpubImportJob returns a copy of the job's status.
+func GetXpubImportJob(id string) (types.XpubImportStatus, bool) {
+	xpubImportMu.Lock()
+	defer xpubImportMu.Unlock()
+	job := xpubImportJobs[id]
+	if job == nil {
+		return types.XpubImportStatus{}, false
+	}
+	status := *job
+	if job.AccountNum != nil {
+		n := *job.AccountNum
+		status.AccountNum = &n
+	}
+	return status, true
+}
+
+func pruneXpubImportJobsLocked() {
+	cutoff := time.Now().Add(-xpubImportRetention).Unix()
+	for id, job := range xpubImportJobs {
+		finished := job.State == XpubImportDone || job.State == XpubImportFailed
+		if finished && job.UpdatedAt < cutoff {
+			delete(xpubImportJobs, id)
+		}
+	}
+}
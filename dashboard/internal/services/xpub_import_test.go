@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"dcrpulse/internal/rpc"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/hdkeychain/v3"
+)
+
+// useNetwork makes network the active one for the rest of the test.
+func useNetwork(t *testing.T, network string) {
+	t.Helper()
+	networkMu.Lock()
+	saved := networkVal
+	networkVal = network
+	networkMu.Unlock()
+	t.Cleanup(func() {
+		networkMu.Lock()
+		networkVal = saved
+		networkMu.Unlock()
+	})
+}
+
+// accountXpub derives the BIP44 account key m/44'/42'/account' of a fixed
+// seed on params.
+func accountXpub(t *testing.T, params *chaincfg.Params, account uint32) string {
+	t.Helper()
+	key, err := hdkeychain.NewMaster(bytes.Repeat([]byte{7}, 32), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []uint32{44, params.SLIP0044CoinType, account} {
+		if key, err = key.Child(i + hdkeychain.HardenedKeyStart); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return key.Neuter().String()
+}
+
+func TestPreviewXpub(t *testing.T) {
+	useNetwork(t, "mainnet")
+	saved := rpc.WalletGrpcClient
+	rpc.WalletGrpcClient = nil
+	t.Cleanup(func() { rpc.WalletGrpcClient = saved })
+	ctx := context.Background()
+
+	xpub := accountXpub(t, chaincfg.MainNetParams(), 2)
+	p, err := PreviewXpub(ctx, xpub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Network != "mainnet" || p.Depth != 3 || p.AccountIndex == nil || *p.AccountIndex != 2 {
+		t.Errorf("preview = %+v", p)
+	}
+	if len(p.Fingerprint) != 8 || len(p.Addresses) != xpubPreviewAddresses || p.Addresses[0][:2] != "Ds" {
+		t.Errorf("fingerprint %q, addresses %v", p.Fingerprint, p.Addresses)
+	}
+
+	if _, err := PreviewXpub(ctx, accountXpub(t, chaincfg.TestNet3Params(), 0)); !errors.Is(err, ErrXpubWrongNetwork) {
+		t.Errorf("testnet key: err = %v, want ErrXpubWrongNetwork", err)
+	}
+	if _, err := PreviewXpub(ctx, xpub[:len(xpub)-1]); !errors.Is(err, ErrInvalidXpub) {
+		t.Errorf("truncated key: err = %v, want ErrInvalidXpub", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package types
+
+// APIResponse is the envelope every JSON API response is wrapped in. Error is
+// nil on success. On failure Error is set, and Data is nil unless the handler
+// has a structured body to return alongside it (respondErrorData), in which
+// case both are set.
+// Meta, when present, is endpoint specific (PageMeta for paginated lists).
+type APIResponse struct {
+	Data  interface{} `json:"data"`
+	Error *APIError   `json:"error"`
+	Meta  interface{} `json:"meta,omitempty"`
+	// NodeSyncing warns that the data was read from dcrd while it was still
+	// in initial block download, so it reflects an incomplete chain.
+	NodeSyncing bool `json:"nodeSyncing,omitempty"`
+}
+
+// APIError is a failed request's machine-readable code and human-readable
+// message.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PageMeta carries pagination for list responses.
+type PageMeta struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// DashboardMeta names the dashboard sections that could not be fetched, with
+// each one's error. The sections themselves are left zero-valued.
+type DashboardMeta struct {
+	FailedSections []string          `json:"failedSections"`
+	Errors         map[string]string `json:"errors,omitempty"`
+}
@@ -18,6 +18,17 @@ type BlockSummary struct {
 	Difficulty    float64   `json:"difficulty"`
 }
 
+// BlockHeaderSummary is the part of a block header charts need, as served by
+// /api/explorer/blocks/headers.
+type BlockHeaderSummary struct {
+	Height     int64   `json:"height"`
+	Hash       string  `json:"hash"`
+	Time       int64   `json:"time"` // Unix
+	Difficulty float64 `json:"difficulty"`
+	PoolSize   uint32  `json:"poolSize"` // Live tickets
+	Size       uint32  `json:"size"`     // Block size in bytes
+}
+
 // BlockDetail for detailed block view
 type BlockDetail struct {
 	BlockSummary
@@ -57,6 +68,63 @@ type TransactionDetail struct {
 	PoliteiaKey    string            `json:"politeiaKey,omitempty"`    // Politeia key from OP_RETURN
 	RecipientCount int               `json:"recipientCount,omitempty"` // Number of treasury payout recipients
 	VotingInfo     *TSpendVotingInfo `json:"votingInfo,omitempty"`     // Voting data for tspend transactions
+	// Classification is the consensus transaction type with its type-specific
+	// details; Type above keeps the explorer's list categories.
+	Classification *TxClassification `json:"classification,omitempty"`
+	// CoinbaseBreakdown is set for mined coinbase and treasurybase
+	// transactions.
+	CoinbaseBreakdown *CoinbaseBreakdown `json:"coinbaseBreakdown,omitempty"`
+}
+
+// CoinbaseBreakdown labels where a coinbase or treasurybase transaction's
+// newly minted coins went, against the subsidy schedule at its height.
+// Amounts are in DCR. Only once the treasury agenda is active does the
+// treasury subsidy flow into the treasury account, through the treasurybase,
+// at its full share regardless of the votes.
+type CoinbaseBreakdown struct {
+	Height          int64            `json:"height"`
+	Voters          int              `json:"voters"` // Votes in the block, which scale the PoW and PoS subsidy
+	Outputs         []CoinbaseOutput `json:"outputs"`
+	WorkSubsidy     float64          `json:"workSubsidy"`     // PoW subsidy; coinbase only
+	Fees            float64          `json:"fees"`            // Work outputs above the PoW subsidy; coinbase only
+	TreasuryActive  bool             `json:"treasuryActive"`  // Before activation the coinbase paid the treasury subsidy to the organization's address
+	TreasurySubsidy float64          `json:"treasurySubsidy"` // Scheduled treasury subsidy
+	StakeReward     float64          `json:"stakeReward"`     // PoS subsidy, paid by the block's votes rather than this transaction
+	TotalSubsidy    float64          `json:"totalSubsidy"`
+}
+
+// CoinbaseOutput is one labelled output of a CoinbaseBreakdown.
+type CoinbaseOutput struct {
+	Index   uint32  `json:"index"`
+	Role    string  `json:"role"` // work_reward, treasury, nulldata
+	Value   float64 `json:"value"`
+	Address string  `json:"address,omitempty"`
+}
+
+// TxClassification identifies a transaction's consensus type. Only the fields
+// relevant to Kind are set.
+type TxClassification struct {
+	Kind  string `json:"kind"` // regular, coinbase, ticket, vote, revocation, treasurybase, treasuryadd, tspend
+	Label string `json:"label"`
+	// tspend
+	Payees []TSpendPayee `json:"payees,omitempty"`
+	// vote and revocation: the ticket being spent
+	TicketHash string `json:"ticketHash,omitempty"`
+	// vote: the block the vote approves and its vote bits
+	VotedBlockHash   string `json:"votedBlockHash,omitempty"`
+	VotedBlockHeight int64  `json:"votedBlockHeight,omitempty"`
+	VoteBits         uint16 `json:"voteBits,omitempty"`
+	// ticket
+	TicketPrice float64 `json:"ticketPrice,omitempty"`
+	// treasuryadd
+	TreasuryAddAmount float64 `json:"treasuryAddAmount,omitempty"`
+}
+
+// TSpendPayee is one treasury spend recipient.
+type TSpendPayee struct {
+	Address     string  `json:"address"`
+	Amount      float64 `json:"amount"`
+	AmountAtoms int64   `json:"amountAtoms"`
 }
 
 // TSpendVotingInfo contains voting data for a treasury spend transaction
@@ -67,6 +135,9 @@ type TSpendVotingInfo struct {
 	NoVotes          int       `json:"noVotes"`          // Number of no votes
 	EligibleVotes    int       `json:"eligibleVotes"`    // Total possible votes in period
 	VotesCast        int       `json:"votesCast"`        // Total votes cast
+	AbstainVotes     int       `json:"abstainVotes"`     // Votes in the window that didn't vote on this tspend
+	MissedVotes      int       `json:"missedVotes"`      // Vote slots in the window no ticket filled
+	Revocations      int       `json:"revocations"`      // Ticket revocations mined in the window
 	QuorumRequired   int       `json:"quorumRequired"`   // Minimum votes needed
 	ApprovalRate     float64   `json:"approvalRate"`     // Yes / (Yes + No)
 	TurnoutRate      float64   `json:"turnoutRate"`      // VotesCast / EligibleVotes
@@ -75,6 +146,29 @@ type TSpendVotingInfo struct {
 	InMempool        bool      `json:"inMempool"`
 	VotingStartTime  time.Time `json:"votingStartTime"`
 	VotingEndTime    time.Time `json:"votingEndTime"`
+	// Mempool tspends only: the tally so far extrapolated to the end of the
+	// voting window, assuming votes keep arriving at the same rate.
+	FirstSeenHeight       int64   `json:"firstSeenHeight,omitempty"`       // Tip height when the tspend was first seen in the mempool
+	ElapsedBlocks         int64   `json:"elapsedBlocks,omitempty"`         // Blocks counted so far
+	WindowBlocks          int64   `json:"windowBlocks,omitempty"`          // Blocks in the full voting window
+	WindowProgress        float64 `json:"windowProgress,omitempty"`        // ElapsedBlocks / WindowBlocks * 100
+	ProjectedVotesCast    int     `json:"projectedVotesCast,omitempty"`    // Expected votes by window end
+	ProjectedTurnoutRate  float64 `json:"projectedTurnoutRate,omitempty"`  // Expected turnout by window end
+	ProjectedApprovalRate float64 `json:"projectedApprovalRate,omitempty"` // Expected approval by window end
+	// PassProjection, for mempool tspends, is the verdict if voting ended now.
+	PassProjection *TSpendPassProjection `json:"passProjection,omitempty"`
+}
+
+// TSpendPassProjection checks a tspend's tally so far against the DCP-0006
+// rules dcrd applies when it is mined: votes cast (yes + no) must reach a
+// quorum of the voting window's vote slots, and yes votes the required share
+// of those cast.
+type TSpendPassProjection struct {
+	WouldPassNow      bool    `json:"wouldPassNow"`
+	Quorum            int     `json:"quorum"`            // Votes cast needed
+	QuorumVotesNeeded int     `json:"quorumVotesNeeded"` // More votes needed to reach Quorum
+	RequiredApproval  float64 `json:"requiredApproval"`  // Yes share of votes cast needed, in percent
+	YesVotesNeeded    int     `json:"yesVotesNeeded"`    // More yes votes needed for the required share, if no more no votes come in
 }
 
 // TxInput represents a transaction input
@@ -106,17 +200,40 @@ type TxOutput struct {
 type Script struct {
 	Asm       string   `json:"asm"`
 	Hex       string   `json:"hex"`
-	Type      string   `json:"type"`
+	Type      string   `json:"type"` // dcrd's script type
 	ReqSigs   int      `json:"reqSigs,omitempty"`
 	Addresses []string `json:"addresses,omitempty"`
+	// Class is the coarse kind of script (p2pkh, p2sh, ticket, treasury-gen,
+	// nulldata, ...) and Label a readable name for it.
+	Class    string           `json:"class"`
+	Label    string           `json:"label"`
+	NullData *NullDataPayload `json:"nullData,omitempty"`
+}
+
+// NullDataPayload is the data an OP_RETURN output carries. Kind is
+// "treasury-votes", "text" (Text holds it) or "binary".
+type NullDataPayload struct {
+	Hex  string `json:"hex"`
+	Size int    `json:"size"`
+	Kind string `json:"kind"`
+	Text string `json:"text,omitempty"`
 }
 
-// SearchResult for universal search
+// SearchResult for universal search. Type, Found and Data describe the first
+// match; Matches lists every one (a hash can match a transaction and a block).
 type SearchResult struct {
-	Type  string      `json:"type"` // block, transaction, address, unknown
-	Found bool        `json:"found"`
-	Data  interface{} `json:"data,omitempty"`
-	Error string      `json:"error,omitempty"`
+	Type    string        `json:"type"` // block, transaction, address, unknown
+	Kind    string        `json:"kind"` // detected input: height, hash, address, unknown
+	Found   bool          `json:"found"`
+	Data    interface{}   `json:"data,omitempty"`
+	Matches []SearchMatch `json:"matches"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// SearchMatch is one object a search query resolved to.
+type SearchMatch struct {
+	Type string      `json:"type"` // block, transaction, address
+	Data interface{} `json:"data"`
 }
 
 // AddressInfo for address detail view
@@ -126,6 +243,49 @@ type AddressInfo struct {
 	Exists   bool     `json:"exists"`
 	Tickets  []string `json:"tickets"`
 	HasIndex bool     `json:"hasIndex"` // whether address indexing is enabled
+	// ScriptType is the type of the script paying to the address as dcrd
+	// names it, with its class and label as in Script.
+	ScriptType  string `json:"scriptType,omitempty"`
+	ScriptClass string `json:"scriptClass,omitempty"`
+	ScriptLabel string `json:"scriptLabel,omitempty"`
+}
+
+// AddressTrace is the graph of transactions that funds paid to an address
+// moved through, following spends forward from a root transaction.
+type AddressTrace struct {
+	Address         string      `json:"address"`
+	Root            string      `json:"root"` // txid whose outputs to Address start the trace
+	Depth           int         `json:"depth"`
+	Nodes           []TraceNode `json:"nodes"`
+	Edges           []TraceEdge `json:"edges"`
+	BlocksScanned   int         `json:"blocksScanned"`
+	Truncated       bool        `json:"truncated"`
+	TruncatedReason string      `json:"truncatedReason,omitempty"`
+}
+
+// TraceNode is one transaction in an AddressTrace. Depth 0 is the root.
+type TraceNode struct {
+	TxID        string        `json:"txid"`
+	Depth       int           `json:"depth"`
+	BlockHeight int64         `json:"blockHeight"` // 0 while in mempool
+	Outputs     []TraceOutput `json:"outputs"`
+}
+
+// TraceOutput is a transaction output and how far it was followed.
+type TraceOutput struct {
+	Vout      uint32   `json:"vout"`
+	Amount    float64  `json:"amount"`
+	Addresses []string `json:"addresses,omitempty"`
+	Status    string   `json:"status"` // unspent, spent, unresolved (spender not found), untraced (not followed)
+	SpentBy   string   `json:"spentBy,omitempty"`
+}
+
+// TraceEdge links an output to the transaction that spent it.
+type TraceEdge struct {
+	From   string  `json:"from"`
+	Vout   uint32  `json:"vout"`
+	To     string  `json:"to"`
+	Amount float64 `json:"amount"`
 }
 
 // PaginatedBlocksResponse for paginated block listings
@@ -143,3 +303,153 @@ type MempoolTransactions struct {
 	Count        int                  `json:"count"`
 	Size         uint64               `json:"size"`
 }
+
+// TicketStage is one step of a ticket's lifecycle. Height is projected for a
+// stage that has not been reached yet; Time is only set once it has.
+type TicketStage struct {
+	Height    int64  `json:"height"`
+	Time      int64  `json:"time,omitempty"`
+	BlockHash string `json:"blockHash,omitempty"`
+	TxHash    string `json:"txHash,omitempty"`
+	Reached   bool   `json:"reached"`
+}
+
+// TicketVote is the vote stage of a ticket, with what the vote approved.
+type TicketVote struct {
+	TicketStage
+	VotedBlockHash   string `json:"votedBlockHash"`
+	VotedBlockHeight int64  `json:"votedBlockHeight"`
+	VoteBits         uint16 `json:"voteBits"`
+	VoteVersion      uint32 `json:"voteVersion"`
+}
+
+// TicketLifecycle describes a ticket from purchase to vote or revocation.
+type TicketLifecycle struct {
+	TicketHash  string       `json:"ticketHash"`
+	Status      string       `json:"status"` // unmined, immature, live, voted, missed, expired, unknown (spent, spender not found)
+	Price       float64      `json:"price"`
+	Purchase    TicketStage  `json:"purchase"`
+	Maturity    TicketStage  `json:"maturity"`
+	Expiry      TicketStage  `json:"expiry"`
+	Vote        *TicketVote  `json:"vote,omitempty"`
+	Revocation  *TicketStage `json:"revocation,omitempty"`
+	SpenderHash string       `json:"spenderHash,omitempty"`
+	Note        string       `json:"note,omitempty"`
+}
+
+// MempoolTxEvent is pushed on the mempool stream for each transaction dcrd
+// accepts into mempool.
+type MempoolTxEvent struct {
+	TxID     string  `json:"txid"`
+	Size     int     `json:"size"`     // bytes
+	FeeAtoms int64   `json:"feeAtoms"` // 0 for stake transactions that pay no fee
+	FeeRate  float64 `json:"feeRate"`  // DCR/kB
+	Type     string  `json:"type"`     // TxClassification kind
+	Label    string  `json:"label"`
+	Received int64   `json:"received"` // Unix seconds
+}
+
+// AddressEvent is pushed on an address stream for each transaction that pays
+// to or spends from the watched address.
+type AddressEvent struct {
+	Address       string  `json:"address"`
+	TxID          string  `json:"txid"`
+	Direction     string  `json:"direction"` // "received" or "sent"
+	Amount        float64 `json:"amount"`    // DCR; sends are net of change back to the address
+	Status        string  `json:"status"`    // "mempool" or "confirmed"
+	Confirmations int64   `json:"confirmations"`
+	BlockHeight   int64   `json:"blockHeight,omitempty"`
+	BlockHash     string  `json:"blockHash,omitempty"`
+	Time          int64   `json:"time"` // Unix seconds; block time once confirmed
+}
+
+// VoteHistory lists the votes cast between FromHeight and ToHeight by tickets
+// whose vote rewards pay Address.
+type VoteHistory struct {
+	Address    string       `json:"address"`
+	FromHeight int64        `json:"fromHeight"`
+	ToHeight   int64        `json:"toHeight"`
+	Votes      []VoteRecord `json:"votes"`
+}
+
+// VoteRecord is one vote (SSGen) and the choices it carried.
+type VoteRecord struct {
+	TxHash         string             `json:"txHash"`
+	TicketHash     string             `json:"ticketHash"`
+	BlockHeight    int64              `json:"blockHeight"`
+	BlockHash      string             `json:"blockHash"`
+	Timestamp      int64              `json:"timestamp"` // block unix time
+	VoteBits       uint16             `json:"voteBits"`
+	VoteVersion    uint32             `json:"voteVersion"`
+	ApprovesParent bool               `json:"approvesParent"` // Vote bit 0: the previous block's regular tree is valid
+	Agendas        []AgendaVoteChoice `json:"agendas,omitempty"`
+	TSpends        []TSpendVoteChoice `json:"tspends,omitempty"`
+}
+
+// AgendaVoteChoice is the choice a vote's bits select on one agenda.
+type AgendaVoteChoice struct {
+	AgendaID string `json:"agendaId"`
+	Choice   string `json:"choice"`
+}
+
+// TSpendVoteChoice is a vote's choice on one treasury spend: yes, no or
+// invalid.
+type TSpendVoteChoice struct {
+	TSpendHash string `json:"tspendHash"`
+	Choice     string `json:"choice"`
+}
+
+// BlockVotes is every vote in one block as the vote counters read it, for
+// checking their parsing against another explorer. ActiveTSpends are the
+// known tspends whose voting window covers the block.
+type BlockVotes struct {
+	Height        int64            `json:"height"`
+	Hash          string           `json:"hash"`
+	Timestamp     int64            `json:"timestamp"`
+	ActiveTSpends []string         `json:"activeTSpends"`
+	Votes         []BlockVoteEntry `json:"votes"`
+}
+
+// BlockVoteEntry is one vote of a block. TSpends lists the entries of its
+// treasury vote output as decoded; TSpendVotes is its choice on each active
+// tspend, abstain included, as counted towards that tspend's tally.
+type BlockVoteEntry struct {
+	VoteRecord
+	TSpendVotes []TSpendVoteChoice `json:"tspendVotes"`
+}
+
+// BlockTransaction is one entry of a block's transaction listing. Kind and
+// Label come from the same classifier as TransactionDetail.Classification.
+type BlockTransaction struct {
+	TxID     string  `json:"txid"`
+	Tree     string  `json:"tree"` // "regular" or "stake"
+	Kind     string  `json:"kind"`
+	Label    string  `json:"label"`
+	TotalIn  float64 `json:"totalIn"`
+	TotalOut float64 `json:"totalOut"`
+	Fee      float64 `json:"fee"` // Zero for coinbase and treasurybase
+	Size     int     `json:"size"`
+}
+
+// BlockTransactions is one page of a block's transactions, regular tree
+// first, then stake tree, in block order.
+type BlockTransactions struct {
+	BlockHash    string             `json:"blockHash"`
+	BlockHeight  int64              `json:"blockHeight"`
+	Transactions []BlockTransaction `json:"transactions"`
+	Offset       int                `json:"offset"`
+	Limit        int                `json:"limit"`
+	Total        int                `json:"total"`
+	RegularCount int                `json:"regularCount"`
+	StakeCount   int                `json:"stakeCount"`
+}
+
+// BlockEvent is pushed on the block stream: "block" for each block
+// connected to the main chain, "reorg" for each block a reorg removed from
+// it. A reorg event carries what the treasury scan results rolled back.
+type BlockEvent struct {
+	Type     string            `json:"type"` // "block" or "reorg"
+	Height   int64             `json:"height"`
+	Hash     string            `json:"hash"`
+	Rollback *TreasuryRollback `json:"rollback,omitempty"`
+}
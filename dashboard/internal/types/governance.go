@@ -63,6 +63,30 @@ type SetTSpendPolicyRequest struct {
 	Passphrase string `json:"passphrase"`
 }
 
+// VotingPolicy is everything the wallet's tickets vote with: its choice on
+// each agenda, and its treasury key and per-TSpend policies.
+type VotingPolicy struct {
+	Agendas             []Agenda            `json:"agendas"`
+	TreasuryKeyPolicies []TreasuryKeyPolicy `json:"treasuryKeyPolicies"`
+	TSpendPolicies      []TSpendPolicy      `json:"tspendPolicies"`
+}
+
+// AgendaChoiceSetting is one agenda choice to set.
+type AgendaChoiceSetting struct {
+	AgendaID string `json:"agendaID"`
+	ChoiceID string `json:"choiceID"`
+}
+
+// SetVotingPolicyRequest is the body for the voting-policy update: any mix of
+// agenda choices and treasury key and TSpend policies, applied under one
+// unlock.
+type SetVotingPolicyRequest struct {
+	Agendas             []AgendaChoiceSetting `json:"agendas"`
+	TreasuryKeyPolicies []TreasuryKeyPolicy   `json:"treasuryKeyPolicies"`
+	TSpendPolicies      []TSpendPolicy        `json:"tspendPolicies"`
+	Passphrase          string                `json:"passphrase"`
+}
+
 // Proposal is the list-view shape for a Politeia proposal.
 type Proposal struct {
 	Token           string           `json:"token"`
@@ -189,3 +213,59 @@ type VoteTrickleStatus struct {
 	DurationSecs int64     `json:"durationSecs,omitempty"`
 	LastError    string    `json:"lastError,omitempty"`
 }
+
+// AgendaChoiceProgress is one choice's votes on an agenda in the current
+// rule change interval.
+type AgendaChoiceProgress struct {
+	ID        string  `json:"id"`
+	IsAbstain bool    `json:"isAbstain"`
+	IsNo      bool    `json:"isNo"`
+	Count     uint32  `json:"count"`
+	Progress  float64 `json:"progress"` // Share of the interval's non-abstain votes
+}
+
+// AgendaProgress is a consensus agenda still being voted on or locked in,
+// with its votes in the current rule change interval.
+type AgendaProgress struct {
+	ID             string                 `json:"id"`
+	Description    string                 `json:"description"`
+	Status         string                 `json:"status"` // "started" or "lockedin"
+	ExpireTime     int64                  `json:"expireTime"`
+	QuorumProgress float64                `json:"quorumProgress"`
+	Choices        []AgendaChoiceProgress `json:"choices"`
+}
+
+// RuleChangeInterval is the rule change interval agenda votes are counted
+// in, as of the current block.
+type RuleChangeInterval struct {
+	StartHeight int64  `json:"startHeight"`
+	EndHeight   int64  `json:"endHeight"`
+	Quorum      uint32 `json:"quorum"`
+	TotalVotes  uint32 `json:"totalVotes"`
+}
+
+// TreasuryPolicyWindow is where the treasury expenditure policy stands for
+// the next block a TSpend can be mined in: the TSpend's outputs are limited
+// by the treasury's activity over the WindowBlocks blocks before it.
+type TreasuryPolicyWindow struct {
+	CurrentHeight  int64 `json:"currentHeight"`
+	NextTVIHeight  int64 `json:"nextTviHeight"`
+	BlocksUntilTVI int64 `json:"blocksUntilTvi"`
+	WindowBlocks   int64 `json:"windowBlocks"`
+	WindowStart    int64 `json:"windowStart"` // First block of the window
+	WindowEnd      int64 `json:"windowEnd"`   // Last block of the window, NextTVIHeight-1
+}
+
+// GovernanceDashboard gathers what /api/governance/dashboard serves. A
+// section that could not be fetched is left empty and named in the
+// response's DashboardMeta.
+type GovernanceDashboard struct {
+	TreasuryBalance      float64               `json:"treasuryBalance"`
+	TreasuryBalanceAtoms int64                 `json:"treasuryBalanceAtoms"`
+	TreasuryBalanceDCR   string                `json:"treasuryBalanceDcr"`
+	RecentFlow           []TreasuryFlowBucket  `json:"recentFlow"` // Last months of scanned activity
+	TSpends              []TSpendStanding      `json:"tspends"`
+	Agendas              []AgendaProgress      `json:"agendas"`
+	RuleChangeInterval   *RuleChangeInterval   `json:"ruleChangeInterval,omitempty"`
+	PolicyWindow         *TreasuryPolicyWindow `json:"policyWindow,omitempty"`
+}
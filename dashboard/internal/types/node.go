@@ -24,6 +24,19 @@ type NodeStatus struct {
 	Version      string  `json:"version"`
 	SyncPhase    string  `json:"syncPhase"`   // "headers" or "blocks"
 	SyncMessage  string  `json:"syncMessage"` // e.g., "Processed 36,000 headers in the last 30 seconds"
+	// TxIndexMissing is set once dcrd has been seen running without
+	// --txindex; TxIndexMessage explains what that limits.
+	TxIndexMissing bool   `json:"txIndexMissing,omitempty"`
+	TxIndexMessage string `json:"txIndexMessage,omitempty"`
+	// RPC* report dcrpulse's own load on dcrd: calls running, calls waiting
+	// for a slot, and the DCRD_RPC_MAX_CONCURRENT limit.
+	RPCInFlight int `json:"rpcInFlight"`
+	RPCQueued   int `json:"rpcQueued"`
+	RPCLimit    int `json:"rpcLimit"`
+	// NodeSyncing is dcrd's initialblockdownload: while it is set, chain
+	// and treasury responses carry a nodeSyncing warning.
+	NodeSyncing          bool    `json:"nodeSyncing"`
+	VerificationProgress float64 `json:"verificationProgress"` // 0-1
 }
 
 type BlockchainInfo struct {
@@ -33,6 +46,52 @@ type BlockchainInfo struct {
 	ChainSize    int64         `json:"chainSize"`
 	BlockTime    string        `json:"blockTime"`
 	RecentBlocks []RecentBlock `json:"recentBlocks"`
+
+	// Passed through from getblockchaininfo.
+	Chain                string  `json:"chain"`
+	Headers              int64   `json:"headers"`
+	SyncHeight           int64   `json:"syncHeight"`
+	ChainWork            string  `json:"chainWork"`
+	VerificationProgress float64 `json:"verificationProgress"`
+	InitialBlockDownload bool    `json:"initialBlockDownload"`
+
+	// Derived from the tip's headers. MedianTime is the median timestamp of
+	// the last 11 blocks (Unix), the reference for time locks. The Next*
+	// fields count blocks to the next work and stake difficulty change and
+	// estimate the seconds until then at the target block time.
+	MedianTime           int64   `json:"medianTime"`
+	NextWorkDiffBlocks   int64   `json:"nextWorkDiffBlocks"`
+	NextWorkDiffSeconds  int64   `json:"nextWorkDiffSeconds"`
+	NextStakeDiffBlocks  int64   `json:"nextStakeDiffBlocks"`
+	NextStakeDiffSeconds int64   `json:"nextStakeDiffSeconds"`
+	SyncPercent          float64 `json:"syncPercent"`
+	SyncPercentText      string  `json:"syncPercentText"` // e.g. "99.97%"
+}
+
+// BlockchainTip is dcrd's best block. Time and MedianTime are Unix
+// timestamps; MedianTime is the median of the last 11 blocks' times.
+type BlockchainTip struct {
+	Height     int64  `json:"height"`
+	Hash       string `json:"hash"`
+	Time       int64  `json:"time"`
+	MedianTime int64  `json:"medianTime"`
+}
+
+// TicketPool is the live ticket pool at a block and the DCR locked in it,
+// served by /api/blockchain/ticketpool.
+type TicketPool struct {
+	Height             int64   `json:"height"`
+	Hash               string  `json:"hash"`
+	Size               uint32  `json:"size"`               // Live tickets
+	ValueAtoms         int64   `json:"valueAtoms"`         // DCR locked in the live tickets, in atoms
+	Value              float64 `json:"value"`              // ValueAtoms in DCR
+	ValueDCR           string  `json:"valueDcr"`           // ValueAtoms as an exact DCR decimal string
+	TicketPrice        float64 `json:"ticketPrice"`        // Current ticket price in DCR
+	AverageTicketPrice float64 `json:"averageTicketPrice"` // Value / Size
+	// Approximate is set when Value is Size times TicketPrice rather than the
+	// sum of the live tickets' purchase prices, which dcrd can't give during
+	// initial block download.
+	Approximate bool `json:"approximate"`
 }
 
 type RecentBlock struct {
@@ -41,12 +100,70 @@ type RecentBlock struct {
 	Timestamp int64  `json:"timestamp"` // Unix timestamp
 }
 
+// NodeInfo is the software dcrd runs and how it is connected, with
+// dcrpulse's own build. dcrd doesn't report its uptime; MinUptimeSeconds is
+// the age of its oldest peer connection, a lower bound, and is omitted with
+// no peers.
+type NodeInfo struct {
+	Version          string    `json:"version"`        // dcrd release, e.g. 2.0.6
+	JSONRPCVersion   string    `json:"jsonrpcVersion"` // dcrd JSON-RPC API
+	UserAgent        string    `json:"userAgent"`
+	ProtocolVersion  int32     `json:"protocolVersion"`
+	Connections      int32     `json:"connections"`
+	RelayFee         float64   `json:"relayFee"` // DCR/kB
+	TimeOffset       int64     `json:"timeOffset"`
+	LocalServices    string    `json:"localServices"`
+	MinUptimeSeconds int64     `json:"minUptimeSeconds,omitempty"`
+	MinUptime        string    `json:"minUptime,omitempty"`
+	Dcrpulse         BuildInfo `json:"dcrpulse"`
+}
+
+// BuildInfo is a binary's version and, for a build from a checkout, its VCS
+// commit.
+type BuildInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	CommitTime string `json:"commitTime,omitempty"`
+	Modified   bool   `json:"modified,omitempty"` // Built with uncommitted changes
+	GoVersion  string `json:"goVersion"`
+}
+
 type NetworkInfo struct {
 	PeerCount     int     `json:"peerCount"`
 	Hashrate      string  `json:"hashrate"`
 	NetworkHashPS float64 `json:"networkHashPS"`
 }
 
+// ActiveNetwork describes the network dcrd is running on and the parameters
+// dcrpulse derived from it.
+type ActiveNetwork struct {
+	Network                  string `json:"network"`    // mainnet, testnet, simnet
+	ParamsName               string `json:"paramsName"` // chaincfg name, e.g. testnet3
+	AddressPrefix            string `json:"addressPrefix"`
+	DefaultPort              string `json:"defaultPort"`
+	TreasuryActivationHeight int64  `json:"treasuryActivationHeight"`
+	TreasuryVoteInterval     int64  `json:"treasuryVoteInterval"`
+	TreasuryVoteWindow       int64  `json:"treasuryVoteWindow"`
+}
+
+// ConsensusParams are the consensus parameters dcrpulse uses for the detected
+// network, as the server currently has them.
+type ConsensusParams struct {
+	Network                        string `json:"network"`    // mainnet, testnet, simnet
+	ParamsName                     string `json:"paramsName"` // chaincfg name, e.g. testnet3
+	TreasuryActivationHeight       int64  `json:"treasuryActivationHeight"`
+	TreasuryVoteInterval           uint64 `json:"treasuryVoteInterval"`
+	TreasuryVoteIntervalMultiplier uint64 `json:"treasuryVoteIntervalMultiplier"`
+	TreasuryVoteWindow             int64  `json:"treasuryVoteWindow"`
+	// TreasuryError is set when the treasury params couldn't be resolved;
+	// the treasury fields are then zero.
+	TreasuryError         string `json:"treasuryError,omitempty"`
+	TicketMaturity        uint16 `json:"ticketMaturity"`
+	StakeValidationHeight int64  `json:"stakeValidationHeight"`
+	CoinbaseMaturity      uint16 `json:"coinbaseMaturity"`
+	TargetTimePerBlock    int64  `json:"targetTimePerBlock"` // seconds
+}
+
 type Peer struct {
 	ID         int    `json:"id"`
 	Address    string `json:"address"`
@@ -58,6 +175,73 @@ type Peer struct {
 	IsSyncNode bool   `json:"isSyncNode"`
 	Inbound    bool   `json:"inbound"`
 	Tor        bool   `json:"tor"`
+
+	// Raw values behind the formatted fields above, for sorting.
+	PingMs      float64 `json:"pingMs"`
+	BytesSent   uint64  `json:"bytesSent"`
+	BytesRecv   uint64  `json:"bytesRecv"`
+	ConnectedAt int64   `json:"connectedAt"` // Unix seconds
+}
+
+// PeerStats aggregates every connected peer, regardless of any filter.
+type PeerStats struct {
+	Total          int     `json:"total"`
+	Inbound        int     `json:"inbound"`
+	Outbound       int     `json:"outbound"`
+	TotalBytesSent uint64  `json:"totalBytesSent"`
+	TotalBytesRecv uint64  `json:"totalBytesRecv"`
+	MedianPingMs   float64 `json:"medianPingMs"`
+}
+
+// PeerList is the /network/peers response when any filter, sort or limit is
+// requested.
+type PeerList struct {
+	Peers []Peer    `json:"peers"`
+	Stats PeerStats `json:"stats"`
+}
+
+// NetworkSummary aggregates the connected peers for network health: release
+// adoption, bandwidth by direction and advertised services.
+type NetworkSummary struct {
+	PeerCount int `json:"peerCount"`
+	// LatestVersion is the newest dcrd release any peer runs, with how many
+	// peers (and what percent of them) run it.
+	LatestVersion string               `json:"latestVersion"`
+	LatestCount   int                  `json:"latestCount"`
+	LatestPercent float64              `json:"latestPercent"`
+	Versions      []PeerVersionBucket  `json:"versions"`  // newest first
+	Protocols     []PeerProtocolBucket `json:"protocols"` // newest first
+	Inbound       PeerBandwidth        `json:"inbound"`
+	Outbound      PeerBandwidth        `json:"outbound"`
+	Services      []PeerServiceCount   `json:"services"`
+}
+
+// PeerVersionBucket counts the peers running one dcrd release ("unknown"
+// for a user agent that isn't dcrd's), with the user agents seen.
+type PeerVersionBucket struct {
+	Version    string   `json:"version"`
+	Count      int      `json:"count"`
+	Percent    float64  `json:"percent"`
+	UserAgents []string `json:"userAgents"`
+}
+
+// PeerProtocolBucket counts the peers speaking one wire protocol version.
+type PeerProtocolBucket struct {
+	Version uint32 `json:"version"`
+	Count   int    `json:"count"`
+}
+
+// PeerBandwidth totals the traffic with the peers of one direction.
+type PeerBandwidth struct {
+	Peers     int    `json:"peers"`
+	BytesSent uint64 `json:"bytesSent"`
+	BytesRecv uint64 `json:"bytesRecv"`
+}
+
+// PeerServiceCount counts the peers advertising a service flag.
+type PeerServiceCount struct {
+	Flag  string `json:"flag"` // wire's name, e.g. SFNodeNetwork
+	Count int    `json:"count"`
 }
 
 type SupplyInfo struct {
@@ -95,3 +279,100 @@ type MempoolInfo struct {
 	RegularTxs     int     `json:"regularTxs"`  // Regular transactions (non-CoinJoin)
 	CoinJoinTxs    int     `json:"coinJoinTxs"` // CoinJoin/StakeShuffle transactions
 }
+
+// RPCEndpoint is one JSON-RPC server's connection settings as posted to
+// /api/connect. Cert is a path readable by dcrpulse; empty disables TLS.
+type RPCEndpoint struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Cert     string `json:"cert"`
+}
+
+// WalletEndpoint is dcrwallet's connection settings. The gRPC client dials
+// Host:GrpcPort with Cert (and the matching .key) for mutual TLS, so it is
+// only attempted when both are set.
+type WalletEndpoint struct {
+	RPCEndpoint
+	GrpcPort string `json:"grpcPort"`
+}
+
+// ConnectRequest is the body of POST /api/connect. Clients left out are not
+// touched.
+type ConnectRequest struct {
+	Dcrd   *RPCEndpoint    `json:"dcrd,omitempty"`
+	Wallet *WalletEndpoint `json:"wallet,omitempty"`
+}
+
+// ClientConnectResult is one client's outcome. Attempted is false when the
+// request didn't configure it.
+type ClientConnectResult struct {
+	Attempted bool   `json:"attempted"`
+	Connected bool   `json:"connected"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ConnectResponse reports each client's outcome and the network dcrd is on.
+// Success is set when every attempted client connected.
+type ConnectResponse struct {
+	Success    bool                `json:"success"`
+	Dcrd       ClientConnectResult `json:"dcrd"`
+	WalletRPC  ClientConnectResult `json:"walletRpc"`
+	WalletGrpc ClientConnectResult `json:"walletGrpc"`
+	Network    string              `json:"network,omitempty"`
+	// NetworkMismatch is set when the connected dcrd and dcrwallet turn out
+	// to be on different networks.
+	NetworkMismatch bool `json:"networkMismatch,omitempty"`
+}
+
+// DependencyStatus is one dependency's state in a readiness check.
+// LatencyMs is how long the probe call took when it was made.
+type DependencyStatus struct {
+	Configured bool   `json:"configured"`
+	Up         bool   `json:"up"`
+	LatencyMs  int64  `json:"latencyMs,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Readiness is the /api/readyz body. Ready requires only dcrd; the wallet is
+// reported for information.
+type Readiness struct {
+	Ready     bool             `json:"ready"`
+	Dcrd      DependencyStatus `json:"dcrd"`
+	WalletRPC DependencyStatus `json:"walletRpc"`
+}
+
+// NetworkMatch is which network dcrd and dcrwallet each run on, named after
+// the chain parameters ("" when unknown), and whether they differ.
+type NetworkMatch struct {
+	DcrdNetwork   string `json:"dcrdNetwork,omitempty"`
+	WalletNetwork string `json:"walletNetwork,omitempty"`
+	Mismatch      bool   `json:"networkMismatch"`
+}
+
+// Overview is the at-a-glance summary served by /api/overview. A section is
+// omitted when its backend is not connected or could not be reached.
+type Overview struct {
+	Node       *OverviewNode     `json:"node,omitempty"`
+	Wallet     *OverviewWallet   `json:"wallet,omitempty"`
+	Treasury   *OverviewTreasury `json:"treasury,omitempty"`
+	LastUpdate time.Time         `json:"lastUpdate"`
+}
+
+type OverviewNode struct {
+	Height      int64   `json:"height"`
+	SyncPercent float64 `json:"syncPercent"`
+	PeerCount   int64   `json:"peerCount"`
+}
+
+type OverviewWallet struct {
+	Synced       bool    `json:"synced"`
+	TotalBalance float64 `json:"totalBalance"` // DCR, all accounts
+}
+
+type OverviewTreasury struct {
+	BalanceAtoms  int64  `json:"balanceAtoms"`
+	BalanceDCR    string `json:"balanceDcr"`
+	ActiveTSpends int    `json:"activeTSpends"` // tspends voting in mempool
+}
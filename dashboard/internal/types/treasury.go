@@ -9,19 +9,31 @@ import "time"
 // TreasuryInfo represents the complete treasury status
 type TreasuryInfo struct {
 	Balance       float64         `json:"balance"`       // Current treasury balance in DCR
+	BalanceAtoms  int64           `json:"balanceAtoms"`  // Current treasury balance in atoms
+	BalanceDCR    string          `json:"balanceDcr"`    // Balance as an exact DCR decimal string
 	BalanceUSD    float64         `json:"balanceUsd"`    // USD equivalent (if available)
 	TotalAdded    float64         `json:"totalAdded"`    // Lifetime treasury additions
 	TotalSpent    float64         `json:"totalSpent"`    // Lifetime treasury expenditures
 	ActiveTSpends []TSpend        `json:"activeTSpends"` // TSpends currently in mempool
 	RecentTSpends []TSpendHistory `json:"recentTSpends"` // Recently approved TSpends
 	LastUpdate    time.Time       `json:"lastUpdate"`
+
+	// TotalSpent and TotalAdded are summed from the scan results: the
+	// TSpends found and the treasurybase inflow (TAdds are not scanned).
+	// TotalsComplete is set once the results cover every block since
+	// treasury activation; until then the totals are partial.
+	TotalSpentAtoms int64 `json:"totalSpentAtoms"`
+	TSpendCount     int   `json:"tspendCount"`
+	TotalsComplete  bool  `json:"totalsComplete"`
 }
 
 // TSpend represents an active treasury spend transaction in mempool
 type TSpend struct {
 	TxHash          string    `json:"txHash"`
-	Amount          float64   `json:"amount"`
-	Payee           string    `json:"payee"`           // Recipient address
+	Amount          float64   `json:"amount"`          // DCR, derived from AmountAtoms
+	AmountAtoms     int64     `json:"amountAtoms"`     // Sum of the paying outputs in atoms
+	AmountDCR       string    `json:"amountDcr"`       // AmountAtoms as an exact DCR decimal string
+	Payee           string    `json:"payee"`           // Recipient address of the last paying output
 	ExpiryHeight    int64     `json:"expiryHeight"`    // Block height when voting expires
 	CurrentHeight   int64     `json:"currentHeight"`   // Current blockchain height
 	BlocksRemaining int64     `json:"blocksRemaining"` // Blocks until expiry
@@ -29,35 +41,179 @@ type TSpend struct {
 	YesVotes        int64     `json:"yesVotes"`        // Yes votes so far (from gettreasuryspendvotes)
 	NoVotes         int64     `json:"noVotes"`         // No votes so far
 	DetectedAt      time.Time `json:"detectedAt"`
+	// EstimatedExpiry dates the expiry: BlocksRemaining blocks at the
+	// network's target block time from the last mempool refresh.
+	EstimatedExpiry *time.Time `json:"estimatedExpiry,omitempty"`
+	// Payees lists every paying output; AmountAtoms is their total.
+	Payees []TSpendPayee `json:"payees"`
+}
+
+// TSpendStanding is a mempool TSpend's vote so far, as served by
+// /api/treasury/mempool/standings.
+type TSpendStanding struct {
+	TxHash          string  `json:"txHash"`
+	AmountDCR       string  `json:"amountDcr"`
+	Payee           string  `json:"payee"`
+	ExpiryHeight    int64   `json:"expiryHeight"`
+	BlocksRemaining int64   `json:"blocksRemaining"` // Blocks until expiry
+	FirstSeenHeight int64   `json:"firstSeenHeight"` // Tip height when the TSpend was first seen; votes count from here
+	YesVotes        int     `json:"yesVotes"`
+	NoVotes         int     `json:"noVotes"`
+	ApprovalRate    float64 `json:"approvalRate"`   // Yes / (Yes + No), in percent
+	TurnoutRate     float64 `json:"turnoutRate"`    // Votes cast / vote slots counted, in percent
+	WindowProgress  float64 `json:"windowProgress"` // Share of the voting window elapsed, in percent
+	// Now is the verdict if voting ended now; ProjectedPass the verdict at
+	// the end of the window if votes keep coming at the same pace and split.
+	Now           *TSpendPassProjection `json:"now"`
+	ProjectedPass bool                  `json:"projectedPass"`
+}
+
+// MempoolTSpendMeta is the envelope meta of the mempool TSpend list. Passing
+// Token back as changedSince returns only what changed after it.
+type MempoolTSpendMeta struct {
+	Token         uint64   `json:"token"`
+	Full          bool     `json:"full"`              // Data is the full set rather than a delta
+	Removed       []string `json:"removed,omitempty"` // TSpends that left the mempool since changedSince
+	CurrentHeight int64    `json:"currentHeight"`
 }
 
 // BalanceSample is one point in the treasury balance-over-time series.
 type BalanceSample struct {
-	Height  int64   `json:"height"`
-	Time    int64   `json:"time"`    // block unix time
-	Balance float64 `json:"balance"` // treasury balance in DCR at that block
+	Height       int64   `json:"height"`
+	Time         int64   `json:"time"`         // block unix time
+	Balance      float64 `json:"balance"`      // treasury balance in DCR at that block
+	BalanceAtoms int64   `json:"balanceAtoms"` // treasury balance in atoms at that block
 }
 
 // TSpendHistory represents a historical approved treasury spend
 type TSpendHistory struct {
 	TxHash      string    `json:"txHash"`
-	Amount      float64   `json:"amount"`
-	Payee       string    `json:"payee"`       // Recipient address
+	Amount      float64   `json:"amount"`      // DCR, derived from AmountAtoms
+	AmountAtoms int64     `json:"amountAtoms"` // Sum of the paying outputs in atoms
+	AmountDCR   string    `json:"amountDcr"`   // AmountAtoms as an exact DCR decimal string
+	Payee       string    `json:"payee"`       // Recipient address of the last paying output
 	BlockHeight int64     `json:"blockHeight"` // Block where it was mined
 	BlockHash   string    `json:"blockHash"`
 	Timestamp   time.Time `json:"timestamp"`
 	VoteResult  string    `json:"voteResult"` // "approved"
+	// Payees lists every paying output, so a spend paying several
+	// recipients is attributed per output; AmountAtoms is their total.
+	Payees []TSpendPayee `json:"payees"`
+	// Proposal is the Politeia proposal the spend funds, when known.
+	Proposal *ProposalLink `json:"proposal,omitempty"`
+}
+
+// ProposalLink ties a tspend to a Politeia proposal. Method is "mapping" for
+// an entry in the configured mapping file and "heuristic" for a match on the
+// proposal's funding window; Title is empty while Politeia is unreachable.
+type ProposalLink struct {
+	Token  string `json:"token"`
+	Title  string `json:"title,omitempty"`
+	URL    string `json:"url"`
+	Method string `json:"method"`
+}
+
+// TreasuryPayee is one recipient's share of the scanned treasury spends.
+type TreasuryPayee struct {
+	Address     string    `json:"address"`
+	Total       float64   `json:"total"` // DCR, derived from TotalAtoms
+	TotalAtoms  int64     `json:"totalAtoms"`
+	TotalDCR    string    `json:"totalDcr"`
+	SpendCount  int       `json:"spendCount"` // Distinct tspends paying the address
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+	FirstTxHash string    `json:"firstTxHash"`
+	LastTxHash  string    `json:"lastTxHash"`
+}
+
+// TreasuryFlow is treasury activity from the scanned history, bucketed by
+// Interval in chronological order with no gaps between the first and last
+// bucket.
+type TreasuryFlow struct {
+	Interval string               `json:"interval"` // "month" or "week"
+	Buckets  []TreasuryFlowBucket `json:"buckets"`
+	// AddsTracked is false while only tspends are scanned, in which case
+	// every bucket's Added is 0.
+	AddsTracked bool `json:"addsTracked"`
+	// TreasuryBaseTotal is the block-reward inflow over the blocks the scan
+	// covered, the sum of every bucket's TreasuryBase.
+	TreasuryBaseTotal float64 `json:"treasuryBaseTotal"`
+}
+
+// TreasuryFlowBucket is one interval of treasury activity, in DCR. Sums are
+// taken in atoms and converted once, so they are exact.
+type TreasuryFlowBucket struct {
+	Start         time.Time `json:"start"`        // Bucket start, UTC
+	TreasuryBase  float64   `json:"treasuryBase"` // Block-reward inflow via treasurybase
+	Added         float64   `json:"added"`        // Treasury adds (TAdds)
+	Spent         float64   `json:"spent"`
+	Net           float64   `json:"net"`           // TreasuryBase + Added - Spent
+	CumulativeNet float64   `json:"cumulativeNet"` // Sum of Net from the first bucket; starts at 0, not the treasury balance
+	TSpendCount   int       `json:"tspendCount"`
 }
 
 // TSpendScanProgress tracks the progress of historical TSpend scanning
 type TSpendScanProgress struct {
-	IsScanning    bool            `json:"isScanning"`
-	CurrentHeight int64           `json:"currentHeight"`
-	TotalHeight   int64           `json:"totalHeight"`
-	Progress      float64         `json:"progress"`    // 0-100%
-	TSpendFound   int             `json:"tspendFound"` // Count of TSpends found so far
-	NewTSpends    []TSpendHistory `json:"newTSpends"`  // TSpends found since last progress check
-	Message       string          `json:"message"`
+	IsScanning                bool            `json:"isScanning"`
+	Mode                      string          `json:"mode,omitempty"` // full, recent or range
+	StartHeight               int64           `json:"startHeight"`
+	CurrentHeight             int64           `json:"currentHeight"`
+	TotalHeight               int64           `json:"totalHeight"`
+	Progress                  float64         `json:"progress"`    // 0-100%
+	TSpendFound               int             `json:"tspendFound"` // Count of TSpends found so far
+	NewTSpends                []TSpendHistory `json:"newTSpends"`  // TSpends found since last progress check
+	Message                   string          `json:"message"`
+	Cancelled                 bool            `json:"cancelled"`                 // last scan was stopped before reaching TotalHeight
+	Rate                      float64         `json:"rate"`                      // Chain blocks covered per second, averaged over the last 30s
+	EstimatedSecondsRemaining int             `json:"estimatedSecondsRemaining"` // 0 when unknown or not scanning
+	// FailedHeights lists blocks that could not be read after retries; the
+	// results miss any tspend in them until they are re-scanned.
+	FailedHeights []int64 `json:"failedHeights"`
+	// CoveredHeight is the last block the results cover without a break,
+	// which the auto-scan keeps at the tip; 0 until a full, recent or range
+	// scan has finished.
+	CoveredHeight int64 `json:"coveredHeight"`
+	// Version changes whenever the progress does; pass it back as since to
+	// the long-poll endpoint to wait for the next change.
+	Version uint64 `json:"version"`
+}
+
+// TSpendScanFound is a snapshot of the TSpends a running or finished scan
+// has found, as served by /api/treasury/scan-found.
+type TSpendScanFound struct {
+	IsScanning     bool            `json:"isScanning"`
+	Mode           string          `json:"mode,omitempty"`
+	StartHeight    int64           `json:"startHeight"`
+	ScannedThrough int64           `json:"scannedThrough"` // Last block read so far
+	EndHeight      int64           `json:"endHeight"`
+	Count          int             `json:"count"`
+	TSpends        []TSpendHistory `json:"tspends"` // In block order
+	// Version is the scan progress version the snapshot was taken at.
+	Version uint64 `json:"version"`
+}
+
+// TSpendScanEstimate is how long a historical scan would take, extrapolated
+// from timing a sample of its blocks.
+type TSpendScanEstimate struct {
+	Mode             string  `json:"mode"`
+	StartHeight      int64   `json:"startHeight"`
+	EndHeight        int64   `json:"endHeight"`
+	Blocks           int     `json:"blocks"` // Blocks the scan would read
+	SampledBlocks    int     `json:"sampledBlocks"`
+	SecondsPerBlock  float64 `json:"secondsPerBlock"`
+	EstimatedSeconds int64   `json:"estimatedSeconds"`
+	Estimated        string  `json:"estimated"` // e.g. "2h 15m"
+}
+
+// ScanResultsMeta is the envelope meta of the scan results. Count and the
+// totals cover the results returned, after any amount filter.
+type ScanResultsMeta struct {
+	FailedHeights []int64 `json:"failedHeights"` // Blocks the scan could not read
+	Complete      bool    `json:"complete"`      // No block was skipped
+	Count         int     `json:"count"`
+	Total         float64 `json:"total"` // DCR, derived from TotalAtoms
+	TotalAtoms    int64   `json:"totalAtoms"`
+	TotalDCR      string  `json:"totalDcr"` // TotalAtoms as an exact DCR decimal string
 }
 
 // VoteParsingProgress tracks progress of vote counting for a tspend
@@ -70,4 +226,167 @@ type VoteParsingProgress struct {
 	NoVotes       int     `json:"noVotes"`       // Current count
 	EstimatedTime int     `json:"estimatedTime"` // Seconds remaining
 	Message       string  `json:"message"`
+	Version       uint64  `json:"version"` // Changes whenever the progress does, for long polls
+}
+
+// TSpendBlockVotes is one block's votes on a tspend: Abstain counts the
+// block's votes that cast no choice on it.
+type TSpendBlockVotes struct {
+	Height  int64 `json:"height"`
+	Yes     int   `json:"yes"`
+	No      int   `json:"no"`
+	Abstain int   `json:"abstain"`
+}
+
+// WebhookEvent is the JSON body POSTed to configured webhook receivers.
+type WebhookEvent struct {
+	Event     string      `json:"event"`
+	Timestamp int64       `json:"timestamp"` // Unix seconds when the event fired
+	Data      interface{} `json:"data"`
+}
+
+// WebhookScanComplete is the payload of a tspend.scan_complete event.
+type WebhookScanComplete struct {
+	StartHeight int64 `json:"startHeight"`
+	EndHeight   int64 `json:"endHeight"`
+	TSpendFound int   `json:"tspendFound"`
+	// FailedHeights lists blocks the scan could not read, if any.
+	FailedHeights []int64 `json:"failedHeights,omitempty"`
+}
+
+// WebhookWalletTx is the payload of a wallet.tx event. BlockHeight and
+// BlockHash are empty for an unmined transaction; the same transaction fires
+// again once it is mined.
+type WebhookWalletTx struct {
+	TxHash      string `json:"txHash"`
+	Type        string `json:"type"`
+	CreditAtoms int64  `json:"creditAtoms"`
+	DebitAtoms  int64  `json:"debitAtoms"`
+	FeeAtoms    int64  `json:"feeAtoms"`
+	BlockHeight int32  `json:"blockHeight,omitempty"`
+	BlockHash   string `json:"blockHash,omitempty"`
+}
+
+// WebhookRescanComplete is the payload of a wallet.rescan_complete event.
+type WebhookRescanComplete struct {
+	RescannedThrough int32 `json:"rescannedThrough"`
+}
+
+// TSpendDetail is everything known about one treasury spend, for a detail
+// page. A mined tspend has a block and is approved; one still in the mempool
+// has BlocksRemaining until its expiry instead.
+type TSpendDetail struct {
+	TxHash          string            `json:"txHash"`
+	Status          string            `json:"status"` // "voting", "expired" or "approved"
+	InMempool       bool              `json:"inMempool"`
+	Amount          float64           `json:"amount"` // DCR, derived from AmountAtoms
+	AmountAtoms     int64             `json:"amountAtoms"`
+	AmountDCR       string            `json:"amountDcr"`
+	Payees          []TSpendPayee     `json:"payees"`
+	BlockHeight     int64             `json:"blockHeight,omitempty"`
+	BlockHash       string            `json:"blockHash,omitempty"`
+	BlockTime       *time.Time        `json:"blockTime,omitempty"`
+	Confirmations   int64             `json:"confirmations"`
+	ExpiryHeight    int64             `json:"expiryHeight"`
+	CurrentHeight   int64             `json:"currentHeight"`
+	BlocksRemaining int64             `json:"blocksRemaining,omitempty"` // Mempool only: blocks until expiry
+	PoliteiaKey     string            `json:"politeiaKey,omitempty"`     // 32-byte push of the OP_RETURN output
+	VotingInfo      *TSpendVotingInfo `json:"votingInfo,omitempty"`
+	Proposal        *ProposalLink     `json:"proposal,omitempty"`
+}
+
+// HeightRange is an inclusive span of block heights.
+type HeightRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// ScanHeightsRequest lists the blocks to re-scan for tspends: Heights are
+// scanned as given, Ranges only at their treasury-vote-interval blocks.
+type ScanHeightsRequest struct {
+	Heights []int64       `json:"heights"`
+	Ranges  []HeightRange `json:"ranges"`
+}
+
+// ScanHeightsResult reports a re-scan of specific heights. NewTSpends holds
+// only the tspends that were not already in the scan results.
+type ScanHeightsResult struct {
+	Scanned        int             `json:"scanned"` // Blocks visited
+	NewTSpendCount int             `json:"newTSpendCount"`
+	NewTSpends     []TSpendHistory `json:"newTSpends"`
+	FailedHeights  []int64         `json:"failedHeights"` // Requested blocks that still could not be read
+	Cancelled      bool            `json:"cancelled"`
+}
+
+// VoteCacheCompaction reports what applying the vote cache retention policy
+// pruned, as returned by /api/admin/compact.
+type VoteCacheCompaction struct {
+	CountsDropped     int `json:"countsDropped"`     // Counts removed entirely
+	BreakdownsDropped int `json:"breakdownsDropped"` // Per-block votes removed, totals kept
+	ProgressDropped   int `json:"progressDropped"`   // Finished progress entries removed
+	CountsKept        int `json:"countsKept"`
+}
+
+// TreasuryRollback is what a reorg took out of the treasury scan results:
+// the TSpends mined from FromHeight on in the orphaned blocks, and the
+// treasurybase runs ending there. RescanHeights are blocks a running scan
+// had already read, left for a heights re-scan.
+type TreasuryRollback struct {
+	FromHeight       int64    `json:"fromHeight"`
+	TSpends          []string `json:"tspends"`
+	TreasuryBaseRuns int      `json:"treasuryBaseRuns"`
+	RescanHeights    []int64  `json:"rescanHeights,omitempty"`
+}
+
+// TicketTSpendVote is how one ticket voted on one tspend. Status is "voted"
+// (Choice is yes, no, abstain or invalid), "not-voted" when the ticket was
+// live during the window but cast no vote in it, or "not-live" when it never
+// was. LiveFrom and LiveUntil are the blocks it could be called to vote in.
+type TicketTSpendVote struct {
+	TSpendHash  string `json:"tspendHash"`
+	TicketHash  string `json:"ticketHash"`
+	Status      string `json:"status"`
+	Choice      string `json:"choice,omitempty"`
+	VoteHash    string `json:"voteHash,omitempty"`
+	VoteHeight  int64  `json:"voteHeight,omitempty"`
+	WindowStart int64  `json:"windowStart"`
+	WindowEnd   int64  `json:"windowEnd"`
+	LiveFrom    int64  `json:"liveFrom,omitempty"`
+	LiveUntil   int64  `json:"liveUntil,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+// TreasuryScanSnapshot is the treasury scan results as exported by
+// /api/treasury/snapshot, for priming another instance without a scan. Hash
+// is the hex SHA-256 of the snapshot's JSON with Hash empty, an unkeyed
+// integrity checksum that catches corruption but not tampering; CoveredHash is
+// the block at CoveredHeight, checked against the importing node's chain.
+// CoveredFrom is the first block the results cover.
+type TreasuryScanSnapshot struct {
+	Version       int               `json:"version"`
+	Network       string            `json:"network"`
+	CoveredFrom   int64             `json:"coveredFrom"`
+	CoveredHeight int64             `json:"coveredHeight"`
+	CoveredHash   string            `json:"coveredHash,omitempty"`
+	TSpends       []TSpendHistory   `json:"tspends"`
+	TreasuryBase  []TreasuryBaseRun `json:"treasuryBase"`
+	FailedHeights []int64           `json:"failedHeights"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	Hash          string            `json:"hash"`
+}
+
+// TreasuryBaseRun is the treasurybase paid in over Blocks blocks ending at
+// Height, as the scan counted it.
+type TreasuryBaseRun struct {
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	Blocks int64     `json:"blocks"`
+	Atoms  int64     `json:"atoms"`
+}
+
+// TreasuryScanSnapshotImport reports a snapshot installed as the scan results.
+type TreasuryScanSnapshotImport struct {
+	TSpends          int   `json:"tspends"`
+	TreasuryBaseRuns int   `json:"treasuryBaseRuns"`
+	CoveredHeight    int64 `json:"coveredHeight"`
 }
@@ -102,12 +102,47 @@ type ImportXpubRequest struct {
 	// omitted value is distinguishable from a deliberate 0.
 	AccountIndex *uint32 `json:"accountIndex,omitempty"`
 	Rescan       bool    `json:"rescan"`
+	// ValidateOnly checks the xpub and previews the account it would create
+	// without importing it or rescanning.
+	ValidateOnly bool `json:"validateOnly,omitempty"`
 }
 
 type ImportXpubResponse struct {
 	Success    bool   `json:"success"`
 	Message    string `json:"message"`
 	AccountNum uint32 `json:"accountNum,omitempty"`
+	// JobID identifies the started import for /wallet/importxpub/status/{id}.
+	JobID string `json:"jobId,omitempty"`
+	// Preview is set instead of JobID for a ValidateOnly request.
+	Preview *XpubPreview `json:"preview,omitempty"`
+}
+
+// XpubPreview describes the account an xpub import would create.
+type XpubPreview struct {
+	Network     string `json:"network"`
+	Fingerprint string `json:"fingerprint"` // First 4 bytes of Hash160 of the public key, hex
+	Depth       uint16 `json:"depth"`       // 3 for an account key (m/44'/coin'/account')
+	// AccountIndex is the BIP44 account the key was derived at, for an
+	// account-level key.
+	AccountIndex *uint32  `json:"accountIndex,omitempty"`
+	Addresses    []string `json:"addresses"`            // First external addresses, index 0 up
+	ImportedAs   string   `json:"importedAs,omitempty"` // Account already holding this key
+}
+
+// XpubImportStatus is the progress of one xpub import: importxpub, then
+// address discovery, then the follow-up rescan from genesis.
+type XpubImportStatus struct {
+	ID          string `json:"id"`
+	AccountName string `json:"accountName"`
+	// State is importing, discovering, rescanning, done or failed.
+	State    string `json:"state"`
+	Imported bool   `json:"imported"`
+	// AccountNum is the dcrwallet account the import created, once known.
+	AccountNum    *uint32 `json:"accountNum,omitempty"`
+	RescanRunning bool    `json:"rescanRunning"`
+	Error         string  `json:"error,omitempty"`
+	StartedAt     int64   `json:"startedAt"`
+	UpdatedAt     int64   `json:"updatedAt"`
 }
 
 type NextAddressResponse struct {
@@ -314,3 +349,115 @@ type WalletStakingInfo struct {
 	BlocksUntilSubsidyReduction int64   `json:"blocksUntilSubsidyReduction"`
 	SubsidyReductionInterval    int64   `json:"subsidyReductionInterval"`
 }
+
+// WalletSyncPhase is the coarse wallet sync state shown to users.
+type WalletSyncPhase string
+
+const (
+	WalletSyncDisconnected WalletSyncPhase = "disconnected"
+	WalletSyncHeaders      WalletSyncPhase = "syncing-headers"
+	WalletSyncBlocks       WalletSyncPhase = "syncing-blocks"
+	WalletSyncRescanning   WalletSyncPhase = "rescanning"
+	WalletSyncSynced       WalletSyncPhase = "synced"
+)
+
+// WalletSyncETAState says whether EstimatedSecondsRemaining is meaningful.
+type WalletSyncETAState string
+
+const (
+	// WalletSyncETADone means the wallet is at or past the chain tip.
+	WalletSyncETADone WalletSyncETAState = "done"
+	// WalletSyncETAEstimated means the ETA comes from the recent height rate.
+	WalletSyncETAEstimated WalletSyncETAState = "estimated"
+	// WalletSyncETAUnknown means there are too few samples yet, or the
+	// wallet is in a phase that doesn't advance its height.
+	WalletSyncETAUnknown WalletSyncETAState = "unknown"
+	// WalletSyncETAStalled means the wallet height hasn't moved over the
+	// whole sampling window while behind the chain.
+	WalletSyncETAStalled WalletSyncETAState = "stalled"
+)
+
+// WalletSyncStatus is the pollable wallet sync state. DetailPhase is the
+// underlying RpcSync phase (e.g. fetching_cfilters) Phase was derived from.
+// BlocksPerSecond is how fast the wallet height advanced over the recent
+// window; EstimatedSecondsRemaining is only meaningful when ETAState is
+// estimated, and is zero otherwise.
+type WalletSyncStatus struct {
+	Synced                    bool               `json:"synced"`
+	Phase                     WalletSyncPhase    `json:"phase"`
+	DetailPhase               string             `json:"detailPhase"`
+	WalletHeight              int64              `json:"walletHeight"`
+	ChainHeight               int64              `json:"chainHeight"`
+	BlocksBehind              int64              `json:"blocksBehind"`
+	BlocksPerSecond           float64            `json:"blocksPerSecond"`
+	EstimatedSecondsRemaining int64              `json:"estimatedSecondsRemaining"`
+	ETAState                  WalletSyncETAState `json:"etaState"`
+}
+
+// WalletAddress is one address the wallet has derived for an account.
+type WalletAddress struct {
+	Address     string  `json:"address"`
+	Account     uint32  `json:"account"`
+	AccountName string  `json:"accountName"`
+	Branch      uint32  `json:"branch"` // 0 external, 1 internal (change)
+	Index       uint32  `json:"index"`
+	Used        bool    `json:"used"`     // Has received at least one output
+	Received    float64 `json:"received"` // DCR, total credited including unconfirmed
+	TxCount     int     `json:"txCount"`  // Transactions paying to the address
+}
+
+// WalletTransactionDetail is one wallet transaction broken down by input and
+// output, with what it did to each of the wallet's accounts. Amounts are in
+// DCR.
+type WalletTransactionDetail struct {
+	TxID          string               `json:"txid"`
+	TxType        string               `json:"txType"` // "regular", "ticket", "vote", "revocation", "coinbase"
+	Confirmations int32                `json:"confirmations"`
+	BlockHash     string               `json:"blockHash,omitempty"`
+	Time          int64                `json:"time"` // Unix; when the wallet first saw it
+	Size          int                  `json:"size"`
+	Fee           float64              `json:"fee"`
+	AmountSent    float64              `json:"amountSent"` // Paid away from the wallet, change excluded
+	ChangeAmount  float64              `json:"changeAmount"`
+	Net           float64              `json:"net"` // Credits less debits over all accounts
+	Inputs        []WalletTxInput      `json:"inputs"`
+	Outputs       []WalletTxOutput     `json:"outputs"`
+	Accounts      []WalletTxAccountNet `json:"accounts"`
+}
+
+// WalletTxInput is a transaction input. Account is set when the input spends
+// one of the wallet's outputs.
+type WalletTxInput struct {
+	Index       uint32  `json:"index"`
+	PrevTxID    string  `json:"prevTxid"`
+	PrevIndex   uint32  `json:"prevIndex"`
+	PrevTree    int8    `json:"prevTree"`
+	Amount      float64 `json:"amount"` // The prevout's value
+	Owned       bool    `json:"owned"`
+	Account     *uint32 `json:"account,omitempty"`
+	AccountName string  `json:"accountName,omitempty"`
+}
+
+// WalletTxOutput is a transaction output. Account is set when it pays the
+// wallet; Internal marks an address on an account's internal branch, and
+// Change such an output of a transaction the wallet funded.
+type WalletTxOutput struct {
+	Index       uint32  `json:"index"`
+	Amount      float64 `json:"amount"`
+	Address     string  `json:"address,omitempty"`
+	ScriptType  string  `json:"scriptType"`
+	Owned       bool    `json:"owned"`
+	Account     *uint32 `json:"account,omitempty"`
+	AccountName string  `json:"accountName,omitempty"`
+	Internal    bool    `json:"internal,omitempty"`
+	Change      bool    `json:"change"`
+}
+
+// WalletTxAccountNet is what a transaction spent from and paid to one account.
+type WalletTxAccountNet struct {
+	Account     uint32  `json:"account"`
+	AccountName string  `json:"accountName"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	Net         float64 `json:"net"`
+}
@@ -16,8 +16,9 @@ type WalletLoadedResponse struct {
 }
 
 // GenerateSeedRequest contains parameters for seed generation.
-// SeedLength is in BYTES (not words). Zero or unset -> dcrwallet's
-// recommended 32 bytes -> 33-word mnemonic (Decred standard).
+// SeedLength is in BYTES (not words): 16 to 64 in steps of 4, giving a
+// mnemonic of SeedLength+1 words. Zero or unset -> the recommended 32 bytes
+// -> 33-word mnemonic (Decred standard).
 type GenerateSeedRequest struct {
 	SeedLength uint32 `json:"seedLength,omitempty"`
 }
@@ -28,6 +29,30 @@ type GenerateSeedResponse struct {
 	SeedHex      string `json:"seedHex"`      // Hex-encoded seed
 }
 
+// VerifySeedRequest carries the user's re-entered backup of the generated
+// seed: either the whole Mnemonic, or a spot check of Words by index.
+type VerifySeedRequest struct {
+	Mnemonic string          `json:"mnemonic,omitempty"`
+	Words    []SeedWordCheck `json:"words,omitempty"`
+}
+
+// SeedWordCheck is one mnemonic word at its 0-based position.
+type SeedWordCheck struct {
+	Index int    `json:"index"`
+	Word  string `json:"word"`
+}
+
+// VerifySeedResponse reports which positions (0-based) did not match.
+// AttemptsLeft is how many more failed checks the seed survives; at 0 it has
+// been dropped and a new seed must be generated.
+type VerifySeedResponse struct {
+	Valid        bool  `json:"valid"`
+	WrongIndices []int `json:"wrongIndices"`
+	Checked      int   `json:"checked"`
+	WordCount    int   `json:"wordCount"`
+	AttemptsLeft int   `json:"attemptsLeft"`
+}
+
 // DecodeSeedRequest carries a user-supplied seed for validation.
 // UserInput accepts either a 33-word mnemonic OR a hex string.
 type DecodeSeedRequest struct {
@@ -53,6 +78,18 @@ type CreateWalletRequest struct {
 	AccountIndex             *uint32 `json:"accountIndex,omitempty"`   // Optional when WatchOnly: BIP44 index of ExtendedPubKey's device account (offline signing)
 }
 
+// RestoreWalletRequest restores a wallet from its seed words.
+type RestoreWalletRequest struct {
+	PublicPassphrase         string `json:"publicPassphrase"`         // Optional, as for CreateWalletRequest
+	ConfirmPublicPassphrase  string `json:"confirmPublicPassphrase"`  // Must equal PublicPassphrase when public is non-empty
+	PrivatePassphrase        string `json:"privatePassphrase"`        // Required: Encrypts private keys for spending
+	ConfirmPrivatePassphrase string `json:"confirmPrivatePassphrase"` // Must equal PrivatePassphrase
+	Mnemonic                 string `json:"mnemonic"`                 // Required: the seed's words, space separated
+	// BirthHeight is the block the wallet was first used at; the rescan starts
+	// there. Optional: nil rescans from genesis.
+	BirthHeight *uint32 `json:"birthHeight,omitempty"`
+}
+
 // CreateWalletResponse indicates wallet creation success
 type CreateWalletResponse struct {
 	Success bool   `json:"success"`
@@ -62,10 +99,34 @@ type CreateWalletResponse struct {
 // OpenWalletRequest contains parameters for opening a wallet
 type OpenWalletRequest struct {
 	PublicPassphrase string `json:"publicPassphrase"` // Optional: Wallet database passphrase (empty if wallet created without one)
+	// BeginHeight is the block the wallet was first used at, below the chain
+	// tip; rescans of the wallet start there. Optional: nil keeps any
+	// birthday already recorded, else genesis.
+	BeginHeight *uint32 `json:"beginHeight,omitempty"`
+}
+
+// CloseWalletResponse indicates wallet close success
+type CloseWalletResponse struct {
+	Success       bool   `json:"success"`
+	Message       string `json:"message,omitempty"`
+	AlreadyClosed bool   `json:"alreadyClosed,omitempty"` // No wallet was open before this request
+}
+
+// ReloadWalletResponse reports the wallet state after a force close and
+// reopen. Sync restarts asynchronously, so SyncPhase is usually not yet
+// synced when this is returned.
+type ReloadWalletResponse struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message,omitempty"`
+	WasOpen         bool   `json:"wasOpen"` // A wallet was closed before reopening
+	Loaded          bool   `json:"loaded"`
+	SyncPhase       string `json:"syncPhase"`
+	DaemonConnected bool   `json:"daemonConnected"`
 }
 
 // OpenWalletResponse indicates wallet open success
 type OpenWalletResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
+	Success     bool   `json:"success"`
+	Message     string `json:"message,omitempty"`
+	AlreadyOpen bool   `json:"alreadyOpen,omitempty"` // Wallet was loaded before this request
 }
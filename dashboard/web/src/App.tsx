@@ -65,6 +65,7 @@ import { getLightningInfo } from './services/lightningApi';
 import { getBisonrelayVersion } from './services/bisonrelayApi';
 import { getDexStatus } from './services/dcrdexApi';
 import { useWalletReady } from './hooks/useWalletReady';
+import { BASE_PATH } from './services/basePath';
 
 function AppContent() {
   const location = useLocation();
@@ -230,7 +231,7 @@ function AppContent() {
 function App() {
   return (
     <ThemeProvider>
-      <BrowserRouter>
+      <BrowserRouter basename={BASE_PATH || undefined}>
         <AuthGate>
           <BisonrelayLiveProvider>
             <AppContent />
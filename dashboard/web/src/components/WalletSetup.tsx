@@ -8,6 +8,7 @@ import { generateSeed, createNamedWallet, importXpub, listWallets } from '../ser
 import { KeyEnds } from './AddressGroups';
 import { AccountExportPicker, SelectedAccountEntry } from './AccountExportPicker';
 import { SeedEntry } from './wallet/SeedEntry';
+import { BASE_PATH } from '../services/basePath';
 
 interface WalletSetupProps {
   // onComplete replaces the default redirect to /wallet (used when embedded in
@@ -248,7 +249,7 @@ export const WalletSetup = ({ onComplete, onCancel }: WalletSetupProps = {}) =>
           if (onComplete) {
             onComplete();
           } else {
-            window.location.assign('/wallet');
+            window.location.assign(`${BASE_PATH}/wallet`);
           }
         }, 2000);
       } else {
@@ -10,6 +10,7 @@ import {
   getBisonrelayContactGroups,
 } from '../../services/bisonrelayApi';
 import { useWalletReady } from '../../hooks/useWalletReady';
+import { BASE_PATH } from '../../services/basePath';
 
 type Listener = (evt: BisonrelayLiveEvent) => void;
 
@@ -125,7 +126,7 @@ export const BisonrelayLiveProvider = ({ children }: { children: ReactNode }) =>
 
     const connect = () => {
       if (cancelled) return;
-      const url = `${window.location.protocol === 'https:' ? 'wss' : 'ws'}://${window.location.host}/api/br/events`;
+      const url = `${window.location.protocol === 'https:' ? 'wss' : 'ws'}://${window.location.host}${BASE_PATH}/api/br/events`;
       ws = new WebSocket(url);
       ws.onopen = () => {
         retry = 1000;
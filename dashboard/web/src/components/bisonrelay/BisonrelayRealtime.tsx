@@ -39,6 +39,7 @@ import { IncomingInviteBanner } from './realtime/IncomingInviteBanner';
 import { InstantCallModal } from './realtime/InstantCallModal';
 import { InviteToRoomModal } from './realtime/InviteToRoomModal';
 import { NewRoomModal } from './realtime/NewRoomModal';
+import { BASE_PATH } from '../../services/basePath';
 
 const readHashRoom = (): string | null => {
   const h = window.location.hash.replace(/^#/, '');
@@ -402,7 +403,7 @@ const ActiveCallView = ({ rv, onLeave }: { rv: string; onLeave: () => void }) =>
   useEffect(() => {
     const onBeforeUnload = () => {
       try {
-        navigator.sendBeacon(`/api/br/rtdt/sessions/${rv}/leave`);
+        navigator.sendBeacon(`${BASE_PATH}/api/br/rtdt/sessions/${rv}/leave`);
       } catch {
         /* ignore */
       }
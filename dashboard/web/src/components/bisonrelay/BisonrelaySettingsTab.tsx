@@ -74,6 +74,7 @@ import { ChannelList } from '../lightning/channels/ChannelList';
 import { RequestLiquidityModal } from '../lightning/channels/RequestLiquidityModal';
 import { setBrNotifPrefs, useBrNotifPrefs } from './brNotifPrefs';
 import { BrMcpSection } from '../settings/BrMcpSection';
+import { BASE_PATH } from '../../services/basePath';
 
 // ---- Section routing --------------------------------------------------------
 
@@ -1542,7 +1543,7 @@ const BackupCard = () => {
 
   const pushDownload = () => {
     const a = document.createElement('a');
-    a.href = '/api/br/backup';
+    a.href = `${BASE_PATH}/api/br/backup`;
     a.download = '';
     document.body.appendChild(a);
     a.click();
@@ -1633,7 +1634,7 @@ const BackupCard = () => {
             Preparing... {elapsed}s
           </button>
         ) : state === 'ready' ? (
-          <a href="/api/br/backup" download className={backupBtnCls}>
+          <a href={`${BASE_PATH}/api/br/backup`} download className={backupBtnCls}>
             <Download className="h-3.5 w-3.5" />
             Save backup file
           </a>
@@ -2,6 +2,8 @@
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
+import { BASE_PATH } from '../../services/basePath';
+
 export interface EmbedSegment {
   kind: 'embed';
   raw: string;
@@ -176,7 +178,7 @@ export function buildDownloadTag(nick: string, filename: string, size: number, m
 
 export function downloadFileUrl(nick: string, filename: string): string {
   if (!nick || !filename) return '';
-  return `/api/br/downloads/${encodeURIComponent(nick)}/${encodeURIComponent(filename)}`;
+  return `${BASE_PATH}/api/br/downloads/${encodeURIComponent(nick)}/${encodeURIComponent(filename)}`;
 }
 
 // embedFileUrl converts a localfilename of the form
@@ -190,7 +192,7 @@ export function embedFileUrl(localFilename: string): string {
   const filename = parts[2];
   if (!/^[0-9a-f]{16}$/.test(contact)) return '';
   if (!/^[A-Za-z0-9._-]+$/.test(filename)) return '';
-  return `/api/br/embeds/${contact}/${encodeURIComponent(filename)}`;
+  return `${BASE_PATH}/api/br/embeds/${contact}/${encodeURIComponent(filename)}`;
 }
 
 // ALLOWED_IMAGE_MIMES are the raster image types we render inline. SVG and any
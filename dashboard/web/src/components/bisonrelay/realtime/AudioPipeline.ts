@@ -2,6 +2,8 @@
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
+import { BASE_PATH } from '../../../services/basePath';
+
 // RealtimeAudioPipeline runs the browser side of the RTDT audio bridge.
 //
 // Phase 3 scope: outbound only (mic -> WebSocket -> brclientd ->
@@ -157,7 +159,7 @@ export class RealtimeAudioPipeline {
 
   private openSocket(): void {
     const proto = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-    const url = `${proto}//${window.location.host}/api/br/rtdt/sessions/${encodeURIComponent(this.rv)}/audio`;
+    const url = `${proto}//${window.location.host}${BASE_PATH}/api/br/rtdt/sessions/${encodeURIComponent(this.rv)}/audio`;
     const ws = new WebSocket(url);
     ws.binaryType = 'arraybuffer';
     this.ws = ws;
@@ -6,6 +6,7 @@ import { ReactNode, createContext, useCallback, useContext, useEffect, useRef, u
 import { getDexExchanges, getMMStatus } from '../../services/dcrdexApi';
 import type { DexNote, MMStatus, MMBotStatus } from '../../services/dcrdexApi';
 import type { MarketSpot } from './useDexFeed';
+import { BASE_PATH } from '../../services/basePath';
 
 type NoteListener = (note: DexNote) => void;
 
@@ -158,7 +159,7 @@ export const DexLiveProvider = ({ children }: { children: ReactNode }) => {
     const connect = () => {
       if (cancelled) return;
       const proto = window.location.protocol === 'https:' ? 'wss' : 'ws';
-      ws = new WebSocket(`${proto}://${window.location.host}/api/dcrdex/notify`);
+      ws = new WebSocket(`${proto}://${window.location.host}${BASE_PATH}/api/dcrdex/notify`);
       ws.onopen = () => {
         retry = 1000;
       };
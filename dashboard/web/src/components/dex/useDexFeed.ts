@@ -4,6 +4,7 @@
 
 import { useEffect, useRef, useState } from 'react';
 import { convQty, convRate } from './dexFormat';
+import { BASE_PATH } from '../../services/basePath';
 
 // MiniOrder mirrors bisonw's order book entry (client/webserver site registry).
 export interface MiniOrder {
@@ -148,7 +149,7 @@ export function useDexFeed(market: DexMarketRef | null, dur = '1h'): DexFeed {
     setError(null);
 
     const proto = window.location.protocol === 'https:' ? 'wss' : 'ws';
-    const ws = new WebSocket(`${proto}://${window.location.host}/api/dcrdex/ws`);
+    const ws = new WebSocket(`${proto}://${window.location.host}${BASE_PATH}/api/dcrdex/ws`);
     wsRef.current = ws;
 
     // rateConv converts an atomic message rate to a conventional price; mirrors
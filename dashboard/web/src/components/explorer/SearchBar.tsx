@@ -5,12 +5,13 @@
 import { useState } from 'react';
 import { Search, Loader2 } from 'lucide-react';
 import { useNavigate } from 'react-router-dom';
-import { searchExplorer } from '../../services/explorerApi';
+import { searchExplorer, type SearchMatch } from '../../services/explorerApi';
 
 export const SearchBar = () => {
   const [query, setQuery] = useState('');
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState('');
+  const [choices, setChoices] = useState<SearchMatch[]>([]);
   const navigate = useNavigate();
 
   const detectSearchType = (q: string): string => {
@@ -44,6 +45,7 @@ export const SearchBar = () => {
 
     setLoading(true);
     setError('');
+    setChoices([]);
 
     try {
       const result = await searchExplorer(query.trim());
@@ -54,23 +56,14 @@ export const SearchBar = () => {
         return;
       }
 
-      // Navigate based on result type
-      switch (result.type) {
-        case 'block':
-          const blockData = result.data as any;
-          navigate(`/explorer/block/${blockData.height}`);
-          break;
-        case 'transaction':
-          const txData = result.data as any;
-          navigate(`/explorer/tx/${txData.txid}`);
-          break;
-        case 'address':
-          navigate(`/explorer/address/${query.trim()}`);
-          break;
-        default:
-          setError('Unknown result type');
+      // A hash that is both a transaction and a block: let the user pick.
+      if (result.matches && result.matches.length > 1) {
+        setChoices(result.matches);
+        setLoading(false);
+        return;
       }
 
+      openMatch({ type: result.type, data: result.data } as SearchMatch);
       setLoading(false);
     } catch (err) {
       setError('Search failed. Please try again.');
@@ -78,6 +71,23 @@ export const SearchBar = () => {
     }
   };
 
+  const openMatch = (match: SearchMatch) => {
+    const data = match.data as any;
+    switch (match.type) {
+      case 'block':
+        navigate(`/explorer/block/${data.height}`);
+        break;
+      case 'transaction':
+        navigate(`/explorer/tx/${data.txid}`);
+        break;
+      case 'address':
+        navigate(`/explorer/address/${query.trim()}`);
+        break;
+      default:
+        setError('Unknown result type');
+    }
+  };
+
   const getPlaceholder = () => {
     const type = detectSearchType(query);
     switch (type) {
@@ -101,6 +111,7 @@ export const SearchBar = () => {
           onChange={(e) => {
             setQuery(e.target.value);
             setError('');
+            setChoices([]);
           }}
           placeholder={getPlaceholder()}
           className="w-full px-4 py-3 pl-12 pr-28 text-lg rounded-lg bg-background border border-border focus:border-primary focus:outline-none focus:ring-2 focus:ring-primary/20 transition-all"
@@ -127,6 +138,22 @@ export const SearchBar = () => {
           {error}
         </div>
       )}
+
+      {choices.length > 1 && (
+        <div className="mt-2 flex flex-wrap items-center gap-2 text-sm">
+          <span className="text-muted-foreground">This hash matches:</span>
+          {choices.map((m) => (
+            <button
+              key={m.type}
+              type="button"
+              onClick={() => openMatch(m)}
+              className="px-3 py-1 rounded-md border border-border hover:border-primary transition-colors"
+            >
+              {m.type === 'block' ? 'Block' : m.type === 'transaction' ? 'Transaction' : 'Address'}
+            </button>
+          ))}
+        </div>
+      )}
       
       <div className="mt-2 text-xs text-muted-foreground">
         Search by: Block height (e.g., 1000000) • Transaction hash • Block hash • Address
@@ -10,14 +10,25 @@ interface TSpendScanProgressProps {
   totalHeight: number;
   tspendFound: number;
   message?: string;
+  rate?: number; // chain blocks covered per second
+  estimatedSecondsRemaining?: number;
 }
 
+const formatEta = (seconds: number) => {
+  if (seconds < 60) return `~${seconds}s`;
+  const minutes = Math.round(seconds / 60);
+  if (minutes < 60) return `~${minutes}m`;
+  return `~${Math.floor(minutes / 60)}h ${minutes % 60}m`;
+};
+
 export const TSpendScanProgress = ({
   progress,
   currentHeight,
   totalHeight,
   tspendFound,
   message,
+  rate,
+  estimatedSecondsRemaining,
 }: TSpendScanProgressProps) => {
   return (
     <div className="p-6 rounded-xl bg-gradient-card backdrop-blur-sm border border-border/50 animate-fade-in">
@@ -46,6 +57,20 @@ export const TSpendScanProgress = ({
           <div className="font-semibold text-success">{tspendFound}</div>
         </div>
 
+        <div>
+          <div className="text-muted-foreground">Time Remaining</div>
+          <div className="font-semibold">
+            {estimatedSecondsRemaining ? formatEta(estimatedSecondsRemaining) : 'Estimating...'}
+          </div>
+        </div>
+
+        <div>
+          <div className="text-muted-foreground">Rate</div>
+          <div className="font-semibold">
+            {rate ? `${Math.round(rate).toLocaleString()} blocks/s` : '-'}
+          </div>
+        </div>
+
         <div className="col-span-2">
           <div className="text-muted-foreground">Current Block</div>
           <div className="font-mono text-sm">
@@ -174,6 +174,10 @@ export const TreasuryCard = () => {
     );
   }
 
+  // Once the server's scan covers the chain since activation its totals are
+  // authoritative; until then fall back to what this browser recorded.
+  const serverTotals = info?.totalsComplete ? info : null;
+
   return (
     <div className="p-6 rounded-xl bg-gradient-card backdrop-blur-sm border border-border/50 animate-fade-in">
       {/* Header */}
@@ -260,13 +264,13 @@ export const TreasuryCard = () => {
         <div className="p-4 rounded-lg bg-muted/5 border border-border/30">
           <div className="flex items-center gap-2 text-sm text-muted-foreground mb-1">
             <ArrowUpFromLine className="h-4 w-4" />
-            Total Spent (Tracked)
+            {serverTotals ? 'Total Spent' : 'Total Spent (Tracked)'}
           </div>
           <div className="text-2xl font-semibold">
-            {formatAmount(localStats.totalSpent)} DCR
+            {formatAmount(serverTotals ? serverTotals.totalSpent : localStats.totalSpent)} DCR
           </div>
           <div className="text-sm text-muted-foreground mt-1">
-            {localStats.count} TSpends recorded
+            {serverTotals ? serverTotals.tspendCount : localStats.count} TSpends recorded
           </div>
         </div>
       </div>
@@ -1,6 +1,6 @@
 import { useState } from 'react';
 import { AlertCircle, CheckCircle2, Download, Loader2 } from 'lucide-react';
-import { exportWalletCsv } from '../../services/api';
+import { exportWalletCsv, exportWalletTransactionsCsv } from '../../services/api';
 
 const exportTypes = [
   { value: 'transactions', label: 'Transactions' },
@@ -28,7 +28,9 @@ export const ExportTab = () => {
     setError(null);
     setDone(null);
     try {
-      const resp = await exportWalletCsv(type);
+      // Transactions come in chunks so a huge history doesn't outlast one
+      // request's timeout.
+      const resp = type === 'transactions' ? await exportWalletTransactionsCsv() : await exportWalletCsv(type);
       const name = filenameFrom(resp.headers['content-disposition'], type);
       const blob = new Blob([resp.data], { type: 'text/csv' });
       const url = URL.createObjectURL(blob);
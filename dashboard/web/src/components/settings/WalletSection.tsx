@@ -7,6 +7,7 @@ import { CheckCircle2, KeyRound, LogOut, Search, Wallet } from 'lucide-react';
 import { changePassphrase, closeActiveWallet, discoverAddresses, getSettings } from '../../services/api';
 import { ChangePassphraseModal } from './ChangePassphraseModal';
 import { DiscoverAddressesModal } from './DiscoverAddressesModal';
+import { BASE_PATH } from '../../services/basePath';
 
 export const WalletSection = () => {
   const [gapLimit, setGapLimit] = useState<number>(200);
@@ -42,7 +43,7 @@ export const WalletSection = () => {
     try {
       await closeActiveWallet();
       // Full reload so the layout returns to the wallet list.
-      window.location.assign('/wallet');
+      window.location.assign(`${BASE_PATH}/wallet`);
     } catch (err) {
       console.error('closeActiveWallet failed:', err);
       setClosing(false);
@@ -12,6 +12,7 @@ import { TreasuryStats } from '../components/governance/TreasuryStats';
 import { ActiveTreasuryVotes } from '../components/governance/ActiveTreasuryVotes';
 import { 
   triggerTSpendScan, 
+  scanTSpendHeights,
   getTSpendScanProgress,
   getTSpendScanResults,
   TSpendScanProgress as ScanProgressType 
@@ -31,6 +32,9 @@ export const GovernanceDashboard = () => {
   const [scanProgress, setScanProgress] = useState<ScanProgressType | null>(null);
   const [lastScanStatus, setLastScanStatus] = useState(getScanStatus());
   const [refreshTrigger, setRefreshTrigger] = useState(0);
+  // Blocks the last scan could not read; its results miss any TSpend in them
+  const [failedHeights, setFailedHeights] = useState<number[]>([]);
+  const [isRescanning, setIsRescanning] = useState(false);
 
   // Sync with historical snapshot on first load
   useEffect(() => {
@@ -160,8 +164,13 @@ export const GovernanceDashboard = () => {
             console.error('Failed to perform final sync:', syncError);
           }
 
-          // Update lastSyncHeight to the final scanned height
-          updateLastSyncHeight(progress.currentHeight);
+          setFailedHeights(progress.failedHeights ?? []);
+
+          // Update lastSyncHeight to the final scanned height. A re-scan of
+          // failed blocks only fills gaps below it.
+          if (progress.mode !== 'heights') {
+            updateLastSyncHeight(progress.currentHeight);
+          }
 
           // Save scan completion status
           saveScanStatus({
@@ -191,7 +200,7 @@ export const GovernanceDashboard = () => {
     // This will be automatically set from the snapshot (block 1,012,032)
     // or from previous scans, so users don't need to rescan from 2021
     const lastSyncHeight = getLastSyncHeight();
-    const startHeight = lastSyncHeight > 0 ? lastSyncHeight + 1 : 552448;
+    const startHeight = lastSyncHeight > 0 ? lastSyncHeight + 1 : 0;
     
     const confirmMessage = lastSyncHeight > 0
       ? `Continue scanning for historical Treasury Spends?\n\n` +
@@ -199,7 +208,7 @@ export const GovernanceDashboard = () => {
         `Will scan from block ${startHeight.toLocaleString()} to current height.\n\n` +
         `Click OK to continue.`
       : `Scan the entire blockchain for historical Treasury Spends?\n\n` +
-        `This will scan from block 552,448 (Treasury activation) to the current height.\n` +
+        `This will scan from the treasury activation block to the current height.\n` +
         `The process may take a very long time.\n\n` +
         `Click OK to continue.`;
 
@@ -210,7 +219,7 @@ export const GovernanceDashboard = () => {
     }
 
     try {
-      await triggerTSpendScan(startHeight);
+      await triggerTSpendScan({ mode: 'full', startHeight });
       setIsScanning(true);
       
       // Start polling immediately
@@ -222,6 +231,35 @@ export const GovernanceDashboard = () => {
     }
   };
 
+  const handleRescanFailed = async () => {
+    if (isScanning || isRescanning || failedHeights.length === 0) {
+      return;
+    }
+    setIsRescanning(true);
+    try {
+      const result = await scanTSpendHeights({ heights: failedHeights });
+      const records: TSpendRecord[] = result.newTSpends.map(t => ({
+        txHash: t.txHash,
+        amount: t.amount,
+        payee: t.payee,
+        blockHeight: t.blockHeight,
+        timestamp: t.timestamp,
+        voteResult: t.voteResult,
+        detectedAt: new Date().toISOString(),
+      }));
+      if (records.length > 0) {
+        saveTSpends(records);
+        setRefreshTrigger(prev => prev + 1);
+      }
+      setFailedHeights(result.failedHeights);
+    } catch (error) {
+      console.error('Failed to re-scan failed blocks:', error);
+      alert(error instanceof Error ? error.message : 'Failed to re-scan failed blocks.');
+    } finally {
+      setIsRescanning(false);
+    }
+  };
+
   const formatDate = (dateString: string) => {
     try {
       return toYMDTime(new Date(dateString));
@@ -271,6 +309,24 @@ export const GovernanceDashboard = () => {
               </div>
             </div>
           )}
+
+          {/* Blocks the last scan could not read */}
+          {failedHeights.length > 0 && !isScanning && (
+            <div className="mt-4 pt-4 border-t border-border/50 flex items-center justify-between gap-4 text-sm">
+              <span className="text-warning">
+                Scan incomplete: {failedHeights.length} block{failedHeights.length === 1 ? '' : 's'} could not be read
+                ({failedHeights.slice(0, 5).map(h => h.toLocaleString()).join(', ')}
+                {failedHeights.length > 5 ? ', …' : ''}). TSpends in them are missing.
+              </span>
+              <button
+                onClick={handleRescanFailed}
+                disabled={isRescanning}
+                className="px-4 py-2 rounded-lg font-medium bg-primary text-primary-foreground hover:bg-primary/90 transition-colors disabled:opacity-50"
+              >
+                {isRescanning ? 'Re-scanning...' : 'Re-scan failed blocks'}
+              </button>
+            </div>
+          )}
         </div>
 
         {/* Scan Progress */}
@@ -281,6 +337,8 @@ export const GovernanceDashboard = () => {
             totalHeight={scanProgress.totalHeight}
             tspendFound={scanProgress.tspendFound}
             message={scanProgress.message}
+            rate={scanProgress.rate}
+            estimatedSecondsRemaining={scanProgress.estimatedSecondsRemaining}
           />
         )}
 
@@ -15,6 +15,7 @@ import { StakingStats } from '../components/StakingStats';
 import { MempoolActivity } from '../components/MempoolActivity';
 import { TicketPoolCard } from '../components/TicketPoolCard';
 import { getDashboardData, DashboardData } from '../services/api';
+import { BASE_PATH } from '../services/basePath';
 
 interface NodeSync {
   status: string;
@@ -70,7 +71,7 @@ export const NodeDashboard = () => {
     const connect = () => {
       if (cancelled) return;
       const proto = window.location.protocol === 'https:' ? 'wss' : 'ws';
-      ws = new WebSocket(`${proto}://${window.location.host}/api/node/sync/stream`);
+      ws = new WebSocket(`${proto}://${window.location.host}${BASE_PATH}/api/node/sync/stream`);
       ws.onopen = () => {
         retry = 1000;
         // Re-pull the authoritative status on every (re)connect so a stream that
@@ -13,6 +13,7 @@ import {
   type WalletInfo,
 } from '../services/api';
 import { WalletSetup } from '../components/WalletSetup';
+import { BASE_PATH } from '../services/basePath';
 
 interface WalletSelectionProps {
   // embedded is true when shown from an open wallet (the "Switch wallet"
@@ -57,7 +58,7 @@ export const WalletSelection = ({ embedded = false }: WalletSelectionProps) => {
     try {
       await selectWallet(name, pass);
       // Full reload so the layout re-evaluates with the new active wallet.
-      window.location.assign('/wallet');
+      window.location.assign(`${BASE_PATH}/wallet`);
     } catch (err: any) {
       setSwitching(null);
       if (err?.response?.status === 401) {
@@ -95,7 +96,7 @@ export const WalletSelection = ({ embedded = false }: WalletSelectionProps) => {
   if (view === 'create') {
     return (
       <WalletSetup
-        onComplete={() => window.location.assign('/wallet')}
+        onComplete={() => window.location.assign(`${BASE_PATH}/wallet`)}
         onCancel={() => setView('list')}
       />
     );
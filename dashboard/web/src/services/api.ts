@@ -3,8 +3,9 @@
 // license that can be found in the LICENSE file.
 
 import axios from 'axios';
+import { BASE_PATH } from './basePath';
 
-const API_BASE_URL = '/api';
+const API_BASE_URL = `${BASE_PATH}/api`;
 
 const api = axios.create({
   baseURL: API_BASE_URL,
@@ -23,15 +24,92 @@ export function setUnauthorizedHandler(fn: (() => void) | null) {
   onUnauthorized = fn;
 }
 
+// Every JSON response from the backend is wrapped in a {data, error, meta}
+// envelope, flagged by the X-Dcrpulse-Envelope header. The interceptors below
+// and authFetch unwrap it so callers keep seeing the bare payload on success
+// and, on failure, either the handler's structured body or the error message
+// string. The parsed envelope error stays available as `error.apiError`.
+export interface APIError {
+  code: string;
+  message: string;
+}
+
+export interface PageMeta {
+  total: number;
+  offset: number;
+  limit: number;
+}
+
+export interface DashboardMeta {
+  failedSections: string[];
+  errors?: Record<string, string>;
+}
+
+// Meta is endpoint specific: PageMeta on paginated lists, DashboardMeta on
+// /dashboard.
+export type APIMeta = PageMeta | DashboardMeta;
+
+interface APIEnvelope {
+  data: unknown;
+  error: APIError | null;
+  meta?: APIMeta;
+  nodeSyncing?: boolean; // data was read from dcrd during initial block download
+}
+
+const ENVELOPE_HEADER = 'x-dcrpulse-envelope';
+
+function isEnvelope(headers: any): boolean {
+  const v = typeof headers?.get === 'function' ? headers.get(ENVELOPE_HEADER) : headers?.[ENVELOPE_HEADER];
+  return v === '1';
+}
+
+// unwrapEnvelope returns what callers saw before the envelope existed: the
+// payload, or for an error without a structured body, its message.
+function unwrapEnvelope(env: APIEnvelope): unknown {
+  if (env.error && (env.data === null || env.data === undefined)) {
+    return env.error.message;
+  }
+  return env.data;
+}
+
+// apiErrorMessage picks the most specific message off a failed request.
+export function apiErrorMessage(err: any, fallback: string): string {
+  if (err?.apiError?.message) return err.apiError.message;
+  const data = err?.response?.data;
+  if (typeof data === 'string' && data.trim()) return data.trim();
+  return data?.message || data?.error || fallback;
+}
+
 api.interceptors.response.use(
-  (resp) => resp,
-  (error) => {
+  (resp) => {
+    if (isEnvelope(resp.headers) && resp.data && typeof resp.data === 'object' && !(resp.data instanceof Blob)) {
+      const env = resp.data as APIEnvelope;
+      (resp as any).meta = env.meta;
+      (resp as any).nodeSyncing = env.nodeSyncing === true;
+      resp.data = env.data;
+    }
+    return resp;
+  },
+  async (error) => {
+    const resp = error?.response;
+    if (resp && isEnvelope(resp.headers)) {
+      try {
+        const env: APIEnvelope =
+          resp.data instanceof Blob ? JSON.parse(await resp.data.text()) : resp.data;
+        if (env && typeof env === 'object' && 'error' in env) {
+          error.apiError = env.error;
+          resp.data = unwrapEnvelope(env);
+        }
+      } catch {
+        // leave the raw body in place
+      }
+    }
     // Only re-lock on the app-password gate's OWN 401 (tagged with the
     // X-Dashboard-Auth header), never on a downstream daemon/wallet 401 such as
     // a wallet's public-passphrase prompt after a wallet switch.
     if (
-      error?.response?.status === 401 &&
-      error.response.headers?.['x-dashboard-auth'] === 'required' &&
+      resp?.status === 401 &&
+      resp.headers?.['x-dashboard-auth'] === 'required' &&
       onUnauthorized
     ) {
       onUnauthorized();
@@ -42,13 +120,28 @@ api.interceptors.response.use(
 
 // authFetch wraps native fetch for the two services that bypass this axios
 // instance (explorer, treasury); it routes a 401 to the same login handler so
-// session expiry on those pages also bounces to the login screen.
+// session expiry on those pages also bounces to the login screen, and unwraps
+// the response envelope into a plain Response the callers can read as before.
 export async function authFetch(input: RequestInfo | URL, init?: RequestInit): Promise<Response> {
   const res = await fetch(input, { credentials: 'same-origin', ...init });
   if (res.status === 401 && res.headers.get('x-dashboard-auth') === 'required' && onUnauthorized) {
     onUnauthorized();
   }
-  return res;
+  if (!isEnvelope(res.headers)) {
+    return res;
+  }
+  let env: APIEnvelope;
+  try {
+    env = await res.json();
+  } catch {
+    return res;
+  }
+  const inner = unwrapEnvelope(env);
+  const body = typeof inner === 'string' && env.error ? inner : JSON.stringify(inner ?? null);
+  const headers = new Headers(res.headers);
+  headers.delete(ENVELOPE_HEADER);
+  headers.delete('content-length');
+  return new Response(body, { status: res.status, statusText: res.statusText, headers });
 }
 
 export interface NodeStatus {
@@ -57,6 +150,13 @@ export interface NodeStatus {
   version: string;
   syncPhase: string;
   syncMessage: string;
+  txIndexMissing?: boolean;
+  txIndexMessage?: string;
+  rpcInFlight: number;
+  rpcQueued: number;
+  rpcLimit: number;
+  nodeSyncing: boolean; // dcrd is in initial block download
+  verificationProgress: number; // 0-1
 }
 
 export interface RecentBlock {
@@ -72,6 +172,20 @@ export interface BlockchainInfo {
   chainSize: number;
   blockTime: string;
   recentBlocks: RecentBlock[];
+  chain: string;
+  headers: number;
+  syncHeight: number;
+  chainWork: string;
+  verificationProgress: number;
+  initialBlockDownload: boolean;
+  // Derived from the tip's headers; medianTime is a Unix timestamp.
+  medianTime: number;
+  nextWorkDiffBlocks: number;
+  nextWorkDiffSeconds: number;
+  nextStakeDiffBlocks: number;
+  nextStakeDiffSeconds: number;
+  syncPercent: number;
+  syncPercentText: string;
 }
 
 export interface NetworkInfo {
@@ -91,6 +205,35 @@ export interface Peer {
   isSyncNode: boolean;
   inbound: boolean;
   tor: boolean;
+  pingMs: number;
+  bytesSent: number;
+  bytesRecv: number;
+  connectedAt: number;
+}
+
+export interface PeerVersionBucket {
+  version: string;
+  count: number;
+  percent: number;
+  userAgents: string[];
+}
+
+export interface PeerBandwidth {
+  peers: number;
+  bytesSent: number;
+  bytesRecv: number;
+}
+
+export interface NetworkSummary {
+  peerCount: number;
+  latestVersion: string;
+  latestCount: number;
+  latestPercent: number;
+  versions: PeerVersionBucket[];
+  protocols: { version: number; count: number }[];
+  inbound: PeerBandwidth;
+  outbound: PeerBandwidth;
+  services: { flag: string; count: number }[];
 }
 
 export interface SupplyInfo {
@@ -140,27 +283,148 @@ export interface DashboardData {
   lastUpdate: string;
 }
 
+// Overview is the lightweight summary; a section is absent when its backend
+// is unavailable.
+export interface Overview {
+  node?: {
+    height: number;
+    syncPercent: number;
+    peerCount: number;
+  };
+  wallet?: {
+    synced: boolean;
+    totalBalance: number;
+  };
+  treasury?: {
+    balanceAtoms: number;
+    balanceDcr: string;
+    activeTSpends: number;
+  };
+  lastUpdate: string;
+}
+
 // API functions
 export const getDashboardData = async (): Promise<DashboardData> => {
   const response = await api.get<DashboardData>('/dashboard');
   return response.data;
 };
 
+export const getOverview = async (): Promise<Overview> => {
+  const response = await api.get<Overview>('/overview');
+  return response.data;
+};
+
 export const getNodeStatus = async (): Promise<NodeStatus> => {
   const response = await api.get<NodeStatus>('/node/status');
   return response.data;
 };
 
+export interface BuildInfo {
+  version: string;
+  commit?: string;
+  commitTime?: string;
+  modified?: boolean;
+  goVersion: string;
+}
+
+// dcrd's software and connectivity. dcrd has no uptime call, so
+// minUptimeSeconds is the age of its oldest peer connection.
+export interface NodeInfo {
+  version: string;
+  jsonrpcVersion: string;
+  userAgent: string;
+  protocolVersion: number;
+  connections: number;
+  relayFee: number; // DCR/kB
+  timeOffset: number;
+  localServices: string;
+  minUptimeSeconds?: number;
+  minUptime?: string;
+  dcrpulse: BuildInfo;
+}
+
+export const getNodeInfo = async (): Promise<NodeInfo> => {
+  const response = await api.get<NodeInfo>('/node/info');
+  return response.data;
+};
+
+export interface RPCEndpoint {
+  host: string;
+  port: string;
+  user: string;
+  password: string;
+  cert: string;
+}
+
+export interface ConnectRequest {
+  dcrd?: RPCEndpoint;
+  wallet?: RPCEndpoint & { grpcPort: string };
+}
+
+export interface ClientConnectResult {
+  attempted: boolean;
+  connected: boolean;
+  error?: string;
+}
+
+export interface ConnectResponse {
+  success: boolean;
+  dcrd: ClientConnectResult;
+  walletRpc: ClientConnectResult;
+  walletGrpc: ClientConnectResult;
+  network?: string;
+  networkMismatch?: boolean;
+}
+
+export const connectRPC = async (req: ConnectRequest): Promise<ConnectResponse> => {
+  const response = await api.post<ConnectResponse>('/connect', req);
+  return response.data;
+};
+
 export const getBlockchainInfo = async (): Promise<BlockchainInfo> => {
   const response = await api.get<BlockchainInfo>('/blockchain/info');
   return response.data;
 };
 
+export interface BlockchainTip {
+  height: number;
+  hash: string;
+  time: number; // Unix
+  medianTime: number; // Unix
+}
+
+export const getBlockchainTip = async (): Promise<BlockchainTip> => {
+  const response = await api.get<BlockchainTip>('/blockchain/tip');
+  return response.data;
+};
+
+export interface TicketPool {
+  height: number;
+  hash: string;
+  size: number;
+  valueAtoms: number;
+  value: number;
+  valueDcr: string;
+  ticketPrice: number;
+  averageTicketPrice: number;
+  approximate: boolean; // value is size * ticketPrice during initial sync
+}
+
+export const getTicketPool = async (): Promise<TicketPool> => {
+  const response = await api.get<TicketPool>('/blockchain/ticketpool');
+  return response.data;
+};
+
 export const getPeers = async (): Promise<Peer[]> => {
   const response = await api.get<Peer[]>('/network/peers');
   return response.data;
 };
 
+export const getNetworkSummary = async (): Promise<NetworkSummary> => {
+  const response = await api.get<NetworkSummary>('/network/summary');
+  return response.data;
+};
+
 export const checkHealth = async (): Promise<any> => {
   const response = await api.get('/health');
   return response.data;
@@ -172,6 +436,10 @@ export interface HealthStatus {
   walletRPCConnected: boolean;
   dcrdTLS: boolean;
   walletTLS: boolean;
+  networkMismatch: boolean;
+  dcrdNetwork?: string;
+  walletNetwork?: string;
+  webSockets: { active: number; max: number };
 }
 
 export const getHealth = async (): Promise<HealthStatus> => {
@@ -295,12 +563,36 @@ export interface ImportXpubRequest {
   accountName: string;
   accountIndex?: number;
   rescan: boolean;
+  validateOnly?: boolean;
+}
+
+export interface XpubPreview {
+  network: string;
+  fingerprint: string;
+  depth: number;
+  accountIndex?: number; // BIP44 account, for an account-level key
+  addresses: string[]; // first external addresses
+  importedAs?: string; // account already holding this key
 }
 
 export interface ImportXpubResponse {
   success: boolean;
   message: string;
   accountNum?: number;
+  jobId?: string;
+  preview?: XpubPreview; // validateOnly requests
+}
+
+export interface XpubImportStatus {
+  id: string;
+  accountName: string;
+  state: 'importing' | 'discovering' | 'rescanning' | 'done' | 'failed';
+  imported: boolean;
+  accountNum?: number;
+  rescanRunning: boolean;
+  error?: string;
+  startedAt: number;
+  updatedAt: number;
 }
 
 // Wallet API Functions
@@ -309,8 +601,12 @@ export const getWalletStatus = async (): Promise<WalletStatus> => {
   return response.data;
 };
 
-export const getWalletDashboard = async (): Promise<WalletDashboardData> => {
-  const response = await api.get<WalletDashboardData>('/wallet/dashboard');
+// minConf is the confirmations an output needs to count as spendable
+// (server default 1; 0 includes mempool).
+export const getWalletDashboard = async (minConf?: number): Promise<WalletDashboardData> => {
+  const response = await api.get<WalletDashboardData>('/wallet/dashboard', {
+    params: minConf !== undefined ? { minConf } : undefined,
+  });
   return response.data;
 };
 
@@ -327,16 +623,58 @@ export const importXpub = async (
   return response.data;
 };
 
+// Validate an xpub and preview the account it would create, without importing.
+export const previewXpub = async (xpub: string): Promise<XpubPreview> => {
+  const body: ImportXpubRequest = { xpub, accountName: '', rescan: false, validateOnly: true };
+  const response = await api.post<ImportXpubResponse>('/wallet/importxpub', body);
+  return response.data.preview as XpubPreview;
+};
+
+export const getXpubImportStatus = async (jobId: string): Promise<XpubImportStatus> => {
+  const response = await api.get<XpubImportStatus>(`/wallet/importxpub/status/${encodeURIComponent(jobId)}`);
+  return response.data;
+};
+
 export interface NextAddressResponse {
   address: string;
   accountNumber: number;
 }
 
-export const getAccounts = async (): Promise<AccountInfo[]> => {
-  const response = await api.get<AccountInfo[]>('/wallet/accounts');
+export const getAccounts = async (minConf?: number): Promise<AccountInfo[]> => {
+  const response = await api.get<AccountInfo[]>('/wallet/accounts', {
+    params: minConf !== undefined ? { minConf } : undefined,
+  });
   return response.data;
 };
 
+export interface WalletAddress {
+  address: string;
+  account: number;
+  accountName: string;
+  branch: number; // 0 external, 1 internal (change)
+  index: number;
+  used: boolean;
+  received: number; // DCR, including unconfirmed
+  txCount: number;
+}
+
+export interface WalletAddressPage {
+  addresses: WalletAddress[];
+  page: PageMeta;
+}
+
+// List the wallet's derived addresses, optionally for one account (number or
+// name) and only used or unused ones.
+export const listWalletAddresses = async (params: {
+  account?: string | number;
+  used?: boolean;
+  offset?: number;
+  limit?: number;
+} = {}): Promise<WalletAddressPage> => {
+  const response = await api.get<WalletAddress[]>('/wallet/addresses', { params });
+  return { addresses: response.data, page: (response as any).meta as PageMeta };
+};
+
 export const createAccount = async (
   accountName: string,
   passphrase: string,
@@ -403,7 +741,7 @@ export const subscribeMixerEvents = (
   onClose?: () => void,
 ): (() => void) => {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const wsUrl = `${protocol}//${window.location.host}/api/wallet/privacy/events`;
+  const wsUrl = `${protocol}//${window.location.host}${BASE_PATH}/api/wallet/privacy/events`;
   const ws = new WebSocket(wsUrl);
 
   ws.onmessage = (event) => {
@@ -623,8 +961,30 @@ export interface SyncProgressData {
   // difference is a usable wall-clock elapsed for the headers-fetch phase.
   firstHeaderTime?: number;
   lastHeaderTime?: number;
+  syncStatus?: WalletSyncStatus;
+}
+
+export type WalletSyncPhase = 'disconnected' | 'syncing-headers' | 'syncing-blocks' | 'rescanning' | 'synced';
+
+export interface WalletSyncStatus {
+  synced: boolean;
+  phase: WalletSyncPhase;
+  detailPhase: string;
+  walletHeight: number;
+  chainHeight: number;
+  blocksBehind: number;
+  blocksPerSecond: number;
+  estimatedSecondsRemaining: number;
+  etaState: WalletSyncETAState;
 }
 
+export type WalletSyncETAState = 'done' | 'estimated' | 'unknown' | 'stalled';
+
+export const getWalletSyncStatus = async (): Promise<WalletSyncStatus> => {
+  const response = await api.get<WalletSyncStatus>('/wallet/sync-status');
+  return response.data;
+};
+
 export const getSyncProgress = async (): Promise<SyncProgressData> => {
   const response = await api.get<SyncProgressData>('/wallet/sync-progress');
   return response.data;
@@ -638,7 +998,7 @@ export const streamRescanProgress = (
 ): (() => void) => {
   // Get WebSocket URL from current origin
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const wsUrl = `${protocol}//${window.location.host}/api/wallet/grpc/stream-rescan`;
+  const wsUrl = `${protocol}//${window.location.host}${BASE_PATH}/api/wallet/grpc/stream-rescan`;
   
   console.log('Connecting to gRPC WebSocket:', wsUrl);
   const ws = new WebSocket(wsUrl);
@@ -675,11 +1035,95 @@ export const streamRescanProgress = (
   };
 };
 
+// subscribeEvents opens a Server-Sent Events stream and hands each event's
+// JSON payload to onEvent. EventSource reconnects by itself after a dropped
+// connection; the returned function closes the stream.
+export const subscribeEvents = <T>(
+  path: string,
+  onEvent: (data: T) => void,
+  onError?: (error: Error) => void
+): (() => void) => {
+  const source = new EventSource(`${API_BASE_URL}${path}`, { withCredentials: true });
+  source.onmessage = (event) => {
+    try {
+      onEvent(JSON.parse(event.data) as T);
+    } catch (err) {
+      console.error('Failed to parse event:', err);
+      onError?.(new Error('Failed to parse event data'));
+    }
+  };
+  source.onerror = () => {
+    onError?.(new Error('Event stream connection error'));
+  };
+  return () => source.close();
+};
+
+// Server-Sent Events form of streamRescanProgress.
+export const subscribeRescanProgress = (
+  onProgress: (data: SyncProgressData) => void,
+  onError?: (error: Error) => void
+): (() => void) => subscribeEvents<SyncProgressData>('/wallet/rescan-progress/events', onProgress, onError);
+
 export const getWalletTransactions = async (count: number = 50, from: number = 0): Promise<TransactionListResponse> => {
   const response = await api.get<TransactionListResponse>(`/wallet/transactions?count=${count}&from=${from}`);
   return response.data;
 };
 
+// WalletTransactionDetail is one wallet transaction attributed to the
+// wallet's accounts; amounts are in DCR. account is set on the inputs and
+// outputs that are the wallet's.
+export interface WalletTxInput {
+  index: number;
+  prevTxid: string;
+  prevIndex: number;
+  prevTree: number;
+  amount: number;
+  owned: boolean;
+  account?: number;
+  accountName?: string;
+}
+
+export interface WalletTxOutput {
+  index: number;
+  amount: number;
+  address?: string;
+  scriptType: string;
+  owned: boolean;
+  account?: number;
+  accountName?: string;
+  internal?: boolean; // an address on the account's internal branch
+  change: boolean; // internal output of a transaction the wallet funded
+}
+
+export interface WalletTxAccountNet {
+  account: number;
+  accountName: string;
+  debit: number;
+  credit: number;
+  net: number;
+}
+
+export interface WalletTransactionDetail {
+  txid: string;
+  txType: string;
+  confirmations: number;
+  blockHash?: string;
+  time: number;
+  size: number;
+  fee: number;
+  net: number;
+  amountSent: number; // paid away from the wallet, change excluded
+  changeAmount: number;
+  inputs: WalletTxInput[];
+  outputs: WalletTxOutput[];
+  accounts: WalletTxAccountNet[];
+}
+
+export const getWalletTransaction = async (txHash: string): Promise<WalletTransactionDetail> => {
+  const response = await api.get<WalletTransactionDetail>(`/wallet/transactions/${encodeURIComponent(txHash)}`);
+  return response.data;
+};
+
 // exportWalletCsv downloads a Decrediton-format CSV export of the wallet's
 // transaction history or statistics. The full-history Balances exports can take
 // a while, so a long per-request timeout is used.
@@ -690,6 +1134,31 @@ export const exportWalletCsv = async (type: string) => {
   });
 };
 
+// exportWalletTransactionsCsv fetches the Transactions export in chunks of
+// chunkSize transactions, each its own request, following X-Next-Cursor until
+// the last, and joins them into one CSV. The headers are the first chunk's.
+export const exportWalletTransactionsCsv = async (chunkSize = 5000) => {
+  const parts: Blob[] = [];
+  let headers: Record<string, any> = {};
+  let cursor: string | undefined;
+  do {
+    const params = new URLSearchParams({ type: 'transactions', limit: String(chunkSize) });
+    if (cursor) {
+      params.set('cursor', cursor);
+    }
+    const resp = await api.get<Blob>(`/wallet/export?${params}`, {
+      responseType: 'blob',
+      timeout: 300000,
+    });
+    if (parts.length === 0) {
+      headers = resp.headers;
+    }
+    parts.push(resp.data);
+    cursor = resp.headers['x-next-cursor'] || undefined;
+  } while (cursor);
+  return { data: new Blob(parts, { type: 'text/csv' }), headers };
+};
+
 // Wallet Creation/Loader Types
 export interface WalletExistsResponse {
   exists: boolean;
@@ -701,8 +1170,9 @@ export interface WalletLoadedResponse {
 }
 
 export interface GenerateSeedRequest {
-  // Seed length in BYTES (not words). Zero or unset -> dcrwallet's
-  // recommended 32 bytes -> 33-word Decred-standard mnemonic.
+  // Seed length in BYTES (not words): 16-64 in steps of 4, giving
+  // seedLength+1 words. Zero or unset -> the recommended 32 bytes ->
+  // 33-word Decred-standard mnemonic. Anything else is a 400.
   seedLength?: number;
 }
 
@@ -731,11 +1201,22 @@ export interface CreateWalletResponse {
 
 export interface OpenWalletRequest {
   publicPassphrase: string; // Optional: Wallet database passphrase (empty if wallet created without one)
+  beginHeight?: number;     // Optional: block the wallet was first used at; rescans start there
 }
 
 export interface OpenWalletResponse {
   success: boolean;
   message?: string;
+  alreadyOpen?: boolean; // wallet was loaded before this request
+}
+
+export interface ReloadWalletResponse {
+  success: boolean;
+  message?: string;
+  wasOpen: boolean;
+  loaded: boolean;
+  syncPhase: string;
+  daemonConnected: boolean;
 }
 
 // Wallet Creation/Loader API Functions
@@ -749,14 +1230,37 @@ export const checkWalletLoaded = async (): Promise<WalletLoadedResponse> => {
   return response.data;
 };
 
-// generateSeed lets dcrwallet pick the recommended length by default
-// (zero -> 32 bytes -> 33-word mnemonic). Pass a non-zero byte count only if
-// you need a non-standard seed length.
+// generateSeed uses the recommended length by default (zero -> 32 bytes ->
+// 33-word mnemonic). Pass a non-zero byte count only if you need a different
+// seed length.
 export const generateSeed = async (seedLength: number = 0): Promise<GenerateSeedResponse> => {
   const response = await api.post<GenerateSeedResponse>('/wallet/generate-seed', { seedLength });
   return response.data;
 };
 
+export interface VerifySeedRequest {
+  // Either the full re-entered mnemonic, or a spot check of words by 0-based index.
+  mnemonic?: string;
+  words?: { index: number; word: string }[];
+}
+
+export interface VerifySeedResponse {
+  valid: boolean;
+  wrongIndices: number[];
+  checked: number;
+  wordCount: number;
+  // Failed checks left before the seed is dropped; 0 means generate a new one.
+  attemptsLeft: number;
+}
+
+// verifySeed checks the user's backup against the last generated seed; it
+// 404s once the wallet has been created from it, the seed has expired, or
+// too many checks have failed.
+export const verifySeed = async (request: VerifySeedRequest): Promise<VerifySeedResponse> => {
+  const response = await api.post<VerifySeedResponse>('/wallet/verify-seed', request);
+  return response.data;
+};
+
 export const decodeSeed = async (userInput: string): Promise<{ seedHex: string }> => {
   const response = await api.post<{ seedHex: string }>('/wallet/decode-seed', { userInput });
   return response.data;
@@ -767,11 +1271,32 @@ export const createWallet = async (request: CreateWalletRequest): Promise<Create
   return response.data;
 };
 
+export interface RestoreWalletRequest {
+  publicPassphrase: string;
+  confirmPublicPassphrase: string;
+  privatePassphrase: string;
+  confirmPrivatePassphrase: string;
+  mnemonic: string;
+  // Block the wallet was first used at; the rescan starts there (genesis when omitted).
+  birthHeight?: number;
+}
+
+export const restoreWallet = async (request: RestoreWalletRequest): Promise<CreateWalletResponse> => {
+  const response = await api.post<CreateWalletResponse>('/wallet/restore', request);
+  return response.data;
+};
+
 export const openWallet = async (request: OpenWalletRequest): Promise<OpenWalletResponse> => {
   const response = await api.post<OpenWalletResponse>('/wallet/open', request);
   return response.data;
 };
 
+// Force-close and reopen the wallet, restarting RPC sync (recovery path).
+export const reloadWallet = async (request: OpenWalletRequest): Promise<ReloadWalletResponse> => {
+  const response = await api.post<ReloadWalletResponse>('/wallet/reload', request);
+  return response.data;
+};
+
 // Multi-wallet API
 
 export interface WalletInfo {
@@ -802,8 +1327,14 @@ export const selectWallet = async (name: string, publicPassphrase = ''): Promise
   return response.data;
 };
 
-export const closeActiveWallet = async (): Promise<{ success: boolean }> => {
-  const response = await api.post<{ success: boolean }>('/wallet/close', {});
+export interface CloseWalletResponse {
+  success: boolean;
+  message?: string;
+  alreadyClosed?: boolean;
+}
+
+export const closeActiveWallet = async (): Promise<CloseWalletResponse> => {
+  const response = await api.post<CloseWalletResponse>('/wallet/close', {});
   return response.data;
 };
 
@@ -932,7 +1463,7 @@ export const subscribePurchaseEvents = (
   onClose?: () => void,
 ): (() => void) => {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const wsUrl = `${protocol}//${window.location.host}/api/wallet/staking/purchase/events`;
+  const wsUrl = `${protocol}//${window.location.host}${BASE_PATH}/api/wallet/staking/purchase/events`;
   const ws = new WebSocket(wsUrl);
 
   ws.onmessage = (event) => {
@@ -1137,7 +1668,7 @@ export const subscribeAutobuyerEvents = (
   onClose?: () => void,
 ): (() => void) => {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const wsUrl = `${protocol}//${window.location.host}/api/wallet/staking/autobuyer/events`;
+  const wsUrl = `${protocol}//${window.location.host}${BASE_PATH}/api/wallet/staking/autobuyer/events`;
   const ws = new WebSocket(wsUrl);
 
   ws.onmessage = (event) => {
@@ -1279,6 +1810,29 @@ export const setTSpendPolicy = async (
   await api.post('/wallet/governance/treasury/tspends/set', { hash, policy, passphrase });
 };
 
+// VotingPolicy is everything the wallet's tickets vote with.
+export interface VotingPolicy {
+  agendas: Agenda[];
+  treasuryKeyPolicies: TreasuryKeyPolicy[];
+  tspendPolicies: TSpendPolicyEntry[];
+}
+
+export interface VotingPolicyUpdate {
+  agendas?: { agendaID: string; choiceID: string }[];
+  treasuryKeyPolicies?: { key: string; policy: string }[];
+  tspendPolicies?: { hash: string; policy: string }[];
+}
+
+export const getVotingPolicy = async (): Promise<VotingPolicy> => {
+  const response = await api.get<VotingPolicy>('/wallet/voting-policy');
+  return response.data;
+};
+
+// Applies every change in update, or none of them if any is invalid.
+export const setVotingPolicy = async (update: VotingPolicyUpdate, passphrase: string): Promise<void> => {
+  await api.post('/wallet/voting-policy', { ...update, passphrase });
+};
+
 // ProposalsResponse is the proposals list envelope: the cached list plus the
 // last successful fetch time and when a manual refresh is next allowed (both
 // unix seconds; 0 when never fetched).
@@ -1440,7 +1994,7 @@ export const subscribeVoteTrickleEvents = (
   onClose?: () => void,
 ): (() => void) => {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const wsUrl = `${protocol}//${window.location.host}/api/wallet/governance/votetrickle/events`;
+  const wsUrl = `${protocol}//${window.location.host}${BASE_PATH}/api/wallet/governance/votetrickle/events`;
   const ws = new WebSocket(wsUrl);
 
   ws.onmessage = (event) => {
@@ -0,0 +1,8 @@
+// Copyright (c) 2015-2026 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// The path dcrpulse is served under, such as '/dcrpulse', or '' at the root.
+// The server sets it as index.html's <base href> (BASE_PATH); API, WebSocket
+// and page URLs are built on it.
+export const BASE_PATH = new URL(document.baseURI).pathname.replace(/\/+$/, '');
@@ -3,6 +3,7 @@
 // license that can be found in the LICENSE file.
 
 import api from './api';
+import { BASE_PATH } from './basePath';
 
 export type BisonrelayStage =
   | 'waiting-for-dcrlnd'
@@ -1128,7 +1129,7 @@ export const startBisonrelayContentGet = async (
 export const bisonrelayContentFileUrl = (fid: string, uid?: string): string => {
   const q = new URLSearchParams({ fid });
   if (uid) q.set('uid', uid);
-  return `/api/br/content/file?${q.toString()}`;
+  return `${BASE_PATH}/api/br/content/file?${q.toString()}`;
 };
 
 // bisonrelayPostEmbedUrl is the same-origin URL the browser loads as an <img>
@@ -1136,7 +1137,7 @@ export const bisonrelayContentFileUrl = (fid: string, uid?: string): string => {
 // forwards brclientd's long-lived cache header.
 export const bisonrelayPostEmbedUrl = (uid: string, pid: string, index: number): string => {
   const q = new URLSearchParams({ uid, pid, index: String(index) });
-  return `/api/br/posts/embed-data?${q.toString()}`;
+  return `${BASE_PATH}/api/br/posts/embed-data?${q.toString()}`;
 };
 
 export interface BisonrelayRates {
@@ -1254,7 +1255,7 @@ export const listBisonrelayStoreFiles = async (): Promise<BisonrelayStoreFile[]>
 // bisonrelayStoreFileUrl is the same-origin URL that streams a store file's
 // bytes - usable directly as an <img> src for previews.
 export const bisonrelayStoreFileUrl = (path: string): string =>
-  `/api/br/store/files/get?path=${encodeURIComponent(path)}`;
+  `${BASE_PATH}/api/br/store/files/get?path=${encodeURIComponent(path)}`;
 
 export const deleteBisonrelayStoreFile = async (path: string): Promise<void> => {
   await api.post('/br/store/files/delete', { path });
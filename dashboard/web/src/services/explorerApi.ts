@@ -2,9 +2,10 @@
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
-import { authFetch } from './api';
+import { authFetch, subscribeEvents } from './api';
+import { BASE_PATH } from './basePath';
 
-const API_BASE_URL = '/api';
+const API_BASE_URL = `${BASE_PATH}/api`;
 
 export interface BlockSummary {
   height: number;
@@ -17,6 +18,16 @@ export interface BlockSummary {
   difficulty: number;
 }
 
+// Lightweight block header, for charts
+export interface BlockHeaderSummary {
+  height: number;
+  hash: string;
+  time: number; // Unix
+  difficulty: number;
+  poolSize: number;
+  size: number;
+}
+
 export interface BlockDetail extends BlockSummary {
   nextHash?: string;
   merkleRoot: string;
@@ -51,6 +62,34 @@ export interface TransactionDetail extends TransactionSummary {
   politeiaKey?: string;
   recipientCount?: number;
   votingInfo?: TSpendVotingInfo;
+  classification?: TxClassification;
+  coinbaseBreakdown?: CoinbaseBreakdown;
+}
+
+// Where a coinbase/treasurybase's minted coins went (DCR amounts).
+export interface CoinbaseBreakdown {
+  height: number;
+  voters: number;
+  outputs: { index: number; role: 'work_reward' | 'treasury' | 'nulldata'; value: number; address?: string }[];
+  workSubsidy: number;
+  fees: number;
+  treasuryActive: boolean; // false: the coinbase paid the treasury subsidy to the organization's address
+  treasurySubsidy: number;
+  stakeReward: number; // paid by the block's votes, not this transaction
+  totalSubsidy: number;
+}
+
+// Consensus transaction type; only the fields for the given kind are set.
+export interface TxClassification {
+  kind: 'regular' | 'coinbase' | 'ticket' | 'vote' | 'revocation' | 'treasurybase' | 'treasuryadd' | 'tspend';
+  label: string;
+  payees?: { address: string; amount: number; amountAtoms: number }[];
+  ticketHash?: string;
+  votedBlockHash?: string;
+  votedBlockHeight?: number;
+  voteBits?: number;
+  ticketPrice?: number;
+  treasuryAddAmount?: number;
 }
 
 export interface TSpendVotingInfo {
@@ -60,6 +99,9 @@ export interface TSpendVotingInfo {
   noVotes: number;
   eligibleVotes: number;
   votesCast: number;
+  abstainVotes: number;
+  missedVotes: number;
+  revocations: number;
   quorumRequired: number;
   approvalRate: number;
   turnoutRate: number;
@@ -68,6 +110,24 @@ export interface TSpendVotingInfo {
   inMempool: boolean;
   votingStartTime: string;
   votingEndTime: string;
+  // Mempool tspends only
+  firstSeenHeight?: number;
+  elapsedBlocks?: number;
+  windowBlocks?: number;
+  windowProgress?: number; // 0-100
+  projectedVotesCast?: number;
+  projectedTurnoutRate?: number;
+  projectedApprovalRate?: number;
+  passProjection?: TSpendPassProjection; // verdict if voting ended now
+}
+
+// Tally so far checked against the DCP-0006 rules dcrd applies when mining a tspend.
+export interface TSpendPassProjection {
+  wouldPassNow: boolean;
+  quorum: number;            // votes cast (yes + no) needed
+  quorumVotesNeeded: number;
+  requiredApproval: number;  // yes share of votes cast needed, percent
+  yesVotesNeeded: number;    // more yes votes needed, if no more no votes come in
 }
 
 export interface VoteParsingProgress {
@@ -79,6 +139,7 @@ export interface VoteParsingProgress {
   noVotes: number;
   estimatedTime: number; // Seconds remaining
   message: string;
+  version?: number;      // pass back as `since` to waitVoteParsingProgress
 }
 
 export interface TxInput {
@@ -110,12 +171,33 @@ export interface Script {
   type: string;
   reqSigs?: number;
   addresses?: string[];
+  class: string;
+  label: string;
+  nullData?: NullDataPayload;
 }
 
+export interface NullDataPayload {
+  hex: string;
+  size: number;
+  kind: 'treasury-votes' | 'text' | 'binary';
+  text?: string;
+}
+
+export type SearchType = 'block' | 'transaction' | 'address';
+
+export interface SearchMatch {
+  type: SearchType;
+  data: BlockDetail | TransactionDetail | AddressInfo;
+}
+
+// type/found/data describe the first match; matches lists all of them (a
+// hash may match both a transaction and a block).
 export interface SearchResult {
   type: string; // block, transaction, address, unknown
+  kind: 'height' | 'hash' | 'address' | 'unknown'; // detected input
   found: boolean;
-  data?: BlockDetail | TransactionDetail;
+  data?: BlockDetail | TransactionDetail | AddressInfo;
+  matches: SearchMatch[];
   error?: string;
 }
 
@@ -125,6 +207,44 @@ export interface AddressInfo {
   exists: boolean;
   tickets: string[];
   hasIndex: boolean;
+  scriptType?: string;
+  scriptClass?: string;
+  scriptLabel?: string;
+}
+
+export type TraceOutputStatus = 'unspent' | 'spent' | 'unresolved' | 'untraced' | 'unspendable';
+
+export interface TraceOutput {
+  vout: number;
+  amount: number;
+  addresses?: string[];
+  status: TraceOutputStatus;
+  spentBy?: string;
+}
+
+export interface TraceNode {
+  txid: string;
+  depth: number;
+  blockHeight: number; // 0 while in mempool
+  outputs: TraceOutput[];
+}
+
+export interface TraceEdge {
+  from: string;
+  vout: number;
+  to: string;
+  amount: number;
+}
+
+export interface AddressTrace {
+  address: string;
+  root: string;
+  depth: number;
+  nodes: TraceNode[];
+  edges: TraceEdge[];
+  blocksScanned: number;
+  truncated: boolean;
+  truncatedReason?: string;
 }
 
 export interface PaginatedBlocksResponse {
@@ -143,8 +263,10 @@ export interface MempoolTransactions {
 
 // API Functions
 
-export async function searchExplorer(query: string): Promise<SearchResult> {
-  const response = await authFetch(`${API_BASE_URL}/explorer/search?q=${encodeURIComponent(query)}`);
+export async function searchExplorer(query: string, type?: SearchType): Promise<SearchResult> {
+  const params = new URLSearchParams({ q: query });
+  if (type) params.set('type', type);
+  const response = await authFetch(`${API_BASE_URL}/explorer/search?${params}`);
   if (!response.ok) {
     throw new Error('Search failed');
   }
@@ -167,6 +289,15 @@ export async function getRecentBlocksPaginated(page: number = 1, pageSize: numbe
   return response.json();
 }
 
+// Headers of every step-th block from start through end (up to 2000)
+export async function getBlockHeaders(start: number, end: number, step: number = 1): Promise<BlockHeaderSummary[]> {
+  const response = await authFetch(`${API_BASE_URL}/explorer/blocks/headers?start=${start}&end=${end}&step=${step}`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch block headers');
+  }
+  return response.json();
+}
+
 export async function getBlockByHeight(height: number): Promise<BlockDetail> {
   const response = await authFetch(`${API_BASE_URL}/explorer/blocks/${height}`);
   if (!response.ok) {
@@ -191,6 +322,55 @@ export async function getTransaction(txhash: string): Promise<TransactionDetail>
   return response.json();
 }
 
+export interface BlockTransaction {
+  txid: string;
+  tree: 'regular' | 'stake';
+  kind: string;
+  label: string;
+  totalIn: number;
+  totalOut: number;
+  fee: number;
+  size: number;
+}
+
+export interface BlockTransactions {
+  blockHash: string;
+  blockHeight: number;
+  transactions: BlockTransaction[];
+  offset: number;
+  limit: number;
+  total: number;
+  regularCount: number;
+  stakeCount: number;
+}
+
+export async function getBlockTransactions(hash: string, limit = 50, offset = 0): Promise<BlockTransactions> {
+  const params = new URLSearchParams({ limit: String(limit), offset: String(offset) });
+  const response = await authFetch(`${API_BASE_URL}/explorer/blocks/hash/${hash}/transactions?${params}`);
+  if (!response.ok) {
+    throw new Error('Block not found');
+  }
+  return response.json();
+}
+
+// Serialized block/transaction hex (verbose=0). Append ?format=bin to the same
+// URLs for an application/octet-stream download instead.
+export async function getRawBlockHex(hash: string): Promise<string> {
+  const response = await authFetch(`${API_BASE_URL}/explorer/blocks/hash/${hash}/raw`);
+  if (!response.ok) {
+    throw new Error('Block not found');
+  }
+  return (await response.json()).hex;
+}
+
+export async function getRawTransactionHex(txhash: string): Promise<string> {
+  const response = await authFetch(`${API_BASE_URL}/explorer/transactions/${txhash}/raw`);
+  if (!response.ok) {
+    throw new Error('Transaction not found');
+  }
+  return (await response.json()).hex;
+}
+
 export async function getAddressInfo(address: string): Promise<AddressInfo> {
   const response = await authFetch(`${API_BASE_URL}/explorer/address/${address}`);
   if (!response.ok) {
@@ -199,6 +379,19 @@ export async function getAddressInfo(address: string): Promise<AddressInfo> {
   return response.json();
 }
 
+// utxo is "txid" (every output paying the address) or "txid:vout".
+export async function getAddressTrace(address: string, utxo: string, depth?: number): Promise<AddressTrace> {
+  const params = new URLSearchParams({ utxo });
+  if (depth !== undefined) {
+    params.set('depth', String(depth));
+  }
+  const response = await authFetch(`${API_BASE_URL}/explorer/address/${address}/trace?${params}`);
+  if (!response.ok) {
+    throw new Error('Failed to trace address');
+  }
+  return response.json();
+}
+
 export async function getVoteParsingProgress(txhash: string): Promise<VoteParsingProgress> {
   const response = await authFetch(`${API_BASE_URL}/treasury/votes/${txhash}/progress`);
   if (!response.ok) {
@@ -207,6 +400,39 @@ export async function getVoteParsingProgress(txhash: string): Promise<VoteParsin
   return response.json();
 }
 
+// Long poll: resolves once the progress version differs from `since`, or with
+// the unchanged progress after `timeout` seconds.
+export async function waitVoteParsingProgress(txhash: string, since: number, timeout?: number): Promise<VoteParsingProgress> {
+  const params = new URLSearchParams({ since: String(since) });
+  if (timeout !== undefined) {
+    params.set('timeout', String(timeout));
+  }
+  const response = await authFetch(`${API_BASE_URL}/treasury/votes/${txhash}/progress/wait?${params}`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch vote parsing progress');
+  }
+  return response.json();
+}
+
+// Stop a tspend's running vote count; resolves with its partial progress,
+// which is not cached.
+export async function cancelVoteParsing(txhash: string): Promise<VoteParsingProgress> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/votes/${txhash}/cancel`, { method: 'POST' });
+  if (!response.ok) {
+    throw new Error('Failed to cancel vote parsing');
+  }
+  return response.json();
+}
+
+// Server-Sent Events stream of a tspend's vote counting progress.
+export function subscribeVoteParsingProgress(
+  txhash: string,
+  onProgress: (progress: VoteParsingProgress) => void,
+  onError?: (error: Error) => void
+): () => void {
+  return subscribeEvents<VoteParsingProgress>(`/treasury/votes/${txhash}/progress/events`, onProgress, onError);
+}
+
 export async function getMempoolTransactions(): Promise<MempoolTransactions> {
   const response = await authFetch(`${API_BASE_URL}/explorer/mempool`);
   if (!response.ok) {
@@ -215,3 +441,57 @@ export async function getMempoolTransactions(): Promise<MempoolTransactions> {
   return response.json();
 }
 
+export interface VoteRecord {
+  txHash: string;
+  ticketHash: string;
+  blockHeight: number;
+  blockHash: string;
+  timestamp: number; // block unix time
+  voteBits: number;
+  voteVersion: number;
+  approvesParent: boolean;
+  agendas?: { agendaId: string; choice: string }[];
+  tspends?: { tspendHash: string; choice: 'yes' | 'no' | 'invalid' }[];
+}
+
+export interface VoteHistory {
+  address: string;
+  fromHeight: number;
+  toHeight: number;
+  votes: VoteRecord[];
+}
+
+// Votes cast by tickets whose rewards pay `address`; from/to default to about
+// the last day and may span at most 4096 blocks.
+export async function getVoteHistory(address: string, from?: number, to?: number): Promise<VoteHistory> {
+  const params = new URLSearchParams({ address });
+  if (from) params.set('from', String(from));
+  if (to) params.set('to', String(to));
+  const response = await authFetch(`${API_BASE_URL}/explorer/votes?${params}`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch vote history');
+  }
+  return response.json();
+}
+
+export interface BlockVoteEntry extends VoteRecord {
+  // The vote's choice on each of the block's activeTSpends.
+  tspendVotes: { tspendHash: string; choice: 'yes' | 'no' | 'abstain' | 'invalid' }[];
+}
+
+export interface BlockVotes {
+  height: number;
+  hash: string;
+  timestamp: number;
+  activeTSpends: string[];
+  votes: BlockVoteEntry[];
+}
+
+// Every vote in the block at `height` as the vote counters parse it.
+export async function getBlockVotes(height: number): Promise<BlockVotes> {
+  const response = await authFetch(`${API_BASE_URL}/explorer/blocks/${height}/votes`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch block votes');
+  }
+  return response.json();
+}
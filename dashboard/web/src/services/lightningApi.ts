@@ -1,4 +1,5 @@
 import api from './api';
+import { BASE_PATH } from './basePath';
 
 export type LightningStage =
   | 'unavailable'
@@ -256,7 +257,7 @@ export const subscribeLightningChannelEvents = (
   onEvent: (ev: ChannelEvent) => void,
 ): (() => void) => {
   const proto = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const url = `${proto}//${window.location.host}/api/wallet/ln/channel-events`;
+  const url = `${proto}//${window.location.host}${BASE_PATH}/api/wallet/ln/channel-events`;
   let ws: WebSocket | null = new WebSocket(url);
   ws.onmessage = (msg) => {
     try {
@@ -396,7 +397,7 @@ export const streamLnPayment = (
   onClose: () => void,
 ): (() => void) => {
   const proto = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const url = `${proto}//${window.location.host}/api/wallet/ln/send`;
+  const url = `${proto}//${window.location.host}${BASE_PATH}/api/wallet/ln/send`;
   let ws: WebSocket | null = new WebSocket(url);
   ws.onopen = () => {
     try {
@@ -483,7 +484,7 @@ export const subscribeLnInvoiceEvents = (
   onClose?: () => void,
 ): (() => void) => {
   const proto = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-  const url = `${proto}//${window.location.host}/api/wallet/ln/invoice-events`;
+  const url = `${proto}//${window.location.host}${BASE_PATH}/api/wallet/ln/invoice-events`;
   let ws: WebSocket | null = new WebSocket(url);
   ws.onmessage = (msg) => {
     try {
@@ -3,6 +3,7 @@
 // license that can be found in the LICENSE file.
 
 import api from './api';
+import { BASE_PATH } from './basePath';
 
 export type TimestampStatus = 'submitted' | 'awaiting' | 'pending' | 'anchored' | 'failed';
 export type ChainState = 'notfound' | 'awaiting' | 'pending' | 'anchored';
@@ -156,9 +157,9 @@ export async function getTimestampStatus(ping = false): Promise<TimestampStatusI
 
 // Direct-download endpoints (opened in a new tab / via an anchor element).
 export function proofDownloadUrl(digest: string): string {
-  return `/api/timestamp/records/${digest}/proof`;
+  return `${BASE_PATH}/api/timestamp/records/${digest}/proof`;
 }
 
 export function exportUrl(): string {
-  return '/api/timestamp/export';
+  return `${BASE_PATH}/api/timestamp/export`;
 }
@@ -2,17 +2,30 @@
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
-import { authFetch } from './api';
+import { authFetch, subscribeEvents } from './api';
+import type { TSpendPassProjection, TSpendVotingInfo } from './explorerApi';
+import { BASE_PATH } from './basePath';
 
-const API_BASE_URL = '/api';
+const API_BASE_URL = `${BASE_PATH}/api`;
+
+// One paying output of a treasury spend
+export interface TSpendPayee {
+  address: string;
+  amount: number;
+  amountAtoms: number;
+}
 
 export interface TSpend {
   txHash: string;
-  amount: number;
-  payee: string;
+  amount: number; // DCR, derived from amountAtoms
+  amountAtoms: number;
+  amountDcr: string; // exact DCR decimal string
+  payee: string; // last paying output's address
+  payees: TSpendPayee[];
   expiryHeight: number;
   currentHeight: number;
   blocksRemaining: number;
+  estimatedExpiry?: string; // blocksRemaining at the network's block time from now
   status: 'voting' | 'approved' | 'rejected';
   yesVotes: number;
   noVotes: number;
@@ -23,23 +36,43 @@ export interface BalanceSample {
   height: number;
   time: number; // block unix time (seconds)
   balance: number; // treasury balance in DCR
+  balanceAtoms: number;
+}
+
+// ProposalLink ties a tspend to the Politeia proposal it funds: 'mapping'
+// comes from the server's mapping file, 'heuristic' from the proposal's
+// funding window.
+export interface ProposalLink {
+  token: string;
+  title?: string; // absent while Politeia is unreachable
+  url: string;
+  method: 'mapping' | 'heuristic';
 }
 
 export interface TSpendHistory {
   txHash: string;
-  amount: number;
+  amount: number; // DCR, derived from amountAtoms
+  amountAtoms?: number; // absent on entries cached by older versions
+  amountDcr?: string;
   payee: string;
+  payees?: TSpendPayee[]; // absent on entries cached by older versions
   blockHeight: number;
   blockHash: string;
   timestamp: string;
   voteResult: 'approved' | 'rejected';
+  proposal?: ProposalLink;
 }
 
 export interface TreasuryInfo {
   balance: number;
+  balanceAtoms: number;
+  balanceDcr: string; // exact DCR decimal string
   balanceUsd: number;
-  totalAdded: number;
-  totalSpent: number;
+  totalAdded: number; // treasurybase inflow the scan results counted
+  totalSpent: number; // summed from the scan results
+  totalSpentAtoms: number;
+  tspendCount: number;
+  totalsComplete: boolean; // the scan results cover every block since activation
   activeTSpends: TSpend[];
   recentTSpends: TSpendHistory[];
   lastUpdate: string;
@@ -47,12 +80,39 @@ export interface TreasuryInfo {
 
 export interface TSpendScanProgress {
   isScanning: boolean;
+  mode?: ScanMode;
+  startHeight: number;
   currentHeight: number;
   totalHeight: number;
   progress: number;
   tspendFound: number;
   newTSpends: TSpendHistory[];
   message: string;
+  cancelled: boolean;
+  rate: number; // chain blocks covered per second
+  estimatedSecondsRemaining: number;
+  failedHeights?: number[]; // blocks that could not be read; re-scan them with mode 'heights'
+  coveredHeight: number; // last block the results cover without a break; 0 before a scan finishes
+  version: number; // pass back as `since` to waitTSpendScanProgress
+}
+
+export type TreasuryFlowInterval = 'month' | 'week';
+
+export interface TreasuryFlowBucket {
+  start: string;
+  treasuryBase: number; // block-reward inflow via treasurybase
+  added: number;
+  spent: number;
+  net: number;
+  cumulativeNet: number; // sum of net from the first bucket; starts at 0, not the treasury balance
+  tspendCount: number;
+}
+
+export interface TreasuryFlow {
+  interval: TreasuryFlowInterval;
+  buckets: TreasuryFlowBucket[];
+  addsTracked: boolean;
+  treasuryBaseTotal: number;
 }
 
 // Fetch current treasury information
@@ -64,17 +124,97 @@ export async function getTreasuryInfo(): Promise<TreasuryInfo> {
   return response.json();
 }
 
+// Heights a historical scan covers. full: activation (or startHeight) to the
+// tip; recent: the last `blocks` blocks or `days` days (default 30 days);
+// range: startHeight through endHeight.
+export type ScanMode = 'full' | 'recent' | 'range' | 'heights';
+
+export interface ScanProfile {
+  mode?: ScanMode;
+  startHeight?: number;
+  endHeight?: number;
+  blocks?: number;
+  days?: number;
+  heights?: number[]; // heights mode only
+}
+
 // Trigger historical TSpend scan
-export async function triggerTSpendScan(startHeight?: number): Promise<{ success: boolean; message: string }> {
+export async function triggerTSpendScan(
+  profile: ScanProfile = {},
+): Promise<{ success: boolean; message: string; startHeight: number; endHeight: number }> {
   const response = await authFetch(`${API_BASE_URL}/treasury/scan-history`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json',
     },
-    body: JSON.stringify({ startHeight: startHeight || 552448 }),
+    body: JSON.stringify(profile),
+  });
+  if (!response.ok) {
+    const message = await response.json().catch(() => null);
+    throw new Error(typeof message === 'string' ? message : 'Failed to trigger TSpend scan');
+  }
+  return response.json();
+}
+
+export interface ScanHeightsRequest {
+  heights?: number[];
+  ranges?: { start: number; end: number }[]; // scanned at their TVI blocks only
+}
+
+export interface ScanHeightsResult {
+  scanned: number;
+  newTSpendCount: number;
+  newTSpends: TSpendHistory[];
+  failedHeights: number[]; // requested blocks that still could not be read
+  cancelled: boolean;
+}
+
+// Re-scan specific heights (up to 500 blocks) and merge new TSpends into the
+// scan results; resolves once the scan is done
+export async function scanTSpendHeights(req: ScanHeightsRequest): Promise<ScanHeightsResult> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/scan-heights`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(req),
   });
   if (!response.ok) {
-    throw new Error('Failed to trigger TSpend scan');
+    const message = await response.json().catch(() => null);
+    throw new Error(typeof message === 'string' ? message : 'Failed to scan heights');
+  }
+  return response.json();
+}
+
+export interface TSpendScanEstimate {
+  mode: string;
+  startHeight: number;
+  endHeight: number;
+  blocks: number; // blocks the scan would read
+  sampledBlocks: number;
+  secondsPerBlock: number;
+  estimatedSeconds: number;
+  estimated: string; // e.g. "2h 15m"
+}
+
+// Estimate how long a scan would take without starting it; the query selects
+// the scan as the scan-history body does
+export async function estimateTSpendScan(query: {
+  mode?: string;
+  from?: number;
+  to?: number;
+  blocks?: number;
+  days?: number;
+} = {}): Promise<TSpendScanEstimate> {
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(query)) {
+    if (value !== undefined) {
+      params.set(key, String(value));
+    }
+  }
+  const response = await authFetch(`${API_BASE_URL}/treasury/scan-estimate?${params}`);
+  if (!response.ok) {
+    throw new Error('Failed to estimate scan');
   }
   return response.json();
 }
@@ -88,15 +228,115 @@ export async function getTSpendScanProgress(): Promise<TSpendScanProgress> {
   return response.json();
 }
 
-// Get scan results
-export async function getTSpendScanResults(): Promise<TSpendHistory[]> {
-  const response = await authFetch(`${API_BASE_URL}/treasury/scan-results`);
+// Long poll for scan progress: resolves once its version differs from `since`,
+// or with the unchanged progress after `timeout` seconds.
+export async function waitTSpendScanProgress(since: number, timeout?: number): Promise<TSpendScanProgress> {
+  const params = new URLSearchParams({ since: String(since) });
+  if (timeout !== undefined) {
+    params.set('timeout', String(timeout));
+  }
+  const response = await authFetch(`${API_BASE_URL}/treasury/scan-progress/wait?${params}`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch scan progress');
+  }
+  return response.json();
+}
+
+// Server-Sent Events stream of scan progress: the current progress, then an
+// event each time it changes.
+export function subscribeTSpendScanProgress(
+  onProgress: (progress: TSpendScanProgress) => void,
+  onError?: (error: Error) => void
+): () => void {
+  return subscribeEvents<TSpendScanProgress>('/treasury/scan-progress/events', onProgress, onError);
+}
+
+// Get scan results, optionally only those whose total lies between
+// minAmount and maxAmount DCR
+export async function getTSpendScanResults(amounts: {
+  minAmount?: number;
+  maxAmount?: number;
+} = {}): Promise<TSpendHistory[]> {
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(amounts)) {
+    if (value !== undefined) {
+      params.set(key, String(value));
+    }
+  }
+  const response = await authFetch(`${API_BASE_URL}/treasury/scan-results?${params}`);
   if (!response.ok) {
     throw new Error('Failed to fetch scan results');
   }
   return response.json();
 }
 
+// Snapshot of the TSpends the running or last scan has found so far
+export interface TSpendScanFound {
+  isScanning: boolean;
+  mode?: string;
+  startHeight: number;
+  scannedThrough: number; // last block read so far
+  endHeight: number;
+  count: number;
+  tspends: TSpendHistory[]; // in block order
+  version: number; // scan progress version of the snapshot
+}
+
+// Get the TSpends found so far without consuming the progress' newTSpends
+export async function getTSpendScanFound(): Promise<TSpendScanFound> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/scan-found`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch scan found list');
+  }
+  return response.json();
+}
+
+// The scan results as exported by /treasury/snapshot; hash is the hex SHA-256
+// of the snapshot's JSON with hash empty
+export interface TreasuryScanSnapshot {
+  version: number;
+  network: string;
+  coveredHeight: number;
+  coveredHash?: string;
+  tspends: TSpendHistory[];
+  treasuryBase: { height: number; time: string; blocks: number; atoms: number }[];
+  failedHeights: number[];
+  createdAt: string;
+  hash: string;
+}
+
+export interface TreasuryScanSnapshotImport {
+  tspends: number;
+  treasuryBaseRuns: number;
+  coveredHeight: number;
+}
+
+// Download the scan results as a snapshot another instance can import
+export async function exportTreasuryScanSnapshot(): Promise<TreasuryScanSnapshot> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/snapshot`);
+  if (!response.ok) {
+    throw new Error('Failed to export scan snapshot');
+  }
+  return response.json();
+}
+
+// Prime the scan results from a snapshot; the server checks its hash and that
+// its blocks are on the node's chain
+export async function importTreasuryScanSnapshot(snapshot: TreasuryScanSnapshot): Promise<TreasuryScanSnapshotImport> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/snapshot/import`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(snapshot),
+  });
+  if (!response.ok) {
+    const message = await response.json().catch(() => null);
+    throw new Error(typeof message === 'string' ? message : 'Failed to import scan snapshot');
+  }
+  return response.json();
+}
+
 // Get the treasury balance-over-time series (sampled ~monthly, cached server-side)
 export async function getTreasuryBalanceHistory(): Promise<BalanceSample[]> {
   const response = await authFetch(`${API_BASE_URL}/treasury/balance-history`);
@@ -106,3 +346,192 @@ export async function getTreasuryBalanceHistory(): Promise<BalanceSample[]> {
   return (await response.json()) ?? [];
 }
 
+export interface TreasuryPayee {
+  address: string;
+  total: number;
+  totalAtoms: number;
+  totalDcr: string;
+  spendCount: number;
+  firstSeen: string;
+  lastSeen: string;
+  firstTxHash: string;
+  lastTxHash: string;
+}
+
+// Get scanned treasury spends totalled by recipient, largest first
+export async function getTreasuryPayees(): Promise<TreasuryPayee[]> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/payees`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch treasury payees');
+  }
+  return response.json();
+}
+
+// Everything known about one treasury spend. Mined spends carry their block;
+// mempool spends carry blocksRemaining until expiry instead.
+export interface TSpendDetail {
+  txHash: string;
+  status: 'voting' | 'expired' | 'approved';
+  inMempool: boolean;
+  amount: number; // DCR, derived from amountAtoms
+  amountAtoms: number;
+  amountDcr: string;
+  payees: TSpendPayee[];
+  blockHeight?: number;
+  blockHash?: string;
+  blockTime?: string;
+  confirmations: number;
+  expiryHeight: number;
+  currentHeight: number;
+  blocksRemaining?: number;
+  politeiaKey?: string; // 32-byte push of the OP_RETURN output
+  votingInfo?: TSpendVotingInfo; // counting may still be in progress for mined spends
+  proposal?: ProposalLink;
+}
+
+// Get one treasury spend's payees, block or mempool state and vote breakdown
+export async function getTSpendDetail(txHash: string): Promise<TSpendDetail> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/tspend/${txHash}`);
+  if (!response.ok) {
+    throw new Error('Treasury spend not found');
+  }
+  return response.json();
+}
+
+// Direct-download URL of a tspend's per-block vote breakdown.
+export function tspendVotesExportUrl(txHash: string, format: 'csv' | 'json' = 'csv'): string {
+  return `${API_BASE_URL}/treasury/tspend/${txHash}/votes/export?format=${format}`;
+}
+
+// How one ticket voted on a treasury spend within its voting window.
+export interface TicketTSpendVote {
+  tspendHash: string;
+  ticketHash: string;
+  status: 'voted' | 'not-voted' | 'not-live';
+  choice?: 'yes' | 'no' | 'abstain' | 'invalid';
+  voteHash?: string;
+  voteHeight?: number;
+  windowStart: number;
+  windowEnd: number;
+  liveFrom?: number; // first and last blocks the ticket could vote in
+  liveUntil?: number;
+  note?: string;
+}
+
+// Look up a ticket's vote on a treasury spend
+export async function getTicketTSpendVote(txHash: string, ticketHash: string): Promise<TicketTSpendVote> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/tspend/${txHash}/ticket/${ticketHash}`);
+  if (!response.ok) {
+    throw new Error('Failed to look up ticket vote');
+  }
+  return response.json();
+}
+
+// Get scanned treasury inflows/outflows bucketed by month or week
+export async function getTreasuryFlow(interval: TreasuryFlowInterval = 'month'): Promise<TreasuryFlow> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/flow?interval=${interval}`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch treasury flow');
+  }
+  return response.json();
+}
+
+// Vote standing of a treasury spend still in the mempool
+export interface TSpendStanding {
+  txHash: string;
+  amountDcr: string;
+  payee: string;
+  expiryHeight: number;
+  blocksRemaining: number;
+  firstSeenHeight: number; // votes are counted from this height
+  yesVotes: number;
+  noVotes: number;
+  approvalRate: number;
+  turnoutRate: number;
+  windowProgress: number;
+  now: TSpendPassProjection; // verdict if voting ended now
+  projectedPass: boolean; // verdict at window end at the current pace
+}
+
+// Get the vote standings of all treasury spends in the mempool
+export async function getMempoolTSpendStandings(): Promise<TSpendStanding[]> {
+  const response = await authFetch(`${API_BASE_URL}/treasury/mempool/standings`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch mempool TSpend standings');
+  }
+  return response.json();
+}
+
+// One choice's votes on an agenda in the current rule change interval
+export interface AgendaChoiceProgress {
+  id: string;
+  isAbstain: boolean;
+  isNo: boolean;
+  count: number;
+  progress: number; // share of the interval's non-abstain votes
+}
+
+// A consensus agenda still being voted on or locked in
+export interface AgendaProgress {
+  id: string;
+  description: string;
+  status: 'started' | 'lockedin';
+  expireTime: number;
+  quorumProgress: number;
+  choices: AgendaChoiceProgress[];
+}
+
+export interface RuleChangeInterval {
+  startHeight: number;
+  endHeight: number;
+  quorum: number;
+  totalVotes: number;
+}
+
+// The expenditure policy window for the next block a TSpend can be mined in
+export interface TreasuryPolicyWindow {
+  currentHeight: number;
+  nextTviHeight: number;
+  blocksUntilTvi: number;
+  windowBlocks: number;
+  windowStart: number;
+  windowEnd: number;
+}
+
+// Failed sections are left empty; see DashboardMeta
+export interface GovernanceDashboard {
+  treasuryBalance: number;
+  treasuryBalanceAtoms: number;
+  treasuryBalanceDcr: string;
+  recentFlow: TreasuryFlowBucket[];
+  tspends: TSpendStanding[];
+  agendas: AgendaProgress[];
+  ruleChangeInterval?: RuleChangeInterval;
+  policyWindow?: TreasuryPolicyWindow;
+}
+
+// Get the treasury, TSpend, agenda and expenditure policy overview
+export async function getGovernanceDashboard(): Promise<GovernanceDashboard> {
+  const response = await authFetch(`${API_BASE_URL}/governance/dashboard`);
+  if (!response.ok) {
+    throw new Error('Failed to fetch governance dashboard');
+  }
+  return response.json();
+}
+
+// What applying the vote cache retention policy pruned
+export interface VoteCacheCompaction {
+  countsDropped: number;
+  breakdownsDropped: number; // per-block votes dropped, totals kept
+  progressDropped: number;
+  countsKept: number;
+}
+
+// Apply the vote cache retention policy now
+export async function compactVoteCache(): Promise<VoteCacheCompaction> {
+  const response = await authFetch(`${API_BASE_URL}/admin/compact`, { method: 'POST' });
+  if (!response.ok) {
+    throw new Error('Failed to compact the vote cache');
+  }
+  return response.json();
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
+import { BASE_PATH } from './basePath';
+
 const STORAGE_KEY = 'decred-pulse-treasury-history';
 const SCAN_STATUS_KEY = 'decred-pulse-treasury-scan-status';
 const STORAGE_VERSION = 1;
@@ -321,7 +323,7 @@ export const syncWithSnapshot = async (): Promise<{ success: boolean; synced: nu
     console.log(`📊 Current localStorage state: ${currentCount} TSpends, lastSyncHeight: ${currentStorage.lastSyncHeight}`);
     
     // Fetch the snapshot from public folder
-    const response = await fetch('/tspend-snapshot.json');
+    const response = await fetch(`${BASE_PATH}/tspend-snapshot.json`);
     if (!response.ok) {
       throw new Error('Failed to fetch TSpend snapshot');
     }
@@ -8,6 +8,9 @@ import path from 'path'
 
 export default defineConfig({
   plugins: [react()],
+  // Asset URLs are relative so the build works under any base path; the Go
+  // server points index.html's <base href> at the path it is served from.
+  base: './',
   resolve: {
     alias: {
       '@': path.resolve(__dirname, './src'),